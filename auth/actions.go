@@ -0,0 +1,45 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+// Actions the users may perform on the entities, as reported to them.
+const (
+	ReadAction          = "read"
+	WriteAction         = "write"
+	DeleteAction        = "delete"
+	ManageMembersAction = "manage_members"
+)
+
+// actionPermissions maps the actions to the permissions allowing them, in
+// the order the actions are reported.
+var actionPermissions = []struct {
+	action      string
+	permissions []string
+}{
+	{ReadAction, []string{ViewPermission}},
+	{WriteAction, []string{EditPermission}},
+	{DeleteAction, []string{DeletePermission}},
+	{ManageMembersAction, []string{SharePermission, AdminPermission}},
+}
+
+// Actions returns the actions allowed by the permissions the user has on
+// an entity. The result is empty, not nil, if no actions are allowed.
+func Actions(permissions []string) []string {
+	granted := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		granted[p] = true
+	}
+
+	actions := []string{}
+	for _, ap := range actionPermissions {
+		for _, p := range ap.permissions {
+			if granted[p] {
+				actions = append(actions, ap.action)
+				break
+			}
+		}
+	}
+
+	return actions
+}
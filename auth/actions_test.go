@@ -0,0 +1,51 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActions(t *testing.T) {
+	cases := []struct {
+		desc        string
+		permissions []string
+		actions     []string
+	}{
+		{
+			desc:        "no permissions",
+			permissions: nil,
+			actions:     []string{},
+		},
+		{
+			desc:        "view permission",
+			permissions: []string{auth.ViewPermission},
+			actions:     []string{auth.ReadAction},
+		},
+		{
+			desc:        "edit permissions",
+			permissions: []string{auth.ViewPermission, auth.EditPermission, auth.SharePermission},
+			actions:     []string{auth.ReadAction, auth.WriteAction, auth.ManageMembersAction},
+		},
+		{
+			desc:        "admin permissions",
+			permissions: []string{auth.AdminPermission, auth.DeletePermission, auth.EditPermission, auth.ViewPermission},
+			actions:     []string{auth.ReadAction, auth.WriteAction, auth.DeleteAction, auth.ManageMembersAction},
+		},
+		{
+			desc:        "permissions without actions",
+			permissions: []string{auth.PublishPermission, auth.SubscribePermission},
+			actions:     []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		actions := auth.Actions(tc.permissions)
+		assert.Equal(t, tc.actions, actions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.actions, actions))
+	}
+}
@@ -52,6 +52,10 @@ const (
 	PublishPermission    = "publish"
 	SubscribePermission  = "subscribe"
 	CreatePermission     = "create"
+
+	// ConnectedDomainPermission is held by the domains over the things
+	// connected to any of their channels.
+	ConnectedDomainPermission = "connected_domain"
 )
 
 const MagistralaObject = "magistrala"
@@ -788,3 +788,174 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
 }
+
+const (
+	ThingsService_WatchThings_FullMethodName      = "/magistrala.ThingsService/WatchThings"
+	ThingsService_RetrieveMetadata_FullMethodName = "/magistrala.ThingsService/RetrieveMetadata"
+)
+
+// ThingsServiceClient is the client API for ThingsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ThingsService is a service that provides notifications about
+// changes of things and the metadata other services are configured
+// through.
+type ThingsServiceClient interface {
+	// WatchThings streams create, update and remove events of the
+	// things in the domain.
+	WatchThings(ctx context.Context, in *WatchThingsReq, opts ...grpc.CallOption) (ThingsService_WatchThingsClient, error)
+	// RetrieveMetadata returns the metadata of a thing or a channel.
+	RetrieveMetadata(ctx context.Context, in *RetrieveMetadataReq, opts ...grpc.CallOption) (*RetrieveMetadataRes, error)
+}
+
+type thingsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewThingsServiceClient(cc grpc.ClientConnInterface) ThingsServiceClient {
+	return &thingsServiceClient{cc}
+}
+
+func (c *thingsServiceClient) WatchThings(ctx context.Context, in *WatchThingsReq, opts ...grpc.CallOption) (ThingsService_WatchThingsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ThingsService_ServiceDesc.Streams[0], ThingsService_WatchThings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &thingsServiceWatchThingsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ThingsService_WatchThingsClient interface {
+	Recv() (*ThingEvent, error)
+	grpc.ClientStream
+}
+
+type thingsServiceWatchThingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *thingsServiceWatchThingsClient) Recv() (*ThingEvent, error) {
+	m := new(ThingEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *thingsServiceClient) RetrieveMetadata(ctx context.Context, in *RetrieveMetadataReq, opts ...grpc.CallOption) (*RetrieveMetadataRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetrieveMetadataRes)
+	err := c.cc.Invoke(ctx, ThingsService_RetrieveMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ThingsServiceServer is the server API for ThingsService service.
+// All implementations must embed UnimplementedThingsServiceServer
+// for forward compatibility
+//
+// ThingsService is a service that provides notifications about
+// changes of things and the metadata other services are configured
+// through.
+type ThingsServiceServer interface {
+	// WatchThings streams create, update and remove events of the
+	// things in the domain.
+	WatchThings(*WatchThingsReq, ThingsService_WatchThingsServer) error
+	// RetrieveMetadata returns the metadata of a thing or a channel.
+	RetrieveMetadata(context.Context, *RetrieveMetadataReq) (*RetrieveMetadataRes, error)
+	mustEmbedUnimplementedThingsServiceServer()
+}
+
+// UnimplementedThingsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedThingsServiceServer struct {
+}
+
+func (UnimplementedThingsServiceServer) WatchThings(*WatchThingsReq, ThingsService_WatchThingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchThings not implemented")
+}
+func (UnimplementedThingsServiceServer) RetrieveMetadata(context.Context, *RetrieveMetadataReq) (*RetrieveMetadataRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveMetadata not implemented")
+}
+func (UnimplementedThingsServiceServer) mustEmbedUnimplementedThingsServiceServer() {}
+
+// UnsafeThingsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ThingsServiceServer will
+// result in compilation errors.
+type UnsafeThingsServiceServer interface {
+	mustEmbedUnimplementedThingsServiceServer()
+}
+
+func RegisterThingsServiceServer(s grpc.ServiceRegistrar, srv ThingsServiceServer) {
+	s.RegisterService(&ThingsService_ServiceDesc, srv)
+}
+
+func _ThingsService_WatchThings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchThingsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThingsServiceServer).WatchThings(m, &thingsServiceWatchThingsServer{ServerStream: stream})
+}
+
+type ThingsService_WatchThingsServer interface {
+	Send(*ThingEvent) error
+	grpc.ServerStream
+}
+
+type thingsServiceWatchThingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *thingsServiceWatchThingsServer) Send(m *ThingEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ThingsService_RetrieveMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveMetadataReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).RetrieveMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThingsService_RetrieveMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).RetrieveMetadata(ctx, req.(*RetrieveMetadataReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ThingsService_ServiceDesc is the grpc.ServiceDesc for ThingsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ThingsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "magistrala.ThingsService",
+	HandlerType: (*ThingsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RetrieveMetadata",
+			Handler:    _ThingsService_RetrieveMetadata_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchThings",
+			Handler:       _ThingsService_WatchThings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "auth.proto",
+}
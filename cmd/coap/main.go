@@ -10,11 +10,15 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/coap"
 	"github.com/absmach/magistrala/coap/api"
+	coapthings "github.com/absmach/magistrala/coap/things"
 	"github.com/absmach/magistrala/coap/tracing"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/auth"
@@ -26,6 +30,7 @@ import (
 	coapserver "github.com/absmach/magistrala/pkg/server/coap"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
+	thingsgrpc "github.com/absmach/magistrala/things/api/grpc"
 	"github.com/caarlos0/env/v10"
 	"golang.org/x/sync/errgroup"
 )
@@ -40,12 +45,28 @@ const (
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_COAP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"               envDefault:"http://localhost:14268/api/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"MG_COAP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
+	LogLevel            string        `env:"MG_COAP_ADAPTER_LOG_LEVEL"            envDefault:"info"`
+	BrokerURL           string        `env:"MG_MESSAGE_BROKER_URL"                envDefault:"nats://localhost:4222"`
+	JaegerURL           url.URL       `env:"MG_JAEGER_URL"                        envDefault:"http://localhost:14268/api/traces"`
+	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"                    envDefault:"true"`
+	InstanceID          string        `env:"MG_COAP_ADAPTER_INSTANCE_ID"          envDefault:""`
+	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"                envDefault:"1.0"`
+	BusAckTimeout       time.Duration `env:"MG_COAP_ADAPTER_BUS_ACK_TIMEOUT"      envDefault:"5s"`
+	SchemaValidation    bool          `env:"MG_COAP_ADAPTER_SCHEMA_VALIDATION"    envDefault:"false"`
+	SchemaCacheTTL      time.Duration `env:"MG_COAP_ADAPTER_SCHEMA_CACHE_TTL"     envDefault:"1m"`
+	SchemaCacheSize     int           `env:"MG_COAP_ADAPTER_SCHEMA_CACHE_SIZE"    envDefault:"10000"`
+	SubtopicRestriction bool          `env:"MG_COAP_ADAPTER_SUBTOPIC_RESTRICTION" envDefault:"false"`
+	SubtopicCacheTTL    time.Duration `env:"MG_COAP_ADAPTER_SUBTOPIC_CACHE_TTL"   envDefault:"1m"`
+	SubtopicCacheSize   int           `env:"MG_COAP_ADAPTER_SUBTOPIC_CACHE_SIZE"  envDefault:"10000"`
+	Transforms          bool          `env:"MG_COAP_ADAPTER_TRANSFORMS"           envDefault:"false"`
+	TransformCacheTTL   time.Duration `env:"MG_COAP_ADAPTER_TRANSFORM_CACHE_TTL"  envDefault:"1m"`
+	TransformCacheSize  int           `env:"MG_COAP_ADAPTER_TRANSFORM_CACHE_SIZE" envDefault:"10000"`
+	DrainWindow         time.Duration `env:"MG_COAP_ADAPTER_DRAIN_WINDOW"         envDefault:"5s"`
+	DrainNotify         bool          `env:"MG_COAP_ADAPTER_DRAIN_NOTIFY"         envDefault:"true"`
+	IdleTimeout         time.Duration `env:"MG_COAP_ADAPTER_IDLE_TIMEOUT"         envDefault:"5m"`
+	DedupWindow         time.Duration `env:"MG_COAP_ADAPTER_DEDUP_WINDOW"         envDefault:"1m"`
+	DedupSize           int           `env:"MG_COAP_ADAPTER_DEDUP_SIZE"           envDefault:"10000"`
+	MaxPayloadSize      int           `env:"MG_COAP_ADAPTER_MAX_PAYLOAD_SIZE"     envDefault:"1048576"`
 }
 
 func main() {
@@ -87,6 +108,13 @@ func main() {
 		return
 	}
 
+	transmissionConfig := coapserver.TransmissionConfig{}
+	if err := env.ParseWithOptions(&transmissionConfig, env.Options{Prefix: envPrefix}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s CoAP transmission configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
 	authConfig := auth.Config{}
 	if err := env.ParseWithOptions(&authConfig, env.Options{Prefix: envPrefixAuthz}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s auth configuration : %s", svcName, err))
@@ -126,7 +154,35 @@ func main() {
 	defer nps.Close()
 	nps = brokerstracing.NewPubSub(coapServerConfig, tracer, nps)
 
-	svc := coap.New(authClient, nps)
+	// Payload schemas, allowed subtopics and payload transforms are read
+	// from the metadata served by things, over the things gRPC connection.
+	thingsClient := thingsgrpc.NewThingsClient(authHandler.Connection(), authConfig.Timeout)
+	var schemas coap.SchemaRepository
+	if cfg.SchemaValidation {
+		schemas = coap.NewSchemaCache(coapthings.New(thingsClient), cfg.SchemaCacheTTL, cfg.SchemaCacheSize)
+	}
+	var subtopics coap.SubtopicRepository
+	if cfg.SubtopicRestriction {
+		subtopics = coap.NewSubtopicCache(coapthings.NewSubtopicRepository(thingsClient), cfg.SubtopicCacheTTL, cfg.SubtopicCacheSize)
+	}
+	var transforms coap.TransformRepository
+	if cfg.Transforms {
+		transforms = coap.NewTransformCache(coapthings.NewTransformRepository(thingsClient), cfg.TransformCacheTTL, cfg.TransformCacheSize)
+	}
+
+	var dedup *coap.Deduplicator
+	if cfg.DedupWindow > 0 && cfg.DedupSize > 0 {
+		dedup = coap.NewDeduplicator(cfg.DedupWindow, cfg.DedupSize)
+	}
+
+	svc := coap.New(authClient, nps, coap.Config{
+		Schemas:       schemas,
+		Subtopics:     subtopics,
+		Transforms:    transforms,
+		Dedup:         dedup,
+		BusAckTimeout: cfg.BusAckTimeout,
+		MaxPayload:    cfg.MaxPayloadSize,
+	})
 
 	svc = tracing.New(tracer, svc)
 
@@ -137,7 +193,7 @@ func main() {
 
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(cfg.InstanceID), logger)
 
-	cs := coapserver.NewServer(ctx, cancel, svcName, coapServerConfig, api.MakeCoAPHandler(svc, logger), logger)
+	cs := coapserver.NewServer(ctx, cancel, svcName, coapServerConfig, transmissionConfig, api.MakeCoAPHandler(svc, logger, cfg.IdleTimeout, cfg.MaxPayloadSize), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
@@ -153,8 +209,30 @@ func main() {
 	g.Go(func() error {
 		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs, cs)
 	})
+	g.Go(func() error {
+		return drainOnSignal(ctx, cancel, svc, cfg.DrainWindow, cfg.DrainNotify)
+	})
 
 	if err := g.Wait(); err != nil {
 		logger.Error(fmt.Sprintf("CoAP adapter service terminated: %s", err))
 	}
 }
+
+// drainOnSignal drains the subscriptions when the service is terminated,
+// before the servers are stopped, so that the clients of a rolling deploy
+// observe again gradually rather than all at once.
+func drainOnSignal(ctx context.Context, cancel context.CancelFunc, svc coap.Service, window time.Duration, notify bool) error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTERM)
+	defer signal.Stop(c)
+	select {
+	case <-c:
+		defer cancel()
+		dctx, dcancel := context.WithTimeout(ctx, window)
+		defer dcancel()
+		_, err := svc.Drain(dctx, window, notify)
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
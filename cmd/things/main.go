@@ -15,6 +15,8 @@ import (
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
+	mgapi "github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/internal/audit"
 	redisclient "github.com/absmach/magistrala/internal/clients/redis"
 	mggroups "github.com/absmach/magistrala/internal/groups"
 	gapi "github.com/absmach/magistrala/internal/groups/api"
@@ -23,6 +25,9 @@ import (
 	gtracing "github.com/absmach/magistrala/internal/groups/tracing"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/auth"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/postgres"
@@ -55,26 +60,48 @@ const (
 	svcName            = "things"
 	envPrefixDB        = "MG_THINGS_DB_"
 	envPrefixHTTP      = "MG_THINGS_HTTP_"
+	envPrefixCORS      = "MG_THINGS_HTTP_CORS_"
 	envPrefixGRPC      = "MG_THINGS_AUTH_GRPC_"
 	envPrefixAuth      = "MG_AUTH_GRPC_"
+	envPrefixBreaker   = "MG_AUTH_GRPC_BREAKER_"
 	defDB              = "things"
 	defSvcHTTPPort     = "9000"
 	defSvcAuthGRPCPort = "7000"
 
-	streamID = "magistrala.things"
+	streamID     = "magistrala.things"
+	thingsStream = "events.magistrala.things"
 )
 
 type config struct {
-	LogLevel         string        `env:"MG_THINGS_LOG_LEVEL"           envDefault:"info"`
-	StandaloneID     string        `env:"MG_THINGS_STANDALONE_ID"       envDefault:""`
-	StandaloneToken  string        `env:"MG_THINGS_STANDALONE_TOKEN"    envDefault:""`
-	JaegerURL        url.URL       `env:"MG_JAEGER_URL"                 envDefault:"http://jaeger:14268/api/traces"`
-	CacheKeyDuration time.Duration `env:"MG_THINGS_CACHE_KEY_DURATION"  envDefault:"10m"`
-	SendTelemetry    bool          `env:"MG_SEND_TELEMETRY"             envDefault:"true"`
-	InstanceID       string        `env:"MG_THINGS_INSTANCE_ID"         envDefault:""`
-	ESURL            string        `env:"MG_ES_URL"                     envDefault:"nats://localhost:4222"`
-	CacheURL         string        `env:"MG_THINGS_CACHE_URL"           envDefault:"redis://localhost:6379/0"`
-	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
+	LogLevel          string        `env:"MG_THINGS_LOG_LEVEL"           envDefault:"info"`
+	StandaloneID      string        `env:"MG_THINGS_STANDALONE_ID"       envDefault:""`
+	StandaloneToken   string        `env:"MG_THINGS_STANDALONE_TOKEN"    envDefault:""`
+	JaegerURL         url.URL       `env:"MG_JAEGER_URL"                 envDefault:"http://jaeger:14268/api/traces"`
+	CacheKeyDuration  time.Duration `env:"MG_THINGS_CACHE_KEY_DURATION"  envDefault:"10m"`
+	IdempotencyTTL    time.Duration `env:"MG_THINGS_IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+	IdempotencyLock   time.Duration `env:"MG_THINGS_IDEMPOTENCY_LOCK"    envDefault:"1m"`
+	StaleThreshold    time.Duration `env:"MG_THINGS_STALE_THRESHOLD"     envDefault:"5m"`
+	HeartbeatInterval time.Duration `env:"MG_THINGS_HEARTBEAT_INTERVAL"  envDefault:"10s"`
+	AuditReads        bool          `env:"MG_THINGS_AUDIT_READS"         envDefault:"false"`
+	ActivityInterval  time.Duration `env:"MG_THINGS_ACTIVITY_INTERVAL"   envDefault:"1m"`
+	SendTelemetry     bool          `env:"MG_SEND_TELEMETRY"             envDefault:"true"`
+	InstanceID        string        `env:"MG_THINGS_INSTANCE_ID"         envDefault:""`
+	GatewayToken      string        `env:"MG_THINGS_GATEWAY_TOKEN"       envDefault:""`
+	ESURL             string        `env:"MG_ES_URL"                     envDefault:"nats://localhost:4222"`
+	CacheURL          string        `env:"MG_THINGS_CACHE_URL"           envDefault:"redis://localhost:6379/0"`
+	TraceRatio        float64       `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
+	WatchHeartbeat    time.Duration `env:"MG_THINGS_WATCH_HEARTBEAT"     envDefault:"30s"`
+	WatchBufferSize   int           `env:"MG_THINGS_WATCH_BUFFER_SIZE"   envDefault:"1000"`
+	MaxViewIDs        int           `env:"MG_THINGS_MAX_VIEW_IDS"        envDefault:"100"`
+	MaxMetadataSize   int           `env:"MG_THINGS_MAX_METADATA_SIZE"   envDefault:"65536"`
+	MaxListWait       time.Duration `env:"MG_THINGS_MAX_LIST_WAIT"       envDefault:"60s"`
+	MaxFilterDepth    int           `env:"MG_THINGS_MAX_FILTER_DEPTH"    envDefault:"5"`
+	MaxFilterNodes    int           `env:"MG_THINGS_MAX_FILTER_NODES"    envDefault:"50"`
+	KeyMinLength      int           `env:"MG_THINGS_KEY_MIN_LENGTH"      envDefault:"0"`
+	KeyRequireLower   bool          `env:"MG_THINGS_KEY_REQUIRE_LOWER"   envDefault:"false"`
+	KeyRequireUpper   bool          `env:"MG_THINGS_KEY_REQUIRE_UPPER"   envDefault:"false"`
+	KeyRequireDigit   bool          `env:"MG_THINGS_KEY_REQUIRE_DIGIT"   envDefault:"false"`
+	KeyRequireSpecial bool          `env:"MG_THINGS_KEY_REQUIRE_SPECIAL" envDefault:"false"`
 }
 
 func main() {
@@ -144,6 +171,13 @@ func main() {
 	}
 	defer cacheclient.Close()
 
+	checks := map[string]magistrala.HealthCheck{
+		"postgres": db.PingContext,
+		"cache": func(ctx context.Context) error {
+			return cacheclient.Ping(ctx).Err()
+		},
+	}
+
 	var authClient magistrala.AuthServiceClient
 
 	switch cfg.StandaloneID != "" && cfg.StandaloneToken != "" {
@@ -158,7 +192,20 @@ func main() {
 			return
 		}
 
-		authServiceClient, authHandler, err := auth.Setup(ctx, authConfig)
+		breakerConfig := auth.BreakerConfig{}
+		if err := env.ParseWithOptions(&breakerConfig, env.Options{Prefix: envPrefixBreaker}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load %s auth circuit breaker configuration : %s", svcName, err))
+			exitCode = 1
+			return
+		}
+		var dialOpts []grpc.DialOption
+		if breakerConfig.Threshold > 0 {
+			state := prometheus.MakeGauge(svcName, "auth_client", "circuit_breaker_state", "State of the auth client circuit breaker: 0 closed, 1 half-open, 2 open.")
+			breaker := auth.NewBreaker(breakerConfig, state)
+			dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(breaker.UnaryClientInterceptor()))
+		}
+
+		authServiceClient, authHandler, err := auth.Setup(ctx, authConfig, dialOpts...)
 		if err != nil {
 			logger.Error(err.Error())
 			exitCode = 1
@@ -166,24 +213,80 @@ func main() {
 		}
 		defer authHandler.Close()
 		authClient = authServiceClient
+		checks["auth"] = func(ctx context.Context) error {
+			return auth.Check(ctx, authHandler, "auth")
+		}
 		logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
 	}
 
-	csvc, gsvc, err := newService(ctx, db, dbConfig, authClient, cacheclient, cfg.CacheKeyDuration, cfg.ESURL, tracer, logger)
+	watcher := thevents.NewWatcher(cfg.WatchBufferSize)
+	csvc, gsvc, err := newService(ctx, db, dbConfig, authClient, cacheclient, watcher, cfg, tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
 		return
 	}
 
+	watchSub, watchConsumer, err := subscribeToThingsES(ctx, watcher, cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+		exitCode = 1
+		return
+	}
+	// The watch consumer is per instance, so drop it on shutdown instead of
+	// leaving a durable consumer behind for every instance ever started.
+	defer func() {
+		if err := watchSub.Unsubscribe(context.Background(), watchConsumer, thingsStream); err != nil {
+			logger.Warn(fmt.Sprintf("failed to remove watch consumer %s: %s", watchConsumer, err))
+		}
+		if err := watchSub.Close(); err != nil {
+			logger.Warn(fmt.Sprintf("failed to close things event store subscriber: %s", err))
+		}
+	}()
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
 		exitCode = 1
 		return
 	}
+	corsConfig := mgapi.CORSConfig{}
+	if err := env.ParseWithOptions(&corsConfig, env.Options{Prefix: envPrefixCORS}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s CORS configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	bodyLimits := mgapi.BodyLimits{}
+	if err := env.ParseWithOptions(&bodyLimits, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP body limits configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	pageLimits := mgapi.PageLimits{}
+	if err := env.ParseWithOptions(&pageLimits, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP page limits configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if err := pageLimits.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("invalid %s HTTP page limits configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	handlerConfig := httpapi.Config{
+		MaxViewIDs:      cfg.MaxViewIDs,
+		MaxMetadataSize: cfg.MaxMetadataSize,
+		MaxListWait:     cfg.MaxListWait,
+		BodyLimits:      bodyLimits,
+		PageLimits:      pageLimits,
+		FilterLimits:    mgclients.FilterLimits{MaxDepth: cfg.MaxFilterDepth, MaxNodes: cfg.MaxFilterNodes},
+		CORS:            corsConfig,
+		InstanceID:      cfg.InstanceID,
+		GatewayToken:    cfg.GatewayToken,
+	}
+	icache := thcache.NewIdempotencyCache(cacheclient, cfg.IdempotencyTTL, cfg.IdempotencyLock)
 	mux := chi.NewRouter()
-	httpSvc := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(csvc, gsvc, mux, logger, cfg.InstanceID), logger)
+	httpSvc := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(csvc, gsvc, icache, handlerConfig, mux, logger, checks), logger)
 
 	grpcServerConfig := server.Config{Port: defSvcAuthGRPCPort}
 	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
@@ -194,6 +297,7 @@ func main() {
 	regiterAuthzServer := func(srv *grpc.Server) {
 		reflection.Register(srv)
 		magistrala.RegisterAuthzServiceServer(srv, grpcapi.NewServer(csvc))
+		magistrala.RegisterThingsServiceServer(srv, grpcapi.NewThingsServer(csvc, cfg.WatchHeartbeat))
 	}
 	gs := grpcserver.NewServer(ctx, cancel, svcName, grpcServerConfig, regiterAuthzServer, logger)
 
@@ -220,28 +324,49 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authClient magistrala.AuthServiceClient, cacheClient *redis.Client, keyDuration time.Duration, esURL string, tracer trace.Tracer, logger *slog.Logger) (things.Service, groups.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authClient magistrala.AuthServiceClient, cacheClient *redis.Client, watcher things.Watcher, cfg config, tracer trace.Tracer, logger *slog.Logger) (things.Service, groups.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	cRepo := thingspg.NewRepository(database)
 	gRepo := gpostgres.New(database)
 
 	idp := uuid.New()
 
-	thingCache := thcache.NewCache(cacheClient, keyDuration)
+	thingCache := thcache.NewCache(cacheClient, cfg.CacheKeyDuration, cfg.StaleThreshold, cfg.HeartbeatInterval)
+	thingCache = thcache.MetricsMiddleware(thingCache, prometheus.MakeCacheMetrics(svcName))
 
-	csvc := things.NewService(authClient, cRepo, gRepo, thingCache, idp)
-	gsvc := mggroups.NewService(gRepo, idp, authClient)
+	keyPolicy := mgclients.KeyPolicy{
+		MinLength:      cfg.KeyMinLength,
+		RequireLower:   cfg.KeyRequireLower,
+		RequireUpper:   cfg.KeyRequireUpper,
+		RequireDigit:   cfg.KeyRequireDigit,
+		RequireSpecial: cfg.KeyRequireSpecial,
+	}
+	csvc := things.NewService(authClient, cRepo, gRepo, thingCache, watcher, idp, keyPolicy)
+	gsvc := mggroups.NewChannelsService(gRepo, idp, authClient, cRepo)
+
+	// Channels created before their thing count was kept get it from the
+	// connection policies, without delaying the start of the service.
+	go func() {
+		if err := mggroups.SyncThingCounts(ctx, gRepo, authClient); err != nil {
+			logger.Warn(fmt.Sprintf("failed to sync channel thing counts: %s", err))
+		}
+	}()
 
-	csvc, err := thevents.NewEventStoreMiddleware(ctx, csvc, esURL)
+	csvc, err := thevents.NewEventStoreMiddleware(ctx, csvc, cfg.ESURL)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	gsvc, err = gevents.NewEventStoreMiddleware(ctx, gsvc, esURL, streamID)
+	gsvc, err = gevents.NewEventStoreMiddleware(ctx, gsvc, cfg.ESURL, streamID)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	auditLogger := audit.New(logger, api.NewAuditIdentifier(authClient, cRepo), cfg.AuditReads)
+	auditLogger.AddRecorder(mggroups.NewActivityRecorder(gRepo, "channel", cfg.ActivityInterval))
+	csvc = api.AuditMiddleware(csvc, auditLogger)
+	gsvc = gapi.AuditMiddleware(gsvc, auditLogger, "channel")
+
 	csvc = ctracing.New(csvc, tracer)
 	csvc = api.LoggingMiddleware(csvc, logger)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
@@ -254,3 +379,24 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 
 	return csvc, gsvc, err
 }
+
+func subscribeToThingsES(ctx context.Context, watcher thevents.Watcher, cfg config, logger *slog.Logger) (events.Subscriber, string, error) {
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Each instance retains the events its watchers resume from, so
+	// every instance needs a dedicated consumer to receive all of them.
+	subConfig := events.SubscriberConfig{
+		Stream:   thingsStream,
+		Consumer: fmt.Sprintf("%s-watch-%s", svcName, cfg.InstanceID),
+		Handler:  watcher,
+	}
+	if err := subscriber.Subscribe(ctx, subConfig); err != nil {
+		subscriber.Close()
+		return nil, "", err
+	}
+
+	return subscriber, subConfig.Consumer, nil
+}
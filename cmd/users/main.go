@@ -17,6 +17,7 @@ import (
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
 	authSvc "github.com/absmach/magistrala/auth"
+	mgapi "github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/internal/email"
 	mggroups "github.com/absmach/magistrala/internal/groups"
 	gapi "github.com/absmach/magistrala/internal/groups/api"
@@ -179,6 +180,18 @@ func main() {
 		return
 	}
 
+	pageLimits := mgapi.PageLimits{}
+	if err := env.ParseWithOptions(&pageLimits, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP page limits configuration : %s", svcName, err.Error()))
+		exitCode = 1
+		return
+	}
+	if err := pageLimits.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("invalid %s HTTP page limits configuration : %s", svcName, err.Error()))
+		exitCode = 1
+		return
+	}
+
 	oauthConfig := oauth2.Config{}
 	if err := env.ParseWithOptions(&oauthConfig, env.Options{Prefix: envPrefixGoogle}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s Google configuration : %s", svcName, err.Error()))
@@ -188,7 +201,7 @@ func main() {
 	oauthProvider := googleoauth.NewProvider(oauthConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)
 
 	mux := chi.NewRouter()
-	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, capi.MakeHandler(csvc, gsvc, mux, logger, cfg.InstanceID, cfg.PassRegex, oauthProvider), logger)
+	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, capi.MakeHandler(csvc, gsvc, pageLimits, mux, logger, cfg.InstanceID, cfg.PassRegex, oauthProvider), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
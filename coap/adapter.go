@@ -9,21 +9,51 @@ package coap
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/messaging"
 )
 
 const chansPrefix = "channels"
 
+var (
+	// ErrBusAckTimeout indicates that the message bus did not acknowledge
+	// the confirmed publish in time.
+	ErrBusAckTimeout = errors.New("message bus acknowledgement timed out")
+
+	// ErrDraining indicates that the adapter is shutting down and doesn't
+	// accept new subscriptions.
+	ErrDraining = errors.New("adapter is draining subscriptions")
+
+	// ErrPayloadTooLarge indicates that the message payload exceeds the
+	// maximum payload size.
+	ErrPayloadTooLarge = errors.New("message payload is too large")
+)
+
 // Service specifies CoAP service API.
 type Service interface {
 	// Publish publishes message to specified channel.
-	// Key is used to authorize publisher.
-	Publish(ctx context.Context, key string, msg *messaging.Message) error
+	// Key is used to authorize publisher. Things restricted to a list of
+	// subtopics are denied publishing to other subtopics with
+	// ErrSubtopicNotAllowed. Payloads of messages published
+	// to channels with a payload schema must match the schema. JSON and
+	// CBOR payloads are validated according to the message content type,
+	// while payloads of other content types are published as is. Confirmed
+	// publish returns only once the message bus acknowledged the message,
+	// or fails with ErrBusAckTimeout if the acknowledgement did not arrive
+	// in time. Payloads of messages published to channels with transforms
+	// are transformed before they are published. Messages carrying an ID
+	// the thing already published recently are not published again and
+	// fail with ErrDuplicateMessage, while messages without an ID are
+	// never deduplicated. Payloads larger than the maximum payload size
+	// fail with ErrPayloadTooLarge before they are validated.
+	Publish(ctx context.Context, key string, msg *messaging.Message, confirm bool) error
 
 	// Subscribes to channel with specified id, subtopic and adds subscription to
 	// service map of subscriptions under given ID.
@@ -31,27 +61,91 @@ type Service interface {
 
 	// Unsubscribe method is used to stop observing resource.
 	Unsubscribe(ctx context.Context, key, chanID, subptopic, token string) error
+
+	// Drain stops accepting new subscriptions, failing them with ErrDraining,
+	// and unsubscribes the active ones one by one, spread evenly over the
+	// drain window. Sessions left once the context is done are unsubscribed
+	// at once. If notify is set, the clients are sent a final notification
+	// without the observe option, which prompts them to observe again,
+	// possibly on another instance. Drain returns the number of drained
+	// sessions.
+	Drain(ctx context.Context, window time.Duration, notify bool) (int, error)
 }
 
 var _ Service = (*adapterService)(nil)
 
 // Observers is a map of maps,.
 type adapterService struct {
-	auth   magistrala.AuthzServiceClient
-	pubsub messaging.PubSub
+	auth          magistrala.AuthzServiceClient
+	pubsub        messaging.PubSub
+	schemas       SchemaRepository
+	subtopics     SubtopicRepository
+	transforms    TransformRepository
+	busAckTimeout time.Duration
+	dedup         *Deduplicator
+	maxPayload    int
+
+	mu       sync.Mutex
+	sessions map[session]Client
+	draining bool
+}
+
+// session identifies an active subscription by the client token and the
+// message bus topic.
+type session struct {
+	token string
+	topic string
+}
+
+// Config configures the optional processing of the published messages.
+type Config struct {
+	// Schemas validates the payloads against the channel schemas, unless
+	// it's nil.
+	Schemas SchemaRepository
+	// Subtopics restricts the subtopics things publish to, unless it's nil.
+	Subtopics SubtopicRepository
+	// Transforms transforms the payloads, unless it's nil.
+	Transforms TransformRepository
+	// Dedup drops the duplicate messages, unless it's nil.
+	Dedup *Deduplicator
+	// BusAckTimeout bounds the time confirmed publishes wait for the
+	// message bus acknowledgement.
+	BusAckTimeout time.Duration
+	// MaxPayload rejects the payloads larger than it, in bytes, unless
+	// it's zero.
+	MaxPayload int
 }
 
-// New instantiates the CoAP adapter implementation.
-func New(authClient magistrala.AuthzServiceClient, pubsub messaging.PubSub) Service {
+// New instantiates the CoAP adapter implementation, processing the published
+// messages as configured by cfg.
+func New(authClient magistrala.AuthzServiceClient, pubsub messaging.PubSub, cfg Config) Service {
 	as := &adapterService{
-		auth:   authClient,
-		pubsub: pubsub,
+		auth:          authClient,
+		pubsub:        pubsub,
+		schemas:       cfg.Schemas,
+		subtopics:     cfg.Subtopics,
+		transforms:    cfg.Transforms,
+		busAckTimeout: cfg.BusAckTimeout,
+		dedup:         cfg.Dedup,
+		maxPayload:    cfg.MaxPayload,
+		sessions:      make(map[session]Client),
 	}
 
 	return as
 }
 
-func (svc *adapterService) Publish(ctx context.Context, key string, msg *messaging.Message) error {
+func (svc *adapterService) Publish(ctx context.Context, key string, msg *messaging.Message, confirm bool) error {
+	subtopic, err := NormalizeSubtopic(msg.GetSubtopic())
+	if err != nil {
+		return err
+	}
+	// Messages are published to a concrete subject, wildcards only
+	// make sense when subscribing.
+	if hasWildcard(subtopic) {
+		return ErrMalformedSubtopic
+	}
+	msg.Subtopic = subtopic
+
 	ar := &magistrala.AuthorizeReq{
 		SubjectType: auth.ThingType,
 		Permission:  auth.PublishPermission,
@@ -68,10 +162,129 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg *messagi
 	}
 	msg.Publisher = res.GetId()
 
-	return svc.pubsub.Publish(ctx, msg.GetChannel(), msg)
+	// Oversized payloads are rejected before they are deduplicated,
+	// validated or transformed, which is where payloads are processed.
+	// The transport rejects them as they are read already, this covers
+	// the payloads published otherwise.
+	if svc.maxPayload > 0 && len(msg.GetPayload()) > svc.maxPayload {
+		return ErrPayloadTooLarge
+	}
+
+	// Retransmitted messages are dropped, unless the original failed to
+	// be published. Retransmissions of the message being published get
+	// its result.
+	if svc.dedup == nil || msg.GetId() == "" {
+		return svc.publish(ctx, msg, confirm)
+	}
+	done, err := svc.dedup.Add(ctx, msg.GetPublisher(), msg.GetId())
+	if err != nil {
+		return err
+	}
+	err = svc.publish(ctx, msg, confirm)
+	done(err)
+
+	return err
+}
+
+func (svc *adapterService) publish(ctx context.Context, msg *messaging.Message, confirm bool) error {
+	if err := svc.authorizeSubtopic(ctx, msg); err != nil {
+		return err
+	}
+	if err := svc.validatePayload(ctx, msg); err != nil {
+		return err
+	}
+	if err := svc.transformPayload(ctx, msg); err != nil {
+		return err
+	}
+
+	if !confirm {
+		return svc.pubsub.Publish(messaging.WithAsyncPublish(ctx), msg.GetChannel(), msg)
+	}
+
+	// The publisher returns once the bus persisted the message, so the
+	// wait for the acknowledgement is bounded by the bus ack timeout.
+	ctx, cancel := context.WithTimeout(ctx, svc.busAckTimeout)
+	defer cancel()
+	if err := svc.pubsub.Publish(ctx, msg.GetChannel(), msg); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.Wrap(ErrBusAckTimeout, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (svc *adapterService) authorizeSubtopic(ctx context.Context, msg *messaging.Message) error {
+	if svc.subtopics == nil {
+		return nil
+	}
+	patterns, err := svc.subtopics.RetrieveAllowedSubtopics(ctx, msg.GetPublisher())
+	if err != nil {
+		return err
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	for _, p := range patterns {
+		if MatchSubtopic(p, msg.GetSubtopic()) {
+			return nil
+		}
+	}
+
+	return errors.Wrap(svcerr.ErrAuthorization, ErrSubtopicNotAllowed)
+}
+
+func (svc *adapterService) validatePayload(ctx context.Context, msg *messaging.Message) error {
+	if svc.schemas == nil {
+		return nil
+	}
+	schema, ok, err := svc.schemas.RetrieveSchema(ctx, msg.GetChannel())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	// The raw payload is published, its JSON representation is only validated.
+	payload, ok, err := jsonPayload(msg.GetContentType(), msg.GetPayload())
+	if err != nil {
+		return errors.Wrap(groups.ErrInvalidPayload, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	return schema.Validate(payload)
+}
+
+// transformPayload transforms the validated payload, so the schema applies
+// to the payloads as published by the things.
+func (svc *adapterService) transformPayload(ctx context.Context, msg *messaging.Message) error {
+	if svc.transforms == nil {
+		return nil
+	}
+	pipeline, ok, err := svc.transforms.RetrieveTransforms(ctx, msg.GetChannel())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	msg.Payload = transformPayload(msg.GetContentType(), msg.GetPayload(), pipeline)
+
+	return nil
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
+	if svc.isDraining() {
+		return ErrDraining
+	}
+	subtopic, err := NormalizeSubtopic(subtopic)
+	if err != nil {
+		return err
+	}
+
 	ar := &magistrala.AuthorizeReq{
 		SubjectType: auth.ThingType,
 		Permission:  auth.SubscribePermission,
@@ -95,10 +308,31 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 		Topic:   subject,
 		Handler: c,
 	}
-	return svc.pubsub.Subscribe(ctx, subCfg)
+	if err := svc.pubsub.Subscribe(ctx, subCfg); err != nil {
+		return err
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if svc.draining {
+		// The drain started while subscribing, so the session is dropped
+		// rather than left behind.
+		if err := svc.pubsub.Unsubscribe(ctx, c.Token(), subject); err != nil {
+			return errors.Wrap(ErrDraining, err)
+		}
+		return ErrDraining
+	}
+	svc.sessions[session{token: c.Token(), topic: subject}] = c
+
+	return nil
 }
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
+	subtopic, err := NormalizeSubtopic(subtopic)
+	if err != nil {
+		return err
+	}
+
 	ar := &magistrala.AuthorizeReq{
 		Domain:      "",
 		SubjectType: auth.ThingType,
@@ -119,5 +353,74 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 		subject = fmt.Sprintf("%s.%s", subject, subtopic)
 	}
 
+	svc.mu.Lock()
+	s := session{token: token, topic: subject}
+	_, active := svc.sessions[s]
+	delete(svc.sessions, s)
+	draining := svc.draining
+	svc.mu.Unlock()
+	// Drained sessions are already unsubscribed.
+	if draining && !active {
+		return nil
+	}
+
 	return svc.pubsub.Unsubscribe(ctx, token, subject)
 }
+
+func (svc *adapterService) Drain(ctx context.Context, window time.Duration, notify bool) (int, error) {
+	svc.mu.Lock()
+	svc.draining = true
+	total := len(svc.sessions)
+	svc.mu.Unlock()
+	if total == 0 {
+		return 0, nil
+	}
+
+	interval := window / time.Duration(total)
+	var drained int
+	var err error
+	for i := 0; ; i++ {
+		if i > 0 && ctx.Err() == nil {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+			}
+		}
+		s, c, ok := svc.nextSession()
+		if !ok {
+			return drained, err
+		}
+		// Sessions are unsubscribed even once the drain window is over.
+		if e := svc.pubsub.Unsubscribe(context.WithoutCancel(ctx), s.token, s.topic); e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+		drained++
+		if notify {
+			if e := c.Cancel(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+}
+
+func (svc *adapterService) isDraining() bool {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	return svc.draining
+}
+
+// nextSession removes an active session, if any, and returns it.
+func (svc *adapterService) nextSession() (session, Client, bool) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	for s, c := range svc.sessions {
+		delete(svc.sessions, s)
+		return s, c, true
+	}
+
+	return session{}, nil, false
+}
@@ -0,0 +1,527 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/mocks"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingKey  = "thing-key"
+	thingID   = "thing-id"
+	channelID = "channel-id"
+)
+
+func cborPayload(t *testing.T, v interface{}) []byte {
+	data, err := cbor.Marshal(v)
+	require.Nil(t, err, fmt.Sprintf("unexpected error while encoding CBOR: %s", err))
+
+	return data
+}
+
+func TestPublish(t *testing.T) {
+	repo := &schemaRepo{
+		metadata: clients.Metadata{
+			groups.SchemaKey: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"temperature"},
+				"properties": map[string]interface{}{
+					"temperature": map[string]interface{}{"type": "number"},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		contentType string
+		payload     []byte
+		err         error
+	}{
+		{
+			desc:    "publish JSON payload without content type",
+			payload: []byte(`{"temperature": 21.5}`),
+		},
+		{
+			desc:        "publish JSON payload",
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"temperature": 21.5}`),
+		},
+		{
+			desc:        "publish JSON payload not matching the schema",
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"temperature": "warm"}`),
+			err:         groups.ErrInvalidPayload,
+		},
+		{
+			desc:        "publish CBOR payload",
+			contentType: coap.CBORContentType,
+			payload:     cborPayload(t, map[string]interface{}{"temperature": 21.5}),
+		},
+		{
+			desc:        "publish CBOR payload not matching the schema",
+			contentType: coap.CBORContentType,
+			payload:     cborPayload(t, map[string]interface{}{"humidity": 40}),
+			err:         groups.ErrInvalidPayload,
+		},
+		{
+			desc:        "publish CBOR payload with JSON content type",
+			contentType: coap.JSONContentType,
+			payload:     cborPayload(t, map[string]interface{}{"temperature": 21.5}),
+			err:         groups.ErrInvalidPayload,
+		},
+		{
+			desc:        "publish malformed CBOR payload",
+			contentType: coap.CBORContentType,
+			payload:     []byte{0xbf, 0x61},
+			err:         groups.ErrInvalidPayload,
+		},
+		{
+			desc:        "publish payload of unknown content type",
+			contentType: "application/octet-stream",
+			payload:     []byte{0x00, 0x01, 0x02},
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{Schemas: repo, BusAckTimeout: time.Second})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Publish", mock.Anything, channelID, mock.Anything).Return(nil)
+
+		msg := &messaging.Message{
+			Channel:     channelID,
+			Payload:     tc.payload,
+			ContentType: tc.contentType,
+		}
+		err := svc.Publish(context.Background(), thingKey, msg, false)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		if tc.err != nil {
+			pubsub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+			continue
+		}
+		// The raw payload and its content type are forwarded to the bus.
+		pubsub.AssertCalled(t, "Publish", mock.Anything, channelID, mock.MatchedBy(func(m *messaging.Message) bool {
+			return string(m.GetPayload()) == string(tc.payload) && m.GetContentType() == tc.contentType
+		}))
+	}
+}
+
+func TestPublishConfirm(t *testing.T) {
+	cases := []struct {
+		desc    string
+		confirm bool
+		async   bool
+	}{
+		{
+			desc:    "publish confirmable message",
+			confirm: true,
+		},
+		{
+			desc:  "publish non-confirmable message",
+			async: true,
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{BusAckTimeout: time.Second})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Publish", mock.Anything, channelID, mock.Anything).Return(nil)
+
+		msg := &messaging.Message{Channel: channelID, Payload: []byte(`{"temperature": 21.5}`)}
+		err := svc.Publish(context.Background(), thingKey, msg, tc.confirm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		// Only confirmable messages wait for the bus acknowledgement.
+		pubsub.AssertCalled(t, "Publish", mock.MatchedBy(func(ctx context.Context) bool {
+			return messaging.IsAsyncPublish(ctx) == tc.async
+		}), channelID, mock.Anything)
+	}
+}
+
+func TestPublishDuplicates(t *testing.T) {
+	cases := []struct {
+		desc       string
+		msgIDs     []string
+		publishErr error
+		published  int
+		errs       []error
+	}{
+		{
+			desc:      "publish messages with distinct IDs",
+			msgIDs:    []string{"1", "2"},
+			published: 2,
+			errs:      []error{nil, nil},
+		},
+		{
+			desc:      "publish retransmitted message",
+			msgIDs:    []string{"1", "1"},
+			published: 1,
+			errs:      []error{nil, coap.ErrDuplicateMessage},
+		},
+		{
+			desc:      "publish messages without ID",
+			msgIDs:    []string{"", ""},
+			published: 2,
+			errs:      []error{nil, nil},
+		},
+		{
+			desc:       "publish retransmitted message after failed publish",
+			msgIDs:     []string{"1", "1"},
+			publishErr: errors.New("bus unavailable"),
+			published:  2,
+			errs:       []error{errors.New("bus unavailable"), errors.New("bus unavailable")},
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{Dedup: coap.NewDeduplicator(time.Minute, 10), BusAckTimeout: time.Second})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Publish", mock.Anything, channelID, mock.Anything).Return(tc.publishErr)
+
+		for i, id := range tc.msgIDs {
+			msg := &messaging.Message{Channel: channelID, Id: id, Payload: []byte(`{"temperature": 21.5}`)}
+			err := svc.Publish(context.Background(), thingKey, msg, false)
+			assert.True(t, errors.Contains(err, tc.errs[i]), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.errs[i], err))
+		}
+		pubsub.AssertNumberOfCalls(t, "Publish", tc.published)
+	}
+}
+
+func TestPublishPayloadSize(t *testing.T) {
+	const maxPayload = 16
+
+	cases := []struct {
+		desc       string
+		payload    []byte
+		maxPayload int
+		published  int
+		err        error
+	}{
+		{
+			desc:       "publish payload under the max payload size",
+			payload:    []byte(`{"temp": 21.5}`),
+			maxPayload: maxPayload,
+			published:  1,
+		},
+		{
+			desc:       "publish payload of the max payload size",
+			payload:    bytes.Repeat([]byte("a"), maxPayload),
+			maxPayload: maxPayload,
+			published:  1,
+		},
+		{
+			desc:       "publish payload over the max payload size",
+			payload:    bytes.Repeat([]byte("a"), maxPayload+1),
+			maxPayload: maxPayload,
+			err:        coap.ErrPayloadTooLarge,
+		},
+		{
+			desc:      "publish large payload without max payload size",
+			payload:   bytes.Repeat([]byte("a"), 1<<20),
+			published: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{BusAckTimeout: time.Second, MaxPayload: tc.maxPayload})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Publish", mock.Anything, channelID, mock.Anything).Return(nil)
+
+		msg := &messaging.Message{Channel: channelID, Payload: tc.payload}
+		err := svc.Publish(context.Background(), thingKey, msg, false)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		pubsub.AssertNumberOfCalls(t, "Publish", tc.published)
+	}
+}
+
+func TestPublishAllowedSubtopics(t *testing.T) {
+	cases := []struct {
+		desc     string
+		repo     *subtopicRepo
+		subtopic string
+		err      error
+	}{
+		{
+			desc:     "publish to subtopic of unrestricted thing",
+			repo:     &subtopicRepo{},
+			subtopic: "room.1.temperature",
+		},
+		{
+			desc:     "publish to allowed subtopic",
+			repo:     &subtopicRepo{patterns: []string{"room.*.temperature"}},
+			subtopic: "room/1/temperature",
+		},
+		{
+			desc:     "publish to subtopic allowed by multi wildcard",
+			repo:     &subtopicRepo{patterns: []string{"status", "room.>"}},
+			subtopic: "room.1.humidity",
+		},
+		{
+			desc:     "publish to subtopic not allowed",
+			repo:     &subtopicRepo{patterns: []string{"room.*.temperature"}},
+			subtopic: "room.1.humidity",
+			err:      coap.ErrSubtopicNotAllowed,
+		},
+		{
+			desc: "publish without subtopic with restricted thing",
+			repo: &subtopicRepo{patterns: []string{"room.>"}},
+			err:  coap.ErrSubtopicNotAllowed,
+		},
+		{
+			desc:     "publish with failing subtopic repository",
+			repo:     &subtopicRepo{err: errRetrieve},
+			subtopic: "room.1.temperature",
+			err:      errRetrieve,
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{Subtopics: tc.repo, BusAckTimeout: time.Second})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Publish", mock.Anything, channelID, mock.Anything).Return(nil)
+
+		msg := &messaging.Message{
+			Channel:  channelID,
+			Subtopic: tc.subtopic,
+			Payload:  []byte(`{"temperature": 21.5}`),
+		}
+		err := svc.Publish(context.Background(), thingKey, msg, false)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		if tc.err == coap.ErrSubtopicNotAllowed {
+			assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("%s: expected authorization error got %s", tc.desc, err))
+		}
+		if tc.err != nil {
+			pubsub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+			continue
+		}
+		pubsub.AssertCalled(t, "Publish", mock.Anything, channelID, mock.Anything)
+	}
+}
+
+func TestPublishTransforms(t *testing.T) {
+	metadata := clients.Metadata{
+		groups.TransformsKey: []interface{}{
+			map[string]interface{}{"rename": map[string]interface{}{"t": "temperature"}},
+			map[string]interface{}{"scale": map[string]interface{}{"field": "temperature", "factor": 0.5}},
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		repo        *transformRepo
+		contentType string
+		payload     []byte
+		expected    []byte
+		err         error
+	}{
+		{
+			desc:        "publish JSON payload to channel with transforms",
+			repo:        &transformRepo{metadata: metadata},
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"t": 43, "id": 12345678901234567890}`),
+			expected:    []byte(`{"id":12345678901234567890,"temperature":21.5}`),
+		},
+		{
+			desc:        "publish CBOR payload to channel with transforms",
+			repo:        &transformRepo{metadata: metadata},
+			contentType: coap.CBORContentType,
+			payload:     cborPayload(t, map[string]interface{}{"t": 43}),
+			expected:    cborPayload(t, map[string]interface{}{"temperature": 21.5}),
+		},
+		{
+			desc:        "publish SenML payload to channel with transforms",
+			repo:        &transformRepo{metadata: metadata},
+			contentType: "application/senml+json",
+			payload:     []byte(`[{"n": "room", "t": 43}, {"n": "hall"}]`),
+			expected:    []byte(`[{"n":"room","temperature":21.5},{"n":"hall"}]`),
+		},
+		{
+			desc:        "publish payload without transformed fields",
+			repo:        &transformRepo{metadata: metadata},
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"humidity": 40}`),
+			expected:    []byte(`{"humidity":40}`),
+		},
+		{
+			desc:        "publish malformed JSON payload to channel with transforms",
+			repo:        &transformRepo{metadata: metadata},
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"t": `),
+			expected:    []byte(`{"t": `),
+		},
+		{
+			desc:        "publish payload of unknown content type to channel with transforms",
+			repo:        &transformRepo{metadata: metadata},
+			contentType: "application/octet-stream",
+			payload:     []byte{0x00, 0x01},
+			expected:    []byte{0x00, 0x01},
+		},
+		{
+			desc:        "publish payload to channel without transforms",
+			repo:        &transformRepo{metadata: clients.Metadata{}},
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"t": 43}`),
+			expected:    []byte(`{"t": 43}`),
+		},
+		{
+			desc:        "publish payload with failing transform repository",
+			repo:        &transformRepo{err: errRetrieve},
+			contentType: coap.JSONContentType,
+			payload:     []byte(`{"t": 43}`),
+			err:         errRetrieve,
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{Transforms: tc.repo, BusAckTimeout: time.Second})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Publish", mock.Anything, channelID, mock.Anything).Return(nil)
+
+		msg := &messaging.Message{
+			Channel:     channelID,
+			Payload:     tc.payload,
+			ContentType: tc.contentType,
+		}
+		err := svc.Publish(context.Background(), thingKey, msg, false)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		if tc.err != nil {
+			pubsub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+			continue
+		}
+		pubsub.AssertCalled(t, "Publish", mock.Anything, channelID, mock.MatchedBy(func(m *messaging.Message) bool {
+			return string(m.GetPayload()) == string(tc.expected) && m.GetContentType() == tc.contentType
+		}))
+	}
+}
+
+type fakeClient struct {
+	token    string
+	canceled bool
+}
+
+func (c *fakeClient) Token() string {
+	return c.token
+}
+
+func (c *fakeClient) Handle(*messaging.Message) error {
+	return nil
+}
+
+func (c *fakeClient) Cancel() error {
+	c.canceled = true
+	return nil
+}
+
+func (c *fakeClient) Done() <-chan struct{} {
+	return nil
+}
+
+func TestDrain(t *testing.T) {
+	cases := []struct {
+		desc     string
+		sessions int
+		window   time.Duration
+		timeout  time.Duration
+		notify   bool
+	}{
+		{
+			desc:     "drain without sessions",
+			sessions: 0,
+			window:   time.Second,
+			timeout:  time.Second,
+		},
+		{
+			desc:     "drain sessions with notification",
+			sessions: 3,
+			window:   30 * time.Millisecond,
+			timeout:  time.Second,
+			notify:   true,
+		},
+		{
+			desc:     "drain sessions without notification",
+			sessions: 3,
+			window:   30 * time.Millisecond,
+			timeout:  time.Second,
+		},
+		{
+			desc:     "drain sessions past the drain window",
+			sessions: 3,
+			window:   time.Hour,
+			timeout:  10 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range cases {
+		authClient := new(authmocks.AuthClient)
+		pubsub := new(mocks.PubSub)
+		svc := coap.New(authClient, pubsub, coap.Config{BusAckTimeout: time.Second})
+
+		authClient.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true, Id: thingID}, nil)
+		pubsub.On("Subscribe", mock.Anything, mock.Anything).Return(nil)
+		pubsub.On("Unsubscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		clients := make([]*fakeClient, tc.sessions)
+		for i := range clients {
+			clients[i] = &fakeClient{token: fmt.Sprintf("token-%d", i)}
+			err := svc.Subscribe(context.Background(), thingKey, channelID, "", clients[i])
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error while subscribing: %s", tc.desc, err))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+		begin := time.Now()
+		drained, err := svc.Drain(ctx, tc.window, tc.notify)
+		cancel()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.sessions, drained, fmt.Sprintf("%s: expected %d drained sessions got %d", tc.desc, tc.sessions, drained))
+		assert.Less(t, time.Since(begin), time.Second, fmt.Sprintf("%s: drain took longer than expected", tc.desc))
+		pubsub.AssertNumberOfCalls(t, "Unsubscribe", tc.sessions)
+		for _, c := range clients {
+			assert.Equal(t, tc.notify, c.canceled, fmt.Sprintf("%s: expected client %s notified %t", tc.desc, c.token, tc.notify))
+		}
+
+		// Drained sessions are not unsubscribed twice, and new ones are refused.
+		for _, c := range clients {
+			err := svc.Unsubscribe(context.Background(), thingKey, channelID, "", c.token)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while unsubscribing drained session: %s", tc.desc, err))
+		}
+		pubsub.AssertNumberOfCalls(t, "Unsubscribe", tc.sessions)
+		err = svc.Subscribe(context.Background(), thingKey, channelID, "", &fakeClient{token: "new-token"})
+		assert.True(t, errors.Contains(err, coap.ErrDraining), fmt.Sprintf("%s: expected error %s got %s", tc.desc, coap.ErrDraining, err))
+	}
+}
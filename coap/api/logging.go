@@ -7,36 +7,74 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/messaging"
 )
 
 var _ coap.Service = (*loggingMiddleware)(nil)
 
 type loggingMiddleware struct {
-	logger *slog.Logger
-	svc    coap.Service
+	logger     *slog.Logger
+	svc        coap.Service
+	duplicates atomic.Uint64
 }
 
 // LoggingMiddleware adds logging facilities to the adapter.
 func LoggingMiddleware(svc coap.Service, logger *slog.Logger) coap.Service {
-	return &loggingMiddleware{logger, svc}
+	return &loggingMiddleware{logger: logger, svc: svc}
 }
 
-// Publish logs the publish request. It logs the channel ID, subtopic (if any) and the time it took to complete the request.
-// If the request fails, it logs the error.
-func (lm *loggingMiddleware) Publish(ctx context.Context, key string, msg *messaging.Message) (err error) {
+// Publish logs the publish request. It logs the channel ID, subtopic (if any), whether the publish
+// was confirmed and the time it took to complete the request.
+// If the request fails, it logs the error. Payloads rejected by the channel schema are logged
+// separately with the reason of the rejection, and so are messages to subtopics the thing is
+// not allowed to publish to. Dropped duplicate messages are logged with their ID and the number
+// of duplicates dropped so far, and oversized messages with the thing and the payload size.
+func (lm *loggingMiddleware) Publish(ctx context.Context, key string, msg *messaging.Message, confirm bool) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
 			slog.String("channel_id", msg.GetChannel()),
+			slog.Bool("confirmed", confirm),
 		}
 		if msg.GetSubtopic() != "" {
 			args = append(args, slog.String("subtopic", msg.GetSubtopic()))
 		}
+		if errors.Contains(err, coap.ErrDuplicateMessage) {
+			args = append(args,
+				slog.String("publisher", msg.GetPublisher()),
+				slog.String("message_id", msg.GetId()),
+				slog.Uint64("duplicates", lm.duplicates.Add(1)),
+			)
+			lm.logger.Info("Publish duplicate message dropped", args...)
+			return
+		}
+		if errors.Contains(err, coap.ErrPayloadTooLarge) {
+			args = append(args,
+				slog.String("publisher", msg.GetPublisher()),
+				slog.Int("payload_size", len(msg.GetPayload())),
+			)
+			lm.logger.Warn("Publish message rejected for payload size", args...)
+			return
+		}
+		if errors.Contains(err, coap.ErrSubtopicNotAllowed) {
+			args = append(args, slog.String("publisher", msg.GetPublisher()))
+			lm.logger.Warn(fmt.Sprintf("Publish message denied for subtopic %q", msg.GetSubtopic()), args...)
+			return
+		}
+		if errors.Contains(err, groups.ErrInvalidPayload) {
+			_, reason := errors.Unwrap(err)
+			args = append(args, slog.Any("reason", reason))
+			lm.logger.Warn("Publish message rejected by channel schema", args...)
+			return
+		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("Publish message failed", args...)
@@ -45,7 +83,7 @@ func (lm *loggingMiddleware) Publish(ctx context.Context, key string, msg *messa
 		lm.logger.Info("Publish message completed successfully", args...)
 	}(time.Now())
 
-	return lm.svc.Publish(ctx, key, msg)
+	return lm.svc.Publish(ctx, key, msg, confirm)
 }
 
 // Subscribe logs the subscribe request. It logs the channel ID, subtopic (if any) and the time it took to complete the request.
@@ -71,7 +109,8 @@ func (lm *loggingMiddleware) Subscribe(ctx context.Context, key, chanID, subtopi
 }
 
 // Unsubscribe logs the unsubscribe request. It logs the channel ID, subtopic (if any) and the time it took to complete the request.
-// If the request fails, it logs the error.
+// If the request fails, it logs the error. Unsubscribes of observations which were not renewed within the idle timeout are
+// logged separately from the ones requested by the clients.
 func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -81,6 +120,16 @@ func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 		if subtopic != "" {
 			args = append(args, slog.String("subtopic", subtopic))
 		}
+		if coap.IsIdleTimeout(ctx) {
+			args = append(args, slog.String("token", token))
+			if err != nil {
+				args = append(args, slog.Any("error", err))
+				lm.logger.Warn("Unsubscribe idle observation failed", args...)
+				return
+			}
+			lm.logger.Info("Unsubscribed observation after idle timeout", args...)
+			return
+		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("Unsubscribe failed", args...)
@@ -91,3 +140,25 @@ func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 
 	return lm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
 }
+
+// Drain logs the drain of the subscriptions. It logs the number of drained sessions,
+// the drain window and the time it took to complete the drain. If the drain fails, it
+// logs the error along with the number of sessions drained before the failure.
+func (lm *loggingMiddleware) Drain(ctx context.Context, window time.Duration, notify bool) (drained int, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("window", window.String()),
+			slog.Bool("notify", notify),
+			slog.Int("drained", drained),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Drain subscriptions failed", args...)
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("Drained %d subscriptions", drained), args...)
+	}(time.Now())
+
+	return lm.svc.Drain(ctx, window, notify)
+}
@@ -32,13 +32,13 @@ func MetricsMiddleware(svc coap.Service, counter metrics.Counter, latency metric
 }
 
 // Publish instruments Publish method with metrics.
-func (mm *metricsMiddleware) Publish(ctx context.Context, key string, msg *messaging.Message) error {
+func (mm *metricsMiddleware) Publish(ctx context.Context, key string, msg *messaging.Message, confirm bool) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "publish").Add(1)
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, key, msg)
+	return mm.svc.Publish(ctx, key, msg, confirm)
 }
 
 // Subscribe instruments Subscribe method with metrics.
@@ -60,3 +60,13 @@ func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 
 	return mm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
 }
+
+// Drain instruments Drain method with metrics.
+func (mm *metricsMiddleware) Drain(ctx context.Context, window time.Duration, notify bool) (int, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "drain").Add(1)
+		mm.latency.With("method", "drain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Drain(ctx, window, notify)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/absmach/magistrala/coap"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/go-chi/chi/v5"
 	"github.com/plgd-dev/go-coap/v3/message"
@@ -25,14 +26,24 @@ import (
 	"github.com/plgd-dev/go-coap/v3/message/pool"
 	"github.com/plgd-dev/go-coap/v3/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
 	protocol     = "coap"
 	authQuery    = "auth"
+	msgIDQuery   = "message_id"
 	startObserve = 0 // observe option value that indicates start of observation
 )
 
+// Trace context queries of messages published on behalf of traced requests.
+// CoAP has no headers, so the W3C trace context is carried in URI queries.
+const (
+	traceparentQuery = "traceparent"
+	tracestateQuery  = "tracestate"
+)
+
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 const (
@@ -41,14 +52,15 @@ const (
 )
 
 var (
-	errMalformedSubtopic = errors.New("malformed subtopic")
-	errBadOptions        = errors.New("bad options")
-	errMethodNotAllowed  = errors.New("method not allowed")
+	errBadOptions       = errors.New("bad options")
+	errMethodNotAllowed = errors.New("method not allowed")
 )
 
 var (
-	logger  *slog.Logger
-	service coap.Service
+	logger       *slog.Logger
+	service      coap.Service
+	observations *coap.Observations
+	maxPayload   int
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -60,10 +72,15 @@ func MakeHandler(instanceID string) http.Handler {
 	return b
 }
 
-// MakeCoAPHandler creates handler for CoAP messages.
-func MakeCoAPHandler(svc coap.Service, l *slog.Logger) mux.HandlerFunc {
+// MakeCoAPHandler creates handler for CoAP messages. Observations which are
+// not renewed within the idle timeout are unsubscribed, unless the idle
+// timeout is zero. Payloads larger than the maximum payload size are
+// rejected as they are read, unless the size is zero.
+func MakeCoAPHandler(svc coap.Service, l *slog.Logger, idleTimeout time.Duration, maxPayloadSize int) mux.HandlerFunc {
 	logger = l
 	service = svc
+	observations = coap.NewObservations(idleTimeout)
+	maxPayload = maxPayloadSize
 
 	return handler
 }
@@ -83,6 +100,16 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 	defer sendResp(w, resp)
 
 	msg, err := decodeMessage(m)
+	if errors.Contains(err, coap.ErrPayloadTooLarge) {
+		// The thing isn't authorized yet, so it's identified by its address.
+		logger.Warn("Publish message rejected for payload size",
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("remote_addr", w.Conn().RemoteAddr().String()),
+			slog.Int("max_payload_size", maxPayload),
+		)
+		resp.SetCode(codes.RequestEntityTooLarge)
+		return
+	}
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error decoding message: %s", err))
 		resp.SetCode(codes.BadRequest)
@@ -101,7 +128,13 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		err = handleGet(m, w, msg, key)
 	case codes.POST:
 		resp.SetCode(codes.Created)
-		err = service.Publish(m.Context(), key, msg)
+		// Confirmable messages are acknowledged only once the message bus
+		// acknowledged them, while non-confirmable ones are fire-and-forget.
+		err = service.Publish(traceContext(m), key, msg, m.Type() == message.Confirmable)
+		// Duplicates are acknowledged, so the device stops retransmitting.
+		if errors.Contains(err, coap.ErrDuplicateMessage) {
+			err = nil
+		}
 	default:
 		err = errMethodNotAllowed
 	}
@@ -112,6 +145,15 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 			resp.SetCode(codes.BadOption)
 		case err == errMethodNotAllowed:
 			resp.SetCode(codes.MethodNotAllowed)
+		case errors.Contains(err, coap.ErrMalformedSubtopic),
+			errors.Contains(err, groups.ErrInvalidPayload):
+			resp.SetCode(codes.BadRequest)
+		case errors.Contains(err, coap.ErrPayloadTooLarge):
+			resp.SetCode(codes.RequestEntityTooLarge)
+		case errors.Contains(err, coap.ErrBusAckTimeout):
+			resp.SetCode(codes.GatewayTimeout)
+		case errors.Contains(err, coap.ErrDraining):
+			resp.SetCode(codes.ServiceUnavailable)
 		case errors.Contains(err, svcerr.ErrAuthorization):
 			resp.SetCode(codes.Forbidden)
 		case errors.Contains(err, svcerr.ErrAuthentication):
@@ -129,9 +171,28 @@ func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key
 		logger.Warn(fmt.Sprintf("Error reading observe option: %s", err))
 		return errBadOptions
 	}
+	o := coap.Observation{
+		Addr:     w.Conn().RemoteAddr().String(),
+		Token:    m.Token().String(),
+		Channel:  msg.GetChannel(),
+		Subtopic: msg.GetSubtopic(),
+	}
 	if obs == startObserve {
 		c := coap.NewClient(w.Conn(), m.Token(), logger)
+		// Renewed observations only reset the idle timer, since the
+		// client is already subscribed.
+		renewed := observations.Observe(o, func() {
+			// The unsubscribe is logged by the logging middleware.
+			_ = service.Unsubscribe(coap.WithIdleTimeout(context.Background()), key, msg.GetChannel(), msg.GetSubtopic(), c.Token())
+		})
+		if renewed {
+			return nil
+		}
 		w.Conn().AddOnClose(func() {
+			// Expired and unsubscribed observations are already gone.
+			if !observations.Remove(o) {
+				return
+			}
 			err := service.Unsubscribe(context.Background(), key, msg.GetChannel(), msg.GetSubtopic(), c.Token())
 			args := []any{
 				slog.String("channel_id", msg.GetChannel()),
@@ -145,8 +206,13 @@ func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key
 			}
 			logger.Warn("Unsubscribe idle client completed successfully", args...)
 		})
-		return service.Subscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), c)
+		if err := service.Subscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), c); err != nil {
+			observations.Remove(o)
+			return err
+		}
+		return nil
 	}
+	observations.Remove(o)
 	return service.Unsubscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), m.Token().String())
 }
 
@@ -160,7 +226,7 @@ func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
 	}
 	channelParts := channelPartRegExp.FindStringSubmatch(path)
 	if len(channelParts) < numGroups {
-		return &messaging.Message{}, errMalformedSubtopic
+		return &messaging.Message{}, coap.ErrMalformedSubtopic
 	}
 
 	st, err := parseSubtopic(channelParts[channelGroup])
@@ -168,33 +234,99 @@ func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
 		return &messaging.Message{}, err
 	}
 	ret := &messaging.Message{
-		Protocol: protocol,
-		Channel:  channelParts[1],
-		Subtopic: st,
-		Payload:  []byte{},
-		Created:  time.Now().UnixNano(),
+		Protocol:    protocol,
+		Channel:     channelParts[1],
+		Subtopic:    st,
+		Payload:     []byte{},
+		Created:     time.Now().UnixNano(),
+		ContentType: contentType(msg),
+		Id:          messageID(msg),
 	}
 
 	if msg.Body() != nil {
-		buff, err := io.ReadAll(msg.Body())
+		// Oversized payloads are read only up to the first byte over the
+		// limit, so they are rejected without being buffered whole.
+		body := io.Reader(msg.Body())
+		if maxPayload > 0 {
+			body = io.LimitReader(body, int64(maxPayload)+1)
+		}
+		buff, err := io.ReadAll(body)
 		if err != nil {
 			return ret, err
 		}
+		if maxPayload > 0 && len(buff) > maxPayload {
+			return ret, coap.ErrPayloadTooLarge
+		}
 		ret.Payload = buff
 	}
 	return ret, nil
 }
 
+// contentType returns the media type of the message Content-Format option.
+// Messages without the option or with an unregistered content format have
+// no content type.
+func contentType(msg *mux.Message) string {
+	cf, err := msg.Options().ContentFormat()
+	if err != nil {
+		return ""
+	}
+	if _, err := message.ToMediaType(cf.String()); err != nil {
+		return ""
+	}
+
+	return cf.String()
+}
+
+// messageID returns the message ID carried in the message URI queries, which
+// is empty for the messages without one.
+func messageID(msg *mux.Message) string {
+	queries, err := msg.Options().Queries()
+	if err != nil {
+		return ""
+	}
+	for _, q := range queries {
+		name, val, ok := strings.Cut(q, "=")
+		if ok && name == msgIDQuery {
+			return val
+		}
+	}
+
+	return ""
+}
+
 func parseKey(msg *mux.Message) (string, error) {
-	authKey, err := msg.Options().GetString(message.URIQuery)
+	queries, err := msg.Options().Queries()
 	if err != nil {
 		return "", err
 	}
-	vars := strings.Split(authKey, "=")
-	if len(vars) != 2 || vars[0] != authQuery {
-		return "", svcerr.ErrAuthorization
+	for _, q := range queries {
+		name, val, ok := strings.Cut(q, "=")
+		if ok && name == authQuery {
+			return val, nil
+		}
+	}
+	return "", svcerr.ErrAuthorization
+}
+
+// traceContext returns the message context continuing the trace carried in
+// the message URI queries. Messages without trace context are not affected.
+func traceContext(msg *mux.Message) context.Context {
+	ctx := msg.Context()
+	queries, err := msg.Options().Queries()
+	if err != nil {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{}
+	for _, q := range queries {
+		name, val, ok := strings.Cut(q, "=")
+		if ok && (name == traceparentQuery || name == tracestateQuery) {
+			carrier[name] = val
+		}
 	}
-	return vars[1], nil
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
 }
 
 func parseSubtopic(subtopic string) (string, error) {
@@ -204,24 +336,8 @@ func parseSubtopic(subtopic string) (string, error) {
 
 	subtopic, err := url.QueryUnescape(subtopic)
 	if err != nil {
-		return "", errMalformedSubtopic
-	}
-	subtopic = strings.ReplaceAll(subtopic, "/", ".")
-
-	elems := strings.Split(subtopic, ".")
-	filteredElems := []string{}
-	for _, elem := range elems {
-		if elem == "" {
-			continue
-		}
-
-		if len(elem) > 1 && (strings.Contains(elem, "*") || strings.Contains(elem, ">")) {
-			return "", errMalformedSubtopic
-		}
-
-		filteredElems = append(filteredElems, elem)
+		return "", coap.ErrMalformedSubtopic
 	}
 
-	subtopic = strings.Join(filteredElems, ".")
-	return subtopic, nil
+	return coap.NormalizeSubtopic(subtopic)
 }
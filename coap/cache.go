@@ -0,0 +1,77 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"sync"
+	"time"
+)
+
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// ttlCache keeps values by key for the ttl. At most size values are kept,
+// so a cache in front of a repository can't grow with the number of the
+// looked up entities.
+type ttlCache[V any] struct {
+	ttl     time.Duration
+	size    int
+	mu      sync.Mutex
+	entries map[string]ttlEntry[V]
+}
+
+func newTTLCache[V any](ttl time.Duration, size int) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]ttlEntry[V]),
+	}
+}
+
+// get returns the value of the key, if it is cached and hasn't expired.
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !time.Now().Before(e.expiresAt) {
+		var v V
+		return v, false
+	}
+
+	return e.value, true
+}
+
+// set caches the value of the key for the ttl.
+func (c *ttlCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[key] = ttlEntry[V]{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// evict removes the expired values, or arbitrary values if none has
+// expired, to make room for a new one.
+func (c *ttlCache[V]) evict() {
+	now := time.Now()
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.entries {
+		if len(c.entries) < c.size {
+			return
+		}
+		delete(c.entries, key)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrDuplicateMessage indicates that the thing already published a message
+// with the same ID within the deduplication window.
+var ErrDuplicateMessage = errors.New("duplicate message")
+
+type dedupKey struct {
+	thingID string
+	msgID   string
+}
+
+type dedupEntry struct {
+	at   time.Time
+	slot int
+}
+
+// pendingMessage is the message being published, which its duplicates
+// wait for.
+type pendingMessage struct {
+	done chan struct{}
+	err  error
+}
+
+// Deduplicator remembers the IDs of the messages published recently by each
+// thing. It holds at most size IDs, forgetting the oldest ones first, so
+// duplicates arriving after many other messages may not be recognized.
+// Messages being published are tracked separately, until they are published.
+type Deduplicator struct {
+	mu     sync.Mutex
+	window time.Duration
+	seen   map[dedupKey]dedupEntry
+	// order holds the keys in the order they were seen, as a ring of
+	// the size of the deduplicator.
+	order    []dedupKey
+	next     int
+	inflight map[dedupKey]*pendingMessage
+}
+
+// NewDeduplicator returns the deduplicator of the messages published within
+// the window, remembering at most size message IDs.
+func NewDeduplicator(window time.Duration, size int) *Deduplicator {
+	return &Deduplicator{
+		window:   window,
+		seen:     make(map[dedupKey]dedupEntry, size),
+		order:    make([]dedupKey, size),
+		inflight: make(map[dedupKey]*pendingMessage),
+	}
+}
+
+// Add starts publishing the message ID of the thing. It returns
+// ErrDuplicateMessage if the message was already published within the
+// window. If the message is being published, Add waits for it and returns
+// ErrDuplicateMessage once it's published, or the error it failed with.
+// Otherwise, the returned function must be called with the result of the
+// publish, which records the message ID if it succeeded.
+func (d *Deduplicator) Add(ctx context.Context, thingID, msgID string) (func(error), error) {
+	if len(d.order) == 0 {
+		return func(error) {}, nil
+	}
+	key := dedupKey{thingID: thingID, msgID: msgID}
+
+	d.mu.Lock()
+	if e, ok := d.seen[key]; ok && time.Since(e.at) < d.window {
+		d.mu.Unlock()
+		return nil, ErrDuplicateMessage
+	}
+	if p, ok := d.inflight[key]; ok {
+		d.mu.Unlock()
+		select {
+		case <-p.done:
+			if p.err != nil {
+				return nil, p.err
+			}
+			return nil, ErrDuplicateMessage
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	p := &pendingMessage{done: make(chan struct{})}
+	d.inflight[key] = p
+	d.mu.Unlock()
+
+	return func(err error) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		delete(d.inflight, key)
+		if err == nil {
+			d.record(key)
+		}
+		p.err = err
+		close(p.done)
+	}, nil
+}
+
+// record remembers the published message ID.
+func (d *Deduplicator) record(key dedupKey) {
+	now := time.Now()
+	if e, ok := d.seen[key]; ok {
+		// The expired key keeps its place in the ring, it's only renewed.
+		d.seen[key] = dedupEntry{at: now, slot: e.slot}
+		return
+	}
+	if old, ok := d.seen[d.order[d.next]]; ok && old.slot == d.next {
+		delete(d.seen, d.order[d.next])
+	}
+	d.order[d.next] = key
+	d.seen[key] = dedupEntry{at: now, slot: d.next}
+	d.next = (d.next + 1) % len(d.order)
+}
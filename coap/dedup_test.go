@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dedupWindow = 50 * time.Millisecond
+
+func publish(dedup *coap.Deduplicator, thingID, msgID string, err error) error {
+	done, addErr := dedup.Add(context.Background(), thingID, msgID)
+	if addErr != nil {
+		return addErr
+	}
+	done(err)
+
+	return nil
+}
+
+func TestDeduplicator(t *testing.T) {
+	dedup := coap.NewDeduplicator(time.Minute, 2)
+
+	assert.Nil(t, publish(dedup, thingID, "1", nil), "expected new message ID to be added")
+	assert.Equal(t, coap.ErrDuplicateMessage, publish(dedup, thingID, "1", nil), "expected duplicate message ID to be rejected")
+	assert.Nil(t, publish(dedup, "other-thing", "1", nil), "expected message ID of another thing to be added")
+
+	// The size is exceeded, so the oldest message ID is forgotten.
+	assert.Nil(t, publish(dedup, thingID, "2", nil), "expected new message ID to be added")
+	assert.Nil(t, publish(dedup, thingID, "1", nil), "expected evicted message ID to be added again")
+
+	// Message IDs of failed publishes are not recorded.
+	assert.Nil(t, publish(dedup, thingID, "3", errors.New("publish failed")), "expected new message ID to be added")
+	assert.Nil(t, publish(dedup, thingID, "3", nil), "expected message ID of failed publish to be added again")
+	assert.Equal(t, coap.ErrDuplicateMessage, publish(dedup, thingID, "3", nil), "expected duplicate message ID to be rejected")
+}
+
+func TestDeduplicatorWindow(t *testing.T) {
+	dedup := coap.NewDeduplicator(dedupWindow, 10)
+
+	assert.Nil(t, publish(dedup, thingID, "1", nil), "expected new message ID to be added")
+	assert.Equal(t, coap.ErrDuplicateMessage, publish(dedup, thingID, "1", nil), "expected duplicate message ID to be rejected")
+	time.Sleep(2 * dedupWindow)
+	assert.Nil(t, publish(dedup, thingID, "1", nil), "expected message ID outside the window to be added again")
+}
+
+func TestDeduplicatorInFlight(t *testing.T) {
+	cases := []struct {
+		desc       string
+		publishErr error
+		err        error
+	}{
+		{
+			desc: "duplicate of published message",
+			err:  coap.ErrDuplicateMessage,
+		},
+		{
+			desc:       "duplicate of failed message",
+			publishErr: errors.New("publish failed"),
+			err:        errors.New("publish failed"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dedup := coap.NewDeduplicator(time.Minute, 10)
+			done, err := dedup.Add(context.Background(), thingID, "1")
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+			errs := make(chan error)
+			go func() {
+				_, err := dedup.Add(context.Background(), thingID, "1")
+				errs <- err
+			}()
+
+			select {
+			case err := <-errs:
+				t.Fatalf("expected duplicate to wait for the message being published, got %v", err)
+			case <-time.After(dedupWindow):
+			}
+
+			done(tc.publishErr)
+			err = <-errs
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v got %v", tc.err, err))
+		})
+	}
+}
+
+func TestDeduplicatorInFlightCanceled(t *testing.T) {
+	dedup := coap.NewDeduplicator(time.Minute, 10)
+	done, err := dedup.Add(context.Background(), thingID, "1")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer done(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = dedup.Add(ctx, thingID, "1")
+	assert.Equal(t, context.Canceled, err, fmt.Sprintf("expected error %v got %v", context.Canceled, err))
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type idleTimeoutCtxKey struct{}
+
+// WithIdleTimeout marks the context of the unsubscribe of an observation
+// which expired, as opposed to the one requested by the client.
+func WithIdleTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idleTimeoutCtxKey{}, true)
+}
+
+// IsIdleTimeout reports whether the context is the one of the unsubscribe
+// of an expired observation.
+func IsIdleTimeout(ctx context.Context) bool {
+	idle, _ := ctx.Value(idleTimeoutCtxKey{}).(bool)
+	return idle
+}
+
+// Observation identifies the observation of a channel by a CoAP client.
+// Tokens are unique only within a connection, so the observation is
+// identified by the client address as well.
+type Observation struct {
+	Addr     string
+	Token    string
+	Channel  string
+	Subtopic string
+}
+
+// Observations tracks the active observations and expires the ones which
+// are not renewed within the idle timeout.
+type Observations struct {
+	mu          sync.Mutex
+	idleTimeout time.Duration
+	timers      map[Observation]*time.Timer
+}
+
+// NewObservations returns the observations expiring after the idle timeout.
+// Zero idle timeout disables the expiry.
+func NewObservations(idleTimeout time.Duration) *Observations {
+	return &Observations{
+		idleTimeout: idleTimeout,
+		timers:      make(map[Observation]*time.Timer),
+	}
+}
+
+// Observe registers the observation, or renews it if it is already active,
+// in which case it returns true. The expire function is called once the
+// observation wasn't renewed for the idle timeout, after the observation
+// is removed.
+func (o *Observations) Observe(obs Observation, expire func()) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	t, renewed := o.timers[obs]
+	// A timer which already fired is replaced, so the renewal isn't lost
+	// to the pending expiry.
+	if renewed && t != nil && t.Stop() {
+		t.Reset(o.idleTimeout)
+		return true
+	}
+	if renewed && t == nil {
+		return true
+	}
+	o.timers[obs] = o.newTimer(obs, expire)
+
+	return renewed
+}
+
+// Remove removes the observation and reports whether it was active.
+func (o *Observations) Remove(obs Observation) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	t, ok := o.timers[obs]
+	if !ok {
+		return false
+	}
+	if t != nil {
+		t.Stop()
+	}
+	delete(o.timers, obs)
+
+	return true
+}
+
+func (o *Observations) newTimer(obs Observation, expire func()) *time.Timer {
+	if o.idleTimeout <= 0 {
+		return nil
+	}
+
+	var t *time.Timer
+	t = time.AfterFunc(o.idleTimeout, func() {
+		o.mu.Lock()
+		// The observation was removed or renewed in the meantime.
+		if o.timers[obs] != t {
+			o.mu.Unlock()
+			return
+		}
+		delete(o.timers, obs)
+		o.mu.Unlock()
+		expire()
+	})
+
+	return t
+}
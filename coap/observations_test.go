@@ -0,0 +1,72 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/coap"
+	"github.com/stretchr/testify/assert"
+)
+
+const idleTimeout = 50 * time.Millisecond
+
+func TestObservationsExpire(t *testing.T) {
+	observations := coap.NewObservations(idleTimeout)
+	obs := coap.Observation{Addr: "127.0.0.1:5683", Token: "token", Channel: "chan"}
+
+	expired := make(chan struct{}, 1)
+	renewed := observations.Observe(obs, func() { expired <- struct{}{} })
+	assert.False(t, renewed, "expected new observation")
+
+	select {
+	case <-expired:
+	case <-time.After(10 * idleTimeout):
+		t.Fatal("expected idle observation to expire")
+	}
+	assert.False(t, observations.Remove(obs), "expected expired observation to be removed")
+}
+
+func TestObservationsRenew(t *testing.T) {
+	observations := coap.NewObservations(idleTimeout)
+	obs := coap.Observation{Addr: "127.0.0.1:5683", Token: "token", Channel: "chan"}
+
+	expired := make(chan struct{}, 1)
+	observations.Observe(obs, func() { expired <- struct{}{} })
+	for i := 0; i < 4; i++ {
+		time.Sleep(idleTimeout / 2)
+		renewed := observations.Observe(obs, func() { expired <- struct{}{} })
+		assert.True(t, renewed, "expected observation to be renewed")
+	}
+	select {
+	case <-expired:
+		t.Fatal("expected renewed observation not to expire")
+	default:
+	}
+
+	assert.True(t, observations.Remove(obs), "expected active observation to be removed")
+	time.Sleep(2 * idleTimeout)
+	select {
+	case <-expired:
+		t.Fatal("expected removed observation not to expire")
+	default:
+	}
+}
+
+func TestObservationsWithoutIdleTimeout(t *testing.T) {
+	observations := coap.NewObservations(0)
+	obs := coap.Observation{Addr: "127.0.0.1:5683", Token: "token", Channel: "chan"}
+
+	assert.False(t, observations.Observe(obs, func() { t.Fatal("expected observation not to expire") }), "expected new observation")
+	assert.True(t, observations.Observe(obs, func() {}), "expected observation to be renewed")
+	assert.True(t, observations.Remove(obs), "expected active observation to be removed")
+	assert.False(t, observations.Remove(obs), "expected removed observation to be inactive")
+}
+
+func TestIsIdleTimeout(t *testing.T) {
+	assert.False(t, coap.IsIdleTimeout(context.Background()), "expected plain context not to be an idle timeout")
+	assert.True(t, coap.IsIdleTimeout(coap.WithIdleTimeout(context.Background())), "expected idle timeout context")
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Content types of the payloads the adapter inspects.
+const (
+	JSONContentType = "application/json"
+	CBORContentType = "application/cbor"
+)
+
+// cborDecMode decodes CBOR maps into string keyed maps, so the decoded
+// payloads can be encoded as JSON.
+var cborDecMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeOf(map[string]interface{}(nil)),
+}.DecMode()
+
+// jsonPayload returns the JSON representation of the payload of the given
+// content type, used for validating the payload. Messages without a content
+// type are treated as JSON. The returned flag reports whether the payload
+// could be inspected; payloads of other content types are not.
+func jsonPayload(contentType string, payload []byte) ([]byte, bool, error) {
+	switch {
+	case contentType == "", contentType == JSONContentType, strings.HasSuffix(contentType, "+json"):
+		return payload, true, nil
+	case contentType == CBORContentType, strings.HasSuffix(contentType, "+cbor"):
+		var v interface{}
+		if err := cborDecMode.Unmarshal(payload, &v); err != nil {
+			return nil, true, err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, true, err
+		}
+		return data, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// transformPayload applies the transforms to the payload of the given
+// content type and encodes it back in the same content type. Payloads which
+// can't be decoded, including those of other content types, are returned
+// unchanged.
+func transformPayload(contentType string, payload []byte, p groups.Pipeline) []byte {
+	switch {
+	case contentType == "", contentType == JSONContentType, strings.HasSuffix(contentType, "+json"):
+		// Numbers are kept as decoded, so the untransformed ones keep their precision.
+		dec := json.NewDecoder(bytes.NewReader(payload))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return payload
+		}
+		data, err := json.Marshal(p.Apply(v))
+		if err != nil {
+			return payload
+		}
+		return data
+	case contentType == CBORContentType, strings.HasSuffix(contentType, "+cbor"):
+		var v interface{}
+		if err := cborDecMode.Unmarshal(payload, &v); err != nil {
+			return payload
+		}
+		data, err := cbor.Marshal(p.Apply(v))
+		if err != nil {
+			return payload
+		}
+		return data
+	default:
+		return payload
+	}
+}
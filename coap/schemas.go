@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// SchemaRepository retrieves the payload schemas of channels.
+type SchemaRepository interface {
+	// RetrieveSchema retrieves the payload schema of the channel. The
+	// returned flag reports whether the channel has a payload schema.
+	RetrieveSchema(ctx context.Context, chanID string) (groups.Schema, bool, error)
+}
+
+type cachedSchema struct {
+	schema groups.Schema
+	ok     bool
+}
+
+type schemaCache struct {
+	repo    SchemaRepository
+	entries *ttlCache[cachedSchema]
+}
+
+var _ SchemaRepository = (*schemaCache)(nil)
+
+// NewSchemaCache returns a schema repository which keeps the schemas
+// retrieved from the given repository for the ttl, so the schemas are
+// not compiled on every published message. Channels without a schema
+// are cached as well. At most size schemas are kept.
+func NewSchemaCache(repo SchemaRepository, ttl time.Duration, size int) SchemaRepository {
+	return &schemaCache{
+		repo:    repo,
+		entries: newTTLCache[cachedSchema](ttl, size),
+	}
+}
+
+func (sc *schemaCache) RetrieveSchema(ctx context.Context, chanID string) (groups.Schema, bool, error) {
+	if e, ok := sc.entries.get(chanID); ok {
+		return e.schema, e.ok, nil
+	}
+
+	schema, ok, err := sc.repo.RetrieveSchema(ctx, chanID)
+	if err != nil {
+		return groups.Schema{}, false, err
+	}
+	sc.entries.set(chanID, cachedSchema{schema: schema, ok: ok})
+
+	return schema, ok, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+)
+
+var errRetrieve = errors.New("failed to retrieve schema")
+
+type schemaRepo struct {
+	metadata clients.Metadata
+	err      error
+	calls    int
+}
+
+func (repo *schemaRepo) RetrieveSchema(_ context.Context, _ string) (groups.Schema, bool, error) {
+	repo.calls++
+	if repo.err != nil {
+		return groups.Schema{}, false, repo.err
+	}
+
+	return groups.SchemaFromMetadata(repo.metadata)
+}
+
+func TestSchemaCache(t *testing.T) {
+	metadata := clients.Metadata{
+		groups.SchemaKey: map[string]interface{}{"type": "object"},
+	}
+
+	cases := []struct {
+		desc  string
+		repo  *schemaRepo
+		ttl   time.Duration
+		ok    bool
+		calls int
+		err   error
+	}{
+		{
+			desc:  "retrieve schema of channel with schema",
+			repo:  &schemaRepo{metadata: metadata},
+			ttl:   time.Minute,
+			ok:    true,
+			calls: 1,
+		},
+		{
+			desc:  "retrieve schema of channel without schema",
+			repo:  &schemaRepo{metadata: clients.Metadata{}},
+			ttl:   time.Minute,
+			ok:    false,
+			calls: 1,
+		},
+		{
+			desc:  "retrieve schema with expired entries",
+			repo:  &schemaRepo{metadata: metadata},
+			ttl:   0,
+			ok:    true,
+			calls: 3,
+		},
+		{
+			desc:  "retrieve schema with failing repository",
+			repo:  &schemaRepo{err: errRetrieve},
+			ttl:   time.Minute,
+			ok:    false,
+			calls: 3,
+			err:   errRetrieve,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cache := coap.NewSchemaCache(tc.repo, tc.ttl, 10)
+			for i := 0; i < 3; i++ {
+				_, ok, err := cache.RetrieveSchema(context.Background(), "chanID")
+				assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.ok, ok))
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.calls, tc.repo.calls, fmt.Sprintf("%s: expected %d repository calls got %d", tc.desc, tc.calls, tc.repo.calls))
+		})
+	}
+}
+
+func TestSchemaCacheSize(t *testing.T) {
+	repo := &schemaRepo{metadata: clients.Metadata{}}
+	cache := coap.NewSchemaCache(repo, time.Minute, 2)
+
+	ids := []string{"chan1", "chan2", "chan3"}
+	for i := 0; i < 2; i++ {
+		for _, id := range ids {
+			_, _, err := cache.RetrieveSchema(context.Background(), id)
+			assert.Nil(t, err, fmt.Sprintf("retrieve schema of %s: unexpected error %s", id, err))
+		}
+	}
+	// The cache can't hold all the channels, so some are retrieved again.
+	assert.Greater(t, repo.calls, len(ids), fmt.Sprintf("expected more than %d repository calls got %d", len(ids), repo.calls))
+}
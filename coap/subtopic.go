@@ -0,0 +1,99 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	subtopicSep    = "."
+	singleWildcard = "*"
+	multiWildcard  = ">"
+)
+
+// ErrMalformedSubtopic indicates that the subtopic has empty segments,
+// illegal characters or misplaced wildcards.
+var ErrMalformedSubtopic = errors.New("malformed subtopic")
+
+// NormalizeSubtopic validates the subtopic and returns it in its canonical
+// form. Both "/" and "." are accepted as separators and normalized to ".",
+// while leading and trailing separators are dropped. Subtopics with empty
+// segments, invalid UTF-8, whitespace or control characters are rejected,
+// as well as wildcards which are not a whole segment or, in case of ">",
+// not the last one.
+func NormalizeSubtopic(subtopic string) (string, error) {
+	if !utf8.ValidString(subtopic) {
+		return "", ErrMalformedSubtopic
+	}
+	subtopic = strings.ReplaceAll(subtopic, "/", subtopicSep)
+	subtopic = strings.TrimPrefix(subtopic, subtopicSep)
+	subtopic = strings.TrimSuffix(subtopic, subtopicSep)
+	if subtopic == "" {
+		return "", nil
+	}
+
+	elems := strings.Split(subtopic, subtopicSep)
+	for i, elem := range elems {
+		if elem == "" {
+			return "", ErrMalformedSubtopic
+		}
+		if strings.IndexFunc(elem, illegalRune) >= 0 {
+			return "", ErrMalformedSubtopic
+		}
+		if len(elem) > 1 && strings.ContainsAny(elem, singleWildcard+multiWildcard) {
+			return "", ErrMalformedSubtopic
+		}
+		if elem == multiWildcard && i != len(elems)-1 {
+			return "", ErrMalformedSubtopic
+		}
+	}
+
+	return subtopic, nil
+}
+
+func hasWildcard(subtopic string) bool {
+	for _, elem := range strings.Split(subtopic, subtopicSep) {
+		if elem == singleWildcard || elem == multiWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchSubtopic reports whether the subtopic matches the pattern, using the
+// wildcard semantics of subscriptions: "*" matches exactly one segment and
+// ">" matches one or more trailing segments. An empty pattern only matches
+// messages without a subtopic, and malformed patterns match nothing.
+func MatchSubtopic(pattern, subtopic string) bool {
+	pattern, err := NormalizeSubtopic(pattern)
+	if err != nil {
+		return false
+	}
+	if pattern == "" || subtopic == "" {
+		return pattern == subtopic
+	}
+
+	pelems := strings.Split(pattern, subtopicSep)
+	elems := strings.Split(subtopic, subtopicSep)
+	for i, pelem := range pelems {
+		if pelem == multiWildcard {
+			return len(elems) > i
+		}
+		if i >= len(elems) || (pelem != singleWildcard && pelem != elems[i]) {
+			return false
+		}
+	}
+
+	return len(pelems) == len(elems)
+}
+
+func illegalRune(r rune) bool {
+	return unicode.IsControl(r) || unicode.IsSpace(r)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSubtopic(t *testing.T) {
+	cases := []struct {
+		desc     string
+		subtopic string
+		expected string
+		err      error
+	}{
+		{
+			desc:     "empty subtopic",
+			subtopic: "",
+			expected: "",
+		},
+		{
+			desc:     "single separator",
+			subtopic: "/",
+			expected: "",
+		},
+		{
+			desc:     "single segment",
+			subtopic: "temperature",
+			expected: "temperature",
+		},
+		{
+			desc:     "dot separated segments",
+			subtopic: "room.1.temperature",
+			expected: "room.1.temperature",
+		},
+		{
+			desc:     "slash separated segments",
+			subtopic: "room/1/temperature",
+			expected: "room.1.temperature",
+		},
+		{
+			desc:     "mixed separators",
+			subtopic: "room/1.temperature",
+			expected: "room.1.temperature",
+		},
+		{
+			desc:     "leading separator",
+			subtopic: "/room/1",
+			expected: "room.1",
+		},
+		{
+			desc:     "trailing separator",
+			subtopic: "room.1.",
+			expected: "room.1",
+		},
+		{
+			desc:     "leading and trailing separators",
+			subtopic: "/room/1/",
+			expected: "room.1",
+		},
+		{
+			desc:     "single wildcard segment",
+			subtopic: "room/*/temperature",
+			expected: "room.*.temperature",
+		},
+		{
+			desc:     "multi wildcard last segment",
+			subtopic: "room/>",
+			expected: "room.>",
+		},
+		{
+			desc:     "empty segment",
+			subtopic: "room//1",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "empty segment with mixed separators",
+			subtopic: "room./1",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "repeated leading separators",
+			subtopic: "//room",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "repeated trailing separators",
+			subtopic: "room..",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "only separators",
+			subtopic: "/./",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "whitespace",
+			subtopic: "room 1",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "control character",
+			subtopic: "room\x00/1",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "line break",
+			subtopic: "room/1\n",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "invalid UTF-8",
+			subtopic: "room/\xff",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "partial single wildcard",
+			subtopic: "room/temp*",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "partial multi wildcard",
+			subtopic: "room/>temp",
+			err:      coap.ErrMalformedSubtopic,
+		},
+		{
+			desc:     "multi wildcard not last",
+			subtopic: "room/>/temperature",
+			err:      coap.ErrMalformedSubtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			subtopic, err := coap.NormalizeSubtopic(tc.subtopic)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.expected, subtopic, fmt.Sprintf("%s: expected subtopic %q got %q", tc.desc, tc.expected, subtopic))
+		})
+	}
+}
+
+func TestMatchSubtopic(t *testing.T) {
+	cases := []struct {
+		desc     string
+		pattern  string
+		subtopic string
+		match    bool
+	}{
+		{
+			desc:     "exact subtopic",
+			pattern:  "room.1.temperature",
+			subtopic: "room.1.temperature",
+			match:    true,
+		},
+		{
+			desc:     "slash separated pattern",
+			pattern:  "room/1/temperature",
+			subtopic: "room.1.temperature",
+			match:    true,
+		},
+		{
+			desc:     "different subtopic",
+			pattern:  "room.1.temperature",
+			subtopic: "room.1.humidity",
+		},
+		{
+			desc:     "longer subtopic",
+			pattern:  "room.1",
+			subtopic: "room.1.temperature",
+		},
+		{
+			desc:     "single wildcard",
+			pattern:  "room.*.temperature",
+			subtopic: "room.1.temperature",
+			match:    true,
+		},
+		{
+			desc:     "single wildcard with more segments",
+			pattern:  "room.*",
+			subtopic: "room.1.temperature",
+		},
+		{
+			desc:     "multi wildcard",
+			pattern:  "room.>",
+			subtopic: "room.1.temperature",
+			match:    true,
+		},
+		{
+			desc:     "multi wildcard without trailing segments",
+			pattern:  "room.>",
+			subtopic: "room",
+		},
+		{
+			desc:     "empty pattern and subtopic",
+			pattern:  "",
+			subtopic: "",
+			match:    true,
+		},
+		{
+			desc:     "empty pattern",
+			pattern:  "",
+			subtopic: "room",
+		},
+		{
+			desc:     "multi wildcard and empty subtopic",
+			pattern:  ">",
+			subtopic: "",
+		},
+		{
+			desc:     "malformed pattern",
+			pattern:  "room.>.temperature",
+			subtopic: "room.1.temperature",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			match := coap.MatchSubtopic(tc.pattern, tc.subtopic)
+			assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected match %t got %t", tc.desc, tc.match, match))
+		})
+	}
+}
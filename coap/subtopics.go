@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrSubtopicNotAllowed indicates that the thing is not allowed to publish
+// to the subtopic.
+var ErrSubtopicNotAllowed = errors.New("thing is not allowed to publish to the subtopic")
+
+// SubtopicRepository retrieves the subtopics things are allowed to publish to.
+type SubtopicRepository interface {
+	// RetrieveAllowedSubtopics retrieves the subtopic patterns the thing is
+	// allowed to publish to. An empty list allows all the subtopics.
+	RetrieveAllowedSubtopics(ctx context.Context, thingID string) ([]string, error)
+}
+
+type subtopicCache struct {
+	repo    SubtopicRepository
+	entries *ttlCache[[]string]
+}
+
+var _ SubtopicRepository = (*subtopicCache)(nil)
+
+// NewSubtopicCache returns a subtopic repository which keeps the allowed
+// subtopics retrieved from the given repository for the ttl, so they are
+// not retrieved on every published message. At most size things are kept.
+func NewSubtopicCache(repo SubtopicRepository, ttl time.Duration, size int) SubtopicRepository {
+	return &subtopicCache{
+		repo:    repo,
+		entries: newTTLCache[[]string](ttl, size),
+	}
+}
+
+func (sc *subtopicCache) RetrieveAllowedSubtopics(ctx context.Context, thingID string) ([]string, error) {
+	if patterns, ok := sc.entries.get(thingID); ok {
+		return patterns, nil
+	}
+
+	patterns, err := sc.repo.RetrieveAllowedSubtopics(ctx, thingID)
+	if err != nil {
+		return nil, err
+	}
+	sc.entries.set(thingID, patterns)
+
+	return patterns, nil
+}
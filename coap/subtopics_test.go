@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type subtopicRepo struct {
+	patterns []string
+	err      error
+	calls    int
+}
+
+func (repo *subtopicRepo) RetrieveAllowedSubtopics(_ context.Context, _ string) ([]string, error) {
+	repo.calls++
+	if repo.err != nil {
+		return nil, repo.err
+	}
+
+	return repo.patterns, nil
+}
+
+func TestSubtopicCache(t *testing.T) {
+	cases := []struct {
+		desc     string
+		repo     *subtopicRepo
+		ttl      time.Duration
+		patterns []string
+		calls    int
+		err      error
+	}{
+		{
+			desc:     "retrieve allowed subtopics of restricted thing",
+			repo:     &subtopicRepo{patterns: []string{"room.>"}},
+			ttl:      time.Minute,
+			patterns: []string{"room.>"},
+			calls:    1,
+		},
+		{
+			desc:  "retrieve allowed subtopics of unrestricted thing",
+			repo:  &subtopicRepo{},
+			ttl:   time.Minute,
+			calls: 1,
+		},
+		{
+			desc:     "retrieve allowed subtopics with expired entries",
+			repo:     &subtopicRepo{patterns: []string{"room.>"}},
+			ttl:      0,
+			patterns: []string{"room.>"},
+			calls:    3,
+		},
+		{
+			desc:  "retrieve allowed subtopics with failing repository",
+			repo:  &subtopicRepo{err: errRetrieve},
+			ttl:   time.Minute,
+			calls: 3,
+			err:   errRetrieve,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cache := coap.NewSubtopicCache(tc.repo, tc.ttl, 10)
+			for i := 0; i < 3; i++ {
+				patterns, err := cache.RetrieveAllowedSubtopics(context.Background(), thingID)
+				assert.Equal(t, tc.patterns, patterns, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.patterns, patterns))
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.calls, tc.repo.calls, fmt.Sprintf("%s: expected %d repository calls got %d", tc.desc, tc.calls, tc.repo.calls))
+		})
+	}
+}
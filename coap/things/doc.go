@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package things contains the repository implementations reading channel
+// payload schemas and transforms and the subtopics things are allowed to
+// publish to from the metadata served by the things service.
+package things
@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// retrieveMetadata retrieves the metadata of the entity of the given type
+// and ID from the things service. Entities which don't exist have no
+// metadata.
+func retrieveMetadata(ctx context.Context, client magistrala.ThingsServiceClient, entityType, id string) (clients.Metadata, error) {
+	res, err := client.RetrieveMetadata(ctx, &magistrala.RetrieveMetadataReq{EntityType: entityType, Id: id})
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	var metadata clients.Metadata
+	if data := res.GetMetadata(); len(data) > 0 {
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+var _ coap.SchemaRepository = (*schemaRepo)(nil)
+
+type schemaRepo struct {
+	client magistrala.ThingsServiceClient
+}
+
+// New instantiates a things gRPC implementation of schema repository.
+func New(client magistrala.ThingsServiceClient) coap.SchemaRepository {
+	return &schemaRepo{
+		client: client,
+	}
+}
+
+func (repo schemaRepo) RetrieveSchema(ctx context.Context, chanID string) (groups.Schema, bool, error) {
+	metadata, err := retrieveMetadata(ctx, repo.client, auth.GroupType, chanID)
+	if err != nil {
+		return groups.Schema{}, false, err
+	}
+
+	return groups.SchemaFromMetadata(metadata)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/clients"
+)
+
+var _ coap.SubtopicRepository = (*subtopicRepo)(nil)
+
+type subtopicRepo struct {
+	client magistrala.ThingsServiceClient
+}
+
+// NewSubtopicRepository instantiates a things gRPC implementation of subtopic
+// repository.
+func NewSubtopicRepository(client magistrala.ThingsServiceClient) coap.SubtopicRepository {
+	return &subtopicRepo{
+		client: client,
+	}
+}
+
+func (repo subtopicRepo) RetrieveAllowedSubtopics(ctx context.Context, thingID string) ([]string, error) {
+	metadata, err := retrieveMetadata(ctx, repo.client, auth.ThingType, thingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.AllowedSubtopicsFromMetadata(metadata)
+}
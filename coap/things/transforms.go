@@ -0,0 +1,36 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+var _ coap.TransformRepository = (*transformRepo)(nil)
+
+type transformRepo struct {
+	client magistrala.ThingsServiceClient
+}
+
+// NewTransformRepository instantiates a things gRPC implementation of
+// transform repository.
+func NewTransformRepository(client magistrala.ThingsServiceClient) coap.TransformRepository {
+	return &transformRepo{
+		client: client,
+	}
+}
+
+func (repo transformRepo) RetrieveTransforms(ctx context.Context, chanID string) (groups.Pipeline, bool, error) {
+	metadata, err := retrieveMetadata(ctx, repo.client, auth.GroupType, chanID)
+	if err != nil {
+		return groups.Pipeline{}, false, err
+	}
+
+	return groups.TransformsFromMetadata(metadata)
+}
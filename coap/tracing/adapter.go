@@ -5,6 +5,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/coap"
 	"github.com/absmach/magistrala/pkg/messaging"
@@ -19,6 +20,7 @@ const (
 	publishOP     = "publish_op"
 	subscribeOP   = "subscribe_op"
 	unsubscribeOP = "unsubscribe_op"
+	drainOP       = "drain_op"
 )
 
 // tracingServiceMiddleware is a middleware implementation for tracing CoAP service operations using OpenTelemetry.
@@ -36,10 +38,12 @@ func New(tracer trace.Tracer, svc coap.Service) coap.Service {
 }
 
 // Publish traces a CoAP publish operation.
-func (tm *tracingServiceMiddleware) Publish(ctx context.Context, key string, msg *messaging.Message) error {
-	ctx, span := tm.tracer.Start(ctx, publishOP)
+func (tm *tracingServiceMiddleware) Publish(ctx context.Context, key string, msg *messaging.Message, confirm bool) error {
+	ctx, span := tm.tracer.Start(ctx, publishOP, trace.WithAttributes(
+		attribute.Bool("confirm", confirm),
+	))
 	defer span.End()
-	return tm.svc.Publish(ctx, key, msg)
+	return tm.svc.Publish(ctx, key, msg, confirm)
 }
 
 // Subscribe traces a CoAP subscribe operation.
@@ -61,3 +65,13 @@ func (tm *tracingServiceMiddleware) Unsubscribe(ctx context.Context, key, chanID
 	defer span.End()
 	return tm.svc.Unsubscribe(ctx, key, chanID, subptopic, token)
 }
+
+// Drain traces the drain of the CoAP subscriptions.
+func (tm *tracingServiceMiddleware) Drain(ctx context.Context, window time.Duration, notify bool) (int, error) {
+	ctx, span := tm.tracer.Start(ctx, drainOP, trace.WithAttributes(
+		attribute.String("window", window.String()),
+		attribute.Bool("notify", notify),
+	))
+	defer span.End()
+	return tm.svc.Drain(ctx, window, notify)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// TransformRepository retrieves the payload transforms of channels.
+type TransformRepository interface {
+	// RetrieveTransforms retrieves the transform pipeline of the channel.
+	// The returned flag reports whether the channel has transforms.
+	RetrieveTransforms(ctx context.Context, chanID string) (groups.Pipeline, bool, error)
+}
+
+type cachedTransforms struct {
+	pipeline groups.Pipeline
+	ok       bool
+}
+
+type transformCache struct {
+	repo    TransformRepository
+	entries *ttlCache[cachedTransforms]
+}
+
+var _ TransformRepository = (*transformCache)(nil)
+
+// NewTransformCache returns a transform repository which keeps the
+// pipelines retrieved from the given repository for the ttl, so they are
+// not built on every published message. Channels without transforms are
+// cached as well. At most size channels are kept.
+func NewTransformCache(repo TransformRepository, ttl time.Duration, size int) TransformRepository {
+	return &transformCache{
+		repo:    repo,
+		entries: newTTLCache[cachedTransforms](ttl, size),
+	}
+}
+
+func (tc *transformCache) RetrieveTransforms(ctx context.Context, chanID string) (groups.Pipeline, bool, error) {
+	if e, ok := tc.entries.get(chanID); ok {
+		return e.pipeline, e.ok, nil
+	}
+
+	pipeline, ok, err := tc.repo.RetrieveTransforms(ctx, chanID)
+	if err != nil {
+		return groups.Pipeline{}, false, err
+	}
+	tc.entries.set(chanID, cachedTransforms{pipeline: pipeline, ok: ok})
+
+	return pipeline, ok, nil
+}
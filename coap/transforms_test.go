@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/coap"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+)
+
+type transformRepo struct {
+	metadata clients.Metadata
+	err      error
+	calls    int
+}
+
+func (repo *transformRepo) RetrieveTransforms(_ context.Context, _ string) (groups.Pipeline, bool, error) {
+	repo.calls++
+	if repo.err != nil {
+		return groups.Pipeline{}, false, repo.err
+	}
+
+	return groups.TransformsFromMetadata(repo.metadata)
+}
+
+func TestTransformCache(t *testing.T) {
+	metadata := clients.Metadata{
+		groups.TransformsKey: []interface{}{
+			map[string]interface{}{"rename": map[string]interface{}{"t": "temperature"}},
+		},
+	}
+
+	cases := []struct {
+		desc  string
+		repo  *transformRepo
+		ttl   time.Duration
+		ok    bool
+		calls int
+		err   error
+	}{
+		{
+			desc:  "retrieve transforms of channel with transforms",
+			repo:  &transformRepo{metadata: metadata},
+			ttl:   time.Minute,
+			ok:    true,
+			calls: 1,
+		},
+		{
+			desc:  "retrieve transforms of channel without transforms",
+			repo:  &transformRepo{metadata: clients.Metadata{}},
+			ttl:   time.Minute,
+			ok:    false,
+			calls: 1,
+		},
+		{
+			desc:  "retrieve transforms with expired entries",
+			repo:  &transformRepo{metadata: metadata},
+			ttl:   0,
+			ok:    true,
+			calls: 3,
+		},
+		{
+			desc:  "retrieve transforms with failing repository",
+			repo:  &transformRepo{err: errRetrieve},
+			ttl:   time.Minute,
+			ok:    false,
+			calls: 3,
+			err:   errRetrieve,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cache := coap.NewTransformCache(tc.repo, tc.ttl, 10)
+			for i := 0; i < 3; i++ {
+				_, ok, err := cache.RetrieveTransforms(context.Background(), "chanID")
+				assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.ok, ok))
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.calls, tc.repo.calls, fmt.Sprintf("%s: expected %d repository calls got %d", tc.desc, tc.calls, tc.repo.calls))
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package forwarders contains the message forwarder, which delivers messages
+// published to channels with a forwarding configuration to external HTTP endpoints.
+package forwarders
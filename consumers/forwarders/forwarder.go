@@ -0,0 +1,202 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package forwarders
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/cenkalti/backoff/v4"
+)
+
+var (
+	errDelivery = errors.New("failed to deliver forwarded message")
+
+	// errPrivateTarget indicates that the forward target resolves to an
+	// address which is not publicly routable.
+	errPrivateTarget = errors.New("forward target address is not public")
+)
+
+// Config defines the options used to deliver forwarded messages.
+type Config struct {
+	Timeout       time.Duration `env:"TIMEOUT"        envDefault:"10s"`
+	MaxRetries    uint64        `env:"MAX_RETRIES"    envDefault:"5"`
+	RetryInterval time.Duration `env:"RETRY_INTERVAL" envDefault:"1s"`
+	CacheTTL      time.Duration `env:"CACHE_TTL"      envDefault:"1m"`
+	Workers       int           `env:"WORKERS"        envDefault:"100"`
+	AllowPrivate  bool          `env:"ALLOW_PRIVATE"  envDefault:"false"`
+}
+
+// Repository retrieves the forwarding configuration of channels.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// RetrieveForward retrieves the forwarding configuration of the channel.
+	// The returned flag reports whether the channel forwards messages, which
+	// disabled channels don't.
+	RetrieveForward(ctx context.Context, channelID string) (groups.Forward, bool, error)
+}
+
+var _ messaging.MessageHandler = (*forwarder)(nil)
+
+type entry struct {
+	forward   groups.Forward
+	ok        bool
+	expiresAt time.Time
+}
+
+type forwarder struct {
+	ctx    context.Context
+	repo   Repository
+	client *http.Client
+	cfg    Config
+	logger *slog.Logger
+	// workers holds a slot per delivery in progress.
+	workers chan struct{}
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// New returns a message handler which forwards messages published to channels
+// that have a forwarding configuration. Delivery happens in the background, so
+// slow or failing endpoints never block or drop the original publish, but at
+// most cfg.Workers messages are delivered at once; further messages wait for
+// a delivery to complete. Unless cfg.AllowPrivate is set, messages are only
+// delivered to publicly routable addresses.
+func New(ctx context.Context, repo Repository, cfg Config, logger *slog.Logger) messaging.MessageHandler {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = publicOnly
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	return &forwarder{
+		ctx:     ctx,
+		repo:    repo,
+		client:  &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		cfg:     cfg,
+		logger:  logger,
+		workers: make(chan struct{}, max(cfg.Workers, 1)),
+		entries: make(map[string]entry),
+	}
+}
+
+func (f *forwarder) Handle(msg *messaging.Message) error {
+	select {
+	case f.workers <- struct{}{}:
+	case <-f.ctx.Done():
+		return f.ctx.Err()
+	}
+	go func() {
+		defer func() { <-f.workers }()
+		f.forward(msg)
+	}()
+
+	return nil
+}
+
+func (f *forwarder) Cancel() error {
+	return nil
+}
+
+func (f *forwarder) forward(msg *messaging.Message) {
+	fwd, ok, err := f.retrieve(msg.GetChannel())
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to retrieve forward config of channel %s: %s", msg.GetChannel(), err))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = f.cfg.RetryInterval
+	op := func() error {
+		return f.deliver(fwd, msg)
+	}
+	if err := backoff.Retry(op, backoff.WithContext(backoff.WithMaxRetries(b, f.cfg.MaxRetries), f.ctx)); err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to forward message of channel %s to %s: %s", msg.GetChannel(), fwd.URL, err))
+	}
+}
+
+func (f *forwarder) deliver(fwd groups.Forward, msg *messaging.Message) error {
+	req, err := http.NewRequestWithContext(f.ctx, fwd.Method, fwd.URL, bytes.NewReader(msg.GetPayload()))
+	if err != nil {
+		return backoff.Permanent(errors.Wrap(errDelivery, err))
+	}
+	for k, v := range fwd.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		if stderrors.Is(err, errPrivateTarget) {
+			return backoff.Permanent(errors.Wrap(errDelivery, errPrivateTarget))
+		}
+		return errors.Wrap(errDelivery, err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode >= http.StatusInternalServerError, res.StatusCode == http.StatusTooManyRequests:
+		return errors.Wrap(errDelivery, errors.New(res.Status))
+	case res.StatusCode >= http.StatusBadRequest:
+		return backoff.Permanent(errors.Wrap(errDelivery, errors.New(res.Status)))
+	default:
+		return nil
+	}
+}
+
+// retrieve returns the forwarding configuration of the channel, caching it
+// for the configured TTL to avoid a lookup per message.
+func (f *forwarder) retrieve(channelID string) (groups.Forward, bool, error) {
+	f.mu.Lock()
+	e, cached := f.entries[channelID]
+	f.mu.Unlock()
+	if cached && time.Now().Before(e.expiresAt) {
+		return e.forward, e.ok, nil
+	}
+
+	fwd, ok, err := f.repo.RetrieveForward(f.ctx, channelID)
+	if err != nil {
+		return groups.Forward{}, false, err
+	}
+
+	f.mu.Lock()
+	f.entries[channelID] = entry{forward: fwd, ok: ok, expiresAt: time.Now().Add(f.cfg.CacheTTL)}
+	f.mu.Unlock()
+
+	return fwd, ok, nil
+}
+
+// publicOnly refuses connections to addresses which are not publicly
+// routable. It checks the resolved address rather than the target host, so
+// host names resolving to private addresses and redirects to them are
+// refused as well.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return errPrivateTarget
+	}
+
+	return nil
+}
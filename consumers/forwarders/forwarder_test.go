@@ -0,0 +1,164 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package forwarders_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/forwarders"
+	"github.com/absmach/magistrala/consumers/forwarders/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// The test servers listen on the loopback interface, so private targets are
+// allowed unless a test checks they are refused.
+var cfg = forwarders.Config{
+	Timeout:       time.Second,
+	MaxRetries:    3,
+	RetryInterval: time.Millisecond,
+	CacheTTL:      time.Minute,
+	Workers:       10,
+	AllowPrivate:  true,
+}
+
+func TestHandle(t *testing.T) {
+	cases := []struct {
+		desc     string
+		statuses []int
+		noFwd    bool
+		attempts int32
+	}{
+		{
+			desc:     "forward message successfully",
+			statuses: []int{http.StatusOK},
+			attempts: 1,
+		},
+		{
+			desc:     "forward message after server errors",
+			statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK},
+			attempts: 3,
+		},
+		{
+			desc:     "forward message with client error",
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			attempts: 1,
+		},
+		{
+			desc:     "forward message with persistent server errors",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			attempts: 4,
+		},
+		{
+			desc:     "forward message on channel without forward",
+			statuses: []int{http.StatusOK},
+			noFwd:    true,
+			attempts: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var attempts atomic.Int32
+			payload := []byte(`{"temperature":21}`)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				body, err := io.ReadAll(r.Body)
+				assert.Nil(t, err, fmt.Sprintf("unexpected error reading body: %s", err))
+				assert.Equal(t, payload, body)
+				assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+				w.WriteHeader(tc.statuses[int(n)-1])
+			}))
+			defer ts.Close()
+
+			repo := new(mocks.Repository)
+			fwd := groups.Forward{URL: ts.URL, Method: http.MethodPost, Headers: map[string]string{"X-Api-Key": "secret"}}
+			repo.On("RetrieveForward", mock.Anything, mock.Anything).Return(fwd, !tc.noFwd, nil)
+
+			f := forwarders.New(context.Background(), repo, cfg, mglog.NewMock())
+			msg := &messaging.Message{Channel: testsutil.GenerateUUID(t), Payload: payload}
+			err := f.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+
+			assert.Eventually(t, func() bool {
+				return attempts.Load() == tc.attempts
+			}, time.Second, 10*time.Millisecond, fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.attempts, attempts.Load()))
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, tc.attempts, attempts.Load(), fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.attempts, attempts.Load()))
+		})
+	}
+}
+
+func TestHandlePrivateTarget(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+	}))
+	defer ts.Close()
+
+	repo := new(mocks.Repository)
+	fwd := groups.Forward{URL: ts.URL, Method: http.MethodPost}
+	repo.On("RetrieveForward", mock.Anything, mock.Anything).Return(fwd, true, nil)
+
+	c := cfg
+	c.AllowPrivate = false
+	f := forwarders.New(context.Background(), repo, c, mglog.NewMock())
+	err := f.Handle(&messaging.Message{Channel: testsutil.GenerateUUID(t), Payload: []byte(`{}`)})
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), attempts.Load(), fmt.Sprintf("expected no delivery to a private target got %d", attempts.Load()))
+}
+
+func TestHandleWorkers(t *testing.T) {
+	release := make(chan struct{})
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		<-release
+	}))
+	defer ts.Close()
+
+	repo := new(mocks.Repository)
+	fwd := groups.Forward{URL: ts.URL, Method: http.MethodPost}
+	repo.On("RetrieveForward", mock.Anything, mock.Anything).Return(fwd, true, nil)
+
+	c := cfg
+	c.Workers = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	f := forwarders.New(ctx, repo, c, mglog.NewMock())
+	msg := &messaging.Message{Channel: testsutil.GenerateUUID(t), Payload: []byte(`{}`)}
+	err := f.Handle(msg)
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+
+	done := make(chan error)
+	go func() {
+		done <- f.Handle(msg)
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("expected the message to wait for a worker got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, int32(1), attempts.Load(), fmt.Sprintf("expected one delivery in progress got %d", attempts.Load()))
+
+	close(release)
+	assert.Nil(t, <-done, "expected the waiting message to be handled")
+	assert.Eventually(t, func() bool {
+		return attempts.Load() == 2
+	}, time.Second, 10*time.Millisecond, fmt.Sprintf("expected 2 deliveries got %d", attempts.Load()))
+
+	cancel()
+}
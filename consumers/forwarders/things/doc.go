@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package things contains the repository implementation reading channel
+// forwarding configuration from the things service.
+package things
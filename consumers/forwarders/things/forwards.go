@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/consumers/forwarders"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+var _ forwarders.Repository = (*forwardRepo)(nil)
+
+type forwardRepo struct {
+	client magistrala.ThingsServiceClient
+}
+
+// New instantiates a things gRPC implementation of forwarders repository.
+func New(client magistrala.ThingsServiceClient) forwarders.Repository {
+	return &forwardRepo{
+		client: client,
+	}
+}
+
+func (repo forwardRepo) RetrieveForward(ctx context.Context, channelID string) (groups.Forward, bool, error) {
+	res, err := repo.client.RetrieveMetadata(ctx, &magistrala.RetrieveMetadataReq{EntityType: auth.GroupType, Id: channelID})
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrNotFound) {
+			return groups.Forward{}, false, nil
+		}
+		return groups.Forward{}, false, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	// Disabled channels are reported as not forwarding, like removed ones.
+	if !res.GetEnabled() {
+		return groups.Forward{}, false, nil
+	}
+
+	var metadata clients.Metadata
+	if data := res.GetMetadata(); len(data) > 0 {
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return groups.Forward{}, false, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+
+	return groups.ForwardFromMetadata(metadata)
+}
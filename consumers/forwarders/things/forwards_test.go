@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package things_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/consumers/forwarders/things"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type thingsClient struct {
+	magistrala.ThingsServiceClient
+	res *magistrala.RetrieveMetadataRes
+	err error
+}
+
+func (tc thingsClient) RetrieveMetadata(_ context.Context, req *magistrala.RetrieveMetadataReq, _ ...grpc.CallOption) (*magistrala.RetrieveMetadataRes, error) {
+	if req.GetEntityType() != auth.GroupType {
+		return nil, errors.ErrMalformedEntity
+	}
+
+	return tc.res, tc.err
+}
+
+func TestRetrieveForward(t *testing.T) {
+	forward := []byte(`{"forward":{"url":"https://example.com/hook"}}`)
+
+	cases := []struct {
+		desc    string
+		res     *magistrala.RetrieveMetadataRes
+		err     error
+		forward groups.Forward
+		ok      bool
+		repoErr error
+	}{
+		{
+			desc:    "retrieve forward of enabled channel",
+			res:     &magistrala.RetrieveMetadataRes{Metadata: forward, Enabled: true},
+			forward: groups.Forward{URL: "https://example.com/hook", Method: "POST"},
+			ok:      true,
+		},
+		{
+			desc: "retrieve forward of disabled channel",
+			res:  &magistrala.RetrieveMetadataRes{Metadata: forward},
+		},
+		{
+			desc: "retrieve forward of channel without metadata",
+			res:  &magistrala.RetrieveMetadataRes{Enabled: true},
+		},
+		{
+			desc: "retrieve forward of unknown channel",
+			err:  errors.Wrap(svcerr.ErrNotFound, repoerr.ErrNotFound),
+		},
+		{
+			desc:    "retrieve forward with failed request",
+			err:     svcerr.ErrServiceUnavailable,
+			repoErr: repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := things.New(thingsClient{res: tc.res, err: tc.err})
+			forward, ok, err := repo.RetrieveForward(context.Background(), "channel")
+			assert.True(t, errors.Contains(err, tc.repoErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.repoErr, err))
+			assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.ok, ok))
+			assert.Equal(t, tc.forward, forward, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.forward, forward))
+		})
+	}
+}
@@ -4,15 +4,19 @@
 package magistrala
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
 const (
 	contentType     = "Content-Type"
 	contentTypeJSON = "application/health+json"
 	svcStatus       = "pass"
+	svcFailStatus   = "fail"
 	description     = " service"
+	checkTimeout    = 5 * time.Second
 )
 
 var (
@@ -51,6 +55,36 @@ type HealthInfo struct {
 	InstanceID string `json:"instance_id"`
 }
 
+// HealthCheck reports whether a service dependency is reachable.
+type HealthCheck func(ctx context.Context) error
+
+// DependencyHealth contains the status of a single service dependency.
+type DependencyHealth struct {
+	// Status contains dependency status.
+	Status string `json:"status"`
+
+	// Latency contains the duration of the dependency check.
+	Latency string `json:"latency"`
+
+	// Error contains the reason of the failed check.
+	Error string `json:"error,omitempty"`
+}
+
+// ReadinessInfo contains readiness endpoint response.
+type ReadinessInfo struct {
+	// Status contains service readiness status.
+	Status string `json:"status"`
+
+	// Description contains service description.
+	Description string `json:"description"`
+
+	// InstanceID contains the ID of the current service instance
+	InstanceID string `json:"instance_id"`
+
+	// Checks contains the status of each service dependency.
+	Checks map[string]DependencyHealth `json:"checks"`
+}
+
 // Health exposes an HTTP handler for retrieving service health.
 func Health(service, instanceID string) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -76,3 +110,49 @@ func Health(service, instanceID string) http.HandlerFunc {
 		}
 	})
 }
+
+// Ready exposes an HTTP handler for retrieving service readiness. The service is
+// ready only if all the dependency checks pass, otherwise 503 is returned.
+func Ready(service, instanceID string, checks map[string]HealthCheck) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(contentType, contentTypeJSON)
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		res := ReadinessInfo{
+			Status:      svcStatus,
+			Description: service + description,
+			InstanceID:  instanceID,
+			Checks:      make(map[string]DependencyHealth, len(checks)),
+		}
+		for name, check := range checks {
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			begin := time.Now()
+			err := check(ctx)
+			cancel()
+
+			dep := DependencyHealth{
+				Status:  svcStatus,
+				Latency: time.Since(begin).String(),
+			}
+			if err != nil {
+				dep.Status = svcFailStatus
+				dep.Error = err.Error()
+				res.Status = svcFailStatus
+			}
+			res.Checks[name] = dep
+		}
+
+		code := http.StatusOK
+		if res.Status != svcStatus {
+			code = http.StatusServiceUnavailable
+		}
+		w.WriteHeader(code)
+
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
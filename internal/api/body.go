@@ -0,0 +1,57 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// BodyLimits configures the maximum sizes of the request bodies, in bytes.
+type BodyLimits struct {
+	// Entity limits the bodies of the requests of a single entity.
+	Entity int64 `env:"MAX_BODY_SIZE"      envDefault:"1048576"`
+	// Bulk limits the bodies of the requests of many entities, such as
+	// bulk creation.
+	Bulk int64 `env:"MAX_BULK_BODY_SIZE" envDefault:"16777216"`
+}
+
+// LimitBodySize returns a request function which limits the request bodies
+// to limit bytes. Requests declaring a larger body are rejected before the
+// body is read, while reading past the limit of the other ones fails with
+// ErrPayloadTooLarge, so oversized bodies are never buffered as a whole.
+// When run after DecodeContentEncoding, the limit applies to decompressed
+// bodies. Zero limit disables the check.
+func LimitBodySize(limit int64) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		switch {
+		case limit <= 0:
+		case r.ContentLength > limit:
+			r.Body = errBody{err: apiutil.ErrPayloadTooLarge}
+		default:
+			r.Body = limitedBody{ReadCloser: http.MaxBytesReader(nil, r.Body, limit)}
+		}
+
+		return ctx
+	}
+}
+
+type limitedBody struct {
+	io.ReadCloser
+}
+
+func (lb limitedBody) Read(p []byte) (int, error) {
+	n, err := lb.ReadCloser.Read(p)
+	var mbe *http.MaxBytesError
+	if stderrors.As(err, &mbe) {
+		return n, apiutil.ErrPayloadTooLarge
+	}
+
+	return n, err
+}
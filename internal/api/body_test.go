@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitBodySize(t *testing.T) {
+	body := `{"name":"thing"}`
+	large := strings.Repeat("a", 1024)
+
+	cases := []struct {
+		desc            string
+		body            []byte
+		limit           int64
+		chunked         bool
+		contentEncoding string
+		err             error
+	}{
+		{
+			desc:  "limit body under the limit",
+			body:  []byte(body),
+			limit: 64,
+		},
+		{
+			desc:  "limit body of the limit size",
+			body:  []byte(body),
+			limit: int64(len(body)),
+		},
+		{
+			desc:  "limit body declaring size over the limit",
+			body:  []byte(large),
+			limit: 64,
+			err:   apiutil.ErrPayloadTooLarge,
+		},
+		{
+			desc:    "limit chunked body over the limit",
+			body:    []byte(large),
+			limit:   64,
+			chunked: true,
+			err:     apiutil.ErrPayloadTooLarge,
+		},
+		{
+			desc:            "limit compressed body decompressed over the limit",
+			body:            gzipped(t, large),
+			limit:           64,
+			contentEncoding: "gzip",
+			err:             apiutil.ErrPayloadTooLarge,
+		},
+		{
+			desc:  "limit body with limit disabled",
+			body:  []byte(large),
+			limit: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodPost, "/things", bytes.NewReader(tc.body))
+		if tc.chunked {
+			r.ContentLength = -1
+		}
+		ctx := context.Background()
+		if tc.contentEncoding != "" {
+			r.Header.Set("Content-Encoding", tc.contentEncoding)
+			ctx = api.DecodeContentEncoding(ctx, r)
+		}
+
+		api.LimitBodySize(tc.limit)(ctx, r)
+		_, err := io.ReadAll(r.Body)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestEncodePayloadTooLarge(t *testing.T) {
+	w := httptest.NewRecorder()
+	api.EncodeError(context.Background(), errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrPayloadTooLarge)), w)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, fmt.Sprintf("expected status code %d got %d", http.StatusRequestEntityTooLarge, w.Code))
+	assert.Contains(t, w.Body.String(), apiutil.ErrPayloadTooLarge.Error())
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/absmach/magistrala/bootstrap"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// errorCodes maps the errors returned by the services to stable,
+// machine-readable codes. Specific errors precede the generic ones they are
+// usually wrapped with, since the first error contained in the response
+// error which is encoded with the same HTTP status determines the code.
+var errorCodes = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{apiutil.ErrBearerToken, http.StatusUnauthorized, "missing_bearer_token"},
+	{apiutil.ErrBearerKey, http.StatusBadRequest, "missing_bearer_key"},
+	{apiutil.ErrMissingID, http.StatusBadRequest, "missing_id"},
+	{apiutil.ErrMissingName, http.StatusBadRequest, "missing_name"},
+	{apiutil.ErrMissingAlias, http.StatusBadRequest, "missing_alias"},
+	{apiutil.ErrMissingEmail, http.StatusBadRequest, "missing_email"},
+	{apiutil.ErrMissingHost, http.StatusBadRequest, "missing_host"},
+	{apiutil.ErrMissingSecret, http.StatusBadRequest, "missing_secret"},
+	{apiutil.ErrMissingIdentity, http.StatusBadRequest, "missing_identity"},
+	{apiutil.ErrMissingPass, http.StatusBadRequest, "missing_password"},
+	{apiutil.ErrMissingConfPass, http.StatusBadRequest, "missing_password_confirmation"},
+	{apiutil.ErrMissingMemberKind, http.StatusBadRequest, "missing_member_kind"},
+	{apiutil.ErrMissingMemberType, http.StatusBadRequest, "missing_member_type"},
+	{apiutil.ErrMissingRelation, http.StatusBadRequest, "missing_relation"},
+	{apiutil.ErrMissingEntityType, http.StatusBadRequest, "missing_entity_type"},
+	{apiutil.ErrMissingCertData, http.StatusBadRequest, "missing_cert_data"},
+	{apiutil.ErrMalformedPolicy, http.StatusBadRequest, "malformed_policy"},
+	{apiutil.ErrInvalidResetPass, http.StatusBadRequest, "invalid_reset_password"},
+	{apiutil.ErrEmptyList, http.StatusBadRequest, "empty_list"},
+	{apiutil.ErrLimitSize, http.StatusBadRequest, "invalid_limit"},
+	{apiutil.ErrNameSize, http.StatusBadRequest, "invalid_name_size"},
+	{apiutil.ErrInvalidIDFormat, http.StatusBadRequest, "invalid_id_format"},
+	{apiutil.ErrInvalidQueryParams, http.StatusBadRequest, "invalid_query_params"},
+	{apiutil.ErrPasswordFormat, http.StatusBadRequest, "invalid_password_format"},
+	{apiutil.ErrInvitationState, http.StatusBadRequest, "invalid_invitation_state"},
+	{apiutil.ErrInvalidAPIKey, http.StatusBadRequest, "invalid_api_key"},
+	{apiutil.ErrBootstrapState, http.StatusBadRequest, "invalid_bootstrap_state"},
+	{apiutil.ErrInvalidContact, http.StatusBadRequest, "invalid_contact"},
+	{apiutil.ErrInvalidTopic, http.StatusBadRequest, "invalid_topic"},
+	{apiutil.ErrInvalidCertData, http.StatusBadRequest, "invalid_cert_data"},
+	{apiutil.ErrEmptyMessage, http.StatusBadRequest, "empty_message"},
+	{apiutil.ErrInvalidLevel, http.StatusBadRequest, "invalid_level"},
+	{apiutil.ErrInvalidDirection, http.StatusBadRequest, "invalid_direction"},
+	{apiutil.ErrInvalidOrder, http.StatusBadRequest, "invalid_order"},
+	{apiutil.ErrInvalidEntityType, http.StatusBadRequest, "invalid_entity_type"},
+	{apiutil.ErrInvalidTimeFormat, http.StatusBadRequest, "invalid_time_format"},
+	{apiutil.ErrInvalidTTL, http.StatusBadRequest, "invalid_ttl"},
+	{apiutil.ErrInvalidScope, http.StatusBadRequest, "invalid_scope"},
+	{apiutil.ErrUnsupportedContentType, http.StatusUnsupportedMediaType, "unsupported_content_type"},
+	{apiutil.ErrMetadataTooLarge, http.StatusRequestEntityTooLarge, "metadata_too_large"},
+	{apiutil.ErrPayloadTooLarge, http.StatusRequestEntityTooLarge, "payload_too_large"},
+	{mgclients.ErrWeakKey, http.StatusBadRequest, "weak_key"},
+	{mgclients.ErrInvalidMetadataFilter, http.StatusBadRequest, "invalid_metadata_filter"},
+	{mgclients.ErrMetadataFilterTooComplex, http.StatusBadRequest, "metadata_filter_too_complex"},
+	{svcerr.ErrInvalidStatus, http.StatusBadRequest, "invalid_status"},
+	{svcerr.ErrInvalidRole, http.StatusBadRequest, "invalid_role"},
+	{svcerr.ErrInvalidPolicy, http.StatusBadRequest, "invalid_policy"},
+	{groups.ErrInvalidRole, http.StatusBadRequest, "invalid_group_role"},
+	{groups.ErrBlueprintVersion, http.StatusBadRequest, "incompatible_blueprint_version"},
+	{groups.ErrParentDomain, http.StatusBadRequest, "invalid_parent_domain"},
+	{groups.ErrTargetDomain, http.StatusBadRequest, "invalid_target_domain"},
+	{groups.ErrTargetParent, http.StatusBadRequest, "invalid_target_parent"},
+	{groups.ErrSameDomain, http.StatusBadRequest, "same_domain"},
+	{groups.ErrGroupHasChildren, http.StatusConflict, "group_has_children"},
+	{groups.ErrNameConflict, http.StatusConflict, "name_conflict"},
+	{groups.ErrSharedThing, http.StatusConflict, "shared_thing"},
+	{groups.ErrConnectionLimitExceeded, http.StatusConflict, "connection_limit_exceeded"},
+	{bootstrap.ErrExternalKey, http.StatusForbidden, "invalid_external_key"},
+	{bootstrap.ErrExternalKeySecure, http.StatusForbidden, "invalid_external_key_secure"},
+	{bootstrap.ErrAddBootstrap, http.StatusBadRequest, "add_bootstrap_failed"},
+	{bootstrap.ErrBootstrap, http.StatusNotFound, "bootstrap_not_found"},
+	{errors.ErrStatusAlreadyAssigned, http.StatusConflict, "status_already_assigned"},
+	{svcerr.ErrServiceUnavailable, http.StatusServiceUnavailable, "service_unavailable"},
+	{svcerr.ErrRateLimited, http.StatusTooManyRequests, "rate_limited"},
+	{svcerr.ErrLogin, http.StatusUnauthorized, "invalid_credentials"},
+	{svcerr.ErrKeyExpired, http.StatusUnauthorized, "key_expired"},
+	{svcerr.ErrThingDisabled, http.StatusForbidden, "thing_disabled"},
+	{svcerr.ErrTokenScope, http.StatusForbidden, "token_scope_denied"},
+	{svcerr.ErrDomainAuthorization, http.StatusForbidden, "domain_authorization_failed"},
+	{svcerr.ErrAuthorization, http.StatusForbidden, "authorization_failed"},
+	{svcerr.ErrAuthentication, http.StatusUnauthorized, "authentication_failed"},
+	{svcerr.ErrPreconditionFailed, http.StatusPreconditionFailed, "precondition_failed"},
+	{svcerr.ErrConflict, http.StatusConflict, "conflict"},
+	{svcerr.ErrNotFound, http.StatusNotFound, "not_found"},
+	{svcerr.ErrEnableClient, http.StatusUnprocessableEntity, "enable_client_failed"},
+	{svcerr.ErrCreateEntity, http.StatusUnprocessableEntity, "create_entity_failed"},
+	{svcerr.ErrUpdateEntity, http.StatusUnprocessableEntity, "update_entity_failed"},
+	{svcerr.ErrRemoveEntity, http.StatusUnprocessableEntity, "remove_entity_failed"},
+	{svcerr.ErrViewEntity, http.StatusBadRequest, "view_entity_failed"},
+	{svcerr.ErrMalformedEntity, http.StatusBadRequest, "malformed_entity"},
+	{errors.ErrMalformedEntity, http.StatusBadRequest, "malformed_entity"},
+	{apiutil.ErrValidation, http.StatusBadRequest, "validation_failed"},
+}
+
+// statusCodes provides the codes of errors without a specific code.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusConflict:              "conflict",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "request_entity_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusUnprocessableEntity:   "unprocessable_entity",
+	http.StatusServiceUnavailable:    "service_unavailable",
+}
+
+// ErrorCode returns the machine-readable code of the error encoded with
+// the given HTTP status code.
+func ErrorCode(err error, status int) string {
+	for _, ec := range errorCodes {
+		if ec.status == status && errors.Contains(err, ec.err) {
+			return ec.code
+		}
+	}
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+
+	return "internal_error"
+}
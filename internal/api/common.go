@@ -14,18 +14,22 @@ import (
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	MemberKindKey    = "member_kind"
 	PermissionKey    = "permission"
+	MyRoleKey        = "my_role"
 	RelationKey      = "relation"
 	StatusKey        = "status"
 	OffsetKey        = "offset"
 	OrderKey         = "order"
 	LimitKey         = "limit"
 	MetadataKey      = "metadata"
+	MetaFilterKey    = "metadata_filter"
 	ParentKey        = "parent_id"
 	OwnerKey         = "owner_id"
 	ClientKey        = "client"
@@ -44,6 +48,18 @@ const (
 	VisibilityKey    = "visibility"
 	SharedByKey      = "shared_by"
 	TokenKey         = "token"
+	CascadeKey       = "cascade"
+	FieldKey         = "field"
+	MergeKey         = "merge"
+	PartialKey       = "partial"
+	CountOnlyKey     = "count_only"
+	UpdatedSinceKey  = "updated_since"
+	SinceIDKey       = "updated_since_id"
+	InactiveSinceKey = "inactive_since"
+	WaitKey          = "wait"
+	EmbedKey         = "embed"
+	FieldsKey        = "fields"
+	KeysKey          = "keys"
 	DefPermission    = "view"
 	DefTotal         = uint64(100)
 	DefOffset        = 0
@@ -55,11 +71,23 @@ const (
 	DefClientStatus  = mgclients.Enabled
 	DefGroupStatus   = mgclients.Enabled
 	DefListPerms     = false
+	DefCascade       = false
+	DefMerge         = false
+	DefPartial       = false
+	DefCountOnly     = false
+	DefKeys          = false
 	SharedVisibility = "shared"
 	MyVisibility     = "mine"
 	AllVisibility    = "all"
+	ChannelsEmbed    = "channels"
 	// ContentType represents JSON content type.
 	ContentType = "application/json"
+	// MergePatchContentType represents JSON merge patch content type.
+	MergePatchContentType = "application/merge-patch+json"
+
+	// TraceIDHeader is the error response header holding the ID of the
+	// trace of the failed request.
+	TraceIDHeader = "X-Trace-ID"
 
 	// MaxNameSize limits name size to prevent making them too complex.
 	MaxLimitSize = 100
@@ -80,9 +108,16 @@ func ValidateUUID(extID string) (err error) {
 	return nil
 }
 
-// EncodeResponse encodes successful response.
-func EncodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
-	if ar, ok := response.(magistrala.Response); ok {
+// EncodeResponse encodes successful response. The response is gzip
+// encoded if DecodeContentEncoding found that the request accepts it.
+func EncodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	ar, ok := response.(magistrala.Response)
+	gzipped := compressResponse(ctx) && !(ok && ar.Empty())
+	if gzipped {
+		w.Header().Set("Content-Encoding", gzipEncoding)
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+	if ok {
 		for k, v := range ar.Headers() {
 			w.Header().Set(k, v)
 		}
@@ -94,30 +129,70 @@ func EncodeResponse(_ context.Context, w http.ResponseWriter, response interface
 		}
 	}
 
+	if gzipped {
+		return encodeGzip(w, response)
+	}
+
 	return json.NewEncoder(w).Encode(response)
 }
 
-// EncodeError encodes an error response.
-func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
+// errorRes represents the body of an error response. Code is a stable,
+// machine-readable identifier of the error, while the error and message
+// are meant for humans.
+type errorRes struct {
+	Err  string `json:"error"`
+	Msg  string `json:"message"`
+	Code string `json:"code"`
+}
+
+// EncodeError encodes an error response. If the request is traced, the
+// trace ID is set in the TraceIDHeader so failures can be correlated with
+// their traces.
+func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	origErr := err
 	var wrapper error
 	if errors.Contains(err, apiutil.ErrValidation) {
 		wrapper, err = errors.Unwrap(err)
 	}
 
 	w.Header().Set("Content-Type", ContentType)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		w.Header().Set(TraceIDHeader, sc.TraceID().String())
+	}
+	status := http.StatusInternalServerError
 	switch {
+	case errors.Contains(err, svcerr.ErrServiceUnavailable):
+		// Failures of the services the request depends on are usually wrapped
+		// as authentication or authorization errors, which would be misleading.
+		err = svcerr.ErrServiceUnavailable
+		status = http.StatusServiceUnavailable
+
+	case errors.Contains(err, svcerr.ErrRateLimited):
+		err = svcerr.ErrRateLimited
+		status = http.StatusTooManyRequests
+
 	case errors.Contains(err, svcerr.ErrAuthorization),
 		errors.Contains(err, svcerr.ErrDomainAuthorization),
 		errors.Contains(err, bootstrap.ErrExternalKey),
 		errors.Contains(err, bootstrap.ErrExternalKeySecure):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusForbidden)
+		status = http.StatusForbidden
 
 	case errors.Contains(err, svcerr.ErrAuthentication),
 		errors.Contains(err, apiutil.ErrBearerToken),
-		errors.Contains(err, svcerr.ErrLogin):
+		errors.Contains(err, svcerr.ErrLogin),
+		errors.Contains(err, svcerr.ErrKeyExpired):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusUnauthorized)
+		status = http.StatusUnauthorized
+	case errors.Contains(err, apiutil.ErrPayloadTooLarge):
+		// Bodies are decoded as malformed entities, which would be
+		// reported as bad requests.
+		err = apiutil.ErrPayloadTooLarge
+		status = http.StatusRequestEntityTooLarge
+	case errors.Contains(err, mgclients.ErrWeakKey):
+		// The error is not unwrapped, so the response describes the key
+		// policy requirements and clients can correct the key.
+		status = http.StatusBadRequest
 	case errors.Contains(err, svcerr.ErrMalformedEntity),
 		errors.Contains(err, apiutil.ErrMalformedPolicy),
 		errors.Contains(err, apiutil.ErrMissingSecret),
@@ -157,43 +232,71 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrEmptyMessage),
 		errors.Contains(err, apiutil.ErrInvalidLevel),
 		errors.Contains(err, apiutil.ErrInvalidDirection),
+		errors.Contains(err, apiutil.ErrInvalidOrder),
 		errors.Contains(err, apiutil.ErrInvalidEntityType),
 		errors.Contains(err, apiutil.ErrMissingEntityType),
-		errors.Contains(err, apiutil.ErrInvalidTimeFormat):
+		errors.Contains(err, apiutil.ErrInvalidTimeFormat),
+		errors.Contains(err, apiutil.ErrInvalidTTL),
+		errors.Contains(err, apiutil.ErrInvalidScope),
+		errors.Contains(err, mgclients.ErrInvalidMetadataFilter),
+		errors.Contains(err, mgclients.ErrMetadataFilterTooComplex),
+		errors.Contains(err, groups.ErrParentDomain),
+		errors.Contains(err, groups.ErrTargetDomain),
+		errors.Contains(err, groups.ErrTargetParent),
+		errors.Contains(err, groups.ErrSameDomain),
+		errors.Contains(err, groups.ErrInvalidRole),
+		errors.Contains(err, groups.ErrBlueprintVersion):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusBadRequest)
+		status = http.StatusBadRequest
 
 	case errors.Contains(err, svcerr.ErrCreateEntity),
 		errors.Contains(err, svcerr.ErrUpdateEntity),
 		errors.Contains(err, svcerr.ErrRemoveEntity),
 		errors.Contains(err, svcerr.ErrEnableClient):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		status = http.StatusUnprocessableEntity
 
 	case errors.Contains(err, svcerr.ErrNotFound),
 		errors.Contains(err, bootstrap.ErrBootstrap):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusNotFound)
+		status = http.StatusNotFound
 
 	case errors.Contains(err, errors.ErrStatusAlreadyAssigned),
-		errors.Contains(err, svcerr.ErrConflict):
+		errors.Contains(err, svcerr.ErrConflict),
+		errors.Contains(err, groups.ErrGroupHasChildren),
+		errors.Contains(err, groups.ErrNameConflict),
+		errors.Contains(err, groups.ErrConnectionLimitExceeded),
+		errors.Contains(err, groups.ErrSharedThing):
+		err = unwrap(err)
+		status = http.StatusConflict
+
+	case errors.Contains(err, svcerr.ErrPreconditionFailed):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusConflict)
+		status = http.StatusPreconditionFailed
 
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		err = unwrap(err)
-		w.WriteHeader(http.StatusUnsupportedMediaType)
+		status = http.StatusUnsupportedMediaType
 
-	default:
-		w.WriteHeader(http.StatusInternalServerError)
+	case errors.Contains(err, apiutil.ErrMetadataTooLarge):
+		err = unwrap(err)
+		status = http.StatusRequestEntityTooLarge
 	}
+	w.WriteHeader(status)
 
 	if wrapper != nil {
 		err = errors.Wrap(wrapper, err)
 	}
 
 	if errorVal, ok := err.(errors.Error); ok {
-		if err := json.NewEncoder(w).Encode(errorVal); err != nil {
+		res := errorRes{
+			Msg:  errorVal.Msg(),
+			Code: ErrorCode(origErr, status),
+		}
+		if e := errorVal.Err(); e != nil {
+			res.Err = e.Msg()
+		}
+		if err := json.NewEncoder(w).Encode(res); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
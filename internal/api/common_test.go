@@ -6,7 +6,9 @@ package api_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,9 +16,14 @@ import (
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/internal/testsutil"
 	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 var _ magistrala.Response = (*response)(nil)
@@ -81,6 +88,7 @@ func (res response) Empty() bool {
 type body struct {
 	Error   string `json:"error,omitempty"`
 	Message string `json:"message"`
+	Code    string `json:"code"`
 }
 
 func TestValidateUUID(t *testing.T) {
@@ -322,6 +330,7 @@ func TestEncodeError(t *testing.T) {
 				message := body{}
 				jerr := json.Unmarshal(responseWriter.Body(), &message)
 				assert.NoError(t, jerr)
+				assert.NotEmpty(t, message.Code)
 
 				var wrapper error
 				switch errors.Contains(err, apiutil.ErrValidation) {
@@ -336,3 +345,135 @@ func TestEncodeError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorCode(t *testing.T) {
+	cases := []struct {
+		desc   string
+		err    error
+		status int
+		code   string
+	}{
+		{
+			desc:   "validation error with specific error",
+			err:    errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID),
+			status: http.StatusBadRequest,
+			code:   "missing_id",
+		},
+		{
+			desc:   "validation error with wrapped specific error",
+			err:    errors.Wrap(apiutil.ErrValidation, errors.Wrap(apiutil.ErrInvalidQueryParams, errors.New("test"))),
+			status: http.StatusBadRequest,
+			code:   "invalid_query_params",
+		},
+		{
+			desc:   "authorization error wrapping not found error",
+			err:    errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrNotFound),
+			status: http.StatusForbidden,
+			code:   "authorization_failed",
+		},
+		{
+			desc:   "create entity error wrapping conflict error",
+			err:    errors.Wrap(svcerr.ErrCreateEntity, svcerr.ErrConflict),
+			status: http.StatusUnprocessableEntity,
+			code:   "create_entity_failed",
+		},
+		{
+			desc:   "validation error with metadata too large error",
+			err:    errors.Wrap(apiutil.ErrValidation, apiutil.ErrMetadataTooLarge),
+			status: http.StatusRequestEntityTooLarge,
+			code:   "metadata_too_large",
+		},
+		{
+			desc:   "weak key error",
+			err:    mgclients.KeyPolicy{MinLength: 16}.Check("weak"),
+			status: http.StatusBadRequest,
+			code:   "weak_key",
+		},
+		{
+			desc:   "authorization error wrapping service unavailable error",
+			err:    errors.Wrap(svcerr.ErrAuthorization, errors.Wrap(svcerr.ErrServiceUnavailable, errors.New("circuit breaker is open"))),
+			status: http.StatusServiceUnavailable,
+			code:   "service_unavailable",
+		},
+		{
+			desc:   "unknown error",
+			err:    errors.New("test"),
+			status: http.StatusInternalServerError,
+			code:   "internal_error",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			responseWriter := newResponseWriter()
+			api.EncodeError(context.Background(), c.err, responseWriter)
+			assert.Equal(t, c.status, responseWriter.StatusCode())
+
+			message := body{}
+			err := json.Unmarshal(responseWriter.Body(), &message)
+			assert.NoError(t, err)
+			assert.Equal(t, c.code, message.Code)
+		})
+	}
+}
+
+func TestEncodeErrorWeakKey(t *testing.T) {
+	policy := mgclients.KeyPolicy{MinLength: 16, RequireDigit: true}
+	responseWriter := newResponseWriter()
+	api.EncodeError(context.Background(), policy.Check("weak"), responseWriter)
+	assert.Equal(t, http.StatusBadRequest, responseWriter.StatusCode())
+
+	message := body{}
+	err := json.Unmarshal(responseWriter.Body(), &message)
+	assert.NoError(t, err)
+	assert.Equal(t, mgclients.ErrWeakKey.Error(), message.Message)
+	assert.Equal(t, policy.String(), message.Error)
+}
+
+func TestEncodeErrorTraceID(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	handler := otelhttp.NewHandler(kithttp.NewServer(
+		func(context.Context, interface{}) (interface{}, error) {
+			return nil, svcerr.ErrNotFound
+		},
+		kithttp.NopRequestDecoder,
+		api.EncodeResponse,
+		kithttp.ServerErrorEncoder(api.EncodeError),
+	), "test")
+
+	cases := []struct {
+		desc        string
+		traceparent string
+		traceID     string
+	}{
+		{
+			desc:        "error of request with trace context",
+			traceparent: fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceID),
+			traceID:     traceID,
+		},
+		{
+			desc:        "error of request with invalid trace context",
+			traceparent: "invalid",
+			traceID:     "",
+		},
+		{
+			desc:    "error of request without trace context",
+			traceID: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/things", http.NoBody)
+			if tc.traceparent != "" {
+				r.Header.Set("traceparent", tc.traceparent)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusNotFound, w.Code, fmt.Sprintf("expected status code %d got %d", http.StatusNotFound, w.Code))
+			assert.Equal(t, tc.traceID, w.Header().Get(api.TraceIDHeader), fmt.Sprintf("expected trace ID %q got %q", tc.traceID, w.Header().Get(api.TraceIDHeader)))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const gzipEncoding = "gzip"
+
+type acceptGzipCtxKey struct{}
+
+// DecodeContentEncoding is a request function which decompresses gzip
+// encoded request bodies before they are decoded, and marks the requests
+// which accept gzip encoded responses so that EncodeResponse compresses
+// them. Decoding a malformed gzip body fails with ErrMalformedEntity. The
+// request function can run more than once on the same request.
+func DecodeContentEncoding(ctx context.Context, r *http.Request) context.Context {
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), gzipEncoding) {
+		r.Header.Del("Content-Encoding")
+		r.Header.Del("Content-Length")
+		r.ContentLength = -1
+		zr, err := gzip.NewReader(r.Body)
+		switch err {
+		case nil:
+			r.Body = gzipBody{Reader: zr, body: r.Body}
+		default:
+			r.Body = errBody{err: errors.Wrap(errors.ErrMalformedEntity, err)}
+		}
+	}
+	if acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		ctx = context.WithValue(ctx, acceptGzipCtxKey{}, true)
+	}
+
+	return ctx
+}
+
+// acceptsGzip reports whether the Accept-Encoding header value allows gzip
+// encoded responses.
+func acceptsGzip(header string) bool {
+	for _, enc := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(enc, ";")
+		name = strings.TrimSpace(name)
+		if !strings.EqualFold(name, gzipEncoding) && name != "*" {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && key == "q" {
+				q, err := strconv.ParseFloat(val, 64)
+				return err == nil && q > 0
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func compressResponse(ctx context.Context) bool {
+	accepted, _ := ctx.Value(acceptGzipCtxKey{}).(bool)
+	return accepted
+}
+
+func encodeGzip(w io.Writer, response interface{}) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(response); err != nil {
+		zw.Close()
+		return err
+	}
+
+	return zw.Close()
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (gb gzipBody) Close() error {
+	if err := gb.Reader.Close(); err != nil {
+		gb.body.Close()
+		return err
+	}
+
+	return gb.body.Close()
+}
+
+type errBody struct {
+	err error
+}
+
+func (eb errBody) Read([]byte) (int, error) {
+	return 0, eb.err
+}
+
+func (eb errBody) Close() error {
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipped(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(data))
+	require.Nil(t, err, fmt.Sprintf("unexpected error while compressing: %s", err))
+	require.Nil(t, zw.Close(), "unexpected error while closing gzip writer")
+
+	return buf.Bytes()
+}
+
+func TestDecodeContentEncoding(t *testing.T) {
+	body := `{"name":"thing"}`
+
+	cases := []struct {
+		desc            string
+		body            []byte
+		contentEncoding string
+		acceptEncoding  string
+		decoded         string
+		compressed      bool
+		err             error
+	}{
+		{
+			desc:    "decode request without encoding",
+			body:    []byte(body),
+			decoded: body,
+		},
+		{
+			desc:            "decode gzip encoded request",
+			body:            gzipped(t, body),
+			contentEncoding: "gzip",
+			decoded:         body,
+		},
+		{
+			desc:            "decode gzip encoded request with upper case encoding",
+			body:            gzipped(t, body),
+			contentEncoding: "GZIP",
+			decoded:         body,
+		},
+		{
+			desc:            "decode malformed gzip encoded request",
+			body:            []byte(body),
+			contentEncoding: "gzip",
+			err:             errors.ErrMalformedEntity,
+		},
+		{
+			desc:           "decode request accepting gzip",
+			body:           []byte(body),
+			acceptEncoding: "deflate, gzip;q=0.8",
+			decoded:        body,
+			compressed:     true,
+		},
+		{
+			desc:           "decode request accepting any encoding",
+			body:           []byte(body),
+			acceptEncoding: "*",
+			decoded:        body,
+			compressed:     true,
+		},
+		{
+			desc:           "decode request refusing gzip",
+			body:           []byte(body),
+			acceptEncoding: "gzip;q=0",
+			decoded:        body,
+		},
+		{
+			desc:           "decode request accepting other encodings",
+			body:           []byte(body),
+			acceptEncoding: "br, deflate",
+			decoded:        body,
+		},
+	}
+
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodPost, "/things", bytes.NewReader(tc.body))
+		if tc.contentEncoding != "" {
+			r.Header.Set("Content-Encoding", tc.contentEncoding)
+		}
+		if tc.acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		}
+
+		ctx := api.DecodeContentEncoding(context.Background(), r)
+		// Running the request function again must not decompress the body twice.
+		ctx = api.DecodeContentEncoding(ctx, r)
+		decoded, err := io.ReadAll(r.Body)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.decoded, string(decoded), fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.decoded, decoded))
+		}
+		assert.Empty(t, r.Header.Get("Content-Encoding"), fmt.Sprintf("%s: expected content encoding to be removed", tc.desc))
+
+		w := httptest.NewRecorder()
+		err = api.EncodeResponse(ctx, w, response{code: http.StatusOK, ID: validUUID, Name: "thing"})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while encoding response: %s", tc.desc, err))
+		switch tc.compressed {
+		case true:
+			assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"), fmt.Sprintf("%s: expected gzip encoded response", tc.desc))
+			zr, err := gzip.NewReader(w.Body)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error while decompressing response: %s", tc.desc, err))
+			res, err := io.ReadAll(zr)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error while decompressing response: %s", tc.desc, err))
+			assert.True(t, strings.Contains(string(res), validUUID), fmt.Sprintf("%s: expected response to contain %s got %s", tc.desc, validUUID, res))
+		default:
+			assert.Empty(t, w.Header().Get("Content-Encoding"), fmt.Sprintf("%s: expected plain response", tc.desc))
+			assert.True(t, strings.Contains(w.Body.String(), validUUID), fmt.Sprintf("%s: expected response to contain %s got %s", tc.desc, validUUID, w.Body.String()))
+		}
+	}
+}
+
+func TestEncodeEmptyResponseWithGzip(t *testing.T) {
+	r := httptest.NewRequest(http.MethodDelete, "/things/id", http.NoBody)
+	r.Header.Set("Accept-Encoding", "gzip")
+	ctx := api.DecodeContentEncoding(context.Background(), r)
+
+	w := httptest.NewRecorder()
+	err := api.EncodeResponse(ctx, w, response{code: http.StatusNoContent, empty: true})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while encoding response: %s", err))
+	assert.Equal(t, http.StatusNoContent, w.Code, fmt.Sprintf("expected status code %d got %d", http.StatusNoContent, w.Code))
+	assert.Empty(t, w.Header().Get("Content-Encoding"), "expected empty response not to be encoded")
+	assert.Zero(t, w.Body.Len(), "expected empty body")
+}
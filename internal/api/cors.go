@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CORSConfig configures the cross-origin resource sharing of an HTTP API.
+// No origins are allowed by default, in which case no CORS headers are sent.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to access the API; "*" allows
+	// any origin.
+	AllowedOrigins []string `env:"ALLOWED_ORIGINS" envDefault:"" envSeparator:","`
+	// AllowedMethods are the methods allowed in cross-origin requests.
+	AllowedMethods []string `env:"ALLOWED_METHODS" envDefault:"GET,POST,PUT,PATCH,DELETE" envSeparator:","`
+	// AllowedHeaders are the request headers allowed in cross-origin requests.
+	AllowedHeaders []string `env:"ALLOWED_HEADERS" envDefault:"Authorization,Content-Type,Content-Encoding,Idempotency-Key,If-Unmodified-Since" envSeparator:","`
+	// AllowCredentials allows cross-origin requests to carry credentials.
+	AllowCredentials bool `env:"ALLOW_CREDENTIALS" envDefault:"false"`
+	// MaxAge is the time the browsers may cache the preflight responses.
+	MaxAge time.Duration `env:"MAX_AGE" envDefault:"10m"`
+}
+
+// CORS returns a middleware adding CORS headers to the responses to the
+// requests of the allowed origins, including the error responses. Preflight
+// requests of the methods routed by routes are answered by the middleware,
+// while the other ones are passed on. If no origins are allowed, the
+// requests are passed on untouched.
+func CORS(cfg CORSConfig, routes chi.Routes) func(http.Handler) http.Handler {
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, o := range cfg.AllowedOrigins {
+		if o = strings.TrimSpace(o); o != "" {
+			origins[o] = true
+		}
+	}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		if len(origins) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			w.Header().Add("Vary", "Origin")
+			if origin == "" || (!origins["*"] && !origins[origin]) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Credentials can't be allowed for any origin, so the origin
+			// is echoed instead of the wildcard.
+			allowed := origin
+			if origins["*"] && !cfg.AllowCredentials {
+				allowed = "*"
+			}
+			w.Header().Set("Access-Control-Allow-Origin", allowed)
+			if cfg.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			method := r.Header.Get("Access-Control-Request-Method")
+			if r.Method != http.MethodOptions || method == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !routes.Match(chi.NewRouteContext(), method, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+			w.Header().Set("Access-Control-Max-Age", maxAge)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
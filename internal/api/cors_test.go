@@ -0,0 +1,168 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/api"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const origin = "https://dashboard.example.com"
+
+func newCORSRouter(cfg api.CORSConfig) *chi.Mux {
+	mux := chi.NewRouter()
+	mux.Use(api.CORS(cfg, mux))
+	mux.Get("/things", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Delete("/things/{id}", func(w http.ResponseWriter, r *http.Request) {
+		api.EncodeError(context.Background(), svcerr.ErrAuthorization, w)
+	})
+
+	return mux
+}
+
+func TestCORS(t *testing.T) {
+	cfg := api.CORSConfig{
+		AllowedOrigins: []string{origin},
+		AllowedMethods: []string{http.MethodGet, http.MethodDelete},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAge:         time.Minute,
+	}
+
+	cases := []struct {
+		desc          string
+		cfg           api.CORSConfig
+		method        string
+		path          string
+		origin        string
+		requestMethod string
+		status        int
+		allowOrigin   string
+		allowMethods  string
+		allowCreds    string
+	}{
+		{
+			desc:        "request from allowed origin",
+			cfg:         cfg,
+			method:      http.MethodGet,
+			path:        "/things",
+			origin:      origin,
+			status:      http.StatusOK,
+			allowOrigin: origin,
+		},
+		{
+			desc:        "failed request from allowed origin",
+			cfg:         cfg,
+			method:      http.MethodDelete,
+			path:        "/things/1",
+			origin:      origin,
+			status:      http.StatusForbidden,
+			allowOrigin: origin,
+		},
+		{
+			desc:   "request from disallowed origin",
+			cfg:    cfg,
+			method: http.MethodGet,
+			path:   "/things",
+			origin: "https://evil.example.com",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "request without origin",
+			cfg:    cfg,
+			method: http.MethodGet,
+			path:   "/things",
+			status: http.StatusOK,
+		},
+		{
+			desc:          "preflight request of routed method",
+			cfg:           cfg,
+			method:        http.MethodOptions,
+			path:          "/things/1",
+			origin:        origin,
+			requestMethod: http.MethodDelete,
+			status:        http.StatusNoContent,
+			allowOrigin:   origin,
+			allowMethods:  "GET, DELETE",
+		},
+		{
+			desc:          "preflight request of unrouted method",
+			cfg:           cfg,
+			method:        http.MethodOptions,
+			path:          "/things/1",
+			origin:        origin,
+			requestMethod: http.MethodPut,
+			status:        http.StatusMethodNotAllowed,
+			allowOrigin:   origin,
+		},
+		{
+			desc:          "preflight request of unknown path",
+			cfg:           cfg,
+			method:        http.MethodOptions,
+			path:          "/unknown",
+			origin:        origin,
+			requestMethod: http.MethodGet,
+			status:        http.StatusNotFound,
+			allowOrigin:   origin,
+		},
+		{
+			desc:          "preflight request without allowed origins",
+			cfg:           api.CORSConfig{},
+			method:        http.MethodOptions,
+			path:          "/things",
+			origin:        origin,
+			requestMethod: http.MethodGet,
+			status:        http.StatusMethodNotAllowed,
+		},
+		{
+			desc:        "request with any origin allowed",
+			cfg:         api.CORSConfig{AllowedOrigins: []string{"*"}},
+			method:      http.MethodGet,
+			path:        "/things",
+			origin:      origin,
+			status:      http.StatusOK,
+			allowOrigin: "*",
+		},
+		{
+			desc:        "request with credentials and any origin allowed",
+			cfg:         api.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method:      http.MethodGet,
+			path:        "/things",
+			origin:      origin,
+			status:      http.StatusOK,
+			allowOrigin: origin,
+			allowCreds:  "true",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tc.requestMethod)
+			}
+			w := httptest.NewRecorder()
+			newCORSRouter(tc.cfg).ServeHTTP(w, req)
+
+			res := w.Result()
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.allowOrigin, res.Header.Get("Access-Control-Allow-Origin"), fmt.Sprintf("%s: unexpected allowed origin", tc.desc))
+			assert.Equal(t, tc.allowMethods, res.Header.Get("Access-Control-Allow-Methods"), fmt.Sprintf("%s: unexpected allowed methods", tc.desc))
+			assert.Equal(t, tc.allowCreds, res.Header.Get("Access-Control-Allow-Credentials"), fmt.Sprintf("%s: unexpected allowed credentials", tc.desc))
+		})
+	}
+}
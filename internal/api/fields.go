@@ -0,0 +1,61 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+)
+
+// ReadFieldsQuery reads the comma separated names of the fields the
+// response is limited to. Names which are not among the allowed ones are
+// rejected with apiutil.ErrInvalidQueryParams. Missing query selects all
+// the fields.
+func ReadFieldsQuery(r *http.Request, allowed ...string) ([]string, error) {
+	v, err := apiutil.ReadStringQuery(r, FieldsKey, "")
+	if err != nil {
+		return nil, err
+	}
+	if v == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, f := range strings.Split(v, ",") {
+		f = strings.TrimSpace(f)
+		if !slices.Contains(allowed, f) {
+			return nil, apiutil.ErrInvalidQueryParams
+		}
+		if !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields, nil
+}
+
+// ProjectFields marshals the value, which marshals to a JSON object, to an
+// object holding only the fields. No fields keep all of them.
+func ProjectFields(v interface{}, fields []string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if val, ok := all[f]; ok {
+			projected[f] = val
+		}
+	}
+
+	return json.Marshal(projected)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFieldsQuery(t *testing.T) {
+	allowed := []string{"id", "name", "metadata"}
+
+	cases := []struct {
+		desc   string
+		query  string
+		fields []string
+		err    error
+	}{
+		{
+			desc: "read without fields",
+		},
+		{
+			desc:   "read fields",
+			query:  "?fields=id,name",
+			fields: []string{"id", "name"},
+		},
+		{
+			desc:   "read fields with spaces and duplicates",
+			query:  "?fields=id,%20name,id",
+			fields: []string{"id", "name"},
+		},
+		{
+			desc:  "read unknown field",
+			query: "?fields=id,secret",
+			err:   apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:  "read empty field",
+			query: "?fields=id,",
+			err:   apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:  "read repeated query",
+			query: "?fields=id&fields=name",
+			err:   apiutil.ErrInvalidQueryParams,
+		},
+	}
+
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/things"+tc.query, nil)
+		fields, err := api.ReadFieldsQuery(r, allowed...)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.fields, fields, fmt.Sprintf("%s: expected fields %v got %v", tc.desc, tc.fields, fields))
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	v := map[string]interface{}{
+		"id":       "1",
+		"name":     "thing",
+		"metadata": map[string]interface{}{"k": "v"},
+	}
+
+	cases := []struct {
+		desc   string
+		fields []string
+		json   string
+	}{
+		{
+			desc: "project without fields",
+			json: `{"id":"1","metadata":{"k":"v"},"name":"thing"}`,
+		},
+		{
+			desc:   "project fields",
+			fields: []string{"id", "name"},
+			json:   `{"id":"1","name":"thing"}`,
+		},
+		{
+			desc:   "project missing field",
+			fields: []string{"id", "tags"},
+			json:   `{"id":"1"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		data, err := api.ProjectFields(v, tc.fields)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.JSONEq(t, tc.json, string(data), fmt.Sprintf("%s: unexpected projection", tc.desc))
+	}
+}
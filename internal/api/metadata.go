@@ -0,0 +1,67 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/go-kit/kit/endpoint"
+)
+
+// MetadataRequest is implemented by the requests carrying metadata of one
+// or more entities.
+type MetadataRequest interface {
+	// EntitiesMetadata returns the metadata of the entities in the order
+	// in which the entities are listed in the request.
+	EntitiesMetadata() []map[string]interface{}
+}
+
+// ValidateMetadataSize returns ErrMetadataTooLarge if the serialized
+// metadata exceeds maxSize bytes. Zero maxSize disables the check.
+func ValidateMetadataSize(metadata map[string]interface{}, maxSize int) error {
+	if maxSize <= 0 || len(metadata) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if len(b) > maxSize {
+		return apiutil.ErrMetadataTooLarge
+	}
+
+	return nil
+}
+
+// LimitMetadataSize returns an endpoint middleware which rejects the
+// requests carrying metadata which exceeds maxSize bytes once serialized.
+// Requests with metadata of many entities are checked per entity and the
+// error reports the index of the first entity which failed.
+func LimitMetadataSize(maxSize int) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, ok := request.(MetadataRequest)
+			if !ok {
+				return next(ctx, request)
+			}
+			entities := req.EntitiesMetadata()
+			for i, metadata := range entities {
+				err := ValidateMetadataSize(metadata, maxSize)
+				switch {
+				case err == nil:
+					continue
+				case len(entities) > 1 && errors.Contains(err, apiutil.ErrMetadataTooLarge):
+					err = errors.Wrap(fmt.Errorf("%s: entity at index %d", apiutil.ErrMetadataTooLarge, i), err)
+				}
+				return nil, errors.Wrap(apiutil.ErrValidation, err)
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const maxMetadataSize = 32
+
+type metadataReq []map[string]interface{}
+
+func (req metadataReq) EntitiesMetadata() []map[string]interface{} {
+	return req
+}
+
+func TestValidateMetadataSize(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata map[string]interface{}
+		maxSize  int
+		err      error
+	}{
+		{
+			desc:     "metadata within the limit",
+			metadata: map[string]interface{}{"key": "value"},
+			maxSize:  maxMetadataSize,
+			err:      nil,
+		},
+		{
+			desc:     "metadata exceeding the limit",
+			metadata: map[string]interface{}{"key": strings.Repeat("a", maxMetadataSize)},
+			maxSize:  maxMetadataSize,
+			err:      apiutil.ErrMetadataTooLarge,
+		},
+		{
+			desc:     "empty metadata",
+			metadata: nil,
+			maxSize:  maxMetadataSize,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with disabled limit",
+			metadata: map[string]interface{}{"key": strings.Repeat("a", maxMetadataSize)},
+			maxSize:  0,
+			err:      nil,
+		},
+		{
+			desc:     "metadata which can't be serialized",
+			metadata: map[string]interface{}{"key": make(chan int)},
+			maxSize:  maxMetadataSize,
+			err:      errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := api.ValidateMetadataSize(tc.metadata, tc.maxSize)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v got %v", tc.err, err))
+		})
+	}
+}
+
+func TestLimitMetadataSize(t *testing.T) {
+	small := map[string]interface{}{"key": "value"}
+	large := map[string]interface{}{"key": strings.Repeat("a", maxMetadataSize)}
+
+	cases := []struct {
+		desc    string
+		request interface{}
+		err     error
+		msg     string
+	}{
+		{
+			desc:    "request within the limit",
+			request: metadataReq{small},
+			err:     nil,
+		},
+		{
+			desc:    "request exceeding the limit",
+			request: metadataReq{large},
+			err:     apiutil.ErrMetadataTooLarge,
+			msg:     apiutil.ErrMetadataTooLarge.Error(),
+		},
+		{
+			desc:    "bulk request with an entity exceeding the limit",
+			request: metadataReq{small, large, large},
+			err:     apiutil.ErrMetadataTooLarge,
+			msg:     fmt.Sprintf("%s: entity at index 1", apiutil.ErrMetadataTooLarge),
+		},
+		{
+			desc:    "request without metadata",
+			request: struct{}{},
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var called bool
+			next := func(_ context.Context, _ interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			}
+			_, err := api.LimitMetadataSize(maxMetadataSize)(next)(context.Background(), tc.request)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v got %v", tc.err, err))
+			assert.Equal(t, tc.err == nil, called, "expected the next endpoint to be called only for valid requests")
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, apiutil.ErrValidation), fmt.Sprintf("expected validation error got %v", err))
+				_, inner := errors.Unwrap(err)
+				wrapper, _ := errors.Unwrap(inner)
+				if wrapper == nil {
+					wrapper = inner
+				}
+				assert.Equal(t, tc.msg, wrapper.Error())
+			}
+		})
+	}
+}
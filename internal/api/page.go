@@ -0,0 +1,69 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// PageLimits configures the page sizes of the list endpoints.
+type PageLimits struct {
+	// Default is the page size of the requests which don't set the limit.
+	Default uint64 `env:"DEFAULT_LIMIT" envDefault:"10"`
+	// Max is the largest page size a request can set. It can't exceed
+	// MaxLimitSize.
+	Max uint64 `env:"MAX_LIMIT"     envDefault:"100"`
+}
+
+// Validate checks that the default page size is within the maximum, which
+// is itself within MaxLimitSize.
+func (pl PageLimits) Validate() error {
+	if pl.Max < 1 || pl.Max > MaxLimitSize {
+		return fmt.Errorf("max limit %d is not between 1 and %d", pl.Max, MaxLimitSize)
+	}
+	if pl.Default < 1 || pl.Default > pl.Max {
+		return fmt.Errorf("default limit %d is not between 1 and the max limit %d", pl.Default, pl.Max)
+	}
+
+	return nil
+}
+
+type pageLimitsCtxKey struct{}
+
+// LimitPageSize returns a middleware which applies the page limits to the
+// limit query parameter. Requests with a limit larger than the maximum are
+// rejected with ErrLimitSize rather than clamped, so clients never get fewer
+// entities per page than they asked for without noticing. Malformed limits
+// are left to the request decoders, which read the default limit of the
+// requests without one with DefaultLimit.
+func LimitPageSize(limits PageLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if vals := r.URL.Query()[LimitKey]; len(vals) == 1 {
+				if limit, err := strconv.ParseUint(vals[0], 10, 64); err == nil && limit > limits.Max {
+					EncodeError(r.Context(), errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize), w)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), pageLimitsCtxKey{}, limits)))
+		})
+	}
+}
+
+// DefaultLimit returns the default page size of the request, which is
+// DefLimit unless the request went through LimitPageSize.
+func DefaultLimit(ctx context.Context) uint64 {
+	if limits, ok := ctx.Value(pageLimitsCtxKey{}).(PageLimits); ok {
+		return limits.Default
+	}
+
+	return DefLimit
+}
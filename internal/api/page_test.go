@@ -0,0 +1,110 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageLimitsValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		limits api.PageLimits
+		valid  bool
+	}{
+		{
+			desc:   "validate default page limits",
+			limits: api.PageLimits{Default: api.DefLimit, Max: api.MaxLimitSize},
+			valid:  true,
+		},
+		{
+			desc:   "validate default limit equal to max limit",
+			limits: api.PageLimits{Default: 20, Max: 20},
+			valid:  true,
+		},
+		{
+			desc:   "validate zero default limit",
+			limits: api.PageLimits{Default: 0, Max: 20},
+		},
+		{
+			desc:   "validate default limit above max limit",
+			limits: api.PageLimits{Default: 30, Max: 20},
+		},
+		{
+			desc:   "validate zero max limit",
+			limits: api.PageLimits{Default: 0, Max: 0},
+		},
+		{
+			desc:   "validate max limit above max limit size",
+			limits: api.PageLimits{Default: api.DefLimit, Max: api.MaxLimitSize + 1},
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.limits.Validate()
+		assert.Equal(t, tc.valid, err == nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+	}
+}
+
+func TestLimitPageSize(t *testing.T) {
+	limits := api.PageLimits{Default: 5, Max: 20}
+
+	cases := []struct {
+		desc   string
+		query  string
+		status int
+		def    uint64
+	}{
+		{
+			desc:   "request without limit",
+			status: http.StatusOK,
+			def:    5,
+		},
+		{
+			desc:   "request with limit under the max limit",
+			query:  "?limit=10",
+			status: http.StatusOK,
+			def:    5,
+		},
+		{
+			desc:   "request with limit of the max limit",
+			query:  "?limit=20",
+			status: http.StatusOK,
+			def:    5,
+		},
+		{
+			desc:   "request with limit above the max limit",
+			query:  "?limit=21",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "request with malformed limit",
+			query:  "?limit=invalid",
+			status: http.StatusOK,
+			def:    5,
+		},
+	}
+
+	for _, tc := range cases {
+		var def uint64
+		h := api.LimitPageSize(limits)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			def = api.DefaultLimit(r.Context())
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/things"+tc.query, nil))
+		assert.Equal(t, tc.status, rec.Code, fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, rec.Code))
+		assert.Equal(t, tc.def, def, fmt.Sprintf("%s: expected default limit %d got %d", tc.desc, tc.def, def))
+	}
+}
+
+func TestDefaultLimit(t *testing.T) {
+	assert.Equal(t, uint64(api.DefLimit), api.DefaultLimit(context.Background()), "expected built-in default limit without page limits")
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala"
+)
+
+const (
+	// OutcomeSuccess marks a successful operation.
+	OutcomeSuccess = "success"
+	// OutcomeFailure marks a failed operation.
+	OutcomeFailure = "failure"
+
+	unknownSubject = "unknown"
+	auditMessage   = "audit"
+)
+
+// ActivityRecorder records the activity of the audited subjects.
+type ActivityRecorder interface {
+	// RecordActivity records that the subject successfully performed an
+	// operation on the entity at the given time.
+	RecordActivity(ctx context.Context, subject, entityType, entityID string, at time.Time)
+}
+
+// Subject is the resolved subject of an audited operation and the domain
+// it acted in.
+type Subject struct {
+	ID     string
+	Domain string
+}
+
+// Identifier resolves the subject of the token the operation is performed with.
+type Identifier interface {
+	Identify(ctx context.Context, token string) (Subject, error)
+}
+
+type authIdentifier struct {
+	auth magistrala.AuthServiceClient
+}
+
+// NewAuthIdentifier returns the identifier resolving the tokens using the
+// auth service.
+func NewAuthIdentifier(authClient magistrala.AuthServiceClient) Identifier {
+	return authIdentifier{auth: authClient}
+}
+
+func (ai authIdentifier) Identify(ctx context.Context, token string) (Subject, error) {
+	res, err := ai.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
+	if err != nil {
+		return Subject{}, err
+	}
+
+	return Subject{ID: res.GetUserId(), Domain: res.GetDomainId()}, nil
+}
+
+// Entry is the outcome of an audited operation on a single entity.
+type Entry struct {
+	EntityID string
+	Err      error
+}
+
+// Logger emits structured audit entries. The token subject is resolved
+// using the identifier so raw tokens never reach the logs.
+type Logger struct {
+	logger     *slog.Logger
+	identifier Identifier
+	reads      bool
+	recorders  []ActivityRecorder
+}
+
+// New returns new audit logger. Read operations are logged only if reads is set.
+func New(logger *slog.Logger, identifier Identifier, reads bool) *Logger {
+	return &Logger{
+		logger:     logger,
+		identifier: identifier,
+		reads:      reads,
+	}
+}
+
+// AddRecorder registers the recorder to be notified of the successful
+// operations of the resolved subjects. Read operations are recorded only
+// if they are logged.
+func (l *Logger) AddRecorder(r ActivityRecorder) {
+	l.recorders = append(l.recorders, r)
+}
+
+// Write logs the outcome of a mutating operation performed by the token subject.
+func (l *Logger) Write(ctx context.Context, token, action, entityType, entityID string, err error) {
+	l.log(ctx, token, action, entityType, Entry{EntityID: entityID, Err: err})
+}
+
+// WriteEntries logs the outcomes of a mutating operation performed by the
+// token subject on multiple entities. The subject is resolved once.
+func (l *Logger) WriteEntries(ctx context.Context, token, action, entityType string, entries ...Entry) {
+	l.log(ctx, token, action, entityType, entries...)
+}
+
+// Read logs the outcome of a read operation if read auditing is enabled.
+func (l *Logger) Read(ctx context.Context, token, action, entityType, entityID string, err error) {
+	if !l.reads {
+		return
+	}
+	l.log(ctx, token, action, entityType, Entry{EntityID: entityID, Err: err})
+}
+
+// ReadEntries logs the outcomes of a read operation on multiple entities if
+// read auditing is enabled. The subject is resolved once.
+func (l *Logger) ReadEntries(ctx context.Context, token, action, entityType string, entries ...Entry) {
+	if !l.reads {
+		return
+	}
+	l.log(ctx, token, action, entityType, entries...)
+}
+
+func (l *Logger) log(ctx context.Context, token, action, entityType string, entries ...Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	subject, idErr := l.identifier.Identify(ctx, token)
+	if idErr != nil || subject.ID == "" {
+		subject = Subject{ID: unknownSubject}
+	}
+
+	now := time.Now()
+	for _, e := range entries {
+		attrs := []slog.Attr{
+			slog.String("subject", subject.ID),
+			slog.String("domain", subject.Domain),
+			slog.String("action", action),
+			slog.String("entity_type", entityType),
+			slog.String("entity_id", e.EntityID),
+			slog.String("outcome", OutcomeSuccess),
+		}
+		if e.Err != nil {
+			attrs[len(attrs)-1] = slog.String("outcome", OutcomeFailure)
+			attrs = append(attrs, slog.String("error", e.Err.Error()))
+		}
+
+		l.logger.LogAttrs(ctx, slog.LevelInfo, auditMessage, attrs...)
+
+		if e.Err == nil && subject.ID != unknownSubject && e.EntityID != "" {
+			for _, r := range l.recorders {
+				r.RecordActivity(ctx, subject.ID, entityType, e.EntityID, now)
+			}
+		}
+	}
+}
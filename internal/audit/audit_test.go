@@ -0,0 +1,185 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/audit"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	token    = "token"
+	userID   = "user"
+	domainID = "domain"
+	entityID = "entity"
+)
+
+type recorder struct {
+	activities []string
+}
+
+func (r *recorder) RecordActivity(_ context.Context, subject, entityType, entityID string, _ time.Time) {
+	r.activities = append(r.activities, subject+" "+entityType+" "+entityID)
+}
+
+func TestAudit(t *testing.T) {
+	cases := []struct {
+		desc     string
+		reads    bool
+		read     bool
+		idRes    *magistrala.IdentityRes
+		idErr    error
+		err      error
+		entry    map[string]interface{}
+		written  bool
+		recorded bool
+	}{
+		{
+			desc:  "write with successful outcome",
+			idRes: &magistrala.IdentityRes{UserId: userID, DomainId: domainID},
+			entry: map[string]interface{}{
+				"subject":     userID,
+				"domain":      domainID,
+				"action":      "update_thing",
+				"entity_type": "thing",
+				"entity_id":   entityID,
+				"outcome":     audit.OutcomeSuccess,
+			},
+			written:  true,
+			recorded: true,
+		},
+		{
+			desc:  "write with failed outcome",
+			idRes: &magistrala.IdentityRes{UserId: userID, DomainId: domainID},
+			err:   svcerr.ErrAuthorization,
+			entry: map[string]interface{}{
+				"subject":     userID,
+				"domain":      domainID,
+				"action":      "update_thing",
+				"entity_type": "thing",
+				"entity_id":   entityID,
+				"outcome":     audit.OutcomeFailure,
+				"error":       svcerr.ErrAuthorization.Error(),
+			},
+			written: true,
+		},
+		{
+			desc:  "write with unresolved subject",
+			idRes: &magistrala.IdentityRes{},
+			idErr: errors.New("invalid token"),
+			entry: map[string]interface{}{
+				"subject":     "unknown",
+				"domain":      "",
+				"action":      "update_thing",
+				"entity_type": "thing",
+				"entity_id":   entityID,
+				"outcome":     audit.OutcomeSuccess,
+			},
+			written: true,
+		},
+		{
+			desc:    "read with read auditing disabled",
+			read:    true,
+			idRes:   &magistrala.IdentityRes{UserId: userID, DomainId: domainID},
+			written: false,
+		},
+		{
+			desc:  "read with read auditing enabled",
+			reads: true,
+			read:  true,
+			idRes: &magistrala.IdentityRes{UserId: userID, DomainId: domainID},
+			entry: map[string]interface{}{
+				"subject":     userID,
+				"domain":      domainID,
+				"action":      "view_thing",
+				"entity_type": "thing",
+				"entity_id":   entityID,
+				"outcome":     audit.OutcomeSuccess,
+			},
+			written:  true,
+			recorded: true,
+		},
+	}
+
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		auth := new(authmocks.AuthClient)
+		authCall := auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(tc.idRes, tc.idErr)
+		l := audit.New(slog.New(slog.NewJSONHandler(&buf, nil)), audit.NewAuthIdentifier(auth), tc.reads)
+		rec := &recorder{}
+		l.AddRecorder(rec)
+
+		switch tc.read {
+		case true:
+			l.Read(context.Background(), token, "view_thing", "thing", entityID, tc.err)
+		default:
+			l.Write(context.Background(), token, "update_thing", "thing", entityID, tc.err)
+		}
+
+		switch tc.recorded {
+		case true:
+			assert.Equal(t, []string{userID + " thing " + entityID}, rec.activities, fmt.Sprintf("%s: expected activity to be recorded", tc.desc))
+		default:
+			assert.Empty(t, rec.activities, fmt.Sprintf("%s: expected no activity got %v", tc.desc, rec.activities))
+		}
+
+		if !tc.written {
+			assert.Zero(t, buf.Len(), fmt.Sprintf("%s: expected no audit entry got %s", tc.desc, buf.String()))
+			authCall.Unset()
+			continue
+		}
+		var entry map[string]interface{}
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		for k, v := range tc.entry {
+			assert.Equal(t, v, entry[k], fmt.Sprintf("%s: expected %s to be %v got %v", tc.desc, k, v, entry[k]))
+		}
+		assert.NotContains(t, buf.String(), token, fmt.Sprintf("%s: raw token must not be logged", tc.desc))
+		authCall.Unset()
+	}
+}
+
+func TestAuditEntries(t *testing.T) {
+	var buf bytes.Buffer
+	auth := new(authmocks.AuthClient)
+	auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(&magistrala.IdentityRes{UserId: userID, DomainId: domainID}, nil)
+	l := audit.New(slog.New(slog.NewJSONHandler(&buf, nil)), audit.NewAuthIdentifier(auth), true)
+	rec := &recorder{}
+	l.AddRecorder(rec)
+
+	entries := []audit.Entry{
+		{EntityID: "first"},
+		{EntityID: "second", Err: svcerr.ErrNotFound},
+		{EntityID: "third"},
+	}
+	l.WriteEntries(context.Background(), token, "create_thing", "thing", entries...)
+	l.ReadEntries(context.Background(), token, "view_thing", "thing", entries...)
+
+	auth.AssertNumberOfCalls(t, "Identify", 2)
+	dec := json.NewDecoder(&buf)
+	for _, action := range []string{"create_thing", "view_thing"} {
+		for _, e := range entries {
+			var entry map[string]interface{}
+			err := dec.Decode(&entry)
+			assert.Nil(t, err, fmt.Sprintf("%s %s: unexpected error %s", action, e.EntityID, err))
+			assert.Equal(t, action, entry["action"], fmt.Sprintf("expected action %s got %v", action, entry["action"]))
+			assert.Equal(t, e.EntityID, entry["entity_id"], fmt.Sprintf("expected entity %s got %v", e.EntityID, entry["entity_id"]))
+			assert.Equal(t, userID, entry["subject"], fmt.Sprintf("expected subject %s got %v", userID, entry["subject"]))
+		}
+	}
+	expected := []string{userID + " thing first", userID + " thing third", userID + " thing first", userID + " thing third"}
+	assert.Equal(t, expected, rec.activities, fmt.Sprintf("expected activities %v got %v", expected, rec.activities))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/internal/audit"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// maxTrackedActivities bounds the number of member activities kept in
+// memory to throttle the writes to the repository.
+const maxTrackedActivities = 10000
+
+type activityKey struct {
+	groupID  string
+	memberID string
+}
+
+type activityRecorder struct {
+	repo     groups.Repository
+	entity   string
+	interval time.Duration
+	mu       sync.Mutex
+	saved    map[activityKey]time.Time
+}
+
+var _ audit.ActivityRecorder = (*activityRecorder)(nil)
+
+// NewActivityRecorder returns an audit activity recorder which stores the last
+// activity of the members of the groups audited as the given entity, e.g. group
+// or channel. The activity of a member is stored at most once per interval per
+// group, so frequent operations don't result in a write each.
+func NewActivityRecorder(repo groups.Repository, entity string, interval time.Duration) audit.ActivityRecorder {
+	return &activityRecorder{
+		repo:     repo,
+		entity:   entity,
+		interval: interval,
+		saved:    make(map[activityKey]time.Time),
+	}
+}
+
+func (ar *activityRecorder) RecordActivity(ctx context.Context, subject, entityType, entityID string, at time.Time) {
+	if entityType != ar.entity {
+		return
+	}
+
+	key := activityKey{groupID: entityID, memberID: subject}
+	ar.mu.Lock()
+	if last, ok := ar.saved[key]; ok && at.Sub(last) < ar.interval {
+		ar.mu.Unlock()
+		return
+	}
+	if len(ar.saved) >= maxTrackedActivities {
+		ar.saved = make(map[activityKey]time.Time)
+	}
+	ar.saved[key] = at
+	ar.mu.Unlock()
+
+	// Activity is tracked opportunistically, so failing to store it must
+	// not affect the audited operation.
+	if err := ar.repo.SaveActivity(ctx, entityID, subject, at); err != nil {
+		ar.mu.Lock()
+		delete(ar.saved, key)
+		ar.mu.Unlock()
+	}
+}
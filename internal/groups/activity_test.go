@@ -0,0 +1,39 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/groups"
+	"github.com/absmach/magistrala/internal/testsutil"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/groups/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordActivity(t *testing.T) {
+	groupID := testsutil.GenerateUUID(t)
+	memberID := testsutil.GenerateUUID(t)
+	now := time.Now()
+
+	repo := new(mocks.Repository)
+	recorder := groups.NewActivityRecorder(repo, "channel", time.Minute)
+
+	repoCall := repo.On("SaveActivity", context.Background(), groupID, memberID, now).Return(repoerr.ErrCreateEntity)
+	recorder.RecordActivity(context.Background(), memberID, "channel", groupID, now)
+	repoCall.Unset()
+
+	// A failed save is retried on the next activity.
+	repo.On("SaveActivity", context.Background(), groupID, memberID, mock.Anything).Return(nil)
+	recorder.RecordActivity(context.Background(), memberID, "channel", groupID, now)
+	// Activity within the interval and activity on other entities is not saved.
+	recorder.RecordActivity(context.Background(), memberID, "channel", groupID, now.Add(time.Second))
+	recorder.RecordActivity(context.Background(), memberID, "thing", groupID, now.Add(time.Hour))
+	recorder.RecordActivity(context.Background(), memberID, "channel", groupID, now.Add(time.Hour))
+
+	repo.AssertNumberOfCalls(t, "SaveActivity", 3)
+}
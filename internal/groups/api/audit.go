@@ -0,0 +1,253 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/audit"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+var _ groups.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	audit  *audit.Logger
+	entity string
+	svc    groups.Service
+}
+
+// AuditMiddleware adds structured audit logging facilities to the groups service.
+// Entity is the name the groups are exposed as, e.g. group or channel.
+func AuditMiddleware(svc groups.Service, audit *audit.Logger, entity string) groups.Service {
+	return &auditMiddleware{audit, entity, svc}
+}
+
+func (am *auditMiddleware) CreateGroup(ctx context.Context, token, kind string, group groups.Group) (groups.Group, error) {
+	g, err := am.svc.CreateGroup(ctx, token, kind, group)
+	am.audit.Write(ctx, token, "create_"+am.entity, am.entity, g.ID, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (groups.Group, error) {
+	g, err := am.svc.CloneGroup(ctx, token, kind, id, parentID, name)
+	am.audit.Write(ctx, token, "clone_"+am.entity, am.entity, g.ID, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) ExportGroup(ctx context.Context, token, id string) (groups.Blueprint, error) {
+	bp, err := am.svc.ExportGroup(ctx, token, id)
+	am.audit.Write(ctx, token, "export_"+am.entity, am.entity, id, err)
+
+	return bp, err
+}
+
+func (am *auditMiddleware) ImportGroup(ctx context.Context, token, kind, domainID string, bp groups.Blueprint) (groups.Group, error) {
+	g, err := am.svc.ImportGroup(ctx, token, kind, domainID, bp)
+	am.audit.Write(ctx, token, "import_"+am.entity, am.entity, g.ID, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) UpdateGroup(ctx context.Context, token string, group groups.Group) (groups.Group, error) {
+	g, err := am.svc.UpdateGroup(ctx, token, group)
+	am.audit.Write(ctx, token, "update_"+am.entity, am.entity, group.ID, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge, partial bool) ([]groups.GroupUpdate, error) {
+	res, err := am.svc.UpdateGroups(ctx, token, gs, merge, partial)
+	if err != nil {
+		entries := make([]audit.Entry, len(gs))
+		for i, g := range gs {
+			entries[i] = audit.Entry{EntityID: g.ID, Err: err}
+		}
+		am.audit.WriteEntries(ctx, token, "update_"+am.entity, am.entity, entries...)
+		return res, err
+	}
+	entries := make([]audit.Entry, len(res))
+	for i, u := range res {
+		entries[i] = audit.Entry{EntityID: u.ID, Err: entryError(u.Error)}
+	}
+	am.audit.WriteEntries(ctx, token, "update_"+am.entity, am.entity, entries...)
+
+	return res, nil
+}
+
+func (am *auditMiddleware) PatchGroup(ctx context.Context, token, id string, patch groups.GroupPatch) (groups.Group, error) {
+	g, err := am.svc.PatchGroup(ctx, token, id, patch)
+	am.audit.Write(ctx, token, "update_"+am.entity, am.entity, id, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) ViewGroup(ctx context.Context, token, id string) (groups.Group, error) {
+	g, err := am.svc.ViewGroup(ctx, token, id)
+	am.audit.Read(ctx, token, "view_"+am.entity, am.entity, id, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) ViewGroupPerms(ctx context.Context, token, id string) ([]string, error) {
+	p, err := am.svc.ViewGroupPerms(ctx, token, id)
+	am.audit.Read(ctx, token, "view_"+am.entity+"_permissions", am.entity, id, err)
+
+	return p, err
+}
+
+func (am *auditMiddleware) ListGroups(ctx context.Context, token, memberKind, memberID string, gm groups.Page) (groups.Page, error) {
+	gp, err := am.svc.ListGroups(ctx, token, memberKind, memberID, gm)
+	am.audit.Read(ctx, token, "list_"+am.entity+"s", am.entity, "", err)
+
+	return gp, err
+}
+
+func (am *auditMiddleware) ListMembers(ctx context.Context, token, groupID, permission, memberKind string) (groups.MembersPage, error) {
+	mp, err := am.svc.ListMembers(ctx, token, groupID, permission, memberKind)
+	am.audit.Read(ctx, token, "list_"+am.entity+"_members", am.entity, groupID, err)
+
+	return mp, err
+}
+
+func (am *auditMiddleware) ListMemberGroups(ctx context.Context, token, memberID string, pm groups.PageMeta) (groups.MemberGroupsPage, error) {
+	page, err := am.svc.ListMemberGroups(ctx, token, memberID, pm)
+	am.audit.Read(ctx, token, "list_member_"+am.entity+"s", "user", memberID, err)
+
+	return page, err
+}
+
+func (am *auditMiddleware) ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) ([]groups.MemberRole, error) {
+	members, err := am.svc.ListMemberRoles(ctx, token, groupID, inactiveSince)
+	am.audit.Read(ctx, token, "list_"+am.entity+"_member_roles", am.entity, groupID, err)
+
+	return members, err
+}
+
+func (am *auditMiddleware) ListDomainMembers(ctx context.Context, token, domainID string, pm groups.PageMeta) (groups.DomainMembersPage, error) {
+	page, err := am.svc.ListDomainMembers(ctx, token, domainID, pm)
+	am.audit.Read(ctx, token, "list_"+am.entity+"_domain_members", "domain", domainID, err)
+
+	return page, err
+}
+
+func (am *auditMiddleware) RevokeMember(ctx context.Context, token, domainID, memberID string) (groups.MemberRevocation, error) {
+	rev, err := am.svc.RevokeMember(ctx, token, domainID, memberID)
+	am.audit.Write(ctx, token, "revoke_"+am.entity+"_member", "user", memberID, err)
+
+	return rev, err
+}
+
+func (am *auditMiddleware) EnableGroup(ctx context.Context, token, id string) (groups.Group, error) {
+	g, err := am.svc.EnableGroup(ctx, token, id)
+	am.audit.Write(ctx, token, "enable_"+am.entity, am.entity, id, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) DisableGroup(ctx context.Context, token, id string) (groups.Group, error) {
+	g, err := am.svc.DisableGroup(ctx, token, id)
+	am.audit.Write(ctx, token, "disable_"+am.entity, am.entity, id, err)
+
+	return g, err
+}
+
+func (am *auditMiddleware) DeleteGroup(ctx context.Context, token, id string, cascade bool) error {
+	err := am.svc.DeleteGroup(ctx, token, id, cascade)
+	am.audit.Write(ctx, token, "delete_"+am.entity, am.entity, id, err)
+
+	return err
+}
+
+func (am *auditMiddleware) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]groups.GroupRemoval, error) {
+	res, err := am.svc.DeleteGroups(ctx, token, ids, cascade)
+	if err != nil {
+		entries := make([]audit.Entry, len(ids))
+		for i, id := range ids {
+			entries[i] = audit.Entry{EntityID: id, Err: err}
+		}
+		am.audit.WriteEntries(ctx, token, "delete_"+am.entity, am.entity, entries...)
+		return res, err
+	}
+	entries := make([]audit.Entry, len(res))
+	for i, r := range res {
+		entries[i] = audit.Entry{EntityID: r.ID, Err: entryError(r.Error)}
+	}
+	am.audit.WriteEntries(ctx, token, "delete_"+am.entity, am.entity, entries...)
+
+	return res, nil
+}
+
+func (am *auditMiddleware) Assign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) error {
+	err := am.svc.Assign(ctx, token, groupID, relation, memberKind, memberIDs...)
+	am.audit.Write(ctx, token, "assign_"+memberKind, am.entity, groupID, err)
+
+	return err
+}
+
+func (am *auditMiddleware) Unassign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) error {
+	err := am.svc.Unassign(ctx, token, groupID, relation, memberKind, memberIDs...)
+	am.audit.Write(ctx, token, "unassign_"+memberKind, am.entity, groupID, err)
+
+	return err
+}
+
+func (am *auditMiddleware) AssignRoles(ctx context.Context, token, memberID string, assignments []groups.RoleAssignment) ([]groups.RoleAssignment, error) {
+	res, err := am.svc.AssignRoles(ctx, token, memberID, assignments)
+	if err != nil {
+		am.audit.Write(ctx, token, "assign_role", am.entity, "", err)
+		return res, err
+	}
+	entries := make([]audit.Entry, len(res))
+	for i, a := range res {
+		entries[i] = audit.Entry{EntityID: a.GroupID, Err: entryError(a.Error)}
+	}
+	am.audit.WriteEntries(ctx, token, "assign_role", am.entity, entries...)
+
+	return res, nil
+}
+
+func (am *auditMiddleware) MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) ([]groups.ThingMove, error) {
+	res, err := am.svc.MoveThings(ctx, token, groupID, targetGroupID, thingIDs)
+	if err != nil {
+		am.audit.Write(ctx, token, "move_things", am.entity, groupID, err)
+		return res, err
+	}
+	entries := make([]audit.Entry, len(res))
+	for i, m := range res {
+		entries[i] = audit.Entry{EntityID: m.ThingID, Err: entryError(m.Error)}
+	}
+	am.audit.WriteEntries(ctx, token, "move_thing", auth.ThingType, entries...)
+
+	return res, nil
+}
+
+func (am *auditMiddleware) ReassignThings(ctx context.Context, token, groupID, targetGroupID string) ([]string, error) {
+	moved, err := am.svc.ReassignThings(ctx, token, groupID, targetGroupID)
+	am.audit.Write(ctx, token, "reassign_things", am.entity, groupID, err)
+
+	return moved, err
+}
+
+func (am *auditMiddleware) AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) ([]groups.ThingAttachment, error) {
+	res, err := am.svc.AttachThings(ctx, token, groupID, thingIDs, reassign)
+	am.audit.Write(ctx, token, "attach_things", am.entity, groupID, err)
+
+	return res, err
+}
+
+// entryError returns the error of the item of a bulk operation reported as
+// the message, if any.
+func entryError(msg string) error {
+	if msg == "" {
+		return nil
+	}
+
+	return errors.New(msg)
+}
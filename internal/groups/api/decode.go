@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
@@ -37,9 +38,9 @@ func DecodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	dir, err := apiutil.ReadNumQuery[int64](r, api.DirKey, -1)
+	dir, err := decodeDir(r, &pm)
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return nil, err
 	}
 
 	memberKind, err := apiutil.ReadStringQuery(r, api.MemberKindKey, "")
@@ -56,6 +57,14 @@ func DecodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+
+	myRole, err := apiutil.ReadStringQuery(r, api.MyRoleKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if pm.Fields, err = api.ReadFieldsQuery(r, groupFields...); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	req := listGroupsReq{
 		token:      apiutil.ExtractBearerToken(r),
 		tree:       tree,
@@ -65,6 +74,7 @@ func DecodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 			Level:      level,
 			ID:         parentID,
 			Permission: permission,
+			MyRole:     myRole,
 			PageMeta:   pm,
 			Direction:  dir,
 			ListPerms:  listPerms,
@@ -182,9 +192,27 @@ func DecodeGroupUpdate(_ context.Context, r *http.Request) (interface{}, error)
 }
 
 func DecodeGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	f, err := api.ReadFieldsQuery(r, groupFields...)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	req := groupReq{
-		token: apiutil.ExtractBearerToken(r),
-		id:    chi.URLParam(r, "groupID"),
+		token:  apiutil.ExtractBearerToken(r),
+		id:     chi.URLParam(r, "groupID"),
+		fields: f,
+	}
+	return req, nil
+}
+
+func DecodeDeleteGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	c, err := apiutil.ReadBoolQuery(r, api.CascadeKey, api.DefCascade)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := deleteGroupReq{
+		token:   apiutil.ExtractBearerToken(r),
+		id:      chi.URLParam(r, "groupID"),
+		cascade: c,
 	}
 	return req, nil
 }
@@ -264,7 +292,7 @@ func decodePageMeta(r *http.Request) (mggroups.PageMeta, error) {
 	if err != nil {
 		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
 	if err != nil {
 		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
@@ -276,13 +304,55 @@ func decodePageMeta(r *http.Request) (mggroups.PageMeta, error) {
 	if err != nil {
 		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	countOnly, err := apiutil.ReadBoolQuery(r, api.CountOnlyKey, api.DefCountOnly)
+	if err != nil {
+		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	order, err := apiutil.ReadStringQuery(r, api.OrderKey, "")
+	if err != nil {
+		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	switch order {
+	case "", mggroups.CreatedAtOrder, mggroups.UpdatedAtOrder, mggroups.NameOrder, mggroups.ThingCountOrder:
+	default:
+		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidOrder)
+	}
+	if countOnly && r.URL.Query().Has(api.LimitKey) {
+		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams)
+	}
+	updatedSince, err := apiutil.ReadTimeQuery(r, api.UpdatedSinceKey, time.Time{})
+	if err != nil {
+		return mggroups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 
 	ret := mggroups.PageMeta{
-		Offset:   offset,
-		Limit:    limit,
-		Name:     name,
-		Metadata: meta,
-		Status:   st,
+		Offset:       offset,
+		Limit:        limit,
+		Name:         name,
+		Metadata:     meta,
+		Status:       st,
+		Order:        order,
+		CountOnly:    countOnly,
+		UpdatedSince: updatedSince,
 	}
 	return ret, nil
 }
+
+// decodeDir reads the dir query parameter, which is either the direction
+// groups are ordered in (asc or desc) or the direction of the hierarchy.
+func decodeDir(r *http.Request, pm *mggroups.PageMeta) (int64, error) {
+	d, err := apiutil.ReadStringQuery(r, api.DirKey, "")
+	if err != nil {
+		return 0, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if d == mggroups.AscDir || d == mggroups.DescDir {
+		pm.Dir = d
+		return -1, nil
+	}
+	dir, err := apiutil.ReadNumQuery[int64](r, api.DirKey, -1)
+	if err != nil {
+		return 0, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return dir, nil
+}
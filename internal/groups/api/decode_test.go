@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
@@ -71,6 +72,45 @@ func TestDecodeListGroupsRequest(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:   "valid request with role filter",
+			url:    "http://localhost:8080?my_role=administrator",
+			header: map[string][]string{},
+			resp: listGroupsReq{
+				Page: groups.Page{
+					PageMeta: groups.PageMeta{
+						Limit: 10,
+					},
+					Permission: api.DefPermission,
+					MyRole:     "administrator",
+					Direction:  -1,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:   "valid request with thing count order",
+			url:    "http://localhost:8080?order=thing_count&dir=desc",
+			header: map[string][]string{},
+			resp: listGroupsReq{
+				Page: groups.Page{
+					PageMeta: groups.PageMeta{
+						Limit: 10,
+						Order: groups.ThingCountOrder,
+						Dir:   groups.DescDir,
+					},
+					Permission: api.DefPermission,
+					Direction:  -1,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with invalid order",
+			url:  "http://localhost:8080?order=random",
+			resp: nil,
+			err:  apiutil.ErrInvalidOrder,
+		},
 		{
 			desc: "valid request with invalid page metadata",
 			url:  "http://localhost:8080?metadata=random",
@@ -119,6 +159,28 @@ func TestDecodeListGroupsRequest(t *testing.T) {
 			resp: nil,
 			err:  apiutil.ErrValidation,
 		},
+		{
+			desc:   "valid request with fields",
+			url:    "http://localhost:8080?fields=id,name",
+			header: map[string][]string{},
+			resp: listGroupsReq{
+				Page: groups.Page{
+					PageMeta: groups.PageMeta{
+						Limit:  10,
+						Fields: []string{"id", "name"},
+					},
+					Permission: api.DefPermission,
+					Direction:  -1,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with unknown field",
+			url:  "http://localhost:8080?fields=id,secret",
+			resp: nil,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
 	}
 
 	for _, tc := range cases {
@@ -450,6 +512,42 @@ func TestDecodePageMeta(t *testing.T) {
 			resp: groups.PageMeta{},
 			err:  apiutil.ErrValidation,
 		},
+		{
+			desc: "valid request with count only",
+			url:  "http://localhost:8080?count_only=true",
+			resp: groups.PageMeta{
+				Limit:     10,
+				CountOnly: true,
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with invalid count only",
+			url:  "http://localhost:8080?count_only=random",
+			resp: groups.PageMeta{},
+			err:  apiutil.ErrValidation,
+		},
+		{
+			desc: "valid request with count only and limit",
+			url:  "http://localhost:8080?count_only=true&limit=10",
+			resp: groups.PageMeta{},
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "valid request with updated since",
+			url:  "http://localhost:8080?updated_since=2024-03-01T12:30:00Z",
+			resp: groups.PageMeta{
+				Limit:        10,
+				UpdatedSince: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with invalid updated since",
+			url:  "http://localhost:8080?updated_since=2024-03-01",
+			resp: groups.PageMeta{},
+			err:  apiutil.ErrInvalidQueryParams,
+		},
 	}
 
 	for _, tc := range cases {
@@ -576,12 +674,14 @@ func TestDecodeGroupUpdate(t *testing.T) {
 func TestDecodeGroupRequest(t *testing.T) {
 	cases := []struct {
 		desc   string
+		url    string
 		header map[string][]string
 		resp   interface{}
 		err    error
 	}{
 		{
 			desc: "valid request",
+			url:  "http://localhost:8080",
 			header: map[string][]string{
 				"Authorization": {"Bearer 123"},
 			},
@@ -592,13 +692,32 @@ func TestDecodeGroupRequest(t *testing.T) {
 		},
 		{
 			desc: "empty token",
+			url:  "http://localhost:8080",
 			resp: groupReq{},
 			err:  nil,
 		},
+		{
+			desc: "valid request with fields",
+			url:  "http://localhost:8080?fields=id,name,status",
+			header: map[string][]string{
+				"Authorization": {"Bearer 123"},
+			},
+			resp: groupReq{
+				token:  "123",
+				fields: []string{"id", "name", "status"},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with unknown field",
+			url:  "http://localhost:8080?fields=id,secret",
+			resp: nil,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
 	}
 
 	for _, tc := range cases {
-		req, err := http.NewRequest(http.MethodGet, "http://localhost:8080", http.NoBody)
+		req, err := http.NewRequest(http.MethodGet, tc.url, http.NoBody)
 		assert.NoError(t, err)
 		req.Header = tc.header
 		resp, err := DecodeGroupRequest(context.Background(), req)
@@ -195,11 +195,26 @@ func TestViewGroupPermsEndpoint(t *testing.T) {
 				id:    testsutil.GenerateUUID(t),
 			},
 			svcResp: []string{
-				valid,
+				auth.ViewPermission,
+				auth.EditPermission,
 			},
 			svcErr: nil,
-			resp:   viewGroupPermsRes{Permissions: []string{valid}},
-			err:    nil,
+			resp: viewGroupPermsRes{
+				Permissions: []string{auth.ViewPermission, auth.EditPermission},
+				Actions:     []string{auth.ReadAction, auth.WriteAction},
+			},
+			err: nil,
+		},
+		{
+			desc: "successfully without permissions",
+			req: groupPermsReq{
+				token: valid,
+				id:    testsutil.GenerateUUID(t),
+			},
+			svcResp: []string{},
+			svcErr:  nil,
+			resp:    viewGroupPermsRes{Permissions: []string{}, Actions: []string{}},
+			err:     nil,
 		},
 		{
 			desc: "unsuccessfully with invalid request",
@@ -349,14 +364,14 @@ func TestDeleteGroupEndpoint(t *testing.T) {
 	svc := new(mocks.Service)
 	cases := []struct {
 		desc   string
-		req    groupReq
+		req    deleteGroupReq
 		svcErr error
 		resp   deleteGroupRes
 		err    error
 	}{
 		{
 			desc: "successfully",
-			req: groupReq{
+			req: deleteGroupReq{
 				token: valid,
 				id:    testsutil.GenerateUUID(t),
 			},
@@ -364,9 +379,20 @@ func TestDeleteGroupEndpoint(t *testing.T) {
 			resp:   deleteGroupRes{deleted: true},
 			err:    nil,
 		},
+		{
+			desc: "successfully with cascade",
+			req: deleteGroupReq{
+				token:   valid,
+				id:      testsutil.GenerateUUID(t),
+				cascade: true,
+			},
+			svcErr: nil,
+			resp:   deleteGroupRes{deleted: true},
+			err:    nil,
+		},
 		{
 			desc: "unsuccessfully with invalid request",
-			req: groupReq{
+			req: deleteGroupReq{
 				id: testsutil.GenerateUUID(t),
 			},
 			resp: deleteGroupRes{},
@@ -374,7 +400,7 @@ func TestDeleteGroupEndpoint(t *testing.T) {
 		},
 		{
 			desc: "unsuccessfully with repo error",
-			req: groupReq{
+			req: deleteGroupReq{
 				token: valid,
 				id:    testsutil.GenerateUUID(t),
 			},
@@ -385,7 +411,7 @@ func TestDeleteGroupEndpoint(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		repoCall := svc.On("DeleteGroup", context.Background(), tc.req.token, tc.req.id).Return(tc.svcErr)
+		repoCall := svc.On("DeleteGroup", context.Background(), tc.req.token, tc.req.id, tc.req.cascade).Return(tc.svcErr)
 		resp, err := DeleteGroupEndpoint(svc)(context.Background(), tc.req)
 		assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
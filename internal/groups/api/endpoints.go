@@ -6,6 +6,7 @@ package api
 import (
 	"context"
 
+	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/groups"
@@ -42,7 +43,7 @@ func ViewGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 			return viewGroupRes{}, err
 		}
 
-		return viewGroupRes{Group: group}, nil
+		return viewGroupRes{Group: group, fields: req.fields}, nil
 	}
 }
 
@@ -58,7 +59,7 @@ func ViewGroupPermsEndpoint(svc groups.Service) endpoint.Endpoint {
 			return viewGroupPermsRes{}, err
 		}
 
-		return viewGroupPermsRes{Permissions: p}, nil
+		return viewGroupPermsRes{Permissions: p, Actions: auth.Actions(p)}, nil
 	}
 }
 
@@ -74,6 +75,7 @@ func UpdateGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 			Name:        req.Name,
 			Description: req.Description,
 			Metadata:    req.Metadata,
+			Version:     req.Version,
 		}
 
 		group, err := svc.UpdateGroup(ctx, req.token, group)
@@ -133,15 +135,18 @@ func ListGroupsEndpoint(svc groups.Service, groupType, memberKind string) endpoi
 			return groupPageRes{}, err
 		}
 
+		if req.CountOnly {
+			return countRes{Total: page.Total}, nil
+		}
 		if req.tree {
 			return buildGroupsResponseTree(page), nil
 		}
 		filterByID := req.Page.ID != ""
 
 		if groupType == groupTypeChannels {
-			return buildChannelsResponse(page, filterByID), nil
+			return buildChannelsResponse(page, filterByID, req.Fields), nil
 		}
-		return buildGroupsResponse(page, filterByID), nil
+		return buildGroupsResponse(page, filterByID, req.Fields), nil
 	}
 }
 
@@ -212,11 +217,11 @@ func UnassignMembersEndpoint(svc groups.Service, relation, memberKind string) en
 
 func DeleteGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(groupReq)
+		req := request.(deleteGroupReq)
 		if err := req.validate(); err != nil {
 			return deleteGroupRes{}, errors.Wrap(apiutil.ErrValidation, err)
 		}
-		if err := svc.DeleteGroup(ctx, req.token, req.id); err != nil {
+		if err := svc.DeleteGroup(ctx, req.token, req.id, req.cascade); err != nil {
 			return deleteGroupRes{}, err
 		}
 		return deleteGroupRes{deleted: true}, nil
@@ -274,7 +279,7 @@ func toViewGroupRes(group groups.Group) viewGroupRes {
 	return view
 }
 
-func buildGroupsResponse(gp groups.Page, filterByID bool) groupPageRes {
+func buildGroupsResponse(gp groups.Page, filterByID bool, fields []string) groupPageRes {
 	res := groupPageRes{
 		pageRes: pageRes{
 			Total: gp.Total,
@@ -285,7 +290,8 @@ func buildGroupsResponse(gp groups.Page, filterByID bool) groupPageRes {
 
 	for _, group := range gp.Groups {
 		view := viewGroupRes{
-			Group: group,
+			Group:  group,
+			fields: fields,
 		}
 		if filterByID && group.Level == 0 {
 			continue
@@ -296,7 +302,7 @@ func buildGroupsResponse(gp groups.Page, filterByID bool) groupPageRes {
 	return res
 }
 
-func buildChannelsResponse(cp groups.Page, filterByID bool) channelPageRes {
+func buildChannelsResponse(cp groups.Page, filterByID bool, fields []string) channelPageRes {
 	res := channelPageRes{
 		pageRes: pageRes{
 			Total: cp.Total,
@@ -310,7 +316,8 @@ func buildChannelsResponse(cp groups.Page, filterByID bool) channelPageRes {
 			continue
 		}
 		view := viewGroupRes{
-			Group: channel,
+			Group:  channel,
+			fields: fields,
 		}
 		res.Channels = append(res.Channels, view)
 	}
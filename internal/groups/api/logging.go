@@ -44,6 +44,71 @@ func (lm *loggingMiddleware) CreateGroup(ctx context.Context, token, kind string
 	return lm.svc.CreateGroup(ctx, token, kind, group)
 }
 
+// CloneGroup logs the clone_group request. It logs the source group id, the clone id and name and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (g groups.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("source_id", id),
+			slog.Group("group",
+				slog.String("id", g.ID),
+				slog.String("name", g.Name),
+				slog.String("parent_id", g.Parent),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Clone group failed", args...)
+			return
+		}
+		lm.logger.Info("Clone group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.CloneGroup(ctx, token, kind, id, parentID, name)
+}
+
+// ExportGroup logs the export_group request. It logs the group id, the blueprint version and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ExportGroup(ctx context.Context, token, id string) (bp groups.Blueprint, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", id),
+			slog.Uint64("version", bp.Version),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export group failed", args...)
+			return
+		}
+		lm.logger.Info("Export group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ExportGroup(ctx, token, id)
+}
+
+// ImportGroup logs the import_group request. It logs the domain id, the blueprint version, the created group id and name and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ImportGroup(ctx context.Context, token, kind, domainID string, bp groups.Blueprint) (g groups.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Uint64("version", bp.Version),
+			slog.Group("group",
+				slog.String("id", g.ID),
+				slog.String("name", g.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Import group failed", args...)
+			return
+		}
+		lm.logger.Info("Import group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ImportGroup(ctx, token, kind, domainID, bp)
+}
+
 // UpdateGroup logs the update_group request. It logs the group name, id and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) UpdateGroup(ctx context.Context, token string, group groups.Group) (g groups.Group, err error) {
@@ -61,11 +126,63 @@ func (lm *loggingMiddleware) UpdateGroup(ctx context.Context, token string, grou
 			lm.logger.Warn("Update group failed", args...)
 			return
 		}
+		if group.Version == 0 {
+			lm.logger.Warn("Update group without version is deprecated and falls back to last-write-wins", args...)
+		}
 		lm.logger.Info("Update group completed successfully", args...)
 	}(time.Now())
 	return lm.svc.UpdateGroup(ctx, token, group)
 }
 
+func (lm *loggingMiddleware) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge, partial bool) (res []groups.GroupUpdate, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("groups", len(gs)),
+			slog.Bool("merge", merge),
+			slog.Bool("partial", partial),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update groups failed", args...)
+			return
+		}
+		failed := 0
+		for _, u := range res {
+			if u.Error != "" {
+				failed++
+			}
+		}
+		args = append(args, slog.Int("failed", failed))
+		lm.logger.Info("Update groups completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateGroups(ctx, token, gs, merge, partial)
+}
+
+// PatchGroup logs the patch_group request. It logs the group id, name and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) PatchGroup(ctx context.Context, token, id string, patch groups.GroupPatch) (g groups.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("group",
+				slog.String("id", id),
+				slog.String("name", g.Name),
+				slog.Uint64("version", g.Version),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Patch group failed", args...)
+			return
+		}
+		lm.logger.Info("Patch group completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PatchGroup(ctx, token, id, patch)
+}
+
 // ViewGroup logs the view_group request. It logs the group name, id and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ViewGroup(ctx context.Context, token, id string) (g groups.Group, err error) {
@@ -121,6 +238,9 @@ func (lm *loggingMiddleware) ListGroups(ctx context.Context, token, memberKind,
 				slog.Uint64("total", cg.Total),
 			),
 		}
+		if gp.MyRole != "" {
+			args = append(args, slog.String("my_role", gp.MyRole))
+		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("List groups failed", args...)
@@ -233,11 +353,181 @@ func (lm *loggingMiddleware) Unassign(ctx context.Context, token, groupID, relat
 	return lm.svc.Unassign(ctx, token, groupID, relation, memberKind, memberIDs...)
 }
 
-func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, token, id string) (err error) {
+func (lm *loggingMiddleware) AssignRoles(ctx context.Context, token, memberID string, assignments []groups.RoleAssignment) (res []groups.RoleAssignment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("member_id", memberID),
+			slog.Int("assignments", len(assignments)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Assign member roles failed", args...)
+			return
+		}
+		lm.logger.Info("Assign member roles completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AssignRoles(ctx, token, memberID, assignments)
+}
+
+func (lm *loggingMiddleware) MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) (res []groups.ThingMove, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", groupID),
+			slog.String("target_group_id", targetGroupID),
+			slog.Int("things", len(thingIDs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Move things between groups failed", args...)
+			return
+		}
+		lm.logger.Info("Move things between groups completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.MoveThings(ctx, token, groupID, targetGroupID, thingIDs)
+}
+
+func (lm *loggingMiddleware) ReassignThings(ctx context.Context, token, groupID, targetGroupID string) (moved []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", groupID),
+			slog.String("target_group_id", targetGroupID),
+			slog.Int("moved", len(moved)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Reassign group things failed", args...)
+			return
+		}
+		lm.logger.Info("Reassign group things completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ReassignThings(ctx, token, groupID, targetGroupID)
+}
+
+func (lm *loggingMiddleware) AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) (res []groups.ThingAttachment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", groupID),
+			slog.Int("things", len(thingIDs)),
+			slog.Bool("reassign", reassign),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Attach things to group failed", args...)
+			return
+		}
+		lm.logger.Info("Attach things to group completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AttachThings(ctx, token, groupID, thingIDs, reassign)
+}
+
+func (lm *loggingMiddleware) ListMemberGroups(ctx context.Context, token, memberID string, pm groups.PageMeta) (page groups.MemberGroupsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("member_id", memberID),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List member groups failed", args...)
+			return
+		}
+		lm.logger.Info("List member groups completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListMemberGroups(ctx, token, memberID, pm)
+}
+
+// ListMemberRoles logs the list_member_roles request. It logs the group id, the inactivity
+// cutoff if any, the number of listed members and the time it took to complete the request.
+func (lm *loggingMiddleware) ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) (members []groups.MemberRole, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("group_id", groupID),
+			slog.Int("members", len(members)),
+		}
+		if !inactiveSince.IsZero() {
+			args = append(args, slog.Time("inactive_since", inactiveSince))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List member roles failed", args...)
+			return
+		}
+		lm.logger.Info("List member roles completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListMemberRoles(ctx, token, groupID, inactiveSince)
+}
+
+// ListDomainMembers logs the list_domain_members request. It logs the domain id, the page
+// and the time it took to complete the request.
+func (lm *loggingMiddleware) ListDomainMembers(ctx context.Context, token, domainID string, pm groups.PageMeta) (page groups.DomainMembersPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List domain members failed", args...)
+			return
+		}
+		lm.logger.Info("List domain members completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListDomainMembers(ctx, token, domainID, pm)
+}
+
+// RevokeMember logs the revoke_member request. It logs the domain id, the member id, the number
+// of groups the member was removed from, the domain permission the member keeps and the time it
+// took to complete the request.
+func (lm *loggingMiddleware) RevokeMember(ctx context.Context, token, domainID, memberID string) (rev groups.MemberRevocation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.String("member_id", memberID),
+			slog.Int("groups", len(rev.Groups)),
+		}
+		if rev.DomainPermission != "" {
+			args = append(args, slog.String("domain_permission", rev.DomainPermission))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke member failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke member completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeMember(ctx, token, domainID, memberID)
+}
+
+func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, token, id string, cascade bool) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
 			slog.String("group_id", id),
+			slog.Bool("cascade", cascade),
 		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
@@ -246,5 +536,30 @@ func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, token, id string)
 		}
 		lm.logger.Info("Delete group completed successfully", args...)
 	}(time.Now())
-	return lm.svc.DeleteGroup(ctx, token, id)
+	return lm.svc.DeleteGroup(ctx, token, id, cascade)
+}
+
+func (lm *loggingMiddleware) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) (res []groups.GroupRemoval, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("groups", len(ids)),
+			slog.Bool("cascade", cascade),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete groups failed", args...)
+			return
+		}
+		failed := 0
+		for _, r := range res {
+			if !r.Removed {
+				failed++
+			}
+		}
+		args = append(args, slog.Int("failed", failed))
+		lm.logger.Info("Delete groups completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeleteGroups(ctx, token, ids, cascade)
 }
@@ -37,6 +37,33 @@ func (ms *metricsMiddleware) CreateGroup(ctx context.Context, token, kind string
 	return ms.svc.CreateGroup(ctx, token, kind, g)
 }
 
+// CloneGroup instruments CloneGroup method with metrics.
+func (ms *metricsMiddleware) CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (groups.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "clone_group").Add(1)
+		ms.latency.With("method", "clone_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CloneGroup(ctx, token, kind, id, parentID, name)
+}
+
+// ExportGroup instruments ExportGroup method with metrics.
+func (ms *metricsMiddleware) ExportGroup(ctx context.Context, token, id string) (groups.Blueprint, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_group").Add(1)
+		ms.latency.With("method", "export_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExportGroup(ctx, token, id)
+}
+
+// ImportGroup instruments ImportGroup method with metrics.
+func (ms *metricsMiddleware) ImportGroup(ctx context.Context, token, kind, domainID string, bp groups.Blueprint) (groups.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "import_group").Add(1)
+		ms.latency.With("method", "import_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ImportGroup(ctx, token, kind, domainID, bp)
+}
+
 // UpdateGroup instruments UpdateGroup method with metrics.
 func (ms *metricsMiddleware) UpdateGroup(ctx context.Context, token string, group groups.Group) (rGroup groups.Group, err error) {
 	defer func(begin time.Time) {
@@ -46,6 +73,25 @@ func (ms *metricsMiddleware) UpdateGroup(ctx context.Context, token string, grou
 	return ms.svc.UpdateGroup(ctx, token, group)
 }
 
+func (ms *metricsMiddleware) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge, partial bool) ([]groups.GroupUpdate, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_groups").Add(1)
+		ms.latency.With("method", "update_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateGroups(ctx, token, gs, merge, partial)
+}
+
+// PatchGroup instruments PatchGroup method with metrics.
+func (ms *metricsMiddleware) PatchGroup(ctx context.Context, token, id string, patch groups.GroupPatch) (groups.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "patch_group").Add(1)
+		ms.latency.With("method", "patch_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PatchGroup(ctx, token, id, patch)
+}
+
 // ViewGroup instruments ViewGroup method with metrics.
 func (ms *metricsMiddleware) ViewGroup(ctx context.Context, token, id string) (g groups.Group, err error) {
 	defer func(begin time.Time) {
@@ -120,10 +166,98 @@ func (ms *metricsMiddleware) Unassign(ctx context.Context, token, groupID, relat
 	return ms.svc.Unassign(ctx, token, groupID, relation, memberKind, memberIDs...)
 }
 
-func (ms *metricsMiddleware) DeleteGroup(ctx context.Context, token, id string) (err error) {
+// AssignRoles instruments AssignRoles method with metrics.
+func (ms *metricsMiddleware) AssignRoles(ctx context.Context, token, memberID string, assignments []groups.RoleAssignment) ([]groups.RoleAssignment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_roles").Add(1)
+		ms.latency.With("method", "assign_roles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AssignRoles(ctx, token, memberID, assignments)
+}
+
+// MoveThings instruments MoveThings method with metrics.
+func (ms *metricsMiddleware) MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) ([]groups.ThingMove, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "move_things").Add(1)
+		ms.latency.With("method", "move_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.MoveThings(ctx, token, groupID, targetGroupID, thingIDs)
+}
+
+func (ms *metricsMiddleware) ReassignThings(ctx context.Context, token, groupID, targetGroupID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "reassign_things").Add(1)
+		ms.latency.With("method", "reassign_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ReassignThings(ctx, token, groupID, targetGroupID)
+}
+
+// AttachThings instruments AttachThings method with metrics.
+func (ms *metricsMiddleware) AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) ([]groups.ThingAttachment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "attach_things").Add(1)
+		ms.latency.With("method", "attach_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AttachThings(ctx, token, groupID, thingIDs, reassign)
+}
+
+// ListMemberGroups instruments ListMemberGroups method with metrics.
+func (ms *metricsMiddleware) ListMemberGroups(ctx context.Context, token, memberID string, pm groups.PageMeta) (groups.MemberGroupsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_member_groups").Add(1)
+		ms.latency.With("method", "list_member_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListMemberGroups(ctx, token, memberID, pm)
+}
+
+// ListMemberRoles instruments ListMemberRoles method with metrics.
+func (ms *metricsMiddleware) ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) ([]groups.MemberRole, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_member_roles").Add(1)
+		ms.latency.With("method", "list_member_roles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListMemberRoles(ctx, token, groupID, inactiveSince)
+}
+
+// ListDomainMembers instruments ListDomainMembers method with metrics.
+func (ms *metricsMiddleware) ListDomainMembers(ctx context.Context, token, domainID string, pm groups.PageMeta) (groups.DomainMembersPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_domain_members").Add(1)
+		ms.latency.With("method", "list_domain_members").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDomainMembers(ctx, token, domainID, pm)
+}
+
+// RevokeMember instruments RevokeMember method with metrics.
+func (ms *metricsMiddleware) RevokeMember(ctx context.Context, token, domainID, memberID string) (groups.MemberRevocation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_member").Add(1)
+		ms.latency.With("method", "revoke_member").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeMember(ctx, token, domainID, memberID)
+}
+
+func (ms *metricsMiddleware) DeleteGroup(ctx context.Context, token, id string, cascade bool) (err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "delete_group").Add(1)
 		ms.latency.With("method", "delete_group").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.DeleteGroup(ctx, token, id)
+	return ms.svc.DeleteGroup(ctx, token, id, cascade)
+}
+
+func (ms *metricsMiddleware) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]groups.GroupRemoval, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_groups").Add(1)
+		ms.latency.With("method", "delete_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DeleteGroups(ctx, token, ids, cascade)
 }
@@ -26,12 +26,17 @@ func (req createGroupReq) validate() error {
 	return nil
 }
 
+func (req createGroupReq) EntitiesMetadata() []map[string]interface{} {
+	return []map[string]interface{}{req.Metadata}
+}
+
 type updateGroupReq struct {
 	token       string
 	id          string
 	Name        string                 `json:"name,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Version     uint64                 `json:"version,omitempty"`
 }
 
 func (req updateGroupReq) validate() error {
@@ -47,6 +52,10 @@ func (req updateGroupReq) validate() error {
 	return nil
 }
 
+func (req updateGroupReq) EntitiesMetadata() []map[string]interface{} {
+	return []map[string]interface{}{req.Metadata}
+}
+
 type listGroupsReq struct {
 	mggroups.Page
 	token      string
@@ -73,13 +82,24 @@ func (req listGroupsReq) validate() error {
 	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
 		return apiutil.ErrLimitSize
 	}
+	// Trees nest the groups, so they can't be limited to fields.
+	if req.tree && len(req.Fields) > 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
 
 	return nil
 }
 
+// groupFields are the fields the group responses can be limited to.
+var groupFields = []string{
+	"id", "domain_id", "parent_id", "name", "description", "metadata", "level", "path",
+	"created_at", "updated_at", "updated_by", "status", "permissions", "version", "thing_count",
+}
+
 type groupReq struct {
-	token string
-	id    string
+	token  string
+	id     string
+	fields []string
 }
 
 func (req groupReq) validate() error {
@@ -93,6 +113,23 @@ func (req groupReq) validate() error {
 	return nil
 }
 
+type deleteGroupReq struct {
+	token   string
+	id      string
+	cascade bool
+}
+
+func (req deleteGroupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type groupPermsReq struct {
 	token string
 	id    string
@@ -149,6 +149,22 @@ func TestListGroupReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrBearerToken,
 		},
+		{
+			desc: "tree with fields",
+			req: listGroupsReq{
+				token:      valid,
+				memberKind: auth.ThingsKind,
+				memberID:   valid,
+				tree:       true,
+				Page: groups.Page{
+					PageMeta: groups.PageMeta{
+						Limit:  10,
+						Fields: []string{"id", "name"},
+					},
+				},
+			},
+			err: apiutil.ErrInvalidQueryParams,
+		},
 		{
 			desc: "empty memberkind",
 			req: listGroupsReq{
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/groups"
 )
 
@@ -19,10 +20,18 @@ var (
 	_ magistrala.Response = (*updateGroupRes)(nil)
 	_ magistrala.Response = (*assignRes)(nil)
 	_ magistrala.Response = (*unassignRes)(nil)
+	_ magistrala.Response = (*countRes)(nil)
 )
 
 type viewGroupRes struct {
 	groups.Group `json:",inline"`
+	// fields limit the response to the requested fields.
+	fields []string
+}
+
+// MarshalJSON limits the group to the requested fields.
+func (res viewGroupRes) MarshalJSON() ([]byte, error) {
+	return api.ProjectFields(res.Group, res.fields)
 }
 
 func (res viewGroupRes) Code() int {
@@ -39,6 +48,7 @@ func (res viewGroupRes) Empty() bool {
 
 type viewGroupPermsRes struct {
 	Permissions []string `json:"permissions"`
+	Actions     []string `json:"actions"`
 }
 
 func (res viewGroupPermsRes) Code() int {
@@ -121,6 +131,22 @@ func (res channelPageRes) Empty() bool {
 	return false
 }
 
+type countRes struct {
+	Total uint64 `json:"total"`
+}
+
+func (res countRes) Code() int {
+	return http.StatusOK
+}
+
+func (res countRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res countRes) Empty() bool {
+	return false
+}
+
 type updateGroupRes struct {
 	groups.Group `json:",inline"`
 }
@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// syncBatchSize is the number of groups whose thing count is synced at once.
+const syncBatchSize = 100
+
+// SyncThingCounts computes the thing count of the groups which don't have
+// it stored from their connection policies, so groups created before the
+// count was kept are listed and limited by their actual connections.
+func SyncThingCounts(ctx context.Context, repo groups.Repository, authClient magistrala.AuthServiceClient) error {
+	for {
+		ids, err := repo.RetrieveUnsyncedThingCounts(ctx, syncBatchSize)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, id := range ids {
+			res, err := authClient.CountObjects(ctx, &magistrala.CountObjectsReq{
+				SubjectType: auth.GroupType,
+				Subject:     id,
+				Permission:  auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+			})
+			if err != nil {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+			// Groups removed in the meantime don't need a count.
+			if err := repo.UpdateThingCount(ctx, id, res.GetCount()); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+		}
+		if len(ids) < syncBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/groups"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncThingCounts(t *testing.T) {
+	full := make([]string, 100)
+	for i := range full {
+		full[i] = testsutil.GenerateUUID(t)
+	}
+	removed := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		batches   [][]string
+		listErr   error
+		countErr  error
+		updateErr error
+		updates   int
+		err       error
+	}{
+		{
+			desc:    "sync thing counts of all unsynced groups",
+			batches: [][]string{full, {removed}},
+			updates: 101,
+		},
+		{
+			desc:    "sync thing counts without unsynced groups",
+			batches: [][]string{{}},
+		},
+		{
+			desc:    "sync thing counts with failed to retrieve unsynced groups",
+			listErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "sync thing counts with failed to count things",
+			batches:  [][]string{{removed}},
+			countErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "sync thing counts of removed group",
+			batches:   [][]string{{removed}},
+			updateErr: repoerr.ErrNotFound,
+			updates:   1,
+		},
+		{
+			desc:      "sync thing counts with failed to update count",
+			batches:   [][]string{{removed}},
+			updateErr: repoerr.ErrUpdateEntity,
+			updates:   1,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+
+			if tc.listErr != nil {
+				repo.On("RetrieveUnsyncedThingCounts", mock.Anything, uint64(100)).Return(nil, tc.listErr)
+			}
+			for _, batch := range tc.batches {
+				repo.On("RetrieveUnsyncedThingCounts", mock.Anything, uint64(100)).Return(batch, nil).Once()
+			}
+			authsvc.On("CountObjects", mock.Anything, mock.Anything).Return(&magistrala.CountObjectsRes{Count: 3}, tc.countErr)
+			repo.On("UpdateThingCount", mock.Anything, mock.Anything, uint64(3)).Return(tc.updateErr)
+
+			err := groups.SyncThingCounts(context.Background(), repo, authsvc)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repo.AssertNumberOfCalls(t, "UpdateThingCount", tc.updates)
+		})
+	}
+}
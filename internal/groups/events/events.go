@@ -11,17 +11,22 @@ import (
 )
 
 var (
-	groupPrefix          = "group."
-	groupCreate          = groupPrefix + "create"
-	groupUpdate          = groupPrefix + "update"
-	groupChangeStatus    = groupPrefix + "change_status"
-	groupView            = groupPrefix + "view"
-	groupViewPerms       = groupPrefix + "view_perms"
-	groupList            = groupPrefix + "list"
-	groupListMemberships = groupPrefix + "list_by_user"
-	groupRemove          = groupPrefix + "remove"
-	groupAssign          = groupPrefix + "assign"
-	groupUnassign        = groupPrefix + "unassign"
+	groupPrefix            = "group."
+	groupCreate            = groupPrefix + "create"
+	groupUpdate            = groupPrefix + "update"
+	groupChangeStatus      = groupPrefix + "change_status"
+	groupView              = groupPrefix + "view"
+	groupViewPerms         = groupPrefix + "view_perms"
+	groupList              = groupPrefix + "list"
+	groupListMemberships   = groupPrefix + "list_by_user"
+	groupListMemberOf      = groupPrefix + "list_member_groups"
+	groupListMemberRoles   = groupPrefix + "list_member_roles"
+	groupListDomainMembers = groupPrefix + "list_domain_members"
+	groupRevokeMember      = groupPrefix + "revoke_member"
+	groupExport            = groupPrefix + "export"
+	groupRemove            = groupPrefix + "remove"
+	groupAssign            = groupPrefix + "assign"
+	groupUnassign          = groupPrefix + "unassign"
 )
 
 var (
@@ -35,6 +40,11 @@ var (
 	_ events.Event = (*viewGroupEvent)(nil)
 	_ events.Event = (*listGroupEvent)(nil)
 	_ events.Event = (*listGroupMembershipEvent)(nil)
+	_ events.Event = (*listMemberGroupsEvent)(nil)
+	_ events.Event = (*listMemberRolesEvent)(nil)
+	_ events.Event = (*listDomainMembersEvent)(nil)
+	_ events.Event = (*revokeMemberEvent)(nil)
+	_ events.Event = (*exportGroupEvent)(nil)
 )
 
 type assignEvent struct {
@@ -259,6 +269,91 @@ func (lgme listGroupMembershipEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type listMemberGroupsEvent struct {
+	memberID string
+	groups.PageMeta
+}
+
+func (lmge listMemberGroupsEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": groupListMemberOf,
+		"member_id": lmge.memberID,
+		"total":     lmge.Total,
+		"offset":    lmge.Offset,
+		"limit":     lmge.Limit,
+	}, nil
+}
+
+type listMemberRolesEvent struct {
+	groupID       string
+	inactiveSince time.Time
+	total         uint64
+}
+
+func (lmre listMemberRolesEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": groupListMemberRoles,
+		"id":        lmre.groupID,
+		"total":     lmre.total,
+	}
+	if !lmre.inactiveSince.IsZero() {
+		val["inactive_since"] = lmre.inactiveSince
+	}
+
+	return val, nil
+}
+
+type listDomainMembersEvent struct {
+	domainID string
+	total    uint64
+	offset   uint64
+	limit    uint64
+}
+
+func (ldme listDomainMembersEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": groupListDomainMembers,
+		"domain":    ldme.domainID,
+		"total":     ldme.total,
+		"offset":    ldme.offset,
+		"limit":     ldme.limit,
+	}, nil
+}
+
+type revokeMemberEvent struct {
+	domainID         string
+	memberID         string
+	groupIDs         []string
+	domainPermission string
+}
+
+func (rme revokeMemberEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": groupRevokeMember,
+		"domain":    rme.domainID,
+		"member_id": rme.memberID,
+		"group_ids": rme.groupIDs,
+	}
+	if rme.domainPermission != "" {
+		val["domain_permission"] = rme.domainPermission
+	}
+
+	return val, nil
+}
+
+type exportGroupEvent struct {
+	id      string
+	version uint64
+}
+
+func (ege exportGroupEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": groupExport,
+		"id":        ege.id,
+		"version":   ege.version,
+	}, nil
+}
+
 type deleteGroupEvent struct {
 	id string
 }
@@ -5,7 +5,10 @@ package events
 
 import (
 	"context"
+	"time"
 
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/groups"
@@ -49,6 +52,69 @@ func (es eventStore) CreateGroup(ctx context.Context, token, kind string, group
 	return group, nil
 }
 
+func (es eventStore) CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (groups.Group, error) {
+	group, err := es.svc.CloneGroup(ctx, token, kind, id, parentID, name)
+	if err != nil {
+		return group, err
+	}
+
+	event := createGroupEvent{
+		group,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return group, err
+	}
+
+	return group, nil
+}
+
+func (es eventStore) ExportGroup(ctx context.Context, token, id string) (groups.Blueprint, error) {
+	bp, err := es.svc.ExportGroup(ctx, token, id)
+	if err != nil {
+		return bp, err
+	}
+	event := exportGroupEvent{
+		id:      id,
+		version: bp.Version,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return bp, err
+	}
+
+	return bp, nil
+}
+
+func (es eventStore) ImportGroup(ctx context.Context, token, kind, domainID string, bp groups.Blueprint) (groups.Group, error) {
+	group, err := es.svc.ImportGroup(ctx, token, kind, domainID, bp)
+	if err != nil {
+		return group, err
+	}
+
+	if err := es.publishCreated(ctx, group); err != nil {
+		return group, err
+	}
+
+	return group, nil
+}
+
+// publishCreated publishes the creation of the group and of its children.
+func (es eventStore) publishCreated(ctx context.Context, group groups.Group) error {
+	g := group
+	g.Children = nil
+	if err := es.Publish(ctx, createGroupEvent{g}); err != nil {
+		return err
+	}
+	for _, child := range group.Children {
+		if err := es.publishCreated(ctx, *child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (es eventStore) UpdateGroup(ctx context.Context, token string, group groups.Group) (groups.Group, error) {
 	group, err := es.svc.UpdateGroup(ctx, token, group)
 	if err != nil {
@@ -66,6 +132,37 @@ func (es eventStore) UpdateGroup(ctx context.Context, token string, group groups
 	return group, nil
 }
 
+func (es eventStore) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge, partial bool) ([]groups.GroupUpdate, error) {
+	res, err := es.svc.UpdateGroups(ctx, token, gs, merge, partial)
+	if err != nil {
+		return res, err
+	}
+
+	for _, u := range res {
+		if u.Group == nil {
+			continue
+		}
+		if err := es.Publish(ctx, updateGroupEvent{*u.Group}); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+func (es eventStore) PatchGroup(ctx context.Context, token, id string, patch groups.GroupPatch) (groups.Group, error) {
+	group, err := es.svc.PatchGroup(ctx, token, id, patch)
+	if err != nil {
+		return group, err
+	}
+
+	if err := es.Publish(ctx, updateGroupEvent{group}); err != nil {
+		return group, err
+	}
+
+	return group, nil
+}
+
 func (es eventStore) ViewGroup(ctx context.Context, token, id string) (groups.Group, error) {
 	group, err := es.svc.ViewGroup(ctx, token, id)
 	if err != nil {
@@ -130,6 +227,79 @@ func (es eventStore) ListMembers(ctx context.Context, token, groupID, permission
 	return mp, nil
 }
 
+func (es eventStore) ListMemberGroups(ctx context.Context, token, memberID string, pm groups.PageMeta) (groups.MemberGroupsPage, error) {
+	page, err := es.svc.ListMemberGroups(ctx, token, memberID, pm)
+	if err != nil {
+		return page, err
+	}
+	event := listMemberGroupsEvent{
+		memberID: memberID,
+		PageMeta: page.PageMeta,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return page, err
+	}
+
+	return page, nil
+}
+
+func (es eventStore) ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) ([]groups.MemberRole, error) {
+	members, err := es.svc.ListMemberRoles(ctx, token, groupID, inactiveSince)
+	if err != nil {
+		return members, err
+	}
+	event := listMemberRolesEvent{
+		groupID:       groupID,
+		inactiveSince: inactiveSince,
+		total:         uint64(len(members)),
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return members, err
+	}
+
+	return members, nil
+}
+
+func (es eventStore) ListDomainMembers(ctx context.Context, token, domainID string, pm groups.PageMeta) (groups.DomainMembersPage, error) {
+	page, err := es.svc.ListDomainMembers(ctx, token, domainID, pm)
+	if err != nil {
+		return page, err
+	}
+	event := listDomainMembersEvent{
+		domainID: domainID,
+		total:    page.Total,
+		offset:   pm.Offset,
+		limit:    pm.Limit,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return page, err
+	}
+
+	return page, nil
+}
+
+func (es eventStore) RevokeMember(ctx context.Context, token, domainID, memberID string) (groups.MemberRevocation, error) {
+	rev, err := es.svc.RevokeMember(ctx, token, domainID, memberID)
+	if err != nil {
+		return rev, err
+	}
+	event := revokeMemberEvent{
+		domainID:         domainID,
+		memberID:         memberID,
+		groupIDs:         rev.Groups,
+		domainPermission: rev.DomainPermission,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return rev, err
+	}
+
+	return rev, nil
+}
+
 func (es eventStore) EnableGroup(ctx context.Context, token, id string) (groups.Group, error) {
 	group, err := es.svc.EnableGroup(ctx, token, id)
 	if err != nil {
@@ -158,6 +328,146 @@ func (es eventStore) Assign(ctx context.Context, token, groupID, relation, membe
 	return nil
 }
 
+func (es eventStore) AssignRoles(ctx context.Context, token, memberID string, assignments []groups.RoleAssignment) ([]groups.RoleAssignment, error) {
+	res, err := es.svc.AssignRoles(ctx, token, memberID, assignments)
+	if err != nil {
+		return res, err
+	}
+
+	for _, a := range res {
+		if a.Error != "" {
+			continue
+		}
+		event := assignEvent{
+			groupID:    a.GroupID,
+			relation:   a.Role,
+			memberKind: auth.UsersKind,
+			memberIDs:  []string{memberID},
+		}
+		if err := es.Publish(ctx, event); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+func (es eventStore) MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) ([]groups.ThingMove, error) {
+	res, err := es.svc.MoveThings(ctx, token, groupID, targetGroupID, thingIDs)
+	if err != nil {
+		return res, err
+	}
+
+	var moved []string
+	for _, m := range res {
+		if m.Error == "" {
+			moved = append(moved, m.ThingID)
+		}
+	}
+	if len(moved) == 0 {
+		return res, nil
+	}
+
+	unassign := unassignEvent{
+		groupID:    groupID,
+		relation:   auth.GroupRelation,
+		memberKind: auth.ThingsKind,
+		memberIDs:  moved,
+	}
+	if err := es.Publish(ctx, unassign); err != nil {
+		return res, err
+	}
+	assign := assignEvent{
+		groupID:    targetGroupID,
+		relation:   auth.GroupRelation,
+		memberKind: auth.ThingsKind,
+		memberIDs:  moved,
+	}
+	if err := es.Publish(ctx, assign); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// ReassignThings publishes the events of the moved things even if the
+// reassignment failed part way, since the previous batches stay moved.
+func (es eventStore) ReassignThings(ctx context.Context, token, groupID, targetGroupID string) ([]string, error) {
+	moved, err := es.svc.ReassignThings(ctx, token, groupID, targetGroupID)
+	if len(moved) == 0 {
+		return moved, err
+	}
+
+	unassign := unassignEvent{
+		groupID:    groupID,
+		relation:   auth.GroupRelation,
+		memberKind: auth.ThingsKind,
+		memberIDs:  moved,
+	}
+	if errPublish := es.Publish(ctx, unassign); errPublish != nil {
+		return moved, errors.Wrap(errPublish, err)
+	}
+	assign := assignEvent{
+		groupID:    targetGroupID,
+		relation:   auth.GroupRelation,
+		memberKind: auth.ThingsKind,
+		memberIDs:  moved,
+	}
+	if errPublish := es.Publish(ctx, assign); errPublish != nil {
+		return moved, errors.Wrap(errPublish, err)
+	}
+
+	return moved, err
+}
+
+func (es eventStore) AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) ([]groups.ThingAttachment, error) {
+	res, err := es.svc.AttachThings(ctx, token, groupID, thingIDs, reassign)
+	if err != nil {
+		return res, err
+	}
+
+	var attached []string
+	detached := map[string][]string{}
+	var gids []string
+	for _, a := range res {
+		if a.Error != "" {
+			continue
+		}
+		attached = append(attached, a.ThingID)
+		for _, gid := range a.Detached {
+			if _, ok := detached[gid]; !ok {
+				gids = append(gids, gid)
+			}
+			detached[gid] = append(detached[gid], a.ThingID)
+		}
+	}
+	for _, gid := range gids {
+		unassign := unassignEvent{
+			groupID:    gid,
+			relation:   auth.GroupRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  detached[gid],
+		}
+		if err := es.Publish(ctx, unassign); err != nil {
+			return res, err
+		}
+	}
+	if len(attached) == 0 {
+		return res, nil
+	}
+	assign := assignEvent{
+		groupID:    groupID,
+		relation:   auth.GroupRelation,
+		memberKind: auth.ThingsKind,
+		memberIDs:  attached,
+	}
+	if err := es.Publish(ctx, assign); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
 func (es eventStore) Unassign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) error {
 	if err := es.svc.Unassign(ctx, token, groupID, relation, memberKind, memberIDs...); err != nil {
 		return err
@@ -200,8 +510,8 @@ func (es eventStore) changeStatus(ctx context.Context, group groups.Group) (grou
 	return group, nil
 }
 
-func (es eventStore) DeleteGroup(ctx context.Context, token, id string) error {
-	if err := es.svc.DeleteGroup(ctx, token, id); err != nil {
+func (es eventStore) DeleteGroup(ctx context.Context, token, id string, cascade bool) error {
+	if err := es.svc.DeleteGroup(ctx, token, id, cascade); err != nil {
 		return err
 	}
 	if err := es.Publish(ctx, deleteGroupEvent{id}); err != nil {
@@ -209,3 +519,21 @@ func (es eventStore) DeleteGroup(ctx context.Context, token, id string) error {
 	}
 	return nil
 }
+
+func (es eventStore) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]groups.GroupRemoval, error) {
+	res, err := es.svc.DeleteGroups(ctx, token, ids, cascade)
+	if err != nil {
+		return res, err
+	}
+
+	for _, r := range res {
+		if !r.Removed {
+			continue
+		}
+		if err := es.Publish(ctx, deleteGroupEvent{r.ID}); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// ListGroupMembers retrieves the members of the group along with the most
+// privileged role each of them holds in it, ordered by their roles from the
+// most privileged one.
+func ListGroupMembers(ctx context.Context, authClient magistrala.AuthServiceClient, groupID string) ([]groups.MemberRole, error) {
+	members := []groups.MemberRole{}
+	seen := make(map[string]struct{})
+	for _, role := range memberRoles {
+		duids, err := authClient.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+			SubjectType: auth.UserType,
+			Permission:  role,
+			Object:      groupID,
+			ObjectType:  auth.GroupType,
+		})
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		// A member holding several roles in the group is reported with the most privileged one.
+		for _, duid := range duids.Policies {
+			_, memberID := auth.DecodeDomainUserID(duid)
+			if _, ok := seen[memberID]; ok {
+				continue
+			}
+			seen[memberID] = struct{}{}
+			members = append(members, groups.MemberRole{MemberID: memberID, Role: role})
+		}
+	}
+
+	return members, nil
+}
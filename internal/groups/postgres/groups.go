@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -57,25 +58,41 @@ func (repo groupRepository) Save(ctx context.Context, g mggroups.Group) (mggroup
 }
 
 func (repo groupRepository) Update(ctx context.Context, g mggroups.Group) (mggroups.Group, error) {
-	var query []string
-	var upq string
-	if g.Name != "" {
-		query = append(query, "name = :name,")
+	g.Status = mgclients.EnabledStatus
+	dbu, err := toDBGroup(g)
+	if err != nil {
+		return mggroups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
-	if g.Description != "" {
-		query = append(query, "description = :description,")
+
+	row, err := repo.db.NamedQueryContext(ctx, updateQuery(g), dbu)
+	if err != nil {
+		return mggroups.Group{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
 	}
-	if g.Metadata != nil {
-		query = append(query, "metadata = :metadata,")
+
+	defer row.Close()
+	if ok := row.Next(); !ok {
+		if g.Version != 0 {
+			// Distinguish a stale version from a missing or disabled group.
+			if grp, err := repo.RetrieveByID(ctx, g.ID); err == nil && grp.Status == mgclients.EnabledStatus {
+				return mggroups.Group{}, repoerr.ErrConflict
+			}
+		}
+		return mggroups.Group{}, errors.Wrap(repoerr.ErrNotFound, row.Err())
 	}
-	if len(query) > 0 {
-		upq = strings.Join(query, " ")
+	dbu = dbGroup{}
+	if err := row.StructScan(&dbu); err != nil {
+		return mggroups.Group{}, errors.Wrap(err, repoerr.ErrUpdateEntity)
 	}
-	g.Status = mgclients.EnabledStatus
-	q := fmt.Sprintf(`UPDATE groups SET %s updated_at = :updated_at, updated_by = :updated_by
-		WHERE id = :id AND status = :status
-		RETURNING id, name, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status`, upq)
+	return toGroup(dbu)
+}
 
+func (repo groupRepository) Replace(ctx context.Context, g mggroups.Group) (mggroups.Group, error) {
+	q := `UPDATE groups SET name = :name, description = :description, metadata = :metadata, updated_at = :updated_at, updated_by = :updated_by,
+		version = version + 1
+		WHERE id = :id AND status = :status AND version = :version
+		RETURNING id, name, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status, version, thing_count`
+
+	g.Status = mgclients.EnabledStatus
 	dbu, err := toDBGroup(g)
 	if err != nil {
 		return mggroups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
@@ -88,18 +105,111 @@ func (repo groupRepository) Update(ctx context.Context, g mggroups.Group) (mggro
 
 	defer row.Close()
 	if ok := row.Next(); !ok {
+		// Distinguish a stale version from a missing or disabled group.
+		if grp, err := repo.RetrieveByID(ctx, g.ID); err == nil && grp.Status == mgclients.EnabledStatus {
+			return mggroups.Group{}, repoerr.ErrConflict
+		}
 		return mggroups.Group{}, errors.Wrap(repoerr.ErrNotFound, row.Err())
 	}
 	dbu = dbGroup{}
 	if err := row.StructScan(&dbu); err != nil {
 		return mggroups.Group{}, errors.Wrap(err, repoerr.ErrUpdateEntity)
 	}
+
 	return toGroup(dbu)
 }
 
+func (repo groupRepository) UpdateAll(ctx context.Context, gs ...mggroups.Group) (ugs []mggroups.Group, err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(err, errRollback)
+			}
+		}
+	}()
+
+	for _, g := range gs {
+		ug, err := updateGroup(ctx, tx, g)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Errorf("group %s", g.ID))
+		}
+		ugs = append(ugs, ug)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return ugs, nil
+}
+
+func updateGroup(ctx context.Context, tx *sqlx.Tx, g mggroups.Group) (mggroups.Group, error) {
+	g.Status = mgclients.EnabledStatus
+	dbu, err := toDBGroup(g)
+	if err != nil {
+		return mggroups.Group{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, tx, updateQuery(g), dbu)
+	if err != nil {
+		return mggroups.Group{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+	if ok := row.Next(); !ok {
+		if err := row.Err(); err != nil {
+			return mggroups.Group{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+		row.Close()
+		if g.Version != 0 {
+			// Distinguish a stale version from a missing or disabled group.
+			var exists bool
+			q := `SELECT EXISTS (SELECT 1 FROM groups WHERE id = $1 AND status = $2)`
+			if err := tx.QueryRowxContext(ctx, q, g.ID, mgclients.EnabledStatus).Scan(&exists); err == nil && exists {
+				return mggroups.Group{}, repoerr.ErrConflict
+			}
+		}
+		return mggroups.Group{}, repoerr.ErrNotFound
+	}
+	dbu = dbGroup{}
+	if err := row.StructScan(&dbu); err != nil {
+		return mggroups.Group{}, errors.Wrap(err, repoerr.ErrUpdateEntity)
+	}
+
+	return toGroup(dbu)
+}
+
+// updateQuery builds the query updating the fields set in the group. A
+// non-zero version restricts the update to that version of the group.
+func updateQuery(g mggroups.Group) string {
+	var query []string
+	var upq, vq string
+	if g.Name != "" {
+		query = append(query, "name = :name,")
+	}
+	if g.Description != "" {
+		query = append(query, "description = :description,")
+	}
+	if g.Metadata != nil {
+		query = append(query, "metadata = :metadata,")
+	}
+	if len(query) > 0 {
+		upq = strings.Join(query, " ")
+	}
+	if g.Version != 0 {
+		vq = "AND version = :version"
+	}
+
+	return fmt.Sprintf(`UPDATE groups SET %s updated_at = :updated_at, updated_by = :updated_by, version = version + 1
+		WHERE id = :id AND status = :status %s
+		RETURNING id, name, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status, version, thing_count`, upq, vq)
+}
+
 func (repo groupRepository) ChangeStatus(ctx context.Context, group mggroups.Group) (mggroups.Group, error) {
 	qc := `UPDATE groups SET status = :status, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
-	RETURNING id, name, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status`
+	RETURNING id, name, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status, thing_count`
 
 	dbg, err := toDBGroup(group)
 	if err != nil {
@@ -122,7 +232,7 @@ func (repo groupRepository) ChangeStatus(ctx context.Context, group mggroups.Gro
 }
 
 func (repo groupRepository) RetrieveByID(ctx context.Context, id string) (mggroups.Group, error) {
-	q := `SELECT id, name, domain_id, COALESCE(parent_id, '') AS parent_id, description, metadata, created_at, updated_at, updated_by, status FROM groups
+	q := `SELECT id, name, domain_id, COALESCE(parent_id, '') AS parent_id, description, metadata, created_at, updated_at, updated_by, status, version, thing_count FROM groups
 	    WHERE id = :id`
 
 	dbg := dbGroup{
@@ -153,24 +263,26 @@ func (repo groupRepository) RetrieveAll(ctx context.Context, gm mggroups.Page) (
 		q = buildHierachy(gm)
 	}
 	if gm.ID == "" {
-		q = `SELECT DISTINCT g.id, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.name, g.description,
-		g.metadata, g.created_at, g.updated_at, g.updated_by, g.status FROM groups g`
+		q = fmt.Sprintf(`SELECT g.id, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.name, g.description,
+		%s, g.created_at, g.updated_at, g.updated_by, g.status, g.thing_count FROM groups g`, metadataColumn(gm))
 	}
-	q = fmt.Sprintf("%s %s ORDER BY g.created_at LIMIT :limit OFFSET :offset;", q, query)
+	q = fmt.Sprintf("%s %s %s LIMIT :limit OFFSET :offset;", q, query, applyOrdering(gm))
 
 	dbPage, err := toDBGroupPage(gm)
 	if err != nil {
 		return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
 	}
-	rows, err := repo.db.NamedQueryContext(ctx, q, dbPage)
-	if err != nil {
-		return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
-	}
-	defer rows.Close()
+	var items []mggroups.Group
+	if !gm.CountOnly {
+		rows, err := repo.db.NamedQueryContext(ctx, q, dbPage)
+		if err != nil {
+			return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+		}
+		defer rows.Close()
 
-	items, err := repo.processRows(rows)
-	if err != nil {
-		return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+		if items, err = repo.processRows(rows); err != nil {
+			return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+		}
 	}
 
 	cq := "SELECT COUNT(*) FROM groups g"
@@ -201,24 +313,26 @@ func (repo groupRepository) RetrieveByIDs(ctx context.Context, gm mggroups.Page,
 		q = buildHierachy(gm)
 	}
 	if gm.ID == "" {
-		q = `SELECT DISTINCT g.id, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.name, g.description,
-		g.metadata, g.created_at, g.updated_at, g.updated_by, g.status FROM groups g`
+		q = fmt.Sprintf(`SELECT g.id, g.domain_id, COALESCE(g.parent_id, '') AS parent_id, g.name, g.description,
+		%s, g.created_at, g.updated_at, g.updated_by, g.status, g.thing_count FROM groups g`, metadataColumn(gm))
 	}
-	q = fmt.Sprintf("%s %s ORDER BY g.created_at LIMIT :limit OFFSET :offset;", q, query)
+	q = fmt.Sprintf("%s %s %s LIMIT :limit OFFSET :offset;", q, query, applyOrdering(gm))
 
 	dbPage, err := toDBGroupPage(gm)
 	if err != nil {
 		return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
 	}
-	rows, err := repo.db.NamedQueryContext(ctx, q, dbPage)
-	if err != nil {
-		return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
-	}
-	defer rows.Close()
+	var items []mggroups.Group
+	if !gm.CountOnly {
+		rows, err := repo.db.NamedQueryContext(ctx, q, dbPage)
+		if err != nil {
+			return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+		}
+		defer rows.Close()
 
-	items, err := repo.processRows(rows)
-	if err != nil {
-		return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+		if items, err = repo.processRows(rows); err != nil {
+			return mggroups.Page{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+		}
 	}
 
 	cq := "SELECT COUNT(*) FROM groups g"
@@ -292,6 +406,144 @@ func (repo groupRepository) UnassignParentGroup(ctx context.Context, parentGroup
 	return nil
 }
 
+func (repo groupRepository) RetrieveChildrenIDs(ctx context.Context, parentGroupID string) ([]string, error) {
+	q := "SELECT id FROM groups WHERE parent_id = $1;"
+
+	rows, err := repo.db.QueryContext(ctx, q, parentGroupID)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (repo groupRepository) RetrieveUnsyncedThingCounts(ctx context.Context, limit uint64) ([]string, error) {
+	q := `SELECT id FROM groups WHERE NOT thing_count_synced ORDER BY id LIMIT $1`
+
+	rows, err := repo.db.QueryContext(ctx, q, limit)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (repo groupRepository) UpdateThingCount(ctx context.Context, groupID string, count uint64) error {
+	q := `UPDATE groups SET thing_count = $2, thing_count_synced = TRUE WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, groupID, count)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo groupRepository) SaveActivity(ctx context.Context, groupID, memberID string, at time.Time) error {
+	q := `INSERT INTO group_activity (group_id, member_id, last_active) VALUES ($1, $2, $3)
+		ON CONFLICT (group_id, member_id) DO UPDATE SET last_active = GREATEST(group_activity.last_active, EXCLUDED.last_active)`
+
+	if _, err := repo.db.ExecContext(ctx, q, groupID, memberID, at.UTC()); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo groupRepository) RetrieveActivity(ctx context.Context, groupID string) (map[string]time.Time, error) {
+	q := "SELECT member_id, last_active FROM group_activity WHERE group_id = $1;"
+
+	rows, err := repo.db.QueryContext(ctx, q, groupID)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	activity := make(map[string]time.Time)
+	for rows.Next() {
+		var memberID string
+		var at time.Time
+		if err := rows.Scan(&memberID, &at); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		activity[memberID] = at
+	}
+
+	return activity, nil
+}
+
+func (repo groupRepository) ReserveThings(ctx context.Context, groupID string, n uint64) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(err, errRollback)
+			}
+		}
+	}()
+
+	// The row lock serializes the reservations of the group, so the count
+	// is checked against the reservations which committed before.
+	var count uint64
+	var meta []byte
+	q := `SELECT thing_count, metadata FROM groups WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRowxContext(ctx, q, groupID).Scan(&count, &meta); err != nil {
+		if err == sql.ErrNoRows {
+			return repoerr.ErrNotFound
+		}
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	var metadata mgclients.Metadata
+	if len(meta) > 0 {
+		if err := json.Unmarshal(meta, &metadata); err != nil {
+			return errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+	limit, err := mggroups.MaxThingsFromMetadata(metadata)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if limit > 0 && count+n > limit {
+		return errors.Wrap(mggroups.ErrConnectionLimitExceeded, fmt.Errorf("%d of %d things connected, can't connect %d more", count, limit, n))
+	}
+
+	q = `UPDATE groups SET thing_count = thing_count + $2 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, q, groupID, n); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (repo groupRepository) Delete(ctx context.Context, groupID string) error {
 	q := "DELETE FROM groups AS g WHERE g.id = $1;"
 
@@ -305,26 +557,75 @@ func (repo groupRepository) Delete(ctx context.Context, groupID string) error {
 	return nil
 }
 
+func (repo groupRepository) DeleteAll(ctx context.Context, groupIDs ...string) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(err, errRollback)
+			}
+		}
+	}()
+
+	q := "DELETE FROM groups AS g WHERE g.id = $1;"
+	for _, id := range groupIDs {
+		result, err := tx.ExecContext(ctx, q, id)
+		if err != nil {
+			return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return errors.Wrap(repoerr.ErrNotFound, fmt.Errorf("group %s", id))
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 func buildHierachy(gm mggroups.Page) string {
 	query := ""
 	switch {
 	case gm.Direction >= 0: // ancestors
 		query = `WITH RECURSIVE groups_cte as (
-			SELECT id, COALESCE(parent_id, '') AS parent_id, domain_id, name, description, metadata, created_at, updated_at, updated_by, status, 0 as level from groups WHERE id = :id
-			UNION SELECT x.id, COALESCE(x.parent_id, '') AS parent_id, x.domain_id, x.name, x.description, x.metadata, x.created_at, x.updated_at, x.updated_by, x.status, level - 1 from groups x
+			SELECT id, COALESCE(parent_id, '') AS parent_id, domain_id, name, description, metadata, created_at, updated_at, updated_by, status, thing_count, 0 as level from groups WHERE id = :id
+			UNION SELECT x.id, COALESCE(x.parent_id, '') AS parent_id, x.domain_id, x.name, x.description, x.metadata, x.created_at, x.updated_at, x.updated_by, x.status, x.thing_count, level - 1 from groups x
 			INNER JOIN groups_cte a ON a.parent_id = x.id
 		) SELECT * FROM groups_cte g`
 
 	case gm.Direction < 0: // descendants
 		query = `WITH RECURSIVE groups_cte as (
-			SELECT id, COALESCE(parent_id, '') AS parent_id, domain_id, name, description, metadata, created_at, updated_at, updated_by, status, 0 as level, CONCAT('', '', id) as path from groups WHERE id = :id
-			UNION SELECT x.id, COALESCE(x.parent_id, '') AS parent_id, x.domain_id, x.name, x.description, x.metadata, x.created_at, x.updated_at, x.updated_by, x.status, level + 1, CONCAT(path, '.', x.id) as path from groups x
+			SELECT id, COALESCE(parent_id, '') AS parent_id, domain_id, name, description, metadata, created_at, updated_at, updated_by, status, thing_count, 0 as level, CONCAT('', '', id) as path from groups WHERE id = :id
+			UNION SELECT x.id, COALESCE(x.parent_id, '') AS parent_id, x.domain_id, x.name, x.description, x.metadata, x.created_at, x.updated_at, x.updated_by, x.status, x.thing_count, level + 1, CONCAT(path, '.', x.id) as path from groups x
 			INNER JOIN groups_cte d ON d.id = x.parent_id
 		) SELECT * FROM groups_cte g`
 	}
 	return query
 }
 
+func applyOrdering(gm mggroups.Page) string {
+	if !gm.UpdatedSince.IsZero() {
+		// Changes are listed in the order they were made, with the ID
+		// breaking the ties so the pages are stable.
+		return "ORDER BY COALESCE(g.updated_at, g.created_at), g.id"
+	}
+	dir := "ASC"
+	if gm.Dir == mggroups.DescDir {
+		dir = "DESC"
+	}
+	switch gm.Order {
+	case mggroups.NameOrder, mggroups.UpdatedAtOrder, mggroups.ThingCountOrder:
+		// Created at breaks the ties so the pages are stable.
+		return fmt.Sprintf("ORDER BY g.%s %s, g.created_at", gm.Order, dir)
+	default:
+		return fmt.Sprintf("ORDER BY g.created_at %s", dir)
+	}
+}
+
 func buildQuery(gm mggroups.Page, ids ...string) string {
 	queries := []string{}
 
@@ -343,6 +644,10 @@ func buildQuery(gm mggroups.Page, ids ...string) string {
 	if len(gm.Metadata) > 0 {
 		queries = append(queries, "g.metadata @> :metadata")
 	}
+	if !gm.UpdatedSince.IsZero() {
+		// Groups which were never updated count as updated when created.
+		queries = append(queries, "COALESCE(g.updated_at, g.created_at) > :updated_since")
+	}
 	if len(queries) > 0 {
 		return fmt.Sprintf("WHERE %s", strings.Join(queries, " AND "))
 	}
@@ -350,6 +655,16 @@ func buildQuery(gm mggroups.Page, ids ...string) string {
 	return ""
 }
 
+// metadataColumn returns the metadata column, or NULL if the metadata isn't
+// among the page fields, so the column isn't read.
+func metadataColumn(gm mggroups.Page) string {
+	if len(gm.Fields) == 0 || slices.Contains(gm.Fields, "metadata") {
+		return "g.metadata"
+	}
+
+	return "NULL AS metadata"
+}
+
 type dbGroup struct {
 	ID          string           `db:"id"`
 	ParentID    *string          `db:"parent_id,omitempty"`
@@ -363,6 +678,8 @@ type dbGroup struct {
 	UpdatedAt   sql.NullTime     `db:"updated_at,omitempty"`
 	UpdatedBy   *string          `db:"updated_by,omitempty"`
 	Status      mgclients.Status `db:"status"`
+	Version     uint64           `db:"version"`
+	ThingCount  uint64           `db:"thing_count"`
 }
 
 func toDBGroup(g mggroups.Group) (dbGroup, error) {
@@ -398,6 +715,8 @@ func toDBGroup(g mggroups.Group) (dbGroup, error) {
 		UpdatedAt:   updatedAt,
 		UpdatedBy:   updatedBy,
 		Status:      g.Status,
+		Version:     g.Version,
+		ThingCount:  g.ThingCount,
 	}, nil
 }
 
@@ -434,6 +753,8 @@ func toGroup(g dbGroup) (mggroups.Group, error) {
 		UpdatedBy:   updatedBy,
 		CreatedAt:   g.CreatedAt,
 		Status:      g.Status,
+		Version:     g.Version,
+		ThingCount:  g.ThingCount,
 	}, nil
 }
 
@@ -451,35 +772,37 @@ func toDBGroupPage(pm mggroups.Page) (dbGroupPage, error) {
 		data = b
 	}
 	return dbGroupPage{
-		ID:       pm.ID,
-		Name:     pm.Name,
-		Metadata: data,
-		Path:     pm.Path,
-		Level:    level,
-		Total:    pm.Total,
-		Offset:   pm.Offset,
-		Limit:    pm.Limit,
-		ParentID: pm.ID,
-		DomainID: pm.DomainID,
-		Status:   pm.Status,
+		ID:           pm.ID,
+		Name:         pm.Name,
+		Metadata:     data,
+		Path:         pm.Path,
+		Level:        level,
+		Total:        pm.Total,
+		Offset:       pm.Offset,
+		Limit:        pm.Limit,
+		ParentID:     pm.ID,
+		DomainID:     pm.DomainID,
+		Status:       pm.Status,
+		UpdatedSince: pm.UpdatedSince,
 	}, nil
 }
 
 type dbGroupPage struct {
-	ClientID string           `db:"client_id"`
-	ID       string           `db:"id"`
-	Name     string           `db:"name"`
-	ParentID string           `db:"parent_id"`
-	DomainID string           `db:"domain_id"`
-	Metadata []byte           `db:"metadata"`
-	Path     string           `db:"path"`
-	Level    uint64           `db:"level"`
-	Total    uint64           `db:"total"`
-	Limit    uint64           `db:"limit"`
-	Offset   uint64           `db:"offset"`
-	Subject  string           `db:"subject"`
-	Action   string           `db:"action"`
-	Status   mgclients.Status `db:"status"`
+	ClientID     string           `db:"client_id"`
+	ID           string           `db:"id"`
+	Name         string           `db:"name"`
+	ParentID     string           `db:"parent_id"`
+	DomainID     string           `db:"domain_id"`
+	Metadata     []byte           `db:"metadata"`
+	Path         string           `db:"path"`
+	Level        uint64           `db:"level"`
+	Total        uint64           `db:"total"`
+	Limit        uint64           `db:"limit"`
+	Offset       uint64           `db:"offset"`
+	Subject      string           `db:"subject"`
+	Action       string           `db:"action"`
+	Status       mgclients.Status `db:"status"`
+	UpdatedSince time.Time        `db:"updated_since"`
 }
 
 func (repo groupRepository) processRows(rows *sqlx.Rows) ([]mggroups.Group, error) {
@@ -271,6 +271,199 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestReplace(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	_, err := repo.Save(context.Background(), validGroup)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+	group, err := repo.RetrieveByID(context.Background(), validGroup.ID)
+	require.Nil(t, err, fmt.Sprintf("retrieve group unexpected error: %s", err))
+	disabledGroup := validGroup
+	disabledGroup.ID = testsutil.GenerateUUID(t)
+	disabledGroup.Status = clients.DisabledStatus
+	_, err = repo.Save(context.Background(), disabledGroup)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		group mggroups.Group
+		err   error
+	}{
+		{
+			desc: "replace group successfully",
+			group: mggroups.Group{
+				ID:          group.ID,
+				Name:        namegen.Generate(),
+				Description: strings.Repeat("a", 64),
+				Metadata:    map[string]interface{}{"key": "value"},
+				UpdatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy:   testsutil.GenerateUUID(t),
+				Version:     group.Version,
+			},
+		},
+		{
+			desc: "replace group clearing description and metadata",
+			group: mggroups.Group{
+				ID:        group.ID,
+				Name:      namegen.Generate(),
+				UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy: testsutil.GenerateUUID(t),
+				Version:   group.Version + 1,
+			},
+		},
+		{
+			desc: "replace group with stale version",
+			group: mggroups.Group{
+				ID:        group.ID,
+				Name:      namegen.Generate(),
+				UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy: testsutil.GenerateUUID(t),
+				Version:   group.Version,
+			},
+			err: repoerr.ErrConflict,
+		},
+		{
+			desc: "replace disabled group",
+			group: mggroups.Group{
+				ID:        disabledGroup.ID,
+				Name:      namegen.Generate(),
+				UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy: testsutil.GenerateUUID(t),
+				Version:   group.Version,
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "replace group with invalid ID",
+			group: mggroups.Group{
+				ID:        testsutil.GenerateUUID(t),
+				Name:      namegen.Generate(),
+				UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+				UpdatedBy: testsutil.GenerateUUID(t),
+				Version:   group.Version,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		switch group, err := repo.Replace(context.Background(), tc.group); {
+		case err == nil:
+			assert.Nil(t, tc.err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.group.Name, group.Name, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.group.Name, group.Name))
+			assert.Equal(t, tc.group.Description, group.Description, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.group.Description, group.Description))
+			assert.Equal(t, len(tc.group.Metadata), len(group.Metadata), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.group.Metadata, group.Metadata))
+			assert.Equal(t, tc.group.Version+1, group.Version, fmt.Sprintf("%s: expected version %d got %d\n", tc.desc, tc.group.Version+1, group.Version))
+		default:
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+	}
+}
+
+func TestUpdateAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	first, err := repo.Save(context.Background(), validGroup)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+	second := validGroup
+	second.ID = testsutil.GenerateUUID(t)
+	second.Name = namegen.Generate()
+	second, err = repo.Save(context.Background(), second)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		groups []mggroups.Group
+		err    error
+	}{
+		{
+			desc: "update groups successfully",
+			groups: []mggroups.Group{
+				{
+					ID:        first.ID,
+					Name:      namegen.Generate(),
+					UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+					UpdatedBy: testsutil.GenerateUUID(t),
+				},
+				{
+					ID:        second.ID,
+					Metadata:  map[string]interface{}{"key": "updated"},
+					UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+					UpdatedBy: testsutil.GenerateUUID(t),
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "update groups with missing group",
+			groups: []mggroups.Group{
+				{
+					ID:        first.ID,
+					Name:      namegen.Generate(),
+					UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+					UpdatedBy: testsutil.GenerateUUID(t),
+				},
+				{
+					ID:        testsutil.GenerateUUID(t),
+					Name:      namegen.Generate(),
+					UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+					UpdatedBy: testsutil.GenerateUUID(t),
+				},
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "update groups with stale version",
+			groups: []mggroups.Group{
+				{
+					ID:        first.ID,
+					Name:      namegen.Generate(),
+					UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+					UpdatedBy: testsutil.GenerateUUID(t),
+				},
+				{
+					ID:        second.ID,
+					Name:      namegen.Generate(),
+					Version:   1,
+					UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+					UpdatedBy: testsutil.GenerateUUID(t),
+				},
+			},
+			err: repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		before, err := repo.RetrieveByID(context.Background(), first.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: retrieve group unexpected error: %s", tc.desc, err))
+		switch groups, err := repo.UpdateAll(context.Background(), tc.groups...); {
+		case err == nil:
+			assert.Equal(t, len(tc.groups), len(groups), fmt.Sprintf("%s: expected %d groups got %d\n", tc.desc, len(tc.groups), len(groups)))
+			for i, g := range groups {
+				assert.Equal(t, tc.groups[i].ID, g.ID, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.groups[i].ID, g.ID))
+				assert.Equal(t, tc.groups[i].UpdatedBy, g.UpdatedBy, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.groups[i].UpdatedBy, g.UpdatedBy))
+			}
+		default:
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			// The groups updated before the failure are rolled back.
+			after, err := repo.RetrieveByID(context.Background(), first.ID)
+			require.Nil(t, err, fmt.Sprintf("%s: retrieve group unexpected error: %s", tc.desc, err))
+			assert.Equal(t, before.Name, after.Name, fmt.Sprintf("%s: expected name %s got %s\n", tc.desc, before.Name, after.Name))
+			assert.Equal(t, before.Version, after.Version, fmt.Sprintf("%s: expected version %d got %d\n", tc.desc, before.Version, after.Version))
+		}
+	}
+}
+
 func TestChangeStatus(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -1047,6 +1240,58 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	parent := validGroup
+	parent.ID = testsutil.GenerateUUID(t)
+	_, err := repo.Save(context.Background(), parent)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+	child := validGroup
+	child.ID = testsutil.GenerateUUID(t)
+	child.Parent = parent.ID
+	_, err = repo.Save(context.Background(), child)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		ids     []string
+		removed []string
+		kept    []string
+		err     error
+	}{
+		{
+			desc: "delete groups with invalid ID",
+			ids:  []string{child.ID, invalidID},
+			kept: []string{child.ID, parent.ID},
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc:    "delete groups successfully",
+			ids:     []string{child.ID, parent.ID},
+			removed: []string{child.ID, parent.ID},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.DeleteAll(context.Background(), tc.ids...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		for _, id := range tc.kept {
+			_, err := repo.RetrieveByID(context.Background(), id)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected group %s to be kept got %s\n", tc.desc, id, err))
+		}
+		for _, id := range tc.removed {
+			_, err := repo.RetrieveByID(context.Background(), id)
+			assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected group %s to be removed got %s\n", tc.desc, id, err))
+		}
+	}
+}
+
 func TestAssignParentGroup(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -1211,3 +1456,171 @@ func getIDs(groups []mggroups.Group) []string {
 
 	return ids
 }
+
+func TestUpdateThingCount(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	counts := []uint64{5, 1, 3}
+	var ids []string
+	for _, count := range counts {
+		group := mggroups.Group{
+			ID:        testsutil.GenerateUUID(t),
+			Domain:    domainID,
+			Name:      namegen.Generate(),
+			Metadata:  map[string]interface{}{},
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+			Status:    clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+		err = repo.UpdateThingCount(context.Background(), group.ID, count)
+		require.Nil(t, err, fmt.Sprintf("update thing count unexpected error: %s", err))
+		ids = append(ids, group.ID)
+	}
+
+	err := repo.UpdateThingCount(context.Background(), invalidID, 1)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	cases := []struct {
+		desc   string
+		dir    string
+		counts []uint64
+	}{
+		{
+			desc:   "order by thing count ascending",
+			dir:    mggroups.AscDir,
+			counts: []uint64{1, 3, 5},
+		},
+		{
+			desc:   "order by thing count descending",
+			dir:    mggroups.DescDir,
+			counts: []uint64{5, 3, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		pm := mggroups.Page{
+			PageMeta: mggroups.PageMeta{
+				Limit:    10,
+				DomainID: domainID,
+				Status:   clients.AllStatus,
+				Order:    mggroups.ThingCountOrder,
+				Dir:      tc.dir,
+			},
+		}
+		page, err := repo.RetrieveByIDs(context.Background(), pm, ids...)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		var got []uint64
+		for _, g := range page.Groups {
+			got = append(got, g.ThingCount)
+		}
+		assert.Equal(t, tc.counts, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.counts, got))
+	}
+}
+
+func TestRetrieveUnsyncedThingCounts(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		group := mggroups.Group{
+			ID:        testsutil.GenerateUUID(t),
+			Domain:    testsutil.GenerateUUID(t),
+			Name:      namegen.Generate(),
+			Metadata:  map[string]interface{}{},
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+			Status:    clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+		ids = append(ids, group.ID)
+	}
+
+	unsynced, err := repo.RetrieveUnsyncedThingCounts(context.Background(), 10)
+	assert.Nil(t, err, fmt.Sprintf("retrieve unsynced groups: unexpected error: %s", err))
+	assert.Empty(t, unsynced, fmt.Sprintf("retrieve unsynced groups: expected new groups to be synced got %v", unsynced))
+
+	// Groups created before the count was kept are unsynced.
+	_, err = db.Exec("UPDATE groups SET thing_count_synced = FALSE")
+	require.Nil(t, err, fmt.Sprintf("mark groups unsynced unexpected error: %s", err))
+	err = repo.UpdateThingCount(context.Background(), ids[0], 2)
+	require.Nil(t, err, fmt.Sprintf("update thing count unexpected error: %s", err))
+
+	unsynced, err = repo.RetrieveUnsyncedThingCounts(context.Background(), 10)
+	assert.Nil(t, err, fmt.Sprintf("retrieve unsynced groups: unexpected error: %s", err))
+	assert.ElementsMatch(t, ids[1:], unsynced, fmt.Sprintf("retrieve unsynced groups: expected %v got %v", ids[1:], unsynced))
+
+	unsynced, err = repo.RetrieveUnsyncedThingCounts(context.Background(), 1)
+	assert.Nil(t, err, fmt.Sprintf("retrieve unsynced groups with limit: unexpected error: %s", err))
+	assert.Len(t, unsynced, 1, fmt.Sprintf("retrieve unsynced groups with limit: expected 1 group got %v", unsynced))
+}
+
+func TestSaveActivity(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	group, err := repo.Save(context.Background(), validGroup)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+
+	memberID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc     string
+		groupID  string
+		at       time.Time
+		activity map[string]time.Time
+		err      error
+	}{
+		{
+			desc:     "save activity of a member",
+			groupID:  group.ID,
+			at:       now,
+			activity: map[string]time.Time{memberID: now},
+		},
+		{
+			desc:     "save later activity of a member",
+			groupID:  group.ID,
+			at:       now.Add(time.Minute),
+			activity: map[string]time.Time{memberID: now.Add(time.Minute)},
+		},
+		{
+			desc:     "save earlier activity of a member",
+			groupID:  group.ID,
+			at:       now,
+			activity: map[string]time.Time{memberID: now.Add(time.Minute)},
+		},
+		{
+			desc:    "save activity of a non-existing group",
+			groupID: testsutil.GenerateUUID(t),
+			at:      now,
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveActivity(context.Background(), tc.groupID, memberID, tc.at)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		activity, err := repo.RetrieveActivity(context.Background(), tc.groupID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.activity, activity, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.activity, activity))
+	}
+}
@@ -33,6 +33,53 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS groups`,
 				},
 			},
+			{
+				// Version is used for optimistic concurrency on group updates.
+				Id: "groups_02",
+				Up: []string{
+					`ALTER TABLE groups ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1`,
+				},
+				Down: []string{
+					`ALTER TABLE groups DROP COLUMN IF EXISTS version`,
+				},
+			},
+			{
+				// Thing count is kept up to date by the service so groups can
+				// be listed by the number of connected things. Groups which
+				// existed before are marked unsynced, so their count is
+				// backfilled from the connection policies. New groups start
+				// with no things.
+				Id: "groups_03",
+				Up: []string{
+					`ALTER TABLE groups ADD COLUMN IF NOT EXISTS thing_count BIGINT NOT NULL DEFAULT 0`,
+					`ALTER TABLE groups ADD COLUMN IF NOT EXISTS thing_count_synced BOOLEAN NOT NULL DEFAULT FALSE`,
+					`ALTER TABLE groups ALTER COLUMN thing_count_synced SET DEFAULT TRUE`,
+					`CREATE INDEX IF NOT EXISTS groups_domain_thing_count_idx ON groups (domain_id, thing_count)`,
+					`CREATE INDEX IF NOT EXISTS groups_thing_count_unsynced_idx ON groups (id) WHERE NOT thing_count_synced`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS groups_thing_count_unsynced_idx`,
+					`DROP INDEX IF EXISTS groups_domain_thing_count_idx`,
+					`ALTER TABLE groups DROP COLUMN IF EXISTS thing_count_synced`,
+					`ALTER TABLE groups DROP COLUMN IF EXISTS thing_count`,
+				},
+			},
+			{
+				// Group activity keeps the time each member last acted on a group.
+				Id: "groups_04",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS group_activity (
+						group_id	VARCHAR(36) NOT NULL,
+						member_id	VARCHAR(254) NOT NULL,
+						last_active	TIMESTAMP NOT NULL,
+						PRIMARY KEY	(group_id, member_id),
+						FOREIGN KEY	(group_id) REFERENCES groups (id) ON DELETE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS group_activity`,
+				},
+			},
 		},
 	}
 }
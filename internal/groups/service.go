@@ -6,6 +6,8 @@ package groups
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/absmach/magistrala"
@@ -13,6 +15,7 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/groups"
 	"golang.org/x/sync/errgroup"
@@ -22,12 +25,47 @@ var (
 	errParentUnAuthz = errors.New("failed to authorize parent group")
 	errMemberKind    = errors.New("invalid member kind")
 	errGroupIDs      = errors.New("invalid group ids")
+
+	// memberRoles are the group roles ordered from the most to the least privileged.
+	memberRoles = []string{
+		auth.AdministratorRelation,
+		auth.EditorRelation,
+		auth.ContributorRelation,
+		auth.MemberRelation,
+		auth.GuestRelation,
+	}
+
+	// groupRoles are the relations a user can be assigned with to a group.
+	groupRoles = map[string]struct{}{
+		auth.AdministratorRelation: {},
+		auth.EditorRelation:        {},
+		auth.ContributorRelation:   {},
+		auth.MemberRelation:        {},
+		auth.GuestRelation:         {},
+	}
 )
 
+// reassignBatchSize is the number of things moved at once when the things
+// of a group are reassigned.
+const reassignBatchSize = 100
+
+// patchAttempts is the number of times a patch is applied to a group which
+// is concurrently updated before the conflict is reported.
+const patchAttempts = 5
+
+// TokenRepository retrieves the scoped tokens issued by the things service,
+// which the auth service doesn't know.
+type TokenRepository interface {
+	// RetrieveToken retrieves the scoped token by the hash of the token.
+	RetrieveToken(ctx context.Context, tokenHash string) (mgclients.ScopedToken, error)
+}
+
 type service struct {
 	groups     groups.Repository
 	auth       magistrala.AuthServiceClient
+	tokens     TokenRepository
 	idProvider magistrala.IDProvider
+	channels   bool
 }
 
 // NewService returns a new Clients service implementation.
@@ -39,11 +77,27 @@ func NewService(g groups.Repository, idp magistrala.IDProvider, authClient magis
 	}
 }
 
+// NewChannelsService returns a new groups service implementation managing
+// the channels of things, whose metadata holds the channel settings. The
+// scoped tokens are resolved using the tokens repository.
+func NewChannelsService(g groups.Repository, idp magistrala.IDProvider, authClient magistrala.AuthServiceClient, tokens TokenRepository) groups.Service {
+	return service{
+		groups:     g,
+		idProvider: idp,
+		auth:       authClient,
+		tokens:     tokens,
+		channels:   true,
+	}
+}
+
 func (svc service) CreateGroup(ctx context.Context, token, kind string, g groups.Group) (gr groups.Group, err error) {
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
 		return groups.Group{}, err
 	}
+	if err := svc.validateMetadata(g.Metadata); err != nil {
+		return groups.Group{}, err
+	}
 	// If domain is disabled , then this authorization will fail for all non-admin domain users
 	if _, err := svc.authorizeKind(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.CreatePermission, auth.DomainType, res.GetDomainId()); err != nil {
 		return groups.Group{}, err
@@ -60,10 +114,17 @@ func (svc service) CreateGroup(ctx context.Context, token, kind string, g groups
 	g.CreatedAt = time.Now()
 	g.Domain = res.GetDomainId()
 	if g.Parent != "" {
-		_, err := svc.authorizeToken(ctx, auth.UserType, token, auth.EditPermission, auth.GroupType, g.Parent)
+		_, err := svc.authorizeToken(ctx, mgclients.ChannelsWriteScope, auth.UserType, token, auth.EditPermission, auth.GroupType, g.Parent)
 		if err != nil {
 			return groups.Group{}, errors.Wrap(errParentUnAuthz, err)
 		}
+		parent, err := svc.groups.RetrieveByID(ctx, g.Parent)
+		if err != nil {
+			return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if parent.Domain != g.Domain {
+			return groups.Group{}, groups.ErrParentDomain
+		}
 	}
 
 	if err := svc.addGroupPolicy(ctx, res.GetId(), res.GetDomainId(), g.ID, g.Parent, kind); err != nil {
@@ -86,7 +147,7 @@ func (svc service) CreateGroup(ctx context.Context, token, kind string, g groups
 }
 
 func (svc service) ViewGroup(ctx context.Context, token, id string) (groups.Group, error) {
-	_, err := svc.authorizeToken(ctx, auth.UserType, token, auth.ViewPermission, auth.GroupType, id)
+	_, err := svc.authorizeToken(ctx, mgclients.ChannelsReadScope, auth.UserType, token, auth.ViewPermission, auth.GroupType, id)
 	if err != nil {
 		return groups.Group{}, err
 	}
@@ -99,21 +160,197 @@ func (svc service) ViewGroup(ctx context.Context, token, id string) (groups.Grou
 	return group, nil
 }
 
+func (svc service) CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (groups.Group, error) {
+	src, err := svc.ViewGroup(ctx, token, id)
+	if err != nil {
+		return groups.Group{}, err
+	}
+
+	g := groups.Group{
+		Name:        src.Name,
+		Description: src.Description,
+		Metadata:    src.Metadata,
+		Parent:      src.Parent,
+		Status:      mgclients.EnabledStatus,
+	}
+	if name != "" {
+		g.Name = name
+	}
+	if parentID != "" {
+		g.Parent = parentID
+	}
+
+	return svc.CreateGroup(ctx, token, kind, g)
+}
+
+func (svc service) ExportGroup(ctx context.Context, token, id string) (groups.Blueprint, error) {
+	g, err := svc.ViewGroup(ctx, token, id)
+	if err != nil {
+		return groups.Blueprint{}, err
+	}
+
+	bg, err := svc.exportGroup(ctx, g)
+	if err != nil {
+		return groups.Blueprint{}, err
+	}
+
+	return groups.Blueprint{Version: groups.BlueprintVersion, BlueprintGroup: bg}, nil
+}
+
+// exportGroup describes the group and its subgroups, leaving out the IDs and members.
+func (svc service) exportGroup(ctx context.Context, g groups.Group) (groups.BlueprintGroup, error) {
+	bg := groups.BlueprintGroup{
+		Name:        g.Name,
+		Description: g.Description,
+		Metadata:    g.Metadata,
+	}
+	// Higher roles inherit the lower ones, so a role is exported only if
+	// some member holds it as the most privileged role.
+	seen := make(map[string]struct{})
+	for _, role := range memberRoles {
+		duids, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+			SubjectType: auth.UserType,
+			Permission:  role,
+			Object:      g.ID,
+			ObjectType:  auth.GroupType,
+		})
+		if err != nil {
+			return groups.BlueprintGroup{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		held := false
+		for _, duid := range duids.Policies {
+			if _, ok := seen[duid]; !ok {
+				seen[duid] = struct{}{}
+				held = true
+			}
+		}
+		if held {
+			bg.Roles = append(bg.Roles, role)
+		}
+	}
+
+	childIDs, err := svc.groups.RetrieveChildrenIDs(ctx, g.ID)
+	if err != nil {
+		return groups.BlueprintGroup{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, childID := range childIDs {
+		child, err := svc.groups.RetrieveByID(ctx, childID)
+		if err != nil {
+			return groups.BlueprintGroup{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		cbg, err := svc.exportGroup(ctx, child)
+		if err != nil {
+			return groups.BlueprintGroup{}, err
+		}
+		bg.Children = append(bg.Children, cbg)
+	}
+
+	return bg, nil
+}
+
+func (svc service) ImportGroup(ctx context.Context, token, kind, domainID string, bp groups.Blueprint) (groups.Group, error) {
+	if bp.Version != groups.BlueprintVersion {
+		return groups.Group{}, errors.Wrap(groups.ErrBlueprintVersion, fmt.Errorf("version %d, expected %d", bp.Version, groups.BlueprintVersion))
+	}
+	if err := validateBlueprintRoles(bp.BlueprintGroup); err != nil {
+		return groups.Group{}, err
+	}
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
+	if err != nil {
+		return groups.Group{}, err
+	}
+	if res.GetDomainId() != domainID {
+		return groups.Group{}, svcerr.ErrDomainAuthorization
+	}
+
+	g, err := svc.importGroup(ctx, token, kind, "", bp.BlueprintGroup)
+	if err != nil {
+		// Remove the part of the subtree created before the failure.
+		if g.ID != "" {
+			if errRollback := svc.deleteGroup(ctx, g.ID, true); errRollback != nil {
+				err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRollback), err)
+			}
+		}
+		return groups.Group{}, err
+	}
+
+	return g, nil
+}
+
+// importGroup creates the group described by the blueprint under the parent,
+// followed by its subgroups. If a subgroup can't be created, the group is
+// returned along with the error, so that the caller can remove it.
+func (svc service) importGroup(ctx context.Context, token, kind, parentID string, bg groups.BlueprintGroup) (groups.Group, error) {
+	g, err := svc.CreateGroup(ctx, token, kind, groups.Group{
+		Parent:      parentID,
+		Name:        bg.Name,
+		Description: bg.Description,
+		Metadata:    bg.Metadata,
+		Status:      mgclients.EnabledStatus,
+	})
+	if err != nil {
+		return groups.Group{}, err
+	}
+	for _, cbg := range bg.Children {
+		child, err := svc.importGroup(ctx, token, kind, g.ID, cbg)
+		if err != nil {
+			return g, err
+		}
+		g.Children = append(g.Children, &child)
+	}
+
+	return g, nil
+}
+
+func validateBlueprintRoles(bg groups.BlueprintGroup) error {
+	for _, role := range bg.Roles {
+		if _, ok := groupRoles[role]; !ok {
+			return errors.Wrap(groups.ErrInvalidRole, fmt.Errorf("role %q for group %s", role, bg.Name))
+		}
+	}
+	for _, child := range bg.Children {
+		if err := validateBlueprintRoles(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (svc service) ViewGroupPerms(ctx context.Context, token, id string) ([]string, error) {
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ChannelsReadScope)
 	if err != nil {
 		return nil, err
 	}
 
-	return svc.listUserGroupPermission(ctx, res.GetId(), id)
+	permissions, err := svc.listUserGroupPermission(ctx, res.GetId(), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) == 0 {
+		// Callers without permissions learn only whether the group
+		// exists in their domain.
+		group, err := svc.groups.RetrieveByID(ctx, id)
+		if err != nil || group.Domain != res.GetDomainId() {
+			return nil, svcerr.ErrNotFound
+		}
+		return []string{}, nil
+	}
+	return permissions, nil
 }
 
 func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID string, gm groups.Page) (groups.Page, error) {
 	var ids []string
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ChannelsReadScope)
 	if err != nil {
 		return groups.Page{}, err
 	}
+	if _, ok := groupRoles[gm.MyRole]; gm.MyRole != "" && !ok {
+		return groups.Page{}, errors.Wrap(groups.ErrInvalidRole, fmt.Errorf("role %q", gm.MyRole))
+	}
+	// Super admins list all the groups of the domain, rather than the
+	// groups with the given IDs.
+	domainWide := false
 	switch memberKind {
 	case auth.ThingsKind:
 		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.ViewPermission, auth.ThingType, memberID); err != nil {
@@ -191,6 +428,7 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 			switch svc.checkSuperAdmin(ctx, res.GetUserId()) {
 			case nil:
 				gm.PageMeta.DomainID = res.GetDomainId()
+				domainWide = true
 			default:
 				// If domain is disabled , then this authorization will fail for all non-admin domain users
 				if _, err := svc.authorizeKind(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.MembershipPermission, auth.DomainType, res.GetDomainId()); err != nil {
@@ -206,6 +444,20 @@ func (svc service) ListGroups(ctx context.Context, token, memberKind, memberID s
 		return groups.Page{}, errMemberKind
 	}
 
+	if gm.MyRole != "" {
+		if domainWide {
+			ids, err = svc.listAllGroupsOfUserID(ctx, res.GetId(), gm.MyRole)
+		} else {
+			ids, err = svc.filterAllowedGroupIDsOfUserID(ctx, res.GetId(), gm.MyRole, ids)
+		}
+		if err != nil {
+			return groups.Page{}, err
+		}
+		if len(ids) == 0 {
+			return groups.Page{PageMeta: groups.PageMeta{Offset: gm.Offset, Limit: gm.Limit}}, nil
+		}
+	}
+
 	gp, err := svc.groups.RetrieveByIDs(ctx, gm, ids...)
 	if err != nil {
 		return groups.Page{}, errors.Wrap(svcerr.ErrViewEntity, err)
@@ -235,6 +487,9 @@ func (svc service) retrievePermissions(ctx context.Context, userID string, group
 	if err != nil {
 		return err
 	}
+	if len(permissions) == 0 {
+		return svcerr.ErrAuthorization
+	}
 	group.Permissions = permissions
 	return nil
 }
@@ -249,9 +504,6 @@ func (svc service) listUserGroupPermission(ctx context.Context, userID, groupID
 	if err != nil {
 		return []string{}, err
 	}
-	if len(lp.GetPermissions()) == 0 {
-		return []string{}, svcerr.ErrAuthorization
-	}
 	return lp.GetPermissions(), nil
 }
 
@@ -274,7 +526,7 @@ func (svc service) checkSuperAdmin(ctx context.Context, userID string) error {
 
 // IMPROVEMENT NOTE: remove this function and all its related auxiliary function, ListMembers are moved to respective service.
 func (svc service) ListMembers(ctx context.Context, token, groupID, permission, memberKind string) (groups.MembersPage, error) {
-	_, err := svc.authorizeToken(ctx, auth.UserType, token, auth.ViewPermission, auth.GroupType, groupID)
+	_, err := svc.authorizeToken(ctx, mgclients.ChannelsReadScope, auth.UserType, token, auth.ViewPermission, auth.GroupType, groupID)
 	if err != nil {
 		return groups.MembersPage{}, err
 	}
@@ -335,15 +587,120 @@ func (svc service) ListMembers(ctx context.Context, token, groupID, permission,
 }
 
 func (svc service) UpdateGroup(ctx context.Context, token string, g groups.Group) (groups.Group, error) {
-	id, err := svc.authorizeToken(ctx, auth.UserType, token, auth.EditPermission, auth.GroupType, g.ID)
+	g, err := svc.prepareUpdate(ctx, token, g, false)
+	if err != nil {
+		return groups.Group{}, err
+	}
+
+	return svc.groups.Update(ctx, g)
+}
+
+func (svc service) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge, partial bool) ([]groups.GroupUpdate, error) {
+	results := make([]groups.GroupUpdate, len(gs))
+	updates := make([]groups.Group, 0, len(gs))
+	indices := make([]int, 0, len(gs))
+	for i, g := range gs {
+		results[i].ID = g.ID
+		u, err := svc.prepareUpdate(ctx, token, g, merge)
+		if err != nil {
+			if !partial {
+				return nil, err
+			}
+			results[i].Error = err.Error()
+			continue
+		}
+		updates = append(updates, u)
+		indices = append(indices, i)
+	}
+	if len(updates) == 0 {
+		return results, nil
+	}
+
+	updated, err := svc.groups.UpdateAll(ctx, updates...)
+	if err != nil {
+		switch {
+		case errors.Contains(err, repoerr.ErrConflict):
+			return nil, errors.Wrap(svcerr.ErrConflict, err)
+		case errors.Contains(err, repoerr.ErrNotFound):
+			return nil, errors.Wrap(svcerr.ErrNotFound, err)
+		default:
+			return nil, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+	for i := range updated {
+		results[indices[i]].Group = &updated[i]
+	}
+
+	return results, nil
+}
+
+func (svc service) PatchGroup(ctx context.Context, token, id string, patch groups.GroupPatch) (groups.Group, error) {
+	uid, err := svc.authorizeToken(ctx, mgclients.ChannelsWriteScope, auth.UserType, token, auth.EditPermission, auth.GroupType, id)
+	if err != nil {
+		return groups.Group{}, err
+	}
+
+	// The patch is applied to the stored group and the replacement is bound
+	// to its version, so the patch is applied again if the group changed in
+	// the meantime.
+	for i := 1; ; i++ {
+		current, err := svc.groups.RetrieveByID(ctx, id)
+		if err != nil {
+			return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if current.Status != mgclients.EnabledStatus {
+			return groups.Group{}, svcerr.ErrNotFound
+		}
+		g := patch.Apply(current)
+		if err := svc.validateMetadata(g.Metadata); err != nil {
+			return groups.Group{}, err
+		}
+		g.UpdatedAt = time.Now()
+		g.UpdatedBy = uid
+
+		g, err = svc.groups.Replace(ctx, g)
+		switch {
+		case err == nil:
+			return g, nil
+		case errors.Contains(err, repoerr.ErrConflict):
+			if i < patchAttempts {
+				continue
+			}
+			return groups.Group{}, errors.Wrap(svcerr.ErrConflict, err)
+		case errors.Contains(err, repoerr.ErrNotFound):
+			return groups.Group{}, errors.Wrap(svcerr.ErrNotFound, err)
+		default:
+			return groups.Group{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+}
+
+// prepareUpdate authorizes the update of the group and returns the group as
+// it is going to be stored. If merge is set, the metadata is merged into the
+// stored one, and the update is bound to the version it was merged with.
+func (svc service) prepareUpdate(ctx context.Context, token string, g groups.Group, merge bool) (groups.Group, error) {
+	id, err := svc.authorizeToken(ctx, mgclients.ChannelsWriteScope, auth.UserType, token, auth.EditPermission, auth.GroupType, g.ID)
 	if err != nil {
 		return groups.Group{}, err
 	}
+	if merge && g.Metadata != nil {
+		current, err := svc.groups.RetrieveByID(ctx, g.ID)
+		if err != nil {
+			return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		g.Metadata = current.Metadata.Merge(g.Metadata)
+		if g.Version == 0 {
+			g.Version = current.Version
+		}
+	}
+	if err := svc.validateMetadata(g.Metadata); err != nil {
+		return groups.Group{}, err
+	}
 
 	g.UpdatedAt = time.Now()
 	g.UpdatedBy = id
 
-	return svc.groups.Update(ctx, g)
+	return g, nil
 }
 
 func (svc service) EnableGroup(ctx context.Context, token, id string) (groups.Group, error) {
@@ -373,7 +730,7 @@ func (svc service) DisableGroup(ctx context.Context, token, id string) (groups.G
 }
 
 func (svc service) Assign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) error {
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
 		return err
 	}
@@ -424,8 +781,27 @@ func (svc service) Assign(ctx context.Context, token, groupID, relation, memberK
 		return errMemberKind
 	}
 
+	if memberKind == auth.ThingsKind {
+		n, err := svc.unconnectedThings(ctx, groupID, memberIDs)
+		if err != nil {
+			return err
+		}
+		if err := svc.reserveThings(ctx, groupID, n); err != nil {
+			return err
+		}
+	}
 	if _, err := svc.auth.AddPolicies(ctx, &policies); err != nil {
-		return errors.Wrap(svcerr.ErrAddPolicies, err)
+		err = errors.Wrap(svcerr.ErrAddPolicies, err)
+		if memberKind == auth.ThingsKind {
+			// Release the reservation.
+			if errCount := svc.updateThingCount(ctx, groupID); errCount != nil {
+				err = errors.Wrap(err, errCount)
+			}
+		}
+		return err
+	}
+	if memberKind == auth.ThingsKind {
+		return svc.updateThingCount(ctx, groupID)
 	}
 
 	return nil
@@ -524,7 +900,7 @@ func (svc service) unassignParentGroup(ctx context.Context, domain, parentGroupI
 }
 
 func (svc service) Unassign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) error {
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
 		return err
 	}
@@ -578,87 +954,933 @@ func (svc service) Unassign(ctx context.Context, token, groupID, relation, membe
 	if _, err := svc.auth.DeletePolicies(ctx, &policies); err != nil {
 		return errors.Wrap(svcerr.ErrDeletePolicies, err)
 	}
+	if memberKind == auth.ThingsKind {
+		return svc.updateThingCount(ctx, groupID)
+	}
+
 	return nil
 }
 
-func (svc service) DeleteGroup(ctx context.Context, token, id string) error {
-	res, err := svc.identify(ctx, token)
+func (svc service) AssignRoles(ctx context.Context, token, memberID string, assignments []groups.RoleAssignment) ([]groups.RoleAssignment, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
-		return err
-	}
-	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.DeletePermission, auth.GroupType, id); err != nil {
-		return err
+		return nil, err
 	}
 
-	deleteRes, err := svc.auth.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
-		EntityType: auth.GroupType,
-		Id:         id,
-	})
-	if err != nil {
-		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	for _, a := range assignments {
+		if _, ok := groupRoles[a.Role]; !ok {
+			return nil, errors.Wrap(groups.ErrInvalidRole, fmt.Errorf("role %q for group %s", a.Role, a.GroupID))
+		}
 	}
-	if !deleteRes.Deleted {
-		return svcerr.ErrAuthorization
+
+	policies := magistrala.AddPoliciesReq{}
+	results := make([]groups.RoleAssignment, len(assignments))
+	for i, a := range assignments {
+		results[i] = groups.RoleAssignment{GroupID: a.GroupID, Role: a.Role}
+		group, err := svc.groups.RetrieveByID(ctx, a.GroupID)
+		if err != nil || group.Domain != res.GetDomainId() {
+			results[i].Error = svcerr.ErrNotFound.Error()
+			continue
+		}
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.GroupType, a.GroupID); err != nil {
+			return nil, err
+		}
+		policies.AddPoliciesReq = append(policies.AddPoliciesReq, &magistrala.AddPolicyReq{
+			Domain:      res.GetDomainId(),
+			SubjectType: auth.UserType,
+			Subject:     auth.EncodeDomainUserID(res.GetDomainId(), memberID),
+			Relation:    a.Role,
+			ObjectType:  auth.GroupType,
+			Object:      a.GroupID,
+		})
 	}
 
-	if err := svc.groups.Delete(ctx, id); err != nil {
-		return err
+	if len(policies.AddPoliciesReq) == 0 {
+		return results, nil
+	}
+	if _, err := svc.auth.AddPolicies(ctx, &policies); err != nil {
+		return nil, errors.Wrap(svcerr.ErrAddPolicies, err)
 	}
 
-	return nil
+	return results, nil
 }
 
-func (svc service) filterAllowedGroupIDsOfUserID(ctx context.Context, userID, permission string, groupIDs []string) ([]string, error) {
-	var ids []string
-	allowedIDs, err := svc.listAllGroupsOfUserID(ctx, userID, permission)
+func (svc service) MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) ([]groups.ThingMove, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
-		return []string{}, err
+		return nil, err
 	}
-
-	for _, gid := range groupIDs {
-		for _, id := range allowedIDs {
-			if id == gid {
-				ids = append(ids, id)
-			}
+	for _, id := range []string{groupID, targetGroupID} {
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.GroupType, id); err != nil {
+			return nil, err
 		}
 	}
-	return ids, nil
-}
 
-func (svc service) listAllGroupsOfUserID(ctx context.Context, userID, permission string) ([]string, error) {
-	allowedIDs, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
-		SubjectType: auth.UserType,
-		Subject:     userID,
-		Permission:  permission,
-		ObjectType:  auth.GroupType,
+	source, err := svc.groups.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	target, err := svc.groups.RetrieveByID(ctx, targetGroupID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if source.Domain != target.Domain {
+		return nil, groups.ErrTargetDomain
+	}
+
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.GroupType,
+		Subject:     groupID,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
 	})
 	if err != nil {
-		return []string{}, err
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	return allowedIDs.Policies, nil
+	members := make(map[string]bool, len(tids.Policies))
+	for _, id := range tids.Policies {
+		members[id] = true
+	}
+
+	moved := []string{}
+	results := make([]groups.ThingMove, len(thingIDs))
+	for i, id := range thingIDs {
+		results[i] = groups.ThingMove{ThingID: id}
+		if !members[id] {
+			results[i].Error = groups.ErrNotMember.Error()
+			continue
+		}
+		moved = append(moved, id)
+	}
+	if len(moved) == 0 {
+		return results, nil
+	}
+	if err := svc.moveThings(ctx, res.GetDomainId(), groupID, targetGroupID, moved); err != nil {
+		return nil, err
+	}
+	if err := svc.updateThingCount(ctx, groupID, targetGroupID); err != nil {
+		return nil, err
+	}
+
+	return results, nil
 }
 
-func (svc service) changeGroupStatus(ctx context.Context, token string, group groups.Group) (groups.Group, error) {
-	id, err := svc.authorizeToken(ctx, auth.UserType, token, auth.EditPermission, auth.GroupType, group.ID)
+func (svc service) ReassignThings(ctx context.Context, token, groupID, targetGroupID string) ([]string, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
-		return groups.Group{}, err
+		return nil, err
 	}
-	dbGroup, err := svc.groups.RetrieveByID(ctx, group.ID)
+	for _, id := range []string{groupID, targetGroupID} {
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.GroupType, id); err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := svc.groups.RetrieveByID(ctx, groupID)
 	if err != nil {
-		return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	if dbGroup.Status == group.Status {
-		return groups.Group{}, errors.ErrStatusAlreadyAssigned
+	target, err := svc.groups.RetrieveByID(ctx, targetGroupID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if source.Domain != target.Domain {
+		return nil, groups.ErrTargetDomain
+	}
+	if source.Parent != target.Parent {
+		return nil, groups.ErrTargetParent
 	}
 
-	group.UpdatedBy = id
-	return svc.groups.ChangeStatus(ctx, group)
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.GroupType,
+		Subject:     groupID,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	// The things are moved in batches, so a failure leaves the things of
+	// the batches moved before it in the target.
+	moved := []string{}
+	for start := 0; start < len(tids.Policies); start += reassignBatchSize {
+		batch := tids.Policies[start:min(start+reassignBatchSize, len(tids.Policies))]
+		if err := svc.moveThings(ctx, res.GetDomainId(), groupID, targetGroupID, batch); err != nil {
+			if errCount := svc.updateThingCount(ctx, groupID, targetGroupID); errCount != nil {
+				err = errors.Wrap(err, errCount)
+			}
+			return moved, err
+		}
+		moved = append(moved, batch...)
+	}
+	if err := svc.updateThingCount(ctx, groupID, targetGroupID); err != nil {
+		return moved, err
+	}
+
+	return moved, nil
 }
 
-func (svc service) identify(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
-	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
+func (svc service) AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) ([]groups.ThingAttachment, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
-		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
+		return nil, err
+	}
+	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.GroupType, groupID); err != nil {
+		return nil, err
+	}
+
+	var addPolicies magistrala.AddPoliciesReq
+	var deletePolicies, rollbackPolicies magistrala.DeletePoliciesReq
+	detached := []string{}
+	// editable holds whether the user can edit the groups the things are
+	// detached from, each group being authorized once.
+	editable := map[string]bool{}
+	counted := map[string]bool{}
+	results := make([]groups.ThingAttachment, len(thingIDs))
+	for i, id := range thingIDs {
+		results[i] = groups.ThingAttachment{ThingID: id}
+		// Things of other domains can't be edited by the user either.
+		if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.EditPermission, auth.ThingType, id); err != nil {
+			results[i].Error = svcerr.ErrAuthorization.Error()
+			continue
+		}
+		gids, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+			SubjectType: auth.GroupType,
+			Permission:  auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+			Object:      id,
+		})
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		attached := false
+		others := []string{}
+		for _, gid := range gids.GetPolicies() {
+			if gid == groupID {
+				attached = true
+				continue
+			}
+			others = append(others, gid)
+		}
+		if len(others) > 0 && !reassign {
+			results[i].Error = groups.ErrSharedThing.Error()
+			continue
+		}
+		// Detaching a thing edits the groups it's detached from as well.
+		if !svc.canEditGroups(ctx, res, editable, others) {
+			results[i].Error = svcerr.ErrAuthorization.Error()
+			continue
+		}
+		if !attached {
+			addPolicies.AddPoliciesReq = append(addPolicies.AddPoliciesReq, &magistrala.AddPolicyReq{
+				Domain:      res.GetDomainId(),
+				SubjectType: auth.GroupType,
+				SubjectKind: auth.ChannelsKind,
+				Subject:     groupID,
+				Relation:    auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+				Object:      id,
+			})
+			rollbackPolicies.DeletePoliciesReq = append(rollbackPolicies.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+				Domain:      res.GetDomainId(),
+				SubjectType: auth.GroupType,
+				SubjectKind: auth.ChannelsKind,
+				Subject:     groupID,
+				Relation:    auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+				Object:      id,
+			})
+		}
+		for _, gid := range others {
+			deletePolicies.DeletePoliciesReq = append(deletePolicies.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+				Domain:      res.GetDomainId(),
+				SubjectType: auth.GroupType,
+				SubjectKind: auth.ChannelsKind,
+				Subject:     gid,
+				Relation:    auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+				Object:      id,
+			})
+			if !counted[gid] {
+				counted[gid] = true
+				detached = append(detached, gid)
+			}
+		}
+		if len(others) > 0 {
+			results[i].Detached = others
+		}
+	}
+
+	if len(addPolicies.AddPoliciesReq) > 0 {
+		if err := svc.reserveThings(ctx, groupID, len(addPolicies.AddPoliciesReq)); err != nil {
+			return nil, err
+		}
+		if _, err := svc.auth.AddPolicies(ctx, &addPolicies); err != nil {
+			err = errors.Wrap(svcerr.ErrAddPolicies, err)
+			if errCount := svc.updateThingCount(ctx, groupID); errCount != nil {
+				err = errors.Wrap(err, errCount)
+			}
+			return nil, err
+		}
+	}
+	if len(deletePolicies.DeletePoliciesReq) > 0 {
+		if _, err := svc.auth.DeletePolicies(ctx, &deletePolicies); err != nil {
+			err = errors.Wrap(svcerr.ErrDeletePolicies, err)
+			if len(rollbackPolicies.DeletePoliciesReq) > 0 {
+				if _, errRollback := svc.auth.DeletePolicies(ctx, &rollbackPolicies); errRollback != nil {
+					err = errors.Wrap(err, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+				}
+			}
+			// The counts are recomputed from the policies, so they hold
+			// whatever part of the detach was applied.
+			if errCount := svc.updateThingCount(ctx, append([]string{groupID}, detached...)...); errCount != nil {
+				err = errors.Wrap(err, errCount)
+			}
+			return nil, err
+		}
+	}
+	if len(addPolicies.AddPoliciesReq) == 0 && len(deletePolicies.DeletePoliciesReq) == 0 {
+		return results, nil
+	}
+	if err := svc.updateThingCount(ctx, append([]string{groupID}, detached...)...); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// canEditGroups reports whether the user can edit all of the groups,
+// recording the outcome of each group in editable.
+func (svc service) canEditGroups(ctx context.Context, user *magistrala.IdentityRes, editable map[string]bool, groupIDs []string) bool {
+	for _, gid := range groupIDs {
+		ok, checked := editable[gid]
+		if !checked {
+			_, err := svc.authorizeKind(ctx, user.GetDomainId(), auth.UserType, auth.UsersKind, user.GetId(), auth.EditPermission, auth.GroupType, gid)
+			ok = err == nil
+			editable[gid] = ok
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (svc service) ListMemberGroups(ctx context.Context, token, memberID string, pm groups.PageMeta) (groups.MemberGroupsPage, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsReadScope)
+	if err != nil {
+		return groups.MemberGroupsPage{}, err
+	}
+
+	var ids []string
+	roles := make(map[string]string)
+	for _, role := range memberRoles {
+		gids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+			SubjectType: auth.UserType,
+			Subject:     auth.EncodeDomainUserID(res.GetDomainId(), memberID),
+			Permission:  role,
+			ObjectType:  auth.GroupType,
+		})
+		if err != nil {
+			return groups.MemberGroupsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		// A member holding several roles in a group is reported with the most privileged one.
+		for _, id := range gids.Policies {
+			if _, ok := roles[id]; !ok {
+				roles[id] = role
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	if res.GetUserId() != memberID {
+		ids, err = svc.filterAllowedGroupIDsOfUserID(ctx, res.GetId(), auth.AdminPermission, ids)
+		if err != nil {
+			return groups.MemberGroupsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+	}
+	if len(ids) == 0 {
+		return groups.MemberGroupsPage{PageMeta: pm, Groups: []groups.MemberGroup{}}, nil
+	}
+
+	gp, err := svc.groups.RetrieveByIDs(ctx, groups.Page{PageMeta: pm}, ids...)
+	if err != nil {
+		return groups.MemberGroupsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	page := groups.MemberGroupsPage{
+		PageMeta: gp.PageMeta,
+		Groups:   make([]groups.MemberGroup, 0, len(gp.Groups)),
+	}
+	for _, g := range gp.Groups {
+		page.Groups = append(page.Groups, groups.MemberGroup{Group: g, Role: roles[g.ID]})
+	}
+
+	return page, nil
+}
+
+func (svc service) ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) ([]groups.MemberRole, error) {
+	if _, err := svc.authorizeToken(ctx, mgclients.ChannelsReadScope, auth.UserType, token, auth.AdminPermission, auth.GroupType, groupID); err != nil {
+		return nil, err
+	}
+
+	activity, err := svc.groups.RetrieveActivity(ctx, groupID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	members, err := ListGroupMembers(ctx, svc.auth, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]groups.MemberRole, 0, len(members))
+	for _, member := range members {
+		if at, ok := activity[member.MemberID]; ok {
+			member.LastActive = &at
+		}
+		if !inactiveSince.IsZero() && member.LastActive != nil && !member.LastActive.Before(inactiveSince) {
+			continue
+		}
+		filtered = append(filtered, member)
+	}
+
+	return filtered, nil
+}
+
+func (svc service) ListDomainMembers(ctx context.Context, token, domainID string, pm groups.PageMeta) (groups.DomainMembersPage, error) {
+	res, err := svc.identify(ctx, token, "")
+	if err != nil {
+		return groups.DomainMembersPage{}, err
+	}
+	if res.GetDomainId() != domainID {
+		return groups.DomainMembersPage{}, svcerr.ErrDomainAuthorization
+	}
+	if _, err := svc.authorizeKind(ctx, domainID, auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, domainID); err != nil {
+		return groups.DomainMembersPage{}, err
+	}
+
+	duids, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+		SubjectType: auth.UserType,
+		Permission:  auth.MembershipPermission,
+		Object:      domainID,
+		ObjectType:  auth.DomainType,
+	})
+	if err != nil {
+		return groups.DomainMembersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	ids := make([]string, 0, len(duids.Policies))
+	for _, duid := range duids.Policies {
+		if _, memberID := auth.DecodeDomainUserID(duid); memberID != "" {
+			ids = append(ids, memberID)
+		}
+	}
+	sort.Strings(ids)
+	ids = slices.Compact(ids)
+
+	page := groups.DomainMembersPage{
+		Total:   uint64(len(ids)),
+		Offset:  pm.Offset,
+		Limit:   pm.Limit,
+		Members: []groups.DomainMember{},
+	}
+	if pm.Offset >= page.Total {
+		return page, nil
+	}
+	// Only the groups of the members of the page are retrieved, so the
+	// number of lookups is bounded by the page rather than by the groups.
+	for _, memberID := range ids[pm.Offset:min(pm.Offset+pm.Limit, page.Total)] {
+		member := groups.DomainMember{MemberID: memberID, Groups: []groups.GroupRole{}}
+		seen := make(map[string]struct{})
+		for _, role := range memberRoles {
+			gids, err := svc.listAllGroupsOfUserID(ctx, auth.EncodeDomainUserID(domainID, memberID), role)
+			if err != nil {
+				return groups.DomainMembersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+			}
+			// Roles are listed from the most privileged one, so a member
+			// holding several roles in a group is reported with it.
+			for _, id := range gids {
+				if _, ok := seen[id]; ok {
+					continue
+				}
+				seen[id] = struct{}{}
+				if member.Role == "" {
+					member.Role = role
+				}
+				member.Groups = append(member.Groups, groups.GroupRole{GroupID: id, Role: role})
+			}
+		}
+		page.Members = append(page.Members, member)
+	}
+
+	return page, nil
+}
+
+func (svc service) RevokeMember(ctx context.Context, token, domainID, memberID string) (groups.MemberRevocation, error) {
+	res, err := svc.identify(ctx, token, "")
+	if err != nil {
+		return groups.MemberRevocation{}, err
+	}
+	if res.GetDomainId() != domainID {
+		return groups.MemberRevocation{}, svcerr.ErrDomainAuthorization
+	}
+	if _, err := svc.authorizeKind(ctx, domainID, auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, domainID); err != nil {
+		return groups.MemberRevocation{}, err
+	}
+
+	subject := auth.EncodeDomainUserID(domainID, memberID)
+	ids := []string{}
+	seen := make(map[string]struct{})
+	for _, role := range memberRoles {
+		gids, err := svc.listAllGroupsOfUserID(ctx, subject, role)
+		if err != nil {
+			return groups.MemberRevocation{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, id := range gids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	if len(ids) > 0 {
+		// Roles inherited from a parent group are removed along with the role
+		// held in the parent, and deleting a role the member doesn't hold
+		// directly is a no-op, so every role is deleted in every group.
+		policies := magistrala.DeletePoliciesReq{}
+		for _, id := range ids {
+			for _, role := range memberRoles {
+				policies.DeletePoliciesReq = append(policies.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+					Domain:      domainID,
+					SubjectType: auth.UserType,
+					Subject:     subject,
+					Relation:    role,
+					ObjectType:  auth.GroupType,
+					Object:      id,
+				})
+			}
+		}
+		if _, err := svc.auth.DeletePolicies(ctx, &policies); err != nil {
+			return groups.MemberRevocation{}, errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+	}
+
+	perm, err := svc.domainPermission(ctx, domainID, subject)
+	if err != nil {
+		return groups.MemberRevocation{}, err
+	}
+
+	return groups.MemberRevocation{Groups: ids, DomainPermission: perm}, nil
+}
+
+// domainPermission returns the most privileged of the domain permissions
+// which are inherited by the groups of the domain, or an empty string if
+// the subject holds none of them.
+func (svc service) domainPermission(ctx context.Context, domainID, subject string) (string, error) {
+	for _, perm := range []string{auth.AdminPermission, auth.EditPermission, auth.ViewPermission} {
+		res, err := svc.auth.Authorize(ctx, &magistrala.AuthorizeReq{
+			Domain:      domainID,
+			SubjectType: auth.UserType,
+			Subject:     subject,
+			Permission:  perm,
+			ObjectType:  auth.DomainType,
+			Object:      domainID,
+		})
+		if err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		if res.GetAuthorized() {
+			return perm, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (svc service) DeleteGroup(ctx context.Context, token, id string, cascade bool) error {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.DeletePermission, auth.GroupType, id); err != nil {
+		return err
+	}
+
+	return svc.deleteGroup(ctx, id, cascade)
+}
+
+func (svc service) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]groups.GroupRemoval, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]groups.GroupRemoval, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+		if err := svc.deleteDependentGroup(ctx, res, id, cascade); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Removed = true
+	}
+
+	return results, nil
+}
+
+// deleteDependentGroup authorizes the removal of the group and removes it.
+// Unless cascade is set, groups with connected things are not removed.
+func (svc service) deleteDependentGroup(ctx context.Context, res *magistrala.IdentityRes, id string, cascade bool) error {
+	if _, err := svc.authorizeKind(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.DeletePermission, auth.GroupType, id); err != nil {
+		return err
+	}
+	if !cascade {
+		// Connections are kept as policies, so they are counted there
+		// rather than trusting the stored thing count.
+		things, err := svc.auth.CountObjects(ctx, &magistrala.CountObjectsReq{
+			SubjectType: auth.GroupType,
+			Subject:     id,
+			Permission:  auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+		})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if things.GetCount() > 0 {
+			return groups.ErrGroupHasThings
+		}
+	}
+
+	return svc.deleteGroup(ctx, id, cascade)
+}
+
+// deleteGroup removes the group, along with its descendants when cascade is
+// set. The groups are removed from the database in a single transaction
+// before their policies are removed. If the policies of a group can't be
+// removed, the groups which still have their policies are restored, so each
+// group is either removed along with its policies or kept.
+func (svc service) deleteGroup(ctx context.Context, id string, cascade bool) error {
+	subtree, err := svc.retrieveSubtree(ctx, id, cascade)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(subtree))
+	for i, g := range subtree {
+		ids[i] = g.ID
+	}
+	if err := svc.groups.DeleteAll(ctx, ids...); err != nil {
+		return err
+	}
+
+	for i, g := range subtree {
+		if err := svc.deleteGroupPolicies(ctx, g.ID); err != nil {
+			if errRollback := svc.restoreGroups(ctx, subtree[i:]); errRollback != nil {
+				err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRollback), err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retrieveSubtree retrieves the group and its descendants, each following
+// its descendants. Unless cascade is set, groups with children are refused.
+func (svc service) retrieveSubtree(ctx context.Context, id string, cascade bool) ([]groups.Group, error) {
+	children, err := svc.groups.RetrieveChildrenIDs(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(children) > 0 && !cascade {
+		return nil, groups.ErrGroupHasChildren
+	}
+	var subtree []groups.Group
+	for _, child := range children {
+		gs, err := svc.retrieveSubtree(ctx, child, cascade)
+		if err != nil {
+			return nil, err
+		}
+		subtree = append(subtree, gs...)
+	}
+	g, err := svc.groups.RetrieveByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return append(subtree, g), nil
+}
+
+func (svc service) deleteGroupPolicies(ctx context.Context, id string) error {
+	deleteRes, err := svc.auth.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
+		EntityType: auth.GroupType,
+		Id:         id,
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+	if !deleteRes.Deleted {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
+
+// restoreGroups saves the removed groups again, each before its descendants,
+// along with their thing counts.
+func (svc service) restoreGroups(ctx context.Context, gs []groups.Group) error {
+	for i := len(gs) - 1; i >= 0; i-- {
+		if _, err := svc.groups.Save(ctx, gs[i]); err != nil {
+			return errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		if err := svc.groups.UpdateThingCount(ctx, gs[i].ID, gs[i].ThingCount); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) filterAllowedGroupIDsOfUserID(ctx context.Context, userID, permission string, groupIDs []string) ([]string, error) {
+	var ids []string
+	allowedIDs, err := svc.listAllGroupsOfUserID(ctx, userID, permission)
+	if err != nil {
+		return []string{}, err
+	}
+
+	for _, gid := range groupIDs {
+		for _, id := range allowedIDs {
+			if id == gid {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (svc service) listAllGroupsOfUserID(ctx context.Context, userID, permission string) ([]string, error) {
+	allowedIDs, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.UserType,
+		Subject:     userID,
+		Permission:  permission,
+		ObjectType:  auth.GroupType,
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	return allowedIDs.Policies, nil
+}
+
+func (svc service) changeGroupStatus(ctx context.Context, token string, group groups.Group) (groups.Group, error) {
+	id, err := svc.authorizeToken(ctx, mgclients.ChannelsWriteScope, auth.UserType, token, auth.EditPermission, auth.GroupType, group.ID)
+	if err != nil {
+		return groups.Group{}, err
+	}
+	dbGroup, err := svc.groups.RetrieveByID(ctx, group.ID)
+	if err != nil {
+		return groups.Group{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if dbGroup.Status == group.Status {
+		return groups.Group{}, errors.ErrStatusAlreadyAssigned
+	}
+
+	group.UpdatedBy = id
+	return svc.groups.ChangeStatus(ctx, group)
+}
+
+// validateMetadata validates the metadata settings of channels. Groups
+// of users don't have channel settings, so their metadata is not checked.
+func (svc service) validateMetadata(m mgclients.Metadata) error {
+	if !svc.channels {
+		return nil
+	}
+
+	return validateChannelMetadata(m)
+}
+
+// validateChannelMetadata validates the forwarding, payload schema,
+// transforms, connection limit and content type settings of the channel.
+func validateChannelMetadata(m mgclients.Metadata) error {
+	if _, _, err := groups.ForwardFromMetadata(m); err != nil {
+		return err
+	}
+	if _, _, err := groups.SchemaFromMetadata(m); err != nil {
+		return err
+	}
+	if _, _, err := groups.TransformsFromMetadata(m); err != nil {
+		return err
+	}
+	if _, err := groups.MaxThingsFromMetadata(m); err != nil {
+		return err
+	}
+
+	return groups.ValidateContentType(m)
+}
+
+// unconnectedThings returns the number of distinct things which are not
+// connected to the group yet.
+func (svc service) unconnectedThings(ctx context.Context, groupID string, thingIDs []string) (int, error) {
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.GroupType,
+		Subject:     groupID,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
+	})
+	if err != nil {
+		return 0, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	connected := make(map[string]bool, len(tids.GetPolicies())+len(thingIDs))
+	for _, id := range tids.GetPolicies() {
+		connected[id] = true
+	}
+	n := 0
+	for _, id := range thingIDs {
+		if !connected[id] {
+			connected[id] = true
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// reserveThings counts n things as connected to the group before they are
+// connected, so concurrent connections can't exceed the limit of the group.
+func (svc service) reserveThings(ctx context.Context, groupID string, n int) error {
+	if n == 0 {
+		return nil
+	}
+	err := svc.groups.ReserveThings(ctx, groupID, uint64(n))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Contains(err, groups.ErrConnectionLimitExceeded):
+		return err
+	default:
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+}
+
+// moveThings connects the things of the group to the target group and
+// disconnects them from the group. Things already connected to the target
+// are only disconnected from the group, since connecting them again would
+// fail. The thing counts of the groups are left to the caller.
+func (svc service) moveThings(ctx context.Context, domainID, groupID, targetGroupID string, thingIDs []string) error {
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.GroupType,
+		Subject:     targetGroupID,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	connected := make(map[string]bool, len(tids.Policies))
+	for _, id := range tids.Policies {
+		connected[id] = true
+	}
+
+	var addPolicies magistrala.AddPoliciesReq
+	var deletePolicies, rollbackPolicies magistrala.DeletePoliciesReq
+	for _, id := range thingIDs {
+		deletePolicies.DeletePoliciesReq = append(deletePolicies.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+			Domain:      domainID,
+			SubjectType: auth.GroupType,
+			SubjectKind: auth.ChannelsKind,
+			Subject:     groupID,
+			Relation:    auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+			Object:      id,
+		})
+		if connected[id] {
+			continue
+		}
+		connected[id] = true
+		addPolicies.AddPoliciesReq = append(addPolicies.AddPoliciesReq, &magistrala.AddPolicyReq{
+			Domain:      domainID,
+			SubjectType: auth.GroupType,
+			SubjectKind: auth.ChannelsKind,
+			Subject:     targetGroupID,
+			Relation:    auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+			Object:      id,
+		})
+		rollbackPolicies.DeletePoliciesReq = append(rollbackPolicies.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+			Domain:      domainID,
+			SubjectType: auth.GroupType,
+			SubjectKind: auth.ChannelsKind,
+			Subject:     targetGroupID,
+			Relation:    auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+			Object:      id,
+		})
+	}
+
+	if len(addPolicies.AddPoliciesReq) > 0 {
+		if err := svc.reserveThings(ctx, targetGroupID, len(addPolicies.AddPoliciesReq)); err != nil {
+			return err
+		}
+		if _, err := svc.auth.AddPolicies(ctx, &addPolicies); err != nil {
+			err = errors.Wrap(svcerr.ErrAddPolicies, err)
+			if errCount := svc.updateThingCount(ctx, targetGroupID); errCount != nil {
+				err = errors.Wrap(err, errCount)
+			}
+			return err
+		}
+	}
+	if _, err := svc.auth.DeletePolicies(ctx, &deletePolicies); err != nil {
+		err = errors.Wrap(svcerr.ErrDeletePolicies, err)
+		if len(rollbackPolicies.DeletePoliciesReq) > 0 {
+			if _, errRollback := svc.auth.DeletePolicies(ctx, &rollbackPolicies); errRollback != nil {
+				err = errors.Wrap(err, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+			}
+		}
+		if errCount := svc.updateThingCount(ctx, targetGroupID); errCount != nil {
+			err = errors.Wrap(err, errCount)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// updateThingCount stores the number of things connected to the groups,
+// which is used to list the groups by thing count.
+func (svc service) updateThingCount(ctx context.Context, groupIDs ...string) error {
+	for _, id := range groupIDs {
+		res, err := svc.auth.CountObjects(ctx, &magistrala.CountObjectsReq{
+			SubjectType: auth.GroupType,
+			Subject:     id,
+			Permission:  auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+		})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		if err := svc.groups.UpdateThingCount(ctx, id, res.GetCount()); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// identify identifies the user of the bearer token, or the user on behalf of
+// whom the scoped token acts if it allows the operation scope. Operations
+// without a scope aren't allowed to scoped tokens.
+func (svc service) identify(ctx context.Context, token, scope string) (*magistrala.IdentityRes, error) {
+	if mgclients.IsScopedToken(token) {
+		st, err := svc.identifyScoped(ctx, token, scope)
+		if err != nil {
+			return nil, err
+		}
+		return &magistrala.IdentityRes{Id: st.Subject, UserId: st.UserID, DomainId: st.DomainID}, nil
+	}
+	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
 	if res.GetId() == "" || res.GetDomainId() == "" {
 		return nil, svcerr.ErrDomainAuthorization
@@ -666,7 +1888,34 @@ func (svc service) identify(ctx context.Context, token string) (*magistrala.Iden
 	return res, nil
 }
 
-func (svc service) authorizeToken(ctx context.Context, subjectType, subject, permission, objectType, object string) (string, error) {
+func (svc service) identifyScoped(ctx context.Context, token, scope string) (mgclients.ScopedToken, error) {
+	if svc.tokens == nil {
+		return mgclients.ScopedToken{}, svcerr.ErrAuthentication
+	}
+	st, err := svc.tokens.RetrieveToken(ctx, mgclients.HashToken(token))
+	if err != nil {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if st.Expired() {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrAuthentication, svcerr.ErrKeyExpired)
+	}
+	if !st.Allows(scope) {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrTokenScope)
+	}
+
+	return st, nil
+}
+
+// authorizeToken authorizes the user of the bearer token, or the user on
+// behalf of whom the scoped token acts if it allows the operation scope.
+func (svc service) authorizeToken(ctx context.Context, scope, subjectType, subject, permission, objectType, object string) (string, error) {
+	if mgclients.IsScopedToken(subject) {
+		st, err := svc.identifyScoped(ctx, subject, scope)
+		if err != nil {
+			return "", err
+		}
+		return svc.authorizeKind(ctx, st.DomainID, subjectType, auth.UsersKind, st.Subject, permission, objectType, object)
+	}
 	req := &magistrala.AuthorizeReq{
 		SubjectType: subjectType,
 		SubjectKind: auth.TokenKind,
@@ -6,6 +6,8 @@ package groups_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -49,7 +51,7 @@ var (
 func TestCreateGroup(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthClient)
-	svc := groups.NewService(repo, idProvider, authsvc)
+	svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
 
 	cases := []struct {
 		desc          string
@@ -68,6 +70,7 @@ func TestCreateGroup(t *testing.T) {
 		addPolErr     error
 		deletePolResp *magistrala.DeletePolicyRes
 		deletePolErr  error
+		parentDomain  string
 		err           error
 	}{
 		{
@@ -189,6 +192,29 @@ func TestCreateGroup(t *testing.T) {
 				Added: true,
 			},
 		},
+		{
+			desc:  "unsuccessfully with parent in a different domain",
+			token: token,
+			kind:  auth.NewGroupKind,
+			group: mggroups.Group{
+				Name:        namegen.Generate(),
+				Description: namegen.Generate(),
+				Status:      clients.Status(groups.EnabledStatus),
+				Parent:      testsutil.GenerateUUID(t),
+			},
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			authzTknResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			parentDomain: testsutil.GenerateUUID(t),
+			err:          mggroups.ErrParentDomain,
+		},
 		{
 			desc:  "unsuccessfully with parent due to authorization error",
 			token: token,
@@ -304,6 +330,11 @@ func TestCreateGroup(t *testing.T) {
 				Object:      tc.group.Parent,
 				ObjectType:  auth.GroupType,
 			}).Return(tc.authzTknResp, tc.authzTknErr)
+			parentDomain := tc.parentDomain
+			if parentDomain == "" {
+				parentDomain = tc.idResp.GetDomainId()
+			}
+			repocall0 := repo.On("RetrieveByID", context.Background(), tc.group.Parent).Return(mggroups.Group{ID: tc.group.Parent, Domain: parentDomain}, nil)
 			repocall := repo.On("Save", context.Background(), mock.Anything).Return(tc.repoResp, tc.repoErr)
 			authcall3 := authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPolResp, tc.addPolErr)
 			authCall4 := authsvc.On("DeletePolicies", mock.Anything, mock.Anything).Return(tc.deletePolResp, tc.deletePolErr)
@@ -320,6 +351,7 @@ func TestCreateGroup(t *testing.T) {
 			authcall.Unset()
 			authcall1.Unset()
 			authcall2.Unset()
+			repocall0.Unset()
 			repocall.Unset()
 			authcall3.Unset()
 			authCall4.Unset()
@@ -330,7 +362,7 @@ func TestCreateGroup(t *testing.T) {
 func TestViewGroup(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthClient)
-	svc := groups.NewService(repo, idProvider, authsvc)
+	svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
 
 	cases := []struct {
 		desc      string
@@ -395,20 +427,393 @@ func TestViewGroup(t *testing.T) {
 	}
 }
 
+func TestCloneGroup(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	parentID := testsutil.GenerateUUID(t)
+	targetID := testsutil.GenerateUUID(t)
+	source := mggroups.Group{
+		ID:          testsutil.GenerateUUID(t),
+		Domain:      domainID,
+		Parent:      parentID,
+		Name:        namegen.Generate(),
+		Description: namegen.Generate(),
+		Metadata:    clients.Metadata{"key": "value"},
+		Status:      clients.DisabledStatus,
+		ThingCount:  3,
+	}
+
+	cases := []struct {
+		desc         string
+		parentID     string
+		name         string
+		viewAuthzRes *magistrala.AuthorizeRes
+		editAuthzRes *magistrala.AuthorizeRes
+		saveErr      error
+		expected     mggroups.Group
+		err          error
+	}{
+		{
+			desc:         "clone group under the source parent",
+			viewAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			editAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			expected: mggroups.Group{
+				Parent:      parentID,
+				Name:        source.Name,
+				Description: source.Description,
+				Metadata:    source.Metadata,
+			},
+		},
+		{
+			desc:         "clone group under the target group with a new name",
+			parentID:     targetID,
+			name:         "clone",
+			viewAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			editAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			expected: mggroups.Group{
+				Parent:      targetID,
+				Name:        "clone",
+				Description: source.Description,
+				Metadata:    source.Metadata,
+			},
+		},
+		{
+			desc:         "clone group without view permission on the source",
+			viewAuthzRes: &magistrala.AuthorizeRes{Authorized: false},
+			err:          svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "clone group without edit permission on the target",
+			parentID:     targetID,
+			viewAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			editAuthzRes: &magistrala.AuthorizeRes{Authorized: false},
+			err:          svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "clone group with failed to save",
+			viewAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			editAuthzRes: &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:      repoerr.ErrCreateEntity,
+			err:          svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
+
+			parent := source.Parent
+			if tc.parentID != "" {
+				parent = tc.parentID
+			}
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     token,
+				Permission:  auth.ViewPermission,
+				Object:      source.ID,
+				ObjectType:  auth.GroupType,
+			}).Return(tc.viewAuthzRes, nil)
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     token,
+				Permission:  auth.EditPermission,
+				Object:      parent,
+				ObjectType:  auth.GroupType,
+			}).Return(tc.editAuthzRes, nil)
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(&magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID}, nil)
+			authsvc.On("Authorize", context.Background(), mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetObjectType() == auth.DomainType
+			})).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(&magistrala.AddPoliciesRes{Added: true}, nil)
+			authsvc.On("DeletePolicies", mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+			repo.On("RetrieveByID", context.Background(), source.ID).Return(source, nil)
+			repo.On("RetrieveByID", context.Background(), parent).Return(mggroups.Group{ID: parent, Domain: domainID}, nil)
+			repo.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, g mggroups.Group) mggroups.Group {
+				return g
+			}, tc.saveErr)
+
+			got, err := svc.CloneGroup(context.Background(), token, auth.NewChannelKind, source.ID, tc.parentID, tc.name)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if tc.err != nil {
+				return
+			}
+			assert.NotEqual(t, source.ID, got.ID, "expected the clone to get a new ID")
+			assert.Equal(t, domainID, got.Domain)
+			assert.Equal(t, tc.expected.Parent, got.Parent)
+			assert.Equal(t, tc.expected.Name, got.Name)
+			assert.Equal(t, tc.expected.Description, got.Description)
+			assert.Equal(t, tc.expected.Metadata, got.Metadata)
+			assert.Equal(t, clients.EnabledStatus, got.Status)
+			assert.Zero(t, got.ThingCount, "expected the clone to have no things")
+		})
+	}
+}
+
+func TestExportGroup(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	adminID := testsutil.GenerateUUID(t)
+	editorID := testsutil.GenerateUUID(t)
+	root := mggroups.Group{
+		ID:          testsutil.GenerateUUID(t),
+		Domain:      domainID,
+		Name:        namegen.Generate(),
+		Description: namegen.Generate(),
+		Metadata:    clients.Metadata{"key": "value"},
+		ThingCount:  3,
+	}
+	child := mggroups.Group{
+		ID:       testsutil.GenerateUUID(t),
+		Domain:   domainID,
+		Parent:   root.ID,
+		Name:     namegen.Generate(),
+		Metadata: clients.Metadata{"schema": "value"},
+	}
+
+	cases := []struct {
+		desc        string
+		authzRes    *magistrala.AuthorizeRes
+		listErr     error
+		childrenErr error
+		retrieveErr error
+		blueprint   mggroups.Blueprint
+		err         error
+	}{
+		{
+			desc:     "export group successfully",
+			authzRes: &magistrala.AuthorizeRes{Authorized: true},
+			blueprint: mggroups.Blueprint{
+				Version: mggroups.BlueprintVersion,
+				BlueprintGroup: mggroups.BlueprintGroup{
+					Name:        root.Name,
+					Description: root.Description,
+					Metadata:    root.Metadata,
+					Roles:       []string{auth.AdministratorRelation, auth.EditorRelation},
+					Children: []mggroups.BlueprintGroup{
+						{
+							Name:     child.Name,
+							Metadata: child.Metadata,
+							Roles:    []string{auth.AdministratorRelation, auth.EditorRelation},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:     "export group without view permission",
+			authzRes: &magistrala.AuthorizeRes{Authorized: false},
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "export group with failed to list policies",
+			authzRes: &magistrala.AuthorizeRes{Authorized: true},
+			listErr:  svcerr.ErrAuthorization,
+			err:      svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "export group with failed to retrieve children",
+			authzRes:    &magistrala.AuthorizeRes{Authorized: true},
+			childrenErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "export group with failed to retrieve child",
+			authzRes:    &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
+
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     token,
+				Permission:  auth.ViewPermission,
+				Object:      root.ID,
+				ObjectType:  auth.GroupType,
+			}).Return(tc.authzRes, nil)
+			// The administrator inherits the lower roles, so it is listed for each of them.
+			roleIDs := map[string][]string{
+				auth.AdministratorRelation: {adminID},
+				auth.EditorRelation:        {adminID, editorID},
+				auth.MemberRelation:        {adminID, editorID},
+			}
+			for _, id := range []string{root.ID, child.ID} {
+				for _, role := range []string{auth.AdministratorRelation, auth.EditorRelation, auth.ContributorRelation, auth.MemberRelation, auth.GuestRelation} {
+					var policies []string
+					for _, uid := range roleIDs[role] {
+						policies = append(policies, auth.EncodeDomainUserID(domainID, uid))
+					}
+					authsvc.On("ListAllSubjects", context.Background(), &magistrala.ListSubjectsReq{
+						SubjectType: auth.UserType,
+						Permission:  role,
+						Object:      id,
+						ObjectType:  auth.GroupType,
+					}).Return(&magistrala.ListSubjectsRes{Policies: policies}, tc.listErr)
+				}
+			}
+			repo.On("RetrieveByID", context.Background(), root.ID).Return(root, nil)
+			repo.On("RetrieveByID", context.Background(), child.ID).Return(child, tc.retrieveErr)
+			repo.On("RetrieveChildrenIDs", context.Background(), root.ID).Return([]string{child.ID}, tc.childrenErr)
+			repo.On("RetrieveChildrenIDs", context.Background(), child.ID).Return([]string{}, nil)
+
+			bp, err := svc.ExportGroup(context.Background(), token, root.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.blueprint, bp)
+		})
+	}
+}
+
+func TestImportGroup(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	blueprint := mggroups.Blueprint{
+		Version: mggroups.BlueprintVersion,
+		BlueprintGroup: mggroups.BlueprintGroup{
+			Name:        "line",
+			Description: namegen.Generate(),
+			Metadata:    clients.Metadata{"key": "value"},
+			Roles:       []string{auth.AdministratorRelation, auth.EditorRelation},
+			Children: []mggroups.BlueprintGroup{
+				{Name: "press", Metadata: clients.Metadata{"max_things": float64(10)}},
+				{Name: "paint", Roles: []string{auth.GuestRelation}},
+			},
+		},
+	}
+	invalidRole := blueprint
+	invalidRole.Children = []mggroups.BlueprintGroup{{Name: "press", Roles: []string{"owner"}}}
+
+	cases := []struct {
+		desc      string
+		domainID  string
+		blueprint mggroups.Blueprint
+		saveErr   error
+		saveFails string
+		deleted   int
+		err       error
+	}{
+		{
+			desc:      "import group successfully",
+			domainID:  domainID,
+			blueprint: blueprint,
+		},
+		{
+			desc:      "import group with incompatible version",
+			domainID:  domainID,
+			blueprint: mggroups.Blueprint{Version: mggroups.BlueprintVersion + 1, BlueprintGroup: blueprint.BlueprintGroup},
+			err:       mggroups.ErrBlueprintVersion,
+		},
+		{
+			desc:      "import group with invalid role",
+			domainID:  domainID,
+			blueprint: invalidRole,
+			err:       mggroups.ErrInvalidRole,
+		},
+		{
+			desc:      "import group into another domain",
+			domainID:  testsutil.GenerateUUID(t),
+			blueprint: blueprint,
+			err:       svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:      "import group with failed to save the root",
+			domainID:  domainID,
+			blueprint: blueprint,
+			saveErr:   repoerr.ErrCreateEntity,
+			saveFails: "line",
+			err:       svcerr.ErrCreateEntity,
+		},
+		{
+			desc:      "import group with failed to save a child",
+			domainID:  domainID,
+			blueprint: blueprint,
+			saveErr:   repoerr.ErrCreateEntity,
+			saveFails: "paint",
+			deleted:   2,
+			err:       svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
+
+			saved := make(map[string]mggroups.Group)
+			children := make(map[string][]string)
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(&magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID}, nil)
+			authsvc.On("Authorize", context.Background(), mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(&magistrala.AddPoliciesRes{Added: true}, nil)
+			authsvc.On("DeletePolicies", mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+			authsvc.On("DeleteEntityPolicies", context.Background(), mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+			repo.On("RetrieveByID", context.Background(), mock.Anything).Return(func(_ context.Context, id string) mggroups.Group {
+				return saved[id]
+			}, nil)
+			repo.On("Save", context.Background(), mock.Anything).Return(func(_ context.Context, g mggroups.Group) mggroups.Group {
+				if g.Name == tc.saveFails {
+					return mggroups.Group{}
+				}
+				saved[g.ID] = g
+				children[g.Parent] = append(children[g.Parent], g.ID)
+				return g
+			}, func(_ context.Context, g mggroups.Group) error {
+				if g.Name == tc.saveFails {
+					return tc.saveErr
+				}
+				return nil
+			})
+			repo.On("RetrieveChildrenIDs", context.Background(), mock.Anything).Return(func(_ context.Context, id string) []string {
+				return children[id]
+			}, nil)
+			repo.On("DeleteAll", context.Background(), mock.Anything).Return(nil)
+
+			g, err := svc.ImportGroup(context.Background(), token, auth.NewChannelKind, tc.domainID, tc.blueprint)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			authsvc.AssertNumberOfCalls(t, "DeleteEntityPolicies", tc.deleted)
+			if tc.err != nil {
+				return
+			}
+			assert.Equal(t, tc.blueprint.Name, g.Name)
+			assert.Equal(t, tc.blueprint.Description, g.Description)
+			assert.Equal(t, tc.blueprint.Metadata, g.Metadata)
+			assert.Equal(t, domainID, g.Domain)
+			assert.Empty(t, g.Parent)
+			assert.Len(t, g.Children, len(tc.blueprint.Children))
+			for i, child := range g.Children {
+				assert.Equal(t, tc.blueprint.Children[i].Name, child.Name)
+				assert.Equal(t, tc.blueprint.Children[i].Metadata, child.Metadata)
+				assert.Equal(t, g.ID, child.Parent)
+			}
+		})
+	}
+}
+
 func TestViewGroupPerms(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthClient)
-	svc := groups.NewService(repo, idProvider, authsvc)
+	svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
+
+	domainID := testsutil.GenerateUUID(t)
 
 	cases := []struct {
-		desc     string
-		token    string
-		id       string
-		idResp   *magistrala.IdentityRes
-		idErr    error
-		listResp *magistrala.ListPermissionsRes
-		listErr  error
-		err      error
+		desc         string
+		token        string
+		id           string
+		idResp       *magistrala.IdentityRes
+		idErr        error
+		listResp     *magistrala.ListPermissionsRes
+		listErr      error
+		retrieveResp mggroups.Group
+		retrieveErr  error
+		err          error
 	}{
 		{
 			desc:  "successfully",
@@ -445,17 +850,45 @@ func TestViewGroupPerms(t *testing.T) {
 			err:     svcerr.ErrAuthorization,
 		},
 		{
-			desc:  "with empty permissions",
+			desc:  "with empty permissions on group in domain",
 			token: token,
 			id:    testsutil.GenerateUUID(t),
 			idResp: &magistrala.IdentityRes{
 				Id:       testsutil.GenerateUUID(t),
-				DomainId: testsutil.GenerateUUID(t),
+				DomainId: domainID,
 			},
 			listResp: &magistrala.ListPermissionsRes{
 				Permissions: []string{},
 			},
-			err: svcerr.ErrAuthorization,
+			retrieveResp: mggroups.Group{Domain: domainID},
+		},
+		{
+			desc:  "with empty permissions on group in other domain",
+			token: token,
+			id:    testsutil.GenerateUUID(t),
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: domainID,
+			},
+			listResp: &magistrala.ListPermissionsRes{
+				Permissions: []string{},
+			},
+			retrieveResp: mggroups.Group{Domain: testsutil.GenerateUUID(t)},
+			err:          svcerr.ErrNotFound,
+		},
+		{
+			desc:  "with empty permissions on non-existing group",
+			token: token,
+			id:    testsutil.GenerateUUID(t),
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: domainID,
+			},
+			listResp: &magistrala.ListPermissionsRes{
+				Permissions: []string{},
+			},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
 		},
 	}
 
@@ -468,6 +901,7 @@ func TestViewGroupPerms(t *testing.T) {
 				Object:      tc.id,
 				ObjectType:  auth.GroupType,
 			}).Return(tc.listResp, tc.listErr)
+			repocall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveResp, tc.retrieveErr)
 			got, err := svc.ViewGroupPerms(context.Background(), tc.token, tc.id)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
 			if err == nil {
@@ -475,6 +909,7 @@ func TestViewGroupPerms(t *testing.T) {
 			}
 			authcall.Unset()
 			authcall1.Unset()
+			repocall.Unset()
 		})
 	}
 }
@@ -482,7 +917,7 @@ func TestViewGroupPerms(t *testing.T) {
 func TestUpdateGroup(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthClient)
-	svc := groups.NewService(repo, idProvider, authsvc)
+	svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
 
 	cases := []struct {
 		desc      string
@@ -532,83 +967,517 @@ func TestUpdateGroup(t *testing.T) {
 			authzErr: nil,
 			err:      svcerr.ErrAuthorization,
 		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.desc, func(t *testing.T) {
-			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
-				SubjectType: auth.UserType,
-				SubjectKind: auth.TokenKind,
-				Subject:     tc.token,
-				Permission:  auth.EditPermission,
-				Object:      tc.group.ID,
-				ObjectType:  auth.GroupType,
-			}).Return(tc.authzResp, tc.authzErr)
-			repo.On("Update", context.Background(), mock.Anything).Return(tc.repoResp, tc.repoErr)
-			got, err := svc.UpdateGroup(context.Background(), tc.token, tc.group)
-			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
-			if err == nil {
-				assert.Equal(t, tc.repoResp, got)
-				ok := repo.AssertCalled(t, "Update", context.Background(), mock.Anything)
-				assert.True(t, ok, fmt.Sprintf("Update was not called on %s", tc.desc))
-			}
-		})
-	}
-}
-
-func TestEnableGroup(t *testing.T) {
-	repo := new(mocks.Repository)
-	authsvc := new(authmocks.AuthClient)
-	svc := groups.NewService(repo, idProvider, authsvc)
-
-	cases := []struct {
-		desc         string
-		token        string
-		id           string
-		authzResp    *magistrala.AuthorizeRes
-		authzErr     error
-		retrieveResp mggroups.Group
-		retrieveErr  error
-		changeResp   mggroups.Group
-		changeErr    error
-		err          error
-	}{
 		{
-			desc:  "successfully",
+			desc:  "with valid forward config",
 			token: token,
-			id:    testsutil.GenerateUUID(t),
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.ForwardKey: map[string]interface{}{
+						"url":     "https://example.com/hook",
+						"headers": map[string]interface{}{"Authorization": "Bearer token"},
+					},
+				},
+			},
 			authzResp: &magistrala.AuthorizeRes{
 				Authorized: true,
 			},
-			retrieveResp: mggroups.Group{
-				Status: clients.Status(groups.DisabledStatus),
-			},
-			changeResp: validGroup,
+			repoResp: validGroup,
 		},
 		{
-			desc:  "with invalid token",
+			desc:  "with forward config using unsupported scheme",
 			token: token,
-			id:    testsutil.GenerateUUID(t),
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.ForwardKey: map[string]interface{}{"url": "ftp://example.com/hook"},
+				},
+			},
 			authzResp: &magistrala.AuthorizeRes{
-				Authorized: false,
+				Authorized: true,
 			},
-			authzErr: svcerr.ErrAuthorization,
-			err:      svcerr.ErrAuthorization,
+			err: errors.ErrMalformedEntity,
 		},
 		{
-			desc:  "with failed to authorize",
+			desc:  "with forward config using unsupported method",
 			token: token,
-			id:    testsutil.GenerateUUID(t),
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.ForwardKey: map[string]interface{}{"url": "http://example.com/hook", "method": "GET"},
+				},
+			},
 			authzResp: &magistrala.AuthorizeRes{
-				Authorized: false,
+				Authorized: true,
 			},
-			authzErr: nil,
-			err:      svcerr.ErrAuthorization,
+			err: errors.ErrMalformedEntity,
 		},
 		{
-			desc:  "with enabled group",
+			desc:  "with malformed forward config",
 			token: token,
-			id:    testsutil.GenerateUUID(t),
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.ForwardKey: "https://example.com/hook",
+				},
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "with valid payload schema",
+			token: token,
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.SchemaKey: map[string]interface{}{"type": "object"},
+				},
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			repoResp: validGroup,
+		},
+		{
+			desc:  "with invalid payload schema",
+			token: token,
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.SchemaKey: map[string]interface{}{"type": "temperature"},
+				},
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "with unknown payload transform",
+			token: token,
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.TransformsKey: []interface{}{
+						map[string]interface{}{"truncate": map[string]interface{}{"field": "t"}},
+					},
+				},
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "with config missing a content type key",
+			token: token,
+			group: mggroups.Group{
+				ID:   testsutil.GenerateUUID(t),
+				Name: namegen.Generate(),
+				Metadata: clients.Metadata{
+					mggroups.ContentTypeKey: mggroups.SenMLContentType,
+					mggroups.ConfigKey:      map[string]interface{}{"write": true},
+				},
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			err: errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
+				Permission:  auth.EditPermission,
+				Object:      tc.group.ID,
+				ObjectType:  auth.GroupType,
+			}).Return(tc.authzResp, tc.authzErr)
+			repo.On("Update", context.Background(), mock.Anything).Return(tc.repoResp, tc.repoErr)
+			got, err := svc.UpdateGroup(context.Background(), tc.token, tc.group)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if err == nil {
+				assert.Equal(t, tc.repoResp, got)
+				ok := repo.AssertCalled(t, "Update", context.Background(), mock.Anything)
+				assert.True(t, ok, fmt.Sprintf("Update was not called on %s", tc.desc))
+			}
+		})
+	}
+}
+
+func TestUpdateUsersGroup(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthClient)
+	svc := groups.NewService(repo, idProvider, authsvc)
+
+	// Groups of users don't have channel settings, so channel metadata
+	// keys are stored as they are.
+	group := mggroups.Group{
+		ID:   testsutil.GenerateUUID(t),
+		Name: namegen.Generate(),
+		Metadata: clients.Metadata{
+			mggroups.ContentTypeKey: mggroups.SenMLContentType,
+			mggroups.ConfigKey:      map[string]interface{}{"write": true},
+		},
+	}
+	authsvc.On("Authorize", context.Background(), mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	repo.On("Update", context.Background(), mock.Anything).Return(group, nil)
+
+	got, err := svc.UpdateGroup(context.Background(), token, group)
+	assert.Nil(t, err, fmt.Sprintf("update users group: unexpected error %s", err))
+	assert.Equal(t, group, got)
+}
+
+func TestUpdateGroups(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthClient)
+	svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
+
+	userID := testsutil.GenerateUUID(t)
+	first := mggroups.Group{
+		ID:       testsutil.GenerateUUID(t),
+		Name:     namegen.Generate(),
+		Metadata: clients.Metadata{"location": map[string]interface{}{"floor": float64(2)}},
+	}
+	second := mggroups.Group{
+		ID:   testsutil.GenerateUUID(t),
+		Name: namegen.Generate(),
+	}
+	current := mggroups.Group{
+		ID:       first.ID,
+		Metadata: clients.Metadata{"location": map[string]interface{}{"building": "north"}, "role": "sensors"},
+		Version:  3,
+	}
+	merged := clients.Metadata{"location": map[string]interface{}{"building": "north", "floor": float64(2)}, "role": "sensors"}
+
+	cases := []struct {
+		desc         string
+		groups       []mggroups.Group
+		merge        bool
+		partial      bool
+		unauthorized map[string]bool
+		updates      []mggroups.Group
+		updateErr    error
+		results      []mggroups.GroupUpdate
+		err          error
+	}{
+		{
+			desc:    "update groups successfully",
+			groups:  []mggroups.Group{first, second},
+			updates: []mggroups.Group{first, second},
+			results: []mggroups.GroupUpdate{
+				{ID: first.ID, Group: &first},
+				{ID: second.ID, Group: &second},
+			},
+		},
+		{
+			desc:         "update groups with unauthorized group",
+			groups:       []mggroups.Group{first, second},
+			unauthorized: map[string]bool{second.ID: true},
+			err:          svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "update groups partially with unauthorized group",
+			groups:       []mggroups.Group{first, second},
+			partial:      true,
+			unauthorized: map[string]bool{second.ID: true},
+			updates:      []mggroups.Group{first},
+			results: []mggroups.GroupUpdate{
+				{ID: first.ID, Group: &first},
+				{ID: second.ID, Error: svcerr.ErrAuthorization.Error()},
+			},
+		},
+		{
+			desc:         "update groups partially with all groups unauthorized",
+			groups:       []mggroups.Group{first, second},
+			partial:      true,
+			unauthorized: map[string]bool{first.ID: true, second.ID: true},
+			results: []mggroups.GroupUpdate{
+				{ID: first.ID, Error: svcerr.ErrAuthorization.Error()},
+				{ID: second.ID, Error: svcerr.ErrAuthorization.Error()},
+			},
+		},
+		{
+			desc:    "update groups with merged metadata",
+			groups:  []mggroups.Group{first},
+			merge:   true,
+			updates: []mggroups.Group{{ID: first.ID, Name: first.Name, Metadata: merged, Version: current.Version}},
+			results: []mggroups.GroupUpdate{
+				{ID: first.ID, Group: &mggroups.Group{ID: first.ID, Name: first.Name, Metadata: merged, Version: current.Version}},
+			},
+		},
+		{
+			desc:      "update groups with conflicting version",
+			groups:    []mggroups.Group{first, second},
+			updates:   []mggroups.Group{first, second},
+			updateErr: repoerr.ErrConflict,
+			err:       svcerr.ErrConflict,
+		},
+		{
+			desc:      "update groups with missing group",
+			groups:    []mggroups.Group{first, second},
+			updates:   []mggroups.Group{first, second},
+			updateErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var authCalls []*mock.Call
+			for _, g := range tc.groups {
+				authCalls = append(authCalls, authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+					SubjectType: auth.UserType,
+					SubjectKind: auth.TokenKind,
+					Subject:     token,
+					Permission:  auth.EditPermission,
+					Object:      g.ID,
+					ObjectType:  auth.GroupType,
+				}).Return(&magistrala.AuthorizeRes{Authorized: !tc.unauthorized[g.ID], Id: userID}, nil))
+			}
+			repoCall := repo.On("RetrieveByID", context.Background(), first.ID).Return(current, nil)
+			repoCall1 := repo.On("UpdateAll", context.Background(), mock.Anything).Return(tc.updates, tc.updateErr)
+			results, err := svc.UpdateGroups(context.Background(), token, tc.groups, tc.merge, tc.partial)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.results, results)
+			switch tc.updates {
+			case nil:
+				repo.AssertNotCalled(t, "UpdateAll", context.Background(), mock.Anything)
+			default:
+				repo.AssertCalled(t, "UpdateAll", context.Background(), mock.MatchedBy(func(gs []mggroups.Group) bool {
+					if len(gs) != len(tc.updates) {
+						return false
+					}
+					for i, g := range gs {
+						if g.ID != tc.updates[i].ID || g.Version != tc.updates[i].Version || g.UpdatedBy != userID {
+							return false
+						}
+						if !reflect.DeepEqual(g.Metadata, tc.updates[i].Metadata) {
+							return false
+						}
+					}
+					return true
+				}))
+			}
+			for _, call := range authCalls {
+				call.Unset()
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repo.Calls = nil
+		})
+	}
+}
+
+func TestPatchGroup(t *testing.T) {
+	userID := testsutil.GenerateUUID(t)
+	stored := mggroups.Group{
+		ID:          testsutil.GenerateUUID(t),
+		Name:        "channel",
+		Description: "description",
+		Metadata: clients.Metadata{
+			"role":   "sensors",
+			"config": map[string]interface{}{"interval": 10.0, "unit": "s"},
+		},
+		Status:  clients.EnabledStatus,
+		Version: 3,
+	}
+	name := "renamed"
+	empty := ""
+
+	cases := []struct {
+		desc         string
+		patch        mggroups.GroupPatch
+		unauthorized bool
+		stored       mggroups.Group
+		retrieveErr  error
+		replaceErrs  []error
+		replaced     mggroups.Group
+		err          error
+	}{
+		{
+			desc:  "patch group nested metadata",
+			patch: mggroups.GroupPatch{Metadata: clients.Metadata{"config": map[string]interface{}{"interval": 30.0, "unit": nil}}},
+			replaced: mggroups.Group{
+				Name:        "channel",
+				Description: "description",
+				Metadata:    clients.Metadata{"role": "sensors", "config": map[string]interface{}{"interval": 30.0}},
+				Version:     3,
+			},
+		},
+		{
+			desc:  "patch group name",
+			patch: mggroups.GroupPatch{Name: &name},
+			replaced: mggroups.Group{
+				Name:        name,
+				Description: "description",
+				Metadata:    stored.Metadata,
+				Version:     3,
+			},
+		},
+		{
+			desc:  "patch group clearing description and metadata",
+			patch: mggroups.GroupPatch{Description: &empty, ClearMetadata: true},
+			replaced: mggroups.Group{
+				Name:     "channel",
+				Metadata: clients.Metadata{},
+				Version:  3,
+			},
+		},
+		{
+			desc:         "patch group without permission",
+			patch:        mggroups.GroupPatch{Name: &name},
+			unauthorized: true,
+			err:          svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "patch non-existent group",
+			patch:       mggroups.GroupPatch{Name: &name},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:   "patch disabled group",
+			patch:  mggroups.GroupPatch{Name: &name},
+			stored: mggroups.Group{ID: stored.ID, Status: clients.DisabledStatus, Version: 3},
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:  "patch group with invalid channel metadata",
+			patch: mggroups.GroupPatch{Metadata: clients.Metadata{"schema": "object"}},
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "patch concurrently updated group",
+			patch:       mggroups.GroupPatch{Name: &name},
+			replaceErrs: []error{repoerr.ErrConflict},
+			replaced: mggroups.Group{
+				Name:        name,
+				Description: "description",
+				Metadata:    stored.Metadata,
+				Version:     3,
+			},
+		},
+		{
+			desc:        "patch continuously updated group",
+			patch:       mggroups.GroupPatch{Name: &name},
+			replaceErrs: []error{repoerr.ErrConflict, repoerr.ErrConflict, repoerr.ErrConflict, repoerr.ErrConflict, repoerr.ErrConflict},
+			err:         svcerr.ErrConflict,
+		},
+		{
+			desc:        "patch group with failed replacement",
+			patch:       mggroups.GroupPatch{Name: &name},
+			replaceErrs: []error{repoerr.ErrUpdateEntity},
+			err:         svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewChannelsService(repo, idProvider, authsvc, nil)
+
+			if tc.stored.ID == "" {
+				tc.stored = stored
+			}
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     token,
+				Permission:  auth.EditPermission,
+				Object:      stored.ID,
+				ObjectType:  auth.GroupType,
+			}).Return(&magistrala.AuthorizeRes{Authorized: !tc.unauthorized, Id: userID}, nil)
+			repo.On("RetrieveByID", context.Background(), stored.ID).Return(tc.stored, tc.retrieveErr)
+			for _, err := range tc.replaceErrs {
+				repo.On("Replace", context.Background(), mock.Anything).Return(mggroups.Group{}, err).Once()
+			}
+			repo.On("Replace", context.Background(), mock.Anything).Return(func(_ context.Context, g mggroups.Group) (mggroups.Group, error) {
+				return g, nil
+			})
+
+			g, err := svc.PatchGroup(context.Background(), token, stored.ID, tc.patch)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %v to contain %v", tc.desc, err, tc.err))
+			if tc.err != nil {
+				return
+			}
+			assert.Equal(t, stored.ID, g.ID, fmt.Sprintf("%s: expected ID %s got %s", tc.desc, stored.ID, g.ID))
+			assert.Equal(t, tc.replaced.Name, g.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, tc.replaced.Name, g.Name))
+			assert.Equal(t, tc.replaced.Description, g.Description, fmt.Sprintf("%s: expected description %s got %s", tc.desc, tc.replaced.Description, g.Description))
+			assert.Equal(t, tc.replaced.Metadata, g.Metadata, fmt.Sprintf("%s: expected metadata %v got %v", tc.desc, tc.replaced.Metadata, g.Metadata))
+			assert.Equal(t, tc.replaced.Version, g.Version, fmt.Sprintf("%s: expected version %d got %d", tc.desc, tc.replaced.Version, g.Version))
+			assert.Equal(t, userID, g.UpdatedBy, fmt.Sprintf("%s: expected updated by %s got %s", tc.desc, userID, g.UpdatedBy))
+			repo.AssertNumberOfCalls(t, "RetrieveByID", len(tc.replaceErrs)+1)
+		})
+	}
+}
+
+func TestEnableGroup(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthClient)
+	svc := groups.NewService(repo, idProvider, authsvc)
+
+	cases := []struct {
+		desc         string
+		token        string
+		id           string
+		authzResp    *magistrala.AuthorizeRes
+		authzErr     error
+		retrieveResp mggroups.Group
+		retrieveErr  error
+		changeResp   mggroups.Group
+		changeErr    error
+		err          error
+	}{
+		{
+			desc:  "successfully",
+			token: token,
+			id:    testsutil.GenerateUUID(t),
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			retrieveResp: mggroups.Group{
+				Status: clients.Status(groups.DisabledStatus),
+			},
+			changeResp: validGroup,
+		},
+		{
+			desc:  "with invalid token",
+			token: token,
+			id:    testsutil.GenerateUUID(t),
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: false,
+			},
+			authzErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "with failed to authorize",
+			token: token,
+			id:    testsutil.GenerateUUID(t),
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: false,
+			},
+			authzErr: nil,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "with enabled group",
+			token: token,
+			id:    testsutil.GenerateUUID(t),
 			authzResp: &magistrala.AuthorizeRes{
 				Authorized: true,
 			},
@@ -1644,31 +2513,144 @@ func TestListGroups(t *testing.T) {
 	}
 }
 
-func TestAssign(t *testing.T) {
+func TestListGroupsByRole(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthClient)
 	svc := groups.NewService(repo, idProvider, authsvc)
 
+	idResp := &magistrala.IdentityRes{
+		Id:       testsutil.GenerateUUID(t),
+		UserId:   testsutil.GenerateUUID(t),
+		DomainId: testsutil.GenerateUUID(t),
+	}
+	visibleIDs := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	otherID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
-		desc                    string
-		token                   string
-		groupID                 string
-		relation                string
-		memberKind              string
-		memberIDs               []string
-		idResp                  *magistrala.IdentityRes
-		idErr                   error
-		authzResp               *magistrala.AuthorizeRes
-		authzErr                error
-		addPoliciesRes          *magistrala.AddPoliciesRes
-		addPoliciesErr          error
-		repoResp                mggroups.Page
-		repoErr                 error
-		addParentPoliciesRes    *magistrala.AddPoliciesRes
-		addParentPoliciesErr    error
-		deleteParentPoliciesRes *magistrala.DeletePolicyRes
+		desc       string
+		role       string
+		superAdmin bool
+		roleIDs    []string
+		repoIDs    []string
+		repoCalled bool
+		err        error
+	}{
+		{
+			desc:       "list groups by role",
+			role:       auth.AdministratorRelation,
+			roleIDs:    []string{visibleIDs[1], otherID},
+			repoIDs:    []string{visibleIDs[1]},
+			repoCalled: true,
+		},
+		{
+			desc:       "list groups by role as super admin",
+			role:       auth.AdministratorRelation,
+			superAdmin: true,
+			roleIDs:    []string{visibleIDs[1], otherID},
+			repoIDs:    []string{visibleIDs[1], otherID},
+			repoCalled: true,
+		},
+		{
+			desc:    "list groups by role without groups with role",
+			role:    auth.EditorRelation,
+			roleIDs: []string{otherID},
+		},
+		{
+			desc:       "list groups by role without groups with role as super admin",
+			role:       auth.EditorRelation,
+			superAdmin: true,
+			roleIDs:    []string{},
+		},
+		{
+			desc: "list groups by invalid role",
+			role: "owner",
+			err:  mggroups.ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page := mggroups.Page{
+				PageMeta:   mggroups.PageMeta{Limit: 10},
+				Permission: auth.ViewPermission,
+				MyRole:     tc.role,
+			}
+			authcall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(idResp, nil)
+			authcall1 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				Subject:     idResp.GetUserId(),
+				Permission:  auth.AdminPermission,
+				ObjectType:  auth.PlatformType,
+				Object:      auth.MagistralaObject,
+			}).Return(&magistrala.AuthorizeRes{Authorized: tc.superAdmin}, nil)
+			authcall2 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.UsersKind,
+				Subject:     idResp.GetId(),
+				Permission:  auth.MembershipPermission,
+				Object:      idResp.GetDomainId(),
+				ObjectType:  auth.DomainType,
+			}).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			authcall3 := authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+				SubjectType: auth.UserType,
+				Subject:     idResp.GetId(),
+				Permission:  auth.ViewPermission,
+				ObjectType:  auth.GroupType,
+			}).Return(&magistrala.ListObjectsRes{Policies: visibleIDs}, nil)
+			authcall4 := authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+				SubjectType: auth.UserType,
+				Subject:     idResp.GetId(),
+				Permission:  tc.role,
+				ObjectType:  auth.GroupType,
+			}).Return(&magistrala.ListObjectsRes{Policies: tc.roleIDs}, nil)
+			repocall := repo.On("RetrieveByIDs", context.Background(), mock.Anything, tc.repoIDs).Return(mggroups.Page{Groups: []mggroups.Group{validGroup}}, nil)
+			got, err := svc.ListGroups(context.Background(), token, auth.UsersKind, "", page)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %v to contain %v", tc.desc, err, tc.err))
+			if err == nil {
+				// The repository returns groups only when called with the IDs of the groups with the role.
+				assert.Equal(t, tc.repoCalled, len(got.Groups) > 0, fmt.Sprintf("%s: unexpected groups %v", tc.desc, got.Groups))
+			}
+			authcall.Unset()
+			authcall1.Unset()
+			authcall2.Unset()
+			authcall3.Unset()
+			authcall4.Unset()
+			repocall.Unset()
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthClient)
+	svc := groups.NewService(repo, idProvider, authsvc)
+
+	cases := []struct {
+		desc                    string
+		token                   string
+		groupID                 string
+		relation                string
+		memberKind              string
+		memberIDs               []string
+		idResp                  *magistrala.IdentityRes
+		idErr                   error
+		authzResp               *magistrala.AuthorizeRes
+		authzErr                error
+		addPoliciesRes          *magistrala.AddPoliciesRes
+		addPoliciesErr          error
+		repoResp                mggroups.Page
+		repoErr                 error
+		addParentPoliciesRes    *magistrala.AddPoliciesRes
+		addParentPoliciesErr    error
+		deleteParentPoliciesRes *magistrala.DeletePolicyRes
 		deleteParentPoliciesErr error
 		repoParentGroupErr      error
+		connected               []string
+		listErr                 error
+		reserve                 int
+		reserveErr              error
+		countErr                error
+		updateCountErr          error
 		err                     error
 	}{
 		{
@@ -1961,6 +2943,117 @@ func TestAssign(t *testing.T) {
 			addPoliciesErr: svcerr.ErrAuthorization,
 			err:            svcerr.ErrAuthorization,
 		},
+		{
+			desc:       "unsuccessfully with things kind due to exceeded connection limit",
+			token:      token,
+			groupID:    testsutil.GenerateUUID(t),
+			relation:   auth.ContributorRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  allowedIDs,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			reserveErr: mggroups.ErrConnectionLimitExceeded,
+			err:        mggroups.ErrConnectionLimitExceeded,
+		},
+		{
+			desc:       "successfully with things kind reserving only unconnected things",
+			token:      token,
+			groupID:    testsutil.GenerateUUID(t),
+			relation:   auth.ContributorRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  append(allowedIDs[:2:2], allowedIDs[1]),
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			addPoliciesRes: &magistrala.AddPoliciesRes{
+				Added: true,
+			},
+			connected: allowedIDs[:1],
+			reserve:   1,
+		},
+		{
+			desc:       "unsuccessfully with things kind due to failed to list connected things",
+			token:      token,
+			groupID:    testsutil.GenerateUUID(t),
+			relation:   auth.ContributorRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  allowedIDs,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			listErr: svcerr.ErrAuthorization,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "unsuccessfully with things kind due to failed to reserve things",
+			token:      token,
+			groupID:    testsutil.GenerateUUID(t),
+			relation:   auth.ContributorRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  allowedIDs,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			reserveErr: repoerr.ErrNotFound,
+			err:        svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:       "unsuccessfully with things kind due to failed to count things",
+			token:      token,
+			groupID:    testsutil.GenerateUUID(t),
+			relation:   auth.ContributorRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  allowedIDs,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			addPoliciesRes: &magistrala.AddPoliciesRes{
+				Added: true,
+			},
+			countErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:       "unsuccessfully with things kind due to failed to update thing count",
+			token:      token,
+			groupID:    testsutil.GenerateUUID(t),
+			relation:   auth.ContributorRelation,
+			memberKind: auth.ThingsKind,
+			memberIDs:  allowedIDs,
+			idResp: &magistrala.IdentityRes{
+				Id:       testsutil.GenerateUUID(t),
+				DomainId: testsutil.GenerateUUID(t),
+			},
+			authzResp: &magistrala.AuthorizeRes{
+				Authorized: true,
+			},
+			addPoliciesRes: &magistrala.AddPoliciesRes{
+				Added: true,
+			},
+			updateCountErr: repoerr.ErrNotFound,
+			err:            svcerr.ErrUpdateEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1978,9 +3071,26 @@ func TestAssign(t *testing.T) {
 			retrieveByIDsCall := &mock.Call{}
 			deletePoliciesCall := &mock.Call{}
 			assignParentCall := &mock.Call{}
+			reserveCall := &mock.Call{}
+			listCall := &mock.Call{}
+			countCall := &mock.Call{}
+			updateCountCall := &mock.Call{}
 			policies := magistrala.AddPoliciesReq{}
 			switch tc.memberKind {
 			case auth.ThingsKind:
+				listCall = authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+					SubjectType: auth.GroupType,
+					Subject:     tc.groupID,
+					Permission:  auth.GroupRelation,
+					ObjectType:  auth.ThingType,
+				}).Return(&magistrala.ListObjectsRes{Policies: tc.connected}, tc.listErr)
+				reserve := len(tc.memberIDs) - len(tc.connected)
+				if tc.reserve > 0 {
+					reserve = tc.reserve
+				}
+				reserveCall = repo.On("ReserveThings", context.Background(), tc.groupID, uint64(reserve)).Return(tc.reserveErr)
+				countCall = authsvc.On("CountObjects", context.Background(), mock.Anything).Return(&magistrala.CountObjectsRes{Count: uint64(len(tc.memberIDs))}, tc.countErr)
+				updateCountCall = repo.On("UpdateThingCount", context.Background(), tc.groupID, mock.Anything).Return(tc.updateCountErr)
 				for _, memberID := range tc.memberIDs {
 					policies.AddPoliciesReq = append(policies.AddPoliciesReq, &magistrala.AddPolicyReq{
 						Domain:      tc.idResp.GetDomainId(),
@@ -2044,6 +3154,12 @@ func TestAssign(t *testing.T) {
 			authcall.Unset()
 			authcall1.Unset()
 			authcall2.Unset()
+			if tc.memberKind == auth.ThingsKind {
+				listCall.Unset()
+				reserveCall.Unset()
+				countCall.Unset()
+				updateCountCall.Unset()
+			}
 			if tc.memberKind == auth.GroupsKind {
 				retrieveByIDsCall.Unset()
 				deletePoliciesCall.Unset()
@@ -2078,6 +3194,8 @@ func TestUnassign(t *testing.T) {
 		deleteParentPoliciesRes *magistrala.DeletePolicyRes
 		deleteParentPoliciesErr error
 		repoParentGroupErr      error
+		countErr                error
+		updateCountErr          error
 		err                     error
 	}{
 		{
@@ -2387,9 +3505,13 @@ func TestUnassign(t *testing.T) {
 			retrieveByIDsCall := &mock.Call{}
 			addPoliciesCall := &mock.Call{}
 			assignParentCall := &mock.Call{}
+			countCall := &mock.Call{}
+			updateCountCall := &mock.Call{}
 			policies := magistrala.DeletePoliciesReq{}
 			switch tc.memberKind {
 			case auth.ThingsKind:
+				countCall = authsvc.On("CountObjects", context.Background(), mock.Anything).Return(&magistrala.CountObjectsRes{Count: uint64(len(tc.memberIDs))}, tc.countErr)
+				updateCountCall = repo.On("UpdateThingCount", context.Background(), tc.groupID, mock.Anything).Return(tc.updateCountErr)
 				for _, memberID := range tc.memberIDs {
 					policies.DeletePoliciesReq = append(policies.DeletePoliciesReq, &magistrala.DeletePolicyReq{
 						Domain:      tc.idResp.GetDomainId(),
@@ -2453,6 +3575,10 @@ func TestUnassign(t *testing.T) {
 			authcall.Unset()
 			authcall1.Unset()
 			authcall2.Unset()
+			if tc.memberKind == auth.ThingsKind {
+				countCall.Unset()
+				updateCountCall.Unset()
+			}
 			if tc.memberKind == auth.GroupsKind {
 				retrieveByIDsCall.Unset()
 				addPoliciesCall.Unset()
@@ -2462,122 +3588,1483 @@ func TestUnassign(t *testing.T) {
 	}
 }
 
-func TestDeleteGroup(t *testing.T) {
+func TestAssignRoles(t *testing.T) {
 	repo := new(mocks.Repository)
 	authsvc := new(authmocks.AuthClient)
 	svc := groups.NewService(repo, idProvider, authsvc)
 
+	domainID := testsutil.GenerateUUID(t)
+	existingGroupID := testsutil.GenerateUUID(t)
+	missingGroupID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
-		desc              string
-		token             string
-		groupID           string
-		idResp            *magistrala.IdentityRes
-		idErr             error
-		authzResp         *magistrala.AuthorizeRes
-		authzErr          error
-		deletePoliciesRes *magistrala.DeletePolicyRes
-		deletePoliciesErr error
-		repoErr           error
-		err               error
+		desc        string
+		token       string
+		assignments []mggroups.RoleAssignment
+		idResp      *magistrala.IdentityRes
+		idErr       error
+		authzResp   *magistrala.AuthorizeRes
+		authzErr    error
+		addPolsErr  error
+		results     []mggroups.RoleAssignment
+		err         error
 	}{
 		{
-			desc:    "successfully",
-			token:   token,
-			groupID: testsutil.GenerateUUID(t),
-			idResp: &magistrala.IdentityRes{
-				Id:       testsutil.GenerateUUID(t),
-				DomainId: testsutil.GenerateUUID(t),
-			},
-			authzResp: &magistrala.AuthorizeRes{
-				Authorized: true,
+			desc:        "assign roles successfully",
+			token:       token,
+			assignments: []mggroups.RoleAssignment{{GroupID: existingGroupID, Role: auth.EditorRelation}},
+			idResp:      &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:   &magistrala.AuthorizeRes{Authorized: true},
+			results:     []mggroups.RoleAssignment{{GroupID: existingGroupID, Role: auth.EditorRelation}},
+		},
+		{
+			desc:  "assign roles with missing group",
+			token: token,
+			assignments: []mggroups.RoleAssignment{
+				{GroupID: existingGroupID, Role: auth.EditorRelation},
+				{GroupID: missingGroupID, Role: auth.MemberRelation},
 			},
-			deletePoliciesRes: &magistrala.DeletePolicyRes{
-				Deleted: true,
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results: []mggroups.RoleAssignment{
+				{GroupID: existingGroupID, Role: auth.EditorRelation},
+				{GroupID: missingGroupID, Role: auth.MemberRelation, Error: svcerr.ErrNotFound.Error()},
 			},
 		},
 		{
-			desc:              "unsuccessfully with invalid token",
-			token:             token,
-			groupID:           testsutil.GenerateUUID(t),
-			idResp:            &magistrala.IdentityRes{},
-			deletePoliciesRes: &magistrala.DeletePolicyRes{},
-			idErr:             svcerr.ErrAuthentication,
-			err:               svcerr.ErrAuthentication,
+			desc:  "assign roles with invalid role",
+			token: token,
+			assignments: []mggroups.RoleAssignment{
+				{GroupID: existingGroupID, Role: auth.EditorRelation},
+				{GroupID: existingGroupID, Role: "owner"},
+			},
+			idResp: &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			err:    mggroups.ErrInvalidRole,
 		},
 		{
-			desc:    "unsuccessfully with authorization error",
-			token:   token,
-			groupID: testsutil.GenerateUUID(t),
-			idResp: &magistrala.IdentityRes{
-				Id:       testsutil.GenerateUUID(t),
-				DomainId: testsutil.GenerateUUID(t),
-			},
-			authzResp: &magistrala.AuthorizeRes{
-				Authorized: false,
-			},
-			deletePoliciesRes: &magistrala.DeletePolicyRes{},
-			authzErr:          svcerr.ErrAuthorization,
-			err:               svcerr.ErrAuthorization,
+			desc:        "assign roles with invalid token",
+			token:       token,
+			assignments: []mggroups.RoleAssignment{{GroupID: existingGroupID, Role: auth.EditorRelation}},
+			idResp:      &magistrala.IdentityRes{},
+			idErr:       svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc:    "unsuccessfully with failed to remove policy",
-			token:   token,
-			groupID: testsutil.GenerateUUID(t),
-			idResp: &magistrala.IdentityRes{
-				Id:       testsutil.GenerateUUID(t),
-				DomainId: testsutil.GenerateUUID(t),
-			},
-			authzResp: &magistrala.AuthorizeRes{
-				Authorized: true,
-			},
-			deletePoliciesRes: &magistrala.DeletePolicyRes{
-				Deleted: false,
-			},
-			deletePoliciesErr: svcerr.ErrAuthorization,
-			err:               svcerr.ErrAuthorization,
+			desc:        "assign roles with failed authorization",
+			token:       token,
+			assignments: []mggroups.RoleAssignment{{GroupID: existingGroupID, Role: auth.EditorRelation}},
+			idResp:      &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:   &magistrala.AuthorizeRes{Authorized: false},
+			err:         svcerr.ErrAuthorization,
 		},
 		{
-			desc:    "unsuccessfully with repo err",
-			token:   token,
-			groupID: testsutil.GenerateUUID(t),
-			idResp: &magistrala.IdentityRes{
-				Id:       testsutil.GenerateUUID(t),
-				DomainId: testsutil.GenerateUUID(t),
-			},
-			authzResp: &magistrala.AuthorizeRes{
-				Authorized: true,
-			},
-			deletePoliciesRes: &magistrala.DeletePolicyRes{
-				Deleted: true,
-			},
-			repoErr: repoerr.ErrNotFound,
-			err:     repoerr.ErrNotFound,
+			desc:        "assign roles with failed to add policies",
+			token:       token,
+			assignments: []mggroups.RoleAssignment{{GroupID: existingGroupID, Role: auth.EditorRelation}},
+			idResp:      &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:   &magistrala.AuthorizeRes{Authorized: true},
+			addPolsErr:  svcerr.ErrAuthorization,
+			err:         svcerr.ErrAddPolicies,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			authcall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
-			authcall1 := authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
-				Domain:      tc.idResp.GetDomainId(),
-				SubjectType: auth.UserType,
-				SubjectKind: auth.UsersKind,
-				Subject:     tc.idResp.GetId(),
-				Permission:  auth.DeletePermission,
-				Object:      tc.groupID,
-				ObjectType:  auth.GroupType,
-			}).Return(tc.authzResp, tc.authzErr)
-			authcall2 := authsvc.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{
-				EntityType: auth.GroupType,
-				Id:         tc.groupID,
-			}).Return(tc.deletePoliciesRes, tc.deletePoliciesErr)
-			repocall := repo.On("Delete", context.Background(), tc.groupID).Return(tc.repoErr)
-			err := svc.DeleteGroup(context.Background(), tc.token, tc.groupID)
+			authcall1 := authsvc.On("Authorize", context.Background(), mock.Anything).Return(tc.authzResp, tc.authzErr)
+			authcall2 := authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(&magistrala.AddPoliciesRes{Added: tc.addPolsErr == nil}, tc.addPolsErr)
+			repocall := repo.On("RetrieveByID", context.Background(), existingGroupID).Return(mggroups.Group{ID: existingGroupID, Domain: domainID}, nil)
+			repocall1 := repo.On("RetrieveByID", context.Background(), missingGroupID).Return(mggroups.Group{}, repoerr.ErrNotFound)
+			results, err := svc.AssignRoles(context.Background(), tc.token, testsutil.GenerateUUID(t), tc.assignments)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.results, results)
 			authcall.Unset()
 			authcall1.Unset()
 			authcall2.Unset()
 			repocall.Unset()
+			repocall1.Unset()
+		})
+	}
+}
+
+func TestMoveThings(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	targetID := testsutil.GenerateUUID(t)
+	memberID := testsutil.GenerateUUID(t)
+	strayID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc         string
+		token        string
+		thingIDs     []string
+		idResp       *magistrala.IdentityRes
+		idErr        error
+		authzResp    *magistrala.AuthorizeRes
+		authzErr     error
+		targetDomain string
+		retrieveErr  error
+		listErr      error
+		addPolsErr   error
+		delPolsErr   error
+		countErr     error
+		reserveErr   error
+		results      []mggroups.ThingMove
+		err          error
+	}{
+		{
+			desc:         "move things successfully",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			results:      []mggroups.ThingMove{{ThingID: memberID}},
+		},
+		{
+			desc:         "move things with thing not in source group",
+			token:        token,
+			thingIDs:     []string{memberID, strayID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			results:      []mggroups.ThingMove{{ThingID: memberID}, {ThingID: strayID, Error: mggroups.ErrNotMember.Error()}},
+		},
+		{
+			desc:     "move things with invalid token",
+			token:    token,
+			thingIDs: []string{memberID},
+			idResp:   &magistrala.IdentityRes{},
+			idErr:    svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "move things with failed authorization",
+			token:     token,
+			thingIDs:  []string{memberID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: false},
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "move things with failed to retrieve groups",
+			token:       token,
+			thingIDs:    []string{memberID},
+			idResp:      &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:   &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "move things to group in another domain",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: testsutil.GenerateUUID(t),
+			err:          mggroups.ErrTargetDomain,
+		},
+		{
+			desc:         "move things with failed to list members",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			listErr:      svcerr.ErrAuthorization,
+			err:          svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "move things with failed to add policies",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			addPolsErr:   svcerr.ErrAuthorization,
+			err:          svcerr.ErrAddPolicies,
+		},
+		{
+			desc:         "move things with failed to delete policies",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			delPolsErr:   svcerr.ErrAuthorization,
+			err:          svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:         "move things with failed to count things",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			countErr:     svcerr.ErrNotFound,
+			err:          svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:         "move things with exceeded connection limit",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			reserveErr:   mggroups.ErrConnectionLimitExceeded,
+			err:          mggroups.ErrConnectionLimitExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
+			authsvc.On("Authorize", context.Background(), mock.Anything).Return(tc.authzResp, tc.authzErr)
+			authsvc.On("ListAllObjects", context.Background(), mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+				return req.GetSubject() == groupID
+			})).Return(&magistrala.ListObjectsRes{Policies: []string{memberID}}, tc.listErr)
+			authsvc.On("ListAllObjects", context.Background(), mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+				return req.GetSubject() == targetID
+			})).Return(&magistrala.ListObjectsRes{}, nil)
+			authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(&magistrala.AddPoliciesRes{Added: tc.addPolsErr == nil}, tc.addPolsErr)
+			authsvc.On("DeletePolicies", context.Background(), mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: tc.delPolsErr == nil}, tc.delPolsErr)
+			repo.On("RetrieveByID", context.Background(), groupID).Return(mggroups.Group{ID: groupID, Domain: domainID}, tc.retrieveErr)
+			repo.On("RetrieveByID", context.Background(), targetID).Return(mggroups.Group{ID: targetID, Domain: tc.targetDomain}, tc.retrieveErr)
+			authsvc.On("CountObjects", context.Background(), mock.Anything).Return(&magistrala.CountObjectsRes{Count: 1}, tc.countErr)
+			repo.On("UpdateThingCount", context.Background(), mock.Anything, mock.Anything).Return(nil)
+			repo.On("ReserveThings", context.Background(), targetID, uint64(1)).Return(tc.reserveErr)
+			results, err := svc.MoveThings(context.Background(), tc.token, groupID, targetID, tc.thingIDs)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.results, results)
+		})
+	}
+}
+
+func TestReassignThings(t *testing.T) {
+	repo := new(mocks.Repository)
+	authsvc := new(authmocks.AuthClient)
+	svc := groups.NewService(repo, idProvider, authsvc)
+
+	domainID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	targetID := testsutil.GenerateUUID(t)
+	memberID := testsutil.GenerateUUID(t)
+
+	var manyIDs []string
+	for i := 0; i < 250; i++ {
+		manyIDs = append(manyIDs, testsutil.GenerateUUID(t))
+	}
+
+	cases := []struct {
+		desc         string
+		token        string
+		thingIDs     []string
+		targetIDs    []string
+		idResp       *magistrala.IdentityRes
+		idErr        error
+		authzResp    *magistrala.AuthorizeRes
+		authzErr     error
+		targetDomain string
+		targetParent string
+		retrieveErr  error
+		listErr      error
+		addPolsErr   error
+		delPolsErr   error
+		countErr     error
+		reserveErr   error
+		batches      int
+		moved        []string
+		err          error
+	}{
+		{
+			desc:         "reassign things successfully",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			batches:      1,
+			moved:        []string{memberID},
+		},
+		{
+			desc:         "reassign things in batches",
+			token:        token,
+			thingIDs:     manyIDs,
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			batches:      3,
+			moved:        manyIDs,
+		},
+		{
+			desc:         "reassign things already connected to target group",
+			token:        token,
+			thingIDs:     []string{memberID},
+			targetIDs:    []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			addPolsErr:   svcerr.ErrAuthorization,
+			moved:        []string{memberID},
+		},
+		{
+			desc:         "reassign things of empty group",
+			token:        token,
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			moved:        []string{},
+		},
+		{
+			desc:     "reassign things with invalid token",
+			token:    token,
+			thingIDs: []string{memberID},
+			idResp:   &magistrala.IdentityRes{},
+			idErr:    svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "reassign things with failed authorization",
+			token:     token,
+			thingIDs:  []string{memberID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: false},
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "reassign things with failed to retrieve groups",
+			token:       token,
+			thingIDs:    []string{memberID},
+			idResp:      &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:   &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "reassign things to group in another domain",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: testsutil.GenerateUUID(t),
+			err:          mggroups.ErrTargetDomain,
+		},
+		{
+			desc:         "reassign things to group with another parent",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			targetParent: testsutil.GenerateUUID(t),
+			err:          mggroups.ErrTargetParent,
+		},
+		{
+			desc:         "reassign things with failed to list members",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			listErr:      svcerr.ErrAuthorization,
+			err:          svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "reassign things with failed to add policies",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			addPolsErr:   svcerr.ErrAuthorization,
+			moved:        []string{},
+			err:          svcerr.ErrAddPolicies,
+		},
+		{
+			desc:         "reassign things with failed to delete policies",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			delPolsErr:   svcerr.ErrAuthorization,
+			moved:        []string{},
+			err:          svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:         "reassign things with failed to count things",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			countErr:     svcerr.ErrNotFound,
+			moved:        []string{memberID},
+			err:          svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:         "reassign things with exceeded connection limit",
+			token:        token,
+			thingIDs:     []string{memberID},
+			idResp:       &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:    &magistrala.AuthorizeRes{Authorized: true},
+			targetDomain: domainID,
+			reserveErr:   mggroups.ErrConnectionLimitExceeded,
+			moved:        []string{},
+			err:          mggroups.ErrConnectionLimitExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authcall := authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
+			authcall1 := authsvc.On("Authorize", context.Background(), mock.Anything).Return(tc.authzResp, tc.authzErr)
+			authcall2 := authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+				SubjectType: auth.GroupType,
+				Subject:     groupID,
+				Permission:  auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+			}).Return(&magistrala.ListObjectsRes{Policies: tc.thingIDs}, tc.listErr)
+			authcall3 := authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+				SubjectType: auth.GroupType,
+				Subject:     targetID,
+				Permission:  auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+			}).Return(&magistrala.ListObjectsRes{Policies: tc.targetIDs}, tc.listErr)
+			authcall4 := authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(&magistrala.AddPoliciesRes{Added: tc.addPolsErr == nil}, tc.addPolsErr)
+			batches := 0
+			authcall5 := authsvc.On("DeletePolicies", context.Background(), mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: tc.delPolsErr == nil}, tc.delPolsErr).Run(func(args mock.Arguments) {
+				batches++
+			})
+			repocall := repo.On("RetrieveByID", context.Background(), groupID).Return(mggroups.Group{ID: groupID, Domain: domainID}, tc.retrieveErr)
+			repocall1 := repo.On("RetrieveByID", context.Background(), targetID).Return(mggroups.Group{ID: targetID, Domain: tc.targetDomain, Parent: tc.targetParent}, tc.retrieveErr)
+			authcall6 := authsvc.On("CountObjects", context.Background(), mock.Anything).Return(&magistrala.CountObjectsRes{Count: 1}, tc.countErr)
+			repocall2 := repo.On("UpdateThingCount", context.Background(), mock.Anything, mock.Anything).Return(nil)
+			repocall3 := repo.On("ReserveThings", context.Background(), targetID, mock.Anything).Return(tc.reserveErr)
+			moved, err := svc.ReassignThings(context.Background(), tc.token, groupID, targetID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.moved, moved)
+			if tc.batches > 0 {
+				assert.Equal(t, tc.batches, batches, fmt.Sprintf("%s: expected %d batches got %d", tc.desc, tc.batches, batches))
+			}
+			authcall.Unset()
+			authcall1.Unset()
+			authcall2.Unset()
+			authcall3.Unset()
+			authcall4.Unset()
+			authcall5.Unset()
+			authcall6.Unset()
+			repocall.Unset()
+			repocall1.Unset()
+			repocall2.Unset()
+			repocall3.Unset()
+		})
+	}
+}
+
+func TestAttachThings(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	otherID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+	strayID := testsutil.GenerateUUID(t)
+	lockedID := testsutil.GenerateUUID(t)
+	sharedID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc       string
+		token      string
+		thingIDs   []string
+		reassign   bool
+		connected  []string
+		idResp     *magistrala.IdentityRes
+		idErr      error
+		authzResp  *magistrala.AuthorizeRes
+		listErr    error
+		addPolsErr error
+		delPolsErr error
+		countErr   error
+		reserveErr error
+		recounted  []string
+		results    []mggroups.ThingAttachment
+		err        error
+	}{
+		{
+			desc:      "attach things successfully",
+			token:     token,
+			thingIDs:  []string{thingID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID}},
+		},
+		{
+			desc:      "attach things already attached to the group",
+			token:     token,
+			thingIDs:  []string{thingID},
+			connected: []string{groupID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID}},
+		},
+		{
+			desc:      "attach things attached to another group",
+			token:     token,
+			thingIDs:  []string{thingID},
+			connected: []string{otherID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID, Error: mggroups.ErrSharedThing.Error()}},
+		},
+		{
+			desc:      "attach things attached to another group with reassign",
+			token:     token,
+			thingIDs:  []string{thingID},
+			reassign:  true,
+			connected: []string{groupID, otherID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID, Detached: []string{otherID}}},
+		},
+		{
+			desc:      "attach things attached to another group with reassign from locked group",
+			token:     token,
+			thingIDs:  []string{thingID},
+			reassign:  true,
+			connected: []string{otherID, lockedID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID, Error: svcerr.ErrAuthorization.Error()}},
+		},
+		{
+			desc:      "attach things attached to the same group with reassign",
+			token:     token,
+			thingIDs:  []string{thingID, sharedID},
+			reassign:  true,
+			connected: []string{otherID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			recounted: []string{groupID, otherID},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID, Detached: []string{otherID}}, {ThingID: sharedID, Detached: []string{otherID}}},
+		},
+		{
+			desc:      "attach things with unauthorized thing",
+			token:     token,
+			thingIDs:  []string{thingID, strayID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			results:   []mggroups.ThingAttachment{{ThingID: thingID}, {ThingID: strayID, Error: svcerr.ErrAuthorization.Error()}},
+		},
+		{
+			desc:     "attach things with invalid token",
+			token:    token,
+			thingIDs: []string{thingID},
+			idResp:   &magistrala.IdentityRes{},
+			idErr:    svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:      "attach things with failed authorization",
+			token:     token,
+			thingIDs:  []string{thingID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: false},
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "attach things with failed to list groups of thing",
+			token:     token,
+			thingIDs:  []string{thingID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			listErr:   svcerr.ErrAuthorization,
+			err:       svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "attach things with failed to add policies",
+			token:      token,
+			thingIDs:   []string{thingID},
+			idResp:     &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:  &magistrala.AuthorizeRes{Authorized: true},
+			addPolsErr: svcerr.ErrAuthorization,
+			err:        svcerr.ErrAddPolicies,
+		},
+		{
+			desc:       "attach things with failed to delete policies",
+			token:      token,
+			thingIDs:   []string{thingID},
+			reassign:   true,
+			connected:  []string{otherID},
+			idResp:     &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:  &magistrala.AuthorizeRes{Authorized: true},
+			delPolsErr: svcerr.ErrAuthorization,
+			recounted:  []string{groupID, otherID},
+			err:        svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:      "attach things with failed to count things",
+			token:     token,
+			thingIDs:  []string{thingID},
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			countErr:  svcerr.ErrNotFound,
+			err:       svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:       "attach things with exceeded connection limit",
+			token:      token,
+			thingIDs:   []string{thingID},
+			idResp:     &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), DomainId: domainID},
+			authzResp:  &magistrala.AuthorizeRes{Authorized: true},
+			reserveErr: mggroups.ErrConnectionLimitExceeded,
+			err:        mggroups.ErrConnectionLimitExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
+			authsvc.On("Authorize", context.Background(), mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetObject() == strayID || req.GetObject() == lockedID
+			})).Return(&magistrala.AuthorizeRes{Authorized: false}, nil)
+			authsvc.On("Authorize", context.Background(), mock.Anything).Return(tc.authzResp, nil)
+			authsvc.On("ListAllSubjects", context.Background(), mock.Anything).Return(&magistrala.ListSubjectsRes{Policies: tc.connected}, tc.listErr)
+			authsvc.On("AddPolicies", context.Background(), mock.Anything).Return(&magistrala.AddPoliciesRes{Added: tc.addPolsErr == nil}, tc.addPolsErr)
+			authsvc.On("DeletePolicies", context.Background(), mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: tc.delPolsErr == nil}, tc.delPolsErr)
+			authsvc.On("CountObjects", context.Background(), mock.Anything).Return(&magistrala.CountObjectsRes{Count: 1}, tc.countErr)
+			repo.On("UpdateThingCount", context.Background(), mock.Anything, mock.Anything).Return(nil)
+			repo.On("ReserveThings", context.Background(), groupID, mock.Anything).Return(tc.reserveErr)
+			results, err := svc.AttachThings(context.Background(), tc.token, groupID, tc.thingIDs, tc.reassign)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.results, results)
+			for _, id := range tc.recounted {
+				repo.AssertCalled(t, "UpdateThingCount", context.Background(), id, mock.Anything)
+			}
+			if len(tc.recounted) > 0 {
+				repo.AssertNumberOfCalls(t, "UpdateThingCount", len(tc.recounted))
+			}
+		})
+	}
+}
+
+func TestListMemberGroups(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	memberID := testsutil.GenerateUUID(t)
+	editedID := testsutil.GenerateUUID(t)
+	viewedID := testsutil.GenerateUUID(t)
+	pm := mggroups.PageMeta{Limit: 10}
+
+	cases := []struct {
+		desc        string
+		token       string
+		idResp      *magistrala.IdentityRes
+		idErr       error
+		editorIDs   []string
+		memberIDs   []string
+		adminIDs    []string
+		listErr     error
+		retrieveIDs []string
+		retrieveErr error
+		page        mggroups.MemberGroupsPage
+		err         error
+	}{
+		{
+			desc:        "list own groups successfully",
+			token:       token,
+			idResp:      &magistrala.IdentityRes{Id: domainID + "_" + memberID, UserId: memberID, DomainId: domainID},
+			editorIDs:   []string{editedID},
+			memberIDs:   []string{editedID, viewedID},
+			retrieveIDs: []string{editedID, viewedID},
+			page: mggroups.MemberGroupsPage{
+				PageMeta: pm,
+				Groups: []mggroups.MemberGroup{
+					{Group: mggroups.Group{ID: editedID}, Role: auth.EditorRelation},
+					{Group: mggroups.Group{ID: viewedID}, Role: auth.MemberRelation},
+				},
+			},
+		},
+		{
+			desc:        "list groups of another member omitting groups the caller does not administer",
+			token:       token,
+			idResp:      &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), UserId: testsutil.GenerateUUID(t), DomainId: domainID},
+			editorIDs:   []string{editedID},
+			memberIDs:   []string{viewedID},
+			adminIDs:    []string{viewedID},
+			retrieveIDs: []string{viewedID},
+			page: mggroups.MemberGroupsPage{
+				PageMeta: pm,
+				Groups: []mggroups.MemberGroup{
+					{Group: mggroups.Group{ID: viewedID}, Role: auth.MemberRelation},
+				},
+			},
+		},
+		{
+			desc:      "list groups of another member without administered groups",
+			token:     token,
+			idResp:    &magistrala.IdentityRes{Id: testsutil.GenerateUUID(t), UserId: testsutil.GenerateUUID(t), DomainId: domainID},
+			editorIDs: []string{editedID},
+			page:      mggroups.MemberGroupsPage{PageMeta: pm, Groups: []mggroups.MemberGroup{}},
+		},
+		{
+			desc:   "list member groups with invalid token",
+			token:  token,
+			idResp: &magistrala.IdentityRes{},
+			idErr:  svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "list member groups with failed to list policies",
+			token:   token,
+			idResp:  &magistrala.IdentityRes{Id: domainID + "_" + memberID, UserId: memberID, DomainId: domainID},
+			listErr: svcerr.ErrAuthorization,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "list member groups with failed to retrieve groups",
+			token:       token,
+			idResp:      &magistrala.IdentityRes{Id: domainID + "_" + memberID, UserId: memberID, DomainId: domainID},
+			editorIDs:   []string{editedID},
+			retrieveIDs: []string{editedID},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+			subject := auth.EncodeDomainUserID(domainID, memberID)
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
+			roleIDs := map[string][]string{
+				auth.EditorRelation: tc.editorIDs,
+				auth.MemberRelation: tc.memberIDs,
+			}
+			authsvc.On("ListAllObjects", context.Background(), mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+				return req.GetSubject() == subject && req.GetPermission() == auth.EditorRelation
+			})).Return(&magistrala.ListObjectsRes{Policies: roleIDs[auth.EditorRelation]}, tc.listErr)
+			authsvc.On("ListAllObjects", context.Background(), mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+				return req.GetSubject() == subject && req.GetPermission() == auth.MemberRelation
+			})).Return(&magistrala.ListObjectsRes{Policies: roleIDs[auth.MemberRelation]}, tc.listErr)
+			authsvc.On("ListAllObjects", context.Background(), mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+				_, ok := roleIDs[req.GetPermission()]
+				return req.GetSubject() == subject && !ok
+			})).Return(&magistrala.ListObjectsRes{}, tc.listErr)
+			authsvc.On("ListAllObjects", context.Background(), mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+				return req.GetSubject() != subject
+			})).Return(&magistrala.ListObjectsRes{Policies: tc.adminIDs}, nil)
+			retrieved := mggroups.Page{PageMeta: pm}
+			for _, id := range tc.retrieveIDs {
+				retrieved.Groups = append(retrieved.Groups, mggroups.Group{ID: id})
+			}
+			repo.On("RetrieveByIDs", context.Background(), mggroups.Page{PageMeta: pm}, mock.Anything).Return(retrieved, tc.retrieveErr)
+			page, err := svc.ListMemberGroups(context.Background(), tc.token, memberID, pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.page, page)
+		})
+	}
+}
+
+func TestListMemberRoles(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	adminID := testsutil.GenerateUUID(t)
+	editorID := testsutil.GenerateUUID(t)
+	idleID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC()
+	earlier := now.Add(-24 * time.Hour)
+
+	cases := []struct {
+		desc          string
+		token         string
+		inactiveSince time.Time
+		authzResp     *magistrala.AuthorizeRes
+		authzErr      error
+		activity      map[string]time.Time
+		activityErr   error
+		listErr       error
+		members       []mggroups.MemberRole
+		err           error
+	}{
+		{
+			desc:      "list member roles successfully",
+			token:     token,
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			activity:  map[string]time.Time{adminID: now, editorID: earlier},
+			members: []mggroups.MemberRole{
+				{MemberID: adminID, Role: auth.AdministratorRelation, LastActive: &now},
+				{MemberID: editorID, Role: auth.EditorRelation, LastActive: &earlier},
+				{MemberID: idleID, Role: auth.MemberRelation},
+			},
+		},
+		{
+			desc:          "list member roles inactive since a time",
+			token:         token,
+			inactiveSince: now.Add(-time.Hour),
+			authzResp:     &magistrala.AuthorizeRes{Authorized: true},
+			activity:      map[string]time.Time{adminID: now, editorID: earlier},
+			members: []mggroups.MemberRole{
+				{MemberID: editorID, Role: auth.EditorRelation, LastActive: &earlier},
+				{MemberID: idleID, Role: auth.MemberRelation},
+			},
+		},
+		{
+			desc:      "list member roles with failed authorization",
+			token:     token,
+			authzResp: &magistrala.AuthorizeRes{Authorized: false},
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "list member roles with failed to retrieve activity",
+			token:       token,
+			authzResp:   &magistrala.AuthorizeRes{Authorized: true},
+			activityErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "list member roles with failed to list policies",
+			token:     token,
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			listErr:   svcerr.ErrAuthorization,
+			err:       svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				SubjectType: auth.UserType,
+				SubjectKind: auth.TokenKind,
+				Subject:     tc.token,
+				Permission:  auth.AdminPermission,
+				Object:      groupID,
+				ObjectType:  auth.GroupType,
+			}).Return(tc.authzResp, tc.authzErr)
+			repo.On("RetrieveActivity", context.Background(), groupID).Return(tc.activity, tc.activityErr)
+			// The administrator inherits the lower roles, so it is listed for each of them.
+			roleIDs := map[string][]string{
+				auth.AdministratorRelation: {adminID},
+				auth.EditorRelation:        {adminID, editorID},
+				auth.MemberRelation:        {adminID, editorID, idleID},
+			}
+			for _, role := range []string{auth.AdministratorRelation, auth.EditorRelation, auth.ContributorRelation, auth.MemberRelation, auth.GuestRelation} {
+				var policies []string
+				for _, id := range roleIDs[role] {
+					policies = append(policies, auth.EncodeDomainUserID(domainID, id))
+				}
+				authsvc.On("ListAllSubjects", context.Background(), &magistrala.ListSubjectsReq{
+					SubjectType: auth.UserType,
+					Permission:  role,
+					Object:      groupID,
+					ObjectType:  auth.GroupType,
+				}).Return(&magistrala.ListSubjectsRes{Policies: policies}, tc.listErr)
+			}
+			members, err := svc.ListMemberRoles(context.Background(), tc.token, groupID, tc.inactiveSince)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.members, members)
+		})
+	}
+}
+
+func TestListDomainMembers(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	userID := testsutil.GenerateUUID(t)
+	group1 := testsutil.GenerateUUID(t)
+	group2 := testsutil.GenerateUUID(t)
+	// The member IDs are sorted so the expected members are in the listed order.
+	memberIDs := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	sort.Strings(memberIDs)
+	adminID, editorID, guestID, idleID := memberIDs[0], memberIDs[1], memberIDs[2], memberIDs[3]
+
+	all := []mggroups.DomainMember{
+		{
+			MemberID: adminID,
+			Role:     auth.AdministratorRelation,
+			Groups: []mggroups.GroupRole{
+				{GroupID: group1, Role: auth.AdministratorRelation},
+				{GroupID: group2, Role: auth.EditorRelation},
+			},
+		},
+		{
+			MemberID: editorID,
+			Role:     auth.EditorRelation,
+			Groups: []mggroups.GroupRole{
+				{GroupID: group2, Role: auth.EditorRelation},
+				{GroupID: group1, Role: auth.MemberRelation},
+			},
+		},
+		{
+			MemberID: guestID,
+			Role:     auth.GuestRelation,
+			Groups: []mggroups.GroupRole{
+				{GroupID: group2, Role: auth.GuestRelation},
+			},
+		},
+		{
+			MemberID: idleID,
+			Groups:   []mggroups.GroupRole{},
+		},
+	}
+
+	cases := []struct {
+		desc      string
+		token     string
+		domainID  string
+		pm        mggroups.PageMeta
+		idResp    *magistrala.IdentityRes
+		idErr     error
+		authzResp *magistrala.AuthorizeRes
+		authzErr  error
+		listErr   error
+		groupsErr error
+		page      mggroups.DomainMembersPage
+		err       error
+	}{
+		{
+			desc:      "list domain members successfully",
+			token:     token,
+			domainID:  domainID,
+			pm:        mggroups.PageMeta{Offset: 0, Limit: 10},
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			page:      mggroups.DomainMembersPage{Total: 4, Offset: 0, Limit: 10, Members: all},
+		},
+		{
+			desc:      "list domain members with offset and limit",
+			token:     token,
+			domainID:  domainID,
+			pm:        mggroups.PageMeta{Offset: 1, Limit: 1},
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			page:      mggroups.DomainMembersPage{Total: 4, Offset: 1, Limit: 1, Members: all[1:2]},
+		},
+		{
+			desc:      "list domain members with offset beyond total",
+			token:     token,
+			domainID:  domainID,
+			pm:        mggroups.PageMeta{Offset: 5, Limit: 10},
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			page:      mggroups.DomainMembersPage{Total: 4, Offset: 5, Limit: 10, Members: []mggroups.DomainMember{}},
+		},
+		{
+			desc:     "list domain members with invalid token",
+			token:    token,
+			domainID: domainID,
+			pm:       mggroups.PageMeta{Limit: 10},
+			idResp:   &magistrala.IdentityRes{},
+			idErr:    svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list domain members of another domain",
+			token:    token,
+			domainID: testsutil.GenerateUUID(t),
+			pm:       mggroups.PageMeta{Limit: 10},
+			idResp:   &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			err:      svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:      "list domain members with failed authorization",
+			token:     token,
+			domainID:  domainID,
+			pm:        mggroups.PageMeta{Limit: 10},
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: false},
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "list domain members with failed to list members",
+			token:     token,
+			domainID:  domainID,
+			pm:        mggroups.PageMeta{Limit: 10},
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			listErr:   svcerr.ErrAuthorization,
+			err:       svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "list domain members with failed to list groups",
+			token:     token,
+			domainID:  domainID,
+			pm:        mggroups.PageMeta{Limit: 10},
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			groupsErr: svcerr.ErrAuthorization,
+			err:       svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token}).Return(tc.idResp, tc.idErr)
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				Domain:      tc.domainID,
+				SubjectType: auth.UserType,
+				SubjectKind: auth.UsersKind,
+				Subject:     userID,
+				Permission:  auth.AdminPermission,
+				Object:      tc.domainID,
+				ObjectType:  auth.DomainType,
+			}).Return(tc.authzResp, tc.authzErr)
+			// The members are listed out of order to check they are sorted.
+			var duids []string
+			for _, id := range []string{guestID, idleID, adminID, editorID, adminID} {
+				duids = append(duids, auth.EncodeDomainUserID(domainID, id))
+			}
+			authsvc.On("ListAllSubjects", context.Background(), &magistrala.ListSubjectsReq{
+				SubjectType: auth.UserType,
+				Permission:  auth.MembershipPermission,
+				Object:      tc.domainID,
+				ObjectType:  auth.DomainType,
+			}).Return(&magistrala.ListSubjectsRes{Policies: duids}, tc.listErr)
+			// Members inherit the lower roles, so the groups are listed for each of them.
+			roleIDs := map[string]map[string][]string{
+				adminID: {
+					auth.AdministratorRelation: {group1},
+					auth.EditorRelation:        {group1, group2},
+					auth.MemberRelation:        {group1, group2},
+					auth.GuestRelation:         {group1, group2},
+				},
+				editorID: {
+					auth.EditorRelation: {group2},
+					auth.MemberRelation: {group2, group1},
+					auth.GuestRelation:  {group2, group1},
+				},
+				guestID: {
+					auth.GuestRelation: {group2},
+				},
+			}
+			for _, memberID := range memberIDs {
+				for _, role := range []string{auth.AdministratorRelation, auth.EditorRelation, auth.ContributorRelation, auth.MemberRelation, auth.GuestRelation} {
+					authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+						SubjectType: auth.UserType,
+						Subject:     auth.EncodeDomainUserID(domainID, memberID),
+						Permission:  role,
+						ObjectType:  auth.GroupType,
+					}).Return(&magistrala.ListObjectsRes{Policies: roleIDs[memberID][role]}, tc.groupsErr)
+				}
+			}
+			page, err := svc.ListDomainMembers(context.Background(), tc.token, tc.domainID, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.page, page)
+		})
+	}
+}
+
+func TestRevokeMember(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	userID := testsutil.GenerateUUID(t)
+	memberID := testsutil.GenerateUUID(t)
+	group1 := testsutil.GenerateUUID(t)
+	group2 := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc       string
+		domainID   string
+		idResp     *magistrala.IdentityRes
+		idErr      error
+		authzResp  *magistrala.AuthorizeRes
+		authzErr   error
+		roleIDs    map[string][]string
+		listErr    error
+		deleteErr  error
+		domainPerm string
+		domainErr  error
+		ids        []string
+		err        error
+	}{
+		{
+			desc:      "revoke member successfully",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			roleIDs: map[string][]string{
+				auth.AdministratorRelation: {group1},
+				auth.EditorRelation:        {group1},
+				auth.MemberRelation:        {group1, group2},
+			},
+			ids: []string{group1, group2},
+		},
+		{
+			desc:      "revoke member holding a domain role",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			roleIDs: map[string][]string{
+				auth.ContributorRelation: {group1},
+			},
+			domainPerm: auth.EditPermission,
+			ids:        []string{group1},
+		},
+		{
+			desc:       "revoke member holding only a domain role",
+			domainID:   domainID,
+			idResp:     &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp:  &magistrala.AuthorizeRes{Authorized: true},
+			domainPerm: auth.AdminPermission,
+			ids:        []string{},
+		},
+		{
+			desc:      "revoke member with failed to check domain role",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			roleIDs:   map[string][]string{auth.MemberRelation: {group1}},
+			domainErr: svcerr.ErrAuthorization,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "revoke member without roles",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			ids:       []string{},
+		},
+		{
+			desc:     "revoke member with invalid token",
+			domainID: domainID,
+			idResp:   &magistrala.IdentityRes{},
+			idErr:    svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "revoke member of another domain",
+			domainID: testsutil.GenerateUUID(t),
+			idResp:   &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			err:      svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:      "revoke member with failed authorization",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: false},
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "revoke member with failed to list groups",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			listErr:   svcerr.ErrAuthorization,
+			err:       svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "revoke member with failed to delete policies",
+			domainID:  domainID,
+			idResp:    &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authzResp: &magistrala.AuthorizeRes{Authorized: true},
+			roleIDs:   map[string][]string{auth.MemberRelation: {group1}},
+			deleteErr: svcerr.ErrAuthorization,
+			err:       svcerr.ErrDeletePolicies,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(tc.idResp, tc.idErr)
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				Domain:      tc.domainID,
+				SubjectType: auth.UserType,
+				SubjectKind: auth.UsersKind,
+				Subject:     userID,
+				Permission:  auth.AdminPermission,
+				Object:      tc.domainID,
+				ObjectType:  auth.DomainType,
+			}).Return(tc.authzResp, tc.authzErr)
+			for _, role := range []string{auth.AdministratorRelation, auth.EditorRelation, auth.ContributorRelation, auth.MemberRelation, auth.GuestRelation} {
+				authsvc.On("ListAllObjects", context.Background(), &magistrala.ListObjectsReq{
+					SubjectType: auth.UserType,
+					Subject:     auth.EncodeDomainUserID(domainID, memberID),
+					Permission:  role,
+					ObjectType:  auth.GroupType,
+				}).Return(&magistrala.ListObjectsRes{Policies: tc.roleIDs[role]}, tc.listErr)
+			}
+			for _, perm := range []string{auth.AdminPermission, auth.EditPermission, auth.ViewPermission} {
+				authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+					Domain:      tc.domainID,
+					SubjectType: auth.UserType,
+					Subject:     auth.EncodeDomainUserID(domainID, memberID),
+					Permission:  perm,
+					Object:      tc.domainID,
+					ObjectType:  auth.DomainType,
+				}).Return(&magistrala.AuthorizeRes{Authorized: perm == tc.domainPerm}, tc.domainErr)
+			}
+			var deleted []*magistrala.DeletePolicyReq
+			authsvc.On("DeletePolicies", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				deleted = args.Get(1).(*magistrala.DeletePoliciesReq).GetDeletePoliciesReq()
+			}).Return(&magistrala.DeletePolicyRes{Deleted: tc.deleteErr == nil}, tc.deleteErr)
+
+			rev, err := svc.RevokeMember(context.Background(), token, tc.domainID, memberID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if tc.err == nil {
+				assert.Equal(t, tc.ids, rev.Groups)
+				// Access inherited through the domain role is reported, since
+				// the domain role is kept.
+				assert.Equal(t, tc.domainPerm, rev.DomainPermission)
+				// Every role is deleted in every group the member was in.
+				assert.Len(t, deleted, 5*len(tc.ids))
+				for _, p := range deleted {
+					assert.Equal(t, auth.EncodeDomainUserID(domainID, memberID), p.GetSubject())
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteGroup(t *testing.T) {
+	idResp := &magistrala.IdentityRes{
+		Id:       testsutil.GenerateUUID(t),
+		DomainId: testsutil.GenerateUUID(t),
+	}
+	groupID := testsutil.GenerateUUID(t)
+	childID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc              string
+		cascade           bool
+		children          []string
+		childrenErr       error
+		idErr             error
+		authzErr          error
+		retrieveErr       error
+		deleteErr         error
+		deletePoliciesRes *magistrala.DeletePolicyRes
+		deletePoliciesErr error
+		saveErr           error
+		removed           []string
+		restored          int
+		err               error
+	}{
+		{
+			desc:    "successfully",
+			removed: []string{groupID},
+		},
+		{
+			desc:     "successfully with children and cascade",
+			cascade:  true,
+			children: []string{childID},
+			removed:  []string{childID, groupID},
+		},
+		{
+			desc:  "unsuccessfully with invalid token",
+			idErr: svcerr.ErrAuthentication,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "unsuccessfully with authorization error",
+			authzErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "unsuccessfully with children and no cascade",
+			children: []string{childID},
+			err:      mggroups.ErrGroupHasChildren,
+		},
+		{
+			desc:        "unsuccessfully with failed to retrieve children",
+			childrenErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "unsuccessfully with failed to retrieve group",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "unsuccessfully with repo err",
+			deleteErr: repoerr.ErrNotFound,
+			removed:   []string{groupID},
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc:              "unsuccessfully with failed to remove policies",
+			cascade:           true,
+			children:          []string{childID},
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			removed:           []string{childID, groupID},
+			restored:          1,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:              "unsuccessfully with policies not removed",
+			deletePoliciesRes: &magistrala.DeletePolicyRes{Deleted: false},
+			removed:           []string{groupID},
+			restored:          1,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "unsuccessfully with failed to restore group",
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			saveErr:           repoerr.ErrCreateEntity,
+			removed:           []string{groupID},
+			restored:          1,
+			err:               errors.ErrRollbackTx,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+
+			deletePoliciesRes := tc.deletePoliciesRes
+			if deletePoliciesRes == nil {
+				deletePoliciesRes = &magistrala.DeletePolicyRes{Deleted: true}
+			}
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(idResp, tc.idErr)
+			authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+				Domain:      idResp.GetDomainId(),
+				SubjectType: auth.UserType,
+				SubjectKind: auth.UsersKind,
+				Subject:     idResp.GetId(),
+				Permission:  auth.DeletePermission,
+				Object:      groupID,
+				ObjectType:  auth.GroupType,
+			}).Return(&magistrala.AuthorizeRes{Authorized: tc.authzErr == nil}, tc.authzErr)
+			authsvc.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{
+				EntityType: auth.GroupType,
+				Id:         childID,
+			}).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+			authsvc.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{
+				EntityType: auth.GroupType,
+				Id:         groupID,
+			}).Return(deletePoliciesRes, tc.deletePoliciesErr)
+			repo.On("RetrieveChildrenIDs", context.Background(), groupID).Return(tc.children, tc.childrenErr)
+			repo.On("RetrieveChildrenIDs", context.Background(), childID).Return([]string{}, nil)
+			repo.On("RetrieveByID", context.Background(), groupID).Return(mggroups.Group{ID: groupID, ThingCount: 2}, tc.retrieveErr)
+			repo.On("RetrieveByID", context.Background(), childID).Return(mggroups.Group{ID: childID, Parent: groupID}, nil)
+			repo.On("DeleteAll", context.Background(), mock.Anything).Return(tc.deleteErr)
+			repo.On("Save", context.Background(), mock.Anything).Return(mggroups.Group{}, tc.saveErr)
+			repo.On("UpdateThingCount", context.Background(), mock.Anything, mock.Anything).Return(nil)
+
+			err := svc.DeleteGroup(context.Background(), token, groupID, tc.cascade)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if tc.removed != nil {
+				repo.AssertCalled(t, "DeleteAll", context.Background(), tc.removed)
+			} else {
+				repo.AssertNotCalled(t, "DeleteAll", context.Background(), mock.Anything)
+			}
+			repo.AssertNumberOfCalls(t, "Save", tc.restored)
+			if tc.restored > 0 && tc.saveErr == nil {
+				repo.AssertCalled(t, "UpdateThingCount", context.Background(), groupID, uint64(2))
+			}
+		})
+	}
+}
+
+func TestDeleteGroups(t *testing.T) {
+	idResp := &magistrala.IdentityRes{
+		Id:       testsutil.GenerateUUID(t),
+		DomainId: testsutil.GenerateUUID(t),
+	}
+	empty := mggroups.Group{ID: testsutil.GenerateUUID(t)}
+	connected := mggroups.Group{ID: testsutil.GenerateUUID(t)}
+	forbidden := mggroups.Group{ID: testsutil.GenerateUUID(t)}
+	ids := []string{empty.ID, connected.ID, forbidden.ID}
+
+	cases := []struct {
+		desc    string
+		cascade bool
+		idErr   error
+		removed []bool
+		errs    []error
+		err     error
+	}{
+		{
+			desc:    "without cascade",
+			removed: []bool{true, false, false},
+			errs:    []error{nil, mggroups.ErrGroupHasThings, svcerr.ErrAuthorization},
+		},
+		{
+			desc:    "with cascade",
+			cascade: true,
+			removed: []bool{true, true, false},
+			errs:    []error{nil, nil, svcerr.ErrAuthorization},
+		},
+		{
+			desc:  "with invalid token",
+			idErr: svcerr.ErrAuthentication,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			authsvc := new(authmocks.AuthClient)
+			svc := groups.NewService(repo, idProvider, authsvc)
+
+			authsvc.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(idResp, tc.idErr)
+			for _, g := range []mggroups.Group{empty, connected, forbidden} {
+				authzResp, authzErr := &magistrala.AuthorizeRes{Authorized: true}, error(nil)
+				if g.ID == forbidden.ID {
+					authzResp, authzErr = &magistrala.AuthorizeRes{Authorized: false}, svcerr.ErrAuthorization
+				}
+				authsvc.On("Authorize", context.Background(), &magistrala.AuthorizeReq{
+					Domain:      idResp.GetDomainId(),
+					SubjectType: auth.UserType,
+					SubjectKind: auth.UsersKind,
+					Subject:     idResp.GetId(),
+					Permission:  auth.DeletePermission,
+					Object:      g.ID,
+					ObjectType:  auth.GroupType,
+				}).Return(authzResp, authzErr)
+				authsvc.On("DeleteEntityPolicies", context.Background(), &magistrala.DeleteEntityPoliciesReq{
+					EntityType: auth.GroupType,
+					Id:         g.ID,
+				}).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+				var things uint64
+				if g.ID == connected.ID {
+					things = 2
+				}
+				authsvc.On("CountObjects", context.Background(), &magistrala.CountObjectsReq{
+					SubjectType: auth.GroupType,
+					Subject:     g.ID,
+					Permission:  auth.GroupRelation,
+					ObjectType:  auth.ThingType,
+				}).Return(&magistrala.CountObjectsRes{Count: things}, nil)
+				repo.On("RetrieveByID", context.Background(), g.ID).Return(g, nil)
+				repo.On("RetrieveChildrenIDs", context.Background(), g.ID).Return([]string{}, nil)
+			}
+			repo.On("DeleteAll", context.Background(), mock.Anything).Return(nil)
+
+			res, err := svc.DeleteGroups(context.Background(), token, ids, tc.cascade)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if err != nil {
+				return
+			}
+			assert.Len(t, res, len(ids))
+			for i, r := range res {
+				assert.Equal(t, ids[i], r.ID)
+				assert.Equal(t, tc.removed[i], r.Removed, fmt.Sprintf("group %d: expected removed %t got %t", i, tc.removed[i], r.Removed))
+				if tc.errs[i] == nil {
+					assert.Empty(t, r.Error)
+					continue
+				}
+				assert.Contains(t, r.Error, tc.errs[i].Error())
+			}
+			if !tc.cascade {
+				repo.AssertNotCalled(t, "DeleteAll", context.Background(), []string{connected.ID})
+			}
+			repo.AssertNotCalled(t, "DeleteAll", context.Background(), []string{forbidden.ID})
 		})
 	}
 }
@@ -5,6 +5,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/groups"
 	"go.opentelemetry.io/otel/attribute"
@@ -31,6 +32,36 @@ func (tm *tracingMiddleware) CreateGroup(ctx context.Context, token, kind string
 	return tm.gsvc.CreateGroup(ctx, token, kind, g)
 }
 
+// CloneGroup traces the "CloneGroup" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (groups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_clone_group", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("parent_id", parentID),
+	))
+	defer span.End()
+
+	return tm.gsvc.CloneGroup(ctx, token, kind, id, parentID, name)
+}
+
+// ExportGroup traces the "ExportGroup" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) ExportGroup(ctx context.Context, token, id string) (groups.Blueprint, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_export_group", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.gsvc.ExportGroup(ctx, token, id)
+}
+
+// ImportGroup traces the "ImportGroup" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) ImportGroup(ctx context.Context, token, kind, domainID string, bp groups.Blueprint) (groups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_import_group", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.Int64("version", int64(bp.Version)),
+	))
+	defer span.End()
+
+	return tm.gsvc.ImportGroup(ctx, token, kind, domainID, bp)
+}
+
 // ViewGroup traces the "ViewGroup" operation of the wrapped groups.Service.
 func (tm *tracingMiddleware) ViewGroup(ctx context.Context, token, id string) (groups.Group, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_view_group", trace.WithAttributes(attribute.String("id", id)))
@@ -71,6 +102,25 @@ func (tm *tracingMiddleware) UpdateGroup(ctx context.Context, token string, g gr
 	return tm.gsvc.UpdateGroup(ctx, token, g)
 }
 
+func (tm *tracingMiddleware) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge, partial bool) ([]groups.GroupUpdate, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_groups", trace.WithAttributes(
+		attribute.Int("groups", len(gs)),
+		attribute.Bool("merge", merge),
+		attribute.Bool("partial", partial),
+	))
+	defer span.End()
+
+	return tm.gsvc.UpdateGroups(ctx, token, gs, merge, partial)
+}
+
+// PatchGroup traces the "PatchGroup" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) PatchGroup(ctx context.Context, token, id string, patch groups.GroupPatch) (groups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_patch_group", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.gsvc.PatchGroup(ctx, token, id, patch)
+}
+
 // EnableGroup traces the "EnableGroup" operation of the wrapped groups.Service.
 func (tm *tracingMiddleware) EnableGroup(ctx context.Context, token, id string) (groups.Group, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_enable_group", trace.WithAttributes(attribute.String("id", id)))
@@ -103,10 +153,114 @@ func (tm *tracingMiddleware) Unassign(ctx context.Context, token, groupID, relat
 	return tm.gsvc.Unassign(ctx, token, groupID, relation, memberKind, memberIDs...)
 }
 
+// AssignRoles traces the "AssignRoles" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) AssignRoles(ctx context.Context, token, memberID string, assignments []groups.RoleAssignment) ([]groups.RoleAssignment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_assign_roles", trace.WithAttributes(
+		attribute.String("member_id", memberID),
+		attribute.Int("assignments", len(assignments)),
+	))
+	defer span.End()
+
+	return tm.gsvc.AssignRoles(ctx, token, memberID, assignments)
+}
+
+// MoveThings traces the "MoveThings" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) ([]groups.ThingMove, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_move_things", trace.WithAttributes(
+		attribute.String("id", groupID),
+		attribute.String("target_id", targetGroupID),
+		attribute.Int("things", len(thingIDs)),
+	))
+	defer span.End()
+
+	return tm.gsvc.MoveThings(ctx, token, groupID, targetGroupID, thingIDs)
+}
+
+func (tm *tracingMiddleware) ReassignThings(ctx context.Context, token, groupID, targetGroupID string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_reassign_things", trace.WithAttributes(
+		attribute.String("id", groupID),
+		attribute.String("target_id", targetGroupID),
+	))
+	defer span.End()
+
+	return tm.gsvc.ReassignThings(ctx, token, groupID, targetGroupID)
+}
+
+func (tm *tracingMiddleware) AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) ([]groups.ThingAttachment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_attach_things", trace.WithAttributes(
+		attribute.String("id", groupID),
+		attribute.Int("things", len(thingIDs)),
+		attribute.Bool("reassign", reassign),
+	))
+	defer span.End()
+
+	return tm.gsvc.AttachThings(ctx, token, groupID, thingIDs, reassign)
+}
+
+// ListMemberGroups traces the "ListMemberGroups" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) ListMemberGroups(ctx context.Context, token, memberID string, pm groups.PageMeta) (groups.MemberGroupsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_member_groups", trace.WithAttributes(
+		attribute.String("member_id", memberID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.gsvc.ListMemberGroups(ctx, token, memberID, pm)
+}
+
+// ListMemberRoles traces the "ListMemberRoles" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) ([]groups.MemberRole, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_member_roles", trace.WithAttributes(
+		attribute.String("group_id", groupID),
+		attribute.String("inactive_since", inactiveSince.Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	return tm.gsvc.ListMemberRoles(ctx, token, groupID, inactiveSince)
+}
+
+// ListDomainMembers traces the "ListDomainMembers" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) ListDomainMembers(ctx context.Context, token, domainID string, pm groups.PageMeta) (groups.DomainMembersPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_domain_members", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.gsvc.ListDomainMembers(ctx, token, domainID, pm)
+}
+
+// RevokeMember traces the "RevokeMember" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) RevokeMember(ctx context.Context, token, domainID, memberID string) (groups.MemberRevocation, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_member", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.String("member_id", memberID),
+	))
+	defer span.End()
+
+	return tm.gsvc.RevokeMember(ctx, token, domainID, memberID)
+}
+
 // DeleteGroup traces the "DeleteGroup" operation of the wrapped groups.Service.
-func (tm *tracingMiddleware) DeleteGroup(ctx context.Context, token, id string) error {
-	ctx, span := tm.tracer.Start(ctx, "svc_delete_group", trace.WithAttributes(attribute.String("id", id)))
+func (tm *tracingMiddleware) DeleteGroup(ctx context.Context, token, id string, cascade bool) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_group", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Bool("cascade", cascade),
+	))
+	defer span.End()
+
+	return tm.gsvc.DeleteGroup(ctx, token, id, cascade)
+}
+
+// DeleteGroups traces the "DeleteGroups" operation of the wrapped groups.Service.
+func (tm *tracingMiddleware) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]groups.GroupRemoval, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_groups", trace.WithAttributes(
+		attribute.Int("groups", len(ids)),
+		attribute.Bool("cascade", cascade),
+	))
 	defer span.End()
 
-	return tm.gsvc.DeleteGroup(ctx, token, id)
+	return tm.gsvc.DeleteGroups(ctx, token, ids, cascade)
 }
@@ -177,6 +177,18 @@ var (
 	// ErrInvalidEntityType indicates invalid entity type.
 	ErrInvalidEntityType = errors.New("invalid entity type")
 
+	// ErrInvalidTTL indicates an invalid key time to live.
+	ErrInvalidTTL = errors.New("invalid ttl")
+
+	// ErrInvalidScope indicates an invalid token scope.
+	ErrInvalidScope = errors.New("invalid token scope")
+
+	// ErrMetadataTooLarge indicates that the serialized metadata exceeds the max size.
+	ErrMetadataTooLarge = errors.New("metadata exceeds the maximum size")
+
+	// ErrPayloadTooLarge indicates that the request body exceeds the max size.
+	ErrPayloadTooLarge = errors.New("request body exceeds the maximum size")
+
 	// ErrInvalidTimeFormat indicates invalid time format i.e not unix time.
 	ErrInvalidTimeFormat = errors.New("invalid time format use unix time")
 )
@@ -5,6 +5,7 @@ package apiutil
 
 // ErrorRes represents the HTTP error response body.
 type ErrorRes struct {
-	Err string `json:"error"`
-	Msg string `json:"message"`
+	Err  string `json:"error"`
+	Msg  string `json:"message"`
+	Code string `json:"code"`
 }
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -77,6 +78,49 @@ func ReadBoolQuery(r *http.Request, key string, def bool) (bool, error) {
 	return b, nil
 }
 
+// ReadTimeQuery reads the value of RFC3339 timestamp http query parameters
+// for a given key.
+func ReadTimeQuery(r *http.Request, key string, def time.Time) (time.Time, error) {
+	vals := r.URL.Query()[key]
+	if len(vals) > 1 {
+		return time.Time{}, ErrInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, vals[0])
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidQueryParams, err)
+	}
+
+	return t, nil
+}
+
+// ReadDurationQuery reads the value of duration http query parameters, such
+// as 30s or 1m, for a given key. Negative durations are rejected.
+func ReadDurationQuery(r *http.Request, key string, def time.Duration) (time.Duration, error) {
+	vals := r.URL.Query()[key]
+	if len(vals) > 1 {
+		return 0, ErrInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(vals[0])
+	if err != nil {
+		return 0, errors.Wrap(ErrInvalidQueryParams, err)
+	}
+	if d < 0 {
+		return 0, ErrInvalidQueryParams
+	}
+
+	return d, nil
+}
+
 type number interface {
 	int64 | float64 | uint16 | uint64
 }
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/apiutil"
@@ -171,6 +172,124 @@ func TestReadBoolQuery(t *testing.T) {
 	}
 }
 
+func TestReadTimeQuery(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		desc string
+		url  string
+		key  string
+		ret  time.Time
+		err  error
+	}{
+		{
+			desc: "valid time query",
+			url:  "http://localhost:8080/?key=2024-03-01T12:30:00Z",
+			key:  "key",
+			ret:  ts,
+			err:  nil,
+		},
+		{
+			desc: "valid time query with offset",
+			url:  "http://localhost:8080/?key=2024-03-01T14:30:00%2B02:00",
+			key:  "key",
+			ret:  ts,
+			err:  nil,
+		},
+		{
+			desc: "invalid time query",
+			url:  "http://localhost:8080/?key=2024-03-01",
+			key:  "key",
+			ret:  time.Time{},
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "empty time query",
+			url:  "http://localhost:8080/",
+			key:  "key",
+			ret:  time.Time{},
+			err:  nil,
+		},
+		{
+			desc: "multiple time query",
+			url:  "http://localhost:8080/?key=2024-03-01T12:30:00Z&key=2024-03-02T12:30:00Z",
+			key:  "key",
+			ret:  time.Time{},
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			parsedURL, err := url.Parse(c.url)
+			assert.NoError(t, err)
+
+			r := &http.Request{URL: parsedURL}
+			ret, err := apiutil.ReadTimeQuery(r, c.key, time.Time{})
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected: %v, got: %v", c.err, err))
+			assert.True(t, c.ret.Equal(ret), fmt.Sprintf("expected: %v, got: %v", c.ret, ret))
+		})
+	}
+}
+
+func TestReadDurationQuery(t *testing.T) {
+	cases := []struct {
+		desc string
+		url  string
+		key  string
+		ret  time.Duration
+		err  error
+	}{
+		{
+			desc: "valid duration query",
+			url:  "http://localhost:8080/?key=30s",
+			key:  "key",
+			ret:  30 * time.Second,
+			err:  nil,
+		},
+		{
+			desc: "invalid duration query",
+			url:  "http://localhost:8080/?key=30",
+			key:  "key",
+			ret:  0,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "negative duration query",
+			url:  "http://localhost:8080/?key=-1s",
+			key:  "key",
+			ret:  0,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "empty duration query",
+			url:  "http://localhost:8080/",
+			key:  "key",
+			ret:  time.Minute,
+			err:  nil,
+		},
+		{
+			desc: "multiple duration query",
+			url:  "http://localhost:8080/?key=1s&key=2s",
+			key:  "key",
+			ret:  0,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			parsedURL, err := url.Parse(c.url)
+			assert.NoError(t, err)
+
+			r := &http.Request{URL: parsedURL}
+			ret, err := apiutil.ReadDurationQuery(r, c.key, time.Minute)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected: %v, got: %v", c.err, err))
+			assert.Equal(t, c.ret, ret, fmt.Sprintf("expected: %v, got: %v", c.ret, ret))
+		})
+	}
+}
+
 func TestReadNumQuery(t *testing.T) {
 	cases := []struct {
 		desc    string
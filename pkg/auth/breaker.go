@@ -0,0 +1,176 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-kit/kit/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen indicates that the request was rejected without calling
+// the service because the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker. It is exported as the
+// value of the breaker state gauge.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all the requests through.
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets a limited number of probe requests through.
+	BreakerHalfOpen
+	// BreakerOpen rejects all the requests.
+	BreakerOpen
+)
+
+// BreakerConfig configures the circuit breaker of a gRPC client. The zero
+// threshold disables the breaker.
+type BreakerConfig struct {
+	// Threshold is the number of consecutive failures opening the breaker.
+	Threshold uint32 `env:"THRESHOLD" envDefault:"0"`
+	// Timeout is the time the breaker stays open before probing the service.
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"30s"`
+	// Probes is the number of requests let through when half-open. The
+	// breaker closes once all of them succeed.
+	Probes uint32 `env:"PROBES" envDefault:"1"`
+}
+
+// Breaker is a circuit breaker failing the requests to an unavailable
+// service fast instead of waiting for them to time out.
+type Breaker struct {
+	cfg      BreakerConfig
+	gauge    metrics.Gauge
+	mu       sync.Mutex
+	state    BreakerState
+	failures uint32
+	probes   uint32
+	passed   uint32
+	openedAt time.Time
+}
+
+// NewBreaker returns a circuit breaker reporting its state to the gauge,
+// unless the gauge is nil.
+func NewBreaker(cfg BreakerConfig, gauge metrics.Gauge) *Breaker {
+	if cfg.Probes == 0 {
+		cfg.Probes = 1
+	}
+	b := &Breaker{
+		cfg:   cfg,
+		gauge: gauge,
+	}
+	b.setState(BreakerClosed)
+
+	return b
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+
+	return b.state
+}
+
+// UnaryClientInterceptor returns a gRPC client interceptor guarding the
+// calls with the breaker. Calls rejected by the breaker fail with
+// ErrCircuitOpen wrapped in svcerr.ErrServiceUnavailable.
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return errors.Wrap(svcerr.ErrServiceUnavailable, ErrCircuitOpen)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(failure(err))
+
+		return err
+	}
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	switch b.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if b.probes >= b.cfg.Probes {
+			return false
+		}
+		b.probes++
+	}
+
+	return true
+}
+
+func (b *Breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.cfg.Threshold {
+			b.open()
+		}
+	case BreakerHalfOpen:
+		if failed {
+			b.open()
+			return
+		}
+		b.passed++
+		if b.passed >= b.cfg.Probes {
+			b.failures = 0
+			b.setState(BreakerClosed)
+		}
+	}
+}
+
+// expire moves the open breaker to half-open once the timeout elapsed.
+func (b *Breaker) expire() {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cfg.Timeout {
+		b.probes = 0
+		b.passed = 0
+		b.setState(BreakerHalfOpen)
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = time.Now()
+	b.setState(BreakerOpen)
+}
+
+func (b *Breaker) setState(state BreakerState) {
+	b.state = state
+	if b.gauge != nil {
+		b.gauge.Set(float64(state))
+	}
+}
+
+// failure reports whether the error indicates that the service is not
+// available, as opposed to errors returned by a healthy service.
+func failure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
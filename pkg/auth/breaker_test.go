@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errUnavailable = status.Error(codes.Unavailable, "connection refused")
+	errDenied      = status.Error(codes.PermissionDenied, "denied")
+)
+
+func invoke(interceptor grpc.UnaryClientInterceptor, err error) (bool, error) {
+	invoked := false
+	invoker := func(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		invoked = true
+		return err
+	}
+
+	return invoked, interceptor(context.Background(), "/magistrala.AuthService/Authorize", nil, nil, nil, invoker)
+}
+
+func TestBreaker(t *testing.T) {
+	timeout := 20 * time.Millisecond
+
+	cases := []struct {
+		desc    string
+		results []error
+		wait    bool
+		state   auth.BreakerState
+	}{
+		{
+			desc:    "successful calls",
+			results: []error{nil, nil, nil},
+			state:   auth.BreakerClosed,
+		},
+		{
+			desc:    "failures below threshold",
+			results: []error{errUnavailable, errUnavailable},
+			state:   auth.BreakerClosed,
+		},
+		{
+			desc:    "failures interrupted by success",
+			results: []error{errUnavailable, errUnavailable, nil, errUnavailable, errUnavailable},
+			state:   auth.BreakerClosed,
+		},
+		{
+			desc:    "errors of a healthy service",
+			results: []error{errDenied, errDenied, errDenied},
+			state:   auth.BreakerClosed,
+		},
+		{
+			desc:    "consecutive failures reaching threshold",
+			results: []error{errUnavailable, errUnavailable, errUnavailable},
+			state:   auth.BreakerOpen,
+		},
+		{
+			desc:    "open breaker after timeout",
+			results: []error{errUnavailable, errUnavailable, errUnavailable},
+			wait:    true,
+			state:   auth.BreakerHalfOpen,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gauge := generic.NewGauge("state")
+			breaker := auth.NewBreaker(auth.BreakerConfig{Threshold: 3, Timeout: timeout, Probes: 2}, gauge)
+			interceptor := breaker.UnaryClientInterceptor()
+			for _, res := range tc.results {
+				invoked, err := invoke(interceptor, res)
+				assert.True(t, invoked, fmt.Sprintf("%s: expected the service to be called", tc.desc))
+				assert.Equal(t, res, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, res, err))
+			}
+			if tc.wait {
+				time.Sleep(timeout)
+			}
+			state := breaker.State()
+			assert.Equal(t, tc.state, state, fmt.Sprintf("%s: expected state %d got %d", tc.desc, tc.state, state))
+			assert.Equal(t, float64(tc.state), gauge.Value(), fmt.Sprintf("%s: expected gauge %d got %f", tc.desc, tc.state, gauge.Value()))
+		})
+	}
+}
+
+func TestBreakerOpen(t *testing.T) {
+	breaker := auth.NewBreaker(auth.BreakerConfig{Threshold: 1, Timeout: time.Minute}, nil)
+	interceptor := breaker.UnaryClientInterceptor()
+
+	_, err := invoke(interceptor, errUnavailable)
+	assert.Equal(t, errUnavailable, err, fmt.Sprintf("expected error %s got %s", errUnavailable, err))
+
+	invoked, err := invoke(interceptor, nil)
+	assert.False(t, invoked, "expected the open breaker not to call the service")
+	assert.True(t, errors.Contains(err, svcerr.ErrServiceUnavailable), fmt.Sprintf("expected error %s got %s", svcerr.ErrServiceUnavailable, err))
+	assert.True(t, errors.Contains(err, auth.ErrCircuitOpen), fmt.Sprintf("expected error %s got %s", auth.ErrCircuitOpen, err))
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	timeout := 20 * time.Millisecond
+
+	cases := []struct {
+		desc    string
+		results []error
+		state   auth.BreakerState
+	}{
+		{
+			desc:    "successful probes",
+			results: []error{nil, nil},
+			state:   auth.BreakerClosed,
+		},
+		{
+			desc:    "failed probe",
+			results: []error{nil, errUnavailable},
+			state:   auth.BreakerOpen,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			breaker := auth.NewBreaker(auth.BreakerConfig{Threshold: 1, Timeout: timeout, Probes: 2}, nil)
+			interceptor := breaker.UnaryClientInterceptor()
+			_, _ = invoke(interceptor, errUnavailable)
+			time.Sleep(timeout)
+
+			for _, res := range tc.results {
+				invoked, _ := invoke(interceptor, res)
+				assert.True(t, invoked, fmt.Sprintf("%s: expected the probe to call the service", tc.desc))
+			}
+			state := breaker.State()
+			assert.Equal(t, tc.state, state, fmt.Sprintf("%s: expected state %d got %d", tc.desc, tc.state, state))
+		})
+	}
+}
+
+func TestBreakerProbeLimit(t *testing.T) {
+	timeout := 20 * time.Millisecond
+	breaker := auth.NewBreaker(auth.BreakerConfig{Threshold: 1, Timeout: timeout, Probes: 1}, nil)
+	interceptor := breaker.UnaryClientInterceptor()
+	_, _ = invoke(interceptor, errUnavailable)
+	time.Sleep(timeout)
+
+	// The probe is still in flight while the other request arrives.
+	probe := func(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		invoked, err := invoke(interceptor, nil)
+		assert.False(t, invoked, "expected requests beyond the probes limit not to call the service")
+		assert.True(t, errors.Contains(err, auth.ErrCircuitOpen), fmt.Sprintf("expected error %s got %s", auth.ErrCircuitOpen, err))
+		return nil
+	}
+	err := interceptor(context.Background(), "/magistrala.AuthService/Authorize", nil, nil, nil, probe)
+	assert.Nil(t, err, fmt.Sprintf("unexpected probe error %s", err))
+	assert.Equal(t, auth.BreakerClosed, breaker.State(), "expected the successful probe to close the breaker")
+}
@@ -10,28 +10,27 @@ import (
 	authgrpc "github.com/absmach/magistrala/auth/api/grpc"
 	"github.com/absmach/magistrala/pkg/errors"
 	thingsauth "github.com/absmach/magistrala/things/api/grpc"
+	"google.golang.org/grpc"
 	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var errSvcNotServing = errors.New("service is not serving")
 
 // Setup loads Auth gRPC configuration and creates new Auth gRPC client.
+// The dial options, such as the interceptor of a circuit breaker, are
+// applied to the client connection.
 //
 // For example:
 //
 //	authClient, authHandler, err := auth.Setup(ctx, auth.Config{})
-func Setup(ctx context.Context, cfg Config) (magistrala.AuthServiceClient, Handler, error) {
-	client, err := newHandler(cfg)
+func Setup(ctx context.Context, cfg Config, opts ...grpc.DialOption) (magistrala.AuthServiceClient, Handler, error) {
+	client, err := newHandler(cfg, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	health := grpchealth.NewHealthClient(client.Connection())
-	resp, err := health.Check(ctx, &grpchealth.HealthCheckRequest{
-		Service: "auth",
-	})
-	if err != nil || resp.GetStatus() != grpchealth.HealthCheckResponse_SERVING {
-		return nil, nil, errSvcNotServing
+	if err := Check(ctx, client, "auth"); err != nil {
+		return nil, nil, err
 	}
 
 	return authgrpc.NewClient(client.Connection(), cfg.Timeout), client, nil
@@ -48,13 +47,41 @@ func SetupAuthz(ctx context.Context, cfg Config) (magistrala.AuthzServiceClient,
 		return nil, nil, err
 	}
 
-	health := grpchealth.NewHealthClient(client.Connection())
+	if err := Check(ctx, client, "things"); err != nil {
+		return nil, nil, err
+	}
+
+	return thingsauth.NewClient(client.Connection(), cfg.Timeout), client, nil
+}
+
+// SetupThings loads Things gRPC configuration and creates new Things gRPC
+// client.
+//
+// For example:
+//
+//	thingsClient, thingsHandler, err := auth.SetupThings(ctx, auth.Config{})
+func SetupThings(ctx context.Context, cfg Config) (magistrala.ThingsServiceClient, Handler, error) {
+	client, err := newHandler(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := Check(ctx, client, "things"); err != nil {
+		return nil, nil, err
+	}
+
+	return thingsauth.NewThingsClient(client.Connection(), cfg.Timeout), client, nil
+}
+
+// Check uses gRPC health checking to verify that the service is serving.
+func Check(ctx context.Context, h Handler, service string) error {
+	health := grpchealth.NewHealthClient(h.Connection())
 	resp, err := health.Check(ctx, &grpchealth.HealthCheckRequest{
-		Service: "things",
+		Service: service,
 	})
 	if err != nil || resp.GetStatus() != grpchealth.HealthCheckResponse_SERVING {
-		return nil, nil, errSvcNotServing
+		return errSvcNotServing
 	}
 
-	return thingsauth.NewClient(client.Connection(), cfg.Timeout), client, nil
+	return nil
 }
@@ -59,8 +59,8 @@ type client struct {
 
 var _ Handler = (*client)(nil)
 
-func newHandler(cfg Config) (Handler, error) {
-	conn, secure, err := connect(cfg)
+func newHandler(cfg Config, opts ...grpc.DialOption) (Handler, error) {
+	conn, secure, err := connect(cfg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +99,7 @@ func (c *client) Secure() string {
 }
 
 // connect creates new gRPC client and connect to gRPC server.
-func connect(cfg Config) (*grpc.ClientConn, security, error) {
+func connect(cfg Config, dialOpts ...grpc.DialOption) (*grpc.ClientConn, security, error) {
 	opts := []grpc.DialOption{
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
@@ -142,6 +142,7 @@ func connect(cfg Config) (*grpc.ClientConn, security, error) {
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(buffSize/10), grpc.MaxCallSendMsgSize(buffSize/10)),
 		grpc.WithWriteBufferSize(buffSize),
 	)
+	opts = append(opts, dialOpts...)
 
 	conn, err := grpc.NewClient(cfg.URL, opts...)
 	if err != nil {
@@ -0,0 +1,46 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// fingerprintLen is the length of the hex encoded SHA-256 fingerprint.
+const fingerprintLen = 64
+
+// CertBinding binds the client certificate with the fingerprint to the
+// thing identified by it.
+type CertBinding struct {
+	Fingerprint string    `json:"fingerprint"`
+	ThingID     string    `json:"thing_id"`
+	Subject     string    `json:"subject,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CertFingerprint returns the lowercase hex SHA-256 fingerprint of the DER
+// encoded certificate.
+func CertFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeFingerprint returns the lowercase hex SHA-256 certificate
+// fingerprint without separators, and false if the fingerprint is invalid.
+// Both "AB:CD:..." and "abcd..." forms are accepted.
+func NormalizeFingerprint(fingerprint string) (string, bool) {
+	fp := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if len(fp) != fingerprintLen {
+		return "", false
+	}
+	if _, err := hex.DecodeString(fp); err != nil {
+		return "", false
+	}
+
+	return fp, true
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeFingerprint(t *testing.T) {
+	fp := strings.Repeat("ab", 32)
+	colons := strings.TrimSuffix(strings.Repeat("AB:", 32), ":")
+
+	cases := []struct {
+		desc        string
+		fingerprint string
+		expected    string
+		valid       bool
+	}{
+		{
+			desc:        "Lowercase hex fingerprint",
+			fingerprint: fp,
+			expected:    fp,
+			valid:       true,
+		},
+		{
+			desc:        "Uppercase fingerprint with colons",
+			fingerprint: colons,
+			expected:    fp,
+			valid:       true,
+		},
+		{
+			desc:        "Fingerprint of invalid length",
+			fingerprint: fp[:40],
+			valid:       false,
+		},
+		{
+			desc:        "Fingerprint with non hex characters",
+			fingerprint: strings.Repeat("zz", 32),
+			valid:       false,
+		},
+		{
+			desc:        "Empty fingerprint",
+			fingerprint: "",
+			valid:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := clients.NormalizeFingerprint(tc.fingerprint)
+			assert.Equal(t, tc.valid, ok, "NormalizeFingerprint() valid = %v, expected %v", ok, tc.valid)
+			assert.Equal(t, tc.expected, got, "NormalizeFingerprint() = %s, expected %s", got, tc.expected)
+		})
+	}
+}
+
+func TestCertFingerprint(t *testing.T) {
+	// SHA-256 of the empty input.
+	expected := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	fp := clients.CertFingerprint([]byte{})
+	assert.Equal(t, expected, fp, fmt.Sprintf("expected fingerprint %s got %s", expected, fp))
+	_, ok := clients.NormalizeFingerprint(fp)
+	assert.True(t, ok, "expected the fingerprint to be valid")
+}
@@ -33,8 +33,14 @@ var (
 // "identity" which can be a username, email, generated name;
 // and "secret" which can be a password or access token.
 type Credentials struct {
-	Identity string `json:"identity,omitempty"` // username or generated login ID
-	Secret   string `json:"secret,omitempty"`   // password or token
+	Identity  string     `json:"identity,omitempty"`   // username or generated login ID
+	Secret    string     `json:"secret,omitempty"`     // password or token
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // secret expiry, nil for non-expiring secrets
+}
+
+// Expired returns true if the secret has an expiry which has passed.
+func (c Credentials) Expired() bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(time.Now())
 }
 
 // Client represents generic Client.
@@ -51,6 +57,7 @@ type Client struct {
 	Status      Status      `json:"status,omitempty"` // 1 for enabled, 0 for disabled
 	Role        Role        `json:"role,omitempty"`   // 1 for admin, 0 for normal user
 	Permissions []string    `json:"permissions,omitempty"`
+	Version     uint64      `json:"version,omitempty"` // incremented on every update, used for optimistic concurrency
 }
 
 // ClientsPage contains page related metadata as well as list
@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import "github.com/absmach/magistrala/pkg/errors"
+
+var (
+	// ErrInvalidMetadataFilter indicates a malformed metadata filter.
+	ErrInvalidMetadataFilter = errors.New("invalid metadata filter")
+
+	// ErrMetadataFilterTooComplex indicates a metadata filter which is
+	// nested too deeply or has too many nodes.
+	ErrMetadataFilterTooComplex = errors.New("metadata filter is too complex")
+)
+
+// FilterLimits bound the metadata filters, so that they don't translate to
+// pathological queries.
+type FilterLimits struct {
+	MaxDepth int
+	MaxNodes int
+}
+
+// MetadataFilter is a boolean expression over the client metadata. A node
+// is either a leaf matching the clients whose metadata field contains the
+// value, or a conjunction, disjunction or negation of other nodes, for
+// example:
+//
+//	{"and": [{"field": "region", "value": "eu"},
+//	         {"or": [{"field": "tier", "value": "gold"},
+//	                 {"field": "tier", "value": "platinum"}]}]}
+type MetadataFilter struct {
+	And   []MetadataFilter `json:"and,omitempty"`
+	Or    []MetadataFilter `json:"or,omitempty"`
+	Not   *MetadataFilter  `json:"not,omitempty"`
+	Field string           `json:"field,omitempty"`
+	Value interface{}      `json:"value,omitempty"`
+}
+
+// Validate checks that each node of the filter is exactly one of a leaf,
+// conjunction, disjunction or negation, and that the filter is within the
+// limits. Zero limits are not enforced.
+func (f MetadataFilter) Validate(limits FilterLimits) error {
+	nodes := 0
+	return f.validate(limits, 1, &nodes)
+}
+
+func (f MetadataFilter) validate(limits FilterLimits, depth int, nodes *int) error {
+	*nodes++
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return ErrMetadataFilterTooComplex
+	}
+	if limits.MaxNodes > 0 && *nodes > limits.MaxNodes {
+		return ErrMetadataFilterTooComplex
+	}
+
+	kinds := 0
+	if f.And != nil {
+		kinds++
+	}
+	if f.Or != nil {
+		kinds++
+	}
+	if f.Not != nil {
+		kinds++
+	}
+	if f.Field != "" {
+		kinds++
+	}
+	if kinds != 1 {
+		return ErrInvalidMetadataFilter
+	}
+	if f.Field == "" && f.Value != nil {
+		return ErrInvalidMetadataFilter
+	}
+
+	var children []MetadataFilter
+	switch {
+	case f.And != nil:
+		children = f.And
+	case f.Or != nil:
+		children = f.Or
+	case f.Not != nil:
+		children = []MetadataFilter{*f.Not}
+	}
+	if f.Field == "" && len(children) == 0 {
+		return ErrInvalidMetadataFilter
+	}
+	for _, c := range children {
+		if err := c.validate(limits, depth+1, nodes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
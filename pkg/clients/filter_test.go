@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataFilterValidate(t *testing.T) {
+	limits := clients.FilterLimits{MaxDepth: 3, MaxNodes: 6}
+
+	cases := []struct {
+		desc   string
+		filter string
+		limits clients.FilterLimits
+		err    error
+	}{
+		{
+			desc:   "leaf",
+			filter: `{"field": "region", "value": "eu"}`,
+			limits: limits,
+		},
+		{
+			desc:   "nested expression",
+			filter: `{"and": [{"field": "region", "value": "eu"}, {"or": [{"field": "tier", "value": "gold"}, {"field": "tier", "value": "platinum"}]}]}`,
+			limits: limits,
+		},
+		{
+			desc:   "negation",
+			filter: `{"not": {"field": "tier", "value": "free"}}`,
+			limits: limits,
+		},
+		{
+			desc:   "leaf with null value",
+			filter: `{"field": "region"}`,
+			limits: limits,
+		},
+		{
+			desc:   "empty node",
+			filter: `{}`,
+			limits: limits,
+			err:    clients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "node of several kinds",
+			filter: `{"field": "region", "value": "eu", "and": [{"field": "tier", "value": "gold"}]}`,
+			limits: limits,
+			err:    clients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "value without field",
+			filter: `{"not": {"field": "tier", "value": "free"}, "value": "eu"}`,
+			limits: limits,
+			err:    clients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "empty conjunction",
+			filter: `{"and": []}`,
+			limits: limits,
+			err:    clients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "invalid nested node",
+			filter: `{"or": [{"field": "tier", "value": "gold"}, {}]}`,
+			limits: limits,
+			err:    clients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "too deep expression",
+			filter: `{"not": {"not": {"not": {"field": "tier", "value": "free"}}}}`,
+			limits: limits,
+			err:    clients.ErrMetadataFilterTooComplex,
+		},
+		{
+			desc:   "too wide expression",
+			filter: `{"or": [{"field": "a", "value": 1}, {"field": "b", "value": 2}, {"field": "c", "value": 3}, {"field": "d", "value": 4}, {"field": "e", "value": 5}, {"field": "f", "value": 6}]}`,
+			limits: limits,
+			err:    clients.ErrMetadataFilterTooComplex,
+		},
+		{
+			desc:   "too deep expression without limits",
+			filter: `{"not": {"not": {"not": {"field": "tier", "value": "free"}}}}`,
+			limits: clients.FilterLimits{},
+		},
+	}
+
+	for _, tc := range cases {
+		var f clients.MetadataFilter
+		err := json.Unmarshal([]byte(tc.filter), &f)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		err = f.Validate(tc.limits)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// minGeneratedKeyLength is the length of the keys generated by a key policy
+// which doesn't require longer keys.
+const minGeneratedKeyLength = 36
+
+const (
+	lowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	upperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars   = "0123456789"
+	specialChars = "!#$%&()*+,-.:;<=>?@[]^_{|}~"
+)
+
+// ErrWeakKey indicates that a client key doesn't satisfy the key policy.
+var ErrWeakKey = errors.New("key doesn't satisfy the key policy")
+
+// KeyPolicy specifies the requirements the client keys have to satisfy.
+// The zero value accepts any key.
+type KeyPolicy struct {
+	MinLength      int
+	RequireLower   bool
+	RequireUpper   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+// Check returns ErrWeakKey wrapping the requirements of the policy if the
+// key doesn't satisfy them.
+func (kp KeyPolicy) Check(key string) error {
+	var lower, upper, digit, special bool
+	for _, r := range key {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			special = true
+		}
+	}
+	if len([]rune(key)) < kp.MinLength ||
+		(kp.RequireLower && !lower) ||
+		(kp.RequireUpper && !upper) ||
+		(kp.RequireDigit && !digit) ||
+		(kp.RequireSpecial && !special) {
+		return errors.Wrap(ErrWeakKey, errors.New(kp.String()))
+	}
+
+	return nil
+}
+
+// Generate generates a random key satisfying the policy.
+func (kp KeyPolicy) Generate() (string, error) {
+	var required []string
+	if kp.RequireLower {
+		required = append(required, lowerChars)
+	}
+	if kp.RequireUpper {
+		required = append(required, upperChars)
+	}
+	if kp.RequireDigit {
+		required = append(required, digitChars)
+	}
+	if kp.RequireSpecial {
+		required = append(required, specialChars)
+	}
+	all := lowerChars + upperChars + digitChars
+	if kp.RequireSpecial {
+		all += specialChars
+	}
+
+	key := make([]byte, max(kp.MinLength, minGeneratedKeyLength))
+	for i := range key {
+		// A character of every required class goes first, the rest are
+		// drawn from all the classes and the key is shuffled afterwards.
+		chars := all
+		if i < len(required) {
+			chars = required[i]
+		}
+		c, err := randInt(len(chars))
+		if err != nil {
+			return "", err
+		}
+		key[i] = chars[c]
+	}
+	for i := len(key) - 1; i > 0; i-- {
+		j, err := randInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		key[i], key[j] = key[j], key[i]
+	}
+
+	return string(key), nil
+}
+
+// String describes the requirements of the policy.
+func (kp KeyPolicy) String() string {
+	var classes []string
+	if kp.RequireLower {
+		classes = append(classes, "a lower case letter")
+	}
+	if kp.RequireUpper {
+		classes = append(classes, "an upper case letter")
+	}
+	if kp.RequireDigit {
+		classes = append(classes, "a digit")
+	}
+	if kp.RequireSpecial {
+		classes = append(classes, "a special character")
+	}
+
+	desc := "key must be"
+	if kp.MinLength > 0 {
+		desc = fmt.Sprintf("%s at least %d characters long", desc, kp.MinLength)
+	}
+	switch {
+	case len(classes) > 0 && kp.MinLength > 0:
+		desc += " and contain "
+	case len(classes) > 0:
+		desc = "key must contain "
+	case kp.MinLength == 0:
+		return "any key is accepted"
+	}
+
+	return desc + strings.Join(classes, ", ")
+}
+
+func randInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(v.Int64()), nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyPolicyCheck(t *testing.T) {
+	strict := clients.KeyPolicy{
+		MinLength:      12,
+		RequireLower:   true,
+		RequireUpper:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+
+	cases := []struct {
+		desc   string
+		policy clients.KeyPolicy
+		key    string
+		err    error
+	}{
+		{
+			desc:   "any key with empty policy",
+			policy: clients.KeyPolicy{},
+			key:    "a",
+		},
+		{
+			desc:   "strong key",
+			policy: strict,
+			key:    "Str0ng-enough",
+		},
+		{
+			desc:   "short key",
+			policy: strict,
+			key:    "Sh0rt-key",
+			err:    clients.ErrWeakKey,
+		},
+		{
+			desc:   "key without upper case letter",
+			policy: strict,
+			key:    "n0-upper-case",
+			err:    clients.ErrWeakKey,
+		},
+		{
+			desc:   "key without lower case letter",
+			policy: strict,
+			key:    "N0-LOWER-CASE",
+			err:    clients.ErrWeakKey,
+		},
+		{
+			desc:   "key without digit",
+			policy: strict,
+			key:    "No-digits-here",
+			err:    clients.ErrWeakKey,
+		},
+		{
+			desc:   "key without special character",
+			policy: strict,
+			key:    "N0specialchars",
+			err:    clients.ErrWeakKey,
+		},
+		{
+			desc:   "UUID key with default length policy",
+			policy: clients.KeyPolicy{MinLength: 36, RequireDigit: true},
+			key:    "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Check(tc.key)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v got %v", tc.err, err))
+		})
+	}
+}
+
+func TestKeyPolicyCheckDescribesRequirements(t *testing.T) {
+	policy := clients.KeyPolicy{MinLength: 16, RequireUpper: true, RequireDigit: true}
+	err := policy.Check("weak")
+	assert.True(t, errors.Contains(err, clients.ErrWeakKey), fmt.Sprintf("expected error %v got %v", clients.ErrWeakKey, err))
+	assert.True(t, strings.Contains(err.Error(), "key must be at least 16 characters long and contain an upper case letter, a digit"), fmt.Sprintf("expected requirements in error got %s", err))
+}
+
+func TestKeyPolicyGenerate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		policy clients.KeyPolicy
+		length int
+	}{
+		{
+			desc:   "generate key with empty policy",
+			policy: clients.KeyPolicy{},
+			length: 36,
+		},
+		{
+			desc:   "generate key with all character classes",
+			policy: clients.KeyPolicy{RequireLower: true, RequireUpper: true, RequireDigit: true, RequireSpecial: true},
+			length: 36,
+		},
+		{
+			desc:   "generate key longer than default",
+			policy: clients.KeyPolicy{MinLength: 64, RequireUpper: true, RequireSpecial: true},
+			length: 64,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Keys are random, so generate a few to catch missing classes.
+			for i := 0; i < 100; i++ {
+				key, err := tc.policy.Generate()
+				assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+				assert.Len(t, key, tc.length)
+				assert.Nil(t, tc.policy.Check(key), fmt.Sprintf("generated key %s doesn't satisfy the policy", key))
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// MetadataOrderPrefix prefixes the order of the clients by a metadata
+	// field, followed by the dot separated path of the field, for example
+	// metadata.location.floor.
+	MetadataOrderPrefix = "metadata."
+
+	// MaxMetadataOrderDepth is the maximum number of keys in the path of the
+	// metadata field the clients are ordered by.
+	MaxMetadataOrderDepth = 5
+)
+
+var metadataKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// IsMetadataOrder reports whether the order is by a metadata field, be the
+// path of the field valid or not.
+func IsMetadataOrder(order string) bool {
+	return strings.HasPrefix(order, MetadataOrderPrefix)
+}
+
+// MetadataOrderPath returns the keys of the path of the metadata field the
+// clients are ordered by. It returns false if the order isn't by a metadata
+// field, or the path is empty, nested deeper than MaxMetadataOrderDepth or
+// has keys other than letters, digits, underscores and dashes.
+func MetadataOrderPath(order string) ([]string, bool) {
+	if !IsMetadataOrder(order) {
+		return nil, false
+	}
+	path := strings.Split(strings.TrimPrefix(order, MetadataOrderPrefix), ".")
+	if len(path) > MaxMetadataOrderDepth {
+		return nil, false
+	}
+	for _, key := range path {
+		if !metadataKeyRegexp.MatchString(key) {
+			return nil, false
+		}
+	}
+
+	return path, true
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataOrderPath(t *testing.T) {
+	cases := []struct {
+		desc  string
+		order string
+		path  []string
+		valid bool
+	}{
+		{
+			desc:  "metadata field",
+			order: "metadata.priority",
+			path:  []string{"priority"},
+			valid: true,
+		},
+		{
+			desc:  "nested metadata field",
+			order: "metadata.location.floor-number_2",
+			path:  []string{"location", "floor-number_2"},
+			valid: true,
+		},
+		{
+			desc:  "metadata field of max depth",
+			order: "metadata.a.b.c.d.e",
+			path:  []string{"a", "b", "c", "d", "e"},
+			valid: true,
+		},
+		{
+			desc:  "metadata field deeper than max depth",
+			order: "metadata.a.b.c.d.e.f",
+		},
+		{
+			desc:  "empty metadata field",
+			order: "metadata.",
+		},
+		{
+			desc:  "metadata field with empty key",
+			order: "metadata.location..floor",
+		},
+		{
+			desc:  "metadata field with trailing dot",
+			order: "metadata.priority.",
+		},
+		{
+			desc:  "metadata field with invalid characters",
+			order: "metadata.priority}'",
+		},
+		{
+			desc:  "metadata field with spaces",
+			order: "metadata.high priority",
+		},
+		{
+			desc:  "column order",
+			order: "created_at",
+		},
+		{
+			desc:  "metadata without field",
+			order: "metadata",
+		},
+	}
+
+	for _, tc := range cases {
+		path, ok := clients.MetadataOrderPath(tc.order)
+		assert.Equal(t, tc.valid, ok, "%s: expected valid %t got %t", tc.desc, tc.valid, ok)
+		assert.Equal(t, tc.path, path, "%s: expected path %v got %v", tc.desc, tc.path, path)
+	}
+}
@@ -3,6 +3,8 @@
 
 package clients
 
+import "time"
+
 // Page contains page metadata that helps navigation.
 type Page struct {
 	Total      uint64   `json:"total"`
@@ -17,7 +19,36 @@ type Page struct {
 	Permission string   `json:"permission,omitempty"`
 	Status     Status   `json:"status,omitempty"`
 	IDs        []string `json:"ids,omitempty"`
+	ExcludeIDs []string `json:"-"`
 	Identity   string   `json:"identity,omitempty"`
 	Role       Role     `json:"-"`
 	ListPerms  bool     `json:"-"`
+	CountOnly  bool     `json:"-"`
+	Connection string   `json:"-"`
+	// UpdatedSince limits the page to the clients updated after the given
+	// time, ordered by the update time. Zero value disables the filter.
+	UpdatedSince time.Time `json:"-"`
+	// UpdatedSinceID resumes the listing after the client with the given ID
+	// among the clients updated exactly at UpdatedSince, so the clients
+	// sharing the update time aren't skipped across pages. Empty value
+	// lists all the clients updated after UpdatedSince.
+	UpdatedSinceID string `json:"-"`
+	// MetadataFilter limits the page to the clients whose metadata matches
+	// the boolean expression, in addition to Metadata. Nil disables the
+	// filter.
+	MetadataFilter *MetadataFilter `json:"-"`
+	// Wait holds the listing of clients updated since UpdatedSince until
+	// some of them change, for up to the given duration.
+	Wait time.Duration `json:"-"`
+	// Fields lists the JSON names of the client fields to retrieve. The
+	// metadata and tags are left out unless listed. Empty list retrieves
+	// all the fields.
+	Fields []string `json:"-"`
+}
+
+// MetadataAggregate contains a distinct metadata value
+// and the number of clients having that value.
+type MetadataAggregate struct {
+	Value interface{} `json:"value"`
+	Count uint64      `json:"count"`
 }
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -144,32 +145,19 @@ func (repo *Repository) RetrieveAll(ctx context.Context, pm clients.Page) (clien
 		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata, COALESCE(c.domain_id, '') AS domain_id, c.status,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
+	q := fmt.Sprintf(`SELECT c.id, c.name, %s, c.identity, %s, COALESCE(c.domain_id, '') AS domain_id, c.status,
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s %s LIMIT :limit OFFSET :offset;`,
+		fieldColumn(pm, "tags"), fieldColumn(pm, "metadata"), query, orderQuery(pm))
 
 	dbPage, err := ToDBClientsPage(pm)
 	if err != nil {
 		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
 	}
-	rows, err := repo.DB.NamedQueryContext(ctx, q, dbPage)
-	if err != nil {
-		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
-	}
-	defer rows.Close()
-
 	var items []clients.Client
-	for rows.Next() {
-		dbc := DBClient{}
-		if err := rows.StructScan(&dbc); err != nil {
-			return clients.ClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
-		}
-
-		c, err := ToClient(dbc)
-		if err != nil {
+	if !pm.CountOnly {
+		if items, err = repo.retrieveClients(ctx, q, dbPage); err != nil {
 			return clients.ClientsPage{}, err
 		}
-
-		items = append(items, c)
 	}
 	cq := fmt.Sprintf(`SELECT COUNT(*) FROM clients c %s;`, query)
 
@@ -250,32 +238,19 @@ func (repo *Repository) RetrieveAllByIDs(ctx context.Context, pm clients.Page) (
 		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata, COALESCE(c.domain_id, '') AS domain_id, c.status,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
+	q := fmt.Sprintf(`SELECT c.id, c.name, %s, c.identity, %s, COALESCE(c.domain_id, '') AS domain_id, c.status,
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s %s LIMIT :limit OFFSET :offset;`,
+		fieldColumn(pm, "tags"), fieldColumn(pm, "metadata"), query, orderQuery(pm))
 
 	dbPage, err := ToDBClientsPage(pm)
 	if err != nil {
 		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
 	}
-	rows, err := repo.DB.NamedQueryContext(ctx, q, dbPage)
-	if err != nil {
-		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
-	}
-	defer rows.Close()
-
 	var items []clients.Client
-	for rows.Next() {
-		dbc := DBClient{}
-		if err := rows.StructScan(&dbc); err != nil {
-			return clients.ClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
-		}
-
-		c, err := ToClient(dbc)
-		if err != nil {
+	if !pm.CountOnly {
+		if items, err = repo.retrieveClients(ctx, q, dbPage); err != nil {
 			return clients.ClientsPage{}, err
 		}
-
-		items = append(items, c)
 	}
 	cq := fmt.Sprintf(`SELECT COUNT(*) FROM clients c %s;`, query)
 
@@ -296,6 +271,31 @@ func (repo *Repository) RetrieveAllByIDs(ctx context.Context, pm clients.Page) (
 	return page, nil
 }
 
+func (repo *Repository) retrieveClients(ctx context.Context, query string, dbPage dbClientsPage) ([]clients.Client, error) {
+	rows, err := repo.DB.NamedQueryContext(ctx, query, dbPage)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrFailedToRetrieveAllGroups, err)
+	}
+	defer rows.Close()
+
+	var items []clients.Client
+	for rows.Next() {
+		dbc := DBClient{}
+		if err := rows.StructScan(&dbc); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+
+		c, err := ToClient(dbc)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, c)
+	}
+
+	return items, nil
+}
+
 func (repo *Repository) update(ctx context.Context, client clients.Client, query string) (clients.Client, error) {
 	dbc, err := ToDBClient(client)
 	if err != nil {
@@ -348,6 +348,8 @@ type DBClient struct {
 	Groups    []groups.Group   `db:"groups,omitempty"`
 	Status    clients.Status   `db:"status,omitempty"`
 	Role      *clients.Role    `db:"role,omitempty"`
+	Version   uint64           `db:"version"`
+	ExpiresAt sql.NullTime     `db:"secret_expires_at"`
 }
 
 func ToDBClient(c clients.Client) (DBClient, error) {
@@ -371,6 +373,10 @@ func ToDBClient(c clients.Client) (DBClient, error) {
 	if c.UpdatedAt != (time.Time{}) {
 		updatedAt = sql.NullTime{Time: c.UpdatedAt, Valid: true}
 	}
+	var expiresAt sql.NullTime
+	if c.Credentials.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *c.Credentials.ExpiresAt, Valid: true}
+	}
 
 	return DBClient{
 		ID:        c.ID,
@@ -385,6 +391,8 @@ func ToDBClient(c clients.Client) (DBClient, error) {
 		UpdatedBy: updatedBy,
 		Status:    c.Status,
 		Role:      &c.Role,
+		Version:   c.Version,
+		ExpiresAt: expiresAt,
 	}, nil
 }
 
@@ -407,6 +415,10 @@ func ToClient(c DBClient) (clients.Client, error) {
 	if c.UpdatedAt.Valid {
 		updatedAt = c.UpdatedAt.Time
 	}
+	var expiresAt *time.Time
+	if c.ExpiresAt.Valid {
+		expiresAt = &c.ExpiresAt.Time
+	}
 
 	cli := clients.Client{
 		ID:     c.ID,
@@ -414,14 +426,16 @@ func ToClient(c DBClient) (clients.Client, error) {
 		Tags:   tags,
 		Domain: c.Domain,
 		Credentials: clients.Credentials{
-			Identity: c.Identity,
-			Secret:   c.Secret,
+			Identity:  c.Identity,
+			Secret:    c.Secret,
+			ExpiresAt: expiresAt,
 		},
 		Metadata:  metadata,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: updatedAt,
 		UpdatedBy: updatedBy,
 		Status:    c.Status,
+		Version:   c.Version,
 	}
 	if c.Role != nil {
 		cli.Role = *c.Role
@@ -434,32 +448,48 @@ func ToDBClientsPage(pm clients.Page) (dbClientsPage, error) {
 	if err != nil {
 		return dbClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
+	_, filter, err := metadataFilterQuery(pm.MetadataFilter)
+	if err != nil {
+		return dbClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	var exclude pgtype.TextArray
+	if err := exclude.Set(pm.ExcludeIDs); err != nil {
+		return dbClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
 	return dbClientsPage{
-		Name:     pm.Name,
-		Identity: pm.Identity,
-		Metadata: data,
-		Domain:   pm.Domain,
-		Total:    pm.Total,
-		Offset:   pm.Offset,
-		Limit:    pm.Limit,
-		Status:   pm.Status,
-		Tag:      pm.Tag,
-		Role:     pm.Role,
+		Name:           pm.Name,
+		Identity:       pm.Identity,
+		Metadata:       data,
+		MetadataFilter: filter,
+		Domain:         pm.Domain,
+		Total:          pm.Total,
+		Offset:         pm.Offset,
+		Limit:          pm.Limit,
+		Status:         pm.Status,
+		Tag:            pm.Tag,
+		Role:           pm.Role,
+		UpdatedSince:   pm.UpdatedSince,
+		UpdatedSinceID: pm.UpdatedSinceID,
+		ExcludeIDs:     exclude,
 	}, nil
 }
 
 type dbClientsPage struct {
-	Total    uint64         `db:"total"`
-	Limit    uint64         `db:"limit"`
-	Offset   uint64         `db:"offset"`
-	Name     string         `db:"name"`
-	Domain   string         `db:"domain_id"`
-	Identity string         `db:"identity"`
-	Metadata []byte         `db:"metadata"`
-	Tag      string         `db:"tag"`
-	Status   clients.Status `db:"status"`
-	GroupID  string         `db:"group_id"`
-	Role     clients.Role   `db:"role"`
+	Total          uint64           `db:"total"`
+	Limit          uint64           `db:"limit"`
+	Offset         uint64           `db:"offset"`
+	Name           string           `db:"name"`
+	Domain         string           `db:"domain_id"`
+	Identity       string           `db:"identity"`
+	Metadata       []byte           `db:"metadata"`
+	MetadataFilter []byte           `db:"metadata_filter"`
+	Tag            string           `db:"tag"`
+	Status         clients.Status   `db:"status"`
+	GroupID        string           `db:"group_id"`
+	Role           clients.Role     `db:"role"`
+	UpdatedSince   time.Time        `db:"updated_since"`
+	UpdatedSinceID string           `db:"updated_since_id"`
+	ExcludeIDs     pgtype.TextArray `db:"exclude_ids"`
 }
 
 func PageQuery(pm clients.Page) (string, error) {
@@ -467,14 +497,24 @@ func PageQuery(pm clients.Page) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(errors.ErrMalformedEntity, err)
 	}
+	fq, _, err := metadataFilterQuery(pm.MetadataFilter)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 	var query []string
 	var emq string
 	if mq != "" {
 		query = append(query, mq)
 	}
+	if fq != "" {
+		query = append(query, fq)
+	}
 	if len(pm.IDs) != 0 {
 		query = append(query, fmt.Sprintf("id IN ('%s')", strings.Join(pm.IDs, "','")))
 	}
+	if len(pm.ExcludeIDs) != 0 {
+		query = append(query, "c.id <> ALL(:exclude_ids)")
+	}
 	if pm.Identity != "" {
 		query = append(query, "c.identity = :identity")
 	}
@@ -494,12 +534,101 @@ func PageQuery(pm clients.Page) (string, error) {
 	if pm.Role != clients.AllRole {
 		query = append(query, "c.role = :role")
 	}
+	// Clients which were never updated count as updated when created. The
+	// ID resumes the listing within the clients updated at the same time,
+	// in the order of the listing.
+	switch {
+	case !pm.UpdatedSince.IsZero() && pm.UpdatedSinceID != "":
+		query = append(query, "(COALESCE(c.updated_at, c.created_at), c.id) > (:updated_since, :updated_since_id)")
+	case !pm.UpdatedSince.IsZero():
+		query = append(query, "COALESCE(c.updated_at, c.created_at) > :updated_since")
+	}
 	if len(query) > 0 {
 		emq = fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
 	}
 	return emq, nil
 }
 
+// metadataFilterQuery translates the metadata filter to the condition over
+// the metadata of the clients aliased as c. The leaves of the filter are
+// returned as a JSON array, passed as the metadata_filter parameter, which
+// the condition refers to by the leaf index, so the filter values are never
+// part of the query.
+func metadataFilterQuery(f *clients.MetadataFilter) (string, []byte, error) {
+	if f == nil {
+		return "", nil, nil
+	}
+
+	var leaves []map[string]interface{}
+	query := metadataFilterCondition(*f, &leaves)
+	param, err := json.Marshal(leaves)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, param, nil
+}
+
+func metadataFilterCondition(f clients.MetadataFilter, leaves *[]map[string]interface{}) string {
+	var conds []string
+	var op string
+	switch {
+	case f.Not != nil:
+		return fmt.Sprintf("NOT (%s)", metadataFilterCondition(*f.Not, leaves))
+	case len(f.And) > 0:
+		conds, op = make([]string, 0, len(f.And)), " AND "
+		for _, c := range f.And {
+			conds = append(conds, metadataFilterCondition(c, leaves))
+		}
+	case len(f.Or) > 0:
+		conds, op = make([]string, 0, len(f.Or)), " OR "
+		for _, c := range f.Or {
+			conds = append(conds, metadataFilterCondition(c, leaves))
+		}
+	default:
+		*leaves = append(*leaves, map[string]interface{}{f.Field: f.Value})
+		return fmt.Sprintf("c.metadata @> (CAST(:metadata_filter AS jsonb) -> %d)", len(*leaves)-1)
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(conds, op))
+}
+
+// fieldColumn returns the column of the field, or NULL if the field isn't
+// among the page fields, so the column isn't read.
+func fieldColumn(pm clients.Page, field string) string {
+	if len(pm.Fields) == 0 || slices.Contains(pm.Fields, field) {
+		return "c." + field
+	}
+
+	return "NULL AS " + field
+}
+
+// orderQuery orders the clients by the creation time, unless they are
+// listed by the update time, in which case the changes are ordered by the
+// update time with the ID breaking the ties, so the pages are stable.
+//
+// Clients ordered by a metadata field are ordered by the numeric values of
+// the field, followed by the other values as text. The clients missing the
+// field are last in either direction.
+func orderQuery(pm clients.Page) string {
+	if !pm.UpdatedSince.IsZero() {
+		return "ORDER BY COALESCE(c.updated_at, c.created_at), c.id"
+	}
+	// The keys of a valid path are safe to be a part of the query.
+	path, ok := clients.MetadataOrderPath(pm.Order)
+	if !ok {
+		return "ORDER BY c.created_at"
+	}
+	dir := "ASC"
+	if pm.Dir == api.DescDir {
+		dir = "DESC"
+	}
+	field := fmt.Sprintf("c.metadata #> '{%s}'", strings.Join(path, ","))
+
+	return fmt.Sprintf(`ORDER BY CASE WHEN jsonb_typeof(%[1]s) = 'number' THEN (%[1]s)::numeric END %[2]s NULLS LAST,
+		%[1]s #>> '{}' %[2]s NULLS LAST, c.created_at`, field, dir)
+}
+
 func constructSearchQuery(pm clients.Page) (string, string) {
 	var query []string
 	var emq string
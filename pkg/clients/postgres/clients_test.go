@@ -6,6 +6,7 @@ package postgres_test
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -326,6 +327,52 @@ func TestRetrieveAll(t *testing.T) {
 				Clients: []mgclients.Client(nil),
 			},
 		},
+		{
+			desc: "with metadata filter",
+			pm: mgclients.Page{
+				Offset: 0,
+				Limit:  nClients,
+				MetadataFilter: &mgclients.MetadataFilter{
+					Or: []mgclients.MetadataFilter{
+						{Field: "department", Value: expectedClients[0].Metadata["department"]},
+						{Field: "department", Value: expectedClients[1].Metadata["department"]},
+					},
+				},
+				Status: mgclients.AllStatus,
+				Role:   mgclients.AllRole,
+			},
+			response: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total:  2,
+					Offset: 0,
+					Limit:  nClients,
+				},
+				Clients: expectedClients[0:2],
+			},
+		},
+		{
+			desc: "with negated metadata filter",
+			pm: mgclients.Page{
+				Offset: 0,
+				Limit:  nClients,
+				MetadataFilter: &mgclients.MetadataFilter{
+					And: []mgclients.MetadataFilter{
+						{Not: &mgclients.MetadataFilter{Field: "department", Value: expectedClients[0].Metadata["department"]}},
+						{Field: "department", Value: expectedClients[1].Metadata["department"]},
+					},
+				},
+				Status: mgclients.AllStatus,
+				Role:   mgclients.AllRole,
+			},
+			response: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total:  1,
+					Offset: 0,
+					Limit:  nClients,
+				},
+				Clients: []mgclients.Client{expectedClients[1]},
+			},
+		},
 		{
 			desc: "with invalid metadata",
 			pm: mgclients.Page{
@@ -663,6 +710,147 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveAllOrderByMetadata(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := &postgres.Repository{database}
+
+	domainID := testsutil.GenerateUUID(t)
+	priorities := []interface{}{3, 10, "b", "a", nil, 2.5}
+	ids := []string{}
+	for _, priority := range priorities {
+		client := mgclients.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Domain: domainID,
+			Name:   namegen.Generate(),
+			Credentials: mgclients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   password,
+			},
+			Metadata:  mgclients.Metadata{},
+			Status:    mgclients.EnabledStatus,
+			CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		}
+		if priority != nil {
+			client.Metadata["settings"] = map[string]interface{}{"priority": priority}
+		}
+		client, err := save(context.Background(), repo, client)
+		require.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+		ids = append(ids, client.ID)
+	}
+
+	cases := []struct {
+		desc  string
+		dir   string
+		order []string
+	}{
+		{
+			desc:  "ordered by metadata field ascending",
+			dir:   "asc",
+			order: []string{ids[5], ids[0], ids[1], ids[3], ids[2], ids[4]},
+		},
+		{
+			desc:  "ordered by metadata field descending",
+			dir:   "desc",
+			order: []string{ids[1], ids[0], ids[5], ids[2], ids[3], ids[4]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			pm := mgclients.Page{
+				Limit:  uint64(len(priorities)),
+				Domain: domainID,
+				Order:  "metadata.settings.priority",
+				Dir:    c.dir,
+				Status: mgclients.AllStatus,
+				Role:   mgclients.AllRole,
+			}
+			page, err := repo.RetrieveAll(context.Background(), pm)
+			require.Nil(t, err, fmt.Sprintf("retrieve clients: expected nil got %s\n", err))
+			order := []string{}
+			for _, client := range page.Clients {
+				order = append(order, client.ID)
+			}
+			assert.Equal(t, c.order, order)
+		})
+	}
+}
+
+func TestRetrieveAllUpdatedSince(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := &postgres.Repository{database}
+
+	domainID := testsutil.GenerateUUID(t)
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		client := mgclients.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Domain: domainID,
+			Name:   namegen.Generate(),
+			Credentials: mgclients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   password,
+			},
+			Metadata:  mgclients.Metadata{},
+			Status:    mgclients.EnabledStatus,
+			CreatedAt: createdAt,
+		}
+		_, err := save(context.Background(), repo, client)
+		require.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+		ids = append(ids, client.ID)
+	}
+	sort.Strings(ids)
+
+	cases := []struct {
+		desc    string
+		since   time.Time
+		sinceID string
+		ids     []string
+	}{
+		{
+			desc:  "retrieve first page of clients changed at the same time",
+			since: createdAt.Add(-time.Second),
+			ids:   ids[:2],
+		},
+		{
+			desc:    "retrieve next page of clients changed at the same time",
+			since:   createdAt,
+			sinceID: ids[1],
+			ids:     ids[2:],
+		},
+		{
+			desc:  "retrieve clients changed after the time",
+			since: createdAt,
+			ids:   []string{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			pm := mgclients.Page{
+				Limit:          2,
+				Domain:         domainID,
+				Status:         mgclients.AllStatus,
+				Role:           mgclients.AllRole,
+				UpdatedSince:   c.since,
+				UpdatedSinceID: c.sinceID,
+			}
+			page, err := repo.RetrieveAll(context.Background(), pm)
+			require.Nil(t, err, fmt.Sprintf("retrieve clients: expected nil got %s\n", err))
+			got := []string{}
+			for _, client := range page.Clients {
+				got = append(got, client.ID)
+			}
+			assert.Equal(t, c.ids, got)
+		})
+	}
+}
+
 func TestRetrieveByIDs(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
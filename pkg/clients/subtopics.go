@@ -0,0 +1,39 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import "github.com/absmach/magistrala/pkg/errors"
+
+// AllowedSubtopicsKey is the client metadata key holding the list of
+// subtopic patterns the thing is allowed to publish to.
+const AllowedSubtopicsKey = "allowed_subtopics"
+
+var errAllowedSubtopics = errors.New("allowed subtopics must be a list of strings")
+
+// AllowedSubtopicsFromMetadata returns the subtopic patterns the thing is
+// allowed to publish to. An empty list is returned if the metadata doesn't
+// restrict the subtopics.
+func AllowedSubtopicsFromMetadata(m Metadata) ([]string, error) {
+	v, ok := m[AllowedSubtopicsKey]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	vals, ok := v.([]interface{})
+	if !ok {
+		if strs, ok := v.([]string); ok {
+			return strs, nil
+		}
+		return nil, errors.Wrap(errors.ErrMalformedEntity, errAllowedSubtopics)
+	}
+	patterns := make([]string, 0, len(vals))
+	for _, val := range vals {
+		p, ok := val.(string)
+		if !ok {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, errAllowedSubtopics)
+		}
+		patterns = append(patterns, p)
+	}
+
+	return patterns, nil
+}
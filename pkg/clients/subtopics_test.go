@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowedSubtopicsFromMetadata(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata clients.Metadata
+		patterns []string
+		err      error
+	}{
+		{
+			desc:     "metadata without allowed subtopics",
+			metadata: clients.Metadata{"role": "sensor"},
+		},
+		{
+			desc:     "metadata with allowed subtopics",
+			metadata: clients.Metadata{clients.AllowedSubtopicsKey: []interface{}{"temperature", "sensors.*"}},
+			patterns: []string{"temperature", "sensors.*"},
+		},
+		{
+			desc:     "metadata with empty allowed subtopics",
+			metadata: clients.Metadata{clients.AllowedSubtopicsKey: []interface{}{}},
+			patterns: []string{},
+		},
+		{
+			desc:     "metadata with allowed subtopics of invalid type",
+			metadata: clients.Metadata{clients.AllowedSubtopicsKey: "temperature"},
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with allowed subtopic of invalid type",
+			metadata: clients.Metadata{clients.AllowedSubtopicsKey: []interface{}{"temperature", 1}},
+			err:      errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			patterns, err := clients.AllowedSubtopicsFromMetadata(tc.metadata)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.patterns, patterns, fmt.Sprintf("%s: expected patterns %v got %v", tc.desc, tc.patterns, patterns))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// ScopedTokenPrefix distinguishes scoped tokens from the bearer tokens
+// issued by the auth service.
+const ScopedTokenPrefix = "mgst_"
+
+// Scopes of the operations a scoped token can be allowed to perform.
+const (
+	ThingsReadScope    = "things:read"
+	ThingsWriteScope   = "things:write"
+	ChannelsReadScope  = "channels:read"
+	ChannelsWriteScope = "channels:write"
+)
+
+// ScopedToken is a token bound to a domain which acts on behalf of the user
+// who issued it, but is allowed to perform only the operations covered by
+// its scopes.
+type ScopedToken struct {
+	ID        string     `json:"id"`
+	Token     string     `json:"token,omitempty"` // returned only when the token is issued
+	TokenHash string     `json:"-"`               // SHA-256 hash of the token, the only form it is stored in
+	DomainID  string     `json:"domain_id"`
+	UserID    string     `json:"user_id"`
+	Subject   string     `json:"-"` // domain user ID used to authorize the operations
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // nil for non-expiring tokens
+}
+
+// ScopedTokensPage contains page related metadata as well as a list of
+// scoped tokens that belong to the page.
+type ScopedTokensPage struct {
+	Page
+	Tokens []ScopedToken
+}
+
+// Allows returns true if the token scopes cover the given scope.
+func (st ScopedToken) Allows(scope string) bool {
+	for _, s := range st.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Expired returns true if the token has an expiry which has passed.
+func (st ScopedToken) Expired() bool {
+	return st.ExpiresAt != nil && !st.ExpiresAt.After(time.Now())
+}
+
+// IsScopedToken returns true if the token is a scoped token.
+func IsScopedToken(token string) bool {
+	return strings.HasPrefix(token, ScopedTokenPrefix)
+}
+
+// HashToken returns the hex encoded SHA-256 hash of the token, by which
+// scoped tokens are stored and looked up.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidScope returns true if the scope is one of the supported scopes.
+func ValidScope(scope string) bool {
+	switch scope {
+	case ThingsReadScope, ThingsWriteScope, ChannelsReadScope, ChannelsWriteScope:
+		return true
+	default:
+		return false
+	}
+}
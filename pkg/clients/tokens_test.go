@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopedTokenAllows(t *testing.T) {
+	token := clients.ScopedToken{Scopes: []string{clients.ThingsReadScope}}
+
+	cases := []struct {
+		desc     string
+		scope    string
+		expected bool
+	}{
+		{
+			desc:     "Scope covered by the token",
+			scope:    clients.ThingsReadScope,
+			expected: true,
+		},
+		{
+			desc:     "Scope not covered by the token",
+			scope:    clients.ThingsWriteScope,
+			expected: false,
+		},
+		{
+			desc:     "Empty scope",
+			scope:    "",
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := token.Allows(tc.scope)
+			assert.Equal(t, tc.expected, got, "Allows() = %v, expected %v", got, tc.expected)
+		})
+	}
+}
+
+func TestScopedTokenExpired(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	cases := []struct {
+		desc     string
+		token    clients.ScopedToken
+		expected bool
+	}{
+		{
+			desc:     "Token without expiry",
+			token:    clients.ScopedToken{},
+			expected: false,
+		},
+		{
+			desc:     "Token expiring in the future",
+			token:    clients.ScopedToken{ExpiresAt: &future},
+			expected: false,
+		},
+		{
+			desc:     "Token expired in the past",
+			token:    clients.ScopedToken{ExpiresAt: &past},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.token.Expired()
+			assert.Equal(t, tc.expected, got, "Expired() = %v, expected %v", got, tc.expected)
+		})
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	token := clients.ScopedTokenPrefix + "secret"
+
+	hash := clients.HashToken(token)
+	assert.Len(t, hash, 64, "hash should be a hex encoded SHA-256 sum")
+	assert.NotContains(t, hash, token, "hash should not contain the token")
+	assert.Equal(t, hash, clients.HashToken(token), "hash should be deterministic")
+	assert.NotEqual(t, hash, clients.HashToken(token+"x"), "different tokens should have different hashes")
+}
@@ -5,3 +5,35 @@ package clients
 
 // Metadata represents arbitrary JSON.
 type Metadata map[string]interface{}
+
+// Merge returns a copy of the metadata with patch deep-merged into it.
+// Nested objects are merged recursively and a nil value removes the key.
+// Any other value, including arrays, replaces the existing one wholesale.
+func (m Metadata) Merge(patch Metadata) Metadata {
+	return Metadata(mergeMaps(m, patch))
+}
+
+func mergeMaps(dst, patch map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		ret[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(ret, k)
+			continue
+		}
+		pv, ok := v.(map[string]interface{})
+		if !ok {
+			ret[k] = v
+			continue
+		}
+		dv, ok := ret[k].(map[string]interface{})
+		if !ok {
+			dv = map[string]interface{}{}
+		}
+		ret[k] = mergeMaps(dv, pv)
+	}
+
+	return ret
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataMerge(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata clients.Metadata
+		patch    clients.Metadata
+		expected clients.Metadata
+	}{
+		{
+			desc:     "add new key",
+			metadata: clients.Metadata{"region": "eu"},
+			patch:    clients.Metadata{"line": "a"},
+			expected: clients.Metadata{"region": "eu", "line": "a"},
+		},
+		{
+			desc:     "replace existing key",
+			metadata: clients.Metadata{"region": "eu"},
+			patch:    clients.Metadata{"region": "us"},
+			expected: clients.Metadata{"region": "us"},
+		},
+		{
+			desc:     "remove key with null",
+			metadata: clients.Metadata{"region": "eu", "line": "a"},
+			patch:    clients.Metadata{"line": nil},
+			expected: clients.Metadata{"region": "eu"},
+		},
+		{
+			desc:     "merge nested objects",
+			metadata: clients.Metadata{"location": map[string]interface{}{"lat": 1.0, "lon": 2.0}},
+			patch:    clients.Metadata{"location": map[string]interface{}{"lon": 3.0, "alt": nil}},
+			expected: clients.Metadata{"location": map[string]interface{}{"lat": 1.0, "lon": 3.0}},
+		},
+		{
+			desc:     "replace arrays wholesale",
+			metadata: clients.Metadata{"tags": []interface{}{"a", "b"}},
+			patch:    clients.Metadata{"tags": []interface{}{"c"}},
+			expected: clients.Metadata{"tags": []interface{}{"c"}},
+		},
+		{
+			desc:     "merge into empty metadata",
+			metadata: nil,
+			patch:    clients.Metadata{"region": "eu"},
+			expected: clients.Metadata{"region": "eu"},
+		},
+	}
+
+	for _, tc := range cases {
+		got := tc.metadata.Merge(tc.patch)
+		assert.Equal(t, tc.expected, got, "%s: expected %v got %v\n", tc.desc, tc.expected, got)
+	}
+}
@@ -16,6 +16,22 @@ var (
 	// ErrDomainAuthorization indicates failure occurred while authorizing the domain.
 	ErrDomainAuthorization = errors.New("failed to perform authorization over the domain")
 
+	// ErrTokenScope indicates that the operation is outside of the token scopes.
+	ErrTokenScope = errors.New("operation is not allowed by the token scopes")
+
+	// ErrKeyExpired indicates use of an expired key.
+	ErrKeyExpired = errors.New("use of expired key")
+
+	// ErrThingDisabled indicates use of the key of a disabled thing.
+	ErrThingDisabled = errors.New("thing is disabled")
+
+	// ErrServiceUnavailable indicates that a service the operation depends on
+	// is temporarily unavailable.
+	ErrServiceUnavailable = errors.New("service is temporarily unavailable")
+
+	// ErrRateLimited indicates that the operation is performed too often.
+	ErrRateLimited = errors.New("operation rate limit exceeded")
+
 	// ErrLogin indicates wrong login credentials.
 	ErrLogin = errors.New("invalid user id or secret")
 
@@ -28,6 +44,9 @@ var (
 	// ErrConflict indicates that entity already exists.
 	ErrConflict = errors.New("entity already exists")
 
+	// ErrPreconditionFailed indicates that the entity was modified since the given time.
+	ErrPreconditionFailed = errors.New("entity modified since the given time")
+
 	// ErrCreateEntity indicates error in creating entity or entities.
 	ErrCreateEntity = errors.New("failed to create entity")
 
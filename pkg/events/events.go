@@ -21,6 +21,14 @@ type Event interface {
 	Encode() (map[string]interface{}, error)
 }
 
+// Positioned is implemented by the events subscribers deliver when the
+// underlying stream reports the position of the event. Positions grow
+// along the stream and are the same for every consumer of the stream.
+type Positioned interface {
+	// Position returns the position of the event in its stream.
+	Position() uint64
+}
+
 // Publisher specifies events publishing API.
 //
 //go:generate mockery --name Publisher --output=./mocks --filename publisher.go --quiet --note "Copyright (c) Abstract Machines"
@@ -52,6 +60,10 @@ type Subscriber interface {
 	// Subscribe subscribes to the event stream and consumes events.
 	Subscribe(ctx context.Context, cfg SubscriberConfig) error
 
+	// Unsubscribe stops consuming the stream and removes the consumer's
+	// durable state from the broker.
+	Unsubscribe(ctx context.Context, consumer, stream string) error
+
 	// Close gracefully closes event subscriber's connection.
 	Close() error
 }
@@ -52,6 +52,24 @@ func (_m *Subscriber) Subscribe(ctx context.Context, cfg events.SubscriberConfig
 	return r0
 }
 
+// Unsubscribe provides a mock function with given fields: ctx, consumer, stream
+func (_m *Subscriber) Unsubscribe(ctx context.Context, consumer string, stream string) error {
+	ret := _m.Called(ctx, consumer, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsubscribe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, consumer, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewSubscriber creates a new instance of Subscriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSubscriber(t interface {
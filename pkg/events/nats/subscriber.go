@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/absmach/magistrala/pkg/events"
@@ -16,11 +17,15 @@ import (
 	broker "github.com/absmach/magistrala/pkg/messaging/nats"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"google.golang.org/protobuf/proto"
 )
 
 const maxReconnects = -1
 
-var _ events.Subscriber = (*subEventStore)(nil)
+var (
+	_ events.Subscriber = (*subEventStore)(nil)
+	_ events.Positioned = event{}
+)
 
 var (
 	eventsPrefix = "events"
@@ -46,7 +51,7 @@ var (
 
 type subEventStore struct {
 	conn   *nats.Conn
-	pubsub messaging.PubSub
+	stream jetstream.Stream
 	logger *slog.Logger
 }
 
@@ -64,18 +69,15 @@ func NewSubscriber(ctx context.Context, url string, logger *slog.Logger) (events
 		return nil, err
 	}
 
-	pubsub, err := broker.NewPubSub(ctx, url, logger, broker.Stream(jsStream))
-	if err != nil {
-		return nil, err
-	}
-
 	return &subEventStore{
 		conn:   conn,
-		pubsub: pubsub,
+		stream: jsStream,
 		logger: logger,
 	}, nil
 }
 
+// Subscribe consumes the stream directly instead of going through the
+// messaging pubsub, so events keep their stream sequence as position.
 func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberConfig) error {
 	if cfg.Stream == "" {
 		return ErrEmptyStream
@@ -84,55 +86,97 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 		return ErrEmptyConsumer
 	}
 
-	subCfg := messaging.SubscriberConfig{
-		ID:    cfg.Consumer,
-		Topic: cfg.Stream,
-		Handler: &eventHandler{
-			handler: cfg.Handler,
-			ctx:     ctx,
-			logger:  es.logger,
-		},
-		DeliveryPolicy: messaging.DeliverNewPolicy,
+	name := formatConsumerName(cfg.Stream, cfg.Consumer)
+	consumerConfig := jetstream.ConsumerConfig{
+		Name:          name,
+		Durable:       name,
+		Description:   fmt.Sprintf("Magistrala consumer of id %s for cfg.Topic %s", cfg.Consumer, cfg.Stream),
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		FilterSubject: cfg.Stream,
+	}
+
+	consumer, err := es.stream.CreateOrUpdateConsumer(ctx, consumerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+
+	if _, err = consumer.Consume(es.handle(ctx, cfg.Handler)); err != nil {
+		return fmt.Errorf("failed to consume: %w", err)
 	}
 
-	return es.pubsub.Subscribe(ctx, subCfg)
+	return nil
+}
+
+func (es *subEventStore) Unsubscribe(ctx context.Context, consumer, stream string) error {
+	if stream == "" {
+		return ErrEmptyStream
+	}
+	if consumer == "" {
+		return ErrEmptyConsumer
+	}
+
+	err := es.stream.DeleteConsumer(ctx, formatConsumerName(stream, consumer))
+	if errors.Is(err, jetstream.ErrConsumerNotFound) {
+		return broker.ErrNotSubscribed
+	}
+
+	return err
 }
 
 func (es *subEventStore) Close() error {
 	es.conn.Close()
-	return es.pubsub.Close()
+
+	return nil
 }
 
 type event struct {
-	Data map[string]interface{}
+	Data     map[string]interface{}
+	Sequence uint64
 }
 
 func (re event) Encode() (map[string]interface{}, error) {
 	return re.Data, nil
 }
 
-type eventHandler struct {
-	handler events.EventHandler
-	ctx     context.Context
-	logger  *slog.Logger
+func (re event) Position() uint64 {
+	return re.Sequence
 }
 
-func (eh *eventHandler) Handle(msg *messaging.Message) error {
-	event := event{
-		Data: make(map[string]interface{}),
+func (es *subEventStore) handle(ctx context.Context, h events.EventHandler) func(m jetstream.Msg) {
+	return func(m jetstream.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data(), &msg); err != nil {
+			es.logger.Warn(fmt.Sprintf("failed to unmarshal nats event: %s", err))
+
+			return
+		}
+
+		event := event{
+			Data: make(map[string]interface{}),
+		}
+		if err := json.Unmarshal(msg.GetPayload(), &event.Data); err != nil {
+			es.logger.Warn(fmt.Sprintf("failed to unmarshal nats event: %s", err))
+
+			return
+		}
+		if md, err := m.Metadata(); err == nil {
+			event.Sequence = md.Sequence.Stream
+		}
+
+		if err := h.Handle(ctx, event); err != nil {
+			es.logger.Warn(fmt.Sprintf("failed to handle nats event: %s", err))
+		}
+		if err := m.Ack(); err != nil {
+			es.logger.Warn(fmt.Sprintf("failed to ack nats event: %s", err))
+		}
 	}
-
-	if err := json.Unmarshal(msg.GetPayload(), &event.Data); err != nil {
-		return err
-	}
-
-	if err := eh.handler.Handle(eh.ctx, event); err != nil {
-		eh.logger.Warn(fmt.Sprintf("failed to handle nats event: %s", err))
-	}
-
-	return nil
 }
 
-func (eh *eventHandler) Cancel() error {
-	return nil
+// formatConsumerName matches the durable consumer names of the messaging
+// pubsub, so consumers created before keep being used.
+func formatConsumerName(stream, consumer string) string {
+	// A durable name cannot contain whitespace, ., *, >, path separators (forward or backwards slash), and non-printable characters.
+	stream = strings.NewReplacer(" ", "_", ".", "_", "*", "_", ">", "_", "/", "_", "\\", "_").Replace(stream)
+
+	return fmt.Sprintf("%s-%s", stream, consumer)
 }
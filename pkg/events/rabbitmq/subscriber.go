@@ -82,6 +82,17 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 	return es.pubsub.Subscribe(ctx, subCfg)
 }
 
+func (es *subEventStore) Unsubscribe(ctx context.Context, consumer, stream string) error {
+	if stream == "" {
+		return ErrEmptyStream
+	}
+	if consumer == "" {
+		return ErrEmptyConsumer
+	}
+
+	return es.pubsub.Unsubscribe(ctx, consumer, stream)
+}
+
 func (es *subEventStore) Close() error {
 	es.conn.Close()
 	return es.pubsub.Close()
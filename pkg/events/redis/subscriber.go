@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/go-redis/redis/v8"
@@ -19,9 +21,15 @@ const (
 	eventCount   = 100
 	exists       = "BUSYGROUP Consumer Group name already exists"
 	group        = "magistrala"
+	// seqBits is the number of bits of an event position holding the
+	// sequence part of the stream entry ID.
+	seqBits = 22
 )
 
-var _ events.Subscriber = (*subEventStore)(nil)
+var (
+	_ events.Subscriber = (*subEventStore)(nil)
+	_ events.Positioned = redisEvent{}
+)
 
 var (
 	// ErrEmptyStream is returned when stream name is empty.
@@ -85,18 +93,49 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 	return nil
 }
 
+func (es *subEventStore) Unsubscribe(ctx context.Context, consumer, stream string) error {
+	if stream == "" {
+		return ErrEmptyStream
+	}
+	if consumer == "" {
+		return ErrEmptyConsumer
+	}
+
+	return es.client.XGroupDelConsumer(ctx, stream, group, consumer).Err()
+}
+
 func (es *subEventStore) Close() error {
 	return es.client.Close()
 }
 
 type redisEvent struct {
 	Data map[string]interface{}
+	ID   string
 }
 
 func (re redisEvent) Encode() (map[string]interface{}, error) {
 	return re.Data, nil
 }
 
+// Position packs the millisecond and sequence parts of the stream entry ID
+// into a single number which keeps the order of the entries.
+func (re redisEvent) Position() uint64 {
+	ms, seq, ok := strings.Cut(re.ID, "-")
+	if !ok {
+		return 0
+	}
+	m, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil || n >= 1<<seqBits {
+		return 0
+	}
+
+	return m<<seqBits | n
+}
+
 func (es *subEventStore) handle(ctx context.Context, stream string, msgs []redis.XMessage, h events.EventHandler) {
 	for _, msg := range msgs {
 		var data map[string]interface{}
@@ -108,6 +147,7 @@ func (es *subEventStore) handle(ctx context.Context, stream string, msgs []redis
 
 		event := redisEvent{
 			Data: data,
+			ID:   msg.ID,
 		}
 
 		if err := h.Handle(ctx, event); err != nil {
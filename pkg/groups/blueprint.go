@@ -0,0 +1,38 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import "github.com/absmach/magistrala/pkg/clients"
+
+// BlueprintVersion is the version of the blueprints exported by this release.
+// Blueprints of other versions are rejected on import.
+const BlueprintVersion = 1
+
+// Blueprint is a portable description of a group subtree, used to replicate
+// the same group setup across domains.
+type Blueprint struct {
+	Version uint64 `json:"version"`
+	BlueprintGroup
+}
+
+// BlueprintGroup describes a group of a blueprint. It holds the settings of
+// the group and the names of the roles held in it, but none of the instance
+// specific data such as IDs, members or connected things.
+type BlueprintGroup struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Metadata    clients.Metadata `json:"metadata,omitempty"`
+	Roles       []string         `json:"roles,omitempty"`
+	Children    []BlueprintGroup `json:"children,omitempty"`
+}
+
+// Depth returns the number of levels of the group subtree.
+func (bg BlueprintGroup) Depth() uint64 {
+	var depth uint64
+	for _, child := range bg.Children {
+		depth = max(depth, child.Depth())
+	}
+
+	return depth + 1
+}
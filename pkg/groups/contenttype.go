@@ -0,0 +1,101 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	// ContentTypeKey is the group metadata key holding the content type of
+	// the messages published to the channel, used by the writers.
+	ContentTypeKey = "content_type"
+
+	// ConfigKey is the group metadata key holding the channel configuration
+	// validated against the channel content type.
+	ConfigKey = "config"
+)
+
+// Content types with registered config validators.
+const (
+	SenMLContentType = "application/senml+json"
+	JSONContentType  = "application/json"
+)
+
+var (
+	errContentType = errors.New("content_type must be a string")
+	errConfig      = errors.New("config must be an object")
+)
+
+// ContentTypeValidator validates the config of a channel with the content
+// type the validator is registered for.
+type ContentTypeValidator func(config map[string]interface{}) error
+
+var contentTypes = struct {
+	sync.RWMutex
+	validators map[string]ContentTypeValidator
+}{
+	validators: map[string]ContentTypeValidator{
+		SenMLContentType: RequireConfigKeys("format"),
+		JSONContentType:  RequireConfigKeys(),
+	},
+}
+
+// RegisterContentType registers the config validator of the content type,
+// replacing the validator registered before.
+func RegisterContentType(contentType string, v ContentTypeValidator) {
+	contentTypes.Lock()
+	defer contentTypes.Unlock()
+	contentTypes.validators[contentType] = v
+}
+
+// RequireConfigKeys returns a validator which requires the config to contain
+// the given keys.
+func RequireConfigKeys(keys ...string) ContentTypeValidator {
+	return func(config map[string]interface{}) error {
+		for _, k := range keys {
+			if _, ok := config[k]; !ok {
+				return fmt.Errorf("config key %s is required", k)
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateContentType validates the config in the group metadata against
+// the validator registered for the content type in the group metadata.
+// Groups without a content type and groups with content types without
+// registered validators are not validated.
+func ValidateContentType(m clients.Metadata) error {
+	v, ok := m[ContentTypeKey]
+	if !ok || v == nil {
+		return nil
+	}
+	ct, ok := v.(string)
+	if !ok {
+		return errors.Wrap(errors.ErrMalformedEntity, errContentType)
+	}
+	contentTypes.RLock()
+	validate, ok := contentTypes.validators[ct]
+	contentTypes.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	config := map[string]interface{}{}
+	if c, ok := m[ConfigKey]; ok && c != nil {
+		if config, ok = c.(map[string]interface{}); !ok {
+			return errors.Wrap(errors.ErrMalformedEntity, errConfig)
+		}
+	}
+	if err := validate(config); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, fmt.Errorf("content type %s: %w", ct, err))
+	}
+
+	return nil
+}
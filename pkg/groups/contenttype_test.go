@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContentType(t *testing.T) {
+	groups.RegisterContentType("application/vnd.test+json", groups.RequireConfigKeys("unit", "scale"))
+
+	cases := []struct {
+		desc     string
+		metadata clients.Metadata
+		err      error
+	}{
+		{
+			desc:     "metadata without content type",
+			metadata: clients.Metadata{"location": "roof"},
+			err:      nil,
+		},
+		{
+			desc:     "metadata with unknown content type",
+			metadata: clients.Metadata{groups.ContentTypeKey: "text/plain"},
+			err:      nil,
+		},
+		{
+			desc:     "metadata with JSON content type without config",
+			metadata: clients.Metadata{groups.ContentTypeKey: groups.JSONContentType},
+			err:      nil,
+		},
+		{
+			desc: "metadata with SenML content type and valid config",
+			metadata: clients.Metadata{
+				groups.ContentTypeKey: groups.SenMLContentType,
+				groups.ConfigKey:      map[string]interface{}{"format": "senml"},
+			},
+			err: nil,
+		},
+		{
+			desc:     "metadata with SenML content type without config",
+			metadata: clients.Metadata{groups.ContentTypeKey: groups.SenMLContentType},
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata with registered content type and valid config",
+			metadata: clients.Metadata{
+				groups.ContentTypeKey: "application/vnd.test+json",
+				groups.ConfigKey:      map[string]interface{}{"unit": "C", "scale": float64(1)},
+			},
+			err: nil,
+		},
+		{
+			desc: "metadata with registered content type and missing config key",
+			metadata: clients.Metadata{
+				groups.ContentTypeKey: "application/vnd.test+json",
+				groups.ConfigKey:      map[string]interface{}{"unit": "C"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with non-string content type",
+			metadata: clients.Metadata{groups.ContentTypeKey: float64(1)},
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata with non-object config",
+			metadata: clients.Metadata{
+				groups.ContentTypeKey: groups.SenMLContentType,
+				groups.ConfigKey:      "format=senml",
+			},
+			err: errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := groups.ValidateContentType(tc.metadata)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidateContentTypeReportsKey(t *testing.T) {
+	err := groups.ValidateContentType(clients.Metadata{groups.ContentTypeKey: groups.SenMLContentType})
+	assert.ErrorContains(t, err, "config key format is required")
+}
@@ -3,7 +3,7 @@
 
 package groups
 
-import "errors"
+import "github.com/absmach/magistrala/pkg/errors"
 
 var (
 	// ErrInvalidStatus indicates invalid status.
@@ -14,4 +14,40 @@ var (
 
 	// ErrDisableGroup indicates error in disabling group.
 	ErrDisableGroup = errors.New("failed to disable group")
+
+	// ErrGroupHasChildren indicates that a group with children can't be removed without cascade.
+	ErrGroupHasChildren = errors.New("group has children")
+
+	// ErrGroupHasThings indicates that a group with connected things can't be removed in bulk without cascade.
+	ErrGroupHasThings = errors.New("group has connected things")
+
+	// ErrInvalidRole indicates an invalid group role.
+	ErrInvalidRole = errors.New("invalid group role")
+
+	// ErrParentDomain indicates that the parent group belongs to a different domain.
+	ErrParentDomain = errors.New("parent group belongs to a different domain")
+
+	// ErrTargetDomain indicates that the target group belongs to a different domain.
+	ErrTargetDomain = errors.New("target group belongs to a different domain")
+
+	// ErrTargetParent indicates that the target group has a different parent group.
+	ErrTargetParent = errors.New("target group has a different parent group")
+
+	// ErrNotMember indicates that the entity is not a member of the group.
+	ErrNotMember = errors.New("entity is not a member of the group")
+
+	// ErrSameDomain indicates that the group already belongs to the target domain.
+	ErrSameDomain = errors.New("group already belongs to the target domain")
+
+	// ErrNameConflict indicates that an entity with the same name exists in the target domain.
+	ErrNameConflict = errors.New("entity with the same name exists in the target domain")
+
+	// ErrConnectionLimitExceeded indicates that connecting the things would exceed the channel limit.
+	ErrConnectionLimitExceeded = errors.New("channel connection limit exceeded")
+
+	// ErrSharedThing indicates that a thing of the group is connected to other groups.
+	ErrSharedThing = errors.New("thing is connected to other groups")
+
+	// ErrBlueprintVersion indicates that the blueprint version is not supported.
+	ErrBlueprintVersion = errors.New("incompatible blueprint version")
 )
@@ -0,0 +1,68 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ForwardKey is the group metadata key holding the message forwarding configuration.
+const ForwardKey = "forward"
+
+var (
+	errForwardURL    = errors.New("forward url must be an absolute http or https url")
+	errForwardMethod = errors.New("forward method must be POST, PUT or PATCH")
+)
+
+// Forward describes the HTTP endpoint to which messages published
+// to a channel are forwarded.
+type Forward struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ForwardFromMetadata extracts the forwarding configuration from the group metadata.
+// The returned flag reports whether the metadata contains a forwarding configuration.
+func ForwardFromMetadata(m clients.Metadata) (Forward, bool, error) {
+	v, ok := m[ForwardKey]
+	if !ok {
+		return Forward{}, false, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Forward{}, true, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	var f Forward
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Forward{}, true, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if f.Method == "" {
+		f.Method = http.MethodPost
+	}
+	if err := f.Validate(); err != nil {
+		return Forward{}, true, err
+	}
+
+	return f, true, nil
+}
+
+// Validate checks that the forwarding configuration targets an HTTP(S) endpoint.
+func (f Forward) Validate() error {
+	u, err := url.Parse(f.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.Wrap(errors.ErrMalformedEntity, errForwardURL)
+	}
+	switch f.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return nil
+	default:
+		return errors.Wrap(errors.ErrMalformedEntity, errForwardMethod)
+	}
+}
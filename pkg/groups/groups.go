@@ -32,6 +32,8 @@ type Group struct {
 	UpdatedBy   string           `json:"updated_by,omitempty"`
 	Status      clients.Status   `json:"status"`
 	Permissions []string         `json:"permissions,omitempty"`
+	Version     uint64           `json:"version,omitempty"`
+	ThingCount  uint64           `json:"thing_count"`
 }
 
 type Member struct {
@@ -39,6 +41,134 @@ type Member struct {
 	Type string `json:"type"`
 }
 
+// RoleAssignment represents assignment of a member to a group with the given role.
+// Error is set if the assignment could not be applied.
+type RoleAssignment struct {
+	GroupID string `json:"group_id"`
+	Role    string `json:"role"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ThingMove represents the outcome of moving a thing between groups.
+// Error is set if the thing could not be moved.
+type ThingMove struct {
+	ThingID string `json:"thing_id"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ThingAttachment represents the outcome of attaching a thing to a group.
+// Detached lists the groups the thing was detached from, while Error is set
+// if the thing could not be attached.
+type ThingAttachment struct {
+	ThingID  string   `json:"thing_id"`
+	Detached []string `json:"detached,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// GroupUpdate represents the outcome of updating a group in a bulk update.
+// Group is set to the updated group, while Error is set if the group could
+// not be updated.
+type GroupUpdate struct {
+	ID    string `json:"id"`
+	Group *Group `json:"group,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// GroupPatch represents a JSON merge patch of the name, description and
+// metadata of a group. Nil fields leave the stored ones unchanged, while an
+// empty description clears the stored one.
+type GroupPatch struct {
+	Name        *string
+	Description *string
+	// Metadata is merged into the stored metadata, so its null values
+	// remove the stored keys.
+	Metadata clients.Metadata
+	// ClearMetadata removes the stored metadata before the merge.
+	ClearMetadata bool
+}
+
+// Apply returns the group with the patch applied.
+func (p GroupPatch) Apply(g Group) Group {
+	if p.Name != nil {
+		g.Name = *p.Name
+	}
+	if p.Description != nil {
+		g.Description = *p.Description
+	}
+	md := clients.Metadata{}
+	if !p.ClearMetadata {
+		md = md.Merge(g.Metadata)
+	}
+	g.Metadata = md.Merge(p.Metadata)
+
+	return g
+}
+
+// GroupRemoval represents the outcome of removing a group in a bulk removal.
+// Removed is set if the group was removed, while Error is set if it could
+// not be removed.
+type GroupRemoval struct {
+	ID      string `json:"id"`
+	Removed bool   `json:"removed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// MemberRevocation represents the outcome of revoking the group roles of a
+// member. Groups are the IDs of the groups the member was removed from.
+// DomainPermission is the most privileged permission the member keeps on
+// the domain through a domain role, which grants access to every group of
+// the domain. It's empty if the member keeps no access to the groups.
+type MemberRevocation struct {
+	Groups           []string `json:"groups"`
+	DomainPermission string   `json:"domain_permission,omitempty"`
+}
+
+// MemberGroup represents a group together with the role a member holds in it.
+type MemberGroup struct {
+	Group
+	Role string `json:"role"`
+}
+
+// MemberRole represents a member of a group together with the role held in it.
+// LastActive is the time the member last acted on the group, or nil if the
+// member has never acted on it.
+type MemberRole struct {
+	MemberID   string     `json:"member_id"`
+	Role       string     `json:"role"`
+	LastActive *time.Time `json:"last_active"`
+}
+
+// GroupRole represents a group together with the role a domain member holds in it.
+type GroupRole struct {
+	GroupID string `json:"group_id"`
+	Role    string `json:"role"`
+}
+
+// DomainMember represents a member of a domain along with the groups the
+// member holds a role in. Role is the most privileged role the member holds
+// across the groups, empty if the member holds no role in any group.
+type DomainMember struct {
+	MemberID string      `json:"member_id"`
+	Role     string      `json:"role"`
+	Groups   []GroupRole `json:"groups"`
+}
+
+// DomainMembersPage contains page related metadata as well as list of
+// the members of the groups of a domain.
+type DomainMembersPage struct {
+	Total   uint64         `json:"total"`
+	Offset  uint64         `json:"offset"`
+	Limit   uint64         `json:"limit"`
+	Members []DomainMember `json:"members"`
+}
+
+// MemberGroupsPage contains page related metadata as well as list of groups
+// a member belongs to.
+type MemberGroupsPage struct {
+	PageMeta
+	Groups []MemberGroup `json:"groups"`
+}
+
 // Memberships contains page related metadata as well as list of memberships that
 // belong to this page.
 type MembersPage struct {
@@ -56,9 +186,12 @@ type Page struct {
 	Level      uint64
 	ID         string
 	Permission string
-	ListPerms  bool
-	Direction  int64 // ancestors (+1) or descendants (-1)
-	Groups     []Group
+	// MyRole limits the page to the groups in which the caller holds the
+	// role directly. Empty value disables the filter.
+	MyRole    string
+	ListPerms bool
+	Direction int64 // ancestors (+1) or descendants (-1)
+	Groups    []Group
 }
 
 // Repository specifies a group persistence API.
@@ -71,6 +204,15 @@ type Repository interface {
 	// Update a group.
 	Update(ctx context.Context, g Group) (Group, error)
 
+	// UpdateAll updates the groups in a single transaction, so either all
+	// of the groups are updated or none of them is.
+	UpdateAll(ctx context.Context, gs ...Group) ([]Group, error)
+
+	// Replace replaces the name, description and metadata of the group with
+	// the given ones, including the empty ones. The replacement is restricted
+	// to the version of the group, so a stale version yields ErrConflict.
+	Replace(ctx context.Context, g Group) (Group, error)
+
 	// RetrieveByID retrieves group by its id.
 	RetrieveByID(ctx context.Context, id string) (Group, error)
 
@@ -89,8 +231,36 @@ type Repository interface {
 	// UnassignParentGroup unassign parent group id fr given group id
 	UnassignParentGroup(ctx context.Context, parentGroupID string, groupIDs ...string) error
 
+	// RetrieveChildrenIDs retrieves IDs of the direct children of the given group.
+	RetrieveChildrenIDs(ctx context.Context, parentGroupID string) ([]string, error)
+
+	// UpdateThingCount stores the number of things connected to the group.
+	UpdateThingCount(ctx context.Context, groupID string, count uint64) error
+
+	// RetrieveUnsyncedThingCounts retrieves IDs of up to limit groups whose
+	// thing count was never stored, i.e. the groups created before the
+	// count was kept.
+	RetrieveUnsyncedThingCounts(ctx context.Context, limit uint64) ([]string, error)
+
+	// ReserveThings adds n to the number of things connected to the group,
+	// unless the sum exceeds the limit of the group, in which case
+	// ErrConnectionLimitExceeded is returned. Reservations of the same group
+	// are serialized, so concurrent connections can't exceed the limit.
+	ReserveThings(ctx context.Context, groupID string, n uint64) error
+
+	// SaveActivity stores the time the member last acted on the group.
+	// Times preceding the stored one are ignored.
+	SaveActivity(ctx context.Context, groupID, memberID string, at time.Time) error
+
+	// RetrieveActivity retrieves the times the members of the group last acted on it.
+	RetrieveActivity(ctx context.Context, groupID string) (map[string]time.Time, error)
+
 	// Delete a group
 	Delete(ctx context.Context, groupID string) error
+
+	// DeleteAll removes the groups in a single transaction, so either all
+	// of the groups are removed or none of them is.
+	DeleteAll(ctx context.Context, groupIDs ...string) error
 }
 
 //go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines" --unroll-variadic=false
@@ -98,9 +268,37 @@ type Service interface {
 	// CreateGroup creates new  group.
 	CreateGroup(ctx context.Context, token, kind string, g Group) (Group, error)
 
+	// CloneGroup creates a new group with the description and metadata of the
+	// group identified by id. The clone is created under parentID, or under
+	// the parent of the source group if parentID is empty, and is named name,
+	// or after the source group if name is empty. Members of the source group
+	// are not cloned.
+	CloneGroup(ctx context.Context, token, kind, id, parentID, name string) (Group, error)
+
+	// ExportGroup exports the group identified by id, along with its
+	// subgroups, as a blueprint. Roles are exported by name only.
+	ExportGroup(ctx context.Context, token, id string) (Blueprint, error)
+
+	// ImportGroup creates a group subtree from the blueprint in the domain
+	// identified by domainID. The returned group holds the created subgroups
+	// as its children. Roles of the blueprint are not assigned to anyone.
+	ImportGroup(ctx context.Context, token, kind, domainID string, bp Blueprint) (Group, error)
+
 	// UpdateGroup updates the group identified by the provided ID.
 	UpdateGroup(ctx context.Context, token string, g Group) (Group, error)
 
+	// UpdateGroups updates the names, descriptions and metadata of many groups
+	// in a single transaction. If merge is set, the metadata is merged into the
+	// existing one instead of replacing it. The whole batch is rejected if any
+	// group can't be updated, unless partial is set, in which case such groups
+	// are reported per group and the rest are updated.
+	UpdateGroups(ctx context.Context, token string, gs []Group, merge, partial bool) ([]GroupUpdate, error)
+
+	// PatchGroup applies the JSON merge patch to the group identified by id.
+	// The patch is applied to the stored group atomically, so concurrent
+	// updates are never overwritten.
+	PatchGroup(ctx context.Context, token, id string, patch GroupPatch) (Group, error)
+
 	// ViewGroup retrieves data about the group identified by ID.
 	ViewGroup(ctx context.Context, token, id string) (Group, error)
 
@@ -119,12 +317,69 @@ type Service interface {
 	// DisableGroup logically disables the group identified with the provided ID.
 	DisableGroup(ctx context.Context, token, id string) (Group, error)
 
-	// DeleteGroup delete the given group id
-	DeleteGroup(ctx context.Context, token, id string) error
+	// DeleteGroup delete the given group id. Groups with children are
+	// rejected unless cascade is set, in which case the whole subtree is removed.
+	DeleteGroup(ctx context.Context, token, id string, cascade bool) error
+
+	// DeleteGroups removes many groups at once and reports the outcome of
+	// each removal. Groups with children or connected things are not removed
+	// unless cascade is set, in which case their subtrees and connections are
+	// removed with them.
+	DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]GroupRemoval, error)
 
 	// Assign member to group
 	Assign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) (err error)
 
 	// Unassign member from group
 	Unassign(ctx context.Context, token, groupID, relation, memberKind string, memberIDs ...string) (err error)
+
+	// AssignRoles assigns the user identified by memberID to many groups at once.
+	// The whole batch is rejected if any role is invalid, while assignments to
+	// missing groups are reported per assignment.
+	AssignRoles(ctx context.Context, token, memberID string, assignments []RoleAssignment) ([]RoleAssignment, error)
+
+	// MoveThings moves things from the group identified by groupID to the target group.
+	// Both groups must belong to the same domain. Things that are not members of
+	// the source group are reported per thing without aborting the rest.
+	MoveThings(ctx context.Context, token, groupID, targetGroupID string, thingIDs []string) ([]ThingMove, error)
+
+	// ReassignThings moves all the things of the group identified by groupID
+	// to the target group in batches, and returns the IDs of the moved things.
+	// Both groups must belong to the same domain and have the same parent
+	// group. If a batch fails, the things
+	// moved by the previous batches are returned along with the error.
+	ReassignThings(ctx context.Context, token, groupID, targetGroupID string) ([]string, error)
+
+	// AttachThings connects the existing things to the group identified by
+	// groupID. Things connected to other groups are detached from them if
+	// reassign is set, and reported per thing otherwise, as are the things
+	// the user can't edit or connected to groups the user can't edit,
+	// without aborting the rest.
+	AttachThings(ctx context.Context, token, groupID string, thingIDs []string, reassign bool) ([]ThingAttachment, error)
+
+	// ListMemberGroups retrieves the groups the user identified by memberID belongs to,
+	// along with the role held in each. Unless the caller is the member, only groups
+	// the caller administers are listed.
+	ListMemberGroups(ctx context.Context, token, memberID string, pm PageMeta) (MemberGroupsPage, error)
+
+	// ListMemberRoles retrieves the users who are members of the group identified
+	// by groupID, along with the role held and the time of their last activity.
+	// If inactiveSince is set, only members who haven't acted on the group since
+	// then are listed, including the members who have never acted on it.
+	ListMemberRoles(ctx context.Context, token, groupID string, inactiveSince time.Time) ([]MemberRole, error)
+
+	// ListDomainMembers retrieves the members of the domain, each listed once
+	// with the groups they hold a role in and the most privileged role they
+	// hold across them. Only domain administrators can list the members of
+	// the domain.
+	ListDomainMembers(ctx context.Context, token, domainID string, pm PageMeta) (DomainMembersPage, error)
+
+	// RevokeMember removes the roles the member holds in the groups of the
+	// domain and returns the IDs of the groups the member was removed from.
+	// Domain roles are managed by the auth service and kept, so the
+	// revocation reports the domain permission the member keeps access to
+	// the groups through. Revoking a member who holds no role removes
+	// nothing, so it can be repeated safely. Only domain administrators can
+	// revoke members.
+	RevokeMember(ctx context.Context, token, domainID, memberID string) (MemberRevocation, error)
 }
@@ -0,0 +1,45 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"math"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// MaxThingsKey is the group metadata key holding the maximum number of
+// things which can be connected to the channel.
+const MaxThingsKey = "max_things"
+
+var errMaxThings = errors.New("max_things must be a non-negative integer")
+
+// MaxThingsFromMetadata extracts the maximum number of things which can be
+// connected to the channel from the group metadata. Zero means that the
+// number of things is not limited.
+func MaxThingsFromMetadata(m clients.Metadata) (uint64, error) {
+	v, ok := m[MaxThingsKey]
+	if !ok || v == nil {
+		return 0, nil
+	}
+	var f float64
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	case uint64:
+		return n, nil
+	default:
+		return 0, errors.Wrap(errors.ErrMalformedEntity, errMaxThings)
+	}
+	if f < 0 || f != math.Trunc(f) || f > math.MaxInt64 {
+		return 0, errors.Wrap(errors.ErrMalformedEntity, errMaxThings)
+	}
+
+	return uint64(f), nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxThingsFromMetadata(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata clients.Metadata
+		max      uint64
+		err      error
+	}{
+		{
+			desc:     "metadata without limit",
+			metadata: clients.Metadata{"location": "roof"},
+			max:      0,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with decoded JSON limit",
+			metadata: clients.Metadata{groups.MaxThingsKey: float64(10)},
+			max:      10,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with integer limit",
+			metadata: clients.Metadata{groups.MaxThingsKey: 5},
+			max:      5,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with zero limit",
+			metadata: clients.Metadata{groups.MaxThingsKey: float64(0)},
+			max:      0,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with negative limit",
+			metadata: clients.Metadata{groups.MaxThingsKey: float64(-1)},
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with fractional limit",
+			metadata: clients.Metadata{groups.MaxThingsKey: 2.5},
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with string limit",
+			metadata: clients.Metadata{groups.MaxThingsKey: "10"},
+			err:      errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			max, err := groups.MaxThingsFromMetadata(tc.metadata)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.max, max, fmt.Sprintf("expected limit %d got %d", tc.max, max))
+		})
+	}
+}
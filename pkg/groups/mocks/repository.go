@@ -9,6 +9,8 @@ import (
 
 	groups "github.com/absmach/magistrala/pkg/groups"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -80,6 +82,130 @@ func (_m *Repository) Delete(ctx context.Context, groupID string) error {
 	return r0
 }
 
+// DeleteAll provides a mock function with given fields: ctx, groupIDs
+func (_m *Repository) DeleteAll(ctx context.Context, groupIDs ...string) error {
+	ret := _m.Called(ctx, groupIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, groupIDs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Replace provides a mock function with given fields: ctx, g
+func (_m *Repository) Replace(ctx context.Context, g groups.Group) (groups.Group, error) {
+	ret := _m.Called(ctx, g)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replace")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) (groups.Group, error)); ok {
+		return rf(ctx, g)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group) groups.Group); ok {
+		r0 = rf(ctx, g)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, groups.Group) error); ok {
+		r1 = rf(ctx, g)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveThings provides a mock function with given fields: ctx, groupID, n
+func (_m *Repository) ReserveThings(ctx context.Context, groupID string, n uint64) error {
+	ret := _m.Called(ctx, groupID, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveThings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, groupID, n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrieveChildrenIDs provides a mock function with given fields: ctx, parentGroupID
+func (_m *Repository) RetrieveChildrenIDs(ctx context.Context, parentGroupID string) ([]string, error) {
+	ret := _m.Called(ctx, parentGroupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveChildrenIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, parentGroupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, parentGroupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, parentGroupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveActivity provides a mock function with given fields: ctx, groupID
+func (_m *Repository) RetrieveActivity(ctx context.Context, groupID string) (map[string]time.Time, error) {
+	ret := _m.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveActivity")
+	}
+
+	var r0 map[string]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]time.Time, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]time.Time); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, gm
 func (_m *Repository) RetrieveAll(ctx context.Context, gm groups.Page) (groups.Page, error) {
 	ret := _m.Called(ctx, gm)
@@ -164,6 +290,36 @@ func (_m *Repository) RetrieveByIDs(ctx context.Context, gm groups.Page, ids ...
 	return r0, r1
 }
 
+// RetrieveUnsyncedThingCounts provides a mock function with given fields: ctx, limit
+func (_m *Repository) RetrieveUnsyncedThingCounts(ctx context.Context, limit uint64) ([]string, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveUnsyncedThingCounts")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]string, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []string); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, g
 func (_m *Repository) Save(ctx context.Context, g groups.Group) (groups.Group, error) {
 	ret := _m.Called(ctx, g)
@@ -192,6 +348,24 @@ func (_m *Repository) Save(ctx context.Context, g groups.Group) (groups.Group, e
 	return r0, r1
 }
 
+// SaveActivity provides a mock function with given fields: ctx, groupID, memberID, at
+func (_m *Repository) SaveActivity(ctx context.Context, groupID string, memberID string, at time.Time) error {
+	ret := _m.Called(ctx, groupID, memberID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveActivity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, groupID, memberID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnassignParentGroup provides a mock function with given fields: ctx, parentGroupID, groupIDs
 func (_m *Repository) UnassignParentGroup(ctx context.Context, parentGroupID string, groupIDs ...string) error {
 	ret := _m.Called(ctx, parentGroupID, groupIDs)
@@ -238,6 +412,54 @@ func (_m *Repository) Update(ctx context.Context, g groups.Group) (groups.Group,
 	return r0, r1
 }
 
+// UpdateAll provides a mock function with given fields: ctx, gs
+func (_m *Repository) UpdateAll(ctx context.Context, gs ...groups.Group) ([]groups.Group, error) {
+	ret := _m.Called(ctx, gs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAll")
+	}
+
+	var r0 []groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...groups.Group) ([]groups.Group, error)); ok {
+		return rf(ctx, gs...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...groups.Group) []groups.Group); ok {
+		r0 = rf(ctx, gs...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...groups.Group) error); ok {
+		r1 = rf(ctx, gs...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateThingCount provides a mock function with given fields: ctx, groupID, count
+func (_m *Repository) UpdateThingCount(ctx context.Context, groupID string, count uint64) error {
+	ret := _m.Called(ctx, groupID, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateThingCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, groupID, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -9,6 +9,8 @@ import (
 
 	groups "github.com/absmach/magistrala/pkg/groups"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -34,6 +36,94 @@ func (_m *Service) Assign(ctx context.Context, token string, groupID string, rel
 	return r0
 }
 
+// AssignRoles provides a mock function with given fields: ctx, token, memberID, assignments
+func (_m *Service) AssignRoles(ctx context.Context, token string, memberID string, assignments []groups.RoleAssignment) ([]groups.RoleAssignment, error) {
+	ret := _m.Called(ctx, token, memberID, assignments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRoles")
+	}
+
+	var r0 []groups.RoleAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []groups.RoleAssignment) ([]groups.RoleAssignment, error)); ok {
+		return rf(ctx, token, memberID, assignments)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []groups.RoleAssignment) []groups.RoleAssignment); ok {
+		r0 = rf(ctx, token, memberID, assignments)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.RoleAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []groups.RoleAssignment) error); ok {
+		r1 = rf(ctx, token, memberID, assignments)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachThings provides a mock function with given fields: ctx, token, groupID, thingIDs, reassign
+func (_m *Service) AttachThings(ctx context.Context, token string, groupID string, thingIDs []string, reassign bool) ([]groups.ThingAttachment, error) {
+	ret := _m.Called(ctx, token, groupID, thingIDs, reassign)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachThings")
+	}
+
+	var r0 []groups.ThingAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, bool) ([]groups.ThingAttachment, error)); ok {
+		return rf(ctx, token, groupID, thingIDs, reassign)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, bool) []groups.ThingAttachment); ok {
+		r0 = rf(ctx, token, groupID, thingIDs, reassign)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.ThingAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, bool) error); ok {
+		r1 = rf(ctx, token, groupID, thingIDs, reassign)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloneGroup provides a mock function with given fields: ctx, token, kind, id, parentID, name
+func (_m *Service) CloneGroup(ctx context.Context, token string, kind string, id string, parentID string, name string) (groups.Group, error) {
+	ret := _m.Called(ctx, token, kind, id, parentID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneGroup")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (groups.Group, error)); ok {
+		return rf(ctx, token, kind, id, parentID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) groups.Group); ok {
+		r0 = rf(ctx, token, kind, id, parentID, name)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, token, kind, id, parentID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateGroup provides a mock function with given fields: ctx, token, kind, g
 func (_m *Service) CreateGroup(ctx context.Context, token string, kind string, g groups.Group) (groups.Group, error) {
 	ret := _m.Called(ctx, token, kind, g)
@@ -62,17 +152,17 @@ func (_m *Service) CreateGroup(ctx context.Context, token string, kind string, g
 	return r0, r1
 }
 
-// DeleteGroup provides a mock function with given fields: ctx, token, id
-func (_m *Service) DeleteGroup(ctx context.Context, token string, id string) error {
-	ret := _m.Called(ctx, token, id)
+// DeleteGroup provides a mock function with given fields: ctx, token, id, cascade
+func (_m *Service) DeleteGroup(ctx context.Context, token string, id string, cascade bool) error {
+	ret := _m.Called(ctx, token, id, cascade)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteGroup")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, token, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, token, id, cascade)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,6 +170,36 @@ func (_m *Service) DeleteGroup(ctx context.Context, token string, id string) err
 	return r0
 }
 
+// DeleteGroups provides a mock function with given fields: ctx, token, ids, cascade
+func (_m *Service) DeleteGroups(ctx context.Context, token string, ids []string, cascade bool) ([]groups.GroupRemoval, error) {
+	ret := _m.Called(ctx, token, ids, cascade)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroups")
+	}
+
+	var r0 []groups.GroupRemoval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, bool) ([]groups.GroupRemoval, error)); ok {
+		return rf(ctx, token, ids, cascade)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, bool) []groups.GroupRemoval); ok {
+		r0 = rf(ctx, token, ids, cascade)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.GroupRemoval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, bool) error); ok {
+		r1 = rf(ctx, token, ids, cascade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisableGroup provides a mock function with given fields: ctx, token, id
 func (_m *Service) DisableGroup(ctx context.Context, token string, id string) (groups.Group, error) {
 	ret := _m.Called(ctx, token, id)
@@ -136,6 +256,90 @@ func (_m *Service) EnableGroup(ctx context.Context, token string, id string) (gr
 	return r0, r1
 }
 
+// ExportGroup provides a mock function with given fields: ctx, token, id
+func (_m *Service) ExportGroup(ctx context.Context, token string, id string) (groups.Blueprint, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportGroup")
+	}
+
+	var r0 groups.Blueprint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (groups.Blueprint, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) groups.Blueprint); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(groups.Blueprint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportGroup provides a mock function with given fields: ctx, token, kind, domainID, bp
+func (_m *Service) ImportGroup(ctx context.Context, token string, kind string, domainID string, bp groups.Blueprint) (groups.Group, error) {
+	ret := _m.Called(ctx, token, kind, domainID, bp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportGroup")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, groups.Blueprint) (groups.Group, error)); ok {
+		return rf(ctx, token, kind, domainID, bp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, groups.Blueprint) groups.Group); ok {
+		r0 = rf(ctx, token, kind, domainID, bp)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, groups.Blueprint) error); ok {
+		r1 = rf(ctx, token, kind, domainID, bp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDomainMembers provides a mock function with given fields: ctx, token, domainID, pm
+func (_m *Service) ListDomainMembers(ctx context.Context, token string, domainID string, pm groups.PageMeta) (groups.DomainMembersPage, error) {
+	ret := _m.Called(ctx, token, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDomainMembers")
+	}
+
+	var r0 groups.DomainMembersPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, groups.PageMeta) (groups.DomainMembersPage, error)); ok {
+		return rf(ctx, token, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, groups.PageMeta) groups.DomainMembersPage); ok {
+		r0 = rf(ctx, token, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(groups.DomainMembersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, groups.PageMeta) error); ok {
+		r1 = rf(ctx, token, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListGroups provides a mock function with given fields: ctx, token, memberKind, memberID, gm
 func (_m *Service) ListGroups(ctx context.Context, token string, memberKind string, memberID string, gm groups.Page) (groups.Page, error) {
 	ret := _m.Called(ctx, token, memberKind, memberID, gm)
@@ -164,6 +368,64 @@ func (_m *Service) ListGroups(ctx context.Context, token string, memberKind stri
 	return r0, r1
 }
 
+// ListMemberGroups provides a mock function with given fields: ctx, token, memberID, pm
+func (_m *Service) ListMemberGroups(ctx context.Context, token string, memberID string, pm groups.PageMeta) (groups.MemberGroupsPage, error) {
+	ret := _m.Called(ctx, token, memberID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMemberGroups")
+	}
+
+	var r0 groups.MemberGroupsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, groups.PageMeta) (groups.MemberGroupsPage, error)); ok {
+		return rf(ctx, token, memberID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, groups.PageMeta) groups.MemberGroupsPage); ok {
+		r0 = rf(ctx, token, memberID, pm)
+	} else {
+		r0 = ret.Get(0).(groups.MemberGroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, groups.PageMeta) error); ok {
+		r1 = rf(ctx, token, memberID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMemberRoles provides a mock function with given fields: ctx, token, groupID, inactiveSince
+func (_m *Service) ListMemberRoles(ctx context.Context, token string, groupID string, inactiveSince time.Time) ([]groups.MemberRole, error) {
+	ret := _m.Called(ctx, token, groupID, inactiveSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMemberRoles")
+	}
+
+	var r0 []groups.MemberRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]groups.MemberRole, error)); ok {
+		return rf(ctx, token, groupID, inactiveSince)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []groups.MemberRole); ok {
+		r0 = rf(ctx, token, groupID, inactiveSince)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.MemberRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, token, groupID, inactiveSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListMembers provides a mock function with given fields: ctx, token, groupID, permission, memberKind
 func (_m *Service) ListMembers(ctx context.Context, token string, groupID string, permission string, memberKind string) (groups.MembersPage, error) {
 	ret := _m.Called(ctx, token, groupID, permission, memberKind)
@@ -192,6 +454,122 @@ func (_m *Service) ListMembers(ctx context.Context, token string, groupID string
 	return r0, r1
 }
 
+// MoveThings provides a mock function with given fields: ctx, token, groupID, targetGroupID, thingIDs
+func (_m *Service) MoveThings(ctx context.Context, token string, groupID string, targetGroupID string, thingIDs []string) ([]groups.ThingMove, error) {
+	ret := _m.Called(ctx, token, groupID, targetGroupID, thingIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveThings")
+	}
+
+	var r0 []groups.ThingMove
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) ([]groups.ThingMove, error)); ok {
+		return rf(ctx, token, groupID, targetGroupID, thingIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) []groups.ThingMove); ok {
+		r0 = rf(ctx, token, groupID, targetGroupID, thingIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.ThingMove)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
+		r1 = rf(ctx, token, groupID, targetGroupID, thingIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchGroup provides a mock function with given fields: ctx, token, id, patch
+func (_m *Service) PatchGroup(ctx context.Context, token string, id string, patch groups.GroupPatch) (groups.Group, error) {
+	ret := _m.Called(ctx, token, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchGroup")
+	}
+
+	var r0 groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, groups.GroupPatch) (groups.Group, error)); ok {
+		return rf(ctx, token, id, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, groups.GroupPatch) groups.Group); ok {
+		r0 = rf(ctx, token, id, patch)
+	} else {
+		r0 = ret.Get(0).(groups.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, groups.GroupPatch) error); ok {
+		r1 = rf(ctx, token, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignThings provides a mock function with given fields: ctx, token, groupID, targetGroupID
+func (_m *Service) ReassignThings(ctx context.Context, token string, groupID string, targetGroupID string) ([]string, error) {
+	ret := _m.Called(ctx, token, groupID, targetGroupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignThings")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]string, error)); ok {
+		return rf(ctx, token, groupID, targetGroupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []string); ok {
+		r0 = rf(ctx, token, groupID, targetGroupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, groupID, targetGroupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeMember provides a mock function with given fields: ctx, token, domainID, memberID
+func (_m *Service) RevokeMember(ctx context.Context, token string, domainID string, memberID string) (groups.MemberRevocation, error) {
+	ret := _m.Called(ctx, token, domainID, memberID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeMember")
+	}
+
+	var r0 groups.MemberRevocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (groups.MemberRevocation, error)); ok {
+		return rf(ctx, token, domainID, memberID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) groups.MemberRevocation); ok {
+		r0 = rf(ctx, token, domainID, memberID)
+	} else {
+		r0 = ret.Get(0).(groups.MemberRevocation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, domainID, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unassign provides a mock function with given fields: ctx, token, groupID, relation, memberKind, memberIDs
 func (_m *Service) Unassign(ctx context.Context, token string, groupID string, relation string, memberKind string, memberIDs ...string) error {
 	ret := _m.Called(ctx, token, groupID, relation, memberKind, memberIDs)
@@ -238,6 +616,36 @@ func (_m *Service) UpdateGroup(ctx context.Context, token string, g groups.Group
 	return r0, r1
 }
 
+// UpdateGroups provides a mock function with given fields: ctx, token, gs, merge, partial
+func (_m *Service) UpdateGroups(ctx context.Context, token string, gs []groups.Group, merge bool, partial bool) ([]groups.GroupUpdate, error) {
+	ret := _m.Called(ctx, token, gs, merge, partial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroups")
+	}
+
+	var r0 []groups.GroupUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []groups.Group, bool, bool) ([]groups.GroupUpdate, error)); ok {
+		return rf(ctx, token, gs, merge, partial)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []groups.Group, bool, bool) []groups.GroupUpdate); ok {
+		r0 = rf(ctx, token, gs, merge, partial)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]groups.GroupUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []groups.Group, bool, bool) error); ok {
+		r1 = rf(ctx, token, gs, merge, partial)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ViewGroup provides a mock function with given fields: ctx, token, id
 func (_m *Service) ViewGroup(ctx context.Context, token string, id string) (groups.Group, error) {
 	ret := _m.Called(ctx, token, id)
@@ -3,16 +3,44 @@
 
 package groups
 
-import "github.com/absmach/magistrala/pkg/clients"
+import (
+	"time"
+
+	"github.com/absmach/magistrala/pkg/clients"
+)
+
+// Orders in which groups are listed.
+const (
+	CreatedAtOrder  = "created_at"
+	UpdatedAtOrder  = "updated_at"
+	NameOrder       = "name"
+	ThingCountOrder = "thing_count"
+)
+
+// Directions in which groups are ordered.
+const (
+	AscDir  = "asc"
+	DescDir = "desc"
+)
 
 // PageMeta contains page metadata that helps navigation.
 type PageMeta struct {
-	Total    uint64           `json:"total"`
-	Offset   uint64           `json:"offset"`
-	Limit    uint64           `json:"limit"`
-	Name     string           `json:"name,omitempty"`
-	DomainID string           `json:"domain_id,omitempty"`
-	Tag      string           `json:"tag,omitempty"`
-	Metadata clients.Metadata `json:"metadata,omitempty"`
-	Status   clients.Status   `json:"status,omitempty"`
+	Total     uint64           `json:"total"`
+	Offset    uint64           `json:"offset"`
+	Limit     uint64           `json:"limit"`
+	Name      string           `json:"name,omitempty"`
+	DomainID  string           `json:"domain_id,omitempty"`
+	Tag       string           `json:"tag,omitempty"`
+	Metadata  clients.Metadata `json:"metadata,omitempty"`
+	Status    clients.Status   `json:"status,omitempty"`
+	Order     string           `json:"order,omitempty"`
+	Dir       string           `json:"dir,omitempty"`
+	CountOnly bool             `json:"-"`
+	// UpdatedSince limits the page to the groups updated after the given
+	// time, ordered by the update time. Zero value disables the filter.
+	UpdatedSince time.Time `json:"-"`
+	// Fields lists the JSON names of the group fields to retrieve. The
+	// metadata is left out unless listed. Empty list retrieves all the
+	// fields.
+	Fields []string `json:"-"`
 }
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaKey is the group metadata key holding the JSON Schema of the
+// payloads published to the channel.
+const SchemaKey = "schema"
+
+// MaxSchemaSize is the maximum size of the JSON encoded payload schema.
+const MaxSchemaSize = 64 * 1024
+
+// ErrInvalidPayload indicates that the message payload does not match
+// the channel payload schema.
+var ErrInvalidPayload = errors.New("payload does not match the channel schema")
+
+var (
+	errSchema     = errors.New("schema must be a valid JSON Schema")
+	errSchemaSize = errors.New("schema exceeds the maximum size")
+	errSchemaRef  = errors.New("schema can only reference its own definitions")
+)
+
+// Schema validates the payloads of the messages published to a channel.
+type Schema struct {
+	schema *gojsonschema.Schema
+}
+
+// SchemaFromMetadata compiles the payload schema from the group metadata.
+// The returned flag reports whether the metadata contains a payload schema.
+func SchemaFromMetadata(m clients.Metadata) (Schema, bool, error) {
+	v, ok := m[SchemaKey]
+	if !ok {
+		return Schema{}, false, nil
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return Schema{}, true, errors.Wrap(errors.ErrMalformedEntity, errSchema)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Schema{}, true, errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(errSchema, err))
+	}
+	if len(data) > MaxSchemaSize {
+		return Schema{}, true, errors.Wrap(errors.ErrMalformedEntity, errSchemaSize)
+	}
+	// The validator loads referenced schemas from files and URLs, so only
+	// references to the schema itself are allowed.
+	if !localRefs(v) {
+		return Schema{}, true, errors.Wrap(errors.ErrMalformedEntity, errSchemaRef)
+	}
+	s, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(v))
+	if err != nil {
+		return Schema{}, true, errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(errSchema, err))
+	}
+
+	return Schema{schema: s}, true, nil
+}
+
+// localRefs reports whether all the references and identifiers in the
+// schema point into the schema itself. Instance values, such as enum
+// members and defaults, are not schemas and are skipped.
+func localRefs(v interface{}) bool {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			switch k {
+			case "enum", "const", "default", "examples":
+				continue
+			}
+			if s, ok := e.(string); ok && (k == "$ref" || k == "$id" || k == "id") && !strings.HasPrefix(s, "#") {
+				return false
+			}
+			if !localRefs(e) {
+				return false
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if !localRefs(e) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Validate checks that the payload is a JSON document matching the schema.
+// The returned error wraps ErrInvalidPayload with the first violation found.
+func (s Schema) Validate(payload []byte) error {
+	res, err := s.schema.Validate(gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+	if errs := res.Errors(); len(errs) > 0 {
+		return errors.Wrap(ErrInvalidPayload, errors.New(errs[0].String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tempSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"temp"},
+	"properties": map[string]interface{}{
+		"temp": map[string]interface{}{"type": "number"},
+	},
+}
+
+func TestSchemaFromMetadata(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata clients.Metadata
+		ok       bool
+		err      error
+	}{
+		{
+			desc:     "metadata without schema",
+			metadata: clients.Metadata{"location": "roof"},
+			ok:       false,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with valid schema",
+			metadata: clients.Metadata{groups.SchemaKey: tempSchema},
+			ok:       true,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with schema which is not an object",
+			metadata: clients.Metadata{groups.SchemaKey: "number"},
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata with schema referencing own definitions",
+			metadata: clients.Metadata{groups.SchemaKey: map[string]interface{}{
+				"definitions": map[string]interface{}{"temp": map[string]interface{}{"type": "number"}},
+				"properties":  map[string]interface{}{"temp": map[string]interface{}{"$ref": "#/definitions/temp"}},
+				"enum":        []interface{}{map[string]interface{}{"id": "sensor"}},
+			}},
+			ok:  true,
+			err: nil,
+		},
+		{
+			desc: "metadata with schema referencing URL",
+			metadata: clients.Metadata{groups.SchemaKey: map[string]interface{}{
+				"properties": map[string]interface{}{"temp": map[string]interface{}{"$ref": "http://example.com/temp.json"}},
+			}},
+			ok:  true,
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata with schema referencing file",
+			metadata: clients.Metadata{groups.SchemaKey: map[string]interface{}{
+				"allOf": []interface{}{map[string]interface{}{"$ref": "file:///etc/passwd"}},
+			}},
+			ok:  true,
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata with schema with external identifier",
+			metadata: clients.Metadata{groups.SchemaKey: map[string]interface{}{
+				"$id":  "http://example.com/schema.json",
+				"$ref": "#/definitions/temp",
+			}},
+			ok:  true,
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata with too large schema",
+			metadata: clients.Metadata{groups.SchemaKey: map[string]interface{}{
+				"description": strings.Repeat("a", groups.MaxSchemaSize),
+			}},
+			ok:  true,
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with invalid schema",
+			metadata: clients.Metadata{groups.SchemaKey: map[string]interface{}{"type": "temperature"}},
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, ok, err := groups.SchemaFromMetadata(tc.metadata)
+			assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.ok, ok))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema, ok, err := groups.SchemaFromMetadata(clients.Metadata{groups.SchemaKey: tempSchema})
+	require.Nil(t, err, fmt.Sprintf("compile schema: unexpected error %s", err))
+	require.True(t, ok, "compile schema: expected schema")
+
+	cases := []struct {
+		desc    string
+		payload string
+		err     error
+	}{
+		{
+			desc:    "matching payload",
+			payload: `{"temp": 21.5}`,
+			err:     nil,
+		},
+		{
+			desc:    "payload with missing property",
+			payload: `{"humidity": 40}`,
+			err:     groups.ErrInvalidPayload,
+		},
+		{
+			desc:    "payload with property of wrong type",
+			payload: `{"temp": "hot"}`,
+			err:     groups.ErrInvalidPayload,
+		},
+		{
+			desc:    "payload which is not JSON",
+			payload: `temp=21.5`,
+			err:     groups.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := schema.Validate([]byte(tc.payload))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
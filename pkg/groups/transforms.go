@@ -0,0 +1,195 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// TransformsKey is the group metadata key holding the ordered list of
+// transforms applied to the payloads published to the channel.
+const TransformsKey = "transforms"
+
+// Built-in transform types.
+const (
+	RenameTransform = "rename"
+	ScaleTransform  = "scale"
+)
+
+var (
+	errTransforms    = errors.New("transforms must be a list of single key objects")
+	errTransformType = errors.New("unknown transform type")
+	errRenameArgs    = errors.New("rename transform must map field names to field names")
+	errScaleArgs     = errors.New("scale transform requires a field name and a numeric factor")
+)
+
+// TransformFunc transforms a decoded payload object in place. Transforms
+// skip the fields missing from the object.
+type TransformFunc func(obj map[string]interface{})
+
+// TransformFactory builds a transform of the registered type from its
+// arguments, as decoded from the channel metadata.
+type TransformFactory func(args interface{}) (TransformFunc, error)
+
+var transformTypes = struct {
+	sync.RWMutex
+	factories map[string]TransformFactory
+}{
+	factories: map[string]TransformFactory{
+		RenameTransform: newRename,
+		ScaleTransform:  newScale,
+	},
+}
+
+// RegisterTransform registers the factory of the transform type, replacing
+// the factory registered before.
+func RegisterTransform(name string, f TransformFactory) {
+	transformTypes.Lock()
+	defer transformTypes.Unlock()
+	transformTypes.factories[name] = f
+}
+
+// Pipeline applies the transforms of a channel in their order.
+type Pipeline struct {
+	transforms []TransformFunc
+}
+
+// TransformsFromMetadata builds the transform pipeline from the group
+// metadata. Each transform is an object whose only key is the transform
+// type, holding the transform arguments. The returned flag reports whether
+// the metadata contains transforms.
+func TransformsFromMetadata(m clients.Metadata) (Pipeline, bool, error) {
+	v, ok := m[TransformsKey]
+	if !ok || v == nil {
+		return Pipeline{}, false, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return Pipeline{}, true, errors.Wrap(errors.ErrMalformedEntity, errTransforms)
+	}
+
+	p := Pipeline{transforms: make([]TransformFunc, 0, len(list))}
+	for i, item := range list {
+		t, ok := item.(map[string]interface{})
+		if !ok || len(t) != 1 {
+			return Pipeline{}, true, errors.Wrap(errors.ErrMalformedEntity, errTransforms)
+		}
+		for name, args := range t {
+			transformTypes.RLock()
+			factory, ok := transformTypes.factories[name]
+			transformTypes.RUnlock()
+			if !ok {
+				return Pipeline{}, true, errors.Wrap(errors.ErrMalformedEntity, fmt.Errorf("transform %d: %w: %s", i, errTransformType, name))
+			}
+			tf, err := factory(args)
+			if err != nil {
+				return Pipeline{}, true, errors.Wrap(errors.ErrMalformedEntity, fmt.Errorf("transform %d: %w", i, err))
+			}
+			p.transforms = append(p.transforms, tf)
+		}
+	}
+
+	return p, true, nil
+}
+
+// Apply applies the transforms to the decoded payload, which is either an
+// object or a list of objects, such as a SenML pack. Other payloads are
+// returned unchanged.
+func (p Pipeline) Apply(payload interface{}) interface{} {
+	switch v := payload.(type) {
+	case map[string]interface{}:
+		for _, t := range p.transforms {
+			t(v)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if obj, ok := item.(map[string]interface{}); ok {
+				for _, t := range p.transforms {
+					t(obj)
+				}
+			}
+		}
+	}
+
+	return payload
+}
+
+// newRename renames the fields, for example {"t": "temperature"}. All the
+// fields are renamed at once, so the renames don't depend on each other.
+func newRename(args interface{}) (TransformFunc, error) {
+	m, ok := args.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil, errRenameArgs
+	}
+	names := make(map[string]string, len(m))
+	for from, v := range m {
+		to, ok := v.(string)
+		if !ok || from == "" || to == "" {
+			return nil, errRenameArgs
+		}
+		names[from] = to
+	}
+
+	return func(obj map[string]interface{}) {
+		values := make(map[string]interface{}, len(names))
+		for from := range names {
+			if v, ok := obj[from]; ok {
+				values[from] = v
+				delete(obj, from)
+			}
+		}
+		for from, v := range values {
+			obj[names[from]] = v
+		}
+	}, nil
+}
+
+// newScale multiplies the numeric field by the factor, for example
+// {"field": "temperature", "factor": 0.1}.
+func newScale(args interface{}) (TransformFunc, error) {
+	m, ok := args.(map[string]interface{})
+	if !ok {
+		return nil, errScaleArgs
+	}
+	field, ok := m["field"].(string)
+	if !ok || field == "" {
+		return nil, errScaleArgs
+	}
+	factor, ok := toFloat(m["factor"])
+	if !ok {
+		return nil, errScaleArgs
+	}
+
+	return func(obj map[string]interface{}) {
+		if v, ok := toFloat(obj[field]); ok {
+			obj[field] = v * factor
+		}
+	}, nil
+}
+
+// toFloat converts the numbers decoded from JSON or CBOR payloads.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package groups_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transforms(t *testing.T, doc string) clients.Metadata {
+	var m clients.Metadata
+	err := json.Unmarshal([]byte(doc), &m)
+	require.Nil(t, err, fmt.Sprintf("unexpected error while decoding metadata: %s", err))
+
+	return m
+}
+
+func TestTransformsFromMetadata(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata clients.Metadata
+		ok       bool
+		err      error
+	}{
+		{
+			desc:     "metadata without transforms",
+			metadata: clients.Metadata{"location": "roof"},
+			ok:       false,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with valid transforms",
+			metadata: transforms(t, `{"transforms": [{"rename": {"t": "temperature"}}, {"scale": {"field": "temperature", "factor": 0.1}}]}`),
+			ok:       true,
+			err:      nil,
+		},
+		{
+			desc:     "metadata with transforms which are not a list",
+			metadata: transforms(t, `{"transforms": {"rename": {"t": "temperature"}}}`),
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with transform of more than one type",
+			metadata: transforms(t, `{"transforms": [{"rename": {"t": "temperature"}, "scale": {"field": "t", "factor": 2}}]}`),
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with unknown transform type",
+			metadata: transforms(t, `{"transforms": [{"truncate": {"field": "t"}}]}`),
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with rename to non-string name",
+			metadata: transforms(t, `{"transforms": [{"rename": {"t": 1}}]}`),
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "metadata with scale without factor",
+			metadata: transforms(t, `{"transforms": [{"scale": {"field": "t"}}]}`),
+			ok:       true,
+			err:      errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, ok, err := groups.TransformsFromMetadata(tc.metadata)
+			assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected ok %t got %t", tc.desc, tc.ok, ok))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestPipelineApply(t *testing.T) {
+	groups.RegisterTransform("upper", func(args interface{}) (groups.TransformFunc, error) {
+		field, ok := args.(string)
+		if !ok {
+			return nil, errors.New("upper transform requires a field name")
+		}
+		return func(obj map[string]interface{}) {
+			if s, ok := obj[field].(string); ok {
+				obj[field] = strings.ToUpper(s)
+			}
+		}, nil
+	})
+
+	cases := []struct {
+		desc     string
+		metadata string
+		payload  interface{}
+		expected interface{}
+	}{
+		{
+			desc:     "rename followed by scale of the renamed field",
+			metadata: `{"transforms": [{"rename": {"t": "temperature"}}, {"scale": {"field": "temperature", "factor": 0.1}}]}`,
+			payload:  map[string]interface{}{"t": float64(215)},
+			expected: map[string]interface{}{"temperature": float64(21.5)},
+		},
+		{
+			desc:     "scale of the renamed field followed by rename",
+			metadata: `{"transforms": [{"scale": {"field": "temperature", "factor": 0.1}}, {"rename": {"t": "temperature"}}]}`,
+			payload:  map[string]interface{}{"t": float64(215)},
+			expected: map[string]interface{}{"temperature": float64(215)},
+		},
+		{
+			desc:     "swapping renames",
+			metadata: `{"transforms": [{"rename": {"a": "b", "b": "a"}}]}`,
+			payload:  map[string]interface{}{"a": float64(1), "b": float64(2)},
+			expected: map[string]interface{}{"a": float64(2), "b": float64(1)},
+		},
+		{
+			desc:     "transforms of missing fields",
+			metadata: `{"transforms": [{"rename": {"t": "temperature"}}, {"scale": {"field": "humidity", "factor": 0.1}}]}`,
+			payload:  map[string]interface{}{"pressure": float64(1013)},
+			expected: map[string]interface{}{"pressure": float64(1013)},
+		},
+		{
+			desc:     "scale of non-numeric field",
+			metadata: `{"transforms": [{"scale": {"field": "t", "factor": 0.1}}]}`,
+			payload:  map[string]interface{}{"t": "warm"},
+			expected: map[string]interface{}{"t": "warm"},
+		},
+		{
+			desc:     "transforms of list of objects",
+			metadata: `{"transforms": [{"rename": {"v": "value"}}]}`,
+			payload:  []interface{}{map[string]interface{}{"n": "t", "v": float64(1)}, "raw"},
+			expected: []interface{}{map[string]interface{}{"n": "t", "value": float64(1)}, "raw"},
+		},
+		{
+			desc:     "transforms of scalar payload",
+			metadata: `{"transforms": [{"scale": {"field": "t", "factor": 0.1}}]}`,
+			payload:  float64(215),
+			expected: float64(215),
+		},
+		{
+			desc:     "registered transform",
+			metadata: `{"transforms": [{"rename": {"u": "unit"}}, {"upper": "unit"}]}`,
+			payload:  map[string]interface{}{"u": "cel"},
+			expected: map[string]interface{}{"unit": "CEL"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, ok, err := groups.TransformsFromMetadata(transforms(t, tc.metadata))
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			require.True(t, ok, fmt.Sprintf("%s: expected transforms", tc.desc))
+			got := p.Apply(tc.payload)
+			assert.Equal(t, tc.expected, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.expected, got))
+		})
+	}
+}
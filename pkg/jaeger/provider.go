@@ -62,8 +62,10 @@ func NewProvider(ctx context.Context, svcName string, jaegerUrl url.URL, instanc
 	}
 	attributes = append(attributes, hostAttr.Attributes()...)
 
+	// Requests carrying a W3C traceparent header continue the trace of the
+	// caller, following its sampling decision so the trace stays complete.
 	tp := trace.NewTracerProvider(
-		trace.WithSampler(trace.TraceIDRatioBased(fraction)),
+		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(fraction))),
 		trace.WithBatcher(exporter),
 		trace.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -6,6 +6,7 @@ package nats
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/messaging"
@@ -23,6 +24,10 @@ const (
 	// reconnectBufSize is obtained from the maximum number of unpublished events
 	// multiplied by the approximate maximum size of a single event.
 	reconnectBufSize = events.MaxUnpublishedEvents * (1024 * 1024)
+
+	// closeTimeout bounds the time Close waits for the acknowledgements of
+	// the asynchronous publishes.
+	closeTimeout = 5 * time.Second
 )
 
 var _ messaging.Publisher = (*publisher)(nil)
@@ -77,12 +82,20 @@ func (pub *publisher) Publish(ctx context.Context, topic string, msg *messaging.
 		subject = fmt.Sprintf("%s.%s", subject, msg.GetSubtopic())
 	}
 
+	if messaging.IsAsyncPublish(ctx) {
+		_, err = pub.js.PublishAsync(subject, data)
+		return err
+	}
 	_, err = pub.js.Publish(ctx, subject, data)
 
 	return err
 }
 
 func (pub *publisher) Close() error {
+	select {
+	case <-pub.js.PublishAsyncComplete():
+	case <-time.After(closeTimeout):
+	}
 	pub.conn.Close()
 	return nil
 }
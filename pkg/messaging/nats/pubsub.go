@@ -58,7 +58,9 @@ func NewPubSub(ctx context.Context, url string, logger *slog.Logger, opts ...mes
 	if err != nil {
 		return nil, err
 	}
-	js, err := jetstream.New(conn)
+	js, err := jetstream.New(conn, jetstream.WithPublishAsyncErrHandler(func(_ jetstream.JetStream, msg *broker.Msg, err error) {
+		logger.Warn(fmt.Sprintf("Failed to publish message to %s: %s", msg.Subject, err))
+	}))
 	if err != nil {
 		return nil, err
 	}
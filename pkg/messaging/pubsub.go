@@ -25,6 +25,22 @@ type Publisher interface {
 	Close() error
 }
 
+type asyncPublishKey struct{}
+
+// WithAsyncPublish returns a context in which publishers don't wait for the
+// broker to acknowledge the published message, if the broker supports it.
+// Failures to persist the message are then not reported to the caller.
+func WithAsyncPublish(ctx context.Context) context.Context {
+	return context.WithValue(ctx, asyncPublishKey{}, true)
+}
+
+// IsAsyncPublish reports whether the publish in the context doesn't wait
+// for the broker acknowledgement.
+func IsAsyncPublish(ctx context.Context) bool {
+	async, _ := ctx.Value(asyncPublishKey{}).(bool)
+	return async
+}
+
 // MessageHandler represents Message handler for Subscriber.
 type MessageHandler interface {
 	// Handle handles messages passed by underlying implementation.
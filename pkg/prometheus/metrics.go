@@ -29,3 +29,30 @@ func MakeMetrics(namespace, subsystem string) (*kitprometheus.Counter, *kitprome
 
 	return counter, latency
 }
+
+// MakeCacheMetrics returns an instance of Prometheus implementation of a
+// counter of cache lookups, labeled by the cache type, the lookup method and
+// its result.
+//
+//	lookups := metrics.MakeCacheMetrics("demo-service")
+func MakeCacheMetrics(namespace string) *kitprometheus.Counter {
+	return kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookup_count",
+		Help:      "Number of cache lookups by result.",
+	}, []string{"cache", "method", "result"})
+}
+
+// MakeGauge returns an instance of Prometheus implementation of a gauge
+// without labels.
+//
+//	state := metrics.MakeGauge("demo-service", "auth_client", "circuit_breaker_state", "State of the circuit breaker.")
+func MakeGauge(namespace, subsystem, name, help string) *kitprometheus.Gauge {
+	return kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, []string{})
+}
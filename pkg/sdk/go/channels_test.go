@@ -39,12 +39,12 @@ func setupChannels() (*httptest.Server, *mocks.Repository, *authmocks.AuthClient
 	thingCache := new(thmocks.Cache)
 
 	auth := new(authmocks.AuthClient)
-	csvc := things.NewService(auth, cRepo, grepo, thingCache, idProvider)
+	csvc := things.NewService(auth, cRepo, grepo, thingCache, new(thmocks.Watcher), idProvider, mgclients.KeyPolicy{})
 	gsvc := groups.NewService(grepo, idProvider, auth)
 
 	logger := mglog.NewMock()
 	mux := chi.NewRouter()
-	api.MakeHandler(csvc, gsvc, mux, logger, "")
+	api.MakeHandler(csvc, gsvc, nil, handlerConfig, mux, logger, nil)
 
 	return httptest.NewServer(mux), grepo, auth
 }
@@ -137,11 +137,13 @@ func TestCreateChannel(t *testing.T) {
 		},
 	}
 	for _, tc := range cases {
-		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)}, nil)
+		domainID := testsutil.GenerateUUID(t)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: validID, DomainId: domainID}, nil)
 		authCall1 := auth.On("AddPolicies", mock.Anything, mock.Anything).Return(&magistrala.AddPoliciesRes{Added: true}, nil)
 		authCall2 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
 		authCall3 := auth.On("DeletePolicies", mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: false}, nil)
 		repoCall := grepo.On("Save", mock.Anything, mock.Anything).Return(convertChannel(sdk.Channel{}), tc.err)
+		repoCall1 := grepo.On("RetrieveByID", mock.Anything, tc.channel.ParentID).Return(mggroups.Group{ID: tc.channel.ParentID, Domain: domainID}, nil)
 		rChannel, err := mgsdk.CreateChannel(tc.channel, validToken)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		if err == nil {
@@ -154,6 +156,7 @@ func TestCreateChannel(t *testing.T) {
 		authCall2.Unset()
 		authCall3.Unset()
 		repoCall.Unset()
+		repoCall1.Unset()
 	}
 }
 
@@ -774,15 +777,19 @@ func TestDeleteChannel(t *testing.T) {
 	authCall = auth.On("DeleteEntityPolicies", mock.Anything, mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
 	authCall1 = auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)}, nil)
 	authCall2 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
-	repoCall = grepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	repoCall = grepo.On("RetrieveChildrenIDs", mock.Anything, channel.ID).Return([]string{}, nil)
+	repoCall1 := grepo.On("RetrieveByID", mock.Anything, channel.ID).Return(convertChannel(channel), nil)
+	repoCall2 := grepo.On("DeleteAll", mock.Anything, []string{channel.ID}).Return(nil)
 	err = mgsdk.DeleteChannel(channel.ID, validToken)
 	assert.Nil(t, err, fmt.Sprintf("Delete channel with correct id: expected %v got %v", nil, err))
-	ok := repoCall.Parent.AssertCalled(t, "Delete", mock.Anything, channel.ID)
-	assert.True(t, ok, "Delete was not called on deleting channel with correct id")
+	ok := repoCall2.Parent.AssertCalled(t, "DeleteAll", mock.Anything, []string{channel.ID})
+	assert.True(t, ok, "DeleteAll was not called on deleting channel with correct id")
 	authCall.Unset()
 	authCall1.Unset()
 	authCall2.Unset()
 	repoCall.Unset()
+	repoCall1.Unset()
+	repoCall2.Unset()
 }
 
 func toIDs(objects interface{}) []string {
@@ -44,7 +44,7 @@ func setupGroups() (*httptest.Server, *mocks.Service) {
 	mux := chi.NewRouter()
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
-	api.MakeHandler(usvc, gsvc, mux, logger, "", passRegex, provider)
+	api.MakeHandler(usvc, gsvc, pageLimits, mux, logger, "", passRegex, provider)
 
 	return httptest.NewServer(mux), gsvc
 }
@@ -1616,11 +1616,11 @@ func TestDeleteGroup(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := gsvc.On("DeleteGroup", mock.Anything, tc.token, tc.groupID).Return(tc.svcErr)
+			svcCall := gsvc.On("DeleteGroup", mock.Anything, tc.token, tc.groupID, false).Return(tc.svcErr)
 			err := mgsdk.DeleteGroup(tc.groupID, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "DeleteGroup", mock.Anything, tc.token, tc.groupID)
+				ok := svcCall.Parent.AssertCalled(t, "DeleteGroup", mock.Anything, tc.token, tc.groupID, false)
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -8,11 +8,14 @@ import (
 	"os"
 	"regexp"
 	"testing"
+	"time"
 
+	mgapi "github.com/absmach/magistrala/internal/api"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	mggroups "github.com/absmach/magistrala/pkg/groups"
 	sdk "github.com/absmach/magistrala/pkg/sdk/go"
 	"github.com/absmach/magistrala/pkg/uuid"
+	thingsapi "github.com/absmach/magistrala/things/api/http"
 	"github.com/absmach/magistrala/users/hasher"
 	umocks "github.com/absmach/magistrala/users/mocks"
 	"github.com/stretchr/testify/assert"
@@ -25,10 +28,24 @@ const (
 	token           = "token"
 	invalidToken    = "invalid"
 	contentType     = "application/senml+json"
+	maxViewIDs      = 100
+	maxMetadataSize = 64 * 1024
+	maxListWait     = time.Second
 )
 
 var (
 	idProvider    = uuid.New()
+	bodyLimits    = mgapi.BodyLimits{Entity: 1024 * 1024, Bulk: 16 * 1024 * 1024}
+	pageLimits    = mgapi.PageLimits{Default: mgapi.DefLimit, Max: mgapi.MaxLimitSize}
+	filterLimits  = mgclients.FilterLimits{MaxDepth: 5, MaxNodes: 50}
+	handlerConfig = thingsapi.Config{
+		MaxViewIDs:      maxViewIDs,
+		MaxMetadataSize: maxMetadataSize,
+		MaxListWait:     maxListWait,
+		BodyLimits:      bodyLimits,
+		PageLimits:      pageLimits,
+		FilterLimits:    filterLimits,
+	}
 	phasher       = hasher.New()
 	validMetadata = sdk.Metadata{"role": "client"}
 	user          = sdk.User{
@@ -185,7 +202,7 @@ func convertClient(c sdk.User) mgclients.Client {
 		Name:        c.Name,
 		Tags:        c.Tags,
 		Domain:      c.Domain,
-		Credentials: mgclients.Credentials(c.Credentials),
+		Credentials: mgclients.Credentials{Identity: c.Credentials.Identity, Secret: c.Credentials.Secret},
 		Metadata:    mgclients.Metadata(c.Metadata),
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
@@ -206,7 +223,7 @@ func convertThing(c sdk.Thing) mgclients.Client {
 		Name:        c.Name,
 		Tags:        c.Tags,
 		Domain:      c.DomainID,
-		Credentials: mgclients.Credentials(c.Credentials),
+		Credentials: mgclients.Credentials{Identity: c.Credentials.Identity, Secret: c.Credentials.Secret},
 		Metadata:    mgclients.Metadata(c.Metadata),
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
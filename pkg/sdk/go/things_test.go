@@ -35,12 +35,12 @@ func setupThings() (*httptest.Server, *mocks.Repository, *gmocks.Repository, *au
 	thingCache := new(mocks.Cache)
 
 	auth := new(authmocks.AuthClient)
-	csvc := things.NewService(auth, cRepo, gRepo, thingCache, idProvider)
+	csvc := things.NewService(auth, cRepo, gRepo, thingCache, new(mocks.Watcher), idProvider, mgclients.KeyPolicy{})
 	gsvc := groups.NewService(gRepo, idProvider, auth)
 
 	logger := mglog.NewMock()
 	mux := chi.NewRouter()
-	api.MakeHandler(csvc, gsvc, mux, logger, "")
+	api.MakeHandler(csvc, gsvc, nil, handlerConfig, mux, logger, nil)
 
 	return httptest.NewServer(mux), cRepo, gRepo, auth, thingCache
 }
@@ -51,12 +51,12 @@ func setupThingsMinimal() (*httptest.Server, *authmocks.AuthClient) {
 	thingCache := new(mocks.Cache)
 
 	auth := new(authmocks.AuthClient)
-	csvc := things.NewService(auth, cRepo, gRepo, thingCache, idProvider)
+	csvc := things.NewService(auth, cRepo, gRepo, thingCache, new(mocks.Watcher), idProvider, mgclients.KeyPolicy{})
 	gsvc := groups.NewService(gRepo, idProvider, auth)
 
 	logger := mglog.NewMock()
 	mux := chi.NewRouter()
-	api.MakeHandler(csvc, gsvc, mux, logger, "")
+	api.MakeHandler(csvc, gsvc, nil, handlerConfig, mux, logger, nil)
 
 	return httptest.NewServer(mux), auth
 }
@@ -850,7 +850,7 @@ func TestUpdateThingTags(t *testing.T) {
 }
 
 func TestUpdateThingSecret(t *testing.T) {
-	ts, cRepo, _, auth, _ := setupThings()
+	ts, cRepo, _, auth, cache := setupThings()
 	defer ts.Close()
 
 	conf := sdk.Config{
@@ -906,6 +906,8 @@ func TestUpdateThingSecret(t *testing.T) {
 			repoCall1 = auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: false}, svcerr.ErrAuthorization)
 		}
 		repoCall2 := cRepo.On("UpdateSecret", mock.Anything, mock.Anything).Return(convertThing(tc.response), tc.repoErr)
+		cacheCall := cache.On("Key", mock.Anything, mock.Anything).Return(tc.oldSecret, nil)
+		cacheCall1 := cache.On("RemoveKey", mock.Anything, tc.oldSecret).Return(nil)
 		uClient, err := mgsdk.UpdateThingSecret(tc.oldSecret, tc.newSecret, tc.token)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, uClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, uClient))
@@ -916,11 +918,13 @@ func TestUpdateThingSecret(t *testing.T) {
 		repoCall2.Unset()
 		repoCall.Unset()
 		repoCall1.Unset()
+		cacheCall.Unset()
+		cacheCall1.Unset()
 	}
 }
 
 func TestEnableThing(t *testing.T) {
-	ts, cRepo, _, auth, _ := setupThings()
+	ts, cRepo, _, auth, cache := setupThings()
 	defer ts.Close()
 
 	conf := sdk.Config{
@@ -980,6 +984,7 @@ func TestEnableThing(t *testing.T) {
 		}
 		repoCall2 := cRepo.On("RetrieveByID", mock.Anything, tc.id).Return(convertThing(tc.thing), tc.repoErr)
 		repoCall3 := cRepo.On("ChangeStatus", mock.Anything, mock.Anything).Return(convertThing(tc.response), tc.repoErr)
+		repoCall4 := cache.On("Enable", mock.Anything, mock.Anything).Return(nil)
 		eClient, err := mgsdk.EnableThing(tc.id, tc.token)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, eClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, eClient))
@@ -993,6 +998,7 @@ func TestEnableThing(t *testing.T) {
 		repoCall1.Unset()
 		repoCall2.Unset()
 		repoCall3.Unset()
+		repoCall4.Unset()
 	}
 
 	cases2 := []struct {
@@ -1115,7 +1121,7 @@ func TestDisableThing(t *testing.T) {
 		}
 		repoCall2 := cRepo.On("RetrieveByID", mock.Anything, tc.id).Return(convertThing(tc.thing), tc.repoErr)
 		repoCall3 := cRepo.On("ChangeStatus", mock.Anything, mock.Anything).Return(convertThing(tc.response), tc.repoErr)
-		repoCall4 := cache.On("Remove", mock.Anything, mock.Anything).Return(nil)
+		repoCall4 := cache.On("Disable", mock.Anything, mock.Anything).Return(nil)
 		dThing, err := mgsdk.DisableThing(tc.id, tc.token)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, dThing, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, dThing))
@@ -1285,7 +1291,9 @@ func TestDeleteThing(t *testing.T) {
 	authCall = auth.On("DeleteEntityPolicies", mock.Anything, mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
 	authCall1 = auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)}, nil)
 	authCall2 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	authCall3 := auth.On("ListAllSubjects", mock.Anything, mock.Anything).Return(&magistrala.ListSubjectsRes{}, nil)
 	repoCall = cRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	repoCall1 := cRepo.On("RetrieveByID", mock.Anything, thing.ID).Return(convertThing(thing), nil)
 	err = mgsdk.DeleteThing(thing.ID, validToken)
 	assert.Nil(t, err, fmt.Sprintf("Delete thing with correct id: expected %v got %v", nil, err))
 	ok := repoCall.Parent.AssertCalled(t, "Delete", mock.Anything, thing.ID)
@@ -1293,6 +1301,8 @@ func TestDeleteThing(t *testing.T) {
 	authCall.Unset()
 	authCall1.Unset()
 	authCall2.Unset()
+	authCall3.Unset()
 	repoCall.Unset()
+	repoCall1.Unset()
 	cacheCall.Unset()
 }
@@ -10,23 +10,37 @@ import (
 	"time"
 
 	"github.com/absmach/magistrala/pkg/server"
-	gocoap "github.com/plgd-dev/go-coap/v3"
 	"github.com/plgd-dev/go-coap/v3/mux"
+	coapnet "github.com/plgd-dev/go-coap/v3/net"
+	"github.com/plgd-dev/go-coap/v3/options"
+	"github.com/plgd-dev/go-coap/v3/udp"
 )
 
+// nStart is the number of simultaneous outstanding interactions with a peer, as defined in RFC 7252.
+const nStart = 1
+
+// TransmissionConfig contains the parameters used to retransmit confirmable messages.
+// Defaults match the values defined in RFC 7252.
+type TransmissionConfig struct {
+	AckTimeout    time.Duration `env:"ACK_TIMEOUT"    envDefault:"2s"`
+	MaxRetransmit uint32        `env:"MAX_RETRANSMIT" envDefault:"4"`
+}
+
 type coapServer struct {
 	server.BaseServer
-	handler mux.HandlerFunc
+	handler      mux.HandlerFunc
+	transmission TransmissionConfig
 }
 
 var _ server.Server = (*coapServer)(nil)
 
-func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, handler mux.HandlerFunc, logger *slog.Logger) server.Server {
+func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, transmission TransmissionConfig, handler mux.HandlerFunc, logger *slog.Logger) server.Server {
 	baseServer := server.NewBaseServer(ctx, cancel, name, config, logger)
 
 	return &coapServer{
-		BaseServer: baseServer,
-		handler:    handler,
+		BaseServer:   baseServer,
+		handler:      handler,
+		transmission: transmission,
 	}
 }
 
@@ -34,9 +48,21 @@ func (s *coapServer) Start() error {
 	errCh := make(chan error)
 	s.Logger.Info(fmt.Sprintf("%s service started using http, exposed port %s", s.Name, s.Address))
 	s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s without TLS", s.Name, s.Protocol, s.Address))
+	s.Logger.Info(fmt.Sprintf("%s service retransmits confirmable messages up to %d times, after an ACK timeout of %s", s.Name, s.transmission.MaxRetransmit, s.transmission.AckTimeout))
 
 	go func() {
-		errCh <- gocoap.ListenAndServe("udp", s.Address, s.handler)
+		l, err := coapnet.NewListenUDP("udp", s.Address)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer l.Close()
+
+		srv := udp.NewServer(
+			options.WithMux(s.handler),
+			options.WithTransmission(nStart, s.transmission.AckTimeout, s.transmission.MaxRetransmit),
+		)
+		errCh <- srv.Serve(l)
 	}()
 
 	select {
@@ -0,0 +1,314 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/audit"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things"
+)
+
+const (
+	thingEntity   = "thing"
+	channelEntity = "channel"
+	tokenEntity   = "scoped_token"
+)
+
+var (
+	_ things.Service   = (*auditMiddleware)(nil)
+	_ audit.Identifier = (*auditIdentifier)(nil)
+)
+
+// TokenRepository retrieves the scoped tokens by the hashes of their values.
+type TokenRepository interface {
+	RetrieveToken(ctx context.Context, tokenHash string) (mgclients.ScopedToken, error)
+}
+
+type auditIdentifier struct {
+	auth   audit.Identifier
+	tokens TokenRepository
+}
+
+// NewAuditIdentifier returns the audit identifier which resolves the scoped
+// tokens using the tokens repository, since the auth service doesn't know
+// them, and the other tokens using the auth service.
+func NewAuditIdentifier(authClient magistrala.AuthServiceClient, tokens TokenRepository) audit.Identifier {
+	return &auditIdentifier{
+		auth:   audit.NewAuthIdentifier(authClient),
+		tokens: tokens,
+	}
+}
+
+func (ai *auditIdentifier) Identify(ctx context.Context, token string) (audit.Subject, error) {
+	if !mgclients.IsScopedToken(token) {
+		return ai.auth.Identify(ctx, token)
+	}
+	st, err := ai.tokens.RetrieveToken(ctx, mgclients.HashToken(token))
+	if err != nil {
+		return audit.Subject{}, err
+	}
+	if st.Expired() {
+		return audit.Subject{}, svcerr.ErrKeyExpired
+	}
+
+	return audit.Subject{ID: st.UserID, Domain: st.DomainID}, nil
+}
+
+type auditMiddleware struct {
+	audit *audit.Logger
+	svc   things.Service
+}
+
+// AuditMiddleware adds structured audit logging facilities to the things service.
+func AuditMiddleware(svc things.Service, audit *audit.Logger) things.Service {
+	return &auditMiddleware{audit, svc}
+}
+
+func (am *auditMiddleware) CreateThings(ctx context.Context, token string, clients ...mgclients.Client) ([]mgclients.Client, error) {
+	cs, err := am.svc.CreateThings(ctx, token, clients...)
+	if err != nil {
+		am.audit.Write(ctx, token, "create_thing", thingEntity, "", err)
+		return cs, err
+	}
+	entries := make([]audit.Entry, len(cs))
+	for i, c := range cs {
+		entries[i] = audit.Entry{EntityID: c.ID}
+	}
+	am.audit.WriteEntries(ctx, token, "create_thing", thingEntity, entries...)
+
+	return cs, nil
+}
+
+func (am *auditMiddleware) ViewClient(ctx context.Context, token, id string) (mgclients.Client, error) {
+	c, err := am.svc.ViewClient(ctx, token, id)
+	am.audit.Read(ctx, token, "view_thing", thingEntity, id, err)
+
+	return c, err
+}
+
+func (am *auditMiddleware) ViewClients(ctx context.Context, token string, ids ...string) ([]things.ViewResult, error) {
+	rs, err := am.svc.ViewClients(ctx, token, ids...)
+	if err != nil {
+		am.audit.Read(ctx, token, "view_thing", thingEntity, "", err)
+		return rs, err
+	}
+	entries := make([]audit.Entry, len(rs))
+	for i, r := range rs {
+		entries[i] = audit.Entry{EntityID: r.ID, Err: r.Err}
+	}
+	am.audit.ReadEntries(ctx, token, "view_thing", thingEntity, entries...)
+
+	return rs, nil
+}
+
+func (am *auditMiddleware) ViewClientPerms(ctx context.Context, token, id string) ([]string, error) {
+	p, err := am.svc.ViewClientPerms(ctx, token, id)
+	am.audit.Read(ctx, token, "view_thing_permissions", thingEntity, id, err)
+
+	return p, err
+}
+
+func (am *auditMiddleware) ListClients(ctx context.Context, token, reqUserID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	cp, err := am.svc.ListClients(ctx, token, reqUserID, pm)
+	am.audit.Read(ctx, token, "list_things", thingEntity, "", err)
+
+	return cp, err
+}
+
+func (am *auditMiddleware) ListClientsByGroup(ctx context.Context, token, channelID string, pm mgclients.Page) (mgclients.MembersPage, error) {
+	mp, err := am.svc.ListClientsByGroup(ctx, token, channelID, pm)
+	am.audit.Read(ctx, token, "list_things_by_channel", thingEntity, "", err)
+
+	return mp, err
+}
+
+func (am *auditMiddleware) ListOrphanedClients(ctx context.Context, token string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	cp, err := am.svc.ListOrphanedClients(ctx, token, pm)
+	am.audit.Read(ctx, token, "list_orphaned_things", thingEntity, "", err)
+
+	return cp, err
+}
+
+func (am *auditMiddleware) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]mggroups.Group, error) {
+	chs, err := am.svc.ListThingsChannels(ctx, token, thingIDs...)
+	am.audit.Read(ctx, token, "list_things_channels", thingEntity, "", err)
+
+	return chs, err
+}
+
+func (am *auditMiddleware) ExportSubtree(ctx context.Context, token, groupID string, pm things.SubtreePageMeta, keys bool) (things.Subtree, error) {
+	st, err := am.svc.ExportSubtree(ctx, token, groupID, pm, keys)
+	am.audit.Read(ctx, token, "export_subtree", channelEntity, groupID, err)
+
+	return st, err
+}
+
+func (am *auditMiddleware) AggregateMetadata(ctx context.Context, token, field string, status mgclients.Status) ([]mgclients.MetadataAggregate, error) {
+	aggs, err := am.svc.AggregateMetadata(ctx, token, field, status)
+	am.audit.Read(ctx, token, "aggregate_things", thingEntity, "", err)
+
+	return aggs, err
+}
+
+func (am *auditMiddleware) UpdateClient(ctx context.Context, token string, client mgclients.Client, merge bool) (mgclients.Client, error) {
+	c, err := am.svc.UpdateClient(ctx, token, client, merge)
+	am.audit.Write(ctx, token, "update_thing", thingEntity, client.ID, err)
+
+	return c, err
+}
+
+func (am *auditMiddleware) UpdateClientTags(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
+	c, err := am.svc.UpdateClientTags(ctx, token, client)
+	am.audit.Write(ctx, token, "update_thing_tags", thingEntity, client.ID, err)
+
+	return c, err
+}
+
+func (am *auditMiddleware) UpdateClientSecret(ctx context.Context, token, id, key string, ttl time.Duration) (mgclients.Client, error) {
+	c, err := am.svc.UpdateClientSecret(ctx, token, id, key, ttl)
+	am.audit.Write(ctx, token, "update_thing_secret", thingEntity, id, err)
+
+	return c, err
+}
+
+func (am *auditMiddleware) RotateKeys(ctx context.Context, token, groupID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	cp, err := am.svc.RotateKeys(ctx, token, groupID, pm)
+	am.audit.Write(ctx, token, "rotate_thing_keys", channelEntity, groupID, err)
+
+	return cp, err
+}
+
+func (am *auditMiddleware) TransferChannel(ctx context.Context, token, groupID, domainID string) (things.ChannelTransfer, error) {
+	ct, err := am.svc.TransferChannel(ctx, token, groupID, domainID)
+	am.audit.Write(ctx, token, "transfer_channel", channelEntity, groupID, err)
+
+	return ct, err
+}
+
+func (am *auditMiddleware) EnableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
+	c, err := am.svc.EnableClient(ctx, token, id)
+	am.audit.Write(ctx, token, "enable_thing", thingEntity, id, err)
+
+	return c, err
+}
+
+func (am *auditMiddleware) DisableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
+	c, err := am.svc.DisableClient(ctx, token, id)
+	am.audit.Write(ctx, token, "disable_thing", thingEntity, id, err)
+
+	return c, err
+}
+
+func (am *auditMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) error {
+	err := am.svc.Share(ctx, token, id, relation, userids...)
+	am.audit.Write(ctx, token, "share_thing", thingEntity, id, err)
+
+	return err
+}
+
+func (am *auditMiddleware) Unshare(ctx context.Context, token, id, relation string, userids ...string) error {
+	err := am.svc.Unshare(ctx, token, id, relation, userids...)
+	am.audit.Write(ctx, token, "unshare_thing", thingEntity, id, err)
+
+	return err
+}
+
+func (am *auditMiddleware) DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (mgclients.Client, error) {
+	c, err := am.svc.DeleteClient(ctx, token, id, unmodifiedSince)
+	am.audit.Write(ctx, token, "delete_thing", thingEntity, id, err)
+
+	return c, err
+}
+
+// Identify and Authorize are called with thing keys on every message, so
+// they are not audited.
+func (am *auditMiddleware) Identify(ctx context.Context, key string) (string, error) {
+	return am.svc.Identify(ctx, key)
+}
+
+// Heartbeat is called with thing keys as often as the things publish, so it
+// is not audited either.
+func (am *auditMiddleware) Heartbeat(ctx context.Context, key, id string) error {
+	return am.svc.Heartbeat(ctx, key, id)
+}
+
+// ValidateKey is read-only and meant to be called before every connection,
+// so it is not audited either.
+func (am *auditMiddleware) ValidateKey(ctx context.Context, key string) (things.KeyValidation, error) {
+	return am.svc.ValidateKey(ctx, key)
+}
+
+func (am *auditMiddleware) IdentifyCert(ctx context.Context, fingerprint, subject string) (string, error) {
+	return am.svc.IdentifyCert(ctx, fingerprint, subject)
+}
+
+func (am *auditMiddleware) BindCert(ctx context.Context, token string, cert mgclients.CertBinding) (mgclients.CertBinding, error) {
+	cb, err := am.svc.BindCert(ctx, token, cert)
+	am.audit.Write(ctx, token, "bind_cert", thingEntity, cert.ThingID, err)
+
+	return cb, err
+}
+
+func (am *auditMiddleware) UnbindCert(ctx context.Context, token, thingID, fingerprint string) error {
+	err := am.svc.UnbindCert(ctx, token, thingID, fingerprint)
+	am.audit.Write(ctx, token, "unbind_cert", thingEntity, thingID, err)
+
+	return err
+}
+
+func (am *auditMiddleware) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (string, error) {
+	return am.svc.Authorize(ctx, req)
+}
+
+func (am *auditMiddleware) RetrieveMetadata(ctx context.Context, entityType, id string) (mgclients.Metadata, mgclients.Status, error) {
+	return am.svc.RetrieveMetadata(ctx, entityType, id)
+}
+
+func (am *auditMiddleware) WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (<-chan things.ThingEvent, error) {
+	ch, err := am.svc.WatchThings(ctx, token, domainID, lastSeq)
+	am.audit.Read(ctx, token, "watch_things", thingEntity, "", err)
+
+	return ch, err
+}
+
+func (am *auditMiddleware) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (mgclients.ScopedToken, error) {
+	st, err := am.svc.IssueToken(ctx, token, scopes, ttl)
+	am.audit.Write(ctx, token, "issue_token", tokenEntity, st.ID, err)
+
+	return st, err
+}
+
+func (am *auditMiddleware) ListTokens(ctx context.Context, token string, pm mgclients.Page) (mgclients.ScopedTokensPage, error) {
+	tp, err := am.svc.ListTokens(ctx, token, pm)
+	am.audit.Read(ctx, token, "list_tokens", tokenEntity, "", err)
+
+	return tp, err
+}
+
+func (am *auditMiddleware) RevokeToken(ctx context.Context, token, id string) error {
+	err := am.svc.RevokeToken(ctx, token, id)
+	am.audit.Write(ctx, token, "revoke_token", tokenEntity, id, err)
+
+	return err
+}
+
+func (am *auditMiddleware) ReconcileCache(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	rec, err := am.svc.ReconcileCache(ctx, token)
+	am.audit.Write(ctx, token, "reconcile_cache", thingEntity, "", err)
+
+	return rec, err
+}
+
+func (am *auditMiddleware) ViewCacheReconciliation(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	rec, err := am.svc.ViewCacheReconciliation(ctx, token)
+	am.audit.Read(ctx, token, "view_cache_reconciliation", thingEntity, "", err)
+
+	return rec, err
+}
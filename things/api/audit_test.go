@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/internal/audit"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/things/api"
+	"github.com/absmach/magistrala/things/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditIdentifier(t *testing.T) {
+	const (
+		userID   = "user"
+		domainID = "domain"
+		token    = "token"
+		scoped   = mgclients.ScopedTokenPrefix + "token"
+	)
+	expired := time.Now().Add(-time.Minute)
+
+	cases := []struct {
+		desc        string
+		token       string
+		scopedToken mgclients.ScopedToken
+		retrieveErr error
+		subject     audit.Subject
+		err         error
+	}{
+		{
+			desc:    "identify bearer token",
+			token:   token,
+			subject: audit.Subject{ID: userID, Domain: domainID},
+		},
+		{
+			desc:        "identify scoped token",
+			token:       scoped,
+			scopedToken: mgclients.ScopedToken{UserID: userID, DomainID: domainID},
+			subject:     audit.Subject{ID: userID, Domain: domainID},
+		},
+		{
+			desc:        "identify expired scoped token",
+			token:       scoped,
+			scopedToken: mgclients.ScopedToken{UserID: userID, DomainID: domainID, ExpiresAt: &expired},
+			err:         svcerr.ErrKeyExpired,
+		},
+		{
+			desc:        "identify unknown scoped token",
+			token:       scoped,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			repo := new(mocks.Repository)
+			auth.On("Identify", context.Background(), &magistrala.IdentityReq{Token: token}).Return(&magistrala.IdentityRes{UserId: userID, DomainId: domainID}, nil)
+			repo.On("RetrieveToken", context.Background(), mgclients.HashToken(scoped)).Return(tc.scopedToken, tc.retrieveErr)
+
+			subject, err := api.NewAuditIdentifier(auth, repo).Identify(context.Background(), tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.subject, subject, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.subject, subject))
+			if mgclients.IsScopedToken(tc.token) {
+				auth.AssertNotCalled(t, "Identify", context.Background(), &magistrala.IdentityReq{Token: tc.token})
+			}
+		})
+	}
+}
@@ -18,9 +18,15 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-const svcName = "magistrala.AuthzService"
+const (
+	svcName       = "magistrala.AuthzService"
+	thingsSvcName = "magistrala.ThingsService"
+)
 
-var _ magistrala.AuthzServiceClient = (*grpcClient)(nil)
+var (
+	_ magistrala.AuthzServiceClient  = (*grpcClient)(nil)
+	_ magistrala.ThingsServiceClient = (*thingsClient)(nil)
+)
 
 type grpcClient struct {
 	timeout   time.Duration
@@ -75,6 +81,56 @@ func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 	}, nil
 }
 
+type thingsClient struct {
+	// Streams can't go through go-kit endpoints, so watching things is
+	// served by the generated client.
+	magistrala.ThingsServiceClient
+	timeout          time.Duration
+	retrieveMetadata endpoint.Endpoint
+}
+
+// NewThingsClient returns new things gRPC client instance.
+func NewThingsClient(conn *grpc.ClientConn, timeout time.Duration) magistrala.ThingsServiceClient {
+	return &thingsClient{
+		ThingsServiceClient: magistrala.NewThingsServiceClient(conn),
+		retrieveMetadata: kitgrpc.NewClient(
+			conn,
+			thingsSvcName,
+			"RetrieveMetadata",
+			encodeRetrieveMetadataRequest,
+			decodeRetrieveMetadataResponse,
+			magistrala.RetrieveMetadataRes{},
+		).Endpoint(),
+
+		timeout: timeout,
+	}
+}
+
+func (client thingsClient) RetrieveMetadata(ctx context.Context, req *magistrala.RetrieveMetadataReq, _ ...grpc.CallOption) (*magistrala.RetrieveMetadataRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveMetadata(ctx, req)
+	if err != nil {
+		return &magistrala.RetrieveMetadataRes{}, decodeError(err)
+	}
+
+	mr := res.(*magistrala.RetrieveMetadataRes)
+	return &magistrala.RetrieveMetadataRes{Metadata: mr.GetMetadata(), Enabled: mr.GetEnabled()}, nil
+}
+
+func encodeRetrieveMetadataRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.RetrieveMetadataReq)
+	return &magistrala.RetrieveMetadataReq{
+		EntityType: req.GetEntityType(),
+		Id:         req.GetId(),
+	}, nil
+}
+
+func decodeRetrieveMetadataResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes.(*magistrala.RetrieveMetadataRes), nil
+}
+
 func decodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		switch st.Code() {
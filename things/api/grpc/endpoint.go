@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/absmach/magistrala"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/things"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -25,3 +26,22 @@ func authorizeEndpoint(svc things.Service) endpoint.Endpoint {
 		}, err
 	}
 }
+
+func retrieveMetadataEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveMetadataReq)
+		if err := req.validate(); err != nil {
+			return retrieveMetadataRes{}, err
+		}
+
+		metadata, status, err := svc.RetrieveMetadata(ctx, req.entityType, req.id)
+		if err != nil {
+			return retrieveMetadataRes{}, err
+		}
+
+		return retrieveMetadataRes{
+			metadata: metadata,
+			enabled:  status == mgclients.EnabledStatus,
+		}, nil
+	}
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/apiutil"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/things"
 	grpcapi "github.com/absmach/magistrala/things/api/grpc"
 	"github.com/absmach/magistrala/things/mocks"
 	"github.com/stretchr/testify/assert"
@@ -22,9 +25,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
-const port = 7000
+const (
+	port      = 7000
+	heartbeat = 100 * time.Millisecond
+)
 
 var (
 	thingID   = "testID"
@@ -40,6 +47,7 @@ func startGRPCServer(svc *mocks.Service, port int) {
 	}
 	server := grpc.NewServer()
 	magistrala.RegisterAuthzServiceServer(server, grpcapi.NewServer(svc))
+	magistrala.RegisterThingsServiceServer(server, grpcapi.NewThingsServer(svc, heartbeat))
 	go func() {
 		if err := server.Serve(listener); err != nil {
 			panic(fmt.Sprintf("failed to serve: %s", err))
@@ -178,3 +186,167 @@ func TestAuthorize(t *testing.T) {
 		svcCall.Unset()
 	}
 }
+
+func TestWatchThings(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, port+1)
+	authAddr := fmt.Sprintf("localhost:%d", port+1)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := magistrala.NewThingsServiceClient(conn)
+
+	created := time.Now()
+	event := things.ThingEvent{
+		Seq:       1,
+		Operation: things.CreateOp,
+		ThingID:   thingID,
+		DomainID:  valid,
+		CreatedAt: created,
+	}
+
+	cases := []struct {
+		desc    string
+		req     *magistrala.WatchThingsReq
+		events  []things.ThingEvent
+		dropped bool
+		res     []*magistrala.ThingEvent
+		err     error
+		code    codes.Code
+	}{
+		{
+			desc:   "watch things successfully",
+			req:    &magistrala.WatchThingsReq{Token: valid, DomainId: valid},
+			events: []things.ThingEvent{event},
+			res: []*magistrala.ThingEvent{
+				{Seq: 1, Operation: things.CreateOp, ThingId: thingID, DomainId: valid, Created: created.UnixNano()},
+				{Heartbeat: true},
+			},
+			code: codes.OK,
+		},
+		{
+			desc: "watch things with invalid token",
+			req:  &magistrala.WatchThingsReq{Token: invalid, DomainId: valid},
+			err:  svcerr.ErrAuthentication,
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "watch things of unauthorized domain",
+			req:  &magistrala.WatchThingsReq{Token: valid, DomainId: invalid},
+			err:  svcerr.ErrDomainAuthorization,
+			code: codes.PermissionDenied,
+		},
+		{
+			desc: "watch things with expired sequence",
+			req:  &magistrala.WatchThingsReq{Token: valid, DomainId: valid, LastSeq: 10},
+			err:  things.ErrSeqExpired,
+			code: codes.OutOfRange,
+		},
+		{
+			desc:    "watch things dropped by the watcher",
+			req:     &magistrala.WatchThingsReq{Token: valid, DomainId: valid, LastSeq: 1},
+			events:  []things.ThingEvent{event},
+			dropped: true,
+			err:     things.ErrWatchDropped,
+			code:    codes.Aborted,
+		},
+	}
+
+	for _, tc := range cases {
+		ch := make(chan things.ThingEvent, len(tc.events))
+		for _, ev := range tc.events {
+			ch <- ev
+		}
+		if tc.dropped {
+			close(ch)
+		}
+		svcErr := tc.err
+		if tc.dropped {
+			svcErr = nil
+		}
+		svcCall := svc.On("WatchThings", mock.Anything, tc.req.GetToken(), tc.req.GetDomainId(), tc.req.GetLastSeq()).Return((<-chan things.ThingEvent)(ch), svcErr)
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := client.WatchThings(ctx, tc.req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		res := []*magistrala.ThingEvent{}
+		for len(res) < len(tc.res) || tc.err != nil {
+			ev, err := stream.Recv()
+			if err != nil {
+				e, ok := status.FromError(err)
+				assert.True(t, ok, fmt.Sprintf("%s: gRPC status not returned", tc.desc))
+				assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected code %s got %s", tc.desc, tc.code, e.Code()))
+				break
+			}
+			res = append(res, &magistrala.ThingEvent{
+				Seq:       ev.GetSeq(),
+				Operation: ev.GetOperation(),
+				ThingId:   ev.GetThingId(),
+				DomainId:  ev.GetDomainId(),
+				Created:   ev.GetCreated(),
+				Heartbeat: ev.GetHeartbeat(),
+			})
+		}
+		if tc.err == nil {
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, res))
+		}
+		cancel()
+		svcCall.Unset()
+	}
+}
+
+func TestRetrieveMetadata(t *testing.T) {
+	svc := new(mocks.Service)
+	startGRPCServer(svc, port+2)
+	authAddr := fmt.Sprintf("localhost:%d", port+2)
+	conn, _ := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewThingsClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		req      *magistrala.RetrieveMetadataReq
+		metadata mgclients.Metadata
+		status   mgclients.Status
+		svcErr   error
+		res      *magistrala.RetrieveMetadataRes
+		err      error
+	}{
+		{
+			desc:     "retrieve thing metadata successfully",
+			req:      &magistrala.RetrieveMetadataReq{EntityType: auth.ThingType, Id: thingID},
+			metadata: mgclients.Metadata{"allowed_subtopics": []interface{}{"status"}},
+			status:   mgclients.EnabledStatus,
+			res:      &magistrala.RetrieveMetadataRes{Metadata: []byte(`{"allowed_subtopics":["status"]}`), Enabled: true},
+		},
+		{
+			desc:   "retrieve metadata of disabled channel",
+			req:    &magistrala.RetrieveMetadataReq{EntityType: auth.GroupType, Id: channelID},
+			status: mgclients.DisabledStatus,
+			res:    &magistrala.RetrieveMetadataRes{},
+		},
+		{
+			desc:   "retrieve metadata of unknown channel",
+			req:    &magistrala.RetrieveMetadataReq{EntityType: auth.GroupType, Id: invalid},
+			svcErr: errors.Wrap(svcerr.ErrViewEntity, repoerr.ErrNotFound),
+			res:    &magistrala.RetrieveMetadataRes{},
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve metadata with invalid entity type",
+			req:  &magistrala.RetrieveMetadataReq{EntityType: invalid, Id: thingID},
+			res:  &magistrala.RetrieveMetadataRes{},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc: "retrieve metadata with missing ID",
+			req:  &magistrala.RetrieveMetadataReq{EntityType: auth.ThingType},
+			res:  &magistrala.RetrieveMetadataRes{},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RetrieveMetadata", mock.Anything, tc.req.GetEntityType(), tc.req.GetId()).Return(tc.metadata, tc.status, tc.svcErr)
+		res, err := client.RetrieveMetadata(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res, res))
+		svcCall.Unset()
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/apiutil"
+)
+
+type retrieveMetadataReq struct {
+	entityType string
+	id         string
+}
+
+func (req retrieveMetadataReq) validate() error {
+	if req.entityType != auth.ThingType && req.entityType != auth.GroupType {
+		return apiutil.ErrInvalidEntityType
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
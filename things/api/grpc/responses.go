@@ -3,7 +3,14 @@
 
 package grpc
 
+import mgclients "github.com/absmach/magistrala/pkg/clients"
+
 type authorizeRes struct {
 	id         string
 	authorized bool
 }
+
+type retrieveMetadataRes struct {
+	metadata mgclients.Metadata
+	enabled  bool
+}
@@ -5,11 +5,14 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/things"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
@@ -17,7 +20,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var _ magistrala.AuthzServiceServer = (*grpcServer)(nil)
+var (
+	_ magistrala.AuthzServiceServer  = (*grpcServer)(nil)
+	_ magistrala.ThingsServiceServer = (*thingsServer)(nil)
+)
 
 type grpcServer struct {
 	magistrala.UnimplementedAuthzServiceServer
@@ -43,6 +49,77 @@ func (s *grpcServer) Authorize(ctx context.Context, req *magistrala.AuthorizeReq
 	return res.(*magistrala.AuthorizeRes), nil
 }
 
+type thingsServer struct {
+	magistrala.UnimplementedThingsServiceServer
+	svc              things.Service
+	heartbeat        time.Duration
+	retrieveMetadata kitgrpc.Handler
+}
+
+// NewThingsServer returns new ThingsServiceServer instance which sends
+// heartbeat events to idle watchers every heartbeat interval.
+func NewThingsServer(svc things.Service, heartbeat time.Duration) magistrala.ThingsServiceServer {
+	return &thingsServer{
+		svc:       svc,
+		heartbeat: heartbeat,
+		retrieveMetadata: kitgrpc.NewServer(
+			retrieveMetadataEndpoint(svc),
+			decodeRetrieveMetadataRequest,
+			encodeRetrieveMetadataResponse,
+		),
+	}
+}
+
+func (s *thingsServer) RetrieveMetadata(ctx context.Context, req *magistrala.RetrieveMetadataReq) (*magistrala.RetrieveMetadataRes, error) {
+	_, res, err := s.retrieveMetadata.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*magistrala.RetrieveMetadataRes), nil
+}
+
+func (s *thingsServer) WatchThings(req *magistrala.WatchThingsReq, stream magistrala.ThingsService_WatchThingsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	evs, err := s.svc.WatchThings(ctx, req.GetToken(), req.GetDomainId(), req.GetLastSeq())
+	if err != nil {
+		return encodeError(err)
+	}
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := stream.Send(&magistrala.ThingEvent{Heartbeat: true}); err != nil {
+				return err
+			}
+		case ev, ok := <-evs:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return encodeError(things.ErrWatchDropped)
+			}
+			res := &magistrala.ThingEvent{
+				Seq:       ev.Seq,
+				Operation: ev.Operation,
+				ThingId:   ev.ThingID,
+				DomainId:  ev.DomainID,
+				Created:   ev.CreatedAt.UnixNano(),
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+			ticker.Reset(s.heartbeat)
+		}
+	}
+}
+
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*magistrala.AuthorizeReq)
 	return req, nil
@@ -53,25 +130,53 @@ func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{
 	return &magistrala.AuthorizeRes{Authorized: res.authorized, Id: res.id}, nil
 }
 
+func decodeRetrieveMetadataRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*magistrala.RetrieveMetadataReq)
+	return retrieveMetadataReq{entityType: req.GetEntityType(), id: req.GetId()}, nil
+}
+
+func encodeRetrieveMetadataResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveMetadataRes)
+	var data []byte
+	if len(res.metadata) > 0 {
+		var err error
+		if data, err = json.Marshal(res.metadata); err != nil {
+			return nil, err
+		}
+	}
+	return &magistrala.RetrieveMetadataRes{Metadata: data, Enabled: res.enabled}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case errors.Contains(err, nil):
 		return nil
 	case errors.Contains(err, errors.ErrMalformedEntity),
 		err == apiutil.ErrInvalidAuthKey,
+		err == apiutil.ErrInvalidEntityType,
 		err == apiutil.ErrMissingID,
 		err == apiutil.ErrMissingMemberType,
 		err == apiutil.ErrMissingPolicySub,
 		err == apiutil.ErrMissingPolicyObj,
 		err == apiutil.ErrMalformedPolicyAct:
 		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Contains(err, svcerr.ErrServiceUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
 	case errors.Contains(err, svcerr.ErrAuthentication),
 		errors.Contains(err, auth.ErrKeyExpired),
+		errors.Contains(err, svcerr.ErrKeyExpired),
 		err == apiutil.ErrMissingEmail,
 		err == apiutil.ErrBearerToken:
 		return status.Error(codes.Unauthenticated, err.Error())
-	case errors.Contains(err, svcerr.ErrAuthorization):
+	case errors.Contains(err, svcerr.ErrAuthorization),
+		errors.Contains(err, svcerr.ErrDomainAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Contains(err, things.ErrSeqExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Contains(err, things.ErrWatchDropped):
+		return status.Error(codes.Aborted, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -6,9 +6,11 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/internal/api"
@@ -16,21 +18,26 @@ import (
 	"github.com/absmach/magistrala/pkg/apiutil"
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
-	opts := []kithttp.ServerOption{
-		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
-	}
+func groupsHandler(svc groups.Service, tsvc things.Service, icache things.IdempotencyCache, cfg Config, r *chi.Mux, logger *slog.Logger) http.Handler {
+	errorEncoder := kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError))
+	limitBody, limitBulkBody := api.LimitBodySize(cfg.BodyLimits.Entity), api.LimitBodySize(cfg.BodyLimits.Bulk)
+	opts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBody), errorEncoder}
+	bulkOpts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBulkBody), errorEncoder}
+	// Bodies are decompressed and limited before they are fingerprinted for idempotency.
+	createOpts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBody, decodeIdempotencyKey), errorEncoder}
+	limitMetadata := api.LimitMetadataSize(cfg.MaxMetadataSize)
 	r.Route("/channels", func(r chi.Router) {
 		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
-			gapi.CreateGroupEndpoint(svc, auth.NewChannelKind),
+			limitMetadata(idempotent(icache, logger)(gapi.CreateGroupEndpoint(svc, auth.NewChannelKind))),
 			gapi.DecodeGroupCreate,
 			api.EncodeResponse,
-			opts...,
+			createOpts...,
 		), "create_channel").ServeHTTP)
 
 		r.Get("/{groupID}", otelhttp.NewHandler(kithttp.NewServer(
@@ -42,7 +49,7 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 
 		r.Delete("/{groupID}", otelhttp.NewHandler(kithttp.NewServer(
 			gapi.DeleteGroupEndpoint(svc),
-			gapi.DecodeGroupRequest,
+			gapi.DecodeDeleteGroupRequest,
 			api.EncodeResponse,
 			opts...,
 		), "delete_channel").ServeHTTP)
@@ -55,12 +62,36 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 		), "view_channel_permissions").ServeHTTP)
 
 		r.Put("/{groupID}", otelhttp.NewHandler(kithttp.NewServer(
-			gapi.UpdateGroupEndpoint(svc),
+			limitMetadata(gapi.UpdateGroupEndpoint(svc)),
 			gapi.DecodeGroupUpdate,
 			api.EncodeResponse,
 			opts...,
 		), "update_channel").ServeHTTP)
 
+		// Request to update a channel with a JSON merge patch
+		r.Patch("/{groupID}", otelhttp.NewHandler(kithttp.NewServer(
+			limitMetadata(patchChannelEndpoint(svc)),
+			decodePatchChannelRequest,
+			api.EncodeResponse,
+			opts...,
+		), "patch_channel").ServeHTTP)
+
+		// Request to update many channels at once
+		r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+			limitMetadata(updateChannelsEndpoint(svc)),
+			decodeUpdateChannelsRequest,
+			api.EncodeResponse,
+			bulkOpts...,
+		), "update_channels").ServeHTTP)
+
+		// Request to delete many channels at once
+		r.Post("/delete", otelhttp.NewHandler(kithttp.NewServer(
+			deleteChannelsEndpoint(svc),
+			decodeDeleteChannelsRequest,
+			api.EncodeResponse,
+			bulkOpts...,
+		), "delete_channels").ServeHTTP)
+
 		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 			gapi.ListGroupsEndpoint(svc, "channels", "users"),
 			gapi.DecodeListGroupsRequest,
@@ -118,6 +149,62 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 			opts...,
 		), "unassign_groups").ServeHTTP)
 
+		// Request to move things from one channel to another
+		r.Post("/{groupID}/things/move", otelhttp.NewHandler(kithttp.NewServer(
+			moveThingsEndpoint(svc),
+			decodeMoveThingsRequest,
+			api.EncodeResponse,
+			bulkOpts...,
+		), "move_things").ServeHTTP)
+
+		// Request to move all the things of a channel to another channel
+		r.Post("/{groupID}/things/reassign", otelhttp.NewHandler(kithttp.NewServer(
+			reassignThingsEndpoint(svc),
+			decodeReassignThingsRequest,
+			api.EncodeResponse,
+			bulkOpts...,
+		), "reassign_things").ServeHTTP)
+
+		// Request to connect existing things to a channel
+		r.Post("/{groupID}/things/attach", otelhttp.NewHandler(kithttp.NewServer(
+			attachThingsEndpoint(svc),
+			decodeAttachThingsRequest,
+			api.EncodeResponse,
+			bulkOpts...,
+		), "attach_things").ServeHTTP)
+
+		// Request to create a channel with the settings of another channel
+		r.Post("/{groupID}/clone", otelhttp.NewHandler(kithttp.NewServer(
+			cloneChannelEndpoint(svc),
+			decodeCloneChannelRequest,
+			api.EncodeResponse,
+			opts...,
+		), "clone_channel").ServeHTTP)
+
+		// Request to export the channel and its subchannels as a blueprint
+		r.Get("/{groupID}/blueprint", otelhttp.NewHandler(kithttp.NewServer(
+			exportChannelEndpoint(svc),
+			decodeExportChannelRequest,
+			api.EncodeResponse,
+			opts...,
+		), "export_channel").ServeHTTP)
+
+		// Request to export the channel with its subchannels, things and members
+		r.Get("/{groupID}/export", otelhttp.NewHandler(kithttp.NewServer(
+			exportSubtreeEndpoint(tsvc),
+			decodeExportSubtreeRequest,
+			api.EncodeResponse,
+			opts...,
+		), "export_channel_subtree").ServeHTTP)
+
+		// Request to list the roles and the last activity of channel members
+		r.Get("/{groupID}/roles", otelhttp.NewHandler(kithttp.NewServer(
+			listMemberRolesEndpoint(svc),
+			decodeListMemberRolesRequest,
+			api.EncodeResponse,
+			opts...,
+		), "list_channel_member_roles").ServeHTTP)
+
 		r.Post("/{groupID}/things/{thingID}/connect", otelhttp.NewHandler(kithttp.NewServer(
 			connectChannelThingEndpoint(svc),
 			decodeConnectChannelThingRequest,
@@ -133,6 +220,30 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 		), "disconnect_channel_thing").ServeHTTP)
 	})
 
+	// Request to create channels of the domain from a blueprint
+	r.Post("/domains/{domainID}/channels/from-blueprint", otelhttp.NewHandler(kithttp.NewServer(
+		limitMetadata(importChannelEndpoint(svc)),
+		decodeImportChannelRequest,
+		api.EncodeResponse,
+		bulkOpts...,
+	), "import_channel").ServeHTTP)
+
+	// Request to list the members of the channels of the domain
+	r.Get("/domains/{domainID}/channels/members", otelhttp.NewHandler(kithttp.NewServer(
+		listDomainMembersEndpoint(svc),
+		decodeListDomainMembersRequest,
+		api.EncodeResponse,
+		opts...,
+	), "list_domain_channel_members").ServeHTTP)
+
+	// Request to remove a member of the domain from all the channels of the domain
+	r.Post("/domains/{domainID}/channels/members/{memberID}/revoke", otelhttp.NewHandler(kithttp.NewServer(
+		revokeMemberEndpoint(svc),
+		decodeRevokeMemberRequest,
+		api.EncodeResponse,
+		opts...,
+	), "revoke_domain_channel_member").ServeHTTP)
+
 	// Ideal location: things service,  things endpoint
 	// Reason for placing here :
 	// SpiceDB provides list of channel ids to which thing id attached
@@ -173,7 +284,7 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 		connectEndpoint(svc),
 		decodeConnectRequest,
 		api.EncodeResponse,
-		opts...,
+		bulkOpts...,
 	), "connect").ServeHTTP)
 
 	// Disconnect channel and thing
@@ -181,7 +292,7 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 		disconnectEndpoint(svc),
 		decodeDisconnectRequest,
 		api.EncodeResponse,
-		opts...,
+		bulkOpts...,
 	), "disconnect").ServeHTTP)
 
 	return r
@@ -251,6 +362,257 @@ func decodeUnassignUserGroupsRequest(_ context.Context, r *http.Request) (interf
 	return req, nil
 }
 
+func decodeMoveThingsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := moveThingsRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeReassignThingsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := reassignThingsRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeAttachThingsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := attachThingsRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateChannelsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	m, err := apiutil.ReadBoolQuery(r, api.MergeKey, api.DefMerge)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	p, err := apiutil.ReadBoolQuery(r, api.PartialKey, api.DefPartial)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := updateChannelsRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		merge:   m,
+		partial: p,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeDeleteChannelsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	c, err := apiutil.ReadBoolQuery(r, api.CascadeKey, api.DefCascade)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := deleteChannelsRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		cascade: c,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodePatchChannelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ct := r.Header.Get("Content-Type")
+	if !strings.Contains(ct, api.MergePatchContentType) && !strings.Contains(ct, api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := patchChannelRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+	// A merge patch which is not an object replaces the whole channel,
+	// which is not supported.
+	if err := json.NewDecoder(r.Body).Decode(&req.patch); err != nil || req.patch == nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeCloneChannelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := cloneChannelRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+	// The body is optional, a request without it clones the channel as is.
+	if r.ContentLength == 0 {
+		return req, nil
+	}
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListMemberRolesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	is, err := apiutil.ReadTimeQuery(r, api.InactiveSinceKey, time.Time{})
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listMemberRolesRequest{
+		token:         apiutil.ExtractBearerToken(r),
+		groupID:       chi.URLParam(r, "groupID"),
+		inactiveSince: is,
+	}
+
+	return req, nil
+}
+
+func decodeListDomainMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listDomainMembersRequest{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		offset:   o,
+		limit:    l,
+	}
+
+	return req, nil
+}
+
+func decodeRevokeMemberRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeMemberRequest{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+		memberID: chi.URLParam(r, "memberID"),
+	}
+
+	return req, nil
+}
+
+func decodeExportChannelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := exportChannelRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+
+	return req, nil
+}
+
+// Collections of an exported subtree, whose pages are read from the queries
+// prefixed with the collection, such as things_offset and things_limit.
+const (
+	channelsCollection = "channels"
+	thingsCollection   = "things"
+	membersCollection  = "members"
+)
+
+func decodeExportSubtreeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var pm things.SubtreePageMeta
+	var err error
+	if pm.Channels, err = readCollectionPage(r, channelsCollection); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if pm.Things, err = readCollectionPage(r, thingsCollection); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if pm.Members, err = readCollectionPage(r, membersCollection); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	k, err := apiutil.ReadBoolQuery(r, api.KeysKey, api.DefKeys)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := exportSubtreeRequest{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+		page:    pm,
+		keys:    k,
+	}
+
+	return req, nil
+}
+
+func readCollectionPage(r *http.Request, collection string) (groups.PageMeta, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, collection+"_"+api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return groups.PageMeta{}, err
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, collection+"_"+api.LimitKey, api.DefaultLimit(r.Context()))
+	if err != nil {
+		return groups.PageMeta{}, err
+	}
+
+	return groups.PageMeta{Offset: o, Limit: l}, nil
+}
+
+func decodeImportChannelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := importChannelRequest{
+		token:    apiutil.ExtractBearerToken(r),
+		domainID: chi.URLParam(r, "domainID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.Blueprint); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeConnectChannelThingRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := connectChannelThingRequest{
 		token:     apiutil.ExtractBearerToken(r),
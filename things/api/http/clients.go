@@ -5,45 +5,95 @@ package http
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/things"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-func clientsHandler(svc things.Service, r *chi.Mux, logger *slog.Logger) http.Handler {
-	opts := []kithttp.ServerOption{
-		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
-	}
+func clientsHandler(svc things.Service, icache things.IdempotencyCache, cfg Config, r *chi.Mux, logger *slog.Logger) http.Handler {
+	errorEncoder := kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError))
+	limitBody, limitBulkBody := api.LimitBodySize(cfg.BodyLimits.Entity), api.LimitBodySize(cfg.BodyLimits.Bulk)
+	opts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBody), errorEncoder}
+	bulkOpts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBulkBody), errorEncoder}
+	// Bodies are decompressed and limited before they are fingerprinted for idempotency.
+	createOpts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBody, decodeIdempotencyKey), errorEncoder}
+	bulkCreateOpts := []kithttp.ServerOption{kithttp.ServerBefore(api.DecodeContentEncoding, limitBulkBody, decodeIdempotencyKey), errorEncoder}
+	limitMetadata := api.LimitMetadataSize(cfg.MaxMetadataSize)
 	r.Route("/things", func(r chi.Router) {
 		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
-			createClientEndpoint(svc),
+			limitMetadata(idempotent(icache, logger)(createClientEndpoint(svc))),
 			decodeCreateClientReq,
 			api.EncodeResponse,
-			opts...,
+			createOpts...,
 		), "create_thing").ServeHTTP)
 
 		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
-			listClientsEndpoint(svc),
+			listClientsEndpoint(svc, cfg.MaxListWait, cfg.FilterLimits),
 			decodeListClients,
 			api.EncodeResponse,
 			opts...,
 		), "list_things").ServeHTTP)
 
+		r.Get("/aggregate", otelhttp.NewHandler(kithttp.NewServer(
+			aggregateClientsEndpoint(svc),
+			decodeAggregateClients,
+			api.EncodeResponse,
+			opts...,
+		), "aggregate_things").ServeHTTP)
+
+		r.Get("/orphaned", otelhttp.NewHandler(kithttp.NewServer(
+			listOrphanedClientsEndpoint(svc, cfg.FilterLimits),
+			decodeListClients,
+			api.EncodeResponse,
+			opts...,
+		), "list_orphaned_things").ServeHTTP)
+
+		r.Post("/tokens", otelhttp.NewHandler(kithttp.NewServer(
+			issueTokenEndpoint(svc),
+			decodeIssueToken,
+			api.EncodeResponse,
+			opts...,
+		), "issue_token").ServeHTTP)
+
+		r.Get("/tokens", otelhttp.NewHandler(kithttp.NewServer(
+			listTokensEndpoint(svc),
+			decodeListTokens,
+			api.EncodeResponse,
+			opts...,
+		), "list_tokens").ServeHTTP)
+
+		r.Delete("/tokens/{tokenID}", otelhttp.NewHandler(kithttp.NewServer(
+			revokeTokenEndpoint(svc),
+			decodeRevokeToken,
+			api.EncodeResponse,
+			opts...,
+		), "revoke_token").ServeHTTP)
+
+		r.Post("/view", otelhttp.NewHandler(kithttp.NewServer(
+			viewClientsEndpoint(svc, cfg.MaxViewIDs),
+			decodeViewClients,
+			api.EncodeResponse,
+			bulkOpts...,
+		), "view_things").ServeHTTP)
+
 		r.Post("/bulk", otelhttp.NewHandler(kithttp.NewServer(
-			createClientsEndpoint(svc),
+			limitMetadata(idempotent(icache, logger)(createClientsEndpoint(svc))),
 			decodeCreateClientsReq,
 			api.EncodeResponse,
-			opts...,
+			bulkCreateOpts...,
 		), "create_things").ServeHTTP)
 
 		r.Get("/{thingID}", otelhttp.NewHandler(kithttp.NewServer(
@@ -61,7 +111,7 @@ func clientsHandler(svc things.Service, r *chi.Mux, logger *slog.Logger) http.Ha
 		), "view_thing_permissions").ServeHTTP)
 
 		r.Patch("/{thingID}", otelhttp.NewHandler(kithttp.NewServer(
-			updateClientEndpoint(svc),
+			limitMetadata(updateClientEndpoint(svc)),
 			decodeUpdateClient,
 			api.EncodeResponse,
 			opts...,
@@ -109,6 +159,28 @@ func clientsHandler(svc things.Service, r *chi.Mux, logger *slog.Logger) http.Ha
 			opts...,
 		), "unshare_thing").ServeHTTP)
 
+		r.Post("/{thingID}/certs", otelhttp.NewHandler(kithttp.NewServer(
+			bindCertEndpoint(svc),
+			decodeBindCert,
+			api.EncodeResponse,
+			opts...,
+		), "bind_thing_cert").ServeHTTP)
+
+		r.Delete("/{thingID}/certs/{fingerprint}", otelhttp.NewHandler(kithttp.NewServer(
+			unbindCertEndpoint(svc),
+			decodeUnbindCert,
+			api.EncodeResponse,
+			opts...,
+		), "unbind_thing_cert").ServeHTTP)
+
+		// Records the thing as active, authenticated by the thing key.
+		r.Post("/{thingID}/heartbeat", otelhttp.NewHandler(kithttp.NewServer(
+			heartbeatEndpoint(svc),
+			decodeHeartbeat,
+			api.EncodeResponse,
+			opts...,
+		), "thing_heartbeat").ServeHTTP)
+
 		r.Delete("/{thingID}", otelhttp.NewHandler(kithttp.NewServer(
 			deleteClientEndpoint(svc),
 			decodeDeleteClientReq,
@@ -129,19 +201,89 @@ func clientsHandler(svc things.Service, r *chi.Mux, logger *slog.Logger) http.Ha
 		opts...,
 	), "list_things_by_channel_id").ServeHTTP)
 
+	// Rotates the keys of the things connected to the channel one page at a time.
+	r.Post("/channels/{groupID}/things/rotate-keys", otelhttp.NewHandler(kithttp.NewServer(
+		rotateKeysEndpoint(svc),
+		decodeRotateKeysRequest,
+		api.EncodeResponse,
+		opts...,
+	), "rotate_channel_thing_keys").ServeHTTP)
+
+	// Moves the channel and its things to another domain.
+	r.Post("/channels/{groupID}/transfer", otelhttp.NewHandler(kithttp.NewServer(
+		transferChannelEndpoint(svc),
+		decodeTransferChannelRequest,
+		api.EncodeResponse,
+		opts...,
+	), "transfer_channel").ServeHTTP)
+
 	r.Get("/users/{userID}/things", otelhttp.NewHandler(kithttp.NewServer(
-		listClientsEndpoint(svc),
+		listClientsEndpoint(svc, cfg.MaxListWait, cfg.FilterLimits),
 		decodeListClients,
 		api.EncodeResponse,
 		opts...,
 	), "list_user_things").ServeHTTP)
+
+	// Identifies the thing by its client certificate, verified either while
+	// the TLS connection to the service was established or by the gateway
+	// terminating the TLS connection of the thing.
+	r.Post("/identify/cert", otelhttp.NewHandler(kithttp.NewServer(
+		identifyCertEndpoint(svc),
+		decodeIdentifyCert(cfg.GatewayToken),
+		api.EncodeResponse,
+		opts...,
+	), "identify_thing_cert").ServeHTTP)
+
+	// Validates the thing key without identifying the thing as seen, on
+	// behalf of the gateways.
+	r.Post("/keys/validate", otelhttp.NewHandler(kithttp.NewServer(
+		validateKeyEndpoint(svc),
+		decodeValidateKey(cfg.GatewayToken),
+		api.EncodeResponse,
+		opts...,
+	), "validate_thing_key").ServeHTTP)
+
+	// Starts rebuilding the cached thing keys from the database.
+	r.Post("/cache/reconcile", otelhttp.NewHandler(kithttp.NewServer(
+		reconcileCacheEndpoint(svc),
+		decodeReconcileCache,
+		api.EncodeResponse,
+		opts...,
+	), "reconcile_cache").ServeHTTP)
+
+	r.Get("/cache/reconcile", otelhttp.NewHandler(kithttp.NewServer(
+		viewCacheReconciliationEndpoint(svc),
+		decodeReconcileCache,
+		api.EncodeResponse,
+		opts...,
+	), "view_cache_reconciliation").ServeHTTP)
 	return r
 }
 
 func decodeViewClient(_ context.Context, r *http.Request) (interface{}, error) {
+	f, err := api.ReadFieldsQuery(r, thingFields...)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	req := viewClientReq{
+		token:  apiutil.ExtractBearerToken(r),
+		id:     chi.URLParam(r, "thingID"),
+		fields: f,
+	}
+
+	return req, nil
+}
+
+func decodeViewClients(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := viewClientsReq{
 		token: apiutil.ExtractBearerToken(r),
-		id:    chi.URLParam(r, "thingID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
 	}
 
 	return req, nil
@@ -165,7 +307,7 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
@@ -190,21 +332,118 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	st, err := mgclients.ToStatus(s)
+	co, err := apiutil.ReadBoolQuery(r, api.CountOnlyKey, api.DefCountOnly)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if co && r.URL.Query().Has(api.LimitKey) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams)
+	}
+	us, err := apiutil.ReadTimeQuery(r, api.UpdatedSinceKey, time.Time{})
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	usid, err := apiutil.ReadStringQuery(r, api.SinceIDKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	w, err := apiutil.ReadDurationQuery(r, api.WaitKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	e, err := apiutil.ReadStringQuery(r, api.EmbedKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	mf, err := decodeMetadataFilter(r)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	f, err := api.ReadFieldsQuery(r, thingFields...)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	ord, err := apiutil.ReadStringQuery(r, api.OrderKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	d, err := apiutil.ReadStringQuery(r, api.DirKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	st, conn, err := decodeStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 	req := listClientsReq{
-		token:      apiutil.ExtractBearerToken(r),
-		status:     st,
-		offset:     o,
-		limit:      l,
-		metadata:   m,
-		name:       n,
-		tag:        t,
-		permission: p,
-		listPerms:  lp,
-		userID:     chi.URLParam(r, "userID"),
+		token:          apiutil.ExtractBearerToken(r),
+		status:         st,
+		connection:     conn,
+		offset:         o,
+		limit:          l,
+		metadata:       m,
+		metadataFilter: mf,
+		name:           n,
+		tag:            t,
+		permission:     p,
+		listPerms:      lp,
+		countOnly:      co,
+		updatedSince:   us,
+		updatedSinceID: usid,
+		wait:           w,
+		embed:          e,
+		fields:         f,
+		order:          ord,
+		dir:            d,
+		userID:         chi.URLParam(r, "userID"),
+	}
+	return req, nil
+}
+
+// decodeStatus decodes the status query, which also accepts the connection
+// states. Listing things by connection state lists enabled things.
+func decodeStatus(s string) (mgclients.Status, string, error) {
+	switch s {
+	case things.OnlineConnection, things.OfflineConnection:
+		return mgclients.EnabledStatus, s, nil
+	default:
+		st, err := mgclients.ToStatus(s)
+		return st, "", err
+	}
+}
+
+// decodeMetadataFilter decodes the JSON encoded metadata filter query. The
+// filter is validated by the endpoint, which knows the filter limits.
+func decodeMetadataFilter(r *http.Request) (*mgclients.MetadataFilter, error) {
+	f, err := apiutil.ReadStringQuery(r, api.MetaFilterKey, "")
+	if err != nil || f == "" {
+		return nil, err
+	}
+	mf := &mgclients.MetadataFilter{}
+	if err := json.Unmarshal([]byte(f), mf); err != nil {
+		return nil, errors.Wrap(mgclients.ErrInvalidMetadataFilter, err)
+	}
+
+	return mf, nil
+}
+
+func decodeAggregateClients(_ context.Context, r *http.Request) (interface{}, error) {
+	f, err := apiutil.ReadStringQuery(r, api.FieldKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, api.DefClientStatus)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	st, err := mgclients.ToStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := aggregateClientsReq{
+		token:  apiutil.ExtractBearerToken(r),
+		field:  f,
+		status: st,
 	}
 	return req, nil
 }
@@ -214,6 +453,11 @@ func decodeUpdateClient(_ context.Context, r *http.Request) (interface{}, error)
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
 	}
 
+	m, err := apiutil.ReadBoolQuery(r, api.MergeKey, api.DefMerge)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	req := updateClientReq{
 		token: apiutil.ExtractBearerToken(r),
 		id:    chi.URLParam(r, "thingID"),
@@ -221,6 +465,7 @@ func decodeUpdateClient(_ context.Context, r *http.Request) (interface{}, error)
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
 	}
+	req.Merge = req.Merge || m
 
 	return req, nil
 }
@@ -296,6 +541,42 @@ func decodeChangeClientStatus(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeRotateKeysRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := rotateKeysReq{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+		offset:  o,
+		limit:   l,
+	}
+
+	return req, nil
+}
+
+func decodeTransferChannelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := transferChannelReq{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: chi.URLParam(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
 func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	s, err := apiutil.ReadStringQuery(r, api.StatusKey, api.DefClientStatus)
 	if err != nil {
@@ -305,7 +586,7 @@ func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{},
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
@@ -313,7 +594,7 @@ func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{},
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	st, err := mgclients.ToStatus(s)
+	st, conn, err := decodeStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
@@ -335,6 +616,7 @@ func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{},
 			Permission: p,
 			Metadata:   m,
 			ListPerms:  lp,
+			Connection: conn,
 		},
 		groupID: chi.URLParam(r, "groupID"),
 	}
@@ -378,6 +660,161 @@ func decodeDeleteClientReq(_ context.Context, r *http.Request) (interface{}, err
 		token: apiutil.ExtractBearerToken(r),
 		id:    chi.URLParam(r, "thingID"),
 	}
+	// Invalid dates are ignored as required by RFC 9110.
+	if since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since")); err == nil {
+		req.unmodifiedSince = since
+	}
+
+	return req, nil
+}
+
+func decodeIssueToken(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := issueTokenReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeBindCert(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := bindCertReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: chi.URLParam(r, "thingID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeUnbindCert(_ context.Context, r *http.Request) (interface{}, error) {
+	req := unbindCertReq{
+		token:       apiutil.ExtractBearerToken(r),
+		thingID:     chi.URLParam(r, "thingID"),
+		fingerprint: chi.URLParam(r, "fingerprint"),
+	}
+
+	return req, nil
+}
+
+// decodeIdentifyCert takes the certificate from the verified chain of the
+// TLS connection, so only certificates issued by the configured client CAs
+// are accepted. Without a verified chain, the certificate verified by the
+// gateway terminating the TLS connection of the thing is taken from the
+// request body, which is accepted only from an authenticated gateway.
+func decodeIdentifyCert(gatewayToken string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			cert := r.TLS.VerifiedChains[0][0]
+			req := identifyCertReq{
+				Fingerprint: mgclients.CertFingerprint(cert.Raw),
+				Subject:     cert.Subject.String(),
+			}
+
+			return req, nil
+		}
+
+		if apiutil.ExtractBearerToken(r) == "" {
+			return nil, errors.Wrap(svcerr.ErrAuthentication, apiutil.ErrMissingCertData)
+		}
+		if err := authenticateGateway(r, gatewayToken); err != nil {
+			return nil, err
+		}
+		if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+		}
+
+		req := identifyCertReq{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+
+		return req, nil
+	}
+}
+
+func decodeValidateKey(gatewayToken string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if err := authenticateGateway(r, gatewayToken); err != nil {
+			return nil, err
+		}
+		if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+		}
+
+		req := validateKeyReq{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+
+		return req, nil
+	}
+}
+
+// authenticateGateway checks that the request carries the gateway token,
+// which never matches if the gateway token is not configured.
+func authenticateGateway(r *http.Request, gatewayToken string) error {
+	token := apiutil.ExtractBearerToken(r)
+	if gatewayToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(gatewayToken)) != 1 {
+		return errors.Wrap(svcerr.ErrAuthentication, apiutil.ErrBearerToken)
+	}
+
+	return nil
+}
+
+func decodeHeartbeat(_ context.Context, r *http.Request) (interface{}, error) {
+	req := heartbeatReq{
+		key: apiutil.ExtractThingKey(r),
+		id:  chi.URLParam(r, "thingID"),
+	}
+
+	return req, nil
+}
+
+func decodeListTokens(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listTokensReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func decodeRevokeToken(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeTokenReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, "tokenID"),
+	}
+
+	return req, nil
+}
+
+func decodeReconcileCache(_ context.Context, r *http.Request) (interface{}, error) {
+	req := reconcileCacheReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
 
 	return req, nil
 }
@@ -5,8 +5,11 @@ package http
 
 import (
 	"context"
+	"math"
+	"time"
 
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
@@ -72,7 +75,44 @@ func viewClientEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return viewClientRes{Client: c}, nil
+		return viewClientRes{Client: c, fields: req.fields}, nil
+	}
+}
+
+func viewClientsEndpoint(svc things.Service, maxIDs int) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewClientsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if len(req.IDs) > maxIDs {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize)
+		}
+
+		rs, err := svc.ViewClients(ctx, req.token, req.IDs...)
+		if err != nil {
+			return nil, err
+		}
+
+		res := viewClientsRes{Things: []viewClientsItem{}}
+		for _, r := range rs {
+			item := viewClientsItem{ID: r.ID}
+			if r.Err != nil {
+				// Only the outermost error is exposed, the same as in the
+				// error responses.
+				item.Error = r.Err.Error()
+				if e, ok := r.Err.(errors.Error); ok {
+					item.Error = e.Msg()
+				}
+				res.Things = append(res.Things, item)
+				continue
+			}
+			c := r.Client
+			item.Thing = &c
+			res.Things = append(res.Things, item)
+		}
+
+		return res, nil
 	}
 }
 
@@ -88,49 +128,180 @@ func viewClientPermsEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return viewClientPermsRes{Permissions: p}, nil
+		return viewClientPermsRes{Permissions: p, Actions: auth.Actions(p)}, nil
 	}
 }
 
-func listClientsEndpoint(svc things.Service) endpoint.Endpoint {
+func listClientsEndpoint(svc things.Service, maxWait time.Duration, filterLimits mgclients.FilterLimits) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listClientsReq)
 		if err := req.validate(); err != nil {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
+		if req.metadataFilter != nil {
+			if err := req.metadataFilter.Validate(filterLimits); err != nil {
+				return nil, errors.Wrap(apiutil.ErrValidation, err)
+			}
+		}
 
 		pm := mgclients.Page{
-			Status:     req.status,
-			Offset:     req.offset,
-			Limit:      req.limit,
-			Name:       req.name,
-			Tag:        req.tag,
-			Permission: req.permission,
-			Metadata:   req.metadata,
-			ListPerms:  req.listPerms,
-			Role:       mgclients.AllRole, // retrieve all things since things don't have roles
+			Status:         req.status,
+			Offset:         req.offset,
+			Limit:          req.limit,
+			Name:           req.name,
+			Tag:            req.tag,
+			Permission:     req.permission,
+			Metadata:       req.metadata,
+			MetadataFilter: req.metadataFilter,
+			ListPerms:      req.listPerms,
+			CountOnly:      req.countOnly,
+			Connection:     req.connection,
+			Role:           mgclients.AllRole, // retrieve all things since things don't have roles
+			UpdatedSince:   req.updatedSince,
+			UpdatedSinceID: req.updatedSinceID,
+			Wait:           min(req.wait, maxWait),
+			Fields:         req.fields,
+			Order:          req.order,
+			Dir:            req.dir,
 		}
 		page, err := svc.ListClients(ctx, req.token, req.userID, pm)
 		if err != nil {
 			return nil, err
 		}
+		if req.countOnly {
+			return countRes{Total: page.Total}, nil
+		}
 
-		res := clientsPageRes{
-			pageRes: pageRes{
-				Total:  page.Total,
-				Offset: page.Offset,
-				Limit:  page.Limit,
-			},
-			Clients: []viewClientRes{},
+		res := newClientsPageRes(page)
+		for i := range res.Clients {
+			res.Clients[i].fields = req.fields
 		}
-		for _, c := range page.Clients {
-			res.Clients = append(res.Clients, viewClientRes{Client: c})
+		if !req.updatedSince.IsZero() {
+			hw, hwID := highWater(page.Clients, req.updatedSince, req.updatedSinceID)
+			res.HighWater, res.HighWaterID = &hw, hwID
+		}
+		if req.embed == api.ChannelsEmbed {
+			if err := embedChannels(ctx, svc, req.token, res.Clients); err != nil {
+				return nil, err
+			}
 		}
 
 		return res, nil
 	}
 }
 
+func listOrphanedClientsEndpoint(svc things.Service, filterLimits mgclients.FilterLimits) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listClientsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if req.metadataFilter != nil {
+			if err := req.metadataFilter.Validate(filterLimits); err != nil {
+				return nil, errors.Wrap(apiutil.ErrValidation, err)
+			}
+		}
+
+		pm := mgclients.Page{
+			Status:         req.status,
+			Offset:         req.offset,
+			Limit:          req.limit,
+			Name:           req.name,
+			Tag:            req.tag,
+			Metadata:       req.metadata,
+			MetadataFilter: req.metadataFilter,
+			CountOnly:      req.countOnly,
+			Connection:     req.connection,
+			Role:           mgclients.AllRole,
+			UpdatedSince:   req.updatedSince,
+			UpdatedSinceID: req.updatedSinceID,
+		}
+		page, err := svc.ListOrphanedClients(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+		if req.countOnly {
+			return countRes{Total: page.Total}, nil
+		}
+
+		return newClientsPageRes(page), nil
+	}
+}
+
+// highWater returns the update time and the ID of the last of the clients,
+// which are ordered by the update time and the ID, to be used as
+// updated_since and updated_since_id of the next poll. Clients updated at
+// the same time as the last one but left out of the page are listed by the
+// next poll.
+func highWater(clients []mgclients.Client, since time.Time, sinceID string) (time.Time, string) {
+	if len(clients) == 0 {
+		return since, sinceID
+	}
+	last := clients[len(clients)-1]
+	if last.UpdatedAt.IsZero() {
+		return last.CreatedAt, last.ID
+	}
+
+	return last.UpdatedAt, last.ID
+}
+
+func newClientsPageRes(page mgclients.ClientsPage) clientsPageRes {
+	res := clientsPageRes{
+		pageRes: pageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+		Clients: []viewClientRes{},
+	}
+	for _, c := range page.Clients {
+		res.Clients = append(res.Clients, viewClientRes{Client: c})
+	}
+
+	return res
+}
+
+// embedChannels sets the channels of the things, which are fetched at once
+// for the whole page. Things without channels get null channels.
+func embedChannels(ctx context.Context, svc things.Service, token string, cs []viewClientRes) error {
+	if len(cs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(cs))
+	for i, c := range cs {
+		ids[i] = c.ID
+	}
+	chs, err := svc.ListThingsChannels(ctx, token, ids...)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		c := chs[cs[i].ID]
+		cs[i].Channels = &c
+	}
+
+	return nil
+}
+
+func aggregateClientsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(aggregateClientsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		aggs, err := svc.AggregateMetadata(ctx, req.token, req.field, req.status)
+		if err != nil {
+			return nil, err
+		}
+		if aggs == nil {
+			aggs = []mgclients.MetadataAggregate{}
+		}
+
+		return aggregateClientsRes{Field: req.field, Values: aggs}, nil
+	}
+}
+
 func listMembersEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listMembersReq)
@@ -158,8 +329,9 @@ func updateClientEndpoint(svc things.Service) endpoint.Endpoint {
 			ID:       req.id,
 			Name:     req.Name,
 			Metadata: req.Metadata,
+			Version:  req.Version,
 		}
-		client, err := svc.UpdateClient(ctx, req.token, cli)
+		client, err := svc.UpdateClient(ctx, req.token, cli, req.Merge)
 		if err != nil {
 			return nil, err
 		}
@@ -195,7 +367,7 @@ func updateClientSecretEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		client, err := svc.UpdateClientSecret(ctx, req.token, req.id, req.Secret)
+		client, err := svc.UpdateClientSecret(ctx, req.token, req.id, req.Secret, time.Duration(req.TTL*time.Second))
 		if err != nil {
 			return nil, err
 		}
@@ -204,6 +376,54 @@ func updateClientSecretEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func rotateKeysEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateKeysReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := mgclients.Page{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.RotateKeys(ctx, req.token, req.groupID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := rotateKeysRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Keys: make(map[string]string, len(page.Clients)),
+		}
+		for _, c := range page.Clients {
+			res.Keys[c.ID] = c.Credentials.Secret
+		}
+
+		return res, nil
+	}
+}
+
+func transferChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(transferChannelReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		ct, err := svc.TransferChannel(ctx, req.token, req.groupID, req.TargetDomainID)
+		if err != nil {
+			return nil, err
+		}
+
+		return transferChannelRes{Channel: ct.Channel, Things: ct.Things}, nil
+	}
+}
+
 func enableClientEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeClientStatusReq)
@@ -342,6 +562,262 @@ func disconnectChannelThingEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
+func moveThingsEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveThingsRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		moves, err := svc.MoveThings(ctx, req.token, req.groupID, req.TargetGroupID, req.ThingIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return moveThingsRes{Moves: moves}, nil
+	}
+}
+
+func reassignThingsEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reassignThingsRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		moved, err := svc.ReassignThings(ctx, req.token, req.groupID, req.TargetGroupID)
+		if err != nil {
+			return nil, err
+		}
+
+		return reassignThingsRes{Moved: len(moved)}, nil
+	}
+}
+
+func attachThingsEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(attachThingsRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		attachments, err := svc.AttachThings(ctx, req.token, req.groupID, req.ThingIDs, req.OnConflict == reassignOnConflict)
+		if err != nil {
+			return nil, err
+		}
+
+		return attachThingsRes{Attachments: attachments}, nil
+	}
+}
+
+func updateChannelsEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateChannelsRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		chs := make([]groups.Group, len(req.Channels))
+		for i, ch := range req.Channels {
+			chs[i] = groups.Group{
+				ID:          ch.ID,
+				Name:        ch.Name,
+				Description: ch.Description,
+				Metadata:    ch.Metadata,
+				Version:     ch.Version,
+			}
+		}
+		updates, err := svc.UpdateGroups(ctx, req.token, chs, req.merge, req.partial)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateChannelsRes{Channels: updates}, nil
+	}
+}
+
+func deleteChannelsEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteChannelsRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		removals, err := svc.DeleteGroups(ctx, req.token, req.IDs, req.cascade)
+		if err != nil {
+			return nil, err
+		}
+
+		return deleteChannelsRes{Channels: removals}, nil
+	}
+}
+
+func patchChannelEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchChannelRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		ch, err := svc.PatchGroup(ctx, req.token, req.groupID, req.groupPatch())
+		if err != nil {
+			return nil, err
+		}
+
+		return patchChannelRes{Group: ch}, nil
+	}
+}
+
+func cloneChannelEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(cloneChannelRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		ch, err := svc.CloneGroup(ctx, req.token, auth.NewChannelKind, req.groupID, req.TargetGroupID, req.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return cloneChannelRes{Group: ch}, nil
+	}
+}
+
+func exportChannelEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportChannelRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		bp, err := svc.ExportGroup(ctx, req.token, req.groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportChannelRes{Blueprint: bp}, nil
+	}
+}
+
+func exportSubtreeEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportSubtreeRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		st, err := svc.ExportSubtree(ctx, req.token, req.groupID, req.page, req.keys)
+		if err != nil {
+			return nil, err
+		}
+
+		res := exportSubtreeRes{
+			Channel: st.Channel,
+			Channels: subtreeChannelsRes{
+				pageRes:  pageRes{Total: st.Channels.Total, Offset: req.page.Channels.Offset, Limit: req.page.Channels.Limit},
+				Channels: st.Channels.Groups,
+			},
+			Things: subtreeThingsRes{
+				pageRes: pageRes{Total: st.Things.Total, Offset: req.page.Things.Offset, Limit: req.page.Things.Limit},
+				Things:  st.Things.Members,
+			},
+			Members: subtreeMembersRes{
+				pageRes: pageRes{Total: st.Members.Total, Offset: req.page.Members.Offset, Limit: req.page.Members.Limit},
+				Members: st.Members.Members,
+			},
+		}
+		if res.Channels.Channels == nil {
+			res.Channels.Channels = []groups.Group{}
+		}
+		if res.Things.Things == nil {
+			res.Things.Things = []mgclients.Client{}
+		}
+		if res.Members.Members == nil {
+			res.Members.Members = []groups.MemberRole{}
+		}
+
+		return res, nil
+	}
+}
+
+func importChannelEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importChannelRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		ch, err := svc.ImportGroup(ctx, req.token, auth.NewChannelKind, req.domainID, req.Blueprint)
+		if err != nil {
+			return nil, err
+		}
+
+		return importChannelRes{Group: ch}, nil
+	}
+}
+
+func listMemberRolesEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listMemberRolesRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		members, err := svc.ListMemberRoles(ctx, req.token, req.groupID, req.inactiveSince)
+		if err != nil {
+			return nil, err
+		}
+
+		return listMemberRolesRes{Members: members}, nil
+	}
+}
+
+func listDomainMembersEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDomainMembersRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := groups.PageMeta{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListDomainMembers(ctx, req.token, req.domainID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listDomainMembersRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Members: page.Members,
+		}
+
+		return res, nil
+	}
+}
+
+func revokeMemberEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeMemberRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		rev, err := svc.RevokeMember(ctx, req.token, req.domainID, req.memberID)
+		if err != nil {
+			return nil, err
+		}
+
+		return revokeMemberRes{MemberRevocation: rev}, nil
+	}
+}
+
 func connectEndpoint(svc groups.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(connectChannelThingRequest)
@@ -409,10 +885,213 @@ func deleteClientEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
-		if err := svc.DeleteClient(ctx, req.token, req.id); err != nil {
+		if _, err := svc.DeleteClient(ctx, req.token, req.id, req.unmodifiedSince); err != nil {
 			return nil, err
 		}
 
 		return deleteClientRes{}, nil
 	}
 }
+
+func issueTokenEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(issueTokenReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		st, err := svc.IssueToken(ctx, req.token, req.Scopes, time.Duration(req.TTL*time.Second))
+		if err != nil {
+			return nil, err
+		}
+
+		return issueTokenRes{ScopedToken: st}, nil
+	}
+}
+
+func listTokensEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listTokensReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := mgclients.Page{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListTokens(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := tokensPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Tokens: []mgclients.ScopedToken{},
+		}
+		res.Tokens = append(res.Tokens, page.Tokens...)
+
+		return res, nil
+	}
+}
+
+func bindCertEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bindCertReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		cert := mgclients.CertBinding{
+			Fingerprint: req.Fingerprint,
+			ThingID:     req.thingID,
+			Subject:     req.Subject,
+		}
+		cert, err := svc.BindCert(ctx, req.token, cert)
+		if err != nil {
+			return nil, err
+		}
+
+		return bindCertRes{CertBinding: cert}, nil
+	}
+}
+
+func identifyCertEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identifyCertReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		id, err := svc.IdentifyCert(ctx, req.Fingerprint, req.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		return identifyCertRes{ID: id}, nil
+	}
+}
+
+func unbindCertEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(unbindCertReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.UnbindCert(ctx, req.token, req.thingID, req.fingerprint); err != nil {
+			return nil, err
+		}
+
+		return unbindCertRes{}, nil
+	}
+}
+
+func validateKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(validateKeyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		kv, err := svc.ValidateKey(ctx, req.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		res := validateKeyRes{Valid: kv.Valid, ThingID: kv.ThingID}
+		if kv.ExpiresAt != nil {
+			ttl := int64(math.Ceil(time.Until(*kv.ExpiresAt).Seconds()))
+			res.ExpiresAt, res.TTL = kv.ExpiresAt, &ttl
+		}
+
+		return res, nil
+	}
+}
+
+func heartbeatEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(heartbeatReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.Heartbeat(ctx, req.key, req.id); err != nil {
+			return nil, err
+		}
+
+		return heartbeatRes{}, nil
+	}
+}
+
+func revokeTokenEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeTokenReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RevokeToken(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return revokeTokenRes{}, nil
+	}
+}
+
+func reconcileCacheEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reconcileCacheReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		rec, err := svc.ReconcileCache(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toReconcileCacheRes(rec)
+		res.started = true
+
+		return res, nil
+	}
+}
+
+func viewCacheReconciliationEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reconcileCacheReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		rec, err := svc.ViewCacheReconciliation(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return toReconcileCacheRes(rec), nil
+	}
+}
+
+func toReconcileCacheRes(rec things.CacheReconciliation) reconcileCacheRes {
+	res := reconcileCacheRes{
+		Running:   rec.Running,
+		Error:     rec.Error,
+		Added:     rec.Added,
+		Corrected: rec.Corrected,
+		Removed:   rec.Removed,
+	}
+	if !rec.StartedAt.IsZero() {
+		res.StartedAt = &rec.StartedAt
+	}
+	if !rec.FinishedAt.IsZero() {
+		res.FinishedAt = &rec.FinishedAt
+	}
+
+	return res
+}
@@ -4,28 +4,45 @@
 package http_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/0x6flab/namegenerator"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/auth"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
 	"github.com/absmach/magistrala/internal/api"
+	mggroups "github.com/absmach/magistrala/internal/groups"
 	"github.com/absmach/magistrala/internal/testsutil"
 	mglog "github.com/absmach/magistrala/logger"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
 	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/things"
 	httpapi "github.com/absmach/magistrala/things/api/http"
 	"github.com/absmach/magistrala/things/mocks"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -47,15 +64,39 @@ var (
 	namesgen     = namegenerator.NewGenerator()
 )
 
-const contentType = "application/json"
+const (
+	contentType     = "application/json"
+	maxViewIDs      = 3
+	maxMetadataSize = 256
+	maxListWait     = time.Second
+	gatewayToken    = "gateway"
+)
+
+var (
+	bodyLimits    = api.BodyLimits{Entity: 16 * 1024, Bulk: 64 * 1024}
+	pageLimits    = api.PageLimits{Default: api.DefLimit, Max: api.MaxLimitSize}
+	filterLimits  = mgclients.FilterLimits{MaxDepth: 3, MaxNodes: 5}
+	handlerConfig = httpapi.Config{
+		MaxViewIDs:      maxViewIDs,
+		MaxMetadataSize: maxMetadataSize,
+		MaxListWait:     maxListWait,
+		BodyLimits:      bodyLimits,
+		PageLimits:      pageLimits,
+		FilterLimits:    filterLimits,
+		GatewayToken:    gatewayToken,
+	}
+)
 
 type testRequest struct {
-	client      *http.Client
-	method      string
-	url         string
-	contentType string
-	token       string
-	body        io.Reader
+	client          *http.Client
+	method          string
+	url             string
+	contentType     string
+	contentEncoding string
+	acceptEncoding  string
+	token           string
+	idempotencyKey  string
+	body            io.Reader
 }
 
 func (tr testRequest) make() (*http.Response, error) {
@@ -72,6 +113,18 @@ func (tr testRequest) make() (*http.Response, error) {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
 
+	if tr.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", tr.idempotencyKey)
+	}
+
+	if tr.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", tr.contentEncoding)
+	}
+
+	if tr.acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", tr.acceptEncoding)
+	}
+
 	req.Header.Set("Referer", "http://localhost")
 
 	return tr.client.Do(req)
@@ -91,11 +144,57 @@ func newThingsServer() (*httptest.Server, *mocks.Service, *gmocks.Service) {
 
 	logger := mglog.NewMock()
 	mux := chi.NewRouter()
-	httpapi.MakeHandler(svc, gsvc, mux, logger, "")
+	httpapi.MakeHandler(svc, gsvc, nil, handlerConfig, mux, logger, nil)
 
 	return httptest.NewServer(mux), svc, gsvc
 }
 
+func TestReadiness(t *testing.T) {
+	cases := []struct {
+		desc   string
+		checks map[string]magistrala.HealthCheck
+		status int
+	}{
+		{
+			desc: "readiness with healthy dependencies",
+			checks: map[string]magistrala.HealthCheck{
+				"postgres": func(context.Context) error { return nil },
+				"cache":    func(context.Context) error { return nil },
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc: "readiness with failing dependency",
+			checks: map[string]magistrala.HealthCheck{
+				"postgres": func(context.Context) error { return nil },
+				"cache":    func(context.Context) error { return errors.New("connection refused") },
+			},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		mux := chi.NewRouter()
+		httpapi.MakeHandler(new(mocks.Service), new(gmocks.Service), nil, handlerConfig, mux, mglog.NewMock(), tc.checks)
+		ts := httptest.NewServer(mux)
+
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/health/ready", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body magistrala.ReadinessInfo
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Len(t, body.Checks, len(tc.checks), fmt.Sprintf("%s: expected %d checks got %d", tc.desc, len(tc.checks), len(body.Checks)))
+		ts.Close()
+	}
+}
+
 func TestCreateThing(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
@@ -220,6 +319,209 @@ func TestCreateThing(t *testing.T) {
 	}
 }
 
+func TestCreateThingIdempotency(t *testing.T) {
+	data := toJSON(client)
+	stored, err := json.Marshal(client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc           string
+		idempotencyKey string
+		storedRes      things.IdempotentResponse
+		reserved       bool
+		reserveErr     error
+		svcErr         error
+		status         int
+		err            error
+	}{
+		{
+			desc:   "register a new thing without idempotency key",
+			status: http.StatusCreated,
+		},
+		{
+			desc:           "register a new thing with a new idempotency key",
+			idempotencyKey: "key",
+			reserved:       true,
+			status:         http.StatusCreated,
+		},
+		{
+			desc:           "register a new thing with a used idempotency key",
+			idempotencyKey: "key",
+			storedRes:      things.IdempotentResponse{Body: stored},
+			status:         http.StatusOK,
+		},
+		{
+			desc:           "register a new thing with an idempotency key used for a different request",
+			idempotencyKey: "key",
+			storedRes:      things.IdempotentResponse{Fingerprint: inValid, Body: stored},
+			status:         http.StatusConflict,
+			err:            svcerr.ErrConflict,
+		},
+		{
+			desc:           "register a new thing with an idempotency key of a request in progress",
+			idempotencyKey: "key",
+			storedRes:      things.IdempotentResponse{},
+			status:         http.StatusConflict,
+			err:            svcerr.ErrConflict,
+		},
+		{
+			desc:           "register a new thing with a new idempotency key and failed creation",
+			idempotencyKey: "key",
+			reserved:       true,
+			svcErr:         svcerr.ErrCreateEntity,
+			status:         http.StatusUnprocessableEntity,
+			err:            svcerr.ErrCreateEntity,
+		},
+		{
+			desc:           "register a new thing with an idempotency key and unavailable cache",
+			idempotencyKey: "key",
+			reserveErr:     repoerr.ErrCreateEntity,
+			status:         http.StatusServiceUnavailable,
+			err:            svcerr.ErrServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := new(mocks.Service)
+			icache := new(mocks.IdempotencyCache)
+			mux := chi.NewRouter()
+			httpapi.MakeHandler(svc, new(gmocks.Service), icache, handlerConfig, mux, mglog.NewMock(), nil)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			req := testRequest{
+				client:         ts.Client(),
+				method:         http.MethodPost,
+				url:            fmt.Sprintf("%s/things/", ts.URL),
+				contentType:    contentType,
+				token:          validToken,
+				idempotencyKey: tc.idempotencyKey,
+				body:           strings.NewReader(data),
+			}
+
+			svc.On("CreateThings", mock.Anything, validToken, client).Return([]mgclients.Client{client}, tc.svcErr)
+			icache.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _, fingerprint string) (things.IdempotentResponse, bool, error) {
+				res := tc.storedRes
+				if len(res.Body) > 0 && res.Fingerprint == "" {
+					res.Fingerprint = fingerprint
+				}
+				return res, tc.reserved, tc.reserveErr
+			})
+			icache.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			icache.On("Remove", mock.Anything, mock.Anything).Return(nil)
+
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var body respBody
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if body.Err != "" || body.Message != "" {
+				err = errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+			switch {
+			case tc.idempotencyKey == "":
+				icache.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+			case !tc.reserved:
+				svc.AssertNotCalled(t, "CreateThings", mock.Anything, mock.Anything, mock.Anything)
+			case tc.svcErr != nil:
+				icache.AssertCalled(t, "Remove", mock.Anything, mock.Anything)
+			default:
+				icache.AssertCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMetadataSizeLimit(t *testing.T) {
+	ts, svc, gsvc := newThingsServer()
+	defer ts.Close()
+
+	large := map[string]interface{}{"key": strings.Repeat("a", maxMetadataSize)}
+	small := map[string]interface{}{"key": "value"}
+
+	cases := []struct {
+		desc   string
+		method string
+		url    string
+		data   interface{}
+		status int
+		msg    string
+	}{
+		{
+			desc:   "create thing with too large metadata",
+			method: http.MethodPost,
+			url:    "/things",
+			data:   map[string]interface{}{"name": "thing", "metadata": large},
+			status: http.StatusRequestEntityTooLarge,
+			msg:    apiutil.ErrMetadataTooLarge.Error(),
+		},
+		{
+			desc:   "bulk create things with too large metadata",
+			method: http.MethodPost,
+			url:    "/things/bulk",
+			data: []map[string]interface{}{
+				{"name": "thing1", "metadata": small},
+				{"name": "thing2", "metadata": large},
+			},
+			status: http.StatusRequestEntityTooLarge,
+			msg:    fmt.Sprintf("%s: entity at index 1", apiutil.ErrMetadataTooLarge),
+		},
+		{
+			desc:   "update thing with too large metadata",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/things/%s", validID),
+			data:   map[string]interface{}{"name": "thing", "metadata": large},
+			status: http.StatusRequestEntityTooLarge,
+			msg:    apiutil.ErrMetadataTooLarge.Error(),
+		},
+		{
+			desc:   "create channel with too large metadata",
+			method: http.MethodPost,
+			url:    "/channels",
+			data:   map[string]interface{}{"name": "channel", "metadata": large},
+			status: http.StatusRequestEntityTooLarge,
+			msg:    apiutil.ErrMetadataTooLarge.Error(),
+		},
+		{
+			desc:   "update channel with too large metadata",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/channels/%s", validID),
+			data:   map[string]interface{}{"name": "channel", "metadata": large},
+			status: http.StatusRequestEntityTooLarge,
+			msg:    apiutil.ErrMetadataTooLarge.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         ts.URL + tc.url,
+			contentType: contentType,
+			token:       validToken,
+			body:        strings.NewReader(toJSON(tc.data)),
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var errRes respBody
+		err = json.NewDecoder(res.Body).Decode(&errRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.msg, errRes.Err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.msg, errRes.Err))
+		assert.Equal(t, "metadata_too_large", errRes.Code, fmt.Sprintf("%s: expected code metadata_too_large got %s", tc.desc, errRes.Code))
+	}
+	// Requests are rejected before reaching the services.
+	svc.AssertNotCalled(t, "CreateThings", mock.Anything, mock.Anything, mock.Anything)
+	svc.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	gsvc.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	gsvc.AssertNotCalled(t, "UpdateGroup", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestCreateThings(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
@@ -364,114 +666,469 @@ func TestCreateThings(t *testing.T) {
 	}
 }
 
-func TestListThings(t *testing.T) {
-	ts, svc, _ := newThingsServer()
+func TestBodySizeLimit(t *testing.T) {
+	ts, svc, gsvc := newThingsServer()
 	defer ts.Close()
 
+	// Bulk bodies between the entity and bulk limits are accepted.
+	var bulk, tooLargeBulk []map[string]interface{}
+	for i := 0; i < 200; i++ {
+		bulk = append(bulk, map[string]interface{}{"name": strings.Repeat("a", 100)})
+	}
+	for i := 0; i < 800; i++ {
+		tooLargeBulk = append(tooLargeBulk, map[string]interface{}{"name": strings.Repeat("a", 100)})
+	}
+	large := strings.Repeat("a", int(bodyLimits.Entity))
+	createArgs := []interface{}{mock.Anything, validToken}
+	for range bulk {
+		createArgs = append(createArgs, mock.Anything)
+	}
+
 	cases := []struct {
-		desc               string
-		query              string
-		token              string
-		listThingsResponse mgclients.ClientsPage
-		status             int
-		err                error
+		desc   string
+		method string
+		url    string
+		data   interface{}
+		status int
+		code   string
 	}{
 		{
-			desc:   "list things with valid token",
-			token:  validToken,
-			status: http.StatusOK,
-			listThingsResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			err: nil,
+			desc:   "create thing with too large body",
+			method: http.MethodPost,
+			url:    "/things",
+			data:   map[string]interface{}{"name": large},
+			status: http.StatusRequestEntityTooLarge,
+			code:   "payload_too_large",
 		},
 		{
-			desc:   "list things with empty token",
-			token:  "",
-			status: http.StatusUnauthorized,
-			err:    apiutil.ErrBearerToken,
+			desc:   "update thing with too large body",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/things/%s", validID),
+			data:   map[string]interface{}{"name": large},
+			status: http.StatusRequestEntityTooLarge,
+			code:   "payload_too_large",
 		},
 		{
-			desc:   "list things with invalid token",
-			token:  inValidToken,
-			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			desc:   "create channel with too large body",
+			method: http.MethodPost,
+			url:    "/channels",
+			data:   map[string]interface{}{"name": large},
+			status: http.StatusRequestEntityTooLarge,
+			code:   "payload_too_large",
 		},
 		{
-			desc:  "list things with offset",
-			token: validToken,
-			listThingsResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Offset: 1,
-					Total:  1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "offset=1",
+			desc:   "bulk create things with body over the entity limit",
+			method: http.MethodPost,
+			url:    "/things/bulk",
+			data:   bulk,
 			status: http.StatusOK,
-			err:    nil,
 		},
 		{
-			desc:   "list things with invalid offset",
-			token:  validToken,
-			query:  "offset=invalid",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			desc:   "bulk create things with too large body",
+			method: http.MethodPost,
+			url:    "/things/bulk",
+			data:   tooLargeBulk,
+			status: http.StatusRequestEntityTooLarge,
+			code:   "payload_too_large",
 		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         ts.URL + tc.url,
+			contentType: contentType,
+			token:       validToken,
+			body:        strings.NewReader(toJSON(tc.data)),
+		}
+
+		svcCall := svc.On("CreateThings", createArgs...).Return([]mgclients.Client{}, nil)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.code, bodyRes.Code, fmt.Sprintf("%s: expected code %s got %s", tc.desc, tc.code, bodyRes.Code))
+		svcCall.Unset()
+	}
+	svc.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything, mock.Anything)
+	gsvc.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateThingsCompressed(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	items := []mgclients.Client{
 		{
-			desc:  "list things with limit",
-			token: validToken,
-			listThingsResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Limit: 1,
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "limit=1",
-			status: http.StatusOK,
-			err:    nil,
+			ID:     testsutil.GenerateUUID(t),
+			Name:   namesgen.Generate(),
+			Status: mgclients.EnabledStatus,
 		},
+	}
+	compress := func(data string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write([]byte(data))
+		require.Nil(t, err, fmt.Sprintf("unexpected error while compressing body: %s", err))
+		require.Nil(t, zw.Close(), "unexpected error while closing gzip writer")
+		return buf.Bytes()
+	}
+
+	cases := []struct {
+		desc           string
+		body           []byte
+		contentType    string
+		acceptEncoding string
+		compressed     bool
+		status         int
+		err            error
+		len            int
+	}{
 		{
-			desc:   "list things with invalid limit",
-			token:  validToken,
-			query:  "limit=invalid",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			desc:        "create things with gzip encoded body",
+			body:        compress(toJSON(items)),
+			contentType: contentType,
+			status:      http.StatusOK,
+			len:         1,
 		},
 		{
-			desc:   "list things with limit greater than max",
-			token:  validToken,
-			query:  fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			desc:           "create things with gzip encoded body and response",
+			body:           compress(toJSON(items)),
+			contentType:    contentType,
+			acceptEncoding: "gzip",
+			compressed:     true,
+			status:         http.StatusOK,
+			len:            1,
 		},
 		{
-			desc:  "list things with name",
-			token: validToken,
-			listThingsResponse: mgclients.ClientsPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Clients: []mgclients.Client{client},
-			},
-			query:  "name=clientname",
-			status: http.StatusOK,
-			err:    nil,
+			desc:        "create things with malformed gzip body",
+			body:        []byte(toJSON(items)),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
 		},
 		{
-			desc:   "list things with invalid name",
-			token:  validToken,
-			query:  "name=invalid",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
+			desc:        "create things with gzip encoded body and invalid content type",
+			body:        compress(toJSON(items)),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
 		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:          ts.Client(),
+			method:          http.MethodPost,
+			url:             fmt.Sprintf("%s/things/bulk", ts.URL),
+			contentType:     tc.contentType,
+			contentEncoding: "gzip",
+			acceptEncoding:  tc.acceptEncoding,
+			token:           validToken,
+			body:            bytes.NewReader(tc.body),
+		}
+
+		svcCall := svc.On("CreateThings", mock.Anything, validToken, items[0]).Return(items, nil)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		body := io.Reader(res.Body)
+		assert.Equal(t, tc.compressed, res.Header.Get("Content-Encoding") == "gzip", fmt.Sprintf("%s: expected compressed response %t", tc.desc, tc.compressed))
+		if tc.compressed {
+			body, err = gzip.NewReader(res.Body)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error while decompressing response body: %s", tc.desc, err))
+		}
+		var bodyRes respBody
+		err = json.NewDecoder(body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.len, bodyRes.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.len, bodyRes.Total))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestCreateThingsWithScopedToken(t *testing.T) {
+	auth := new(authmocks.AuthClient)
+	repo := new(mocks.Repository)
+	svc := things.NewService(auth, repo, new(gmocks.Repository), new(mocks.Cache), new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+	mux := chi.NewRouter()
+	httpapi.MakeHandler(svc, new(gmocks.Service), nil, handlerConfig, mux, mglog.NewMock(), nil)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	items := []mgclients.Client{
 		{
-			desc:   "list things with duplicate name",
-			token:  validToken,
+			Name:   namesgen.Generate(),
+			Status: mgclients.EnabledStatus,
+		},
+	}
+	readToken := mgclients.ScopedTokenPrefix + "read"
+	writeToken := mgclients.ScopedTokenPrefix + "write"
+	expiredToken := mgclients.ScopedTokenPrefix + "expired"
+	expiredAt := time.Now().Add(-time.Minute)
+
+	cases := []struct {
+		desc        string
+		token       string
+		scopedToken mgclients.ScopedToken
+		status      int
+		code        string
+	}{
+		{
+			desc:  "create things with things:read token",
+			token: readToken,
+			scopedToken: mgclients.ScopedToken{
+				DomainID: validID,
+				Subject:  validID,
+				Scopes:   []string{mgclients.ThingsReadScope},
+			},
+			status: http.StatusForbidden,
+			code:   "token_scope_denied",
+		},
+		{
+			desc:  "create things with things:write token",
+			token: writeToken,
+			scopedToken: mgclients.ScopedToken{
+				DomainID: validID,
+				Subject:  validID,
+				Scopes:   []string{mgclients.ThingsReadScope, mgclients.ThingsWriteScope},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:  "create things with expired token",
+			token: expiredToken,
+			scopedToken: mgclients.ScopedToken{
+				DomainID:  validID,
+				Subject:   validID,
+				Scopes:    []string{mgclients.ThingsWriteScope},
+				ExpiresAt: &expiredAt,
+			},
+			status: http.StatusUnauthorized,
+			code:   "key_expired",
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/bulk", ts.URL),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(toJSON(items)),
+		}
+
+		repoCall := repo.On("RetrieveToken", mock.Anything, mgclients.HashToken(tc.token)).Return(tc.scopedToken, nil)
+		authCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		authCall1 := auth.On("AddPolicies", mock.Anything, mock.Anything).Return(&magistrala.AddPoliciesRes{Added: true}, nil)
+		repoCall1 := repo.On("Save", mock.Anything, mock.Anything).Return(items, nil)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.code, bodyRes.Code, fmt.Sprintf("%s: expected code %s got %s", tc.desc, tc.code, bodyRes.Code))
+		repoCall.Unset()
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestViewChannelWithScopedToken(t *testing.T) {
+	authClient := new(authmocks.AuthClient)
+	repo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	gsvc := mggroups.NewChannelsService(gRepo, uuid.NewMock(), authClient, repo)
+	mux := chi.NewRouter()
+	httpapi.MakeHandler(new(mocks.Service), gsvc, nil, handlerConfig, mux, mglog.NewMock(), nil)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	channel := groups.Group{ID: testsutil.GenerateUUID(t), Name: namesgen.Generate(), Domain: validID}
+
+	cases := []struct {
+		desc        string
+		token       string
+		scopedToken mgclients.ScopedToken
+		status      int
+		code        string
+	}{
+		{
+			desc:  "view channel with things:read token",
+			token: mgclients.ScopedTokenPrefix + "things",
+			scopedToken: mgclients.ScopedToken{
+				DomainID: validID,
+				Subject:  validID,
+				Scopes:   []string{mgclients.ThingsReadScope, mgclients.ThingsWriteScope},
+			},
+			status: http.StatusForbidden,
+			code:   "token_scope_denied",
+		},
+		{
+			desc:  "view channel with channels:read token",
+			token: mgclients.ScopedTokenPrefix + "channels",
+			scopedToken: mgclients.ScopedToken{
+				DomainID: validID,
+				Subject:  validID,
+				Scopes:   []string{mgclients.ChannelsReadScope},
+			},
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s", ts.URL, channel.ID),
+			token:  tc.token,
+		}
+
+		repoCall := repo.On("RetrieveToken", mock.Anything, mgclients.HashToken(tc.token)).Return(tc.scopedToken, nil)
+		authCall := authClient.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+			Domain:      validID,
+			SubjectType: auth.UserType,
+			SubjectKind: auth.UsersKind,
+			Subject:     validID,
+			Permission:  auth.ViewPermission,
+			ObjectType:  auth.GroupType,
+			Object:      channel.ID,
+		}).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		repoCall1 := gRepo.On("RetrieveByID", mock.Anything, channel.ID).Return(channel, nil)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.code, bodyRes.Code, fmt.Sprintf("%s: expected code %s got %s", tc.desc, tc.code, bodyRes.Code))
+		authClient.AssertNotCalled(t, "Identify", mock.Anything, mock.Anything)
+		repoCall.Unset()
+		authCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestListThings(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc               string
+		query              string
+		token              string
+		listThingsResponse mgclients.ClientsPage
+		status             int
+		err                error
+	}{
+		{
+			desc:   "list things with valid token",
+			token:  validToken,
+			status: http.StatusOK,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			err: nil,
+		},
+		{
+			desc:   "list things with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "list things with invalid token",
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "list things with offset",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Offset: 1,
+					Total:  1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "offset=1",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list things with invalid offset",
+			token:  validToken,
+			query:  "offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:  "list things with limit",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Limit: 1,
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "limit=1",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list things with invalid limit",
+			token:  validToken,
+			query:  "limit=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list things with limit greater than max",
+			token:  validToken,
+			query:  fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:  "list things with name",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "name=clientname",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list things with invalid name",
+			token:  validToken,
+			query:  "name=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list things with duplicate name",
+			token:  validToken,
 			query:  "name=1&name=2",
 			status: http.StatusBadRequest,
 			err:    apiutil.ErrInvalidQueryParams,
@@ -489,6 +1146,32 @@ func TestListThings(t *testing.T) {
 			status: http.StatusOK,
 			err:    nil,
 		},
+		{
+			desc:  "list online things",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "status=online",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:  "list offline things",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "status=offline",
+			status: http.StatusOK,
+			err:    nil,
+		},
 		{
 			desc:   "list things with invalid status",
 			token:  validToken,
@@ -557,6 +1240,40 @@ func TestListThings(t *testing.T) {
 			status: http.StatusBadRequest,
 			err:    apiutil.ErrInvalidQueryParams,
 		},
+		{
+			desc:  "list things with metadata filter",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "metadata_filter=" + url.QueryEscape(`{"and": [{"field": "region", "value": "eu"}, {"or": [{"field": "tier", "value": "gold"}, {"field": "tier", "value": "platinum"}]}]}`),
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list things with malformed metadata filter",
+			token:  validToken,
+			query:  "metadata_filter=invalid",
+			status: http.StatusBadRequest,
+			err:    mgclients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "list things with invalid metadata filter",
+			token:  validToken,
+			query:  "metadata_filter=" + url.QueryEscape(`{"and": []}`),
+			status: http.StatusBadRequest,
+			err:    mgclients.ErrInvalidMetadataFilter,
+		},
+		{
+			desc:   "list things with too deep metadata filter",
+			token:  validToken,
+			query:  "metadata_filter=" + url.QueryEscape(`{"not": {"not": {"not": {"field": "tier", "value": "free"}}}}`),
+			status: http.StatusBadRequest,
+			err:    mgclients.ErrMetadataFilterTooComplex,
+		},
 		{
 			desc:  "list things with permissions",
 			token: validToken,
@@ -611,83 +1328,141 @@ func TestListThings(t *testing.T) {
 			status: http.StatusBadRequest,
 			err:    apiutil.ErrInvalidQueryParams,
 		},
-	}
-
-	for _, tc := range cases {
-		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodGet,
-			url:         ts.URL + "/things?" + tc.query,
-			contentType: contentType,
-			token:       tc.token,
-		}
-
-		svcCall := svc.On("ListClients", mock.Anything, tc.token, "", mock.Anything).Return(tc.listThingsResponse, tc.err)
-		res, err := req.make()
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-
-		var bodyRes respBody
-		err = json.NewDecoder(res.Body).Decode(&bodyRes)
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if bodyRes.Err != "" || bodyRes.Message != "" {
-			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
-		}
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		svcCall.Unset()
-	}
-}
-
-func TestViewThing(t *testing.T) {
-	ts, svc, _ := newThingsServer()
-	defer ts.Close()
-
-	cases := []struct {
-		desc   string
-		token  string
-		id     string
-		status int
-		err    error
-	}{
 		{
-			desc:   "view client with valid token",
+			desc:  "list things with count only",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+			},
+			query:  "count_only=true",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list things with count only and limit",
 			token:  validToken,
-			id:     client.ID,
+			query:  "count_only=true&limit=10",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "list things with invalid count only",
+			token:  validToken,
+			query:  "count_only=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:  "list things updated since a timestamp",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "updated_since=2024-03-01T12:30:00Z",
 			status: http.StatusOK,
 			err:    nil,
 		},
 		{
-			desc:   "view client with invalid token",
-			token:  inValidToken,
-			id:     client.ID,
-			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			desc:   "list things with invalid updated since",
+			token:  validToken,
+			query:  "updated_since=yesterday",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
 		},
 		{
-			desc:   "view client with empty token",
-			token:  "",
-			id:     client.ID,
-			status: http.StatusUnauthorized,
-			err:    apiutil.ErrBearerToken,
+			desc:   "list things with duplicate updated since",
+			token:  validToken,
+			query:  "updated_since=2024-03-01T12:30:00Z&updated_since=2024-03-02T12:30:00Z",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "list things with invalid wait",
+			token:  validToken,
+			query:  "updated_since=2024-03-01T12:30:00Z&wait=30",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "list things with wait without updated since",
+			token:  validToken,
+			query:  "wait=30s",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "list things with invalid embed",
+			token:  validToken,
+			query:  "embed=profile",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:  "list things ordered by metadata field",
+			token: validToken,
+			listThingsResponse: mgclients.ClientsPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Clients: []mgclients.Client{client},
+			},
+			query:  "order=metadata.priority&dir=desc",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list things ordered by too deeply nested metadata field",
+			token:  validToken,
+			query:  "order=metadata.a.b.c.d.e.f",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "list things ordered by malformed metadata field",
+			token:  validToken,
+			query:  "order=metadata.priority%27",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "list things with invalid order",
+			token:  validToken,
+			query:  "order=priority",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidOrder,
+		},
+		{
+			desc:   "list things with invalid dir",
+			token:  validToken,
+			query:  "order=metadata.priority&dir=up",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidDirection,
 		},
 	}
 
 	for _, tc := range cases {
 		req := testRequest{
-			client: ts.Client(),
-			method: http.MethodGet,
-			url:    fmt.Sprintf("%s/things/%s", ts.URL, tc.id),
-			token:  tc.token,
+			client:      ts.Client(),
+			method:      http.MethodGet,
+			url:         ts.URL + "/things?" + tc.query,
+			contentType: contentType,
+			token:       tc.token,
 		}
 
-		svcCall := svc.On("ViewClient", mock.Anything, tc.token, tc.id).Return(mgclients.Client{}, tc.err)
+		svcCall := svc.On("ListClients", mock.Anything, tc.token, "", mock.Anything).Return(tc.listThingsResponse, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		var errRes respBody
-		err = json.NewDecoder(res.Body).Decode(&errRes)
+
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if errRes.Err != "" || errRes.Message != "" {
-			err = errors.Wrap(errors.New(errRes.Err), errors.New(errRes.Message))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
 		}
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -695,49 +1470,42 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
-func TestViewThingPerms(t *testing.T) {
+func TestListThingsEmbedChannels(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
 
+	unconnected := mgclients.Client{ID: testsutil.GenerateUUID(t), Name: "unconnected", Status: mgclients.EnabledStatus}
+	page := mgclients.ClientsPage{
+		Page:    mgclients.Page{Total: 2},
+		Clients: []mgclients.Client{client, unconnected},
+	}
+	channel := groups.Group{ID: testsutil.GenerateUUID(t), Name: "channel"}
+
 	cases := []struct {
 		desc     string
-		token    string
-		thingID  string
-		response []string
+		query    string
+		channels map[string][]groups.Group
+		listErr  error
 		status   int
-		err      error
+		embedded bool
 	}{
 		{
-			desc:     "view thing permissions with valid token",
-			token:    validToken,
-			thingID:  client.ID,
-			response: []string{"view", "delete", "membership"},
+			desc:     "list things without embed",
 			status:   http.StatusOK,
-			err:      nil,
-		},
-		{
-			desc:     "view thing permissions with invalid token",
-			token:    inValidToken,
-			thingID:  client.ID,
-			response: []string{},
-			status:   http.StatusUnauthorized,
-			err:      svcerr.ErrAuthentication,
+			embedded: false,
 		},
 		{
-			desc:     "view thing permissions with empty token",
-			token:    "",
-			thingID:  client.ID,
-			response: []string{},
-			status:   http.StatusUnauthorized,
-			err:      apiutil.ErrBearerToken,
+			desc:     "list things with embedded channels",
+			query:    "embed=channels",
+			channels: map[string][]groups.Group{client.ID: {channel}},
+			status:   http.StatusOK,
+			embedded: true,
 		},
 		{
-			desc:     "view thing permissions with invalid id",
-			token:    validToken,
-			thingID:  inValid,
-			response: []string{},
-			status:   http.StatusForbidden,
-			err:      svcerr.ErrAuthorization,
+			desc:    "list things with embedded channels with failed to list channels",
+			query:   "embed=channels",
+			listErr: svcerr.ErrViewEntity,
+			status:  http.StatusBadRequest,
 		},
 	}
 
@@ -745,394 +1513,559 @@ func TestViewThingPerms(t *testing.T) {
 		req := testRequest{
 			client: ts.Client(),
 			method: http.MethodGet,
-			url:    fmt.Sprintf("%s/things/%s/permissions", ts.URL, tc.thingID),
-			token:  tc.token,
+			url:    ts.URL + "/things?" + tc.query,
+			token:  validToken,
 		}
 
-		svcCall := svc.On("ViewClientPerms", mock.Anything, tc.token, tc.thingID).Return(tc.response, tc.err)
+		listCall := svc.On("ListClients", mock.Anything, validToken, "", mock.Anything).Return(page, nil)
+		chsCall := svc.On("ListThingsChannels", mock.Anything, validToken, client.ID, unconnected.ID).Return(tc.channels, tc.listErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		var resBody respBody
-		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var bodyRes struct {
+			Things []map[string]json.RawMessage `json:"things"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if resBody.Err != "" || resBody.Message != "" {
-			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		for _, th := range bodyRes.Things {
+			chs, ok := th["channels"]
+			assert.Equal(t, tc.embedded, ok, fmt.Sprintf("%s: expected embedded channels %t", tc.desc, tc.embedded))
+			if !ok {
+				continue
+			}
+			var id string
+			err := json.Unmarshal(th["id"], &id)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding thing ID: %s", tc.desc, err))
+			switch id {
+			case client.ID:
+				var got []groups.Group
+				err := json.Unmarshal(chs, &got)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding channels: %s", tc.desc, err))
+				assert.Len(t, got, 1, fmt.Sprintf("%s: expected one channel got %d", tc.desc, len(got)))
+				assert.Equal(t, channel.ID, got[0].ID, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, channel.ID, got[0].ID))
+			default:
+				assert.Equal(t, "null", string(chs), fmt.Sprintf("%s: expected null channels got %s", tc.desc, chs))
+			}
 		}
-		assert.Equal(t, len(tc.response), len(resBody.Permissions), fmt.Sprintf("%s: expected %d got %d", tc.desc, len(tc.response), len(resBody.Permissions)))
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		svcCall.Unset()
+		listCall.Unset()
+		chsCall.Unset()
 	}
 }
 
-func TestUpdateThing(t *testing.T) {
-	ts, svc, _ := newThingsServer()
-	defer ts.Close()
-
-	newName := "newname"
-	newTag := "newtag"
-	newMetadata := mgclients.Metadata{"newkey": "newvalue"}
+func TestListThingsWait(t *testing.T) {
+	since := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	updated := client
+	updated.CreatedAt = since.Add(-time.Hour)
+	updated.UpdatedAt = since.Add(time.Minute)
+	created := client
+	created.CreatedAt = since.Add(2 * time.Minute)
+	// The things updated at the same time are split by the page limit.
+	tiedAt := since.Add(3 * time.Minute)
+	tied := []mgclients.Client{
+		{ID: "a", CreatedAt: since, UpdatedAt: tiedAt},
+		{ID: "b", CreatedAt: since, UpdatedAt: tiedAt},
+		{ID: "c", CreatedAt: since, UpdatedAt: tiedAt},
+	}
 
 	cases := []struct {
-		desc           string
-		id             string
-		data           string
-		clientResponse mgclients.Client
-		token          string
-		contentType    string
-		status         int
-		err            error
+		desc        string
+		query       string
+		since       time.Time
+		sinceID     string
+		wait        time.Duration
+		response    mgclients.ClientsPage
+		highWater   time.Time
+		highWaterID string
+		status      int
 	}{
 		{
-			desc:        "update thing with valid token",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
-			token:       validToken,
-			contentType: contentType,
-			clientResponse: mgclients.Client{
-				ID:       client.ID,
-				Name:     newName,
-				Tags:     []string{newTag},
-				Metadata: newMetadata,
+			desc:  "list things changed since a timestamp with wait",
+			query: "updated_since=2024-03-01T12:30:00Z&wait=500ms",
+			wait:  500 * time.Millisecond,
+			response: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1},
+				Clients: []mgclients.Client{updated},
 			},
-			status: http.StatusOK,
-			err:    nil,
+			highWater:   updated.UpdatedAt,
+			highWaterID: updated.ID,
 		},
 		{
-			desc:        "update thing with invalid token",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
-			token:       inValidToken,
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			desc:  "list things created since a timestamp with wait beyond the limit",
+			query: "updated_since=2024-03-01T12:30:00Z&wait=1h",
+			wait:  maxListWait,
+			response: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 2},
+				Clients: []mgclients.Client{updated, created},
+			},
+			highWater:   created.CreatedAt,
+			highWaterID: created.ID,
 		},
 		{
-			desc:        "update thing with empty token",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
-			token:       "",
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
+			desc:      "list unchanged things with wait",
+			query:     "updated_since=2024-03-01T12:30:00Z&wait=30s",
+			wait:      maxListWait,
+			highWater: since,
 		},
 		{
-			desc:        "update thing with invalid id",
-			id:          inValid,
-			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
-			token:       validToken,
-			contentType: contentType,
-			status:      http.StatusForbidden,
-			err:         svcerr.ErrAuthorization,
+			desc:  "list things changed since a timestamp without wait",
+			query: "updated_since=2024-03-01T12:30:00Z",
+			response: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1},
+				Clients: []mgclients.Client{updated},
+			},
+			highWater:   updated.UpdatedAt,
+			highWaterID: updated.ID,
 		},
 		{
-			desc:        "update thing with invalid contentype",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
-			token:       validToken,
-			contentType: "application/xml",
-			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			desc:  "list first page of things changed at the same time",
+			query: "updated_since=2024-03-01T12:30:00Z&limit=2",
+			response: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 3, Limit: 2},
+				Clients: tied[:2],
+			},
+			highWater:   tiedAt,
+			highWaterID: tied[1].ID,
 		},
 		{
-			desc:        "update thing with malformed data",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"name":%s}`, "invalid"),
-			token:       validToken,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:    "list next page of things changed at the same time",
+			query:   "updated_since=2024-03-01T12:33:00Z&updated_since_id=b&limit=2",
+			since:   tiedAt,
+			sinceID: tied[1].ID,
+			response: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1, Limit: 2},
+				Clients: tied[2:],
+			},
+			highWater:   tiedAt,
+			highWaterID: tied[2].ID,
 		},
 		{
-			desc:        "update thing with empty id",
-			id:          " ",
-			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
-			token:       validToken,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingID,
+			desc:        "list unchanged things after a thing changed at the same time",
+			query:       "updated_since=2024-03-01T12:33:00Z&updated_since_id=c",
+			since:       tiedAt,
+			sinceID:     tied[2].ID,
+			highWater:   tiedAt,
+			highWaterID: tied[2].ID,
+		},
+		{
+			desc:   "list things after a thing without a timestamp",
+			query:  "updated_since_id=c",
+			status: http.StatusBadRequest,
 		},
 	}
 
 	for _, tc := range cases {
-		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPatch,
-			url:         fmt.Sprintf("%s/things/%s", ts.URL, tc.id),
-			contentType: tc.contentType,
-			token:       tc.token,
-			body:        strings.NewReader(tc.data),
+		if tc.since.IsZero() {
+			tc.since = since
 		}
-
-		svcCall := svc.On("UpdateClient", mock.Anything, tc.token, mock.Anything).Return(tc.clientResponse, tc.err)
+		if tc.status == 0 {
+			tc.status = http.StatusOK
+		}
+		ts, svc, _ := newThingsServer()
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/things?" + tc.query,
+			token:  validToken,
+		}
+
+		svc.On("ListClients", mock.Anything, validToken, "", mock.MatchedBy(func(pm mgclients.Page) bool {
+			return pm.Wait == tc.wait && pm.UpdatedSince.Equal(tc.since) && pm.UpdatedSinceID == tc.sinceID
+		})).Return(tc.response, nil)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			ts.Close()
+			continue
+		}
 
-		var resBody respBody
-		err = json.NewDecoder(res.Body).Decode(&resBody)
+		var body struct {
+			HighWater   time.Time `json:"high_water"`
+			HighWaterID string    `json:"high_water_id"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if resBody.Err != "" || resBody.Message != "" {
-			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		assert.True(t, tc.highWater.Equal(body.HighWater), fmt.Sprintf("%s: expected high water %s got %s", tc.desc, tc.highWater, body.HighWater))
+		assert.Equal(t, tc.highWaterID, body.HighWaterID, fmt.Sprintf("%s: expected high water ID %s got %s", tc.desc, tc.highWaterID, body.HighWaterID))
+		ts.Close()
+	}
+}
+
+func TestListOrphanedThings(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	orphaned := mgclients.ClientsPage{
+		Page:    mgclients.Page{Total: 1},
+		Clients: []mgclients.Client{client},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		response mgclients.ClientsPage
+		status   int
+		err      error
+	}{
+		{
+			desc:     "list orphaned things with valid token",
+			token:    validToken,
+			response: orphaned,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list orphaned things with filters",
+			token:    validToken,
+			query:    "name=clientname&tag=tag1&connection=offline&offset=0&limit=10",
+			response: orphaned,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list orphaned things with count only",
+			token:    validToken,
+			query:    "count_only=true",
+			response: mgclients.ClientsPage{Page: mgclients.Page{Total: 1}},
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:   "list orphaned things with invalid limit",
+			token:  validToken,
+			query:  "limit=1000",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list orphaned things with invalid token",
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list orphaned things with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "list orphaned things with non-admin token",
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/orphaned?%s", ts.URL, tc.query),
+			token:  tc.token,
 		}
 
-		if err == nil {
-			assert.Equal(t, tc.clientResponse.ID, resBody.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.clientResponse, resBody.ID))
+		svcCall := svc.On("ListOrphanedClients", mock.Anything, tc.token, mock.Anything).Return(tc.response, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
 		}
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			assert.Equal(t, int(tc.response.Total), bodyRes.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.response.Total, bodyRes.Total))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestUpdateThingsTags(t *testing.T) {
+func TestViewThing(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
 
-	newTag := "newtag"
-
 	cases := []struct {
-		desc           string
-		id             string
-		data           string
-		contentType    string
-		clientResponse mgclients.Client
-		token          string
-		status         int
-		err            error
+		desc   string
+		token  string
+		id     string
+		status int
+		err    error
 	}{
 		{
-			desc:        "update thing tags with valid token",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
-			contentType: contentType,
-			clientResponse: mgclients.Client{
-				ID:   client.ID,
-				Tags: []string{newTag},
-			},
+			desc:   "view client with valid token",
 			token:  validToken,
+			id:     client.ID,
 			status: http.StatusOK,
 			err:    nil,
 		},
 		{
-			desc:        "update thing tags with empty token",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
-			contentType: contentType,
-			token:       "",
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
+			desc:   "view client with invalid token",
+			token:  inValidToken,
+			id:     client.ID,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "update thing tags with invalid token",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
-			contentType: contentType,
-			token:       inValidToken,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			desc:   "view client with empty token",
+			token:  "",
+			id:     client.ID,
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
 		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ViewClient", mock.Anything, tc.token, tc.id).Return(mgclients.Client{}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var errRes respBody
+		err = json.NewDecoder(res.Body).Decode(&errRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if errRes.Err != "" || errRes.Message != "" {
+			err = errors.Wrap(errors.New(errRes.Err), errors.New(errRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestThingFields(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	page := mgclients.ClientsPage{
+		Page:    mgclients.Page{Total: 1, Limit: 10},
+		Clients: []mgclients.Client{client},
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		fields []string
+		status int
+		keys   []string
+	}{
 		{
-			desc:        "update thing tags with invalid id",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
-			contentType: contentType,
-			token:       validToken,
-			status:      http.StatusForbidden,
-			err:         svcerr.ErrAuthorization,
+			desc:   "view thing with fields",
+			url:    fmt.Sprintf("%s/things/%s?fields=id,name,status", ts.URL, client.ID),
+			fields: []string{"id", "name", "status"},
+			status: http.StatusOK,
+			keys:   []string{"id", "name", "status"},
 		},
 		{
-			desc:        "update thing tags with invalid contentype",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
-			contentType: "application/xml",
-			token:       validToken,
-			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			desc:   "view thing without fields",
+			url:    fmt.Sprintf("%s/things/%s", ts.URL, client.ID),
+			status: http.StatusOK,
+			keys:   []string{"id", "name", "tags", "credentials", "metadata", "created_at", "updated_at", "status"},
 		},
 		{
-			desc:        "update things tags with empty id",
-			id:          "",
-			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
-			contentType: contentType,
-			token:       validToken,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:   "view thing with unknown field",
+			url:    fmt.Sprintf("%s/things/%s?fields=id,secret", ts.URL, client.ID),
+			status: http.StatusBadRequest,
 		},
 		{
-			desc:        "update things with malfomed data",
-			id:          client.ID,
-			data:        fmt.Sprintf(`{"tags":[%s]}`, newTag),
-			contentType: contentType,
-			token:       validToken,
-			status:      http.StatusBadRequest,
-			err:         errors.ErrMalformedEntity,
+			desc:   "list things with fields",
+			url:    fmt.Sprintf("%s/things?fields=id,name", ts.URL),
+			fields: []string{"id", "name"},
+			status: http.StatusOK,
+			keys:   []string{"id", "name"},
+		},
+		{
+			desc:   "list things with unknown field",
+			url:    fmt.Sprintf("%s/things?fields=id,secret", ts.URL),
+			status: http.StatusBadRequest,
 		},
 	}
 
 	for _, tc := range cases {
 		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPatch,
-			url:         fmt.Sprintf("%s/things/%s/tags", ts.URL, tc.id),
-			contentType: tc.contentType,
-			token:       tc.token,
-			body:        strings.NewReader(tc.data),
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  validToken,
 		}
 
-		svcCall := svc.On("UpdateClientTags", mock.Anything, tc.token, mock.Anything).Return(tc.clientResponse, tc.err)
+		viewCall := svc.On("ViewClient", mock.Anything, validToken, client.ID).Return(client, nil)
+		var fields []string
+		listCall := svc.On("ListClients", mock.Anything, validToken, "", mock.Anything).Run(func(args mock.Arguments) {
+			fields = args.Get(3).(mgclients.Page).Fields
+		}).Return(page, nil)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		var resBody respBody
-		err = json.NewDecoder(res.Body).Decode(&resBody)
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if resBody.Err != "" || resBody.Message != "" {
-			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
-		}
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		svcCall.Unset()
+		if strings.Contains(tc.url, "/things?") {
+			assert.Equal(t, tc.fields, fields, fmt.Sprintf("%s: expected listed fields %v got %v", tc.desc, tc.fields, fields))
+		}
+		if tc.status == http.StatusOK {
+			var body map[string]json.RawMessage
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if things, ok := body["things"]; ok {
+				var items []map[string]json.RawMessage
+				err = json.Unmarshal(things, &items)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Len(t, items, 1, fmt.Sprintf("%s: expected a single thing", tc.desc))
+				body = items[0]
+			}
+			var keys []string
+			for k := range body {
+				keys = append(keys, k)
+			}
+			assert.ElementsMatch(t, tc.keys, keys, fmt.Sprintf("%s: expected fields %v got %v", tc.desc, tc.keys, keys))
+		}
+		viewCall.Unset()
+		listCall.Unset()
 	}
 }
 
-func TestUpdateClientSecret(t *testing.T) {
+func TestViewClients(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
 
+	unknownID := testsutil.GenerateUUID(t)
+	ids := []string{client.ID, unknownID}
+
 	cases := []struct {
 		desc        string
-		data        string
-		client      mgclients.Client
-		contentType string
 		token       string
+		contentType string
+		data        string
+		ids         []string
+		svcRes      []things.ViewResult
+		svcErr      error
+		response    []string
 		status      int
 		err         error
 	}{
 		{
-			desc: "update thing secret with valid token",
-			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
-			client: mgclients.Client{
-				ID: client.ID,
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "strongersecret",
-				},
-			},
-			contentType: contentType,
+			desc:        "view things with valid token",
 			token:       validToken,
-			status:      http.StatusOK,
-			err:         nil,
+			contentType: contentType,
+			data:        toJSON(map[string][]string{"ids": ids}),
+			ids:         ids,
+			svcRes: []things.ViewResult{
+				{ID: client.ID, Client: client},
+				{ID: unknownID, Err: errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrNotFound)},
+			},
+			response: []string{"", svcerr.ErrAuthorization.Error()},
+			status:   http.StatusOK,
+			err:      nil,
 		},
 		{
-			desc: "update thing secret with empty token",
-			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
-			client: mgclients.Client{
-				ID: client.ID,
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "strongersecret",
-				},
-			},
+			desc:        "view things with invalid token",
+			token:       inValidToken,
 			contentType: contentType,
-			token:       "",
+			data:        toJSON(map[string][]string{"ids": ids}),
+			ids:         ids,
+			svcErr:      svcerr.ErrAuthentication,
 			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
+			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc: "update thing secret with invalid token",
-			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
-			client: mgclients.Client{
-				ID: client.ID,
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "strongersecret",
-				},
-			},
+			desc:        "view things with empty token",
+			token:       "",
 			contentType: contentType,
-			token:       inValid,
+			data:        toJSON(map[string][]string{"ids": ids}),
 			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			err:         apiutil.ErrBearerToken,
 		},
 		{
-			desc: "update thing secret with empty id",
-			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
-			client: mgclients.Client{
-				ID: "",
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "strongersecret",
-				},
-			},
+			desc:        "view things with empty list",
+			token:       validToken,
 			contentType: contentType,
+			data:        toJSON(map[string][]string{"ids": {}}),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrEmptyList,
+		},
+		{
+			desc:        "view things with empty id",
 			token:       validToken,
+			contentType: contentType,
+			data:        toJSON(map[string][]string{"ids": {client.ID, ""}}),
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrMissingID,
 		},
 		{
-			desc: "update thing secret with empty secret",
-			data: fmt.Sprintf(`{"secret": "%s"}`, ""),
-			client: mgclients.Client{
-				ID: client.ID,
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "",
-				},
-			},
-			contentType: contentType,
+			desc:        "view things with too many ids",
 			token:       validToken,
+			contentType: contentType,
+			data:        toJSON(map[string][]string{"ids": {validID, validID, validID, validID}}),
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingSecret,
+			err:         apiutil.ErrLimitSize,
 		},
 		{
-			desc: "update thing secret with invalid contentype",
-			data: fmt.Sprintf(`{"secret": "%s"}`, ""),
-			client: mgclients.Client{
-				ID: client.ID,
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "",
-				},
-			},
-			contentType: "application/xml",
+			desc:        "view things with invalid content type",
 			token:       validToken,
+			contentType: "application/xml",
+			data:        toJSON(map[string][]string{"ids": ids}),
 			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			err:         apiutil.ErrUnsupportedContentType,
 		},
 		{
-			desc: "update thing secret with malformed data",
-			data: fmt.Sprintf(`{"secret": %s}`, "invalid"),
-			client: mgclients.Client{
-				ID: client.ID,
-				Credentials: mgclients.Credentials{
-					Identity: "clientname",
-					Secret:   "",
-				},
-			},
-			contentType: contentType,
+			desc:        "view things with malformed body",
 			token:       validToken,
+			contentType: contentType,
+			data:        `{"ids": [`,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			err:         errors.ErrMalformedEntity,
 		},
 	}
 
 	for _, tc := range cases {
 		req := testRequest{
 			client:      ts.Client(),
-			method:      http.MethodPatch,
-			url:         fmt.Sprintf("%s/things/%s/secret", ts.URL, tc.client.ID),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/view", ts.URL),
 			contentType: tc.contentType,
 			token:       tc.token,
 			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("UpdateClientSecret", mock.Anything, tc.token, tc.client.ID, mock.Anything).Return(tc.client, tc.err)
-
+		args := []interface{}{mock.Anything, tc.token}
+		for _, id := range tc.ids {
+			args = append(args, id)
+		}
+		svcCall := svc.On("ViewClients", args...).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		var resBody respBody
-		err = json.NewDecoder(res.Body).Decode(&resBody)
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if resBody.Err != "" || resBody.Message != "" {
-			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Things []struct {
+					ID    string            `json:"id"`
+					Thing *mgclients.Client `json:"thing"`
+					Error string            `json:"error"`
+				} `json:"things"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Len(t, body.Things, len(tc.ids), fmt.Sprintf("%s: expected %d things got %d", tc.desc, len(tc.ids), len(body.Things)))
+			for i, item := range body.Things {
+				assert.Equal(t, tc.ids[i], item.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.ids[i], item.ID))
+				assert.Equal(t, tc.response[i], item.Error, fmt.Sprintf("%s: expected error %q got %q", tc.desc, tc.response[i], item.Error))
+				assert.Equal(t, item.Error == "", item.Thing != nil, fmt.Sprintf("%s: expected thing only for entries without an error", tc.desc))
+			}
+		}
+		var errRes respBody
+		if tc.status != http.StatusOK {
+			err = json.NewDecoder(res.Body).Decode(&errRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		}
+		if errRes.Err != "" || errRes.Message != "" {
+			err = errors.Wrap(errors.New(errRes.Err), errors.New(errRes.Message))
 		}
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1140,68 +2073,72 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 }
 
-func TestEnableThing(t *testing.T) {
+func TestViewThingPerms(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
 
 	cases := []struct {
 		desc     string
-		client   mgclients.Client
-		response mgclients.Client
 		token    string
+		thingID  string
+		response []string
+		actions  []string
 		status   int
 		err      error
 	}{
 		{
-			desc:   "enable thing with valid token",
-			client: client,
-			response: mgclients.Client{
-				ID:     client.ID,
-				Status: mgclients.EnabledStatus,
-			},
-			token:  validToken,
-			status: http.StatusOK,
-			err:    nil,
+			desc:     "view thing permissions with valid token",
+			token:    validToken,
+			thingID:  client.ID,
+			response: []string{"view", "delete", "membership"},
+			actions:  []string{"read", "delete"},
+			status:   http.StatusOK,
+			err:      nil,
 		},
 		{
-			desc:   "enable thing with invalid token",
-			client: client,
-			token:  inValidToken,
-			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			desc:     "view thing permissions without permissions",
+			token:    validToken,
+			thingID:  client.ID,
+			response: []string{},
+			actions:  []string{},
+			status:   http.StatusOK,
+			err:      nil,
 		},
 		{
-			desc: "enable thing with empty id",
-			client: mgclients.Client{
-				ID: "",
-			},
-			token:  validToken,
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrMissingID,
+			desc:     "view thing permissions with invalid token",
+			token:    inValidToken,
+			thingID:  client.ID,
+			response: []string{},
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
 		},
 		{
-			desc: "enable thing with invalid id",
-			client: mgclients.Client{
-				ID: "invalid",
-			},
-			token:  validToken,
-			status: http.StatusForbidden,
-			err:    svcerr.ErrAuthorization,
+			desc:     "view thing permissions with empty token",
+			token:    "",
+			thingID:  client.ID,
+			response: []string{},
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "view thing permissions with invalid id",
+			token:    validToken,
+			thingID:  inValid,
+			response: []string{},
+			status:   http.StatusNotFound,
+			err:      svcerr.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
-		data := toJSON(tc.client)
 		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/things/%s/enable", ts.URL, tc.client.ID),
-			contentType: contentType,
-			token:       tc.token,
-			body:        strings.NewReader(data),
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/permissions", ts.URL, tc.thingID),
+			token:  tc.token,
 		}
 
-		svcCall := svc.On("EnableClient", mock.Anything, tc.token, tc.client.ID).Return(tc.response, tc.err)
+		svcCall := svc.On("ViewClientPerms", mock.Anything, tc.token, tc.thingID).Return(tc.response, tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		var resBody respBody
@@ -1210,8 +2147,9 @@ func TestEnableThing(t *testing.T) {
 		if resBody.Err != "" || resBody.Message != "" {
 			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
 		}
-		if err == nil {
-			assert.Equal(t, tc.response.Status, resBody.Status, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.Status, resBody.Status))
+		assert.Equal(t, len(tc.response), len(resBody.Permissions), fmt.Sprintf("%s: expected %d got %d", tc.desc, len(tc.response), len(resBody.Permissions)))
+		if tc.status == http.StatusOK {
+			assert.Equal(t, tc.actions, resBody.Actions, fmt.Sprintf("%s: expected actions %v got %v", tc.desc, tc.actions, resBody.Actions))
 		}
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1219,201 +2157,2960 @@ func TestEnableThing(t *testing.T) {
 	}
 }
 
-func TestDisableThing(t *testing.T) {
+func TestUpdateThing(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
 
+	newName := "newname"
+	newTag := "newtag"
+	newMetadata := mgclients.Metadata{"newkey": "newvalue"}
+
 	cases := []struct {
-		desc     string
-		client   mgclients.Client
-		response mgclients.Client
-		token    string
-		status   int
-		err      error
+		desc           string
+		id             string
+		data           string
+		clientResponse mgclients.Client
+		token          string
+		contentType    string
+		status         int
+		err            error
 	}{
 		{
-			desc:   "disable thing with valid token",
-			client: client,
-			response: mgclients.Client{
-				ID:     client.ID,
-				Status: mgclients.DisabledStatus,
+			desc:        "update thing with valid token",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			token:       validToken,
+			contentType: contentType,
+			clientResponse: mgclients.Client{
+				ID:       client.ID,
+				Name:     newName,
+				Tags:     []string{newTag},
+				Metadata: newMetadata,
 			},
-			token:  validToken,
 			status: http.StatusOK,
 			err:    nil,
 		},
 		{
-			desc:   "disable thing with invalid token",
-			client: client,
-			token:  inValidToken,
-			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			desc:        "update thing with invalid token",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			token:       inValidToken,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc: "disable thing with empty id",
-			client: mgclients.Client{
-				ID: "",
-			},
-			token:  validToken,
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrMissingID,
+			desc:        "update thing with empty token",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			token:       "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
 		},
 		{
-			desc: "disable thing with invalid id",
-			client: mgclients.Client{
-				ID: "invalid",
-			},
-			token:  validToken,
-			status: http.StatusForbidden,
-			err:    svcerr.ErrAuthorization,
-		},
-	}
-
-	for _, tc := range cases {
-		data := toJSON(tc.client)
-		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/things/%s/disable", ts.URL, tc.client.ID),
+			desc:        "update thing with invalid id",
+			id:          inValid,
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			token:       validToken,
 			contentType: contentType,
-			token:       tc.token,
-			body:        strings.NewReader(data),
-		}
-
-		svcCall := svc.On("DisableClient", mock.Anything, tc.token, tc.client.ID).Return(tc.response, tc.err)
-		res, err := req.make()
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		var resBody respBody
-		err = json.NewDecoder(res.Body).Decode(&resBody)
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if resBody.Err != "" || resBody.Message != "" {
-			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
-		}
-		if err == nil {
-			assert.Equal(t, tc.response.Status, resBody.Status, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.Status, resBody.Status))
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "update thing with invalid contentype",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "update thing with malformed data",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"name":%s}`, "invalid"),
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "update thing with empty id",
+			id:          " ",
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingID,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/things/%s", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("UpdateClient", mock.Anything, tc.token, mock.Anything, false).Return(tc.clientResponse, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+
+		if err == nil {
+			assert.Equal(t, tc.clientResponse.ID, resBody.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.clientResponse.ID, resBody.ID))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUpdateThingsTags(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	newTag := "newtag"
+
+	cases := []struct {
+		desc           string
+		id             string
+		data           string
+		contentType    string
+		clientResponse mgclients.Client
+		token          string
+		status         int
+		err            error
+	}{
+		{
+			desc:        "update thing tags with valid token",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
+			contentType: contentType,
+			clientResponse: mgclients.Client{
+				ID:   client.ID,
+				Tags: []string{newTag},
+			},
+			token:  validToken,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:        "update thing tags with empty token",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "update thing tags with invalid token",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
+			contentType: contentType,
+			token:       inValidToken,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "update thing tags with invalid id",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "update thing tags with invalid contentype",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
+			contentType: "application/xml",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "update things tags with empty id",
+			id:          "",
+			data:        fmt.Sprintf(`{"tags":["%s"]}`, newTag),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "update things with malfomed data",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"tags":[%s]}`, newTag),
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/things/%s/tags", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("UpdateClientTags", mock.Anything, tc.token, mock.Anything).Return(tc.clientResponse, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUpdateClientSecret(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		client      mgclients.Client
+		contentType string
+		token       string
+		status      int
+		err         error
+	}{
+		{
+			desc: "update thing secret with valid token",
+			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "strongersecret",
+				},
+			},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc: "update thing secret with empty token",
+			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "strongersecret",
+				},
+			},
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc: "update thing secret with invalid token",
+			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "strongersecret",
+				},
+			},
+			contentType: contentType,
+			token:       inValid,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc: "update thing secret with empty id",
+			data: fmt.Sprintf(`{"secret": "%s"}`, "strongersecret"),
+			client: mgclients.Client{
+				ID: "",
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "strongersecret",
+				},
+			},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingID,
+		},
+		{
+			desc: "update thing secret with empty secret",
+			data: fmt.Sprintf(`{"secret": "%s"}`, ""),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "",
+				},
+			},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingSecret,
+		},
+		{
+			desc: "update thing secret with invalid contentype",
+			data: fmt.Sprintf(`{"secret": "%s"}`, ""),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "",
+				},
+			},
+			contentType: "application/xml",
+			token:       validToken,
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc: "update thing secret with weak key",
+			data: fmt.Sprintf(`{"secret": "%s"}`, "weak"),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "weak",
+				},
+			},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         mgclients.ErrWeakKey,
+		},
+		{
+			desc: "update thing secret with malformed data",
+			data: fmt.Sprintf(`{"secret": %s}`, "invalid"),
+			client: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: "clientname",
+					Secret:   "",
+				},
+			},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/things/%s/secret", ts.URL, tc.client.ID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("UpdateClientSecret", mock.Anything, tc.token, tc.client.ID, mock.Anything, mock.Anything).Return(tc.client, tc.err)
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestEnableThing(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		client   mgclients.Client
+		response mgclients.Client
+		token    string
+		status   int
+		err      error
+	}{
+		{
+			desc:   "enable thing with valid token",
+			client: client,
+			response: mgclients.Client{
+				ID:     client.ID,
+				Status: mgclients.EnabledStatus,
+			},
+			token:  validToken,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "enable thing with invalid token",
+			client: client,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc: "enable thing with empty id",
+			client: mgclients.Client{
+				ID: "",
+			},
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc: "enable thing with invalid id",
+			client: mgclients.Client{
+				ID: "invalid",
+			},
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.client)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/enable", ts.URL, tc.client.ID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := svc.On("EnableClient", mock.Anything, tc.token, tc.client.ID).Return(tc.response, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+		if err == nil {
+			assert.Equal(t, tc.response.Status, resBody.Status, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.Status, resBody.Status))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDisableThing(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		client   mgclients.Client
+		response mgclients.Client
+		token    string
+		status   int
+		err      error
+	}{
+		{
+			desc:   "disable thing with valid token",
+			client: client,
+			response: mgclients.Client{
+				ID:     client.ID,
+				Status: mgclients.DisabledStatus,
+			},
+			token:  validToken,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "disable thing with invalid token",
+			client: client,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc: "disable thing with empty id",
+			client: mgclients.Client{
+				ID: "",
+			},
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc: "disable thing with invalid id",
+			client: mgclients.Client{
+				ID: "invalid",
+			},
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.client)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/disable", ts.URL, tc.client.ID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := svc.On("DisableClient", mock.Anything, tc.token, tc.client.ID).Return(tc.response, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var resBody respBody
+		err = json.NewDecoder(res.Body).Decode(&resBody)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if resBody.Err != "" || resBody.Message != "" {
+			err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		}
+		if err == nil {
+			assert.Equal(t, tc.response.Status, resBody.Status, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.Status, resBody.Status))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestShareThing(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		thingID     string
+		token       string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "share thing with valid token",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "share thing with invalid token",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       inValidToken,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "share thing with empty token",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "share thing with empty id",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     " ",
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingID,
+		},
+		{
+			desc:        "share thing with missing relation",
+			data:        fmt.Sprintf(`{"relation": "%s", user_ids" : ["%s", "%s"]}`, " ", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingRelation,
+		},
+		{
+			desc:        "share thing with malformed data",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [%s, "%s"]}`, "editor", "invalid", validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "share thing with empty thing id",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     "",
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "share thing with empty relation",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, " ", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingRelation,
+		},
+		{
+			desc:        "share thing with empty user ids",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [" ", " "]}`, "editor"),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "share thing with invalid content type",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/share", ts.URL, tc.thingID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("Share", mock.Anything, tc.token, tc.thingID, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUnShareThing(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		thingID     string
+		token       string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "unshare thing with valid token",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:        "unshare thing with invalid token",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       inValidToken,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "unshare thing with empty token",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "unshare thing with empty id",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     " ",
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingID,
+		},
+		{
+			desc:        "unshare thing with missing relation",
+			data:        fmt.Sprintf(`{"relation": "%s", user_ids" : ["%s", "%s"]}`, " ", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingRelation,
+		},
+		{
+			desc:        "unshare thing with malformed data",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [%s, "%s"]}`, "editor", "invalid", validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "unshare thing with empty thing id",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     "",
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "unshare thing with empty relation",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, " ", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingRelation,
+		},
+		{
+			desc:        "unshare thing with empty user ids",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [" ", " "]}`, "editor"),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "unshare thing with invalid content type",
+			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
+			thingID:     client.ID,
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/unshare", ts.URL, tc.thingID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("Unshare", mock.Anything, tc.token, tc.thingID, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDeleteThing(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		err    error
+	}{
+		{
+			desc:   "delete thing with valid token",
+			id:     client.ID,
+			token:  validToken,
+			status: http.StatusNoContent,
+			err:    nil,
+		},
+		{
+			desc:   "delete thing with invalid token",
+			id:     client.ID,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "delete thing with empty token",
+			id:     client.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "delete thing with empty id",
+			id:     " ",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingID,
+		},
+		{
+			desc:   "delete thing with invalid id",
+			id:     "invalid",
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "delete thing modified since given time",
+			id:     client.ID,
+			token:  validToken,
+			status: http.StatusPreconditionFailed,
+			err:    svcerr.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("DeleteClient", mock.Anything, tc.token, tc.id, mock.Anything).Return(mgclients.Client{}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestBindCert(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	fingerprint := strings.Repeat("ab", 32)
+	cert := mgclients.CertBinding{
+		Fingerprint: fingerprint,
+		ThingID:     client.ID,
+		Subject:     "CN=sensor-1",
+	}
+
+	cases := []struct {
+		desc        string
+		data        string
+		id          string
+		token       string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "bind cert with valid request",
+			data:        fmt.Sprintf(`{"fingerprint": "%s", "subject": "CN=sensor-1"}`, fingerprint),
+			id:          client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "bind cert with invalid token",
+			data:        fmt.Sprintf(`{"fingerprint": "%s"}`, fingerprint),
+			id:          client.ID,
+			token:       inValidToken,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "bind cert with empty token",
+			data:        fmt.Sprintf(`{"fingerprint": "%s"}`, fingerprint),
+			id:          client.ID,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "bind cert already bound",
+			data:        fmt.Sprintf(`{"fingerprint": "%s"}`, fingerprint),
+			id:          client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+			err:         svcerr.ErrConflict,
+		},
+		{
+			desc:        "bind cert without fingerprint",
+			data:        `{"subject": "CN=sensor-1"}`,
+			id:          client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingCertData,
+		},
+		{
+			desc:        "bind cert with invalid fingerprint",
+			data:        `{"fingerprint": "ab:cd"}`,
+			id:          client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidCertData,
+		},
+		{
+			desc:        "bind cert with malformed request",
+			data:        `{"fingerprint": 1}`,
+			id:          client.ID,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "bind cert with invalid content type",
+			data:        fmt.Sprintf(`{"fingerprint": "%s"}`, fingerprint),
+			id:          client.ID,
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/certs", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("BindCert", mock.Anything, tc.token, mock.Anything).Return(cert, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		key    string
+		id     string
+		svcErr error
+		status int
+		code   string
+	}{
+		{
+			desc:   "heartbeat with valid key",
+			key:    secret,
+			id:     client.ID,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "heartbeat with invalid key",
+			key:    inValid,
+			id:     client.ID,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+			code:   "authorization_failed",
+		},
+		{
+			desc:   "heartbeat without key",
+			id:     client.ID,
+			status: http.StatusBadRequest,
+			code:   "missing_bearer_key",
+		},
+		{
+			desc:   "heartbeat sent too often",
+			key:    secret,
+			id:     client.ID,
+			svcErr: svcerr.ErrRateLimited,
+			status: http.StatusTooManyRequests,
+			code:   "rate_limited",
+		},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/things/%s/heartbeat", ts.URL, tc.id), http.NoBody)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.key != "" {
+			req.Header.Set("Authorization", apiutil.ThingPrefix+tc.key)
+		}
+
+		svcCall := svc.On("Heartbeat", mock.Anything, tc.key, tc.id).Return(tc.svcErr)
+		res, err := ts.Client().Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.code != "" {
+			var bodyRes respBody
+			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.code, bodyRes.Code, fmt.Sprintf("%s: expected code %s got %s", tc.desc, tc.code, bodyRes.Code))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	expiresAt := time.Now().Add(time.Hour).Round(time.Second).UTC()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		svcRes      things.KeyValidation
+		svcErr      error
+		status      int
+		valid       bool
+		thingID     string
+		ttl         bool
+		code        string
+	}{
+		{
+			desc:        "validate valid key",
+			token:       gatewayToken,
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: contentType,
+			svcRes:      things.KeyValidation{Valid: true, ThingID: client.ID},
+			status:      http.StatusOK,
+			valid:       true,
+			thingID:     client.ID,
+		},
+		{
+			desc:        "validate expiring key",
+			token:       gatewayToken,
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: contentType,
+			svcRes:      things.KeyValidation{Valid: true, ThingID: client.ID, ExpiresAt: &expiresAt},
+			status:      http.StatusOK,
+			valid:       true,
+			thingID:     client.ID,
+			ttl:         true,
+		},
+		{
+			desc:        "validate invalid key",
+			token:       gatewayToken,
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "validate key with service failure",
+			token:       gatewayToken,
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: contentType,
+			svcErr:      svcerr.ErrServiceUnavailable,
+			status:      http.StatusServiceUnavailable,
+			code:        "service_unavailable",
+		},
+		{
+			desc:        "validate empty key",
+			token:       gatewayToken,
+			data:        `{"key": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			code:        "missing_secret",
+		},
+		{
+			desc:        "validate key with malformed body",
+			token:       gatewayToken,
+			data:        `{"key": `,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "validate key without gateway token",
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "validate key with invalid gateway token",
+			token:       inValidToken,
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "validate key with invalid content type",
+			token:       gatewayToken,
+			data:        fmt.Sprintf(`{"key": "%s"}`, secret),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/keys/validate", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("ValidateKey", mock.Anything, secret).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		var bodyRes struct {
+			Valid     bool       `json:"valid"`
+			ThingID   string     `json:"thing_id"`
+			ExpiresAt *time.Time `json:"expires_at"`
+			TTL       *int64     `json:"ttl"`
+			Code      string     `json:"code"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if tc.status == http.StatusOK {
+			assert.Equal(t, tc.valid, bodyRes.Valid, fmt.Sprintf("%s: expected valid %t got %t", tc.desc, tc.valid, bodyRes.Valid))
+			assert.Equal(t, tc.thingID, bodyRes.ThingID, fmt.Sprintf("%s: expected thing ID %s got %s", tc.desc, tc.thingID, bodyRes.ThingID))
+			assert.Equal(t, tc.ttl, bodyRes.TTL != nil, fmt.Sprintf("%s: unexpected TTL %v", tc.desc, bodyRes.TTL))
+			if tc.ttl {
+				assert.True(t, expiresAt.Equal(*bodyRes.ExpiresAt), fmt.Sprintf("%s: expected expiry %s got %s", tc.desc, expiresAt, bodyRes.ExpiresAt))
+				assert.InDelta(t, time.Hour.Seconds(), *bodyRes.TTL, 5, fmt.Sprintf("%s: unexpected TTL %d", tc.desc, *bodyRes.TTL))
+			}
+		}
+		if tc.code != "" {
+			assert.Equal(t, tc.code, bodyRes.Code, fmt.Sprintf("%s: expected code %s got %s", tc.desc, tc.code, bodyRes.Code))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestIdentifyCert(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cert := &x509.Certificate{
+		Raw:     []byte("certificate"),
+		Subject: pkix.Name{CommonName: "sensor-1", Organization: []string{"Acme"}},
+	}
+	fingerprint := mgclients.CertFingerprint(cert.Raw)
+	verified := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	forwarded := toJSON(map[string]string{"fingerprint": fingerprint, "subject": cert.Subject.String()})
+
+	cases := []struct {
+		desc        string
+		tls         *tls.ConnectionState
+		token       string
+		contentType string
+		body        string
+		forwarded   bool
+		status      int
+		svcRes      string
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:   "identify thing with known cert",
+			tls:    verified,
+			status: http.StatusOK,
+			svcRes: client.ID,
+		},
+		{
+			desc:   "identify thing with unknown cert",
+			tls:    verified,
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "identify disabled thing",
+			tls:    verified,
+			status: http.StatusForbidden,
+			svcErr: errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrThingDisabled),
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "identify thing without TLS",
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "identify thing without verified cert",
+			tls:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "identify thing with cert forwarded by gateway",
+			token:       gatewayToken,
+			contentType: contentType,
+			body:        forwarded,
+			forwarded:   true,
+			status:      http.StatusOK,
+			svcRes:      client.ID,
+		},
+		{
+			desc:        "identify thing with cert forwarded with invalid token",
+			token:       inValidToken,
+			contentType: contentType,
+			body:        forwarded,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "identify thing with cert forwarded without content type",
+			token:  gatewayToken,
+			body:   forwarded,
+			status: http.StatusUnsupportedMediaType,
+			err:    apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "identify thing with malformed forwarded cert",
+			token:       gatewayToken,
+			contentType: contentType,
+			body:        "{",
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "identify thing with forwarded cert without fingerprint",
+			token:       gatewayToken,
+			contentType: contentType,
+			body:        toJSON(map[string]string{"subject": cert.Subject.String()}),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingCertData,
+		},
+	}
+
+	calls := 0
+	for _, tc := range cases {
+		// The verified chain is set by the TLS server, so the request is
+		// served directly with the connection state in place.
+		req := httptest.NewRequest(http.MethodPost, "/identify/cert", strings.NewReader(tc.body))
+		req.TLS = tc.tls
+		if tc.token != "" {
+			req.Header.Set("Authorization", apiutil.BearerPrefix+tc.token)
+		}
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		rec := httptest.NewRecorder()
+
+		svcCall := svc.On("IdentifyCert", mock.Anything, fingerprint, cert.Subject.String()).Return(tc.svcRes, tc.svcErr)
+		ts.Config.Handler.ServeHTTP(rec, req)
+		res := rec.Result()
+		var bodyRes struct {
+			ID      string `json:"id"`
+			Err     string `json:"error"`
+			Message string `json:"message"`
+		}
+		err := json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.svcRes, bodyRes.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.svcRes, bodyRes.ID))
+		if tc.tls == verified || tc.forwarded {
+			calls++
+		}
+		svc.AssertNumberOfCalls(t, "IdentifyCert", calls)
+		svcCall.Unset()
+	}
+}
+
+func TestUnbindCert(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	fingerprint := strings.Repeat("ab", 32)
+
+	cases := []struct {
+		desc        string
+		id          string
+		fingerprint string
+		token       string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "unbind cert with valid request",
+			id:          client.ID,
+			fingerprint: fingerprint,
+			token:       validToken,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "unbind cert with invalid token",
+			id:          client.ID,
+			fingerprint: fingerprint,
+			token:       inValidToken,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "unbind cert with empty token",
+			id:          client.ID,
+			fingerprint: fingerprint,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "unbind cert not bound to the thing",
+			id:          client.ID,
+			fingerprint: fingerprint,
+			token:       validToken,
+			status:      http.StatusNotFound,
+			svcErr:      svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "unbind cert with invalid fingerprint",
+			id:          client.ID,
+			fingerprint: "xyz",
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidCertData,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/%s/certs/%s", ts.URL, tc.id, tc.fingerprint),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("UnbindCert", mock.Anything, tc.token, tc.id, tc.fingerprint).Return(tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.err != nil {
+			var bodyRes respBody
+			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if bodyRes.Err != "" || bodyRes.Message != "" {
+				err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	st := mgclients.ScopedToken{
+		ID:       testsutil.GenerateUUID(t),
+		Token:    mgclients.ScopedTokenPrefix + testsutil.GenerateUUID(t),
+		DomainID: testsutil.GenerateUUID(t),
+		Scopes:   []string{mgclients.ThingsReadScope},
+	}
+
+	cases := []struct {
+		desc        string
+		data        string
+		token       string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "issue token with valid request",
+			data:        `{"scopes": ["things:read"], "ttl": 3600}`,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "issue token with invalid token",
+			data:        `{"scopes": ["things:read"]}`,
+			token:       inValidToken,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "issue token with scoped token",
+			data:        `{"scopes": ["things:read"]}`,
+			token:       st.Token,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrTokenScope),
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "issue token with empty token",
+			data:        `{"scopes": ["things:read"]}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "issue token with invalid scope",
+			data:        `{"scopes": ["things:admin"]}`,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidScope,
+		},
+		{
+			desc:        "issue token with too long ttl",
+			data:        `{"scopes": ["things:read"], "ttl": 9223372037}`,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidTTL,
+		},
+		{
+			desc:        "issue token with malformed request",
+			data:        `{"scopes": "things:read"}`,
+			token:       validToken,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "issue token with invalid content type",
+			data:        `{"scopes": ["things:read"]}`,
+			token:       validToken,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/tokens", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("IssueToken", mock.Anything, tc.token, mock.Anything, mock.Anything).Return(st, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListTokens(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	page := mgclients.ScopedTokensPage{
+		Page: mgclients.Page{Total: 1, Offset: 0, Limit: 10},
+		Tokens: []mgclients.ScopedToken{{
+			ID:       testsutil.GenerateUUID(t),
+			DomainID: testsutil.GenerateUUID(t),
+			Scopes:   []string{mgclients.ThingsReadScope},
+		}},
+	}
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		page   mgclients.Page
+		status int
+		total  int
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "list tokens with valid token",
+			token:  validToken,
+			page:   mgclients.Page{Offset: 0, Limit: 10},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list tokens with offset and limit",
+			query:  "?offset=1&limit=5",
+			token:  validToken,
+			page:   mgclients.Page{Offset: 1, Limit: 5},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list tokens with invalid token",
+			token:  inValidToken,
+			page:   mgclients.Page{Offset: 0, Limit: 10},
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list tokens with empty token",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "list tokens with limit above max",
+			query:  fmt.Sprintf("?limit=%d", api.MaxLimitSize+1),
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list tokens with invalid offset",
+			query:  "?offset=invalid",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/tokens%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ListTokens", mock.Anything, tc.token, tc.page).Return(page, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.total, bodyRes.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, bodyRes.Total))
+		svcCall.Unset()
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		err    error
+	}{
+		{
+			desc:   "revoke token with valid token",
+			id:     validID,
+			token:  validToken,
+			status: http.StatusNoContent,
+			err:    nil,
+		},
+		{
+			desc:   "revoke token with invalid token",
+			id:     validID,
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "revoke token with empty token",
+			id:     validID,
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "revoke non-existing token",
+			id:     inValid,
+			token:  validToken,
+			status: http.StatusUnprocessableEntity,
+			err:    svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/tokens/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RevokeToken", mock.Anything, tc.token, tc.id).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestReconcileCache(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	rec := things.CacheReconciliation{Running: true, StartedAt: time.Now().UTC(), Added: 2, Corrected: 1, Removed: 3}
+
+	cases := []struct {
+		desc   string
+		method string
+		svcFn  string
+		token  string
+		rec    things.CacheReconciliation
+		status int
+		err    error
+	}{
+		{
+			desc:   "reconcile cache with valid token",
+			method: http.MethodPost,
+			svcFn:  "ReconcileCache",
+			token:  validToken,
+			rec:    rec,
+			status: http.StatusAccepted,
+			err:    nil,
+		},
+		{
+			desc:   "reconcile cache with invalid token",
+			method: http.MethodPost,
+			svcFn:  "ReconcileCache",
+			token:  inValidToken,
+			status: http.StatusUnauthorized,
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "reconcile cache with empty token",
+			method: http.MethodPost,
+			svcFn:  "ReconcileCache",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "reconcile cache with non-admin token",
+			method: http.MethodPost,
+			svcFn:  "ReconcileCache",
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "view cache reconciliation with valid token",
+			method: http.MethodGet,
+			svcFn:  "ViewCacheReconciliation",
+			token:  validToken,
+			rec:    rec,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "view cache reconciliation with empty token",
+			method: http.MethodGet,
+			svcFn:  "ViewCacheReconciliation",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "view cache reconciliation with non-admin token",
+			method: http.MethodGet,
+			svcFn:  "ViewCacheReconciliation",
+			token:  validToken,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    fmt.Sprintf("%s/cache/reconcile", ts.URL),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On(tc.svcFn, mock.Anything, tc.token).Return(tc.rec, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.err == nil {
+			var body struct {
+				Running   bool      `json:"running"`
+				StartedAt time.Time `json:"started_at"`
+				Added     uint64    `json:"added"`
+				Corrected uint64    `json:"corrected"`
+				Removed   uint64    `json:"removed"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.True(t, body.Running, fmt.Sprintf("%s: expected running reconciliation", tc.desc))
+			assert.True(t, tc.rec.StartedAt.Equal(body.StartedAt), fmt.Sprintf("%s: expected start %s got %s", tc.desc, tc.rec.StartedAt, body.StartedAt))
+			assert.Equal(t, tc.rec.Added, body.Added, fmt.Sprintf("%s: expected %d added got %d", tc.desc, tc.rec.Added, body.Added))
+			assert.Equal(t, tc.rec.Corrected, body.Corrected, fmt.Sprintf("%s: expected %d corrected got %d", tc.desc, tc.rec.Corrected, body.Corrected))
+			assert.Equal(t, tc.rec.Removed, body.Removed, fmt.Sprintf("%s: expected %d removed got %d", tc.desc, tc.rec.Removed, body.Removed))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestListMembers(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc                string
+		query               string
+		token               string
+		listMembersResponse mgclients.MembersPage
+		status              int
+		err                 error
+		groupdID            string
+	}{
+		{
+			desc:     "list members with valid token",
+			token:    validToken,
+			groupdID: client.ID,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list members with empty token",
+			token:    "",
+			groupdID: client.ID,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "list members with invalid token",
+			token:    inValidToken,
+			groupdID: client.ID,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list members with offset",
+			token:    validToken,
+			query:    "offset=1",
+			groupdID: client.ID,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Offset: 1,
+					Total:  1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list members with invalid offset",
+			token:    validToken,
+			query:    "offset=invalid",
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with limit",
+			token:    validToken,
+			query:    "limit=1",
+			groupdID: client.ID,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Limit: 1,
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list members with invalid limit",
+			token:    validToken,
+			query:    "limit=invalid",
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with limit greater than 100",
+			token:    validToken,
+			query:    fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with channel_id",
+			token:    validToken,
+			query:    fmt.Sprintf("channel_id=%s", validID),
+			groupdID: client.ID,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list members with invalid channel_id",
+			token:    validToken,
+			query:    "channel_id=invalid",
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with duplicate channel_id",
+			token:    validToken,
+			query:    fmt.Sprintf("channel_id=%s&channel_id=%s", validID, validID),
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with connected set",
+			token:    validToken,
+			query:    "connected=true",
+			groupdID: client.ID,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list members with invalid connected set",
+			token:    validToken,
+			query:    "connected=invalid",
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:   "list members with duplicate connected set",
+			token:  validToken,
+			query:  "connected=true&connected=false",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with invalid group id",
+			token:    validToken,
+			query:    "",
+			groupdID: "invalid",
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "list members with empty group id",
+			token:    validToken,
+			query:    "",
+			groupdID: "",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:  "list members with status",
+			query: fmt.Sprintf("status=%s", mgclients.EnabledStatus),
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:  "list online members",
+			query: "status=online",
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list members with invalid status",
+			query:    "status=invalid",
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with duplicate status",
+			query:    fmt.Sprintf("status=%s&status=%s", mgclients.EnabledStatus, mgclients.DisabledStatus),
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:  "list members with metadata",
+			token: validToken,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			groupdID: client.ID,
+			query:    "metadata=%7B%22domain%22%3A%20%22example.com%22%7D&",
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list members with invalid metadata",
+			query:    "metadata=invalid",
+			groupdID: client.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with duplicate metadata",
+			query:    "metadata=%7B%22domain%22%3A%20%22example.com%22%7D&metadata=%7B%22domain%22%3A%20%22example.com%22%7D",
+			groupdID: client.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:  "list members with permission",
+			query: fmt.Sprintf("permission=%s", "read"),
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list members with invalid permission",
+			query:    "permission=invalid",
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with duplicate permission",
+			query:    fmt.Sprintf("permission=%s&permission=%s", "read", "write"),
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:  "list members with list permission",
+			query: "list_perms=true",
+			token: validToken,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Total: 1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			groupdID: client.ID,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list members with invalid list permission",
+			query:    "list_perms=invalid",
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with duplicate list permission",
+			query:    "list_perms=true&list_perms=false",
+			token:    validToken,
+			groupdID: client.ID,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list members with all query params",
+			query:    fmt.Sprintf("offset=1&limit=1&channel_id=%s&connected=true&status=%s&metadata=%s&permission=%s&list_perms=true", validID, mgclients.EnabledStatus, "%7B%22domain%22%3A%20%22example.com%22%7D", "read"),
+			token:    validToken,
+			groupdID: client.ID,
+			listMembersResponse: mgclients.MembersPage{
+				Page: mgclients.Page{
+					Offset: 1,
+					Limit:  1,
+					Total:  1,
+				},
+				Members: []mgclients.Client{client},
+			},
+			status: http.StatusOK,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodGet,
+			url:         ts.URL + fmt.Sprintf("/channels/%s/things?", tc.groupdID) + tc.query,
+			contentType: contentType,
+			token:       tc.token,
+		}
+
+		svcCall := svc.On("ListClientsByGroup", mock.Anything, tc.token, mock.Anything, mock.Anything).Return(tc.listMembersResponse, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var bodyRes respBody
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRotateKeys(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	rotated := client
+	rotated.Credentials.Secret = "newkey"
+
+	cases := []struct {
+		desc     string
+		query    string
+		token    string
+		groupID  string
+		response mgclients.ClientsPage
+		keys     map[string]string
+		status   int
+		err      error
+	}{
+		{
+			desc:    "rotate keys with valid token",
+			token:   validToken,
+			groupID: validID,
+			response: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1, Limit: 10},
+				Clients: []mgclients.Client{rotated},
+			},
+			keys:   map[string]string{rotated.ID: rotated.Credentials.Secret},
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:    "rotate keys with empty token",
+			token:   "",
+			groupID: validID,
+			status:  http.StatusUnauthorized,
+			err:     apiutil.ErrBearerToken,
+		},
+		{
+			desc:    "rotate keys with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			status:  http.StatusUnauthorized,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "rotate keys with unauthorized user",
+			token:   validToken,
+			groupID: validID,
+			status:  http.StatusForbidden,
+			err:     svcerr.ErrAuthorization,
+		},
+		{
+			desc:    "rotate keys with limit greater than max",
+			token:   validToken,
+			groupID: validID,
+			query:   fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
+			status:  http.StatusBadRequest,
+			err:     apiutil.ErrValidation,
+		},
+		{
+			desc:    "rotate keys with invalid offset",
+			token:   validToken,
+			groupID: validID,
+			query:   "offset=invalid",
+			status:  http.StatusBadRequest,
+			err:     apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    ts.URL + fmt.Sprintf("/channels/%s/things/rotate-keys?", tc.groupID) + tc.query,
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("RotateKeys", mock.Anything, tc.token, tc.groupID, mock.Anything).Return(tc.response, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var bodyRes struct {
+			Keys    map[string]string `json:"keys"`
+			Err     string            `json:"error"`
+			Message string            `json:"message"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.err == nil {
+			assert.Equal(t, tc.keys, bodyRes.Keys, fmt.Sprintf("%s: expected keys %v got %v", tc.desc, tc.keys, bodyRes.Keys))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestTransferChannel(t *testing.T) {
+	ts, svc, _ := newThingsServer()
+	defer ts.Close()
+
+	targetDomainID := testsutil.GenerateUUID(t)
+	moved := client
+	moved.Domain = targetDomainID
+	transfer := things.ChannelTransfer{
+		Channel: groups.Group{ID: validID, Domain: targetDomainID, Name: "channel"},
+		Things:  []mgclients.Client{moved},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		groupID     string
+		data        string
+		contentType string
+		response    things.ChannelTransfer
+		status      int
+		err         error
+	}{
+		{
+			desc:        "transfer channel with valid token",
+			token:       validToken,
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: contentType,
+			response:    transfer,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "transfer channel with empty token",
+			token:       "",
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "transfer channel with invalid token",
+			token:       inValidToken,
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "transfer channel without target domain",
+			token:       validToken,
+			groupID:     validID,
+			data:        `{}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingID,
+		},
+		{
+			desc:        "transfer channel with invalid content type",
+			token:       validToken,
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "transfer channel with malformed body",
+			token:       validToken,
+			groupID:     validID,
+			data:        `{"target_domain_id": 1}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "transfer channel to the same domain",
+			token:       validToken,
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         groups.ErrSameDomain,
+		},
+		{
+			desc:        "transfer channel with name taken in the target domain",
+			token:       validToken,
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: contentType,
+			status:      http.StatusConflict,
+			err:         groups.ErrNameConflict,
+		},
+		{
+			desc:        "transfer channel with shared thing",
+			token:       validToken,
+			groupID:     validID,
+			data:        fmt.Sprintf(`{"target_domain_id": "%s"}`, targetDomainID),
+			contentType: contentType,
+			status:      http.StatusConflict,
+			err:         groups.ErrSharedThing,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/transfer", ts.URL, tc.groupID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.data),
+		}
+
+		svcCall := svc.On("TransferChannel", mock.Anything, tc.token, tc.groupID, targetDomainID).Return(tc.response, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var bodyRes struct {
+			Channel groups.Group       `json:"channel"`
+			Things  []mgclients.Client `json:"things"`
+			Err     string             `json:"error"`
+			Message string             `json:"message"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&bodyRes)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		if bodyRes.Err != "" || bodyRes.Message != "" {
+			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.err == nil {
+			assert.Equal(t, targetDomainID, bodyRes.Channel.Domain, fmt.Sprintf("%s: expected channel domain %s got %s", tc.desc, targetDomainID, bodyRes.Channel.Domain))
+			assert.Len(t, bodyRes.Things, 1, fmt.Sprintf("%s: expected one thing", tc.desc))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestAssignUsers(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		groupID     string
+		reqBody     interface{}
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:    "assign users to a group successfully",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:    "assign users to a group with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "assign users to a group with empty token",
+			token:   "",
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:    "assign users to a group with empty group id",
+			token:   validToken,
+			groupID: "",
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "assign users to a group with empty relation",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "assign users to a group with empty user ids",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "assign users to a group with invalid request body",
+			token:   validToken,
+			groupID: validID,
+			reqBody: map[string]interface{}{
+				"relation": make(chan int),
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         nil,
+		},
+		{
+			desc:    "assign users to a group with invalid content type",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/users/assign", ts.URL, tc.groupID),
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := gsvc.On("Assign", mock.Anything, tc.token, tc.groupID, mock.Anything, "users", mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUnassignUsers(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		groupID     string
+		reqBody     interface{}
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:    "unassign users from a group successfully",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:    "unassign users from a group with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "unassign users from a group with empty token",
+			token:   "",
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:    "unassign users from a group with empty group id",
+			token:   validToken,
+			groupID: "",
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "unassign users from a group with empty relation",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "unassign users from a group with empty user ids",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "unassign users from a group with invalid request body",
+			token:   validToken,
+			groupID: validID,
+			reqBody: map[string]interface{}{
+				"relation": make(chan int),
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         nil,
+		},
+		{
+			desc:    "unassign users from a group with invalid content type",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				Relation: "member",
+				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/users/unassign", ts.URL, tc.groupID),
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.groupID, mock.Anything, "users", mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestAssignGroupsToChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		groupID     string
+		reqBody     interface{}
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:    "assign groups to a channel successfully",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:    "assign groups to a channel with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "assign groups to a channel with empty token",
+			token:   "",
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:    "assign groups to a channel with empty group id",
+			token:   validToken,
+			groupID: "",
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "assign groups to a channel with empty group ids",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "assign groups to a channel with invalid request body",
+			token:   validToken,
+			groupID: validID,
+			reqBody: map[string]interface{}{
+				"group_ids": make(chan int),
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "assign groups to a channel with invalid content type",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/groups/assign", ts.URL, tc.groupID),
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := gsvc.On("Assign", mock.Anything, tc.token, tc.groupID, mock.Anything, "channels", mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUnassignGroupsFromChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		groupID     string
+		reqBody     interface{}
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:    "unassign groups from a channel successfully",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:    "unassign groups from a channel with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "unassign groups from a channel with empty token",
+			token:   "",
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:    "unassign groups from a channel with empty group id",
+			token:   validToken,
+			groupID: "",
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "unassign groups from a channel with empty group ids",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{},
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "unassign groups from a channel with invalid request body",
+			token:   validToken,
+			groupID: validID,
+			reqBody: map[string]interface{}{
+				"group_ids": make(chan int),
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:    "unassign groups from a channel with invalid content type",
+			token:   validToken,
+			groupID: validID,
+			reqBody: groupReqBody{
+				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/groups/unassign", ts.URL, tc.groupID),
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(data),
 		}
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.groupID, mock.Anything, "channels", mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 		svcCall.Unset()
 	}
 }
 
-func TestShareThing(t *testing.T) {
-	ts, svc, _ := newThingsServer()
+func TestConnectThingToChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
 	cases := []struct {
 		desc        string
-		data        string
-		thingID     string
 		token       string
+		channelID   string
+		thingID     string
 		contentType string
 		status      int
 		err         error
 	}{
 		{
-			desc:        "share thing with valid token",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
+			desc:        "connect thing to a channel successfully",
 			token:       validToken,
+			channelID:   validID,
+			thingID:     validID,
 			contentType: contentType,
 			status:      http.StatusCreated,
 			err:         nil,
 		},
 		{
-			desc:        "share thing with invalid token",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
+			desc:        "connect thing to a channel with invalid token",
 			token:       inValidToken,
+			channelID:   validID,
+			thingID:     validID,
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
 			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "share thing with empty token",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
-			token:       "",
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
-		},
-		{
-			desc:        "share thing with empty id",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     " ",
-			token:       validToken,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingID,
-		},
-		{
-			desc:        "share thing with missing relation",
-			data:        fmt.Sprintf(`{"relation": "%s", user_ids" : ["%s", "%s"]}`, " ", validID, validID),
-			thingID:     client.ID,
+			desc:        "connect thing to a channel with empty channel id",
 			token:       validToken,
+			channelID:   "",
+			thingID:     validID,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingRelation,
+			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "share thing with malformed data",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [%s, "%s"]}`, "editor", "invalid", validID),
-			thingID:     client.ID,
+			desc:        "connect thing to a channel with empty thing id",
 			token:       validToken,
+			channelID:   validID,
+			thingID:     "",
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
+	}
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/things/%s/connect", ts.URL, tc.channelID, tc.thingID),
+			token:       tc.token,
+			contentType: tc.contentType,
+		}
+
+		svcCall := gsvc.On("Assign", mock.Anything, tc.token, tc.channelID, "group", "things", []string{tc.thingID}).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDisconnectThingFromChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		channelID   string
+		thingID     string
+		contentType string
+		status      int
+		err         error
+	}{
 		{
-			desc:        "share thing with empty thing id",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     "",
+			desc:        "disconnect thing from a channel successfully",
 			token:       validToken,
+			channelID:   validID,
+			thingID:     validID,
 			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			status:      http.StatusNoContent,
+			err:         nil,
 		},
 		{
-			desc:        "share thing with empty relation",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, " ", validID, validID),
-			thingID:     client.ID,
-			token:       validToken,
+			desc:        "disconnect thing from a channel with invalid token",
+			token:       inValidToken,
+			channelID:   validID,
+			thingID:     validID,
 			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingRelation,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "share thing with empty user ids",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [" ", " "]}`, "editor"),
-			thingID:     client.ID,
+			desc:        "disconnect thing from a channel with empty channel id",
 			token:       validToken,
+			channelID:   "",
+			thingID:     validID,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "share thing with invalid content type",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
+			desc:        "disconnect thing from a channel with empty thing id",
 			token:       validToken,
-			contentType: "application/xml",
-			status:      http.StatusUnsupportedMediaType,
+			channelID:   validID,
+			thingID:     "",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
 	}
-
 	for _, tc := range cases {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/things/%s/share", ts.URL, tc.thingID),
-			contentType: tc.contentType,
+			url:         fmt.Sprintf("%s/channels/%s/things/%s/disconnect", ts.URL, tc.channelID, tc.thingID),
 			token:       tc.token,
-			body:        strings.NewReader(tc.data),
+			contentType: tc.contentType,
 		}
 
-		svcCall := svc.On("Share", mock.Anything, tc.token, tc.thingID, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.channelID, "group", "things", []string{tc.thingID}).Return(tc.err)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1421,647 +5118,695 @@ func TestShareThing(t *testing.T) {
 	}
 }
 
-func TestUnShareThing(t *testing.T) {
-	ts, svc, _ := newThingsServer()
+func TestConnect(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
 	cases := []struct {
 		desc        string
-		data        string
-		thingID     string
 		token       string
+		reqBody     interface{}
 		contentType string
 		status      int
 		err         error
 	}{
 		{
-			desc:        "unshare thing with valid token",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
-			token:       validToken,
+			desc:  "connect thing to a channel successfully",
+			token: validToken,
+			reqBody: groupReqBody{
+				ChannelID: validID,
+				ThingID:   validID,
+			},
 			contentType: contentType,
-			status:      http.StatusNoContent,
+			status:      http.StatusCreated,
 			err:         nil,
 		},
 		{
-			desc:        "unshare thing with invalid token",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
-			token:       inValidToken,
+			desc:  "connect thing to a channel with invalid token",
+			token: inValidToken,
+			reqBody: groupReqBody{
+				ChannelID: validID,
+				ThingID:   validID,
+			},
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
 			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "unshare thing with empty token",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
-			token:       "",
+			desc:  "connect thing to a channel with empty channel id",
+			token: validToken,
+			reqBody: groupReqBody{
+				ChannelID: "",
+				ThingID:   validID,
+			},
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "unshare thing with empty id",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     " ",
-			token:       validToken,
+			desc:  "connect thing to a channel with empty thing id",
+			token: validToken,
+			reqBody: groupReqBody{
+				ChannelID: validID,
+				ThingID:   "",
+			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingID,
+			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "unshare thing with missing relation",
-			data:        fmt.Sprintf(`{"relation": "%s", user_ids" : ["%s", "%s"]}`, " ", validID, validID),
-			thingID:     client.ID,
-			token:       validToken,
+			desc:  "connect thing to a channel with invalid request body",
+			token: validToken,
+			reqBody: map[string]interface{}{
+				"channel_id": make(chan int),
+			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingRelation,
+			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "unshare thing with malformed data",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [%s, "%s"]}`, "editor", "invalid", validID),
-			thingID:     client.ID,
+			desc:  "connect thing to a channel with invalid content type",
+			token: validToken,
+			reqBody: groupReqBody{
+				ChannelID: validID,
+				ThingID:   validID,
+			},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/connect", ts.URL),
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := gsvc.On("Assign", mock.Anything, tc.token, mock.Anything, "group", "things", mock.Anything).Return(tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestCloneChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	targetID := testsutil.GenerateUUID(t)
+	clone := groups.Group{ID: testsutil.GenerateUUID(t), Name: "clone", Parent: targetID}
+
+	cases := []struct {
+		desc        string
+		token       string
+		groupID     string
+		data        string
+		contentType string
+		name        string
+		parentID    string
+		svcRes      groups.Group
+		svcErr      error
+		status      int
+		location    string
+	}{
+		{
+			desc:        "clone channel successfully",
 			token:       validToken,
+			groupID:     validID,
+			data:        toJSON(map[string]string{"name": "clone", "target_group_id": targetID}),
 			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			name:        "clone",
+			parentID:    targetID,
+			svcRes:      clone,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/channels/%s", clone.ID),
+		},
+		{
+			desc:     "clone channel without body",
+			token:    validToken,
+			groupID:  validID,
+			svcRes:   clone,
+			status:   http.StatusCreated,
+			location: fmt.Sprintf("/channels/%s", clone.ID),
 		},
 		{
-			desc:        "unshare thing with empty thing id",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     "",
-			token:       validToken,
+			desc:        "clone channel with invalid token",
+			token:       inValidToken,
+			groupID:     validID,
+			data:        toJSON(map[string]string{"name": "clone"}),
 			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			name:        "clone",
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "unshare thing with empty relation",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, " ", validID, validID),
-			thingID:     client.ID,
+			desc:        "clone channel without permission",
 			token:       validToken,
+			groupID:     validID,
+			data:        toJSON(map[string]string{"target_group_id": targetID}),
 			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrMissingRelation,
+			parentID:    targetID,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
 		},
 		{
-			desc:        "unshare thing with empty user ids",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : [" ", " "]}`, "editor"),
-			thingID:     client.ID,
+			desc:        "clone channel with long name",
 			token:       validToken,
+			groupID:     validID,
+			data:        toJSON(map[string]string{"name": strings.Repeat("a", api.MaxNameSize+1)}),
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "unshare thing with invalid content type",
-			data:        fmt.Sprintf(`{"relation": "%s", "user_ids" : ["%s", "%s"]}`, "editor", validID, validID),
-			thingID:     client.ID,
+			desc:        "clone channel with invalid content type",
 			token:       validToken,
+			groupID:     validID,
+			data:        toJSON(map[string]string{"name": "clone"}),
 			contentType: "application/xml",
 			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "clone channel with malformed body",
+			token:       validToken,
+			groupID:     validID,
+			data:        `{"name": `,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
 		},
 	}
-
 	for _, tc := range cases {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/things/%s/unshare", ts.URL, tc.thingID),
-			contentType: tc.contentType,
+			url:         fmt.Sprintf("%s/channels/%s/clone", ts.URL, tc.groupID),
 			token:       tc.token,
+			contentType: tc.contentType,
 			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("Unshare", mock.Anything, tc.token, tc.thingID, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("CloneGroup", mock.Anything, tc.token, auth.NewChannelKind, tc.groupID, tc.parentID, tc.name).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
 		svcCall.Unset()
 	}
 }
 
-func TestDeleteThing(t *testing.T) {
-	ts, svc, _ := newThingsServer()
+func TestExportChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	blueprint := groups.Blueprint{
+		Version: groups.BlueprintVersion,
+		BlueprintGroup: groups.BlueprintGroup{
+			Name:     "line",
+			Metadata: mgclients.Metadata{"key": "value"},
+			Roles:    []string{auth.AdministratorRelation},
+			Children: []groups.BlueprintGroup{{Name: "press"}},
+		},
+	}
+
 	cases := []struct {
-		desc   string
-		id     string
-		token  string
-		status int
-		err    error
+		desc    string
+		token   string
+		groupID string
+		svcRes  groups.Blueprint
+		svcErr  error
+		status  int
 	}{
 		{
-			desc:   "delete thing with valid token",
-			id:     client.ID,
-			token:  validToken,
-			status: http.StatusNoContent,
-			err:    nil,
+			desc:    "export channel successfully",
+			token:   validToken,
+			groupID: validID,
+			svcRes:  blueprint,
+			status:  http.StatusOK,
 		},
 		{
-			desc:   "delete thing with invalid token",
-			id:     client.ID,
-			token:  inValidToken,
-			status: http.StatusUnauthorized,
-			err:    svcerr.ErrAuthentication,
+			desc:    "export channel with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			svcErr:  svcerr.ErrAuthentication,
+			status:  http.StatusUnauthorized,
 		},
 		{
-			desc:   "delete thing with empty token",
-			id:     client.ID,
-			token:  "",
-			status: http.StatusUnauthorized,
-			err:    apiutil.ErrBearerToken,
+			desc:    "export channel with empty token",
+			groupID: validID,
+			status:  http.StatusUnauthorized,
 		},
 		{
-			desc:   "delete thing with empty id",
-			id:     " ",
-			token:  validToken,
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrMissingID,
+			desc:    "export channel without permission",
+			token:   validToken,
+			groupID: validID,
+			svcErr:  svcerr.ErrAuthorization,
+			status:  http.StatusForbidden,
 		},
 		{
-			desc:   "delete thing with invalid id",
-			id:     "invalid",
-			token:  validToken,
-			status: http.StatusForbidden,
-			err:    svcerr.ErrAuthorization,
+			desc:    "export non-existing channel",
+			token:   validToken,
+			groupID: validID,
+			svcErr:  svcerr.ErrViewEntity,
+			status:  http.StatusBadRequest,
 		},
 	}
-
 	for _, tc := range cases {
 		req := testRequest{
 			client: ts.Client(),
-			method: http.MethodDelete,
-			url:    fmt.Sprintf("%s/things/%s", ts.URL, tc.id),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/blueprint", ts.URL, tc.groupID),
 			token:  tc.token,
 		}
 
-		svcCall := svc.On("DeleteClient", mock.Anything, tc.token, tc.id).Return(tc.err)
+		svcCall := gsvc.On("ExportGroup", mock.Anything, tc.token, tc.groupID).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body groups.Blueprint
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes, body, fmt.Sprintf("%s: expected blueprint %v got %v", tc.desc, tc.svcRes, body))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestListMembers(t *testing.T) {
+func TestExportSubtree(t *testing.T) {
 	ts, svc, _ := newThingsServer()
 	defer ts.Close()
 
+	channel := groups.Group{ID: validID, Name: "line"}
+	child := groups.Group{ID: testsutil.GenerateUUID(t), Name: "press", Parent: validID}
+	members := []groups.MemberRole{
+		{MemberID: testsutil.GenerateUUID(t), Role: auth.AdministratorRelation},
+		{MemberID: testsutil.GenerateUUID(t), Role: auth.EditorRelation},
+	}
+	defPage := groups.PageMeta{Offset: api.DefOffset, Limit: api.DefLimit}
+	page := things.SubtreePageMeta{Channels: defPage, Things: defPage, Members: defPage}
+	pagedThings := page
+	pagedThings.Things = groups.PageMeta{Offset: 1, Limit: 1}
+	pagedMembers := page
+	pagedMembers.Members = groups.PageMeta{Offset: 1, Limit: 1}
+	unkeyed := client
+	unkeyed.Credentials.Secret = ""
+	keyed := client
+	keyed.Credentials.Secret = secret
+
 	cases := []struct {
-		desc                string
-		query               string
-		token               string
-		listMembersResponse mgclients.MembersPage
-		status              int
-		err                 error
-		groupdID            string
+		desc    string
+		token   string
+		query   string
+		page    things.SubtreePageMeta
+		keys    bool
+		subtree things.Subtree
+		svcErr  error
+		status  int
+		secret  string
+		err     error
 	}{
-		{
-			desc:     "list members with valid token",
-			token:    validToken,
-			groupdID: client.ID,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			status: http.StatusOK,
-			err:    nil,
-		},
-		{
-			desc:     "list members with empty token",
-			token:    "",
-			groupdID: client.ID,
-			status:   http.StatusUnauthorized,
-			err:      apiutil.ErrBearerToken,
-		},
-		{
-			desc:     "list members with invalid token",
-			token:    inValidToken,
-			groupdID: client.ID,
-			status:   http.StatusUnauthorized,
-			err:      svcerr.ErrAuthentication,
-		},
-		{
-			desc:     "list members with offset",
-			token:    validToken,
-			query:    "offset=1",
-			groupdID: client.ID,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Offset: 1,
-					Total:  1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			status: http.StatusOK,
-			err:    nil,
-		},
-		{
-			desc:     "list members with invalid offset",
-			token:    validToken,
-			query:    "offset=invalid",
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
-		},
-		{
-			desc:     "list members with limit",
-			token:    validToken,
-			query:    "limit=1",
-			groupdID: client.ID,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Limit: 1,
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			status: http.StatusOK,
-			err:    nil,
-		},
-		{
-			desc:     "list members with invalid limit",
-			token:    validToken,
-			query:    "limit=invalid",
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
-		},
-		{
-			desc:     "list members with limit greater than 100",
-			token:    validToken,
-			query:    fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
-		},
-		{
-			desc:     "list members with channel_id",
-			token:    validToken,
-			query:    fmt.Sprintf("channel_id=%s", validID),
-			groupdID: client.ID,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			status: http.StatusOK,
-			err:    nil,
-		},
-		{
-			desc:     "list members with invalid channel_id",
-			token:    validToken,
-			query:    "channel_id=invalid",
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
-		},
-		{
-			desc:     "list members with duplicate channel_id",
-			token:    validToken,
-			query:    fmt.Sprintf("channel_id=%s&channel_id=%s", validID, validID),
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
-		},
-		{
-			desc:     "list members with connected set",
-			token:    validToken,
-			query:    "connected=true",
-			groupdID: client.ID,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			status: http.StatusOK,
-			err:    nil,
-		},
-		{
-			desc:     "list members with invalid connected set",
-			token:    validToken,
-			query:    "connected=invalid",
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
-		},
-		{
-			desc:   "list members with duplicate connected set",
-			token:  validToken,
-			query:  "connected=true&connected=false",
-			status: http.StatusBadRequest,
-			err:    apiutil.ErrValidation,
-		},
-		{
-			desc:     "list members with invalid group id",
-			token:    validToken,
-			query:    "",
-			groupdID: "invalid",
-			status:   http.StatusForbidden,
-			err:      svcerr.ErrAuthorization,
-		},
-		{
-			desc:     "list members with empty group id",
-			token:    validToken,
-			query:    "",
-			groupdID: "",
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrMissingID,
-		},
-		{
-			desc:  "list members with status",
-			query: fmt.Sprintf("status=%s", mgclients.EnabledStatus),
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
+		{
+			desc:  "export subtree successfully",
+			token: validToken,
+			page:  page,
+			subtree: things.Subtree{
+				Channel:  channel,
+				Channels: groups.Page{PageMeta: groups.PageMeta{Total: 1}, Groups: []groups.Group{child}},
+				Things:   mgclients.MembersPage{Page: mgclients.Page{Total: 1}, Members: []mgclients.Client{unkeyed}},
+				Members:  things.MemberRolesPage{PageMeta: groups.PageMeta{Total: 2}, Members: members},
 			},
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusOK,
-			err:      nil,
+			status: http.StatusOK,
 		},
 		{
-			desc:     "list members with invalid status",
-			query:    "status=invalid",
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:  "export subtree with keys",
+			token: validToken,
+			query: "keys=true",
+			page:  page,
+			keys:  true,
+			subtree: things.Subtree{
+				Channel:  channel,
+				Channels: groups.Page{PageMeta: groups.PageMeta{Total: 1}, Groups: []groups.Group{child}},
+				Things:   mgclients.MembersPage{Page: mgclients.Page{Total: 1}, Members: []mgclients.Client{keyed}},
+				Members:  things.MemberRolesPage{PageMeta: groups.PageMeta{Total: 2}, Members: members},
+			},
+			status: http.StatusOK,
+			secret: secret,
 		},
 		{
-			desc:     "list members with duplicate status",
-			query:    fmt.Sprintf("status=%s&status=%s", mgclients.EnabledStatus, mgclients.DisabledStatus),
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:  "export subtree with paged things",
+			token: validToken,
+			query: "things_offset=1&things_limit=1",
+			page:  pagedThings,
+			subtree: things.Subtree{
+				Channel:  channel,
+				Channels: groups.Page{PageMeta: groups.PageMeta{Total: 1}, Groups: []groups.Group{child}},
+				Things:   mgclients.MembersPage{Page: mgclients.Page{Total: 2}, Members: []mgclients.Client{unkeyed}},
+				Members:  things.MemberRolesPage{PageMeta: groups.PageMeta{Total: 2}, Members: members},
+			},
+			status: http.StatusOK,
 		},
 		{
-			desc:  "list members with metadata",
+			desc:  "export subtree with paged members",
 			token: validToken,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
+			query: "members_offset=1&members_limit=1",
+			page:  pagedMembers,
+			subtree: things.Subtree{
+				Channel:  channel,
+				Channels: groups.Page{PageMeta: groups.PageMeta{Total: 1}, Groups: []groups.Group{child}},
+				Things:   mgclients.MembersPage{Page: mgclients.Page{Total: 1}, Members: []mgclients.Client{unkeyed}},
+				Members:  things.MemberRolesPage{PageMeta: groups.PageMeta{Total: 2}, Members: members[1:]},
 			},
-			groupdID: client.ID,
-			query:    "metadata=%7B%22domain%22%3A%20%22example.com%22%7D&",
-			status:   http.StatusOK,
-			err:      nil,
+			status: http.StatusOK,
 		},
 		{
-			desc:     "list members with invalid metadata",
-			query:    "metadata=invalid",
-			groupdID: client.ID,
-			token:    validToken,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:   "export subtree with invalid keys",
+			token:  validToken,
+			query:  "keys=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
 		},
 		{
-			desc:     "list members with duplicate metadata",
-			query:    "metadata=%7B%22domain%22%3A%20%22example.com%22%7D&metadata=%7B%22domain%22%3A%20%22example.com%22%7D",
-			groupdID: client.ID,
-			token:    validToken,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrInvalidQueryParams,
+			desc:   "export subtree with invalid things offset",
+			token:  validToken,
+			query:  "things_offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
 		},
 		{
-			desc:  "list members with permission",
-			query: fmt.Sprintf("permission=%s", "read"),
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusOK,
-			err:      nil,
+			desc:   "export subtree with invalid members limit",
+			token:  validToken,
+			query:  "members_limit=0",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
 		},
 		{
-			desc:     "list members with invalid permission",
-			query:    "permission=invalid",
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:   "export subtree with empty token",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
 		},
 		{
-			desc:     "list members with duplicate permission",
-			query:    fmt.Sprintf("permission=%s&permission=%s", "read", "write"),
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:   "export subtree without administrator role",
+			token:  validToken,
+			page:   page,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
 		},
 		{
-			desc:  "list members with list permission",
-			query: "list_perms=true",
-			token: validToken,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Total: 1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			groupdID: client.ID,
-			status:   http.StatusOK,
-			err:      nil,
+			desc:   "export subtree with failed to retrieve subtree",
+			token:  validToken,
+			page:   page,
+			svcErr: svcerr.ErrViewEntity,
+			status: http.StatusBadRequest,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/export?%s", ts.URL, validID, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := svc.On("ExportSubtree", mock.Anything, tc.token, validID, tc.page, tc.keys).Return(tc.subtree, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Channel  groups.Group `json:"channel"`
+				Channels struct {
+					Total    uint64         `json:"total"`
+					Channels []groups.Group `json:"channels"`
+				} `json:"channels"`
+				Things struct {
+					Total  uint64             `json:"total"`
+					Offset uint64             `json:"offset"`
+					Limit  uint64             `json:"limit"`
+					Things []mgclients.Client `json:"things"`
+				} `json:"things"`
+				Members struct {
+					Total   uint64              `json:"total"`
+					Offset  uint64              `json:"offset"`
+					Limit   uint64              `json:"limit"`
+					Members []groups.MemberRole `json:"members"`
+				} `json:"members"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, channel.ID, body.Channel.ID, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, channel.ID, body.Channel.ID))
+			assert.Equal(t, []groups.Group{child}, body.Channels.Channels, fmt.Sprintf("%s: unexpected subchannels", tc.desc))
+			assert.Equal(t, tc.subtree.Things.Total, body.Things.Total, fmt.Sprintf("%s: unexpected things total", tc.desc))
+			assert.Equal(t, tc.page.Things.Offset, body.Things.Offset, fmt.Sprintf("%s: unexpected things offset", tc.desc))
+			assert.Equal(t, tc.page.Things.Limit, body.Things.Limit, fmt.Sprintf("%s: unexpected things limit", tc.desc))
+			assert.Len(t, body.Things.Things, 1, fmt.Sprintf("%s: expected one thing", tc.desc))
+			if len(body.Things.Things) == 1 {
+				assert.Equal(t, tc.secret, body.Things.Things[0].Credentials.Secret, fmt.Sprintf("%s: unexpected thing key", tc.desc))
+			}
+			assert.Equal(t, uint64(len(members)), body.Members.Total, fmt.Sprintf("%s: unexpected members total", tc.desc))
+			assert.Equal(t, tc.page.Members.Offset, body.Members.Offset, fmt.Sprintf("%s: unexpected members offset", tc.desc))
+			assert.Equal(t, tc.subtree.Members.Members, body.Members.Members, fmt.Sprintf("%s: unexpected members", tc.desc))
+		} else {
+			var body respBody
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if body.Err != "" || body.Message != "" {
+				err = errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestImportChannel(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	domainID := testsutil.GenerateUUID(t)
+	blueprint := groups.Blueprint{
+		Version: groups.BlueprintVersion,
+		BlueprintGroup: groups.BlueprintGroup{
+			Name:     "line",
+			Metadata: mgclients.Metadata{"key": "value"},
+			Roles:    []string{auth.AdministratorRelation},
+			Children: []groups.BlueprintGroup{{Name: "press"}},
+		},
+	}
+	child := groups.Group{ID: testsutil.GenerateUUID(t), Name: "press"}
+	created := groups.Group{ID: testsutil.GenerateUUID(t), Name: "line", Children: []*groups.Group{&child}}
+
+	cases := []struct {
+		desc        string
+		token       string
+		domainID    string
+		data        string
+		contentType string
+		blueprint   groups.Blueprint
+		svcRes      groups.Group
+		svcErr      error
+		status      int
+		location    string
+	}{
+		{
+			desc:        "import channel successfully",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(blueprint),
+			contentType: contentType,
+			blueprint:   blueprint,
+			svcRes:      created,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/channels/%s", created.ID),
 		},
 		{
-			desc:     "list members with invalid list permission",
-			query:    "list_perms=invalid",
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:        "import channel with invalid token",
+			token:       inValidToken,
+			domainID:    domainID,
+			data:        toJSON(blueprint),
+			contentType: contentType,
+			blueprint:   blueprint,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:     "list members with duplicate list permission",
-			query:    "list_perms=true&list_perms=false",
-			token:    validToken,
-			groupdID: client.ID,
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrValidation,
+			desc:        "import channel into another domain",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(blueprint),
+			contentType: contentType,
+			blueprint:   blueprint,
+			svcErr:      svcerr.ErrDomainAuthorization,
+			status:      http.StatusForbidden,
 		},
 		{
-			desc:     "list members with all query params",
-			query:    fmt.Sprintf("offset=1&limit=1&channel_id=%s&connected=true&status=%s&metadata=%s&permission=%s&list_perms=true", validID, mgclients.EnabledStatus, "%7B%22domain%22%3A%20%22example.com%22%7D", "read"),
-			token:    validToken,
-			groupdID: client.ID,
-			listMembersResponse: mgclients.MembersPage{
-				Page: mgclients.Page{
-					Offset: 1,
-					Limit:  1,
-					Total:  1,
-				},
-				Members: []mgclients.Client{client},
-			},
-			status: http.StatusOK,
-			err:    nil,
+			desc:        "import channel with incompatible version",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(blueprint),
+			contentType: contentType,
+			blueprint:   blueprint,
+			svcErr:      groups.ErrBlueprintVersion,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import channel with missing name",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(groups.Blueprint{Version: groups.BlueprintVersion}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import channel with invalid content type",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(blueprint),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "import channel with malformed body",
+			token:       validToken,
+			domainID:    domainID,
+			data:        `{"version": `,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
 		},
 	}
-
 	for _, tc := range cases {
 		req := testRequest{
 			client:      ts.Client(),
-			method:      http.MethodGet,
-			url:         ts.URL + fmt.Sprintf("/channels/%s/things?", tc.groupdID) + tc.query,
-			contentType: contentType,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/domains/%s/channels/from-blueprint", ts.URL, tc.domainID),
 			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
 		}
 
-		svcCall := svc.On("ListClientsByGroup", mock.Anything, tc.token, mock.Anything, mock.Anything).Return(tc.listMembersResponse, tc.err)
+		svcCall := gsvc.On("ImportGroup", mock.Anything, tc.token, auth.NewChannelKind, tc.domainID, tc.blueprint).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-
-		var bodyRes respBody
-		err = json.NewDecoder(res.Body).Decode(&bodyRes)
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-		if bodyRes.Err != "" || bodyRes.Message != "" {
-			err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
-		}
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
 		svcCall.Unset()
 	}
 }
 
-func TestAssignUsers(t *testing.T) {
+func TestMoveThings(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	targetID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
 		desc        string
 		token       string
 		groupID     string
 		reqBody     interface{}
 		contentType string
+		svcRes      []groups.ThingMove
+		svcErr      error
 		status      int
-		err         error
 	}{
 		{
-			desc:    "assign users to a group successfully",
+			desc:    "move things successfully",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
+				"thing_ids":       []string{validID},
 			},
 			contentType: contentType,
-			status:      http.StatusCreated,
-			err:         nil,
+			svcRes:      []groups.ThingMove{{ThingID: validID}},
+			status:      http.StatusOK,
 		},
 		{
-			desc:    "assign users to a group with invalid token",
+			desc:    "move things with invalid token",
 			token:   inValidToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
-		},
-		{
-			desc:    "assign users to a group with empty token",
-			token:   "",
-			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
+				"thing_ids":       []string{validID},
 			},
 			contentType: contentType,
+			svcErr:      svcerr.ErrAuthentication,
 			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
 		},
 		{
-			desc:    "assign users to a group with empty group id",
+			desc:    "move things with empty target group id",
 			token:   validToken,
-			groupID: "",
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			groupID: validID,
+			reqBody: map[string]interface{}{
+				"thing_ids": []string{validID},
 			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "assign users to a group with empty relation",
+			desc:    "move things to the same group",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": validID,
+				"thing_ids":       []string{validID},
 			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "assign users to a group with empty user ids",
+			desc:    "move things with empty thing ids",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
+				"thing_ids":       []string{},
 			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "assign users to a group with invalid request body",
+			desc:    "move things to group in another domain",
 			token:   validToken,
 			groupID: validID,
 			reqBody: map[string]interface{}{
-				"relation": make(chan int),
+				"target_group_id": targetID,
+				"thing_ids":       []string{validID},
 			},
 			contentType: contentType,
+			svcErr:      groups.ErrTargetDomain,
 			status:      http.StatusBadRequest,
-			err:         nil,
 		},
 		{
-			desc:    "assign users to a group with invalid content type",
+			desc:    "move things with invalid content type",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
+				"thing_ids":       []string{validID},
 			},
 			contentType: "application/xml",
 			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
 		},
 	}
-
 	for _, tc := range cases {
 		data := toJSON(tc.reqBody)
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/users/assign", ts.URL, tc.groupID),
+			url:         fmt.Sprintf("%s/channels/%s/things/move", ts.URL, tc.groupID),
 			token:       tc.token,
 			contentType: tc.contentType,
 			body:        strings.NewReader(data),
 		}
 
-		svcCall := gsvc.On("Assign", mock.Anything, tc.token, tc.groupID, mock.Anything, "users", mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("MoveThings", mock.Anything, tc.token, tc.groupID, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -2069,224 +5814,217 @@ func TestAssignUsers(t *testing.T) {
 	}
 }
 
-func TestUnassignUsers(t *testing.T) {
+func TestReassignThings(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	targetID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
 		desc        string
 		token       string
 		groupID     string
 		reqBody     interface{}
 		contentType string
+		svcRes      []string
+		svcErr      error
 		status      int
-		err         error
+		moved       int
 	}{
 		{
-			desc:    "unassign users from a group successfully",
+			desc:    "reassign things successfully",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
 			},
 			contentType: contentType,
-			status:      http.StatusNoContent,
-			err:         nil,
+			svcRes:      []string{validID, testsutil.GenerateUUID(t)},
+			status:      http.StatusOK,
+			moved:       2,
 		},
 		{
-			desc:    "unassign users from a group with invalid token",
+			desc:    "reassign things with invalid token",
 			token:   inValidToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
-		},
-		{
-			desc:    "unassign users from a group with empty token",
-			token:   "",
-			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
 			},
 			contentType: contentType,
+			svcErr:      svcerr.ErrAuthentication,
 			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
-		},
-		{
-			desc:    "unassign users from a group with empty group id",
-			token:   validToken,
-			groupID: "",
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "unassign users from a group with empty relation",
-			token:   validToken,
-			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
+			desc:        "reassign things with empty target group id",
+			token:       validToken,
+			groupID:     validID,
+			reqBody:     map[string]interface{}{},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "unassign users from a group with empty user ids",
+			desc:    "reassign things to the same group",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{},
+			reqBody: map[string]interface{}{
+				"target_group_id": validID,
 			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "unassign users from a group with invalid request body",
+			desc:    "reassign things to group in another domain",
 			token:   validToken,
 			groupID: validID,
 			reqBody: map[string]interface{}{
-				"relation": make(chan int),
+				"target_group_id": targetID,
 			},
 			contentType: contentType,
+			svcErr:      groups.ErrTargetDomain,
 			status:      http.StatusBadRequest,
-			err:         nil,
 		},
 		{
-			desc:    "unassign users from a group with invalid content type",
+			desc:    "reassign things with invalid content type",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				Relation: "member",
-				UserIDs:  []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"target_group_id": targetID,
 			},
 			contentType: "application/xml",
 			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
 		},
 	}
-
 	for _, tc := range cases {
 		data := toJSON(tc.reqBody)
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/users/unassign", ts.URL, tc.groupID),
+			url:         fmt.Sprintf("%s/channels/%s/things/reassign", ts.URL, tc.groupID),
 			token:       tc.token,
 			contentType: tc.contentType,
 			body:        strings.NewReader(data),
 		}
 
-		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.groupID, mock.Anything, "users", mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("ReassignThings", mock.Anything, tc.token, tc.groupID, mock.Anything).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Moved int `json:"moved"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.moved, body.Moved, fmt.Sprintf("%s: expected %d moved got %d", tc.desc, tc.moved, body.Moved))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestAssignGroupsToChannel(t *testing.T) {
+func TestAttachThings(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	thingID := testsutil.GenerateUUID(t)
+	otherID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
 		desc        string
 		token       string
 		groupID     string
 		reqBody     interface{}
 		contentType string
+		reassign    bool
+		svcRes      []groups.ThingAttachment
+		svcErr      error
 		status      int
-		err         error
 	}{
 		{
-			desc:    "assign groups to a channel successfully",
+			desc:    "attach things successfully",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"thing_ids": []string{thingID},
 			},
 			contentType: contentType,
-			status:      http.StatusCreated,
-			err:         nil,
+			svcRes:      []groups.ThingAttachment{{ThingID: thingID}},
+			status:      http.StatusOK,
 		},
 		{
-			desc:    "assign groups to a channel with invalid token",
-			token:   inValidToken,
+			desc:    "attach things with reassign on conflict",
+			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"thing_ids":   []string{thingID},
+				"on_conflict": "reassign",
 			},
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			reassign:    true,
+			svcRes:      []groups.ThingAttachment{{ThingID: thingID, Detached: []string{otherID}}},
+			status:      http.StatusOK,
 		},
 		{
-			desc:    "assign groups to a channel with empty token",
-			token:   "",
+			desc:    "attach things with report on conflict",
+			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"thing_ids":   []string{thingID},
+				"on_conflict": "report",
 			},
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
+			svcRes:      []groups.ThingAttachment{{ThingID: thingID, Error: groups.ErrSharedThing.Error()}},
+			status:      http.StatusOK,
 		},
 		{
-			desc:    "assign groups to a channel with empty group id",
-			token:   validToken,
-			groupID: "",
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			desc:    "attach things with invalid token",
+			token:   inValidToken,
+			groupID: validID,
+			reqBody: map[string]interface{}{
+				"thing_ids": []string{thingID},
 			},
 			contentType: contentType,
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "attach things with empty thing ids",
+			token:       validToken,
+			groupID:     validID,
+			reqBody:     map[string]interface{}{},
+			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "assign groups to a channel with empty group ids",
+			desc:    "attach things with invalid on conflict",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{},
+			reqBody: map[string]interface{}{
+				"thing_ids":   []string{thingID},
+				"on_conflict": "ignore",
 			},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:    "assign groups to a channel with invalid request body",
+			desc:    "attach things with exceeded connection limit",
 			token:   validToken,
 			groupID: validID,
 			reqBody: map[string]interface{}{
-				"group_ids": make(chan int),
+				"thing_ids": []string{thingID},
 			},
 			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			svcErr:      groups.ErrConnectionLimitExceeded,
+			status:      http.StatusConflict,
 		},
 		{
-			desc:    "assign groups to a channel with invalid content type",
+			desc:    "attach things with invalid content type",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			reqBody: map[string]interface{}{
+				"thing_ids": []string{thingID},
 			},
 			contentType: "application/xml",
 			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
 		},
 	}
 	for _, tc := range cases {
@@ -2294,357 +6032,674 @@ func TestAssignGroupsToChannel(t *testing.T) {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/groups/assign", ts.URL, tc.groupID),
+			url:         fmt.Sprintf("%s/channels/%s/things/attach", ts.URL, tc.groupID),
 			token:       tc.token,
 			contentType: tc.contentType,
 			body:        strings.NewReader(data),
 		}
 
-		svcCall := gsvc.On("Assign", mock.Anything, tc.token, tc.groupID, mock.Anything, "channels", mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("AttachThings", mock.Anything, tc.token, tc.groupID, mock.Anything, tc.reassign).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Attachments []groups.ThingAttachment `json:"attachments"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes, body.Attachments, fmt.Sprintf("%s: expected attachments %v got %v", tc.desc, tc.svcRes, body.Attachments))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestUnassignGroupsFromChannel(t *testing.T) {
+func TestListMemberRoles(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	lastActive := time.Now().UTC().Truncate(time.Second)
+	members := []groups.MemberRole{
+		{MemberID: testsutil.GenerateUUID(t), Role: auth.AdministratorRelation, LastActive: &lastActive},
+		{MemberID: testsutil.GenerateUUID(t), Role: auth.MemberRelation},
+	}
+
 	cases := []struct {
-		desc        string
-		token       string
-		groupID     string
-		reqBody     interface{}
-		contentType string
-		status      int
-		err         error
+		desc          string
+		token         string
+		groupID       string
+		query         string
+		inactiveSince time.Time
+		svcRes        []groups.MemberRole
+		svcErr        error
+		status        int
 	}{
 		{
-			desc:    "unassign groups from a channel successfully",
+			desc:    "list member roles successfully",
 			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: contentType,
-			status:      http.StatusNoContent,
-			err:         nil,
+			svcRes:  members,
+			status:  http.StatusOK,
 		},
 		{
-			desc:    "unassign groups from a channel with invalid token",
-			token:   inValidToken,
+			desc:          "list member roles inactive since a time",
+			token:         validToken,
+			groupID:       validID,
+			query:         "inactive_since=" + lastActive.Format(time.RFC3339),
+			inactiveSince: lastActive,
+			svcRes:        members[1:],
+			status:        http.StatusOK,
+		},
+		{
+			desc:    "list member roles with invalid inactive since",
+			token:   validToken,
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			query:   "inactive_since=yesterday",
+			status:  http.StatusBadRequest,
 		},
 		{
-			desc:    "unassign groups from a channel with empty token",
-			token:   "",
+			desc:    "list member roles with empty token",
 			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         apiutil.ErrBearerToken,
+			status:  http.StatusUnauthorized,
 		},
 		{
-			desc:    "unassign groups from a channel with empty group id",
+			desc:    "list member roles without permission",
 			token:   validToken,
-			groupID: "",
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
+			groupID: validID,
+			svcErr:  svcerr.ErrAuthorization,
+			status:  http.StatusForbidden,
+		},
+	}
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/roles?%s", ts.URL, tc.groupID, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := gsvc.On("ListMemberRoles", mock.Anything, tc.token, tc.groupID, tc.inactiveSince).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Members []groups.MemberRole `json:"members"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, len(tc.svcRes), len(body.Members), fmt.Sprintf("%s: expected %d members got %d", tc.desc, len(tc.svcRes), len(body.Members)))
+			for i, m := range body.Members {
+				assert.Equal(t, tc.svcRes[i].LastActive == nil, m.LastActive == nil, fmt.Sprintf("%s: unexpected last activity of member %s", tc.desc, m.MemberID))
+			}
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestListDomainMembers(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	members := []groups.DomainMember{
+		{
+			MemberID: testsutil.GenerateUUID(t),
+			Role:     auth.AdministratorRelation,
+			Groups: []groups.GroupRole{
+				{GroupID: testsutil.GenerateUUID(t), Role: auth.AdministratorRelation},
+				{GroupID: testsutil.GenerateUUID(t), Role: auth.MemberRelation},
 			},
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		query    string
+		pm       groups.PageMeta
+		svcRes   groups.DomainMembersPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list domain members successfully",
+			token:    validToken,
+			domainID: validID,
+			pm:       groups.PageMeta{Offset: api.DefOffset, Limit: api.DefLimit},
+			svcRes:   groups.DomainMembersPage{Total: 1, Limit: api.DefLimit, Members: members},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list domain members with offset and limit",
+			token:    validToken,
+			domainID: validID,
+			query:    "offset=1&limit=5",
+			pm:       groups.PageMeta{Offset: 1, Limit: 5},
+			svcRes:   groups.DomainMembersPage{Total: 1, Offset: 1, Limit: 5, Members: []groups.DomainMember{}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list domain members with invalid limit",
+			token:    validToken,
+			domainID: validID,
+			query:    "limit=invalid",
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list domain members with limit above maximum",
+			token:    validToken,
+			domainID: validID,
+			query:    fmt.Sprintf("limit=%d", api.MaxLimitSize+1),
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list domain members with empty token",
+			domainID: validID,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list domain members without permission",
+			token:    validToken,
+			domainID: validID,
+			pm:       groups.PageMeta{Offset: api.DefOffset, Limit: api.DefLimit},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/domains/%s/channels/members?%s", ts.URL, tc.domainID, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := gsvc.On("ListDomainMembers", mock.Anything, tc.token, tc.domainID, tc.pm).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Total   uint64                `json:"total"`
+				Members []groups.DomainMember `json:"members"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes.Total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, body.Total))
+			assert.Equal(t, tc.svcRes.Members, body.Members, fmt.Sprintf("%s: unexpected members", tc.desc))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestRevokeMember(t *testing.T) {
+	ts, _, gsvc := newThingsServer()
+	defer ts.Close()
+
+	groupIDs := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		memberID string
+		svcRes   groups.MemberRevocation
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "revoke member successfully",
+			token:    validToken,
+			domainID: validID,
+			memberID: validID,
+			svcRes:   groups.MemberRevocation{Groups: groupIDs},
+			status:   http.StatusOK,
 		},
 		{
-			desc:    "unassign groups from a channel with empty group ids",
-			token:   validToken,
-			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{},
-			},
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:     "revoke member holding a domain role",
+			token:    validToken,
+			domainID: validID,
+			memberID: validID,
+			svcRes:   groups.MemberRevocation{Groups: groupIDs, DomainPermission: auth.EditPermission},
+			status:   http.StatusOK,
 		},
 		{
-			desc:    "unassign groups from a channel with invalid request body",
-			token:   validToken,
-			groupID: validID,
-			reqBody: map[string]interface{}{
-				"group_ids": make(chan int),
-			},
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:     "revoke member without roles",
+			token:    validToken,
+			domainID: validID,
+			memberID: validID,
+			svcRes:   groups.MemberRevocation{Groups: []string{}},
+			status:   http.StatusOK,
 		},
 		{
-			desc:    "unassign groups from a channel with invalid content type",
-			token:   validToken,
-			groupID: validID,
-			reqBody: groupReqBody{
-				GroupIDs: []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)},
-			},
-			contentType: "application/xml",
-			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			desc:     "revoke member with empty token",
+			domainID: validID,
+			memberID: validID,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "revoke member without permission",
+			token:    validToken,
+			domainID: validID,
+			memberID: validID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
 		},
 	}
 	for _, tc := range cases {
-		data := toJSON(tc.reqBody)
 		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/groups/unassign", ts.URL, tc.groupID),
-			token:       tc.token,
-			contentType: tc.contentType,
-			body:        strings.NewReader(data),
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/domains/%s/channels/members/%s/revoke", ts.URL, tc.domainID, tc.memberID),
+			token:  tc.token,
 		}
 
-		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.groupID, mock.Anything, "channels", mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("RevokeMember", mock.Anything, tc.token, tc.domainID, tc.memberID).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body groups.MemberRevocation
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes, body, fmt.Sprintf("%s: unexpected revocation", tc.desc))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestConnectThingToChannel(t *testing.T) {
+func TestUpdateChannels(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	secondID := testsutil.GenerateUUID(t)
+	channels := []map[string]interface{}{
+		{"id": validID, "name": "first", "metadata": map[string]interface{}{"role": "sensors"}},
+		{"id": secondID, "version": 2},
+	}
+
 	cases := []struct {
 		desc        string
 		token       string
-		channelID   string
-		thingID     string
+		query       string
+		reqBody     interface{}
 		contentType string
+		merge       bool
+		partial     bool
+		svcRes      []groups.GroupUpdate
+		svcErr      error
 		status      int
-		err         error
 	}{
 		{
-			desc:        "connect thing to a channel successfully",
+			desc:        "update channels successfully",
 			token:       validToken,
-			channelID:   validID,
-			thingID:     validID,
+			reqBody:     map[string]interface{}{"channels": channels},
 			contentType: contentType,
-			status:      http.StatusCreated,
-			err:         nil,
+			svcRes: []groups.GroupUpdate{
+				{ID: validID, Group: &groups.Group{ID: validID}},
+				{ID: secondID, Group: &groups.Group{ID: secondID}},
+			},
+			status: http.StatusOK,
 		},
 		{
-			desc:        "connect thing to a channel with invalid token",
+			desc:        "update channels partially with merged metadata",
+			token:       validToken,
+			query:       "?merge=true&partial=true",
+			reqBody:     map[string]interface{}{"channels": channels},
+			contentType: contentType,
+			merge:       true,
+			partial:     true,
+			svcRes: []groups.GroupUpdate{
+				{ID: validID, Group: &groups.Group{ID: validID}},
+				{ID: secondID, Error: svcerr.ErrAuthorization.Error()},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:        "update channels with invalid partial query",
+			token:       validToken,
+			query:       "?partial=yes",
+			reqBody:     map[string]interface{}{"channels": channels},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update channels with invalid token",
 			token:       inValidToken,
-			channelID:   validID,
-			thingID:     validID,
+			reqBody:     map[string]interface{}{"channels": channels},
+			contentType: contentType,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update channels with empty token",
+			reqBody:     map[string]interface{}{"channels": channels},
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "connect thing to a channel with empty channel id",
+			desc:        "update channels with empty list",
 			token:       validToken,
-			channelID:   "",
-			thingID:     validID,
+			reqBody:     map[string]interface{}{"channels": []interface{}{}},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "connect thing to a channel with empty thing id",
+			desc:        "update channels with duplicated channel",
 			token:       validToken,
-			channelID:   validID,
-			thingID:     "",
+			reqBody:     map[string]interface{}{"channels": []map[string]interface{}{{"id": validID}, {"id": validID}}},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update channels with conflicting version",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"channels": channels},
+			contentType: contentType,
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "update channels with invalid content type",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"channels": channels},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "update channels with malformed body",
+			token:       validToken,
+			reqBody:     "channels",
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 	}
 	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
 		req := testRequest{
 			client:      ts.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/things/%s/connect", ts.URL, tc.channelID, tc.thingID),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/channels%s", ts.URL, tc.query),
 			token:       tc.token,
 			contentType: tc.contentType,
+			body:        strings.NewReader(data),
 		}
 
-		svcCall := gsvc.On("Assign", mock.Anything, tc.token, tc.channelID, "group", "things", []string{tc.thingID}).Return(tc.err)
+		svcCall := gsvc.On("UpdateGroups", mock.Anything, tc.token, mock.Anything, tc.merge, tc.partial).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Channels []groups.GroupUpdate `json:"channels"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, len(tc.svcRes), len(body.Channels), fmt.Sprintf("%s: expected %d results got %d", tc.desc, len(tc.svcRes), len(body.Channels)))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestDisconnectThingFromChannel(t *testing.T) {
+func TestDeleteChannels(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	secondID := testsutil.GenerateUUID(t)
+	ids := []string{validID, secondID}
+
 	cases := []struct {
 		desc        string
 		token       string
-		channelID   string
-		thingID     string
+		query       string
+		reqBody     interface{}
 		contentType string
+		cascade     bool
+		svcRes      []groups.GroupRemoval
+		svcErr      error
 		status      int
-		err         error
 	}{
 		{
-			desc:        "disconnect thing from a channel successfully",
+			desc:        "delete channels successfully",
 			token:       validToken,
-			channelID:   validID,
-			thingID:     validID,
+			reqBody:     map[string]interface{}{"ids": ids},
 			contentType: contentType,
-			status:      http.StatusNoContent,
-			err:         nil,
+			svcRes: []groups.GroupRemoval{
+				{ID: validID, Removed: true},
+				{ID: secondID, Error: groups.ErrGroupHasThings.Error()},
+			},
+			status: http.StatusOK,
 		},
 		{
-			desc:        "disconnect thing from a channel with invalid token",
+			desc:        "delete channels with cascade",
+			token:       validToken,
+			query:       "?cascade=true",
+			reqBody:     map[string]interface{}{"ids": ids},
+			contentType: contentType,
+			cascade:     true,
+			svcRes: []groups.GroupRemoval{
+				{ID: validID, Removed: true},
+				{ID: secondID, Removed: true},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:        "delete channels with invalid cascade query",
+			token:       validToken,
+			query:       "?cascade=yes",
+			reqBody:     map[string]interface{}{"ids": ids},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "delete channels with invalid token",
 			token:       inValidToken,
-			channelID:   validID,
-			thingID:     validID,
+			reqBody:     map[string]interface{}{"ids": ids},
 			contentType: contentType,
+			svcErr:      svcerr.ErrAuthentication,
 			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "disconnect thing from a channel with empty channel id",
+			desc:        "delete channels with empty token",
+			reqBody:     map[string]interface{}{"ids": ids},
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "delete channels with empty list",
 			token:       validToken,
-			channelID:   "",
-			thingID:     validID,
+			reqBody:     map[string]interface{}{"ids": []string{}},
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "disconnect thing from a channel with empty thing id",
+			desc:        "delete channels with empty id",
 			token:       validToken,
-			channelID:   validID,
-			thingID:     "",
+			reqBody:     map[string]interface{}{"ids": []string{validID, ""}},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "delete channels with duplicated id",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"ids": []string{validID, validID}},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "delete channels with invalid content type",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"ids": ids},
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "delete channels with malformed body",
+			token:       validToken,
+			reqBody:     "ids",
 			contentType: contentType,
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 	}
 	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/channels/%s/things/%s/disconnect", ts.URL, tc.channelID, tc.thingID),
+			url:         fmt.Sprintf("%s/channels/delete%s", ts.URL, tc.query),
 			token:       tc.token,
 			contentType: tc.contentType,
+			body:        strings.NewReader(data),
 		}
 
-		svcCall := gsvc.On("Unassign", mock.Anything, tc.token, tc.channelID, "group", "things", []string{tc.thingID}).Return(tc.err)
+		svcCall := gsvc.On("DeleteGroups", mock.Anything, tc.token, mock.Anything, tc.cascade).Return(tc.svcRes, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Channels []groups.GroupRemoval `json:"channels"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, tc.svcRes, body.Channels, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.svcRes, body.Channels))
+		}
 		svcCall.Unset()
 	}
 }
 
-func TestConnect(t *testing.T) {
+func TestPatchChannel(t *testing.T) {
 	ts, _, gsvc := newThingsServer()
 	defer ts.Close()
 
+	patched := groups.Group{
+		ID:       validID,
+		Name:     "renamed",
+		Metadata: mgclients.Metadata{"role": "sensors"},
+		Version:  4,
+	}
+	name := "renamed"
+	empty := ""
+
 	cases := []struct {
 		desc        string
 		token       string
 		reqBody     interface{}
 		contentType string
+		patch       groups.GroupPatch
+		svcErr      error
 		status      int
-		err         error
 	}{
 		{
-			desc:  "connect thing to a channel successfully",
-			token: validToken,
-			reqBody: groupReqBody{
-				ChannelID: validID,
-				ThingID:   validID,
-			},
-			contentType: contentType,
-			status:      http.StatusCreated,
-			err:         nil,
+			desc:        "patch channel nested metadata",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"metadata": map[string]interface{}{"config": map[string]interface{}{"interval": 30, "unit": nil}}},
+			contentType: "application/merge-patch+json",
+			patch:       groups.GroupPatch{Metadata: mgclients.Metadata{"config": map[string]interface{}{"interval": 30.0, "unit": nil}}},
+			status:      http.StatusOK,
 		},
 		{
-			desc:  "connect thing to a channel with invalid token",
-			token: inValidToken,
-			reqBody: groupReqBody{
-				ChannelID: validID,
-				ThingID:   validID,
-			},
+			desc:        "patch channel name with JSON content type",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"name": "renamed"},
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			patch:       groups.GroupPatch{Name: &name},
+			status:      http.StatusOK,
 		},
 		{
-			desc:  "connect thing to a channel with empty channel id",
-			token: validToken,
-			reqBody: groupReqBody{
-				ChannelID: "",
-				ThingID:   validID,
-			},
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:        "patch channel removing metadata",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"metadata": nil},
+			contentType: "application/merge-patch+json",
+			patch:       groups.GroupPatch{ClearMetadata: true},
+			status:      http.StatusOK,
 		},
 		{
-			desc:  "connect thing to a channel with empty thing id",
-			token: validToken,
-			reqBody: groupReqBody{
-				ChannelID: validID,
-				ThingID:   "",
-			},
-			contentType: contentType,
+			desc:        "patch channel removing description",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"description": nil},
+			contentType: "application/merge-patch+json",
+			patch:       groups.GroupPatch{Description: &empty},
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "patch channel removing name",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"name": nil},
+			contentType: "application/merge-patch+json",
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:  "connect thing to a channel with invalid request body",
-			token: validToken,
-			reqBody: map[string]interface{}{
-				"channel_id": make(chan int),
-			},
-			contentType: contentType,
+			desc:        "patch channel with array patch",
+			token:       validToken,
+			reqBody:     []interface{}{"name"},
+			contentType: "application/merge-patch+json",
 			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:  "connect thing to a channel with invalid content type",
-			token: validToken,
-			reqBody: groupReqBody{
-				ChannelID: validID,
-				ThingID:   validID,
-			},
+			desc:        "patch channel with invalid content type",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"name": "renamed"},
 			contentType: "application/xml",
 			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "patch channel with empty token",
+			reqBody:     map[string]interface{}{"name": "renamed"},
+			contentType: "application/merge-patch+json",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "patch non-existent channel",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"name": "renamed"},
+			contentType: "application/merge-patch+json",
+			patch:       groups.GroupPatch{Name: &name},
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "patch channel with invalid config",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"metadata": map[string]interface{}{"schema": "object"}},
+			contentType: "application/merge-patch+json",
+			patch:       groups.GroupPatch{Metadata: mgclients.Metadata{"schema": "object"}},
+			svcErr:      errors.ErrMalformedEntity,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "patch concurrently updated channel",
+			token:       validToken,
+			reqBody:     map[string]interface{}{"name": "renamed"},
+			contentType: "application/merge-patch+json",
+			patch:       groups.GroupPatch{Name: &name},
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
 		},
 	}
 	for _, tc := range cases {
 		data := toJSON(tc.reqBody)
 		req := testRequest{
 			client:      ts.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/connect", ts.URL),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/channels/%s", ts.URL, validID),
 			token:       tc.token,
 			contentType: tc.contentType,
 			body:        strings.NewReader(data),
 		}
 
-		svcCall := gsvc.On("Assign", mock.Anything, tc.token, mock.Anything, "group", "things", mock.Anything).Return(tc.err)
+		svcCall := gsvc.On("PatchGroup", mock.Anything, tc.token, validID, tc.patch).Return(patched, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body groups.Group
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			assert.Equal(t, patched.Name, body.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, patched.Name, body.Name))
+			assert.Equal(t, patched.Metadata, body.Metadata, fmt.Sprintf("%s: expected metadata %v got %v", tc.desc, patched.Metadata, body.Metadata))
+		}
 		svcCall.Unset()
 	}
 }
@@ -2749,8 +6804,10 @@ func TestDisconnect(t *testing.T) {
 type respBody struct {
 	Err         string           `json:"error"`
 	Message     string           `json:"message"`
+	Code        string           `json:"code"`
 	Total       int              `json:"total"`
 	Permissions []string         `json:"permissions"`
+	Actions     []string         `json:"actions"`
 	ID          string           `json:"id"`
 	Tags        []string         `json:"tags"`
 	Status      mgclients.Status `json:"status"`
@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/things"
+	"github.com/go-kit/kit/endpoint"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+var (
+	errIdempotencyKeyReused     = errors.New("idempotency key is already used for a different request")
+	errIdempotencyKeyInProgress = errors.New("request with the same idempotency key is in progress")
+)
+
+type idempotencyCtxKey struct{}
+
+type idempotencyReq struct {
+	key         string
+	fingerprint string
+}
+
+// decodeIdempotencyKey stores the idempotency key of the request, scoped to
+// the request token, along with the request body fingerprint in the context.
+func decodeIdempotencyKey(ctx context.Context, r *http.Request) context.Context {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		return ctx
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return ctx
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return context.WithValue(ctx, idempotencyCtxKey{}, idempotencyReq{
+		key:         hash([]byte(apiutil.ExtractBearerToken(r))) + ":" + key,
+		fingerprint: hash(body),
+	})
+}
+
+// idempotent makes the endpoint return the stored response to requests
+// retried with the same idempotency key instead of processing them again.
+// Requests are refused while the cache is unavailable, since processing them
+// without a reservation could create duplicates.
+func idempotent(cache things.IdempotencyCache, logger *slog.Logger) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, ok := ctx.Value(idempotencyCtxKey{}).(idempotencyReq)
+			if !ok || cache == nil {
+				return next(ctx, request)
+			}
+
+			stored, reserved, err := cache.Reserve(ctx, req.key, req.fingerprint)
+			if err != nil {
+				return nil, errors.Wrap(svcerr.ErrServiceUnavailable, err)
+			}
+			if !reserved {
+				switch {
+				case stored.Fingerprint != req.fingerprint:
+					return nil, errors.Wrap(svcerr.ErrConflict, errIdempotencyKeyReused)
+				case len(stored.Body) == 0:
+					return nil, errors.Wrap(svcerr.ErrConflict, errIdempotencyKeyInProgress)
+				default:
+					return idempotentRes{body: stored.Body}, nil
+				}
+			}
+
+			res, err := next(ctx, request)
+			if err != nil {
+				// Release the key so the request can be retried.
+				if rerr := cache.Remove(ctx, req.key); rerr != nil {
+					return nil, errors.Wrap(err, rerr)
+				}
+				return nil, err
+			}
+
+			body, err := json.Marshal(res)
+			if err != nil {
+				return res, nil
+			}
+			// The entities are already created, so the response is returned
+			// even if it can't be stored for the retries.
+			if err := cache.Save(ctx, req.key, things.IdempotentResponse{Fingerprint: req.fingerprint, Body: body}); err != nil {
+				logger.Error(fmt.Sprintf("failed to store response for idempotency key: %s", err))
+			}
+
+			return res, nil
+		}
+	}
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
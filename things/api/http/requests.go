@@ -4,11 +4,15 @@
 package http
 
 import (
+	"time"
+
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things"
 )
 
 type createClientReq struct {
@@ -30,6 +34,10 @@ func (req createClientReq) validate() error {
 	return nil
 }
 
+func (req createClientReq) EntitiesMetadata() []map[string]interface{} {
+	return []map[string]interface{}{req.client.Metadata}
+}
+
 type createClientsReq struct {
 	token   string
 	Clients []mgclients.Client
@@ -56,9 +64,25 @@ func (req createClientsReq) validate() error {
 	return nil
 }
 
+func (req createClientsReq) EntitiesMetadata() []map[string]interface{} {
+	mds := make([]map[string]interface{}, len(req.Clients))
+	for i, c := range req.Clients {
+		mds[i] = c.Metadata
+	}
+
+	return mds
+}
+
+// thingFields are the fields the thing responses can be limited to.
+var thingFields = []string{
+	"id", "name", "tags", "domain", "credentials", "metadata", "created_at",
+	"updated_at", "updated_by", "status", "permissions", "version", "channels",
+}
+
 type viewClientReq struct {
-	token string
-	id    string
+	token  string
+	id     string
+	fields []string
 }
 
 func (req viewClientReq) validate() error {
@@ -71,6 +95,27 @@ func (req viewClientReq) validate() error {
 	return nil
 }
 
+type viewClientsReq struct {
+	token string
+	IDs   []string `json:"ids"`
+}
+
+func (req viewClientsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.IDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	for _, id := range req.IDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
 type viewClientPermsReq struct {
 	token string
 	id    string
@@ -87,23 +132,37 @@ func (req viewClientPermsReq) validate() error {
 }
 
 type listClientsReq struct {
-	token      string
-	status     mgclients.Status
-	offset     uint64
-	limit      uint64
-	name       string
-	tag        string
-	permission string
-	visibility string
-	userID     string
-	listPerms  bool
-	metadata   mgclients.Metadata
+	token          string
+	status         mgclients.Status
+	offset         uint64
+	limit          uint64
+	name           string
+	tag            string
+	permission     string
+	visibility     string
+	userID         string
+	listPerms      bool
+	countOnly      bool
+	connection     string
+	metadata       mgclients.Metadata
+	metadataFilter *mgclients.MetadataFilter
+	updatedSince   time.Time
+	updatedSinceID string
+	wait           time.Duration
+	embed          string
+	fields         []string
+	order          string
+	dir            string
 }
 
 func (req listClientsReq) validate() error {
 	if req.limit > api.MaxLimitSize || req.limit < 1 {
 		return apiutil.ErrLimitSize
 	}
+	// Only changes since a point in time can be waited for or resumed.
+	if (req.wait > 0 || req.updatedSinceID != "") && req.updatedSince.IsZero() {
+		return apiutil.ErrInvalidQueryParams
+	}
 	if req.visibility != "" &&
 		req.visibility != api.AllVisibility &&
 		req.visibility != api.MyVisibility &&
@@ -113,6 +172,35 @@ func (req listClientsReq) validate() error {
 	if len(req.name) > api.MaxNameSize {
 		return apiutil.ErrNameSize
 	}
+	if req.embed != "" && req.embed != api.ChannelsEmbed {
+		return apiutil.ErrInvalidQueryParams
+	}
+	if req.order != "" && !mgclients.IsMetadataOrder(req.order) {
+		return apiutil.ErrInvalidOrder
+	}
+	if _, ok := mgclients.MetadataOrderPath(req.order); req.order != "" && !ok {
+		return apiutil.ErrInvalidQueryParams
+	}
+	if req.dir != "" && req.dir != api.AscDir && req.dir != api.DescDir {
+		return apiutil.ErrInvalidDirection
+	}
+
+	return nil
+}
+
+type aggregateClientsReq struct {
+	token  string
+	field  string
+	status mgclients.Status
+}
+
+func (req aggregateClientsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.field == "" {
+		return apiutil.ErrInvalidQueryParams
+	}
 
 	return nil
 }
@@ -134,12 +222,52 @@ func (req listMembersReq) validate() error {
 	return nil
 }
 
+type rotateKeysReq struct {
+	token   string
+	groupID string
+	offset  uint64
+	limit   uint64
+}
+
+func (req rotateKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type transferChannelReq struct {
+	token          string
+	groupID        string
+	TargetDomainID string `json:"target_domain_id"`
+}
+
+func (req transferChannelReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" || req.TargetDomainID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type updateClientReq struct {
 	token    string
 	id       string
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 	Tags     []string               `json:"tags,omitempty"`
+	Version  uint64                 `json:"version,omitempty"`
+	Merge    bool                   `json:"merge,omitempty"`
 }
 
 func (req updateClientReq) validate() error {
@@ -156,6 +284,10 @@ func (req updateClientReq) validate() error {
 	return nil
 }
 
+func (req updateClientReq) EntitiesMetadata() []map[string]interface{} {
+	return []map[string]interface{}{req.Metadata}
+}
+
 type updateClientTagsReq struct {
 	id    string
 	token string
@@ -173,10 +305,15 @@ func (req updateClientTagsReq) validate() error {
 	return nil
 }
 
+// maxKeyTTL is the longest key lifetime in seconds, ten years, which keeps
+// the lifetime well within the range of time.Duration.
+const maxKeyTTL = 10 * 365 * 24 * 60 * 60
+
 type updateClientCredentialsReq struct {
 	token  string
 	id     string
-	Secret string `json:"secret,omitempty"`
+	Secret string        `json:"secret,omitempty"`
+	TTL    time.Duration `json:"ttl,omitempty"` // key lifetime in seconds, zero for non-expiring keys
 }
 
 func (req updateClientCredentialsReq) validate() error {
@@ -189,6 +326,9 @@ func (req updateClientCredentialsReq) validate() error {
 	if req.Secret == "" {
 		return apiutil.ErrMissingSecret
 	}
+	if req.TTL < 0 || req.TTL > maxKeyTTL {
+		return apiutil.ErrInvalidTTL
+	}
 
 	return nil
 }
@@ -325,6 +465,425 @@ func (req *disconnectChannelThingRequest) validate() error {
 	return nil
 }
 
+type moveThingsRequest struct {
+	token         string
+	groupID       string
+	TargetGroupID string   `json:"target_group_id"`
+	ThingIDs      []string `json:"thing_ids"`
+}
+
+func (req moveThingsRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" || req.TargetGroupID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.groupID == req.TargetGroupID {
+		return errors.ErrMalformedEntity
+	}
+	if len(req.ThingIDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	for _, id := range req.ThingIDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+type reassignThingsRequest struct {
+	token         string
+	groupID       string
+	TargetGroupID string `json:"target_group_id"`
+}
+
+func (req reassignThingsRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" || req.TargetGroupID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.groupID == req.TargetGroupID {
+		return errors.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+const (
+	reportOnConflict   = "report"
+	reassignOnConflict = "reassign"
+)
+
+type attachThingsRequest struct {
+	token      string
+	groupID    string
+	ThingIDs   []string `json:"thing_ids"`
+	OnConflict string   `json:"on_conflict,omitempty"`
+}
+
+func (req attachThingsRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.ThingIDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.ThingIDs) > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	ids := make(map[string]bool, len(req.ThingIDs))
+	for _, id := range req.ThingIDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+		if ids[id] {
+			return errors.ErrMalformedEntity
+		}
+		ids[id] = true
+	}
+	switch req.OnConflict {
+	case "", reportOnConflict, reassignOnConflict:
+	default:
+		return errors.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type updateChannelsRequest struct {
+	token    string
+	merge    bool
+	partial  bool
+	Channels []updateChannelRequest `json:"channels"`
+}
+
+type updateChannelRequest struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Version     uint64                 `json:"version,omitempty"`
+}
+
+func (req updateChannelsRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Channels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.Channels) > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	ids := make(map[string]bool, len(req.Channels))
+	for _, ch := range req.Channels {
+		if ch.ID == "" {
+			return apiutil.ErrMissingID
+		}
+		// A channel updated twice would conflict with itself.
+		if ids[ch.ID] {
+			return errors.ErrMalformedEntity
+		}
+		ids[ch.ID] = true
+		if len(ch.Name) > api.MaxNameSize {
+			return apiutil.ErrNameSize
+		}
+	}
+
+	return nil
+}
+
+func (req updateChannelsRequest) EntitiesMetadata() []map[string]interface{} {
+	mds := make([]map[string]interface{}, len(req.Channels))
+	for i, ch := range req.Channels {
+		mds[i] = ch.Metadata
+	}
+
+	return mds
+}
+
+type deleteChannelsRequest struct {
+	token   string
+	cascade bool
+	IDs     []string `json:"ids"`
+}
+
+func (req deleteChannelsRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.IDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.IDs) > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	ids := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+		if ids[id] {
+			return errors.ErrMalformedEntity
+		}
+		ids[id] = true
+	}
+
+	return nil
+}
+
+// Fields of the channels which can be patched.
+const (
+	nameField        = "name"
+	descriptionField = "description"
+	metadataField    = "metadata"
+)
+
+type patchChannelRequest struct {
+	token   string
+	groupID string
+	patch   map[string]interface{}
+}
+
+func (req patchChannelRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+	for k, v := range req.patch {
+		switch k {
+		case nameField:
+			// Channels can't be left without a name.
+			name, ok := v.(string)
+			if v != nil && !ok {
+				return errors.ErrMalformedEntity
+			}
+			if name == "" {
+				return apiutil.ErrMissingName
+			}
+			if len(name) > api.MaxNameSize {
+				return apiutil.ErrNameSize
+			}
+		case descriptionField:
+			// A null description clears the stored one.
+			if _, ok := v.(string); v != nil && !ok {
+				return errors.ErrMalformedEntity
+			}
+		case metadataField:
+			if _, ok := v.(map[string]interface{}); v != nil && !ok {
+				return errors.ErrMalformedEntity
+			}
+		default:
+			return errors.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+// groupPatch returns the validated patch as a group patch.
+func (req patchChannelRequest) groupPatch() groups.GroupPatch {
+	var patch groups.GroupPatch
+	for k, v := range req.patch {
+		switch k {
+		case nameField:
+			name := v.(string)
+			patch.Name = &name
+		case descriptionField:
+			desc, _ := v.(string)
+			patch.Description = &desc
+		case metadataField:
+			if v == nil {
+				patch.ClearMetadata = true
+				continue
+			}
+			patch.Metadata = v.(map[string]interface{})
+		}
+	}
+
+	return patch
+}
+
+func (req patchChannelRequest) EntitiesMetadata() []map[string]interface{} {
+	md, _ := req.patch[metadataField].(map[string]interface{})
+
+	return []map[string]interface{}{md}
+}
+
+type cloneChannelRequest struct {
+	token         string
+	groupID       string
+	Name          string `json:"name,omitempty"`
+	TargetGroupID string `json:"target_group_id,omitempty"`
+}
+
+func (req cloneChannelRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type listMemberRolesRequest struct {
+	token         string
+	groupID       string
+	inactiveSince time.Time
+}
+
+func (req listMemberRolesRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listDomainMembersRequest struct {
+	token    string
+	domainID string
+	offset   uint64
+	limit    uint64
+}
+
+func (req listDomainMembersRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type revokeMemberRequest struct {
+	token    string
+	domainID string
+	memberID string
+}
+
+func (req revokeMemberRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" || req.memberID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type exportChannelRequest struct {
+	token   string
+	groupID string
+}
+
+func (req exportChannelRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type exportSubtreeRequest struct {
+	token   string
+	groupID string
+	page    things.SubtreePageMeta
+	keys    bool
+}
+
+func (req exportSubtreeRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+	for _, pm := range []groups.PageMeta{req.page.Channels, req.page.Things, req.page.Members} {
+		if pm.Limit > api.MaxLimitSize || pm.Limit < 1 {
+			return apiutil.ErrLimitSize
+		}
+	}
+
+	return nil
+}
+
+type importChannelRequest struct {
+	token    string
+	domainID string
+	groups.Blueprint
+}
+
+func (req importChannelRequest) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.domainID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Depth() > groups.MaxLevel {
+		return apiutil.ErrInvalidLevel
+	}
+
+	return validateBlueprintNames(req.BlueprintGroup)
+}
+
+func (req importChannelRequest) EntitiesMetadata() []map[string]interface{} {
+	return blueprintMetadata(req.BlueprintGroup, nil)
+}
+
+func validateBlueprintNames(bg groups.BlueprintGroup) error {
+	if bg.Name == "" {
+		return apiutil.ErrMissingName
+	}
+	if len(bg.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	for _, child := range bg.Children {
+		if err := validateBlueprintNames(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func blueprintMetadata(bg groups.BlueprintGroup, metadata []map[string]interface{}) []map[string]interface{} {
+	metadata = append(metadata, bg.Metadata)
+	for _, child := range bg.Children {
+		metadata = blueprintMetadata(child, metadata)
+	}
+
+	return metadata
+}
+
 type thingShareRequest struct {
 	token    string
 	thingID  string
@@ -360,16 +919,175 @@ func (req *thingUnshareRequest) validate() error {
 }
 
 type deleteClientReq struct {
+	token           string
+	id              string
+	unmodifiedSince time.Time
+}
+
+func (req deleteClientReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
+type issueTokenReq struct {
+	token  string
+	Scopes []string      `json:"scopes"`
+	TTL    time.Duration `json:"ttl,omitempty"` // token lifetime in seconds, zero for non-expiring tokens
+}
+
+func (req issueTokenReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Scopes) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	for _, scope := range req.Scopes {
+		if !mgclients.ValidScope(scope) {
+			return apiutil.ErrInvalidScope
+		}
+	}
+	if req.TTL < 0 || req.TTL > maxKeyTTL {
+		return apiutil.ErrInvalidTTL
+	}
+
+	return nil
+}
+
+type listTokensReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listTokensReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type bindCertReq struct {
+	token       string
+	thingID     string
+	Fingerprint string `json:"fingerprint"`
+	Subject     string `json:"subject,omitempty"`
+}
+
+func (req bindCertReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Fingerprint == "" {
+		return apiutil.ErrMissingCertData
+	}
+	if _, ok := mgclients.NormalizeFingerprint(req.Fingerprint); !ok {
+		return apiutil.ErrInvalidCertData
+	}
+
+	return nil
+}
+
+type unbindCertReq struct {
+	token       string
+	thingID     string
+	fingerprint string
+}
+
+func (req unbindCertReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.fingerprint == "" {
+		return apiutil.ErrMissingCertData
+	}
+	if _, ok := mgclients.NormalizeFingerprint(req.fingerprint); !ok {
+		return apiutil.ErrInvalidCertData
+	}
+
+	return nil
+}
+
+type identifyCertReq struct {
+	Fingerprint string `json:"fingerprint"`
+	Subject     string `json:"subject,omitempty"`
+}
+
+func (req identifyCertReq) validate() error {
+	if req.Fingerprint == "" {
+		return apiutil.ErrMissingCertData
+	}
+
+	return nil
+}
+
+type validateKeyReq struct {
+	Key string `json:"key"`
+}
+
+func (req validateKeyReq) validate() error {
+	if req.Key == "" {
+		return apiutil.ErrMissingSecret
+	}
+
+	return nil
+}
+
+type heartbeatReq struct {
+	key string
+	id  string
+}
+
+func (req heartbeatReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type revokeTokenReq struct {
 	token string
 	id    string
 }
 
-func (req deleteClientReq) validate() error {
+func (req revokeTokenReq) validate() error {
 	if req.token == "" {
 		return apiutil.ErrBearerToken
 	}
 	if req.id == "" {
 		return apiutil.ErrMissingID
 	}
+
+	return nil
+}
+
+type reconcileCacheReq struct {
+	token string
+}
+
+func (req reconcileCacheReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
 	return nil
 }
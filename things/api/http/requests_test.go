@@ -13,6 +13,8 @@ import (
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
+	"github.com/absmach/magistrala/things"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -190,6 +192,51 @@ func TestViewClientReqValidate(t *testing.T) {
 	}
 }
 
+func TestViewClientsReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  viewClientsReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: viewClientsReq{
+				token: valid,
+				IDs:   []string{validID},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: viewClientsReq{
+				token: "",
+				IDs:   []string{validID},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty ids",
+			req: viewClientsReq{
+				token: valid,
+				IDs:   []string{},
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "empty id",
+			req: viewClientsReq{
+				token: valid,
+				IDs:   []string{validID, ""},
+			},
+			err: apiutil.ErrMissingID,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
 func TestViewClientPermsReq(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -275,6 +322,43 @@ func TestListClientsReqValidate(t *testing.T) {
 			},
 			err: apiutil.ErrNameSize,
 		},
+		{
+			desc: "order by metadata field",
+			req: listClientsReq{
+				token: valid,
+				limit: 10,
+				order: "metadata.location.floor",
+				dir:   api.DescDir,
+			},
+			err: nil,
+		},
+		{
+			desc: "order by malformed metadata field",
+			req: listClientsReq{
+				token: valid,
+				limit: 10,
+				order: "metadata.location..floor",
+			},
+			err: apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "invalid order",
+			req: listClientsReq{
+				token: valid,
+				limit: 10,
+				order: "name",
+			},
+			err: apiutil.ErrInvalidOrder,
+		},
+		{
+			desc: "invalid dir",
+			req: listClientsReq{
+				token: valid,
+				limit: 10,
+				dir:   "up",
+			},
+			err: apiutil.ErrInvalidDirection,
+		},
 	}
 	for _, c := range cases {
 		err := c.req.validate()
@@ -319,6 +403,111 @@ func TestListMembersReqValidate(t *testing.T) {
 	}
 }
 
+func TestRotateKeysReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  rotateKeysReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: rotateKeysReq{
+				token:   valid,
+				groupID: validID,
+				limit:   10,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: rotateKeysReq{
+				token:   "",
+				groupID: validID,
+				limit:   10,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty id",
+			req: rotateKeysReq{
+				token:   valid,
+				groupID: "",
+				limit:   10,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "zero limit",
+			req: rotateKeysReq{
+				token:   valid,
+				groupID: validID,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "limit greater than max",
+			req: rotateKeysReq{
+				token:   valid,
+				groupID: validID,
+				limit:   api.MaxLimitSize + 1,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestTransferChannelReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  transferChannelReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: transferChannelReq{
+				token:          valid,
+				groupID:        validID,
+				TargetDomainID: validID,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: transferChannelReq{
+				token:          "",
+				groupID:        validID,
+				TargetDomainID: validID,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty id",
+			req: transferChannelReq{
+				token:          valid,
+				groupID:        "",
+				TargetDomainID: validID,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty target domain id",
+			req: transferChannelReq{
+				token:   valid,
+				groupID: validID,
+			},
+			err: apiutil.ErrMissingID,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
 func TestUpdateClientReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -450,6 +639,36 @@ func TestUpdateClientCredentialsReqValidate(t *testing.T) {
 			},
 			err: apiutil.ErrMissingSecret,
 		},
+		{
+			desc: "valid request with ttl",
+			req: updateClientCredentialsReq{
+				token:  valid,
+				id:     validID,
+				Secret: valid,
+				TTL:    3600,
+			},
+			err: nil,
+		},
+		{
+			desc: "negative ttl",
+			req: updateClientCredentialsReq{
+				token:  valid,
+				id:     validID,
+				Secret: valid,
+				TTL:    -1,
+			},
+			err: apiutil.ErrInvalidTTL,
+		},
+		{
+			desc: "ttl above max",
+			req: updateClientCredentialsReq{
+				token:  valid,
+				id:     validID,
+				Secret: valid,
+				TTL:    maxKeyTTL + 1,
+			},
+			err: apiutil.ErrInvalidTTL,
+		},
 	}
 	for _, c := range cases {
 		err := c.req.validate()
@@ -750,36 +969,68 @@ func TestConnectChannelThingRequestValidate(t *testing.T) {
 	}
 }
 
-func TestDisconnectChannelThingRequestValidate(t *testing.T) {
+func TestMoveThingsRequestValidate(t *testing.T) {
+	targetID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
 		desc string
-		req  disconnectChannelThingRequest
+		req  moveThingsRequest
 		err  error
 	}{
 		{
 			desc: "valid request",
-			req: disconnectChannelThingRequest{
-				token:     valid,
-				ChannelID: validID,
-				ThingID:   validID,
+			req: moveThingsRequest{
+				token:         valid,
+				groupID:       validID,
+				TargetGroupID: targetID,
+				ThingIDs:      []string{validID},
 			},
 			err: nil,
 		},
 		{
-			desc: "empty channel id",
-			req: disconnectChannelThingRequest{
-				token:     valid,
-				ChannelID: "",
-				ThingID:   validID,
+			desc: "empty token",
+			req: moveThingsRequest{
+				groupID:       validID,
+				TargetGroupID: targetID,
+				ThingIDs:      []string{validID},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty target group id",
+			req: moveThingsRequest{
+				token:    valid,
+				groupID:  validID,
+				ThingIDs: []string{validID},
 			},
 			err: apiutil.ErrMissingID,
 		},
 		{
-			desc: "empty thing id",
-			req: disconnectChannelThingRequest{
-				token:     valid,
-				ChannelID: validID,
-				ThingID:   "",
+			desc: "same source and target group",
+			req: moveThingsRequest{
+				token:         valid,
+				groupID:       validID,
+				TargetGroupID: validID,
+				ThingIDs:      []string{validID},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "empty thing ids",
+			req: moveThingsRequest{
+				token:         valid,
+				groupID:       validID,
+				TargetGroupID: targetID,
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "empty thing id in list",
+			req: moveThingsRequest{
+				token:         valid,
+				groupID:       validID,
+				TargetGroupID: targetID,
+				ThingIDs:      []string{""},
 			},
 			err: apiutil.ErrMissingID,
 		},
@@ -790,51 +1041,47 @@ func TestDisconnectChannelThingRequestValidate(t *testing.T) {
 	}
 }
 
-func TestThingShareRequestValidate(t *testing.T) {
+func TestReassignThingsRequestValidate(t *testing.T) {
+	targetID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
 		desc string
-		req  thingShareRequest
+		req  reassignThingsRequest
 		err  error
 	}{
 		{
 			desc: "valid request",
-			req: thingShareRequest{
-				token:    valid,
-				thingID:  validID,
-				UserIDs:  []string{validID},
-				Relation: valid,
+			req: reassignThingsRequest{
+				token:         valid,
+				groupID:       validID,
+				TargetGroupID: targetID,
 			},
 			err: nil,
 		},
 		{
-			desc: "empty thing id",
-			req: thingShareRequest{
-				token:    valid,
-				thingID:  "",
-				UserIDs:  []string{validID},
-				Relation: valid,
+			desc: "empty token",
+			req: reassignThingsRequest{
+				groupID:       validID,
+				TargetGroupID: targetID,
 			},
-			err: errors.ErrMalformedEntity,
+			err: apiutil.ErrBearerToken,
 		},
 		{
-			desc: "empty user ids",
-			req: thingShareRequest{
-				token:    valid,
-				thingID:  validID,
-				UserIDs:  []string{},
-				Relation: valid,
+			desc: "empty target group id",
+			req: reassignThingsRequest{
+				token:   valid,
+				groupID: validID,
 			},
-			err: svcerr.ErrCreateEntity,
+			err: apiutil.ErrMissingID,
 		},
 		{
-			desc: "empty relation",
-			req: thingShareRequest{
-				token:    valid,
-				thingID:  validID,
-				UserIDs:  []string{validID},
-				Relation: "",
+			desc: "same source and target group",
+			req: reassignThingsRequest{
+				token:         valid,
+				groupID:       validID,
+				TargetGroupID: validID,
 			},
-			err: svcerr.ErrCreateEntity,
+			err: errors.ErrMalformedEntity,
 		},
 	}
 	for _, c := range cases {
@@ -843,51 +1090,632 @@ func TestThingShareRequestValidate(t *testing.T) {
 	}
 }
 
-func TestThingUnshareRequestValidate(t *testing.T) {
+func TestExportSubtreeRequestValidate(t *testing.T) {
+	page := things.SubtreePageMeta{
+		Channels: groups.PageMeta{Limit: 10},
+		Things:   groups.PageMeta{Limit: 10},
+		Members:  groups.PageMeta{Limit: 10},
+	}
+	bigThings := page
+	bigThings.Things.Limit = api.MaxLimitSize + 1
+	zeroMembers := page
+	zeroMembers.Members.Limit = 0
+
 	cases := []struct {
 		desc string
-		req  thingUnshareRequest
+		req  exportSubtreeRequest
 		err  error
 	}{
 		{
 			desc: "valid request",
-			req: thingUnshareRequest{
-				token:    valid,
-				thingID:  validID,
-				UserIDs:  []string{validID},
-				Relation: valid,
+			req: exportSubtreeRequest{
+				token:   valid,
+				groupID: validID,
+				page:    page,
+				keys:    true,
 			},
 			err: nil,
 		},
 		{
-			desc: "empty thing id",
-			req: thingUnshareRequest{
-				token:    valid,
-				thingID:  "",
-				UserIDs:  []string{validID},
-				Relation: valid,
+			desc: "empty token",
+			req: exportSubtreeRequest{
+				groupID: validID,
+				page:    page,
 			},
-			err: errors.ErrMalformedEntity,
+			err: apiutil.ErrBearerToken,
 		},
 		{
-			desc: "empty user ids",
-			req: thingUnshareRequest{
-				token:    valid,
-				thingID:  validID,
-				UserIDs:  []string{},
-				Relation: valid,
+			desc: "empty group id",
+			req: exportSubtreeRequest{
+				token: valid,
+				page:  page,
 			},
-			err: svcerr.ErrCreateEntity,
+			err: apiutil.ErrMissingID,
 		},
 		{
-			desc: "empty relation",
+			desc: "things limit too big",
+			req: exportSubtreeRequest{
+				token:   valid,
+				groupID: validID,
+				page:    bigThings,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "zero members limit",
+			req: exportSubtreeRequest{
+				token:   valid,
+				groupID: validID,
+				page:    zeroMembers,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestAttachThingsRequestValidate(t *testing.T) {
+	thingID := testsutil.GenerateUUID(t)
+	thingIDs := make([]string, api.MaxLimitSize+1)
+	for i := range thingIDs {
+		thingIDs[i] = testsutil.GenerateUUID(t)
+	}
+
+	cases := []struct {
+		desc string
+		req  attachThingsRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: attachThingsRequest{
+				token:    valid,
+				groupID:  validID,
+				ThingIDs: []string{thingID},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request with reassign on conflict",
+			req: attachThingsRequest{
+				token:      valid,
+				groupID:    validID,
+				ThingIDs:   []string{thingID},
+				OnConflict: reassignOnConflict,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: attachThingsRequest{
+				groupID:  validID,
+				ThingIDs: []string{thingID},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty group id",
+			req: attachThingsRequest{
+				token:    valid,
+				ThingIDs: []string{thingID},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty thing ids",
+			req: attachThingsRequest{
+				token:   valid,
+				groupID: validID,
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "too many thing ids",
+			req: attachThingsRequest{
+				token:    valid,
+				groupID:  validID,
+				ThingIDs: thingIDs,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "empty thing id",
+			req: attachThingsRequest{
+				token:    valid,
+				groupID:  validID,
+				ThingIDs: []string{thingID, ""},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "duplicate thing ids",
+			req: attachThingsRequest{
+				token:    valid,
+				groupID:  validID,
+				ThingIDs: []string{thingID, thingID},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "invalid on conflict",
+			req: attachThingsRequest{
+				token:      valid,
+				groupID:    validID,
+				ThingIDs:   []string{thingID},
+				OnConflict: "ignore",
+			},
+			err: errors.ErrMalformedEntity,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestUpdateChannelsRequestValidate(t *testing.T) {
+	channels := make([]updateChannelRequest, api.MaxLimitSize+1)
+	for i := range channels {
+		channels[i] = updateChannelRequest{ID: testsutil.GenerateUUID(t)}
+	}
+
+	cases := []struct {
+		desc string
+		req  updateChannelsRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: updateChannelsRequest{
+				token:    valid,
+				Channels: []updateChannelRequest{{ID: validID, Name: valid}},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: updateChannelsRequest{
+				Channels: []updateChannelRequest{{ID: validID}},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty channels",
+			req: updateChannelsRequest{
+				token: valid,
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "too many channels",
+			req: updateChannelsRequest{
+				token:    valid,
+				Channels: channels,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "empty channel id",
+			req: updateChannelsRequest{
+				token:    valid,
+				Channels: []updateChannelRequest{{Name: valid}},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "duplicated channel id",
+			req: updateChannelsRequest{
+				token:    valid,
+				Channels: []updateChannelRequest{{ID: validID}, {ID: validID}},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "name too long",
+			req: updateChannelsRequest{
+				token:    valid,
+				Channels: []updateChannelRequest{{ID: validID, Name: strings.Repeat("a", api.MaxNameSize+1)}},
+			},
+			err: apiutil.ErrNameSize,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestPatchChannelRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  patchChannelRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch: map[string]interface{}{
+					"name":        valid,
+					"description": valid,
+					"metadata":    map[string]interface{}{"config": map[string]interface{}{"interval": nil}},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request removing metadata",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"metadata": nil},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: patchChannelRequest{
+				groupID: validID,
+				patch:   map[string]interface{}{"name": valid},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty channel id",
+			req: patchChannelRequest{
+				token: valid,
+				patch: map[string]interface{}{"name": valid},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "removed name",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"name": nil},
+			},
+			err: apiutil.ErrMissingName,
+		},
+		{
+			desc: "name too long",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"name": strings.Repeat("a", api.MaxNameSize+1)},
+			},
+			err: apiutil.ErrNameSize,
+		},
+		{
+			desc: "name of invalid type",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"name": 1.0},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "removed description",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"description": nil},
+			},
+			err: nil,
+		},
+		{
+			desc: "description of invalid type",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"description": 1.0},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "metadata of invalid type",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"metadata": "config"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "unknown field",
+			req: patchChannelRequest{
+				token:   valid,
+				groupID: validID,
+				patch:   map[string]interface{}{"status": "disabled"},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestDisconnectChannelThingRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  disconnectChannelThingRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: disconnectChannelThingRequest{
+				token:     valid,
+				ChannelID: validID,
+				ThingID:   validID,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty channel id",
+			req: disconnectChannelThingRequest{
+				token:     valid,
+				ChannelID: "",
+				ThingID:   validID,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty thing id",
+			req: disconnectChannelThingRequest{
+				token:     valid,
+				ChannelID: validID,
+				ThingID:   "",
+			},
+			err: apiutil.ErrMissingID,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestThingShareRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  thingShareRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: thingShareRequest{
+				token:    valid,
+				thingID:  validID,
+				UserIDs:  []string{validID},
+				Relation: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty thing id",
+			req: thingShareRequest{
+				token:    valid,
+				thingID:  "",
+				UserIDs:  []string{validID},
+				Relation: valid,
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "empty user ids",
+			req: thingShareRequest{
+				token:    valid,
+				thingID:  validID,
+				UserIDs:  []string{},
+				Relation: valid,
+			},
+			err: svcerr.ErrCreateEntity,
+		},
+		{
+			desc: "empty relation",
+			req: thingShareRequest{
+				token:    valid,
+				thingID:  validID,
+				UserIDs:  []string{validID},
+				Relation: "",
+			},
+			err: svcerr.ErrCreateEntity,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestThingUnshareRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  thingUnshareRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: thingUnshareRequest{
+				token:    valid,
+				thingID:  validID,
+				UserIDs:  []string{validID},
+				Relation: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty thing id",
+			req: thingUnshareRequest{
+				token:    valid,
+				thingID:  "",
+				UserIDs:  []string{validID},
+				Relation: valid,
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "empty user ids",
+			req: thingUnshareRequest{
+				token:    valid,
+				thingID:  validID,
+				UserIDs:  []string{},
+				Relation: valid,
+			},
+			err: svcerr.ErrCreateEntity,
+		},
+		{
+			desc: "empty relation",
 			req: thingUnshareRequest{
 				token:    valid,
 				thingID:  validID,
 				UserIDs:  []string{validID},
 				Relation: "",
 			},
-			err: svcerr.ErrCreateEntity,
+			err: svcerr.ErrCreateEntity,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestDeleteClientReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  deleteClientReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: deleteClientReq{
+				token: valid,
+				id:    validID,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: deleteClientReq{
+				token: "",
+				id:    validID,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty id",
+			req: deleteClientReq{
+				token: valid,
+				id:    "",
+			},
+			err: apiutil.ErrMissingID,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestAggregateClientsReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  aggregateClientsReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: aggregateClientsReq{
+				token: valid,
+				field: "region",
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: aggregateClientsReq{
+				token: "",
+				field: "region",
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty field",
+			req: aggregateClientsReq{
+				token: valid,
+				field: "",
+			},
+			err: apiutil.ErrInvalidQueryParams,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestIssueTokenReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  issueTokenReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: issueTokenReq{
+				token:  valid,
+				Scopes: []string{mgclients.ThingsReadScope},
+				TTL:    3600,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: issueTokenReq{
+				Scopes: []string{mgclients.ThingsReadScope},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty scopes",
+			req: issueTokenReq{
+				token: valid,
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "invalid scope",
+			req: issueTokenReq{
+				token:  valid,
+				Scopes: []string{mgclients.ThingsReadScope, "things:admin"},
+			},
+			err: apiutil.ErrInvalidScope,
+		},
+		{
+			desc: "negative ttl",
+			req: issueTokenReq{
+				token:  valid,
+				Scopes: []string{mgclients.ThingsWriteScope},
+				TTL:    -1,
+			},
+			err: apiutil.ErrInvalidTTL,
+		},
+		{
+			desc: "too long ttl",
+			req: issueTokenReq{
+				token:  valid,
+				Scopes: []string{mgclients.ThingsWriteScope},
+				TTL:    maxKeyTTL + 1,
+			},
+			err: apiutil.ErrInvalidTTL,
 		},
 	}
 	for _, c := range cases {
@@ -896,33 +1724,346 @@ func TestThingUnshareRequestValidate(t *testing.T) {
 	}
 }
 
-func TestDeleteClientReqValidate(t *testing.T) {
+func TestListTokensReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
-		req  deleteClientReq
+		req  listTokensReq
 		err  error
 	}{
 		{
 			desc: "valid request",
-			req: deleteClientReq{
+			req: listTokensReq{
 				token: valid,
-				id:    validID,
+				limit: 10,
 			},
 			err: nil,
 		},
 		{
 			desc: "empty token",
-			req: deleteClientReq{
-				token: "",
+			req: listTokensReq{
+				limit: 10,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "zero limit",
+			req: listTokensReq{
+				token: valid,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "limit above max",
+			req: listTokensReq{
+				token: valid,
+				limit: api.MaxLimitSize + 1,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestRevokeTokenReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  revokeTokenReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: revokeTokenReq{
+				token: valid,
 				id:    validID,
 			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: revokeTokenReq{
+				id: validID,
+			},
 			err: apiutil.ErrBearerToken,
 		},
 		{
 			desc: "empty id",
-			req: deleteClientReq{
+			req: revokeTokenReq{
 				token: valid,
-				id:    "",
+			},
+			err: apiutil.ErrMissingID,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestReconcileCacheReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  reconcileCacheReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  reconcileCacheReq{token: valid},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  reconcileCacheReq{},
+			err:  apiutil.ErrBearerToken,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestCloneChannelRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  cloneChannelRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: cloneChannelRequest{
+				token:         valid,
+				groupID:       validID,
+				Name:          valid,
+				TargetGroupID: testsutil.GenerateUUID(t),
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request without overrides",
+			req: cloneChannelRequest{
+				token:   valid,
+				groupID: validID,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: cloneChannelRequest{
+				groupID: validID,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty group id",
+			req: cloneChannelRequest{
+				token: valid,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "long name",
+			req: cloneChannelRequest{
+				token:   valid,
+				groupID: validID,
+				Name:    strings.Repeat("a", api.MaxNameSize+1),
+			},
+			err: apiutil.ErrNameSize,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestImportChannelRequestValidate(t *testing.T) {
+	deep := groups.BlueprintGroup{Name: valid}
+	for i := uint64(0); i < groups.MaxLevel; i++ {
+		deep = groups.BlueprintGroup{Name: valid, Children: []groups.BlueprintGroup{deep}}
+	}
+
+	cases := []struct {
+		desc string
+		req  importChannelRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: importChannelRequest{
+				token:    valid,
+				domainID: validID,
+				Blueprint: groups.Blueprint{
+					Version: groups.BlueprintVersion,
+					BlueprintGroup: groups.BlueprintGroup{
+						Name:     valid,
+						Children: []groups.BlueprintGroup{{Name: valid}},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: importChannelRequest{
+				domainID:  validID,
+				Blueprint: groups.Blueprint{BlueprintGroup: groups.BlueprintGroup{Name: valid}},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty domain id",
+			req: importChannelRequest{
+				token:     valid,
+				Blueprint: groups.Blueprint{BlueprintGroup: groups.BlueprintGroup{Name: valid}},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "missing name",
+			req: importChannelRequest{
+				token:    valid,
+				domainID: validID,
+			},
+			err: apiutil.ErrMissingName,
+		},
+		{
+			desc: "missing child name",
+			req: importChannelRequest{
+				token:    valid,
+				domainID: validID,
+				Blueprint: groups.Blueprint{
+					BlueprintGroup: groups.BlueprintGroup{
+						Name:     valid,
+						Children: []groups.BlueprintGroup{{}},
+					},
+				},
+			},
+			err: apiutil.ErrMissingName,
+		},
+		{
+			desc: "long child name",
+			req: importChannelRequest{
+				token:    valid,
+				domainID: validID,
+				Blueprint: groups.Blueprint{
+					BlueprintGroup: groups.BlueprintGroup{
+						Name:     valid,
+						Children: []groups.BlueprintGroup{{Name: strings.Repeat("a", api.MaxNameSize+1)}},
+					},
+				},
+			},
+			err: apiutil.ErrNameSize,
+		},
+		{
+			desc: "too deep",
+			req: importChannelRequest{
+				token:     valid,
+				domainID:  validID,
+				Blueprint: groups.Blueprint{BlueprintGroup: deep},
+			},
+			err: apiutil.ErrInvalidLevel,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestListDomainMembersRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  listDomainMembersRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: listDomainMembersRequest{
+				token:    valid,
+				domainID: validID,
+				limit:    10,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: listDomainMembersRequest{
+				domainID: validID,
+				limit:    10,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty domain id",
+			req: listDomainMembersRequest{
+				token: valid,
+				limit: 10,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "zero limit",
+			req: listDomainMembersRequest{
+				token:    valid,
+				domainID: validID,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "limit above maximum",
+			req: listDomainMembersRequest{
+				token:    valid,
+				domainID: validID,
+				limit:    api.MaxLimitSize + 1,
+			},
+			err: apiutil.ErrLimitSize,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestRevokeMemberRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  revokeMemberRequest
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: revokeMemberRequest{
+				token:    valid,
+				domainID: validID,
+				memberID: validID,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: revokeMemberRequest{
+				domainID: validID,
+				memberID: validID,
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty domain id",
+			req: revokeMemberRequest{
+				token:    valid,
+				memberID: validID,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty member id",
+			req: revokeMemberRequest{
+				token:    valid,
+				domainID: validID,
 			},
 			err: apiutil.ErrMissingID,
 		},
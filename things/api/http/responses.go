@@ -4,11 +4,15 @@
 package http
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/groups"
 )
 
 var (
@@ -17,12 +21,30 @@ var (
 	_ magistrala.Response = (*createClientRes)(nil)
 	_ magistrala.Response = (*deleteClientRes)(nil)
 	_ magistrala.Response = (*clientsPageRes)(nil)
+	_ magistrala.Response = (*tokensPageRes)(nil)
 	_ magistrala.Response = (*viewMembersRes)(nil)
+	_ magistrala.Response = (*transferChannelRes)(nil)
 	_ magistrala.Response = (*assignUsersGroupsRes)(nil)
 	_ magistrala.Response = (*unassignUsersGroupsRes)(nil)
 	_ magistrala.Response = (*connectChannelThingRes)(nil)
 	_ magistrala.Response = (*disconnectChannelThingRes)(nil)
 	_ magistrala.Response = (*changeClientStatusRes)(nil)
+	_ magistrala.Response = (*aggregateClientsRes)(nil)
+	_ magistrala.Response = (*moveThingsRes)(nil)
+	_ magistrala.Response = (*reassignThingsRes)(nil)
+	_ magistrala.Response = (*attachThingsRes)(nil)
+	_ magistrala.Response = (*updateChannelsRes)(nil)
+	_ magistrala.Response = (*deleteChannelsRes)(nil)
+	_ magistrala.Response = (*cloneChannelRes)(nil)
+	_ magistrala.Response = (*exportChannelRes)(nil)
+	_ magistrala.Response = (*exportSubtreeRes)(nil)
+	_ magistrala.Response = (*importChannelRes)(nil)
+	_ magistrala.Response = (*listMemberRolesRes)(nil)
+	_ magistrala.Response = (*listDomainMembersRes)(nil)
+	_ magistrala.Response = (*revokeMemberRes)(nil)
+	_ magistrala.Response = (*countRes)(nil)
+	_ magistrala.Response = (*idempotentRes)(nil)
+	_ magistrala.Response = (*heartbeatRes)(nil)
 )
 
 type pageRes struct {
@@ -76,6 +98,10 @@ func (res updateClientRes) Empty() bool {
 
 type viewClientRes struct {
 	mgclients.Client
+	// Channels are set only when embedded in the listing.
+	Channels *[]groups.Group `json:"channels,omitempty"`
+	// fields limit the response to the requested fields.
+	fields []string
 }
 
 func (res viewClientRes) Code() int {
@@ -90,8 +116,52 @@ func (res viewClientRes) Empty() bool {
 	return false
 }
 
+// MarshalJSON adds the embedded channels to the thing, whose own marshaller
+// would leave them out, and limits the thing to the requested fields.
+func (res viewClientRes) MarshalJSON() ([]byte, error) {
+	if res.Channels == nil {
+		return api.ProjectFields(res.Client, res.fields)
+	}
+	data, err := json.Marshal(res.Client)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields["channels"], err = json.Marshal(*res.Channels); err != nil {
+		return nil, err
+	}
+
+	return api.ProjectFields(fields, res.fields)
+}
+
+type viewClientsItem struct {
+	ID    string            `json:"id"`
+	Thing *mgclients.Client `json:"thing,omitempty"`
+	Error string            `json:"error,omitempty"`
+}
+
+type viewClientsRes struct {
+	Things []viewClientsItem `json:"things"`
+}
+
+func (res viewClientsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewClientsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewClientsRes) Empty() bool {
+	return false
+}
+
 type viewClientPermsRes struct {
 	Permissions []string `json:"permissions"`
+	Actions     []string `json:"actions"`
 }
 
 func (res viewClientPermsRes) Code() int {
@@ -108,7 +178,9 @@ func (res viewClientPermsRes) Empty() bool {
 
 type clientsPageRes struct {
 	pageRes
-	Clients []viewClientRes `json:"things"`
+	Clients     []viewClientRes `json:"things"`
+	HighWater   *time.Time      `json:"high_water,omitempty"`
+	HighWaterID string          `json:"high_water_id,omitempty"`
 }
 
 func (res clientsPageRes) Code() int {
@@ -123,6 +195,40 @@ func (res clientsPageRes) Empty() bool {
 	return false
 }
 
+type rotateKeysRes struct {
+	pageRes
+	Keys map[string]string `json:"keys"`
+}
+
+func (res rotateKeysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rotateKeysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rotateKeysRes) Empty() bool {
+	return false
+}
+
+type transferChannelRes struct {
+	Channel groups.Group       `json:"channel"`
+	Things  []mgclients.Client `json:"things"`
+}
+
+func (res transferChannelRes) Code() int {
+	return http.StatusOK
+}
+
+func (res transferChannelRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res transferChannelRes) Empty() bool {
+	return false
+}
+
 type viewMembersRes struct {
 	mgclients.Client
 }
@@ -281,6 +387,237 @@ func (res disconnectChannelThingRes) Empty() bool {
 	return true
 }
 
+type moveThingsRes struct {
+	Moves []groups.ThingMove `json:"moves"`
+}
+
+func (res moveThingsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res moveThingsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res moveThingsRes) Empty() bool {
+	return false
+}
+
+type reassignThingsRes struct {
+	Moved int `json:"moved"`
+}
+
+func (res reassignThingsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res reassignThingsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res reassignThingsRes) Empty() bool {
+	return false
+}
+
+type attachThingsRes struct {
+	Attachments []groups.ThingAttachment `json:"attachments"`
+}
+
+func (res attachThingsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res attachThingsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res attachThingsRes) Empty() bool {
+	return false
+}
+
+type updateChannelsRes struct {
+	Channels []groups.GroupUpdate `json:"channels"`
+}
+
+func (res updateChannelsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res updateChannelsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateChannelsRes) Empty() bool {
+	return false
+}
+
+type deleteChannelsRes struct {
+	Channels []groups.GroupRemoval `json:"channels"`
+}
+
+func (res deleteChannelsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deleteChannelsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteChannelsRes) Empty() bool {
+	return false
+}
+
+type cloneChannelRes struct {
+	groups.Group
+}
+
+func (res cloneChannelRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res cloneChannelRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/channels/%s", res.ID),
+	}
+}
+
+func (res cloneChannelRes) Empty() bool {
+	return false
+}
+
+type exportChannelRes struct {
+	groups.Blueprint
+}
+
+func (res exportChannelRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportChannelRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res exportChannelRes) Empty() bool {
+	return false
+}
+
+type subtreeChannelsRes struct {
+	pageRes
+	Channels []groups.Group `json:"channels"`
+}
+
+type subtreeThingsRes struct {
+	pageRes
+	Things []mgclients.Client `json:"things"`
+}
+
+type subtreeMembersRes struct {
+	pageRes
+	Members []groups.MemberRole `json:"members"`
+}
+
+type exportSubtreeRes struct {
+	Channel  groups.Group       `json:"channel"`
+	Channels subtreeChannelsRes `json:"channels"`
+	Things   subtreeThingsRes   `json:"things"`
+	Members  subtreeMembersRes  `json:"members"`
+}
+
+func (res exportSubtreeRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportSubtreeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res exportSubtreeRes) Empty() bool {
+	return false
+}
+
+type importChannelRes struct {
+	groups.Group
+}
+
+func (res importChannelRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res importChannelRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/channels/%s", res.ID),
+	}
+}
+
+func (res importChannelRes) Empty() bool {
+	return false
+}
+
+type patchChannelRes struct {
+	groups.Group
+}
+
+func (res patchChannelRes) Code() int {
+	return http.StatusOK
+}
+
+func (res patchChannelRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res patchChannelRes) Empty() bool {
+	return false
+}
+
+type listMemberRolesRes struct {
+	Members []groups.MemberRole `json:"members"`
+}
+
+func (res listMemberRolesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listMemberRolesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listMemberRolesRes) Empty() bool {
+	return false
+}
+
+type listDomainMembersRes struct {
+	pageRes
+	Members []groups.DomainMember `json:"members"`
+}
+
+func (res listDomainMembersRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listDomainMembersRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listDomainMembersRes) Empty() bool {
+	return false
+}
+
+type revokeMemberRes struct {
+	groups.MemberRevocation
+}
+
+func (res revokeMemberRes) Code() int {
+	return http.StatusOK
+}
+
+func (res revokeMemberRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeMemberRes) Empty() bool {
+	return false
+}
+
 type thingShareRes struct{}
 
 func (res thingShareRes) Code() int {
@@ -308,3 +645,210 @@ func (res thingUnshareRes) Headers() map[string]string {
 func (res thingUnshareRes) Empty() bool {
 	return true
 }
+
+type countRes struct {
+	Total uint64 `json:"total"`
+}
+
+func (res countRes) Code() int {
+	return http.StatusOK
+}
+
+func (res countRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res countRes) Empty() bool {
+	return false
+}
+
+type aggregateClientsRes struct {
+	Field  string                        `json:"field"`
+	Values []mgclients.MetadataAggregate `json:"values"`
+}
+
+func (res aggregateClientsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res aggregateClientsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res aggregateClientsRes) Empty() bool {
+	return false
+}
+
+// idempotentRes replays the stored response of a retried request.
+type idempotentRes struct {
+	body []byte
+}
+
+func (res idempotentRes) Code() int {
+	return http.StatusOK
+}
+
+func (res idempotentRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res idempotentRes) Empty() bool {
+	return false
+}
+
+func (res idempotentRes) MarshalJSON() ([]byte, error) {
+	return res.body, nil
+}
+
+type issueTokenRes struct {
+	mgclients.ScopedToken
+}
+
+func (res issueTokenRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res issueTokenRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res issueTokenRes) Empty() bool {
+	return false
+}
+
+type bindCertRes struct {
+	mgclients.CertBinding
+}
+
+func (res bindCertRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res bindCertRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bindCertRes) Empty() bool {
+	return false
+}
+
+type unbindCertRes struct{}
+
+func (res unbindCertRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unbindCertRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unbindCertRes) Empty() bool {
+	return true
+}
+
+type identifyCertRes struct {
+	ID string `json:"id"`
+}
+
+func (res identifyCertRes) Code() int {
+	return http.StatusOK
+}
+
+func (res identifyCertRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res identifyCertRes) Empty() bool {
+	return false
+}
+
+type validateKeyRes struct {
+	Valid     bool       `json:"valid"`
+	ThingID   string     `json:"thing_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	TTL       *int64     `json:"ttl,omitempty"` // remaining key lifetime in seconds
+}
+
+func (res validateKeyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res validateKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res validateKeyRes) Empty() bool {
+	return false
+}
+
+type heartbeatRes struct{}
+
+func (res heartbeatRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res heartbeatRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res heartbeatRes) Empty() bool {
+	return true
+}
+
+type tokensPageRes struct {
+	pageRes
+	Tokens []mgclients.ScopedToken `json:"tokens"`
+}
+
+func (res tokensPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res tokensPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res tokensPageRes) Empty() bool {
+	return false
+}
+
+type revokeTokenRes struct{}
+
+func (res revokeTokenRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeTokenRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeTokenRes) Empty() bool {
+	return true
+}
+
+type reconcileCacheRes struct {
+	Running    bool       `json:"running"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Added      uint64     `json:"added"`
+	Corrected  uint64     `json:"corrected"`
+	Removed    uint64     `json:"removed"`
+	started    bool
+}
+
+func (res reconcileCacheRes) Code() int {
+	if res.started {
+		return http.StatusAccepted
+	}
+
+	return http.StatusOK
+}
+
+func (res reconcileCacheRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res reconcileCacheRes) Empty() bool {
+	return false
+}
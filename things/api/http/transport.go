@@ -6,20 +6,55 @@ package http
 import (
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things"
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// MakeHandler returns a HTTP handler for Things and Groups API endpoints.
-func MakeHandler(tsvc things.Service, grps groups.Service, mux *chi.Mux, logger *slog.Logger, instanceID string) http.Handler {
-	clientsHandler(tsvc, mux, logger)
-	groupsHandler(grps, mux, logger)
+// Config configures the Things and Groups API endpoints.
+type Config struct {
+	// MaxViewIDs limits the number of things viewed by a bulk view request.
+	MaxViewIDs int
+	// MaxMetadataSize limits the metadata of created and updated entities,
+	// in bytes.
+	MaxMetadataSize int
+	// MaxListWait limits the time a listing waits for changes.
+	MaxListWait time.Duration
+	// BodyLimits limits the request bodies, with the bulk limit applying
+	// to the requests of many entities.
+	BodyLimits api.BodyLimits
+	// PageLimits limits the page size of the listings.
+	PageLimits api.PageLimits
+	// FilterLimits limits the metadata filters of the listings.
+	FilterLimits mgclients.FilterLimits
+	// CORS configures the cross-origin requests.
+	CORS api.CORSConfig
+	// InstanceID is reported by the health endpoints.
+	InstanceID string
+	// GatewayToken authenticates the gateways which validate thing keys
+	// and identify things by the certificates the gateways verified. The
+	// gateway requests are rejected if it's empty.
+	GatewayToken string
+}
+
+// MakeHandler returns a HTTP handler for Things and Groups API endpoints,
+// configured by cfg. Checks are run by the readiness endpoint to verify
+// service dependencies. Create requests carrying an idempotency key are
+// deduplicated using icache, if it's not nil.
+func MakeHandler(tsvc things.Service, grps groups.Service, icache things.IdempotencyCache, cfg Config, mux *chi.Mux, logger *slog.Logger, checks map[string]magistrala.HealthCheck) http.Handler {
+	mux.Use(api.CORS(cfg.CORS, mux))
+	mux.Use(api.LimitPageSize(cfg.PageLimits))
+	clientsHandler(tsvc, icache, cfg, mux, logger)
+	groupsHandler(grps, tsvc, icache, cfg, mux, logger)
 
-	mux.Get("/health", magistrala.Health("things", instanceID))
+	mux.Get("/health", magistrala.Health("things", cfg.InstanceID))
+	mux.Get("/health/ready", magistrala.Ready("things", cfg.InstanceID, checks))
 	mux.Handle("/metrics", promhttp.Handler())
 
 	return mux
@@ -11,6 +11,7 @@ import (
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things"
 )
 
@@ -59,6 +60,29 @@ func (lm *loggingMiddleware) ViewClient(ctx context.Context, token, id string) (
 	return lm.svc.ViewClient(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ViewClients(ctx context.Context, token string, ids ...string) (rs []things.ViewResult, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("requested", len(ids)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View things failed", args...)
+			return
+		}
+		var failed int
+		for _, r := range rs {
+			if r.Err != nil {
+				failed++
+			}
+		}
+		args = append(args, slog.Int("failed", failed))
+		lm.logger.Info("View things completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewClients(ctx, token, ids...)
+}
+
 func (lm *loggingMiddleware) ViewClientPerms(ctx context.Context, token, id string) (p []string, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -96,7 +120,82 @@ func (lm *loggingMiddleware) ListClients(ctx context.Context, token, reqUserID s
 	return lm.svc.ListClients(ctx, token, reqUserID, pm)
 }
 
-func (lm *loggingMiddleware) UpdateClient(ctx context.Context, token string, client mgclients.Client) (c mgclients.Client, err error) {
+func (lm *loggingMiddleware) ListOrphanedClients(ctx context.Context, token string, pm mgclients.Page) (cp mgclients.ClientsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", cp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List orphaned things failed", args...)
+			return
+		}
+		lm.logger.Info("List orphaned things completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListOrphanedClients(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (chs map[string][]mggroups.Group, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("things", len(thingIDs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List things channels failed", args...)
+			return
+		}
+		lm.logger.Info("List things channels completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListThingsChannels(ctx, token, thingIDs...)
+}
+
+func (lm *loggingMiddleware) ExportSubtree(ctx context.Context, token, groupID string, pm things.SubtreePageMeta, keys bool) (st things.Subtree, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", groupID),
+			slog.Bool("keys", keys),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export channel subtree failed", args...)
+			return
+		}
+		args = append(args,
+			slog.Uint64("channels", st.Channels.Total),
+			slog.Uint64("things", st.Things.Total),
+			slog.Uint64("members", st.Members.Total),
+		)
+		lm.logger.Info("Export channel subtree completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ExportSubtree(ctx, token, groupID, pm, keys)
+}
+
+func (lm *loggingMiddleware) AggregateMetadata(ctx context.Context, token, field string, status mgclients.Status) (aggs []mgclients.MetadataAggregate, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("field", field),
+			slog.Int("values", len(aggs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Aggregate things metadata failed", args...)
+			return
+		}
+		lm.logger.Info("Aggregate things metadata completed successfully", args...)
+	}(time.Now())
+	return lm.svc.AggregateMetadata(ctx, token, field, status)
+}
+
+func (lm *loggingMiddleware) UpdateClient(ctx context.Context, token string, client mgclients.Client, merge bool) (c mgclients.Client, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -105,15 +204,19 @@ func (lm *loggingMiddleware) UpdateClient(ctx context.Context, token string, cli
 				slog.String("name", client.Name),
 				slog.Any("metadata", client.Metadata),
 			),
+			slog.Bool("merge", merge),
 		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("Update thing failed", args...)
 			return
 		}
+		if client.Version == 0 && !merge {
+			lm.logger.Warn("Update thing without version is deprecated and falls back to last-write-wins", args...)
+		}
 		lm.logger.Info("Update thing completed successfully", args...)
 	}(time.Now())
-	return lm.svc.UpdateClient(ctx, token, client)
+	return lm.svc.UpdateClient(ctx, token, client, merge)
 }
 
 func (lm *loggingMiddleware) UpdateClientTags(ctx context.Context, token string, client mgclients.Client) (c mgclients.Client, err error) {
@@ -136,7 +239,7 @@ func (lm *loggingMiddleware) UpdateClientTags(ctx context.Context, token string,
 	return lm.svc.UpdateClientTags(ctx, token, client)
 }
 
-func (lm *loggingMiddleware) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string) (c mgclients.Client, err error) {
+func (lm *loggingMiddleware) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string, ttl time.Duration) (c mgclients.Client, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -145,6 +248,9 @@ func (lm *loggingMiddleware) UpdateClientSecret(ctx context.Context, token, oldS
 				slog.String("name", c.Name),
 			),
 		}
+		if ttl > 0 {
+			args = append(args, slog.String("ttl", ttl.String()))
+		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("Update thing secret failed", args...)
@@ -152,7 +258,46 @@ func (lm *loggingMiddleware) UpdateClientSecret(ctx context.Context, token, oldS
 		}
 		lm.logger.Info("Update thing secret completed successfully", args...)
 	}(time.Now())
-	return lm.svc.UpdateClientSecret(ctx, token, oldSecret, newSecret)
+	return lm.svc.UpdateClientSecret(ctx, token, oldSecret, newSecret, ttl)
+}
+
+func (lm *loggingMiddleware) RotateKeys(ctx context.Context, token, groupID string, pm mgclients.Page) (cp mgclients.ClientsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", groupID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", cp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Rotate channel thing keys failed", args...)
+			return
+		}
+		lm.logger.Info("Rotate channel thing keys completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RotateKeys(ctx, token, groupID, pm)
+}
+
+func (lm *loggingMiddleware) TransferChannel(ctx context.Context, token, groupID, domainID string) (ct things.ChannelTransfer, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", groupID),
+			slog.String("target_domain_id", domainID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Transfer channel failed", args...)
+			return
+		}
+		args = append(args, slog.Int("things", len(ct.Things)))
+		lm.logger.Info("Transfer channel completed successfully", args...)
+	}(time.Now())
+	return lm.svc.TransferChannel(ctx, token, groupID, domainID)
 }
 
 func (lm *loggingMiddleware) EnableClient(ctx context.Context, token, id string) (c mgclients.Client, err error) {
@@ -230,6 +375,91 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 	return lm.svc.Identify(ctx, key)
 }
 
+func (lm *loggingMiddleware) Heartbeat(ctx context.Context, key, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Heartbeat failed", args...)
+			return
+		}
+		lm.logger.Info("Heartbeat completed successfully", args...)
+	}(time.Now())
+	return lm.svc.Heartbeat(ctx, key, id)
+}
+
+func (lm *loggingMiddleware) ValidateKey(ctx context.Context, key string) (res things.KeyValidation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Bool("valid", res.Valid),
+			slog.String("thing_id", res.ThingID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Validate thing key failed", args...)
+			return
+		}
+		lm.logger.Info("Validate thing key completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ValidateKey(ctx, key)
+}
+
+func (lm *loggingMiddleware) IdentifyCert(ctx context.Context, fingerprint, subject string) (id string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("fingerprint", fingerprint),
+			slog.String("subject", subject),
+			slog.String("thing_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Identify thing by certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Identify thing by certificate completed successfully", args...)
+	}(time.Now())
+	return lm.svc.IdentifyCert(ctx, fingerprint, subject)
+}
+
+func (lm *loggingMiddleware) BindCert(ctx context.Context, token string, cert mgclients.CertBinding) (cb mgclients.CertBinding, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", cert.ThingID),
+			slog.String("fingerprint", cert.Fingerprint),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Bind certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Bind certificate completed successfully", args...)
+	}(time.Now())
+	return lm.svc.BindCert(ctx, token, cert)
+}
+
+func (lm *loggingMiddleware) UnbindCert(ctx context.Context, token, thingID, fingerprint string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("thing_id", thingID),
+			slog.String("fingerprint", fingerprint),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unbind certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Unbind certificate completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UnbindCert(ctx, token, thingID, fingerprint)
+}
+
 func (lm *loggingMiddleware) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (id string, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -249,6 +479,23 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 	return lm.svc.Authorize(ctx, req)
 }
 
+func (lm *loggingMiddleware) RetrieveMetadata(ctx context.Context, entityType, id string) (metadata mgclients.Metadata, status mgclients.Status, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("entity_type", entityType),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve metadata failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve metadata completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RetrieveMetadata(ctx, entityType, id)
+}
+
 func (lm *loggingMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -285,12 +532,15 @@ func (lm *loggingMiddleware) Unshare(ctx context.Context, token, id, relation st
 	return lm.svc.Unshare(ctx, token, id, relation, userids...)
 }
 
-func (lm *loggingMiddleware) DeleteClient(ctx context.Context, token, id string) (err error) {
+func (lm *loggingMiddleware) DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (c mgclients.Client, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
 			slog.String("thing_id", id),
 		}
+		if !unmodifiedSince.IsZero() {
+			args = append(args, slog.Time("unmodified_since", unmodifiedSince))
+		}
 		if err != nil {
 			args = append(args, slog.Any("error", err))
 			lm.logger.Warn("Delete thing failed", args...)
@@ -298,5 +548,117 @@ func (lm *loggingMiddleware) DeleteClient(ctx context.Context, token, id string)
 		}
 		lm.logger.Info("Delete thing completed successfully", args...)
 	}(time.Now())
-	return lm.svc.DeleteClient(ctx, token, id)
+	return lm.svc.DeleteClient(ctx, token, id, unmodifiedSince)
+}
+
+func (lm *loggingMiddleware) WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (ch <-chan things.ThingEvent, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", domainID),
+			slog.Uint64("last_seq", lastSeq),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Watch things failed", args...)
+			return
+		}
+		lm.logger.Info("Watch things started successfully", args...)
+	}(time.Now())
+	return lm.svc.WatchThings(ctx, token, domainID, lastSeq)
+}
+
+func (lm *loggingMiddleware) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (st mgclients.ScopedToken, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Any("scopes", scopes),
+		}
+		if ttl > 0 {
+			args = append(args, slog.String("ttl", ttl.String()))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Issue scoped token failed", args...)
+			return
+		}
+		args = append(args, slog.String("token_id", st.ID))
+		lm.logger.Info("Issue scoped token completed successfully", args...)
+	}(time.Now())
+	return lm.svc.IssueToken(ctx, token, scopes, ttl)
+}
+
+func (lm *loggingMiddleware) ListTokens(ctx context.Context, token string, pm mgclients.Page) (tp mgclients.ScopedTokensPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("total", tp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List scoped tokens failed", args...)
+			return
+		}
+		lm.logger.Info("List scoped tokens completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListTokens(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RevokeToken(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("token_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke scoped token failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke scoped token completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RevokeToken(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ReconcileCache(ctx context.Context, token string) (rec things.CacheReconciliation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Reconcile cache failed", args...)
+			return
+		}
+		args = append(args,
+			slog.Time("started_at", rec.StartedAt),
+		)
+		lm.logger.Info("Reconcile cache started successfully", args...)
+	}(time.Now())
+	return lm.svc.ReconcileCache(ctx, token)
+}
+
+func (lm *loggingMiddleware) ViewCacheReconciliation(ctx context.Context, token string) (rec things.CacheReconciliation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View cache reconciliation failed", args...)
+			return
+		}
+		args = append(args,
+			slog.Bool("running", rec.Running),
+			slog.Uint64("added", rec.Added),
+			slog.Uint64("corrected", rec.Corrected),
+			slog.Uint64("removed", rec.Removed),
+		)
+		lm.logger.Info("View cache reconciliation completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewCacheReconciliation(ctx, token)
 }
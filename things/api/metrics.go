@@ -9,6 +9,7 @@ import (
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things"
 	"github.com/go-kit/kit/metrics"
 )
@@ -46,6 +47,14 @@ func (ms *metricsMiddleware) ViewClient(ctx context.Context, token, id string) (
 	return ms.svc.ViewClient(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ViewClients(ctx context.Context, token string, ids ...string) ([]things.ViewResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_things").Add(1)
+		ms.latency.With("method", "view_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewClients(ctx, token, ids...)
+}
+
 func (ms *metricsMiddleware) ViewClientPerms(ctx context.Context, token, id string) ([]string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_thing_permissions").Add(1)
@@ -62,12 +71,44 @@ func (ms *metricsMiddleware) ListClients(ctx context.Context, token, reqUserID s
 	return ms.svc.ListClients(ctx, token, reqUserID, pm)
 }
 
-func (ms *metricsMiddleware) UpdateClient(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
+func (ms *metricsMiddleware) ListOrphanedClients(ctx context.Context, token string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_orphaned_things").Add(1)
+		ms.latency.With("method", "list_orphaned_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListOrphanedClients(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]mggroups.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_things_channels").Add(1)
+		ms.latency.With("method", "list_things_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListThingsChannels(ctx, token, thingIDs...)
+}
+
+func (ms *metricsMiddleware) ExportSubtree(ctx context.Context, token, groupID string, pm things.SubtreePageMeta, keys bool) (things.Subtree, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_subtree").Add(1)
+		ms.latency.With("method", "export_subtree").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExportSubtree(ctx, token, groupID, pm, keys)
+}
+
+func (ms *metricsMiddleware) AggregateMetadata(ctx context.Context, token, field string, status mgclients.Status) ([]mgclients.MetadataAggregate, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "aggregate_things").Add(1)
+		ms.latency.With("method", "aggregate_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AggregateMetadata(ctx, token, field, status)
+}
+
+func (ms *metricsMiddleware) UpdateClient(ctx context.Context, token string, client mgclients.Client, merge bool) (mgclients.Client, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "update_thing_name_and_metadata").Add(1)
 		ms.latency.With("method", "update_thing_name_and_metadata").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.UpdateClient(ctx, token, client)
+	return ms.svc.UpdateClient(ctx, token, client, merge)
 }
 
 func (ms *metricsMiddleware) UpdateClientTags(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
@@ -78,12 +119,28 @@ func (ms *metricsMiddleware) UpdateClientTags(ctx context.Context, token string,
 	return ms.svc.UpdateClientTags(ctx, token, client)
 }
 
-func (ms *metricsMiddleware) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string) (mgclients.Client, error) {
+func (ms *metricsMiddleware) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string, ttl time.Duration) (mgclients.Client, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "update_thing_secret").Add(1)
 		ms.latency.With("method", "update_thing_secret").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.UpdateClientSecret(ctx, token, oldSecret, newSecret)
+	return ms.svc.UpdateClientSecret(ctx, token, oldSecret, newSecret, ttl)
+}
+
+func (ms *metricsMiddleware) RotateKeys(ctx context.Context, token, groupID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "rotate_channel_thing_keys").Add(1)
+		ms.latency.With("method", "rotate_channel_thing_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RotateKeys(ctx, token, groupID, pm)
+}
+
+func (ms *metricsMiddleware) TransferChannel(ctx context.Context, token, groupID, domainID string) (things.ChannelTransfer, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "transfer_channel").Add(1)
+		ms.latency.With("method", "transfer_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.TransferChannel(ctx, token, groupID, domainID)
 }
 
 func (ms *metricsMiddleware) EnableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
@@ -118,6 +175,46 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string,
 	return ms.svc.Identify(ctx, key)
 }
 
+func (ms *metricsMiddleware) Heartbeat(ctx context.Context, key, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "heartbeat").Add(1)
+		ms.latency.With("method", "heartbeat").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Heartbeat(ctx, key, id)
+}
+
+func (ms *metricsMiddleware) ValidateKey(ctx context.Context, key string) (things.KeyValidation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "validate_key").Add(1)
+		ms.latency.With("method", "validate_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ValidateKey(ctx, key)
+}
+
+func (ms *metricsMiddleware) IdentifyCert(ctx context.Context, fingerprint, subject string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "identify_thing_cert").Add(1)
+		ms.latency.With("method", "identify_thing_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IdentifyCert(ctx, fingerprint, subject)
+}
+
+func (ms *metricsMiddleware) BindCert(ctx context.Context, token string, cert mgclients.CertBinding) (mgclients.CertBinding, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "bind_cert").Add(1)
+		ms.latency.With("method", "bind_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.BindCert(ctx, token, cert)
+}
+
+func (ms *metricsMiddleware) UnbindCert(ctx context.Context, token, thingID, fingerprint string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unbind_cert").Add(1)
+		ms.latency.With("method", "unbind_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UnbindCert(ctx, token, thingID, fingerprint)
+}
+
 func (ms *metricsMiddleware) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (id string, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
@@ -126,6 +223,14 @@ func (ms *metricsMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 	return ms.svc.Authorize(ctx, req)
 }
 
+func (ms *metricsMiddleware) RetrieveMetadata(ctx context.Context, entityType, id string) (mgclients.Metadata, mgclients.Status, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_metadata").Add(1)
+		ms.latency.With("method", "retrieve_metadata").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RetrieveMetadata(ctx, entityType, id)
+}
+
 func (ms *metricsMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "share").Add(1)
@@ -142,10 +247,58 @@ func (ms *metricsMiddleware) Unshare(ctx context.Context, token, id, relation st
 	return ms.svc.Unshare(ctx, token, id, relation, userids...)
 }
 
-func (ms *metricsMiddleware) DeleteClient(ctx context.Context, token, id string) error {
+func (ms *metricsMiddleware) DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (mgclients.Client, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "delete_client").Add(1)
 		ms.latency.With("method", "delete_client").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.DeleteClient(ctx, token, id)
+	return ms.svc.DeleteClient(ctx, token, id, unmodifiedSince)
+}
+
+func (ms *metricsMiddleware) WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (<-chan things.ThingEvent, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "watch_things").Add(1)
+		ms.latency.With("method", "watch_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.WatchThings(ctx, token, domainID, lastSeq)
+}
+
+func (ms *metricsMiddleware) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (mgclients.ScopedToken, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "issue_token").Add(1)
+		ms.latency.With("method", "issue_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IssueToken(ctx, token, scopes, ttl)
+}
+
+func (ms *metricsMiddleware) ListTokens(ctx context.Context, token string, pm mgclients.Page) (mgclients.ScopedTokensPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_tokens").Add(1)
+		ms.latency.With("method", "list_tokens").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListTokens(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RevokeToken(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_token").Add(1)
+		ms.latency.With("method", "revoke_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeToken(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ReconcileCache(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "reconcile_cache").Add(1)
+		ms.latency.With("method", "reconcile_cache").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ReconcileCache(ctx, token)
+}
+
+func (ms *metricsMiddleware) ViewCacheReconciliation(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_cache_reconciliation").Add(1)
+		ms.latency.With("method", "view_cache_reconciliation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewCacheReconciliation(ctx, token)
 }
@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things"
+	"github.com/go-redis/redis/v8"
+)
+
+const idempotencyPrefix = "idempotency_key"
+
+var errKeyContended = errors.New("idempotency key is being reserved concurrently")
+
+var _ things.IdempotencyCache = (*idempotencyCache)(nil)
+
+type idempotencyCache struct {
+	client           *redis.Client
+	keyDuration      time.Duration
+	inFlightDuration time.Duration
+}
+
+// NewIdempotencyCache returns redis idempotency cache implementation which
+// keeps the completed responses for the given duration. Reservations of
+// requests in progress expire after inFlight, so a key held by a crashed
+// request is released without waiting for the whole key duration.
+func NewIdempotencyCache(client *redis.Client, duration, inFlight time.Duration) things.IdempotencyCache {
+	return &idempotencyCache{
+		client:           client,
+		keyDuration:      duration,
+		inFlightDuration: inFlight,
+	}
+}
+
+func (ic *idempotencyCache) Reserve(ctx context.Context, key, fingerprint string) (things.IdempotentResponse, bool, error) {
+	ikey := fmt.Sprintf("%s:%s", idempotencyPrefix, key)
+	data, err := json.Marshal(things.IdempotentResponse{Fingerprint: fingerprint})
+	if err != nil {
+		return things.IdempotentResponse{}, false, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	// The reservation may expire between SetNX and Get, in which case the
+	// key is free again and reserving it is retried once.
+	for range 2 {
+		ok, err := ic.client.SetNX(ctx, ikey, data, ic.inFlightDuration).Result()
+		if err != nil {
+			return things.IdempotentResponse{}, false, errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+		if ok {
+			return things.IdempotentResponse{}, true, nil
+		}
+
+		stored, err := ic.client.Get(ctx, ikey).Bytes()
+		switch {
+		case err == redis.Nil:
+			continue
+		case err != nil:
+			return things.IdempotentResponse{}, false, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		var res things.IdempotentResponse
+		if err := json.Unmarshal(stored, &res); err != nil {
+			return things.IdempotentResponse{}, false, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+
+		return res, false, nil
+	}
+
+	return things.IdempotentResponse{}, false, errors.Wrap(repoerr.ErrCreateEntity, errKeyContended)
+}
+
+// Save stores the response and extends the key to the full key duration.
+func (ic *idempotencyCache) Save(ctx context.Context, key string, res things.IdempotentResponse) error {
+	ikey := fmt.Sprintf("%s:%s", idempotencyPrefix, key)
+	data, err := json.Marshal(res)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if err := ic.client.Set(ctx, ikey, data, ic.keyDuration).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (ic *idempotencyCache) Remove(ctx context.Context, key string) error {
+	ikey := fmt.Sprintf("%s:%s", idempotencyPrefix, key)
+	if err := ic.client.Del(ctx, ikey).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
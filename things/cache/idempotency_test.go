@@ -0,0 +1,52 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	icache := cache.NewIdempotencyCache(redisClient, 1*time.Minute, 1*time.Second)
+	ctx := context.Background()
+
+	res, reserved, err := icache.Reserve(ctx, testKey, "fingerprint")
+	assert.Nil(t, err, fmt.Sprintf("reserve key: unexpected error %s", err))
+	assert.True(t, reserved, "reserve key: expected key to be reserved")
+	assert.Equal(t, things.IdempotentResponse{}, res, fmt.Sprintf("reserve key: expected empty response got %v", res))
+
+	res, reserved, err = icache.Reserve(ctx, testKey, "other")
+	assert.Nil(t, err, fmt.Sprintf("reserve key in progress: unexpected error %s", err))
+	assert.False(t, reserved, "reserve key in progress: expected key not to be reserved")
+	assert.Equal(t, things.IdempotentResponse{Fingerprint: "fingerprint"}, res, fmt.Sprintf("reserve key in progress: expected pending response got %v", res))
+
+	saved := things.IdempotentResponse{Fingerprint: "fingerprint", Body: []byte(`{"id":"testID"}`)}
+	err = icache.Save(ctx, testKey, saved)
+	assert.Nil(t, err, fmt.Sprintf("save response: unexpected error %s", err))
+
+	res, reserved, err = icache.Reserve(ctx, testKey, "fingerprint")
+	assert.Nil(t, err, fmt.Sprintf("reserve completed key: unexpected error %s", err))
+	assert.False(t, reserved, "reserve completed key: expected key not to be reserved")
+	assert.Equal(t, saved, res, fmt.Sprintf("reserve completed key: expected %v got %v", saved, res))
+
+	err = icache.Remove(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("remove key: unexpected error %s", err))
+
+	_, reserved, err = icache.Reserve(ctx, testKey, "fingerprint")
+	assert.Nil(t, err, fmt.Sprintf("reserve removed key: unexpected error %s", err))
+	assert.True(t, reserved, "reserve removed key: expected key to be reserved")
+
+	time.Sleep(1500 * time.Millisecond)
+	_, reserved, err = icache.Reserve(ctx, testKey, "fingerprint")
+	assert.Nil(t, err, fmt.Sprintf("reserve abandoned key: unexpected error %s", err))
+	assert.True(t, reserved, "reserve abandoned key: expected in-flight reservation to expire")
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/things"
+	"github.com/go-kit/kit/metrics"
+)
+
+// Results of the cache lookups.
+const (
+	hit   = "hit"
+	miss  = "miss"
+	fault = "error"
+)
+
+// thingCacheType labels the lookups of the thing cache.
+const thingCacheType = "thing"
+
+var _ things.Cache = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	lookups metrics.Counter
+	cache   things.Cache
+}
+
+// MetricsMiddleware returns a thing cache wrapper counting the hits and the
+// misses of the cache lookups.
+func MetricsMiddleware(cache things.Cache, lookups metrics.Counter) things.Cache {
+	return &metricsMiddleware{
+		lookups: lookups,
+		cache:   cache,
+	}
+}
+
+func (mm *metricsMiddleware) Save(ctx context.Context, thingSecret, thingID string, expiresAt time.Time) error {
+	return mm.cache.Save(ctx, thingSecret, thingID, expiresAt)
+}
+
+func (mm *metricsMiddleware) ID(ctx context.Context, thingSecret string) (string, error) {
+	id, err := mm.cache.ID(ctx, thingSecret)
+	mm.count("id", err)
+
+	return id, err
+}
+
+func (mm *metricsMiddleware) Key(ctx context.Context, thingID string) (string, error) {
+	key, err := mm.cache.Key(ctx, thingID)
+	mm.count("key", err)
+
+	return key, err
+}
+
+func (mm *metricsMiddleware) Remove(ctx context.Context, thingID string) error {
+	return mm.cache.Remove(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) RemoveKey(ctx context.Context, thingSecret string) error {
+	return mm.cache.RemoveKey(ctx, thingSecret)
+}
+
+func (mm *metricsMiddleware) Disable(ctx context.Context, thingID string) error {
+	return mm.cache.Disable(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) Enable(ctx context.Context, thingID string) error {
+	return mm.cache.Enable(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) SaveCert(ctx context.Context, cert mgclients.CertBinding) error {
+	return mm.cache.SaveCert(ctx, cert)
+}
+
+func (mm *metricsMiddleware) Cert(ctx context.Context, fingerprint string) (mgclients.CertBinding, error) {
+	cert, err := mm.cache.Cert(ctx, fingerprint)
+	mm.count("cert", err)
+
+	return cert, err
+}
+
+func (mm *metricsMiddleware) RemoveCert(ctx context.Context, fingerprint, thingID string) error {
+	return mm.cache.RemoveCert(ctx, fingerprint, thingID)
+}
+
+func (mm *metricsMiddleware) Scan(ctx context.Context, cursor uint64, count int64) ([]things.CacheEntry, uint64, error) {
+	return mm.cache.Scan(ctx, cursor, count)
+}
+
+func (mm *metricsMiddleware) SaveDomain(ctx context.Context, thingID, domainID string) error {
+	return mm.cache.SaveDomain(ctx, thingID, domainID)
+}
+
+func (mm *metricsMiddleware) Seen(ctx context.Context, thingID string) error {
+	return mm.cache.Seen(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) Online(ctx context.Context, domainID string, limit int64) ([]string, error) {
+	return mm.cache.Online(ctx, domainID, limit)
+}
+
+func (mm *metricsMiddleware) Heartbeat(ctx context.Context, thingID string) (bool, error) {
+	return mm.cache.Heartbeat(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) Expiry(ctx context.Context, thingSecret string) (time.Time, error) {
+	return mm.cache.Expiry(ctx, thingSecret)
+}
+
+// count records the result of the lookup. Lookups of disabled things are
+// hits, since they are answered by the cache.
+func (mm *metricsMiddleware) count(method string, err error) {
+	result := hit
+	switch {
+	case err == nil, errors.Contains(err, svcerr.ErrThingDisabled):
+	case errors.Contains(err, repoerr.ErrNotFound):
+		result = miss
+	default:
+		result = fault
+	}
+	mm.lookups.With("cache", thingCacheType, "method", method, "result", result).Add(1)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/things/cache"
+	"github.com/absmach/magistrala/things/mocks"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// lookupCounter records the counted values by their label values.
+type lookupCounter struct {
+	lvs    []string
+	counts map[string]float64
+}
+
+func (c *lookupCounter) With(labelValues ...string) metrics.Counter {
+	return &lookupCounter{lvs: append(append([]string{}, c.lvs...), labelValues...), counts: c.counts}
+}
+
+func (c *lookupCounter) Add(delta float64) {
+	c.counts[strings.Join(c.lvs, ",")] += delta
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	cases := []struct {
+		desc   string
+		err    error
+		result string
+	}{
+		{
+			desc:   "lookup of cached thing",
+			err:    nil,
+			result: "hit",
+		},
+		{
+			desc:   "lookup of cached disabled thing",
+			err:    svcerr.ErrThingDisabled,
+			result: "hit",
+		},
+		{
+			desc:   "lookup of uncached thing",
+			err:    repoerr.ErrNotFound,
+			result: "miss",
+		},
+		{
+			desc:   "lookup with failed cache",
+			err:    repoerr.ErrViewEntity,
+			result: "error",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tcache := new(mocks.Cache)
+			counter := &lookupCounter{counts: map[string]float64{}}
+			mcache := cache.MetricsMiddleware(tcache, counter)
+
+			tcache.On("ID", mock.Anything, testKey).Return(testID, tc.err)
+			tcache.On("Cert", mock.Anything, testKey).Return(mgclients.CertBinding{ThingID: testID}, tc.err)
+			tcache.On("Key", mock.Anything, testID).Return(testKey, tc.err)
+			_, _ = mcache.ID(context.Background(), testKey)
+			_, _ = mcache.ID(context.Background(), testKey)
+			_, _ = mcache.Cert(context.Background(), testKey)
+			_, _ = mcache.Key(context.Background(), testID)
+
+			expected := map[string]float64{
+				fmt.Sprintf("cache,thing,method,id,result,%s", tc.result):   2,
+				fmt.Sprintf("cache,thing,method,cert,result,%s", tc.result): 1,
+				fmt.Sprintf("cache,thing,method,key,result,%s", tc.result):  1,
+			}
+			assert.Equal(t, expected, counter.counts, fmt.Sprintf("%s: unexpected lookup counts", tc.desc))
+		})
+	}
+}
@@ -6,45 +6,104 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/things"
 	"github.com/go-redis/redis/v8"
 )
 
 const (
-	keyPrefix = "thing_key"
-	idPrefix  = "thing_id"
+	keyPrefix   = "thing_key"
+	idPrefix    = "thing_id"
+	lastSeenKey = "thing_last_seen"
+	domainKey   = "thing_domain"
+	disabledKey = "thing_disabled"
+	certPrefix  = "thing_cert"
+	certsPrefix = "thing_certs"
+	beatPrefix  = "thing_heartbeat"
+	expiryKey   = "thing_key_expiry"
+
+	certThingField   = "thing_id"
+	certSubjectField = "subject"
 )
 
 var _ things.Cache = (*thingCache)(nil)
 
+// Activity of a thing is written to the cache at most once per this
+// fraction of the stale threshold.
+const seenRefreshDivisor = 10
+
+// maxSeenWrites bounds the number of things whose last activity write is
+// remembered by the instance.
+const maxSeenWrites = 100000
+
 type thingCache struct {
-	client      *redis.Client
-	keyDuration time.Duration
+	client            *redis.Client
+	keyDuration       time.Duration
+	staleThreshold    time.Duration
+	heartbeatInterval time.Duration
+	writes            *seenWrites
 }
 
-// NewCache returns redis thing cache implementation.
-func NewCache(client *redis.Client, duration time.Duration) things.Cache {
+// NewCache returns redis thing cache implementation. Things which were not
+// seen within the stale threshold are considered offline, and things can
+// send one heartbeat per heartbeat interval. Zero interval doesn't limit
+// the heartbeats.
+func NewCache(client *redis.Client, duration, staleThreshold, heartbeatInterval time.Duration) things.Cache {
 	return &thingCache{
-		client:      client,
-		keyDuration: duration,
+		client:            client,
+		keyDuration:       duration,
+		staleThreshold:    staleThreshold,
+		heartbeatInterval: heartbeatInterval,
+		writes: &seenWrites{
+			refresh: staleThreshold / seenRefreshDivisor,
+			last:    make(map[string]time.Time),
+		},
 	}
 }
 
-func (tc *thingCache) Save(ctx context.Context, thingKey, thingID string) error {
+func (tc *thingCache) Save(ctx context.Context, thingKey, thingID string, expiresAt time.Time) error {
 	if thingKey == "" || thingID == "" {
 		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing key or thing id is empty"))
 	}
+
+	// Never serve an expiring key from cache after its expiry.
+	duration := tc.keyDuration
+	if !expiresAt.IsZero() {
+		ttl := time.Until(expiresAt)
+		if ttl <= 0 {
+			return nil
+		}
+		if duration <= 0 || ttl < duration {
+			duration = ttl
+		}
+	}
+
 	tkey := fmt.Sprintf("%s:%s", keyPrefix, thingKey)
-	if err := tc.client.Set(ctx, tkey, thingID, tc.keyDuration).Err(); err != nil {
+	if err := tc.client.Set(ctx, tkey, thingID, duration).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	if err := tc.client.Set(ctx, tid, thingKey, tc.keyDuration).Err(); err != nil {
+	if err := tc.client.Set(ctx, tid, thingKey, duration).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	ekey := fmt.Sprintf("%s:%s", expiryKey, thingKey)
+	if expiresAt.IsZero() {
+		if err := tc.client.Del(ctx, ekey).Err(); err != nil {
+			return errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+		return nil
+	}
+	if err := tc.client.Set(ctx, ekey, expiresAt.Unix(), duration).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
@@ -62,10 +121,49 @@ func (tc *thingCache) ID(ctx context.Context, thingKey string) (string, error) {
 		return "", errors.Wrap(repoerr.ErrNotFound, err)
 	}
 
+	disabled, err := tc.client.SIsMember(ctx, disabledKey, thingID).Result()
+	if err != nil {
+		return "", errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if disabled {
+		return "", svcerr.ErrThingDisabled
+	}
+
 	return thingID, nil
 }
 
+func (tc *thingCache) Key(ctx context.Context, thingID string) (string, error) {
+	if thingID == "" {
+		return "", repoerr.ErrNotFound
+	}
+
+	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
+	key, err := tc.client.Get(ctx, tid).Result()
+	if err == redis.Nil {
+		return "", repoerr.ErrNotFound
+	}
+	if err != nil {
+		return "", errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return key, nil
+}
+
 func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
+	if err := tc.removeSeen(ctx, thingID); err != nil {
+		return err
+	}
+	if err := tc.client.Del(ctx, fmt.Sprintf("%s:%s", domainKey, thingID)).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	if err := tc.client.SRem(ctx, disabledKey, thingID).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	if err := tc.removeCerts(ctx, thingID); err != nil {
+		return err
+	}
+
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
 	key, err := tc.client.Get(ctx, tid).Result()
 	// Redis returns Nil Reply when key does not exist.
@@ -77,9 +175,333 @@ func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
 	}
 
 	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+	ekey := fmt.Sprintf("%s:%s", expiryKey, key)
+	if err := tc.client.Del(ctx, tkey, ekey, tid).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *thingCache) RemoveKey(ctx context.Context, thingKey string) error {
+	tkey := fmt.Sprintf("%s:%s", keyPrefix, thingKey)
+	thingID, err := tc.client.Get(ctx, tkey).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	// The ID entry is removed only if it still refers to the removed key.
+	keys := []string{tkey, fmt.Sprintf("%s:%s", expiryKey, thingKey)}
+	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
+	key, err := tc.client.Get(ctx, tid).Result()
+	if err != nil && err != redis.Nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	if key == thingKey {
+		keys = append(keys, tid)
+	}
+	if err := tc.client.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *thingCache) Disable(ctx context.Context, thingID string) error {
+	if thingID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing id is empty"))
+	}
+
+	// The cached secrets are kept, so the disabled thing is rejected without
+	// looking its secret up in the database.
+	if err := tc.client.SAdd(ctx, disabledKey, thingID).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return tc.removeSeen(ctx, thingID)
+}
+
+func (tc *thingCache) Enable(ctx context.Context, thingID string) error {
+	if err := tc.client.SRem(ctx, disabledKey, thingID).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *thingCache) SaveCert(ctx context.Context, cert mgclients.CertBinding) error {
+	if cert.Fingerprint == "" || cert.ThingID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("certificate fingerprint or thing id is empty"))
+	}
+
+	// The subject is cached along with the thing ID, so it's checked
+	// without a database lookup. The fingerprints of the thing are tracked
+	// so they are removed with it.
+	ckey := fmt.Sprintf("%s:%s", certPrefix, cert.Fingerprint)
+	cids := fmt.Sprintf("%s:%s", certsPrefix, cert.ThingID)
+	if err := tc.client.HSet(ctx, ckey, certThingField, cert.ThingID, certSubjectField, cert.Subject).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if err := tc.client.SAdd(ctx, cids, cert.Fingerprint).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if tc.keyDuration > 0 {
+		for _, key := range []string{ckey, cids} {
+			if err := tc.client.Expire(ctx, key, tc.keyDuration).Err(); err != nil {
+				return errors.Wrap(repoerr.ErrCreateEntity, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tc *thingCache) Cert(ctx context.Context, fingerprint string) (mgclients.CertBinding, error) {
+	if fingerprint == "" {
+		return mgclients.CertBinding{}, repoerr.ErrNotFound
+	}
+
+	ckey := fmt.Sprintf("%s:%s", certPrefix, fingerprint)
+	fields, err := tc.client.HGetAll(ctx, ckey).Result()
+	if err != nil {
+		return mgclients.CertBinding{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	thingID, ok := fields[certThingField]
+	if !ok {
+		return mgclients.CertBinding{}, repoerr.ErrNotFound
+	}
+
+	disabled, err := tc.client.SIsMember(ctx, disabledKey, thingID).Result()
+	if err != nil {
+		return mgclients.CertBinding{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if disabled {
+		return mgclients.CertBinding{}, svcerr.ErrThingDisabled
+	}
+
+	return mgclients.CertBinding{
+		Fingerprint: fingerprint,
+		ThingID:     thingID,
+		Subject:     fields[certSubjectField],
+	}, nil
+}
+
+func (tc *thingCache) RemoveCert(ctx context.Context, fingerprint, thingID string) error {
+	if fingerprint == "" {
+		return errors.Wrap(repoerr.ErrRemoveEntity, errors.New("certificate fingerprint is empty"))
+	}
+
+	ckey := fmt.Sprintf("%s:%s", certPrefix, fingerprint)
+	cids := fmt.Sprintf("%s:%s", certsPrefix, thingID)
+	if err := tc.client.Del(ctx, ckey).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	if err := tc.client.SRem(ctx, cids, fingerprint).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *thingCache) removeCerts(ctx context.Context, thingID string) error {
+	cids := fmt.Sprintf("%s:%s", certsPrefix, thingID)
+	fingerprints, err := tc.client.SMembers(ctx, cids).Result()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	keys := []string{cids}
+	for _, fp := range fingerprints {
+		keys = append(keys, fmt.Sprintf("%s:%s", certPrefix, fp))
+	}
+	if err := tc.client.Del(ctx, keys...).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrRemoveEntity, err)
 	}
 
 	return nil
 }
+
+func (tc *thingCache) Scan(ctx context.Context, cursor uint64, count int64) ([]things.CacheEntry, uint64, error) {
+	tkeys, next, err := tc.client.Scan(ctx, cursor, keyPrefix+":*", count).Result()
+	if err != nil {
+		return nil, 0, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if len(tkeys) == 0 {
+		return []things.CacheEntry{}, next, nil
+	}
+
+	ids, err := tc.client.MGet(ctx, tkeys...).Result()
+	if err != nil {
+		return nil, 0, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	entries := make([]things.CacheEntry, 0, len(tkeys))
+	for i, tkey := range tkeys {
+		// Entries which expired after the scan are skipped.
+		thingID, ok := ids[i].(string)
+		if !ok {
+			continue
+		}
+		entries = append(entries, things.CacheEntry{
+			Secret:  strings.TrimPrefix(tkey, keyPrefix+":"),
+			ThingID: thingID,
+		})
+	}
+
+	return entries, next, nil
+}
+
+func (tc *thingCache) SaveDomain(ctx context.Context, thingID, domainID string) error {
+	if thingID == "" || domainID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing id or domain id is empty"))
+	}
+
+	// The entry doesn't expire, since the domain of a thing changes only
+	// when its channel is transferred, which overwrites the entry.
+	if err := tc.client.Set(ctx, fmt.Sprintf("%s:%s", domainKey, thingID), domainID, 0).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *thingCache) Seen(ctx context.Context, thingID string) error {
+	if thingID == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing id is empty"))
+	}
+
+	now := time.Now()
+	if !tc.writes.due(thingID, now) {
+		return nil
+	}
+	domainID, err := tc.client.Get(ctx, fmt.Sprintf("%s:%s", domainKey, thingID)).Result()
+	if err == redis.Nil {
+		tc.writes.forget(thingID)
+		return repoerr.ErrNotFound
+	}
+	if err != nil {
+		tc.writes.forget(thingID)
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	// Stale entries are pruned on write, so reading the online things
+	// doesn't modify the set.
+	key := fmt.Sprintf("%s:%s", lastSeenKey, domainID)
+	stale := strconv.FormatInt(now.Add(-tc.staleThreshold).Unix(), 10)
+	_, err = tc.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(now.Unix()), Member: thingID})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+stale)
+		return nil
+	})
+	if err != nil {
+		tc.writes.forget(thingID)
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *thingCache) Heartbeat(ctx context.Context, thingID string) (bool, error) {
+	if thingID == "" {
+		return false, errors.Wrap(repoerr.ErrCreateEntity, errors.New("thing id is empty"))
+	}
+
+	// The marker expires with the interval, so it is shared by the
+	// instances and doesn't outlive the limit.
+	if tc.heartbeatInterval > 0 {
+		ok, err := tc.client.SetNX(ctx, fmt.Sprintf("%s:%s", beatPrefix, thingID), true, tc.heartbeatInterval).Result()
+		if err != nil {
+			return false, errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (tc *thingCache) Expiry(ctx context.Context, thingKey string) (time.Time, error) {
+	ekey := fmt.Sprintf("%s:%s", expiryKey, thingKey)
+	exp, err := tc.client.Get(ctx, ekey).Int64()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return time.Unix(exp, 0), nil
+}
+
+func (tc *thingCache) Online(ctx context.Context, domainID string, limit int64) ([]string, error) {
+	key := fmt.Sprintf("%s:%s", lastSeenKey, domainID)
+	stale := strconv.FormatInt(time.Now().Add(-tc.staleThreshold).Unix(), 10)
+	ids, err := tc.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: stale, Max: "+inf", Count: limit}).Result()
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return ids, nil
+}
+
+// removeSeen removes the thing from the online things of its domain.
+func (tc *thingCache) removeSeen(ctx context.Context, thingID string) error {
+	tc.writes.forget(thingID)
+	domainID, err := tc.client.Get(ctx, fmt.Sprintf("%s:%s", domainKey, thingID)).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	if err := tc.client.ZRem(ctx, fmt.Sprintf("%s:%s", lastSeenKey, domainID), thingID).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// seenWrites remembers when the instance last wrote the activity of the
+// things, so things sending many messages don't write on every message.
+type seenWrites struct {
+	mu      sync.Mutex
+	refresh time.Duration
+	last    map[string]time.Time
+}
+
+// due reports whether the activity of the thing should be written, and
+// marks it written if so.
+func (sw *seenWrites) due(thingID string, now time.Time) bool {
+	if sw.refresh <= 0 {
+		return true
+	}
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if last, ok := sw.last[thingID]; ok && now.Sub(last) < sw.refresh {
+		return false
+	}
+	if len(sw.last) >= maxSeenWrites {
+		for id, last := range sw.last {
+			if now.Sub(last) >= sw.refresh {
+				delete(sw.last, id)
+			}
+		}
+		// Forgetting a recent write only costs an extra write.
+		if len(sw.last) >= maxSeenWrites {
+			clear(sw.last)
+		}
+	}
+	sw.last[thingID] = now
+
+	return true
+}
+
+func (sw *seenWrites) forget(thingID string) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	delete(sw.last, thingID)
+}
@@ -10,8 +10,11 @@ import (
 	"testing"
 	"time"
 
+	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/things"
 	"github.com/absmach/magistrala/things/cache"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,11 +24,14 @@ const (
 	testID   = "testID"
 	testKey2 = "testKey2"
 	testID2  = "testID2"
+
+	testDomain  = "testDomain"
+	testDomain2 = "testDomain2"
 )
 
 func TestSave(t *testing.T) {
 	redisClient.FlushAll(context.Background())
-	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
 	ctx := context.Background()
 
 	cases := []struct {
@@ -85,7 +91,7 @@ func TestSave(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := tscache.Save(ctx, tc.key, tc.id)
+		err := tscache.Save(ctx, tc.key, tc.id, time.Time{})
 		if err == nil {
 			id, _ := tscache.ID(ctx, tc.key)
 			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.id, id))
@@ -94,12 +100,58 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestSaveExpiring(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	ctx := context.Background()
+
+	cases := []struct {
+		desc      string
+		key       string
+		id        string
+		expiresAt time.Time
+		cachedID  string
+	}{
+		{
+			desc:      "Save thing with key expiring after cache duration",
+			key:       testKey,
+			id:        testID,
+			expiresAt: time.Now().Add(time.Hour),
+			cachedID:  testID,
+		},
+		{
+			desc:      "Save thing with key expiring before cache duration",
+			key:       testKey2,
+			id:        testID2,
+			expiresAt: time.Now().Add(time.Second),
+			cachedID:  testID2,
+		},
+		{
+			desc:      "Save thing with expired key",
+			key:       "expiredKey",
+			id:        testID,
+			expiresAt: time.Now().Add(-time.Second),
+			cachedID:  "",
+		},
+	}
+
+	for _, tc := range cases {
+		err := tscache.Save(ctx, tc.key, tc.id, tc.expiresAt)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		id, _ := tscache.ID(ctx, tc.key)
+		assert.Equal(t, tc.cachedID, id, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.cachedID, id))
+	}
+
+	ttl := redisClient.TTL(ctx, fmt.Sprintf("thing_key:%s", testKey2)).Val()
+	assert.LessOrEqual(t, ttl, time.Second, fmt.Sprintf("expected ttl at most %s got %s", time.Second, ttl))
+}
+
 func TestID(t *testing.T) {
 	redisClient.FlushAll(context.Background())
-	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
 	ctx := context.Background()
 
-	err := tscache.Save(ctx, testKey, testID)
+	err := tscache.Save(ctx, testKey, testID, time.Time{})
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
 
 	cases := []struct {
@@ -139,10 +191,10 @@ func TestID(t *testing.T) {
 
 func TestRemove(t *testing.T) {
 	redisClient.FlushAll(context.Background())
-	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
 	ctx := context.Background()
 
-	err := tscache.Save(ctx, testKey, testID)
+	err := tscache.Save(ctx, testKey, testID, time.Time{})
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
 
 	cases := []struct {
@@ -177,3 +229,213 @@ func TestRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestDisable(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	ctx := context.Background()
+
+	err := tscache.Save(ctx, testKey, testID, time.Time{})
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+	err = tscache.Disable(ctx, "")
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("Disable thing with empty ID: expected %s got %s", repoerr.ErrCreateEntity, err))
+
+	err = tscache.Disable(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Disable thing: expected nil got %s", err))
+	_, err = tscache.ID(ctx, testKey)
+	assert.True(t, errors.Contains(err, svcerr.ErrThingDisabled), fmt.Sprintf("Get disabled thing ID: expected %s got %s", svcerr.ErrThingDisabled, err))
+	key, err := tscache.Key(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Get disabled thing key: expected nil got %s", err))
+	assert.Equal(t, testKey, key, fmt.Sprintf("Get disabled thing key: expected %s got %s", testKey, key))
+
+	err = tscache.Enable(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Enable thing: expected nil got %s", err))
+	id, err := tscache.ID(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("Get enabled thing ID: expected nil got %s", err))
+	assert.Equal(t, testID, id, fmt.Sprintf("Get enabled thing ID: expected %s got %s", testID, id))
+}
+
+func TestCert(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	ctx := context.Background()
+	cert := mgclients.CertBinding{
+		Fingerprint: strings.Repeat("ab", 32),
+		ThingID:     testID,
+		Subject:     "CN=sensor-1",
+	}
+
+	err := tscache.SaveCert(ctx, mgclients.CertBinding{ThingID: testID})
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("Save cert with empty fingerprint: expected %s got %s", repoerr.ErrCreateEntity, err))
+	err = tscache.SaveCert(ctx, cert)
+	assert.Nil(t, err, fmt.Sprintf("Save cert: expected nil got %s", err))
+
+	cb, err := tscache.Cert(ctx, cert.Fingerprint)
+	assert.Nil(t, err, fmt.Sprintf("Get cert: expected nil got %s", err))
+	assert.Equal(t, cert, cb, fmt.Sprintf("Get cert: expected %v got %v", cert, cb))
+	_, err = tscache.Cert(ctx, strings.Repeat("cd", 32))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Get unknown cert: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = tscache.Disable(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Disable thing: expected nil got %s", err))
+	_, err = tscache.Cert(ctx, cert.Fingerprint)
+	assert.True(t, errors.Contains(err, svcerr.ErrThingDisabled), fmt.Sprintf("Get cert of disabled thing: expected %s got %s", svcerr.ErrThingDisabled, err))
+
+	err = tscache.Remove(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Remove thing: expected nil got %s", err))
+	_, err = tscache.Cert(ctx, cert.Fingerprint)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Get cert of removed thing: expected %s got %s", repoerr.ErrNotFound, err))
+}
+
+func TestRemoveCert(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	ctx := context.Background()
+	cert := mgclients.CertBinding{Fingerprint: strings.Repeat("ab", 32), ThingID: testID}
+	other := mgclients.CertBinding{Fingerprint: strings.Repeat("cd", 32), ThingID: testID}
+
+	for _, cb := range []mgclients.CertBinding{cert, other} {
+		err := tscache.SaveCert(ctx, cb)
+		assert.Nil(t, err, fmt.Sprintf("Save cert: expected nil got %s", err))
+	}
+
+	err := tscache.RemoveCert(ctx, "", testID)
+	assert.True(t, errors.Contains(err, repoerr.ErrRemoveEntity), fmt.Sprintf("Remove cert with empty fingerprint: expected %s got %s", repoerr.ErrRemoveEntity, err))
+	err = tscache.RemoveCert(ctx, cert.Fingerprint, testID)
+	assert.Nil(t, err, fmt.Sprintf("Remove cert: expected nil got %s", err))
+
+	_, err = tscache.Cert(ctx, cert.Fingerprint)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Get removed cert: expected %s got %s", repoerr.ErrNotFound, err))
+	cb, err := tscache.Cert(ctx, other.Fingerprint)
+	assert.Nil(t, err, fmt.Sprintf("Get kept cert: expected nil got %s", err))
+	assert.Equal(t, other, cb, fmt.Sprintf("Get kept cert: expected %v got %v", other, cb))
+}
+
+func TestOnline(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	err := tscache.Seen(ctx, testID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Seen thing of unknown domain: expected %s got %s", repoerr.ErrNotFound, err))
+	err = tscache.SaveDomain(ctx, testID, testDomain)
+	assert.Nil(t, err, fmt.Sprintf("Save thing domain: expected nil got %s", err))
+	err = tscache.SaveDomain(ctx, testID2, testDomain2)
+	assert.Nil(t, err, fmt.Sprintf("Save thing domain: expected nil got %s", err))
+	err = tscache.Seen(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Seen thing: expected nil got %s", err))
+	err = tscache.Seen(ctx, testID2)
+	assert.Nil(t, err, fmt.Sprintf("Seen thing: expected nil got %s", err))
+	err = tscache.Seen(ctx, "")
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("Seen thing with empty ID: expected %s got %s", repoerr.ErrCreateEntity, err))
+
+	ids, err := tscache.Online(ctx, testDomain, 10)
+	assert.Nil(t, err, fmt.Sprintf("Online things: expected nil got %s", err))
+	assert.Equal(t, []string{testID}, ids, fmt.Sprintf("Online things: expected %v got %v", []string{testID}, ids))
+
+	err = tscache.Remove(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Remove thing: expected nil got %s", err))
+	ids, err = tscache.Online(ctx, testDomain, 10)
+	assert.Nil(t, err, fmt.Sprintf("Online things after removal: expected nil got %s", err))
+	assert.Empty(t, ids, fmt.Sprintf("Online things after removal: expected none got %v", ids))
+	ids, err = tscache.Online(ctx, testDomain2, 10)
+	assert.Nil(t, err, fmt.Sprintf("Online things of other domain: expected nil got %s", err))
+	assert.Equal(t, []string{testID2}, ids, fmt.Sprintf("Online things of other domain: expected %v got %v", []string{testID2}, ids))
+
+	staleCache := cache.NewCache(redisClient, 1*time.Minute, -1*time.Minute, 0)
+	err = staleCache.Seen(ctx, testID2)
+	assert.Nil(t, err, fmt.Sprintf("Seen thing: expected nil got %s", err))
+	ids, err = staleCache.Online(ctx, testDomain2, 10)
+	assert.Nil(t, err, fmt.Sprintf("Online stale things: expected nil got %s", err))
+	assert.Empty(t, ids, fmt.Sprintf("Online stale things: expected none got %v", ids))
+}
+
+func TestHeartbeat(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 1*time.Minute)
+	ok, err := tscache.Heartbeat(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Heartbeat: expected nil got %s", err))
+	assert.True(t, ok, "Heartbeat: expected heartbeat to be allowed")
+	ok, err = tscache.Heartbeat(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Repeated heartbeat: expected nil got %s", err))
+	assert.False(t, ok, "Repeated heartbeat: expected heartbeat to be limited")
+	ok, err = tscache.Heartbeat(ctx, testID2)
+	assert.Nil(t, err, fmt.Sprintf("Heartbeat of other thing: expected nil got %s", err))
+	assert.True(t, ok, "Heartbeat of other thing: expected heartbeat to be allowed")
+	_, err = tscache.Heartbeat(ctx, "")
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("Heartbeat with empty ID: expected %s got %s", repoerr.ErrCreateEntity, err))
+}
+
+func TestExpiry(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	err := tscache.Save(ctx, testKey, testID, expiresAt)
+	assert.Nil(t, err, fmt.Sprintf("Save expiring key: expected nil got %s", err))
+	err = tscache.Save(ctx, testKey2, testID2, time.Time{})
+	assert.Nil(t, err, fmt.Sprintf("Save key: expected nil got %s", err))
+
+	exp, err := tscache.Expiry(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("Expiry of expiring key: expected nil got %s", err))
+	assert.True(t, expiresAt.Equal(exp), fmt.Sprintf("Expiry of expiring key: expected %s got %s", expiresAt, exp))
+	exp, err = tscache.Expiry(ctx, testKey2)
+	assert.Nil(t, err, fmt.Sprintf("Expiry of non-expiring key: expected nil got %s", err))
+	assert.True(t, exp.IsZero(), fmt.Sprintf("Expiry of non-expiring key: expected zero time got %s", exp))
+
+	err = tscache.RemoveKey(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("Remove key: expected nil got %s", err))
+	exp, err = tscache.Expiry(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("Expiry of removed key: expected nil got %s", err))
+	assert.True(t, exp.IsZero(), fmt.Sprintf("Expiry of removed key: expected zero time got %s", exp))
+}
+
+func TestReconcileEntries(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 0)
+	err := tscache.Save(ctx, testKey, testID, time.Time{})
+	assert.Nil(t, err, fmt.Sprintf("Save thing: expected nil got %s", err))
+	err = tscache.Save(ctx, testKey2, testID2, time.Time{})
+	assert.Nil(t, err, fmt.Sprintf("Save thing: expected nil got %s", err))
+
+	key, err := tscache.Key(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Key of thing: expected nil got %s", err))
+	assert.Equal(t, testKey, key, fmt.Sprintf("Key of thing: expected %s got %s", testKey, key))
+	_, err = tscache.Key(ctx, "")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Key of thing with empty ID: expected %s got %s", repoerr.ErrNotFound, err))
+
+	var entries []things.CacheEntry
+	var cursor uint64
+	for {
+		batch, next, err := tscache.Scan(ctx, cursor, 1)
+		assert.Nil(t, err, fmt.Sprintf("Scan cache: expected nil got %s", err))
+		entries = append(entries, batch...)
+		if err != nil || next == 0 {
+			break
+		}
+		cursor = next
+	}
+	expected := []things.CacheEntry{{Secret: testKey, ThingID: testID}, {Secret: testKey2, ThingID: testID2}}
+	assert.ElementsMatch(t, expected, entries, fmt.Sprintf("Scan cache: expected %v got %v", expected, entries))
+
+	err = tscache.RemoveKey(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("Remove key: expected nil got %s", err))
+	_, err = tscache.ID(ctx, testKey)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("ID of removed key: expected %s got %s", repoerr.ErrNotFound, err))
+	_, err = tscache.Key(ctx, testID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("Key of thing with removed key: expected %s got %s", repoerr.ErrNotFound, err))
+
+	// Removing a stale key keeps the current key of the thing.
+	err = redisClient.Set(ctx, "thing_key:"+testKey, testID2, time.Minute).Err()
+	assert.Nil(t, err, fmt.Sprintf("Save stale key: expected nil got %s", err))
+	err = tscache.RemoveKey(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("Remove stale key: expected nil got %s", err))
+	key, err = tscache.Key(ctx, testID2)
+	assert.Nil(t, err, fmt.Sprintf("Key of thing with stale key removed: expected nil got %s", err))
+	assert.Equal(t, testKey2, key, fmt.Sprintf("Key of thing with stale key removed: expected %s got %s", testKey2, key))
+}
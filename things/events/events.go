@@ -114,19 +114,25 @@ func (uce updateClientEvent) Encode() (map[string]interface{}, error) {
 
 type changeStatusClientEvent struct {
 	id        string
+	domain    string
 	status    string
 	updatedAt time.Time
 	updatedBy string
 }
 
 func (rce changeStatusClientEvent) Encode() (map[string]interface{}, error) {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"operation":  clientChangeStatus,
 		"id":         rce.id,
 		"status":     rce.status,
 		"updated_at": rce.updatedAt,
 		"updated_by": rce.updatedBy,
-	}, nil
+	}
+	if rce.domain != "" {
+		val["domain"] = rce.domain
+	}
+
+	return val, nil
 }
 
 type viewClientEvent struct {
@@ -352,12 +358,18 @@ func (sce shareClientEvent) Encode() (map[string]interface{}, error) {
 }
 
 type removeClientEvent struct {
-	id string
+	id     string
+	domain string
 }
 
 func (dce removeClientEvent) Encode() (map[string]interface{}, error) {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"operation": clientRemove,
 		"id":        dce.id,
-	}, nil
+	}
+	if dce.domain != "" {
+		val["domain"] = dce.domain
+	}
+
+	return val, nil
 }
@@ -5,11 +5,13 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things"
 )
 
@@ -54,8 +56,24 @@ func (es *eventStore) CreateThings(ctx context.Context, token string, thing ...m
 	return sths, nil
 }
 
-func (es *eventStore) UpdateClient(ctx context.Context, token string, thing mgclients.Client) (mgclients.Client, error) {
-	cli, err := es.svc.UpdateClient(ctx, token, thing)
+func (es *eventStore) ListOrphanedClients(ctx context.Context, token string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	return es.svc.ListOrphanedClients(ctx, token, pm)
+}
+
+func (es *eventStore) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]mggroups.Group, error) {
+	return es.svc.ListThingsChannels(ctx, token, thingIDs...)
+}
+
+func (es *eventStore) ExportSubtree(ctx context.Context, token, groupID string, pm things.SubtreePageMeta, keys bool) (things.Subtree, error) {
+	return es.svc.ExportSubtree(ctx, token, groupID, pm, keys)
+}
+
+func (es *eventStore) AggregateMetadata(ctx context.Context, token, field string, status mgclients.Status) ([]mgclients.MetadataAggregate, error) {
+	return es.svc.AggregateMetadata(ctx, token, field, status)
+}
+
+func (es *eventStore) UpdateClient(ctx context.Context, token string, thing mgclients.Client, merge bool) (mgclients.Client, error) {
+	cli, err := es.svc.UpdateClient(ctx, token, thing, merge)
 	if err != nil {
 		return cli, err
 	}
@@ -72,8 +90,8 @@ func (es *eventStore) UpdateClientTags(ctx context.Context, token string, thing
 	return es.update(ctx, "tags", cli)
 }
 
-func (es *eventStore) UpdateClientSecret(ctx context.Context, token, id, key string) (mgclients.Client, error) {
-	cli, err := es.svc.UpdateClientSecret(ctx, token, id, key)
+func (es *eventStore) UpdateClientSecret(ctx context.Context, token, id, key string, ttl time.Duration) (mgclients.Client, error) {
+	cli, err := es.svc.UpdateClientSecret(ctx, token, id, key, ttl)
 	if err != nil {
 		return cli, err
 	}
@@ -81,6 +99,36 @@ func (es *eventStore) UpdateClientSecret(ctx context.Context, token, id, key str
 	return es.update(ctx, "secret", cli)
 }
 
+func (es *eventStore) RotateKeys(ctx context.Context, token, groupID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	cp, err := es.svc.RotateKeys(ctx, token, groupID, pm)
+	if err != nil {
+		return cp, err
+	}
+
+	for _, cli := range cp.Clients {
+		if _, err := es.update(ctx, "secret", cli); err != nil {
+			return cp, err
+		}
+	}
+
+	return cp, nil
+}
+
+func (es *eventStore) TransferChannel(ctx context.Context, token, groupID, domainID string) (things.ChannelTransfer, error) {
+	ct, err := es.svc.TransferChannel(ctx, token, groupID, domainID)
+	if err != nil {
+		return ct, err
+	}
+
+	for _, cli := range ct.Things {
+		if _, err := es.update(ctx, "domain", cli); err != nil {
+			return ct, err
+		}
+	}
+
+	return ct, nil
+}
+
 func (es *eventStore) update(ctx context.Context, operation string, thing mgclients.Client) (mgclients.Client, error) {
 	event := updateClientEvent{
 		thing, operation,
@@ -109,6 +157,10 @@ func (es *eventStore) ViewClient(ctx context.Context, token, id string) (mgclien
 	return cli, nil
 }
 
+func (es *eventStore) ViewClients(ctx context.Context, token string, ids ...string) ([]things.ViewResult, error) {
+	return es.svc.ViewClients(ctx, token, ids...)
+}
+
 func (es *eventStore) ViewClientPerms(ctx context.Context, token, id string) ([]string, error) {
 	permissions, err := es.svc.ViewClientPerms(ctx, token, id)
 	if err != nil {
@@ -177,6 +229,7 @@ func (es *eventStore) DisableClient(ctx context.Context, token, id string) (mgcl
 func (es *eventStore) changeStatus(ctx context.Context, cli mgclients.Client) (mgclients.Client, error) {
 	event := changeStatusClientEvent{
 		id:        cli.ID,
+		domain:    cli.Domain,
 		updatedAt: cli.UpdatedAt,
 		updatedBy: cli.UpdatedBy,
 		status:    cli.Status.String(),
@@ -203,6 +256,37 @@ func (es *eventStore) Identify(ctx context.Context, key string) (string, error)
 	return thingID, nil
 }
 
+func (es *eventStore) Heartbeat(ctx context.Context, key, id string) error {
+	return es.svc.Heartbeat(ctx, key, id)
+}
+
+func (es *eventStore) ValidateKey(ctx context.Context, key string) (things.KeyValidation, error) {
+	return es.svc.ValidateKey(ctx, key)
+}
+
+func (es *eventStore) IdentifyCert(ctx context.Context, fingerprint, subject string) (string, error) {
+	thingID, err := es.svc.IdentifyCert(ctx, fingerprint, subject)
+	if err != nil {
+		return thingID, err
+	}
+	event := identifyClientEvent{
+		thingID: thingID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return thingID, err
+	}
+	return thingID, nil
+}
+
+func (es *eventStore) BindCert(ctx context.Context, token string, cert mgclients.CertBinding) (mgclients.CertBinding, error) {
+	return es.svc.BindCert(ctx, token, cert)
+}
+
+func (es *eventStore) UnbindCert(ctx context.Context, token, thingID, fingerprint string) error {
+	return es.svc.UnbindCert(ctx, token, thingID, fingerprint)
+}
+
 func (es *eventStore) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (string, error) {
 	thingID, err := es.svc.Authorize(ctx, req)
 	if err != nil {
@@ -222,6 +306,10 @@ func (es *eventStore) Authorize(ctx context.Context, req *magistrala.AuthorizeRe
 	return thingID, nil
 }
 
+func (es *eventStore) RetrieveMetadata(ctx context.Context, entityType, id string) (mgclients.Metadata, mgclients.Status, error) {
+	return es.svc.RetrieveMetadata(ctx, entityType, id)
+}
+
 func (es *eventStore) Share(ctx context.Context, token, id, relation string, userids ...string) error {
 	if err := es.svc.Share(ctx, token, id, relation, userids...); err != nil {
 		return err
@@ -252,16 +340,42 @@ func (es *eventStore) Unshare(ctx context.Context, token, id, relation string, u
 	return es.Publish(ctx, event)
 }
 
-func (es *eventStore) DeleteClient(ctx context.Context, token, id string) error {
-	if err := es.svc.DeleteClient(ctx, token, id); err != nil {
-		return err
+func (es *eventStore) DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (mgclients.Client, error) {
+	cli, err := es.svc.DeleteClient(ctx, token, id, unmodifiedSince)
+	if err != nil {
+		return cli, err
 	}
 
-	event := removeClientEvent{id}
+	// The domain lets the watchers of the domain receive the event.
+	event := removeClientEvent{id: id, domain: cli.Domain}
 
 	if err := es.Publish(ctx, event); err != nil {
-		return err
+		return cli, err
 	}
 
-	return nil
+	return cli, nil
+}
+
+func (es *eventStore) WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (<-chan things.ThingEvent, error) {
+	return es.svc.WatchThings(ctx, token, domainID, lastSeq)
+}
+
+func (es *eventStore) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (mgclients.ScopedToken, error) {
+	return es.svc.IssueToken(ctx, token, scopes, ttl)
+}
+
+func (es *eventStore) ListTokens(ctx context.Context, token string, pm mgclients.Page) (mgclients.ScopedTokensPage, error) {
+	return es.svc.ListTokens(ctx, token, pm)
+}
+
+func (es *eventStore) RevokeToken(ctx context.Context, token, id string) error {
+	return es.svc.RevokeToken(ctx, token, id)
+}
+
+func (es *eventStore) ReconcileCache(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	return es.svc.ReconcileCache(ctx, token)
+}
+
+func (es *eventStore) ViewCacheReconciliation(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	return es.svc.ViewCacheReconciliation(ctx, token)
 }
@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/things"
+)
+
+// Watcher dispatches thing changes consumed from the things event stream
+// to the watchers of their domain.
+type Watcher interface {
+	things.Watcher
+	events.EventHandler
+}
+
+var _ Watcher = (*watcher)(nil)
+
+type subscription struct {
+	domainID string
+	// after skips the events a watcher resuming ahead of this instance
+	// already received from another one.
+	after  uint64
+	events chan things.ThingEvent
+}
+
+type watcher struct {
+	mu   sync.Mutex
+	size int
+	// since is the oldest position watchers can resume from; every event
+	// after it is retained. Zero until the first positioned event.
+	since  uint64
+	recent []things.ThingEvent
+	subs   map[*subscription]struct{}
+}
+
+// NewWatcher returns a watcher which retains the latest size events so
+// watchers are able to resume from the last sequence number they received.
+// Sequence numbers are the positions of the events in the things event
+// stream, so they don't depend on the things instance serving the watcher.
+// Events without a position are streamed but can't be resumed from.
+func NewWatcher(size int) Watcher {
+	return &watcher{
+		size: size,
+		subs: make(map[*subscription]struct{}),
+	}
+}
+
+func (w *watcher) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+	op := toOperation(events.Read(msg, "operation", ""))
+	if op == "" {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	te := things.ThingEvent{
+		Operation: op,
+		ThingID:   events.Read(msg, "id", ""),
+		DomainID:  events.Read(msg, "domain", ""),
+		CreatedAt: time.Now(),
+	}
+	if pe, ok := event.(events.Positioned); ok {
+		te.Seq = pe.Position()
+	}
+	if te.Seq > 0 {
+		if w.since == 0 {
+			w.since = te.Seq
+		}
+		w.recent = append(w.recent, te)
+		if len(w.recent) > w.size {
+			w.since = w.recent[len(w.recent)-w.size-1].Seq
+			w.recent = w.recent[len(w.recent)-w.size:]
+		}
+	}
+
+	for sub := range w.subs {
+		if sub.domainID != te.DomainID || (te.Seq > 0 && te.Seq <= sub.after) {
+			continue
+		}
+		select {
+		case sub.events <- te:
+		default:
+			// Drop watchers which can't keep up; they resume from
+			// the last received sequence number.
+			w.remove(sub)
+		}
+	}
+
+	return nil
+}
+
+func (w *watcher) Watch(ctx context.Context, domainID string, lastSeq uint64) (<-chan things.ThingEvent, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var missed []things.ThingEvent
+	if lastSeq > 0 {
+		// Events between lastSeq and the first event this instance
+		// consumed may have been missed, so only resume after since.
+		if w.since == 0 || lastSeq < w.since {
+			return nil, things.ErrSeqExpired
+		}
+		for _, te := range w.recent {
+			if te.Seq > lastSeq && te.DomainID == domainID {
+				missed = append(missed, te)
+			}
+		}
+	}
+
+	sub := &subscription{
+		domainID: domainID,
+		after:    lastSeq,
+		events:   make(chan things.ThingEvent, w.size+len(missed)),
+	}
+	for _, te := range missed {
+		sub.events <- te
+	}
+	w.subs[sub] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.remove(sub)
+	}()
+
+	return sub.events, nil
+}
+
+func (w *watcher) remove(sub *subscription) {
+	if _, ok := w.subs[sub]; ok {
+		delete(w.subs, sub)
+		close(sub.events)
+	}
+}
+
+func toOperation(operation string) string {
+	switch {
+	case operation == clientCreate:
+		return things.CreateOp
+	case operation == clientRemove:
+		return things.RemoveOp
+	case operation == clientChangeStatus, strings.HasPrefix(operation, clientUpdate):
+		return things.UpdateOp
+	default:
+		return ""
+	}
+}
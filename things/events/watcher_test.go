@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/things"
+	"github.com/absmach/magistrala/things/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent map[string]interface{}
+
+func (te testEvent) Encode() (map[string]interface{}, error) {
+	return te, nil
+}
+
+type positionedEvent struct {
+	testEvent
+	position uint64
+}
+
+func (pe positionedEvent) Position() uint64 {
+	return pe.position
+}
+
+func TestWatch(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+
+	w := events.NewWatcher(2)
+	evs := []positionedEvent{
+		{testEvent{"operation": "thing.create", "id": thingID, "domain": domainID}, 10},
+		{testEvent{"operation": "thing.create", "id": testsutil.GenerateUUID(t), "domain": testsutil.GenerateUUID(t)}, 12},
+		{testEvent{"operation": "thing.update_tags", "id": thingID, "domain": domainID}, 15},
+		{testEvent{"operation": "thing.view", "id": thingID, "domain": domainID}, 16},
+		{testEvent{"operation": "thing.remove", "id": thingID, "domain": domainID}, 20},
+	}
+	for _, ev := range evs {
+		require.Nil(t, w.Handle(context.Background(), ev), "handling event should not fail")
+	}
+
+	cases := []struct {
+		desc    string
+		lastSeq uint64
+		ops     []string
+		err     error
+	}{
+		{
+			desc: "watch without sequence",
+			ops:  []string{},
+		},
+		{
+			desc:    "watch from the latest sequence",
+			lastSeq: 20,
+			ops:     []string{},
+		},
+		{
+			desc:    "watch from a buffered sequence",
+			lastSeq: 15,
+			ops:     []string{things.RemoveOp},
+		},
+		{
+			desc:    "watch from the last evicted sequence",
+			lastSeq: 12,
+			ops:     []string{things.UpdateOp, things.RemoveOp},
+		},
+		{
+			desc:    "watch from a sequence between buffered events",
+			lastSeq: 13,
+			ops:     []string{things.UpdateOp, things.RemoveOp},
+		},
+		{
+			desc:    "watch from an evicted sequence",
+			lastSeq: 10,
+			err:     things.ErrSeqExpired,
+		},
+		{
+			desc:    "watch from a sequence ahead of the watcher",
+			lastSeq: 25,
+			ops:     []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ch, err := w.Watch(ctx, domainID, tc.lastSeq)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err != nil {
+				return
+			}
+			ops := []string{}
+			for len(ch) > 0 {
+				ev := <-ch
+				assert.Equal(t, thingID, ev.ThingID, fmt.Sprintf("%s: expected thing %s got %s\n", tc.desc, thingID, ev.ThingID))
+				ops = append(ops, ev.Operation)
+			}
+			assert.Equal(t, tc.ops, ops, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ops, ops))
+		})
+	}
+}
+
+func TestWatchLive(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+
+	w := events.NewWatcher(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := w.Watch(ctx, domainID, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = w.Handle(context.Background(), positionedEvent{testEvent{"operation": "thing.change_status", "id": thingID, "domain": domainID}, 7})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ev := <-ch
+	assert.Equal(t, uint64(7), ev.Seq, fmt.Sprintf("expected sequence 7 got %d", ev.Seq))
+	assert.Equal(t, things.UpdateOp, ev.Operation, fmt.Sprintf("expected operation %s got %s", things.UpdateOp, ev.Operation))
+
+	// Slow watchers are dropped once their buffer is full.
+	for i := uint64(0); i < 2; i++ {
+		err = w.Handle(context.Background(), positionedEvent{testEvent{"operation": "thing.update", "id": thingID, "domain": domainID}, 8 + i})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	<-ch
+	_, ok := <-ch
+	assert.False(t, ok, "expected slow watcher to be closed")
+
+	cancel()
+}
+
+func TestWatchResume(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	thingID := testsutil.GenerateUUID(t)
+
+	// A new instance can't tell which events preceded the first one it
+	// consumed, nor resume from events without a stream position.
+	w := events.NewWatcher(2)
+	_, err := w.Watch(context.Background(), domainID, 5)
+	assert.True(t, errors.Contains(err, things.ErrSeqExpired), fmt.Sprintf("expected %s got %s", things.ErrSeqExpired, err))
+
+	err = w.Handle(context.Background(), testEvent{"operation": "thing.create", "id": thingID, "domain": domainID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = w.Watch(context.Background(), domainID, 5)
+	assert.True(t, errors.Contains(err, things.ErrSeqExpired), fmt.Sprintf("expected %s got %s", things.ErrSeqExpired, err))
+
+	err = w.Handle(context.Background(), positionedEvent{testEvent{"operation": "thing.update", "id": thingID, "domain": domainID}, 6})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = w.Watch(context.Background(), domainID, 5)
+	assert.True(t, errors.Contains(err, things.ErrSeqExpired), fmt.Sprintf("expected %s got %s", things.ErrSeqExpired, err))
+
+	// A watcher resuming ahead of this instance skips the events it
+	// already received.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := w.Watch(ctx, domainID, 8)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for i := uint64(7); i <= 9; i++ {
+		err = w.Handle(context.Background(), positionedEvent{testEvent{"operation": "thing.update", "id": thingID, "domain": domainID}, i})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	ev := <-ch
+	assert.Equal(t, uint64(9), ev.Seq, fmt.Sprintf("expected sequence 9 got %d", ev.Seq))
+	assert.Equal(t, 0, len(ch), fmt.Sprintf("expected no more events got %d", len(ch)))
+}
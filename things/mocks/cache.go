@@ -7,7 +7,13 @@ package mocks
 import (
 	context "context"
 
+	clients "github.com/absmach/magistrala/pkg/clients"
+
 	mock "github.com/stretchr/testify/mock"
+
+	things "github.com/absmach/magistrala/things"
+
+	time "time"
 )
 
 // Cache is an autogenerated mock type for the Cache type
@@ -15,6 +21,126 @@ type Cache struct {
 	mock.Mock
 }
 
+// Cert provides a mock function with given fields: ctx, fingerprint
+func (_m *Cache) Cert(ctx context.Context, fingerprint string) (clients.CertBinding, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cert")
+	}
+
+	var r0 clients.CertBinding
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (clients.CertBinding, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) clients.CertBinding); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		r0 = ret.Get(0).(clients.CertBinding)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disable provides a mock function with given fields: ctx, thingID
+func (_m *Cache) Disable(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, thingID
+func (_m *Cache) Enable(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Expiry provides a mock function with given fields: ctx, thingSecret
+func (_m *Cache) Expiry(ctx context.Context, thingSecret string) (time.Time, error) {
+	ret := _m.Called(ctx, thingSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Expiry")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, thingSecret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, thingSecret)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, thingSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Heartbeat provides a mock function with given fields: ctx, thingID
+func (_m *Cache) Heartbeat(ctx context.Context, thingID string) (bool, error) {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Heartbeat")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, thingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, thingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ID provides a mock function with given fields: ctx, thingSecret
 func (_m *Cache) ID(ctx context.Context, thingSecret string) (string, error) {
 	ret := _m.Called(ctx, thingSecret)
@@ -43,6 +169,64 @@ func (_m *Cache) ID(ctx context.Context, thingSecret string) (string, error) {
 	return r0, r1
 }
 
+// Key provides a mock function with given fields: ctx, thingID
+func (_m *Cache) Key(ctx context.Context, thingID string) (string, error) {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Key")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, thingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, thingID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, thingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Online provides a mock function with given fields: ctx, domainID, limit
+func (_m *Cache) Online(ctx context.Context, domainID string, limit int64) ([]string, error) {
+	ret := _m.Called(ctx, domainID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Online")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]string, error)); ok {
+		return rf(ctx, domainID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []string); ok {
+		r0 = rf(ctx, domainID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, domainID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Remove provides a mock function with given fields: ctx, thingID
 func (_m *Cache) Remove(ctx context.Context, thingID string) error {
 	ret := _m.Called(ctx, thingID)
@@ -61,17 +245,144 @@ func (_m *Cache) Remove(ctx context.Context, thingID string) error {
 	return r0
 }
 
-// Save provides a mock function with given fields: ctx, thingSecret, thingID
-func (_m *Cache) Save(ctx context.Context, thingSecret string, thingID string) error {
-	ret := _m.Called(ctx, thingSecret, thingID)
+// RemoveCert provides a mock function with given fields: ctx, fingerprint, thingID
+func (_m *Cache) RemoveCert(ctx context.Context, fingerprint string, thingID string) error {
+	ret := _m.Called(ctx, fingerprint, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fingerprint, thingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveKey provides a mock function with given fields: ctx, thingSecret
+func (_m *Cache) RemoveKey(ctx context.Context, thingSecret string) error {
+	ret := _m.Called(ctx, thingSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingSecret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: ctx, thingSecret, thingID, expiresAt
+func (_m *Cache) Save(ctx context.Context, thingSecret string, thingID string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, thingSecret, thingID, expiresAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, thingSecret, thingID, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCert provides a mock function with given fields: ctx, cert
+func (_m *Cache) SaveCert(ctx context.Context, cert clients.CertBinding) error {
+	ret := _m.Called(ctx, cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.CertBinding) error); ok {
+		r0 = rf(ctx, cert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveDomain provides a mock function with given fields: ctx, thingID, domainID
+func (_m *Cache) SaveDomain(ctx context.Context, thingID string, domainID string) error {
+	ret := _m.Called(ctx, thingID, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDomain")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, thingSecret, thingID)
+		r0 = rf(ctx, thingID, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Scan provides a mock function with given fields: ctx, cursor, count
+func (_m *Cache) Scan(ctx context.Context, cursor uint64, count int64) ([]things.CacheEntry, uint64, error) {
+	ret := _m.Called(ctx, cursor, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scan")
+	}
+
+	var r0 []things.CacheEntry
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) ([]things.CacheEntry, uint64, error)); ok {
+		return rf(ctx, cursor, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) []things.CacheEntry); ok {
+		r0 = rf(ctx, cursor, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]things.CacheEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int64) uint64); ok {
+		r1 = rf(ctx, cursor, count)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, int64) error); ok {
+		r2 = rf(ctx, cursor, count)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Seen provides a mock function with given fields: ctx, thingID
+func (_m *Cache) Seen(ctx context.Context, thingID string) error {
+	ret := _m.Called(ctx, thingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Seen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, thingID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -9,6 +9,8 @@ import (
 
 	clients "github.com/absmach/magistrala/pkg/clients"
 
+	groups "github.com/absmach/magistrala/pkg/groups"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -17,6 +19,36 @@ type Repository struct {
 	mock.Mock
 }
 
+// AggregateMetadata provides a mock function with given fields: ctx, pm, field
+func (_m *Repository) AggregateMetadata(ctx context.Context, pm clients.Page, field string) ([]clients.MetadataAggregate, error) {
+	ret := _m.Called(ctx, pm, field)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateMetadata")
+	}
+
+	var r0 []clients.MetadataAggregate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Page, string) ([]clients.MetadataAggregate, error)); ok {
+		return rf(ctx, pm, field)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Page, string) []clients.MetadataAggregate); ok {
+		r0 = rf(ctx, pm, field)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.MetadataAggregate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Page, string) error); ok {
+		r1 = rf(ctx, pm, field)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChangeStatus provides a mock function with given fields: ctx, client
 func (_m *Repository) ChangeStatus(ctx context.Context, client clients.Client) (clients.Client, error) {
 	ret := _m.Called(ctx, client)
@@ -63,6 +95,42 @@ func (_m *Repository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// RemoveCert provides a mock function with given fields: ctx, clientID, fingerprint
+func (_m *Repository) RemoveCert(ctx context.Context, clientID string, fingerprint string) error {
+	ret := _m.Called(ctx, clientID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, clientID, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveToken provides a mock function with given fields: ctx, domainID, id
+func (_m *Repository) RemoveToken(ctx context.Context, domainID string, id string) error {
+	ret := _m.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, pm
 func (_m *Repository) RetrieveAll(ctx context.Context, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -147,6 +215,69 @@ func (_m *Repository) RetrieveAllByIDs(ctx context.Context, pm clients.Page) (cl
 	return r0, r1
 }
 
+// RetrieveAllTokens provides a mock function with given fields: ctx, domainID, pm
+func (_m *Repository) RetrieveAllTokens(ctx context.Context, domainID string, pm clients.Page) (clients.ScopedTokensPage, error) {
+	ret := _m.Called(ctx, domainID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllTokens")
+	}
+
+	var r0 clients.ScopedTokensPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Page) (clients.ScopedTokensPage, error)); ok {
+		return rf(ctx, domainID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Page) clients.ScopedTokensPage); ok {
+		r0 = rf(ctx, domainID, pm)
+	} else {
+		r0 = ret.Get(0).(clients.ScopedTokensPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, clients.Page) error); ok {
+		r1 = rf(ctx, domainID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveByCert provides a mock function with given fields: ctx, fingerprint
+func (_m *Repository) RetrieveByCert(ctx context.Context, fingerprint string) (clients.CertBinding, clients.Status, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByCert")
+	}
+
+	var r0 clients.CertBinding
+	var r1 clients.Status
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (clients.CertBinding, clients.Status, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) clients.CertBinding); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		r0 = ret.Get(0).(clients.CertBinding)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) clients.Status); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Get(1).(clients.Status)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, fingerprint)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // RetrieveByID provides a mock function with given fields: ctx, id
 func (_m *Repository) RetrieveByID(ctx context.Context, id string) (clients.Client, error) {
 	ret := _m.Called(ctx, id)
@@ -231,6 +362,101 @@ func (_m *Repository) RetrieveBySecret(ctx context.Context, key string) (clients
 	return r0, r1
 }
 
+// RetrieveKeys provides a mock function with given fields: ctx, afterID, limit
+func (_m *Repository) RetrieveKeys(ctx context.Context, afterID string, limit uint64) ([]clients.Client, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveKeys")
+	}
+
+	var r0 []clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) ([]clients.Client, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) []clients.Client); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveKeysByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) RetrieveKeysByIDs(ctx context.Context, ids ...string) ([]clients.Client, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveKeysByIDs")
+	}
+
+	var r0 []clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) ([]clients.Client, error)); ok {
+		return rf(ctx, ids...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) []clients.Client); ok {
+		r0 = rf(ctx, ids...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveToken provides a mock function with given fields: ctx, tokenHash
+func (_m *Repository) RetrieveToken(ctx context.Context, tokenHash string) (clients.ScopedToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveToken")
+	}
+
+	var r0 clients.ScopedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (clients.ScopedToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) clients.ScopedToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(clients.ScopedToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, client
 func (_m *Repository) Save(ctx context.Context, client ...clients.Client) ([]clients.Client, error) {
 	_va := make([]interface{}, len(client))
@@ -268,6 +494,67 @@ func (_m *Repository) Save(ctx context.Context, client ...clients.Client) ([]cli
 	return r0, r1
 }
 
+// SaveCert provides a mock function with given fields: ctx, cert
+func (_m *Repository) SaveCert(ctx context.Context, cert clients.CertBinding) error {
+	ret := _m.Called(ctx, cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.CertBinding) error); ok {
+		r0 = rf(ctx, cert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveToken provides a mock function with given fields: ctx, token
+func (_m *Repository) SaveToken(ctx context.Context, token clients.ScopedToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.ScopedToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransferChannel provides a mock function with given fields: ctx, channel, _a2
+func (_m *Repository) TransferChannel(ctx context.Context, channel groups.Group, _a2 ...clients.Client) error {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, channel)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, groups.Group, ...clients.Client) error); ok {
+		r0 = rf(ctx, channel, _a2...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, client
 func (_m *Repository) Update(ctx context.Context, client clients.Client) (clients.Client, error) {
 	ret := _m.Called(ctx, client)
@@ -380,6 +667,31 @@ func (_m *Repository) UpdateSecret(ctx context.Context, client clients.Client) (
 	return r0, r1
 }
 
+// UpdateSecrets provides a mock function with given fields: ctx, _a1
+func (_m *Repository) UpdateSecrets(ctx context.Context, _a1 ...clients.Client) error {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSecrets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...clients.Client) error); ok {
+		r0 = rf(ctx, _a1...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTags provides a mock function with given fields: ctx, client
 func (_m *Repository) UpdateTags(ctx context.Context, client clients.Client) (clients.Client, error) {
 	ret := _m.Called(ctx, client)
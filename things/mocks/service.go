@@ -9,9 +9,15 @@ import (
 
 	clients "github.com/absmach/magistrala/pkg/clients"
 
+	groups "github.com/absmach/magistrala/pkg/groups"
+
 	magistrala "github.com/absmach/magistrala"
 
 	mock "github.com/stretchr/testify/mock"
+
+	things "github.com/absmach/magistrala/things"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -19,6 +25,36 @@ type Service struct {
 	mock.Mock
 }
 
+// AggregateMetadata provides a mock function with given fields: ctx, token, field, status
+func (_m *Service) AggregateMetadata(ctx context.Context, token string, field string, status clients.Status) ([]clients.MetadataAggregate, error) {
+	ret := _m.Called(ctx, token, field, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateMetadata")
+	}
+
+	var r0 []clients.MetadataAggregate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Status) ([]clients.MetadataAggregate, error)); ok {
+		return rf(ctx, token, field, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Status) []clients.MetadataAggregate); ok {
+		r0 = rf(ctx, token, field, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.MetadataAggregate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, clients.Status) error); ok {
+		r1 = rf(ctx, token, field, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Authorize provides a mock function with given fields: ctx, req
 func (_m *Service) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (string, error) {
 	ret := _m.Called(ctx, req)
@@ -47,6 +83,34 @@ func (_m *Service) Authorize(ctx context.Context, req *magistrala.AuthorizeReq)
 	return r0, r1
 }
 
+// BindCert provides a mock function with given fields: ctx, token, cert
+func (_m *Service) BindCert(ctx context.Context, token string, cert clients.CertBinding) (clients.CertBinding, error) {
+	ret := _m.Called(ctx, token, cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BindCert")
+	}
+
+	var r0 clients.CertBinding
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.CertBinding) (clients.CertBinding, error)); ok {
+		return rf(ctx, token, cert)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.CertBinding) clients.CertBinding); ok {
+		r0 = rf(ctx, token, cert)
+	} else {
+		r0 = ret.Get(0).(clients.CertBinding)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, clients.CertBinding) error); ok {
+		r1 = rf(ctx, token, cert)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateThings provides a mock function with given fields: ctx, token, client
 func (_m *Service) CreateThings(ctx context.Context, token string, client ...clients.Client) ([]clients.Client, error) {
 	_va := make([]interface{}, len(client))
@@ -84,22 +148,32 @@ func (_m *Service) CreateThings(ctx context.Context, token string, client ...cli
 	return r0, r1
 }
 
-// DeleteClient provides a mock function with given fields: ctx, token, id
-func (_m *Service) DeleteClient(ctx context.Context, token string, id string) error {
-	ret := _m.Called(ctx, token, id)
+// DeleteClient provides a mock function with given fields: ctx, token, id, unmodifiedSince
+func (_m *Service) DeleteClient(ctx context.Context, token string, id string, unmodifiedSince time.Time) (clients.Client, error) {
+	ret := _m.Called(ctx, token, id, unmodifiedSince)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteClient")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, token, id)
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (clients.Client, error)); ok {
+		return rf(ctx, token, id, unmodifiedSince)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) clients.Client); ok {
+		r0 = rf(ctx, token, id, unmodifiedSince)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(clients.Client)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, token, id, unmodifiedSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DisableClient provides a mock function with given fields: ctx, token, id
@@ -158,6 +232,52 @@ func (_m *Service) EnableClient(ctx context.Context, token string, id string) (c
 	return r0, r1
 }
 
+// ExportSubtree provides a mock function with given fields: ctx, token, groupID, pm, keys
+func (_m *Service) ExportSubtree(ctx context.Context, token string, groupID string, pm things.SubtreePageMeta, keys bool) (things.Subtree, error) {
+	ret := _m.Called(ctx, token, groupID, pm, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportSubtree")
+	}
+
+	var r0 things.Subtree
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, things.SubtreePageMeta, bool) (things.Subtree, error)); ok {
+		return rf(ctx, token, groupID, pm, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, things.SubtreePageMeta, bool) things.Subtree); ok {
+		r0 = rf(ctx, token, groupID, pm, keys)
+	} else {
+		r0 = ret.Get(0).(things.Subtree)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, things.SubtreePageMeta, bool) error); ok {
+		r1 = rf(ctx, token, groupID, pm, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Heartbeat provides a mock function with given fields: ctx, key, id
+func (_m *Service) Heartbeat(ctx context.Context, key string, id string) error {
+	ret := _m.Called(ctx, key, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Heartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Identify provides a mock function with given fields: ctx, key
 func (_m *Service) Identify(ctx context.Context, key string) (string, error) {
 	ret := _m.Called(ctx, key)
@@ -186,6 +306,62 @@ func (_m *Service) Identify(ctx context.Context, key string) (string, error) {
 	return r0, r1
 }
 
+// IdentifyCert provides a mock function with given fields: ctx, fingerprint, subject
+func (_m *Service) IdentifyCert(ctx context.Context, fingerprint string, subject string) (string, error) {
+	ret := _m.Called(ctx, fingerprint, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdentifyCert")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, fingerprint, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, fingerprint, subject)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fingerprint, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueToken provides a mock function with given fields: ctx, token, scopes, ttl
+func (_m *Service) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (clients.ScopedToken, error) {
+	ret := _m.Called(ctx, token, scopes, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueToken")
+	}
+
+	var r0 clients.ScopedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, time.Duration) (clients.ScopedToken, error)); ok {
+		return rf(ctx, token, scopes, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, time.Duration) clients.ScopedToken); ok {
+		r0 = rf(ctx, token, scopes, ttl)
+	} else {
+		r0 = ret.Get(0).(clients.ScopedToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, time.Duration) error); ok {
+		r1 = rf(ctx, token, scopes, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListClients provides a mock function with given fields: ctx, token, reqUserID, pm
 func (_m *Service) ListClients(ctx context.Context, token string, reqUserID string, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _m.Called(ctx, token, reqUserID, pm)
@@ -242,6 +418,210 @@ func (_m *Service) ListClientsByGroup(ctx context.Context, token string, groupID
 	return r0, r1
 }
 
+// ListOrphanedClients provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListOrphanedClients(ctx context.Context, token string, pm clients.Page) (clients.ClientsPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrphanedClients")
+	}
+
+	var r0 clients.ClientsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Page) (clients.ClientsPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Page) clients.ClientsPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(clients.ClientsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, clients.Page) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListThingsChannels provides a mock function with given fields: ctx, token, thingIDs
+func (_m *Service) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]groups.Group, error) {
+	_va := make([]interface{}, len(thingIDs))
+	for _i := range thingIDs {
+		_va[_i] = thingIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, token)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListThingsChannels")
+	}
+
+	var r0 map[string][]groups.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) (map[string][]groups.Group, error)); ok {
+		return rf(ctx, token, thingIDs...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) map[string][]groups.Group); ok {
+		r0 = rf(ctx, token, thingIDs...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]groups.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...string) error); ok {
+		r1 = rf(ctx, token, thingIDs...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTokens provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListTokens(ctx context.Context, token string, pm clients.Page) (clients.ScopedTokensPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokens")
+	}
+
+	var r0 clients.ScopedTokensPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Page) (clients.ScopedTokensPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Page) clients.ScopedTokensPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(clients.ScopedTokensPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, clients.Page) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileCache provides a mock function with given fields: ctx, token
+func (_m *Service) ReconcileCache(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileCache")
+	}
+
+	var r0 things.CacheReconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (things.CacheReconciliation, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) things.CacheReconciliation); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(things.CacheReconciliation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveMetadata provides a mock function with given fields: ctx, entityType, id
+func (_m *Service) RetrieveMetadata(ctx context.Context, entityType string, id string) (clients.Metadata, clients.Status, error) {
+	ret := _m.Called(ctx, entityType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveMetadata")
+	}
+
+	var r0 clients.Metadata
+	var r1 clients.Status
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (clients.Metadata, clients.Status, error)); ok {
+		return rf(ctx, entityType, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) clients.Metadata); ok {
+		r0 = rf(ctx, entityType, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(clients.Metadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) clients.Status); ok {
+		r1 = rf(ctx, entityType, id)
+	} else {
+		r1 = ret.Get(1).(clients.Status)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, entityType, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RevokeToken provides a mock function with given fields: ctx, token, id
+func (_m *Service) RevokeToken(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateKeys provides a mock function with given fields: ctx, token, groupID, pm
+func (_m *Service) RotateKeys(ctx context.Context, token string, groupID string, pm clients.Page) (clients.ClientsPage, error) {
+	ret := _m.Called(ctx, token, groupID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateKeys")
+	}
+
+	var r0 clients.ClientsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Page) (clients.ClientsPage, error)); ok {
+		return rf(ctx, token, groupID, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, clients.Page) clients.ClientsPage); ok {
+		r0 = rf(ctx, token, groupID, pm)
+	} else {
+		r0 = ret.Get(0).(clients.ClientsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, clients.Page) error); ok {
+		r1 = rf(ctx, token, groupID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Share provides a mock function with given fields: ctx, token, id, relation, userids
 func (_m *Service) Share(ctx context.Context, token string, id string, relation string, userids ...string) error {
 	_va := make([]interface{}, len(userids))
@@ -267,6 +647,52 @@ func (_m *Service) Share(ctx context.Context, token string, id string, relation
 	return r0
 }
 
+// TransferChannel provides a mock function with given fields: ctx, token, groupID, domainID
+func (_m *Service) TransferChannel(ctx context.Context, token string, groupID string, domainID string) (things.ChannelTransfer, error) {
+	ret := _m.Called(ctx, token, groupID, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferChannel")
+	}
+
+	var r0 things.ChannelTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (things.ChannelTransfer, error)); ok {
+		return rf(ctx, token, groupID, domainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) things.ChannelTransfer); ok {
+		r0 = rf(ctx, token, groupID, domainID)
+	} else {
+		r0 = ret.Get(0).(things.ChannelTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, groupID, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnbindCert provides a mock function with given fields: ctx, token, thingID, fingerprint
+func (_m *Service) UnbindCert(ctx context.Context, token string, thingID string, fingerprint string) error {
+	ret := _m.Called(ctx, token, thingID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnbindCert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, token, thingID, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Unshare provides a mock function with given fields: ctx, token, id, relation, userids
 func (_m *Service) Unshare(ctx context.Context, token string, id string, relation string, userids ...string) error {
 	_va := make([]interface{}, len(userids))
@@ -292,9 +718,9 @@ func (_m *Service) Unshare(ctx context.Context, token string, id string, relatio
 	return r0
 }
 
-// UpdateClient provides a mock function with given fields: ctx, token, client
-func (_m *Service) UpdateClient(ctx context.Context, token string, client clients.Client) (clients.Client, error) {
-	ret := _m.Called(ctx, token, client)
+// UpdateClient provides a mock function with given fields: ctx, token, client, merge
+func (_m *Service) UpdateClient(ctx context.Context, token string, client clients.Client, merge bool) (clients.Client, error) {
+	ret := _m.Called(ctx, token, client, merge)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateClient")
@@ -302,17 +728,17 @@ func (_m *Service) UpdateClient(ctx context.Context, token string, client client
 
 	var r0 clients.Client
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Client) (clients.Client, error)); ok {
-		return rf(ctx, token, client)
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Client, bool) (clients.Client, error)); ok {
+		return rf(ctx, token, client, merge)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Client) clients.Client); ok {
-		r0 = rf(ctx, token, client)
+	if rf, ok := ret.Get(0).(func(context.Context, string, clients.Client, bool) clients.Client); ok {
+		r0 = rf(ctx, token, client, merge)
 	} else {
 		r0 = ret.Get(0).(clients.Client)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, clients.Client) error); ok {
-		r1 = rf(ctx, token, client)
+	if rf, ok := ret.Get(1).(func(context.Context, string, clients.Client, bool) error); ok {
+		r1 = rf(ctx, token, client, merge)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -320,9 +746,9 @@ func (_m *Service) UpdateClient(ctx context.Context, token string, client client
 	return r0, r1
 }
 
-// UpdateClientSecret provides a mock function with given fields: ctx, token, id, key
-func (_m *Service) UpdateClientSecret(ctx context.Context, token string, id string, key string) (clients.Client, error) {
-	ret := _m.Called(ctx, token, id, key)
+// UpdateClientSecret provides a mock function with given fields: ctx, token, id, key, ttl
+func (_m *Service) UpdateClientSecret(ctx context.Context, token string, id string, key string, ttl time.Duration) (clients.Client, error) {
+	ret := _m.Called(ctx, token, id, key, ttl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateClientSecret")
@@ -330,17 +756,17 @@ func (_m *Service) UpdateClientSecret(ctx context.Context, token string, id stri
 
 	var r0 clients.Client
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (clients.Client, error)); ok {
-		return rf(ctx, token, id, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) (clients.Client, error)); ok {
+		return rf(ctx, token, id, key, ttl)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) clients.Client); ok {
-		r0 = rf(ctx, token, id, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) clients.Client); ok {
+		r0 = rf(ctx, token, id, key, ttl)
 	} else {
 		r0 = ret.Get(0).(clients.Client)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, token, id, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, token, id, key, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -376,6 +802,62 @@ func (_m *Service) UpdateClientTags(ctx context.Context, token string, client cl
 	return r0, r1
 }
 
+// ValidateKey provides a mock function with given fields: ctx, key
+func (_m *Service) ValidateKey(ctx context.Context, key string) (things.KeyValidation, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateKey")
+	}
+
+	var r0 things.KeyValidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (things.KeyValidation, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) things.KeyValidation); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(things.KeyValidation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewCacheReconciliation provides a mock function with given fields: ctx, token
+func (_m *Service) ViewCacheReconciliation(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewCacheReconciliation")
+	}
+
+	var r0 things.CacheReconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (things.CacheReconciliation, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) things.CacheReconciliation); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(things.CacheReconciliation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ViewClient provides a mock function with given fields: ctx, token, id
 func (_m *Service) ViewClient(ctx context.Context, token string, id string) (clients.Client, error) {
 	ret := _m.Called(ctx, token, id)
@@ -434,6 +916,73 @@ func (_m *Service) ViewClientPerms(ctx context.Context, token string, id string)
 	return r0, r1
 }
 
+// ViewClients provides a mock function with given fields: ctx, token, ids
+func (_m *Service) ViewClients(ctx context.Context, token string, ids ...string) ([]things.ViewResult, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, token)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewClients")
+	}
+
+	var r0 []things.ViewResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) ([]things.ViewResult, error)); ok {
+		return rf(ctx, token, ids...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) []things.ViewResult); ok {
+		r0 = rf(ctx, token, ids...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]things.ViewResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...string) error); ok {
+		r1 = rf(ctx, token, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchThings provides a mock function with given fields: ctx, token, domainID, lastSeq
+func (_m *Service) WatchThings(ctx context.Context, token string, domainID string, lastSeq uint64) (<-chan things.ThingEvent, error) {
+	ret := _m.Called(ctx, token, domainID, lastSeq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchThings")
+	}
+
+	var r0 <-chan things.ThingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) (<-chan things.ThingEvent, error)); ok {
+		return rf(ctx, token, domainID, lastSeq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) <-chan things.ThingEvent); ok {
+		r0 = rf(ctx, token, domainID, lastSeq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan things.ThingEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
+		r1 = rf(ctx, token, domainID, lastSeq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+type dbCertBinding struct {
+	Fingerprint string           `db:"fingerprint"`
+	ClientID    string           `db:"client_id"`
+	Subject     sql.NullString   `db:"subject"`
+	CreatedAt   time.Time        `db:"created_at"`
+	Status      mgclients.Status `db:"status"`
+}
+
+func (repo clientRepo) SaveCert(ctx context.Context, cert mgclients.CertBinding) error {
+	q := `INSERT INTO client_certs (fingerprint, client_id, subject, created_at)
+        VALUES (:fingerprint, :client_id, :subject, :created_at)`
+
+	dbcb := dbCertBinding{
+		Fingerprint: cert.Fingerprint,
+		ClientID:    cert.ThingID,
+		Subject:     sql.NullString{String: cert.Subject, Valid: cert.Subject != ""},
+		CreatedAt:   cert.CreatedAt,
+	}
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbcb); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo clientRepo) RetrieveByCert(ctx context.Context, fingerprint string) (mgclients.CertBinding, mgclients.Status, error) {
+	q := fmt.Sprintf(`SELECT cc.fingerprint, cc.client_id, cc.subject, cc.created_at, c.status
+        FROM client_certs cc JOIN clients c ON c.id = cc.client_id
+        WHERE cc.fingerprint = :fingerprint AND c.status != %d`, mgclients.DeletedStatus)
+
+	rows, err := repo.DB.NamedQueryContext(ctx, q, dbCertBinding{Fingerprint: fingerprint})
+	if err != nil {
+		return mgclients.CertBinding{}, 0, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return mgclients.CertBinding{}, 0, repoerr.ErrNotFound
+	}
+	var dbcb dbCertBinding
+	if err := rows.StructScan(&dbcb); err != nil {
+		return mgclients.CertBinding{}, 0, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	cert := mgclients.CertBinding{
+		Fingerprint: dbcb.Fingerprint,
+		ThingID:     dbcb.ClientID,
+		Subject:     dbcb.Subject.String,
+		CreatedAt:   dbcb.CreatedAt,
+	}
+
+	return cert, dbcb.Status, nil
+}
+
+func (repo clientRepo) RemoveCert(ctx context.Context, clientID, fingerprint string) error {
+	q := `DELETE FROM client_certs WHERE fingerprint = $1 AND client_id = $2`
+
+	result, err := repo.DB.ExecContext(ctx, q, fingerprint, clientID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertBindings(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM client_certs")
+		require.Nil(t, err, fmt.Sprintf("clean client certs unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	client := clients.Client{
+		ID:   testsutil.GenerateUUID(t),
+		Name: clientName,
+		Credentials: clients.Credentials{
+			Identity: clientIdentity,
+			Secret:   testsutil.GenerateUUID(t),
+		},
+		Metadata: clients.Metadata{},
+		Status:   clients.EnabledStatus,
+	}
+	_, err := repo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cert := clients.CertBinding{
+		Fingerprint: strings.Repeat("ab", 32),
+		ThingID:     client.ID,
+		Subject:     "CN=sensor-1",
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	err = repo.SaveCert(context.Background(), cert)
+	assert.Nil(t, err, fmt.Sprintf("save cert: unexpected error %s", err))
+
+	err = repo.SaveCert(context.Background(), cert)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save duplicate cert: expected %s got %s", repoerr.ErrConflict, err))
+
+	cb, status, err := repo.RetrieveByCert(context.Background(), cert.Fingerprint)
+	assert.Nil(t, err, fmt.Sprintf("retrieve by cert: unexpected error %s", err))
+	assert.Equal(t, cert, cb, fmt.Sprintf("retrieve by cert: expected %v got %v", cert, cb))
+	assert.Equal(t, client.Status, status, fmt.Sprintf("retrieve by cert: expected status %s got %s", client.Status, status))
+
+	_, _, err = repo.RetrieveByCert(context.Background(), strings.Repeat("cd", 32))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve by unknown cert: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveCert(context.Background(), testsutil.GenerateUUID(t), cert.Fingerprint)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("remove cert of another client: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveCert(context.Background(), client.ID, cert.Fingerprint)
+	assert.Nil(t, err, fmt.Sprintf("remove cert: unexpected error %s", err))
+
+	_, _, err = repo.RetrieveByCert(context.Background(), cert.Fingerprint)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve by removed cert: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.SaveCert(context.Background(), cert)
+	assert.Nil(t, err, fmt.Sprintf("save removed cert: unexpected error %s", err))
+
+	_, err = db.Exec("DELETE FROM clients WHERE id = $1", client.ID)
+	require.Nil(t, err, fmt.Sprintf("remove client unexpected error: %s", err))
+
+	_, _, err = repo.RetrieveByCert(context.Background(), cert.Fingerprint)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve by cert of removed client: expected %s got %s", repoerr.ErrNotFound, err))
+}
@@ -5,13 +5,19 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	pgclients "github.com/absmach/magistrala/pkg/clients/postgres"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+	"github.com/jmoiron/sqlx"
 )
 
 var _ mgclients.Repository = (*clientRepo)(nil)
@@ -31,8 +37,55 @@ type Repository interface {
 	// operation failure.
 	Save(ctx context.Context, client ...mgclients.Client) ([]mgclients.Client, error)
 
-	// RetrieveBySecret retrieves a client based on the secret (key).
+	// RetrieveBySecret retrieves a client based on the secret (key). Disabled
+	// clients are retrieved as well.
 	RetrieveBySecret(ctx context.Context, key string) (mgclients.Client, error)
+
+	// AggregateMetadata returns distinct values of the metadata field with their
+	// counts across the clients matching the page.
+	AggregateMetadata(ctx context.Context, pm mgclients.Page, field string) ([]mgclients.MetadataAggregate, error)
+
+	// UpdateSecrets updates the secrets of the clients in a single transaction.
+	// If any of the clients is not updated, none of them are.
+	UpdateSecrets(ctx context.Context, clients ...mgclients.Client) error
+
+	// TransferChannel moves the channel and the clients to the domain of the
+	// channel in a single transaction. The parent of the channel, the domains
+	// of the clients and the update times are set from the given values.
+	TransferChannel(ctx context.Context, channel mggroups.Group, clients ...mgclients.Client) error
+
+	// RetrieveKeys retrieves the IDs, keys, key expiries and statuses of at
+	// most limit clients with IDs greater than afterID, ordered by ID.
+	RetrieveKeys(ctx context.Context, afterID string, limit uint64) ([]mgclients.Client, error)
+
+	// RetrieveKeysByIDs retrieves the IDs, keys, key expiries and statuses
+	// of the clients with the given IDs.
+	RetrieveKeysByIDs(ctx context.Context, ids ...string) ([]mgclients.Client, error)
+
+	// SaveToken persists the scoped token.
+	SaveToken(ctx context.Context, token mgclients.ScopedToken) error
+
+	// RetrieveToken retrieves the scoped token by the hash of its value.
+	RetrieveToken(ctx context.Context, tokenHash string) (mgclients.ScopedToken, error)
+
+	// RetrieveAllTokens retrieves the page of scoped tokens of the domain,
+	// ordered by their issue time.
+	RetrieveAllTokens(ctx context.Context, domainID string, pm mgclients.Page) (mgclients.ScopedTokensPage, error)
+
+	// RemoveToken removes the scoped token with the given ID from the domain.
+	RemoveToken(ctx context.Context, domainID, id string) error
+
+	// SaveCert persists the binding of the certificate to the client.
+	SaveCert(ctx context.Context, cert mgclients.CertBinding) error
+
+	// RetrieveByCert retrieves the binding of the certificate with the
+	// fingerprint along with the status of the bound client. Bindings of
+	// disabled clients are retrieved as well.
+	RetrieveByCert(ctx context.Context, fingerprint string) (mgclients.CertBinding, mgclients.Status, error)
+
+	// RemoveCert removes the binding of the certificate with the fingerprint
+	// to the client.
+	RemoveCert(ctx context.Context, clientID, fingerprint string) error
 }
 
 // NewRepository instantiates a PostgreSQL
@@ -53,7 +106,7 @@ func (repo clientRepo) Save(ctx context.Context, cs ...mgclients.Client) ([]mgcl
 	for _, cli := range cs {
 		q := `INSERT INTO clients (id, name, tags, domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status)
         VALUES (:id, :name, :tags, :domain_id, :identity, :secret, :metadata, :created_at, :updated_at, :updated_by, :status)
-        RETURNING id, name, tags, identity, secret, metadata, COALESCE(domain_id, '') AS domain_id, status, created_at, updated_at, updated_by`
+        RETURNING id, name, tags, identity, secret, metadata, COALESCE(domain_id, '') AS domain_id, status, version, created_at, updated_at, updated_by`
 
 		dbcli, err := pgclients.ToDBClient(cli)
 		if err != nil {
@@ -91,9 +144,11 @@ func (repo clientRepo) Save(ctx context.Context, cs ...mgclients.Client) ([]mgcl
 }
 
 func (repo clientRepo) RetrieveBySecret(ctx context.Context, key string) (mgclients.Client, error) {
-	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, metadata, created_at, updated_at, updated_by, status
+	// Disabled things are retrieved too, so they can be told apart from
+	// unknown keys.
+	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, secret_expires_at, metadata, created_at, updated_at, updated_by, status
         FROM clients
-        WHERE secret = :secret AND status = %d`, mgclients.EnabledStatus)
+        WHERE secret = :secret AND status != %d`, mgclients.DeletedStatus)
 
 	dbc := pgclients.DBClient{
 		Secret: key,
@@ -121,3 +176,308 @@ func (repo clientRepo) RetrieveBySecret(ctx context.Context, key string) (mgclie
 
 	return mgclients.Client{}, repoerr.ErrNotFound
 }
+
+// Update updates the client name and metadata and increments its version.
+// If the client carries a non-zero version, the update is applied only if it
+// matches the stored version, otherwise ErrConflict is returned.
+func (repo clientRepo) Update(ctx context.Context, client mgclients.Client) (mgclients.Client, error) {
+	var query []string
+	var upq, vq string
+	if client.Name != "" {
+		query = append(query, "name = :name,")
+	}
+	if client.Metadata != nil {
+		query = append(query, "metadata = :metadata,")
+	}
+	if len(query) > 0 {
+		upq = strings.Join(query, " ")
+	}
+	if client.Version != 0 {
+		vq = "AND version = :version"
+	}
+
+	q := fmt.Sprintf(`UPDATE clients SET %s updated_at = :updated_at, updated_by = :updated_by, version = version + 1
+        WHERE id = :id AND status = :status %s
+        RETURNING id, name, tags, identity, secret, secret_expires_at, metadata, COALESCE(domain_id, '') AS domain_id, status, version, created_at, updated_at, updated_by`,
+		upq, vq)
+	client.Status = mgclients.EnabledStatus
+
+	dbcli, err := pgclients.ToDBClient(client)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	row, err := repo.DB.NamedQueryContext(ctx, q, dbcli)
+	if err != nil {
+		return mgclients.Client{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	if row.Next() {
+		dbcli = pgclients.DBClient{}
+		if err := row.StructScan(&dbcli); err != nil {
+			return mgclients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+
+		return pgclients.ToClient(dbcli)
+	}
+
+	if client.Version != 0 {
+		// Distinguish a stale version from a missing or disabled client.
+		if c, err := repo.RetrieveByID(ctx, client.ID); err == nil && c.Status == mgclients.EnabledStatus {
+			return mgclients.Client{}, repoerr.ErrConflict
+		}
+	}
+
+	return mgclients.Client{}, repoerr.ErrNotFound
+}
+
+// UpdateSecret updates the client secret together with its expiry.
+func (repo clientRepo) UpdateSecret(ctx context.Context, client mgclients.Client) (mgclients.Client, error) {
+	q := `UPDATE clients SET secret = :secret, secret_expires_at = :secret_expires_at, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status
+        RETURNING id, name, tags, identity, secret_expires_at, metadata, COALESCE(domain_id, '') AS domain_id, status, version, created_at, updated_at, updated_by`
+	client.Status = mgclients.EnabledStatus
+
+	dbcli, err := pgclients.ToDBClient(client)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	row, err := repo.DB.NamedQueryContext(ctx, q, dbcli)
+	if err != nil {
+		return mgclients.Client{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	if row.Next() {
+		dbcli = pgclients.DBClient{}
+		if err := row.StructScan(&dbcli); err != nil {
+			return mgclients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+
+		return pgclients.ToClient(dbcli)
+	}
+
+	return mgclients.Client{}, repoerr.ErrNotFound
+}
+
+func (repo clientRepo) UpdateSecrets(ctx context.Context, cs ...mgclients.Client) (err error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	q := `UPDATE clients SET secret = :secret, secret_expires_at = :secret_expires_at, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id`
+	for _, cli := range cs {
+		dbcli, err := pgclients.ToDBClient(cli)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		res, err := tx.NamedExecContext(ctx, q, dbcli)
+		if err != nil {
+			return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		if cnt != 1 {
+			return errors.Wrap(repoerr.ErrNotFound, fmt.Errorf("client %s", cli.ID))
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo clientRepo) TransferChannel(ctx context.Context, channel mggroups.Group, cs ...mgclients.Client) (err error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(apiutil.ErrRollbackTx, errRollback)
+			}
+		}
+	}()
+
+	var parentID *string
+	if channel.Parent != "" {
+		parentID = &channel.Parent
+	}
+	q := `UPDATE groups SET domain_id = $1, parent_id = $2, updated_at = $3, updated_by = $4 WHERE id = $5`
+	res, err := tx.ExecContext(ctx, q, channel.Domain, parentID, channel.UpdatedAt, channel.UpdatedBy, channel.ID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	if cnt != 1 {
+		return errors.Wrap(repoerr.ErrNotFound, fmt.Errorf("group %s", channel.ID))
+	}
+
+	q = `UPDATE clients SET domain_id = :domain_id, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id`
+	for _, cli := range cs {
+		dbcli, err := pgclients.ToDBClient(cli)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		res, err := tx.NamedExecContext(ctx, q, dbcli)
+		if err != nil {
+			return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		if cnt != 1 {
+			return errors.Wrap(repoerr.ErrNotFound, fmt.Errorf("client %s", cli.ID))
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo clientRepo) RetrieveByID(ctx context.Context, id string) (mgclients.Client, error) {
+	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, identity, secret, secret_expires_at, metadata, created_at, updated_at, updated_by, status, version
+        FROM clients WHERE id = :id`
+
+	dbc := pgclients.DBClient{
+		ID: id,
+	}
+
+	row, err := repo.DB.NamedQueryContext(ctx, q, dbc)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer row.Close()
+
+	dbc = pgclients.DBClient{}
+	if row.Next() {
+		if err := row.StructScan(&dbc); err != nil {
+			return mgclients.Client{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+
+		return pgclients.ToClient(dbc)
+	}
+
+	return mgclients.Client{}, repoerr.ErrNotFound
+}
+
+func (repo clientRepo) AggregateMetadata(ctx context.Context, pm mgclients.Page, field string) ([]mgclients.MetadataAggregate, error) {
+	query, err := pgclients.PageQuery(pm)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	fq := "c.metadata -> :field IS NOT NULL"
+	if query == "" {
+		query = fmt.Sprintf("WHERE %s", fq)
+	} else {
+		query = fmt.Sprintf("%s AND %s", query, fq)
+	}
+
+	q := fmt.Sprintf(`SELECT c.metadata -> :field AS value, COUNT(*) AS count FROM clients c %s
+        GROUP BY value ORDER BY count DESC;`, query)
+
+	params := map[string]interface{}{
+		"field":     field,
+		"domain_id": pm.Domain,
+	}
+	rows, err := repo.DB.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	aggs := []mgclients.MetadataAggregate{}
+	for rows.Next() {
+		dba := dbMetadataAggregate{}
+		if err := rows.StructScan(&dba); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		var value interface{}
+		if err := json.Unmarshal(dba.Value, &value); err != nil {
+			return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		aggs = append(aggs, mgclients.MetadataAggregate{Value: value, Count: dba.Count})
+	}
+
+	return aggs, nil
+}
+
+type dbMetadataAggregate struct {
+	Value []byte `db:"value"`
+	Count uint64 `db:"count"`
+}
+
+func (repo clientRepo) RetrieveKeys(ctx context.Context, afterID string, limit uint64) ([]mgclients.Client, error) {
+	q := `SELECT id, secret, secret_expires_at, status FROM clients WHERE id > $1 ORDER BY id LIMIT $2`
+
+	rows, err := repo.DB.QueryxContext(ctx, q, afterID, limit)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	return scanKeys(rows)
+}
+
+func (repo clientRepo) RetrieveKeysByIDs(ctx context.Context, ids ...string) ([]mgclients.Client, error) {
+	if len(ids) == 0 {
+		return []mgclients.Client{}, nil
+	}
+	var arr pgtype.TextArray
+	if err := arr.Set(ids); err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	q := `SELECT id, secret, secret_expires_at, status FROM clients WHERE id = ANY($1)`
+
+	rows, err := repo.DB.QueryxContext(ctx, q, arr)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	return scanKeys(rows)
+}
+
+func scanKeys(rows *sqlx.Rows) ([]mgclients.Client, error) {
+	cs := []mgclients.Client{}
+	for rows.Next() {
+		dbc := pgclients.DBClient{}
+		if err := rows.StructScan(&dbc); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		c, err := pgclients.ToClient(dbc)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		cs = append(cs, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return cs, nil
+}
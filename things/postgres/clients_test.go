@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/0x6flab/namegenerator"
 	"github.com/absmach/magistrala/internal/testsutil"
@@ -364,3 +365,190 @@ func TestClientsRetrieveBySecret(t *testing.T) {
 		assert.Equal(t, res, tc.response, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, res))
 	}
 }
+
+func TestClientsUpdate(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	client := clients.Client{
+		ID:   testsutil.GenerateUUID(t),
+		Name: clientName,
+		Credentials: clients.Credentials{
+			Identity:  clientIdentity,
+			Secret:    testsutil.GenerateUUID(t),
+			ExpiresAt: &expiresAt,
+		},
+		Metadata: clients.Metadata{},
+		Status:   clients.EnabledStatus,
+	}
+	disabled := clients.Client{
+		ID:   testsutil.GenerateUUID(t),
+		Name: clientName,
+		Credentials: clients.Credentials{
+			Identity: "disabled-" + clientIdentity,
+			Secret:   testsutil.GenerateUUID(t),
+		},
+		Metadata: clients.Metadata{},
+		Status:   clients.DisabledStatus,
+	}
+	_, err := repo.Save(context.Background(), client, disabled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	saved, err := repo.RetrieveByID(context.Background(), client.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		client clients.Client
+		err    error
+	}{
+		{
+			desc:   "update client with current version",
+			client: clients.Client{ID: client.ID, Name: namesgen.Generate(), Version: saved.Version},
+			err:    nil,
+		},
+		{
+			desc:   "update client with stale version",
+			client: clients.Client{ID: client.ID, Name: namesgen.Generate(), Version: saved.Version},
+			err:    repoerr.ErrConflict,
+		},
+		{
+			desc:   "update disabled client with version",
+			client: clients.Client{ID: disabled.ID, Name: namesgen.Generate(), Version: saved.Version},
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "update non-existent client with version",
+			client: clients.Client{ID: testsutil.GenerateUUID(t), Name: namesgen.Generate(), Version: saved.Version},
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := repo.Update(context.Background(), tc.client)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.client.Name, res.Name, fmt.Sprintf("%s: expected name %s got %s\n", tc.desc, tc.client.Name, res.Name))
+			assert.Equal(t, &expiresAt, res.Credentials.ExpiresAt, fmt.Sprintf("%s: expected key expiry %v got %v\n", tc.desc, expiresAt, res.Credentials.ExpiresAt))
+		}
+	}
+}
+
+func TestClientsUpdateSecrets(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	var saved []clients.Client
+	for i := 0; i < 2; i++ {
+		client := clients.Client{
+			ID:   testsutil.GenerateUUID(t),
+			Name: namesgen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: fmt.Sprintf("%d-%s", i, clientIdentity),
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata: clients.Metadata{},
+			Status:   clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		saved = append(saved, client)
+	}
+
+	rotate := func(cs ...clients.Client) []clients.Client {
+		var rotated []clients.Client
+		for _, c := range cs {
+			c.Credentials.Secret = testsutil.GenerateUUID(t)
+			rotated = append(rotated, c)
+		}
+		return rotated
+	}
+
+	cases := []struct {
+		desc    string
+		clients []clients.Client
+		updated bool
+		err     error
+	}{
+		{
+			desc:    "update secrets successfully",
+			clients: rotate(saved...),
+			updated: true,
+			err:     nil,
+		},
+		{
+			desc:    "update secrets with non-existent client",
+			clients: rotate(saved[0], clients.Client{ID: testsutil.GenerateUUID(t)}),
+			updated: false,
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateSecrets(context.Background(), tc.clients...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		for _, c := range tc.clients[:1] {
+			_, err := repo.RetrieveBySecret(context.Background(), c.Credentials.Secret)
+			assert.Equal(t, tc.updated, err == nil, fmt.Sprintf("%s: expected secret updated %t got error %s\n", tc.desc, tc.updated, err))
+		}
+	}
+}
+
+func TestClientsRetrieveKeys(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	keys := map[string]string{}
+	var ids []string
+	for i := 0; i < 3; i++ {
+		client := clients.Client{
+			ID:   testsutil.GenerateUUID(t),
+			Name: namesgen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: fmt.Sprintf("%d-%s", i, clientIdentity),
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata: clients.Metadata{},
+			Status:   clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		keys[client.ID] = client.Credentials.Secret
+		ids = append(ids, client.ID)
+	}
+
+	var retrieved []clients.Client
+	var afterID string
+	for {
+		cs, err := repo.RetrieveKeys(context.Background(), afterID, 2)
+		require.Nil(t, err, fmt.Sprintf("retrieve keys: unexpected error: %s", err))
+		retrieved = append(retrieved, cs...)
+		if len(cs) < 2 {
+			break
+		}
+		afterID = cs[len(cs)-1].ID
+	}
+	assert.Len(t, retrieved, len(keys), fmt.Sprintf("retrieve keys: expected %d clients got %d", len(keys), len(retrieved)))
+	for i, c := range retrieved {
+		assert.Equal(t, keys[c.ID], c.Credentials.Secret, fmt.Sprintf("retrieve keys: expected key %s got %s", keys[c.ID], c.Credentials.Secret))
+		if i > 0 {
+			assert.True(t, retrieved[i-1].ID < c.ID, "retrieve keys: expected clients ordered by ID")
+		}
+	}
+
+	cs, err := repo.RetrieveKeysByIDs(context.Background(), ids[0], testsutil.GenerateUUID(t))
+	assert.Nil(t, err, fmt.Sprintf("retrieve keys by IDs: unexpected error: %s", err))
+	assert.Len(t, cs, 1, fmt.Sprintf("retrieve keys by IDs: expected 1 client got %d", len(cs)))
+	if len(cs) == 1 {
+		assert.Equal(t, keys[ids[0]], cs[0].Credentials.Secret, fmt.Sprintf("retrieve keys by IDs: expected key %s got %s", keys[ids[0]], cs[0].Credentials.Secret))
+	}
+}
@@ -36,6 +36,65 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS clients`,
 				},
 			},
+			{
+				// Version is used for optimistic concurrency on thing updates.
+				Id: "clients_02",
+				Up: []string{
+					`ALTER TABLE clients ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1`,
+				},
+				Down: []string{
+					`ALTER TABLE clients DROP COLUMN IF EXISTS version`,
+				},
+			},
+			{
+				// Secret expiry is NULL for non-expiring thing keys.
+				Id: "clients_03",
+				Up: []string{
+					`ALTER TABLE clients ADD COLUMN IF NOT EXISTS secret_expires_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE clients DROP COLUMN IF EXISTS secret_expires_at`,
+				},
+			},
+			{
+				// Scoped tokens act on behalf of the issuing user, limited to
+				// the domain and the scopes they are bound to. They are stored
+				// by their SHA-256 hash, so a database leak doesn't expose
+				// usable tokens.
+				Id: "clients_04",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS scoped_tokens (
+						id			VARCHAR(36) PRIMARY KEY,
+						token_hash	VARCHAR(64) NOT NULL UNIQUE,
+						domain_id	VARCHAR(36) NOT NULL,
+						user_id		VARCHAR(36) NOT NULL,
+						subject		VARCHAR(254) NOT NULL,
+						scopes		TEXT[] NOT NULL,
+						created_at	TIMESTAMP,
+						expires_at	TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_scoped_tokens_domain_id ON scoped_tokens (domain_id, created_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS scoped_tokens`,
+				},
+			},
+			{
+				// Certificate bindings identify things by the SHA-256
+				// fingerprint of their client certificates.
+				Id: "clients_05",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS client_certs (
+						fingerprint	VARCHAR(64) PRIMARY KEY,
+						client_id	VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						subject		TEXT,
+						created_at	TIMESTAMP
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS client_certs`,
+				},
+			},
 		},
 	}
 }
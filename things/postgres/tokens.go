@@ -0,0 +1,156 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+type dbScopedToken struct {
+	ID        string           `db:"id"`
+	TokenHash string           `db:"token_hash"`
+	DomainID  string           `db:"domain_id"`
+	UserID    string           `db:"user_id"`
+	Subject   string           `db:"subject"`
+	Scopes    pgtype.TextArray `db:"scopes"`
+	CreatedAt time.Time        `db:"created_at"`
+	ExpiresAt sql.NullTime     `db:"expires_at"`
+}
+
+func (repo clientRepo) SaveToken(ctx context.Context, st mgclients.ScopedToken) error {
+	q := `INSERT INTO scoped_tokens (id, token_hash, domain_id, user_id, subject, scopes, created_at, expires_at)
+        VALUES (:id, :token_hash, :domain_id, :user_id, :subject, :scopes, :created_at, :expires_at)`
+
+	dbst, err := toDBScopedToken(st)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbst); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo clientRepo) RetrieveToken(ctx context.Context, tokenHash string) (mgclients.ScopedToken, error) {
+	q := `SELECT id, token_hash, domain_id, user_id, subject, scopes, created_at, expires_at
+        FROM scoped_tokens WHERE token_hash = :token_hash`
+
+	rows, err := repo.DB.NamedQueryContext(ctx, q, dbScopedToken{TokenHash: tokenHash})
+	if err != nil {
+		return mgclients.ScopedToken{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return mgclients.ScopedToken{}, repoerr.ErrNotFound
+	}
+	var dbst dbScopedToken
+	if err := rows.StructScan(&dbst); err != nil {
+		return mgclients.ScopedToken{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toScopedToken(dbst), nil
+}
+
+func (repo clientRepo) RetrieveAllTokens(ctx context.Context, domainID string, pm mgclients.Page) (mgclients.ScopedTokensPage, error) {
+	q := `SELECT id, domain_id, user_id, subject, scopes, created_at, expires_at
+        FROM scoped_tokens WHERE domain_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`
+
+	rows, err := repo.DB.QueryxContext(ctx, q, domainID, pm.Limit, pm.Offset)
+	if err != nil {
+		return mgclients.ScopedTokensPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []mgclients.ScopedToken
+	for rows.Next() {
+		var dbst dbScopedToken
+		if err := rows.StructScan(&dbst); err != nil {
+			return mgclients.ScopedTokensPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toScopedToken(dbst))
+	}
+
+	var total uint64
+	cq := `SELECT COUNT(*) FROM scoped_tokens WHERE domain_id = $1`
+	if err := repo.DB.QueryRowxContext(ctx, cq, domainID).Scan(&total); err != nil {
+		return mgclients.ScopedTokensPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return mgclients.ScopedTokensPage{
+		Page: mgclients.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Tokens: items,
+	}, nil
+}
+
+func (repo clientRepo) RemoveToken(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM scoped_tokens WHERE id = $1 AND domain_id = $2`
+
+	res, err := repo.DB.ExecContext(ctx, q, id, domainID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func toDBScopedToken(st mgclients.ScopedToken) (dbScopedToken, error) {
+	var scopes pgtype.TextArray
+	if err := scopes.Set(st.Scopes); err != nil {
+		return dbScopedToken{}, err
+	}
+	var expiresAt sql.NullTime
+	if st.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *st.ExpiresAt, Valid: true}
+	}
+
+	return dbScopedToken{
+		ID:        st.ID,
+		TokenHash: st.TokenHash,
+		DomainID:  st.DomainID,
+		UserID:    st.UserID,
+		Subject:   st.Subject,
+		Scopes:    scopes,
+		CreatedAt: st.CreatedAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func toScopedToken(dbst dbScopedToken) mgclients.ScopedToken {
+	var scopes []string
+	for _, e := range dbst.Scopes.Elements {
+		scopes = append(scopes, e.String)
+	}
+	var expiresAt *time.Time
+	if dbst.ExpiresAt.Valid {
+		expiresAt = &dbst.ExpiresAt.Time
+	}
+
+	return mgclients.ScopedToken{
+		ID:        dbst.ID,
+		TokenHash: dbst.TokenHash,
+		DomainID:  dbst.DomainID,
+		UserID:    dbst.UserID,
+		Subject:   dbst.Subject,
+		Scopes:    scopes,
+		CreatedAt: dbst.CreatedAt,
+		ExpiresAt: expiresAt,
+	}
+}
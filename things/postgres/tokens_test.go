@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/things/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopedTokens(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM scoped_tokens")
+		require.Nil(t, err, fmt.Sprintf("clean scoped tokens unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	token := clients.ScopedToken{
+		ID:        testsutil.GenerateUUID(t),
+		TokenHash: clients.HashToken(clients.ScopedTokenPrefix + testsutil.GenerateUUID(t)),
+		DomainID:  testsutil.GenerateUUID(t),
+		UserID:    testsutil.GenerateUUID(t),
+		Subject:   testsutil.GenerateUUID(t),
+		Scopes:    []string{clients.ThingsReadScope, clients.ThingsWriteScope},
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		ExpiresAt: &expiresAt,
+	}
+
+	err := repo.SaveToken(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("save token: unexpected error %s", err))
+
+	err = repo.SaveToken(context.Background(), token)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save duplicate token: expected %s got %s", repoerr.ErrConflict, err))
+
+	st, err := repo.RetrieveToken(context.Background(), token.TokenHash)
+	assert.Nil(t, err, fmt.Sprintf("retrieve token: unexpected error %s", err))
+	assert.Equal(t, token.ID, st.ID, fmt.Sprintf("retrieve token: expected id %s got %s", token.ID, st.ID))
+	assert.Equal(t, token.Scopes, st.Scopes, fmt.Sprintf("retrieve token: expected scopes %v got %v", token.Scopes, st.Scopes))
+	assert.Equal(t, token.Subject, st.Subject, fmt.Sprintf("retrieve token: expected subject %s got %s", token.Subject, st.Subject))
+	require.NotNil(t, st.ExpiresAt, "retrieve token: expected expiry")
+	assert.True(t, expiresAt.Equal(*st.ExpiresAt), fmt.Sprintf("retrieve token: expected expiry %v got %v", expiresAt, *st.ExpiresAt))
+
+	page, err := repo.RetrieveAllTokens(context.Background(), token.DomainID, clients.Page{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve all tokens: unexpected error %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("retrieve all tokens: expected total 1 got %d", page.Total))
+	require.Len(t, page.Tokens, 1, "retrieve all tokens: expected one token")
+	assert.Equal(t, token.ID, page.Tokens[0].ID, fmt.Sprintf("retrieve all tokens: expected id %s got %s", token.ID, page.Tokens[0].ID))
+	assert.Empty(t, page.Tokens[0].TokenHash, "retrieve all tokens: expected no token hash")
+
+	page, err = repo.RetrieveAllTokens(context.Background(), testsutil.GenerateUUID(t), clients.Page{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve all tokens of other domain: unexpected error %s", err))
+	assert.Empty(t, page.Tokens, "retrieve all tokens of other domain: expected no tokens")
+
+	_, err = repo.RetrieveToken(context.Background(), clients.HashToken(clients.ScopedTokenPrefix+testsutil.GenerateUUID(t)))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve unknown token: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveToken(context.Background(), testsutil.GenerateUUID(t), token.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("remove token from other domain: expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveToken(context.Background(), token.DomainID, token.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove token: unexpected error %s", err))
+
+	_, err = repo.RetrieveToken(context.Background(), token.TokenHash)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed token: expected %s got %s", repoerr.ErrNotFound, err))
+}
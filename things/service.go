@@ -4,34 +4,65 @@ package things
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/internal/groups"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	mggroups "github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things/postgres"
 	"golang.org/x/sync/errgroup"
 )
 
+// reconcileBatchSize is the number of things and cache entries reconciled
+// at once, which bounds the load on the database and the cache.
+const reconcileBatchSize = 100
+
+// maxConnectionFilter is the maximal number of online things of a domain
+// the things can be filtered by connection state against.
+const maxConnectionFilter = 10000
+
+// maxConnectionLookups is the maximal number of concurrent connection
+// lookups of a single listing, which bounds the load on the auth service.
+const maxConnectionLookups = 10
+
 type service struct {
 	auth        magistrala.AuthServiceClient
 	clients     postgres.Repository
 	clientCache Cache
+	watcher     Watcher
 	idProvider  magistrala.IDProvider
 	grepo       mggroups.Repository
+	keyPolicy   mgclients.KeyPolicy
+	reconciler  *reconciler
+}
+
+// reconciler holds the state of the cache reconciliation, which runs in
+// the background.
+type reconciler struct {
+	mu    sync.Mutex
+	state CacheReconciliation
 }
 
-// NewService returns a new Clients service implementation.
-func NewService(uauth magistrala.AuthServiceClient, c postgres.Repository, grepo mggroups.Repository, tcache Cache, idp magistrala.IDProvider) Service {
+// NewService returns a new Clients service implementation. Keys supplied
+// by the users when the thing keys are updated have to satisfy the key
+// policy, which the generated keys always satisfy.
+func NewService(uauth magistrala.AuthServiceClient, c postgres.Repository, grepo mggroups.Repository, tcache Cache, watcher Watcher, idp magistrala.IDProvider, kp mgclients.KeyPolicy) Service {
 	return service{
 		auth:        uauth,
 		clients:     c,
 		grepo:       grepo,
 		clientCache: tcache,
+		watcher:     watcher,
 		idProvider:  idp,
+		keyPolicy:   kp,
+		reconciler:  &reconciler{},
 	}
 }
 
@@ -55,12 +86,34 @@ func (svc service) Authorize(ctx context.Context, req *magistrala.AuthorizeReq)
 	if !resp.GetAuthorized() {
 		return "", svcerr.ErrAuthorization
 	}
+	// Failing to record the activity must not prevent the thing from
+	// communicating, it only affects its reported connection state.
+	_ = svc.seen(ctx, thingID)
 
 	return thingID, nil
 }
 
+func (svc service) RetrieveMetadata(ctx context.Context, entityType, id string) (mgclients.Metadata, mgclients.Status, error) {
+	switch entityType {
+	case auth.ThingType:
+		client, err := svc.clients.RetrieveByID(ctx, id)
+		if err != nil {
+			return nil, mgclients.DisabledStatus, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		return client.Metadata, client.Status, nil
+	case auth.GroupType:
+		group, err := svc.grepo.RetrieveByID(ctx, id)
+		if err != nil {
+			return nil, mgclients.DisabledStatus, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		return group.Metadata, group.Status, nil
+	default:
+		return nil, mgclients.DisabledStatus, svcerr.ErrMalformedEntity
+	}
+}
+
 func (svc service) CreateThings(ctx context.Context, token string, cls ...mgclients.Client) ([]mgclients.Client, error) {
-	user, err := svc.identify(ctx, token)
+	user, err := svc.identify(ctx, token, mgclients.ThingsWriteScope)
 	if err != nil {
 		return []mgclients.Client{}, err
 	}
@@ -79,7 +132,7 @@ func (svc service) CreateThings(ctx context.Context, token string, cls ...mgclie
 			c.ID = clientID
 		}
 		if c.Credentials.Secret == "" {
-			key, err := svc.idProvider.ID()
+			key, err := svc.generateKey()
 			if err != nil {
 				return []mgclients.Client{}, err
 			}
@@ -88,6 +141,9 @@ func (svc service) CreateThings(ctx context.Context, token string, cls ...mgclie
 		if c.Status != mgclients.DisabledStatus && c.Status != mgclients.EnabledStatus {
 			return []mgclients.Client{}, svcerr.ErrInvalidStatus
 		}
+		if _, err := mgclients.AllowedSubtopicsFromMetadata(c.Metadata); err != nil {
+			return []mgclients.Client{}, err
+		}
 		c.Domain = user.GetDomainId()
 		c.CreatedAt = time.Now()
 		clients = append(clients, c)
@@ -113,7 +169,7 @@ func (svc service) CreateThings(ctx context.Context, token string, cls ...mgclie
 }
 
 func (svc service) ViewClient(ctx context.Context, token, id string) (mgclients.Client, error) {
-	_, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.ViewPermission, auth.ThingType, id)
+	_, err := svc.authorizeToken(ctx, token, mgclients.ThingsReadScope, auth.ViewPermission, auth.ThingType, id)
 	if err != nil {
 		return mgclients.Client{}, err
 	}
@@ -124,8 +180,62 @@ func (svc service) ViewClient(ctx context.Context, token, id string) (mgclients.
 	return client, nil
 }
 
+func (svc service) ViewClients(ctx context.Context, token string, ids ...string) ([]ViewResult, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
+	if err != nil {
+		return nil, err
+	}
+
+	// IDs may be repeated, so each of them is authorized only once.
+	authzErrs := make(map[string]error)
+	var allowedIDs []string
+	for _, id := range ids {
+		if _, ok := authzErrs[id]; ok {
+			continue
+		}
+		_, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.ViewPermission, auth.ThingType, id)
+		authzErrs[id] = err
+		if err == nil {
+			allowedIDs = append(allowedIDs, id)
+		}
+	}
+
+	found := make(map[string]mgclients.Client)
+	if len(allowedIDs) > 0 {
+		pm := mgclients.Page{
+			IDs:    allowedIDs,
+			Status: mgclients.AllStatus,
+			Limit:  uint64(len(allowedIDs)),
+		}
+		cp, err := svc.clients.RetrieveAllByIDs(ctx, pm)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		for _, c := range cp.Clients {
+			found[c.ID] = c
+		}
+	}
+
+	results := make([]ViewResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+		if err := authzErrs[id]; err != nil {
+			results[i].Err = err
+			continue
+		}
+		c, ok := found[id]
+		if !ok {
+			results[i].Err = svcerr.ErrNotFound
+			continue
+		}
+		results[i].Client = c
+	}
+
+	return results, nil
+}
+
 func (svc service) ViewClientPerms(ctx context.Context, token, id string) ([]string, error) {
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
 	if err != nil {
 		return nil, err
 	}
@@ -135,18 +245,61 @@ func (svc service) ViewClientPerms(ctx context.Context, token, id string) ([]str
 		return nil, err
 	}
 	if len(permissions) == 0 {
-		return nil, svcerr.ErrAuthorization
+		// Callers without permissions learn only whether the thing
+		// exists in their domain.
+		thing, err := svc.clients.RetrieveByID(ctx, id)
+		if err != nil || thing.Domain != res.GetDomainId() {
+			return nil, svcerr.ErrNotFound
+		}
+		return []string{}, nil
 	}
 	return permissions, nil
 }
 
 func (svc service) ListClients(ctx context.Context, token, reqUserID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
-	var ids []string
-
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
 	if err != nil {
 		return mgclients.ClientsPage{}, err
 	}
+	if pm.Wait <= 0 || pm.UpdatedSince.IsZero() {
+		return svc.listClients(ctx, res, reqUserID, pm)
+	}
+
+	// The subscription ends with the request, whether it is answered or
+	// abandoned by the client.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Subscribe before listing, so that changes made in the meantime wake the request.
+	changes, err := svc.watcher.Watch(ctx, res.GetDomainId(), 0)
+	if err != nil {
+		return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	timer := time.NewTimer(pm.Wait)
+	defer timer.Stop()
+
+	for {
+		tp, err := svc.listClients(ctx, res, reqUserID, pm)
+		if err != nil || tp.Total > 0 {
+			return tp, err
+		}
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				// The watcher drops subscribers which fall behind.
+				if changes, err = svc.watcher.Watch(ctx, res.GetDomainId(), 0); err != nil {
+					return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+				}
+			}
+		case <-timer.C:
+			return tp, nil
+		case <-ctx.Done():
+			return tp, nil
+		}
+	}
+}
+
+func (svc service) listClients(ctx context.Context, res *magistrala.IdentityRes, reqUserID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	var ids []string
 
 	switch {
 	case (reqUserID != "" && reqUserID != res.GetUserId()):
@@ -180,6 +333,13 @@ func (svc service) ListClients(ctx context.Context, token, reqUserID string, pm
 	}
 
 	pm.IDs = ids
+	ok, err := svc.filterConnection(ctx, res.GetDomainId(), &pm)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+	if !ok {
+		return mgclients.ClientsPage{Page: mgclients.Page{Offset: pm.Offset, Limit: pm.Limit}}, nil
+	}
 
 	tp, err := svc.clients.RetrieveAllByIDs(ctx, pm)
 	if err != nil {
@@ -204,6 +364,46 @@ func (svc service) ListClients(ctx context.Context, token, reqUserID string, pm
 	return tp, nil
 }
 
+// filterConnection restricts the page to the domain things in the requested
+// connection state. It returns false if none of the things can match.
+func (svc service) filterConnection(ctx context.Context, domainID string, pm *mgclients.Page) (bool, error) {
+	if pm.Connection == "" {
+		return true, nil
+	}
+	online, err := svc.clientCache.Online(ctx, domainID, maxConnectionFilter+1)
+	if err != nil {
+		return false, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(online) > maxConnectionFilter {
+		return false, errors.Wrap(svcerr.ErrMalformedEntity, ErrConnectionFilterTooLarge)
+	}
+
+	switch pm.Connection {
+	case OnlineConnection:
+		// Page without IDs in the domain context lists all the domain things.
+		if len(pm.IDs) == 0 && pm.Domain != "" {
+			pm.IDs = online
+			return len(online) > 0, nil
+		}
+		onlineIDs := make(map[string]struct{}, len(online))
+		for _, id := range online {
+			onlineIDs[id] = struct{}{}
+		}
+		var ids []string
+		for _, id := range pm.IDs {
+			if _, ok := onlineIDs[id]; ok {
+				ids = append(ids, id)
+			}
+		}
+		pm.IDs = ids
+		return len(ids) > 0, nil
+	case OfflineConnection:
+		pm.ExcludeIDs = online
+	}
+
+	return len(pm.IDs) > 0 || pm.Domain != "", nil
+}
+
 // Experimental functions used for async calling of svc.listUserThingPermission. This might be helpful during listing of large number of entities.
 func (svc service) retrievePermissions(ctx context.Context, userID string, client *mgclients.Client) error {
 	permissions, err := svc.listUserThingPermission(ctx, userID, client.ID)
@@ -278,28 +478,368 @@ func (svc service) checkSuperAdmin(ctx context.Context, userID string) error {
 	return nil
 }
 
-func (svc service) UpdateClient(ctx context.Context, token string, cli mgclients.Client) (mgclients.Client, error) {
-	userID, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.ThingType, cli.ID)
+func (svc service) AggregateMetadata(ctx context.Context, token, field string, status mgclients.Status) ([]mgclients.MetadataAggregate, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := mgclients.Page{
+		Status: status,
+		Role:   mgclients.AllRole,
+	}
+	switch err := svc.checkSuperAdmin(ctx, res.GetUserId()); err {
+	case nil:
+		pm.Domain = res.GetDomainId()
+	default:
+		if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.MembershipPermission, auth.DomainType, res.GetDomainId()); err != nil {
+			return nil, err
+		}
+		ids, err := svc.listClientIDs(ctx, res.GetId(), auth.ViewPermission)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		if len(ids) == 0 {
+			return []mgclients.MetadataAggregate{}, nil
+		}
+		pm.IDs = ids
+	}
+
+	aggs, err := svc.clients.AggregateMetadata(ctx, pm, field)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return aggs, nil
+}
+
+func (svc service) ListOrphanedClients(ctx context.Context, token string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+	if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+
+	pm.Domain = res.GetDomainId()
+	pm.IDs = nil
+	ok, err := svc.filterConnection(ctx, res.GetDomainId(), &pm)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+	if !ok {
+		return mgclients.ClientsPage{Page: mgclients.Page{Offset: pm.Offset, Limit: pm.Limit}}, nil
+	}
+	connected, err := svc.connectedClientIDs(ctx, pm.Domain)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
+	}
+	pm.ExcludeIDs = append(pm.ExcludeIDs, connected...)
+
+	tp, err := svc.clients.RetrieveAllByIDs(ctx, pm)
+	if err != nil {
+		return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tp, nil
+}
+
+// connectedClientIDs returns the IDs of the things connected to any channel
+// of the domain. Connections are kept as policies, so they are looked up
+// at once through the permission the domain holds over connected things.
+func (svc service) connectedClientIDs(ctx context.Context, domainID string) ([]string, error) {
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.DomainType,
+		Subject:     domainID,
+		Permission:  auth.ConnectedDomainPermission,
+		ObjectType:  auth.ThingType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tids.Policies, nil
+}
+
+func (svc service) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]mggroups.Group, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
+	if err != nil {
+		return nil, err
+	}
+	chs := make(map[string][]mggroups.Group)
+	if len(thingIDs) == 0 {
+		return chs, nil
+	}
+
+	// Super admins can view all the channels of the domain, while the
+	// other users view the things and channels shared with them.
+	var allowed map[string]bool
+	if err := svc.checkSuperAdmin(ctx, res.GetUserId()); err != nil {
+		if thingIDs, err = svc.filterAllowedThingIDs(ctx, res.GetId(), auth.ViewPermission, thingIDs); err != nil {
+			return nil, err
+		}
+		gids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+			SubjectType: auth.UserType,
+			Subject:     res.GetId(),
+			Permission:  auth.ViewPermission,
+			ObjectType:  auth.GroupType,
+		})
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		allowed = make(map[string]bool, len(gids.Policies))
+		for _, id := range gids.Policies {
+			allowed[id] = true
+		}
+	}
+
+	// Connections are kept as policies, so they are looked up concurrently
+	// and the channels of all the things are then retrieved at once.
+	conns := make([][]string, len(thingIDs))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConnectionLookups)
+	for i, id := range thingIDs {
+		i, id := i, id
+		g.Go(func() error {
+			cp, err := svc.auth.ListAllSubjects(gctx, &magistrala.ListSubjectsReq{
+				SubjectType: auth.GroupType,
+				Permission:  auth.GroupRelation,
+				ObjectType:  auth.ThingType,
+				Object:      id,
+			})
+			if err != nil {
+				return errors.Wrap(svcerr.ErrViewEntity, err)
+			}
+			conns[i] = cp.GetPolicies()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, cids := range conns {
+		for _, id := range cids {
+			if !seen[id] && (allowed == nil || allowed[id]) {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return chs, nil
+	}
+	gp, err := svc.grepo.RetrieveByIDs(ctx, mggroups.Page{
+		PageMeta: mggroups.PageMeta{
+			DomainID: res.GetDomainId(),
+			Status:   mgclients.AllStatus,
+			Limit:    uint64(len(ids)),
+		},
+	}, ids...)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	byID := make(map[string]mggroups.Group, len(gp.Groups))
+	for _, gr := range gp.Groups {
+		byID[gr.ID] = gr
+	}
+	for i, id := range thingIDs {
+		for _, cid := range conns[i] {
+			if gr, ok := byID[cid]; ok {
+				chs[id] = append(chs[id], gr)
+			}
+		}
+	}
+
+	return chs, nil
+}
+
+func (svc service) ExportSubtree(ctx context.Context, token, groupID string, pm SubtreePageMeta, keys bool) (Subtree, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
+	if err != nil {
+		return Subtree{}, err
+	}
+	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.GroupType, groupID); err != nil {
+		return Subtree{}, err
+	}
+
+	channel, err := svc.grepo.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return Subtree{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	descendants, err := svc.retrieveDescendantIDs(ctx, groupID)
+	if err != nil {
+		return Subtree{}, err
+	}
+	cp := mggroups.Page{
+		PageMeta: mggroups.PageMeta{
+			Offset: pm.Channels.Offset,
+			Limit:  pm.Channels.Limit,
+			Status: mgclients.AllStatus,
+		},
+	}
+	channels := mggroups.Page{PageMeta: cp.PageMeta, Groups: []mggroups.Group{}}
+	if len(descendants) > 0 {
+		if channels, err = svc.grepo.RetrieveByIDs(ctx, cp, descendants...); err != nil {
+			return Subtree{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+	}
+
+	things, err := svc.retrieveSubtreeThings(ctx, append([]string{groupID}, descendants...), pm.Things, keys)
+	if err != nil {
+		return Subtree{}, err
+	}
+
+	members, err := groups.ListGroupMembers(ctx, svc.auth, groupID)
+	if err != nil {
+		return Subtree{}, err
+	}
+	total := uint64(len(members))
+	mp := MemberRolesPage{
+		PageMeta: mggroups.PageMeta{Total: total, Offset: pm.Members.Offset, Limit: pm.Members.Limit},
+		Members:  members[min(pm.Members.Offset, total):min(pm.Members.Offset+pm.Members.Limit, total)],
+	}
+
+	return Subtree{
+		Channel:  channel,
+		Channels: channels,
+		Things:   things,
+		Members:  mp,
+	}, nil
+}
+
+// retrieveDescendantIDs retrieves the IDs of the groups descending from the
+// group, level by level.
+func (svc service) retrieveDescendantIDs(ctx context.Context, groupID string) ([]string, error) {
+	var ids []string
+	for level := []string{groupID}; len(level) > 0; {
+		var next []string
+		for _, id := range level {
+			children, err := svc.grepo.RetrieveChildrenIDs(ctx, id)
+			if err != nil {
+				return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+			}
+			next = append(next, children...)
+		}
+		ids = append(ids, next...)
+		level = next
+	}
+
+	return ids, nil
+}
+
+// retrieveSubtreeThings retrieves the page of the things connected to any of
+// the groups, with their keys if keys is set.
+func (svc service) retrieveSubtreeThings(ctx context.Context, groupIDs []string, pm mggroups.PageMeta, keys bool) (mgclients.MembersPage, error) {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, id := range groupIDs {
+		tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+			SubjectType: auth.GroupType,
+			Subject:     id,
+			Permission:  auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+		})
+		if err != nil {
+			return mgclients.MembersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		// Things connected to several groups of the subtree are listed once.
+		for _, tid := range tids.GetPolicies() {
+			if !seen[tid] {
+				seen[tid] = true
+				ids = append(ids, tid)
+			}
+		}
+	}
+
+	page := mgclients.MembersPage{
+		Page:    mgclients.Page{Offset: pm.Offset, Limit: pm.Limit},
+		Members: []mgclients.Client{},
+	}
+	if len(ids) == 0 {
+		return page, nil
+	}
+	cp, err := svc.clients.RetrieveAllByIDs(ctx, mgclients.Page{
+		IDs:    ids,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Status: mgclients.AllStatus,
+	})
+	if err != nil {
+		return mgclients.MembersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	page.Page = cp.Page
+	if len(cp.Clients) == 0 {
+		return page, nil
+	}
+	page.Members = cp.Clients
+	if !keys {
+		return page, nil
+	}
+
+	pids := make([]string, len(cp.Clients))
+	for i, c := range cp.Clients {
+		pids[i] = c.ID
+	}
+	ks, err := svc.clients.RetrieveKeysByIDs(ctx, pids...)
+	if err != nil {
+		return mgclients.MembersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	secrets := make(map[string]string, len(ks))
+	for _, k := range ks {
+		secrets[k.ID] = k.Credentials.Secret
+	}
+	for i := range page.Members {
+		page.Members[i].Credentials.Secret = secrets[page.Members[i].ID]
+	}
+
+	return page, nil
+}
+
+func (svc service) UpdateClient(ctx context.Context, token string, cli mgclients.Client, merge bool) (mgclients.Client, error) {
+	userID, err := svc.authorizeToken(ctx, token, mgclients.ThingsWriteScope, auth.EditPermission, auth.ThingType, cli.ID)
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
+	if merge && cli.Metadata != nil {
+		current, err := svc.clients.RetrieveByID(ctx, cli.ID)
+		if err != nil {
+			return mgclients.Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		cli.Metadata = current.Metadata.Merge(cli.Metadata)
+		// Guard the read-modify-write against concurrent updates.
+		if cli.Version == 0 {
+			cli.Version = current.Version
+		}
+	}
+	if _, err := mgclients.AllowedSubtopicsFromMetadata(cli.Metadata); err != nil {
+		return mgclients.Client{}, err
+	}
+
 	client := mgclients.Client{
 		ID:        cli.ID,
 		Name:      cli.Name,
 		Metadata:  cli.Metadata,
 		UpdatedAt: time.Now(),
 		UpdatedBy: userID,
+		Version:   cli.Version,
 	}
 	client, err = svc.clients.Update(ctx, client)
 	if err != nil {
+		if cli.Version != 0 && errors.Contains(err, repoerr.ErrConflict) {
+			return mgclients.Client{}, svcerr.ErrConflict
+		}
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
 	return client, nil
 }
 
 func (svc service) UpdateClientTags(ctx context.Context, token string, cli mgclients.Client) (mgclients.Client, error) {
-	userID, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.ThingType, cli.ID)
+	userID, err := svc.authorizeToken(ctx, token, mgclients.ThingsWriteScope, auth.EditPermission, auth.ThingType, cli.ID)
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
@@ -317,70 +857,458 @@ func (svc service) UpdateClientTags(ctx context.Context, token string, cli mgcli
 	return client, nil
 }
 
-func (svc service) UpdateClientSecret(ctx context.Context, token, id, key string) (mgclients.Client, error) {
-	userID, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.EditPermission, auth.ThingType, id)
+func (svc service) UpdateClientSecret(ctx context.Context, token, id, key string, ttl time.Duration) (mgclients.Client, error) {
+	userID, err := svc.authorizeToken(ctx, token, mgclients.ThingsWriteScope, auth.EditPermission, auth.ThingType, id)
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	if err := svc.keyPolicy.Check(key); err != nil {
+		return mgclients.Client{}, err
+	}
 
+	now := time.Now()
 	client := mgclients.Client{
 		ID: id,
 		Credentials: mgclients.Credentials{
 			Secret: key,
 		},
-		UpdatedAt: time.Now(),
+		UpdatedAt: now,
 		UpdatedBy: userID,
 		Status:    mgclients.EnabledStatus,
 	}
+	if ttl > 0 {
+		exp := now.Add(ttl)
+		client.Credentials.ExpiresAt = &exp
+	}
 	client, err = svc.clients.UpdateSecret(ctx, client)
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	// Drop the cached key so the old key and its expiry are not served.
+	if err := svc.removeCachedKey(ctx, id); err != nil {
+		return client, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
 	return client, nil
 }
 
-func (svc service) EnableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
-	client := mgclients.Client{
-		ID:        id,
-		Status:    mgclients.EnabledStatus,
-		UpdatedAt: time.Now(),
-	}
-	client, err := svc.changeClientStatus(ctx, token, client)
-	if err != nil {
-		return mgclients.Client{}, errors.Wrap(mgclients.ErrEnableClient, err)
+// removeCachedKey removes the cached key of the thing. The rest of the
+// cached state of the thing, such as its activity, is kept, since the thing
+// remains the same with its new key.
+func (svc service) removeCachedKey(ctx context.Context, thingID string) error {
+	key, err := svc.clientCache.Key(ctx, thingID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
 	}
 
-	return client, nil
+	return svc.clientCache.RemoveKey(ctx, key)
 }
 
-func (svc service) DisableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
-	client := mgclients.Client{
-		ID:        id,
-		Status:    mgclients.DisabledStatus,
-		UpdatedAt: time.Now(),
-	}
-	client, err := svc.changeClientStatus(ctx, token, client)
+// generateKey generates a thing key. Keys are UUIDs, unless a UUID doesn't
+// satisfy the key policy.
+func (svc service) generateKey() (string, error) {
+	key, err := svc.idProvider.ID()
 	if err != nil {
-		return mgclients.Client{}, errors.Wrap(mgclients.ErrDisableClient, err)
+		return "", err
 	}
-
-	if err := svc.clientCache.Remove(ctx, client.ID); err != nil {
-		return client, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	if svc.keyPolicy.Check(key) == nil {
+		return key, nil
 	}
 
-	return client, nil
+	return svc.keyPolicy.Generate()
 }
 
-func (svc service) Share(ctx context.Context, token, id, relation string, userids ...string) error {
-	user, err := svc.identify(ctx, token)
+func (svc service) RotateKeys(ctx context.Context, token, groupID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
 	if err != nil {
-		return err
+		return mgclients.ClientsPage{}, err
 	}
-	if _, err := svc.authorize(ctx, user.GetDomainId(), auth.UserType, auth.UsersKind, user.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
-		return errors.Wrap(svcerr.ErrAuthorization, err)
+	userID, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.GroupType, groupID)
+	if err != nil {
+		return mgclients.ClientsPage{}, err
 	}
 
-	policies := magistrala.AddPoliciesReq{}
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.GroupType,
+		Subject:     groupID,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
+	})
+	if err != nil {
+		return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrNotFound, err)
+	}
+	if len(tids.Policies) == 0 {
+		return mgclients.ClientsPage{Page: mgclients.Page{Offset: pm.Offset, Limit: pm.Limit}}, nil
+	}
+
+	// Keys of disabled things are rotated as well, so they can't be
+	// used once the things are enabled again.
+	pm.IDs = tids.Policies
+	pm.Status = mgclients.AllStatus
+	cp, err := svc.clients.RetrieveAllByIDs(ctx, pm)
+	if err != nil {
+		return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	now := time.Now()
+	for i := range cp.Clients {
+		key, err := svc.generateKey()
+		if err != nil {
+			return mgclients.ClientsPage{}, err
+		}
+		cp.Clients[i].Credentials.Secret = key
+		cp.Clients[i].Credentials.ExpiresAt = nil
+		cp.Clients[i].UpdatedAt = now
+		cp.Clients[i].UpdatedBy = userID
+	}
+	if err := svc.clients.UpdateSecrets(ctx, cp.Clients...); err != nil {
+		return mgclients.ClientsPage{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	// Drop the cached keys so the old keys are no longer accepted.
+	for _, c := range cp.Clients {
+		if err := svc.removeCachedKey(ctx, c.ID); err != nil {
+			return cp, errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+	}
+
+	return cp, nil
+}
+
+func (svc service) TransferChannel(ctx context.Context, token, groupID, domainID string) (ChannelTransfer, error) {
+	res, err := svc.identify(ctx, token, mgclients.ChannelsWriteScope)
+	if err != nil {
+		return ChannelTransfer{}, err
+	}
+	if domainID == res.GetDomainId() {
+		return ChannelTransfer{}, mggroups.ErrSameDomain
+	}
+	// The user has to administer both the source and the target domain.
+	userID, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, res.GetDomainId())
+	if err != nil {
+		return ChannelTransfer{}, err
+	}
+	targetUserID := auth.EncodeDomainUserID(domainID, res.GetUserId())
+	if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, targetUserID, auth.AdminPermission, auth.DomainType, domainID); err != nil {
+		return ChannelTransfer{}, err
+	}
+
+	source, err := svc.grepo.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return ChannelTransfer{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if source.Domain != res.GetDomainId() {
+		return ChannelTransfer{}, svcerr.ErrAuthorization
+	}
+	children, err := svc.grepo.RetrieveChildrenIDs(ctx, groupID)
+	if err != nil {
+		return ChannelTransfer{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(children) > 0 {
+		return ChannelTransfer{}, mggroups.ErrGroupHasChildren
+	}
+
+	things, err := svc.channelThings(ctx, groupID)
+	if err != nil {
+		return ChannelTransfer{}, err
+	}
+	if err := svc.checkNameConflicts(ctx, domainID, source, things); err != nil {
+		return ChannelTransfer{}, err
+	}
+
+	now := time.Now()
+	channel := source
+	channel.Domain = domainID
+	channel.Parent = ""
+	channel.UpdatedAt = now
+	channel.UpdatedBy = userID
+	moved := make([]mgclients.Client, len(things))
+	for i, t := range things {
+		t.Domain = domainID
+		t.UpdatedAt = now
+		t.UpdatedBy = userID
+		moved[i] = t
+	}
+	if err := svc.clients.TransferChannel(ctx, channel, moved...); err != nil {
+		return ChannelTransfer{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	err = svc.saveCachedDomain(ctx, moved, domainID)
+	if err == nil {
+		err = svc.transferPolicies(ctx, source, domainID, targetUserID, moved)
+	}
+	if err != nil {
+		if errRollback := svc.clients.TransferChannel(ctx, source, things...); errRollback != nil {
+			return ChannelTransfer{}, errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRollback), err)
+		}
+		if errRollback := svc.saveCachedDomain(ctx, things, source.Domain); errRollback != nil {
+			err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRollback), err)
+		}
+		return ChannelTransfer{}, err
+	}
+
+	return ChannelTransfer{Channel: channel, Things: moved}, nil
+}
+
+// saveCachedDomain records the domain of the moved things in the cache, so
+// their activity is recorded under the domain they belong to.
+func (svc service) saveCachedDomain(ctx context.Context, things []mgclients.Client, domainID string) error {
+	for _, t := range things {
+		if err := svc.clientCache.SaveDomain(ctx, t.ID, domainID); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// channelThings returns the things connected to the channel. The things
+// which are connected to other channels as well can't be moved with the
+// channel, so ErrSharedThing is returned for them.
+func (svc service) channelThings(ctx context.Context, groupID string) ([]mgclients.Client, error) {
+	tids, err := svc.auth.ListAllObjects(ctx, &magistrala.ListObjectsReq{
+		SubjectType: auth.GroupType,
+		Subject:     groupID,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrNotFound, err)
+	}
+	if len(tids.Policies) == 0 {
+		return []mgclients.Client{}, nil
+	}
+	for _, id := range tids.Policies {
+		chs, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+			SubjectType: auth.GroupType,
+			Permission:  auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+			Object:      id,
+		})
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		for _, ch := range chs.Policies {
+			if ch != groupID {
+				return nil, errors.Wrap(mggroups.ErrSharedThing, fmt.Errorf("thing %s", id))
+			}
+		}
+	}
+
+	cp, err := svc.clients.RetrieveAllByIDs(ctx, mgclients.Page{
+		IDs:    tids.Policies,
+		Status: mgclients.AllStatus,
+		Limit:  uint64(len(tids.Policies)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return cp.Clients, nil
+}
+
+// checkNameConflicts returns ErrNameConflict if the channel or any of the
+// things are named the same as a channel or a thing of the target domain.
+func (svc service) checkNameConflicts(ctx context.Context, domainID string, channel mggroups.Group, things []mgclients.Client) error {
+	gp, err := svc.grepo.RetrieveAll(ctx, mggroups.Page{
+		PageMeta: mggroups.PageMeta{
+			Name:     channel.Name,
+			DomainID: domainID,
+			Status:   mgclients.AllStatus,
+			Limit:    1,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if gp.Total > 0 {
+		return errors.Wrap(mggroups.ErrNameConflict, fmt.Errorf("channel %s", channel.Name))
+	}
+	for _, t := range things {
+		if t.Name == "" {
+			continue
+		}
+		cp, err := svc.clients.RetrieveAll(ctx, mgclients.Page{
+			Name:   t.Name,
+			Domain: domainID,
+			Status: mgclients.AllStatus,
+			Limit:  1,
+		})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if cp.Total > 0 {
+			return errors.Wrap(mggroups.ErrNameConflict, fmt.Errorf("thing %s", t.Name))
+		}
+	}
+
+	return nil
+}
+
+// transferPolicies replaces the domain relations of the moved channel and
+// things. The grants of the users of the source domain are removed, and the
+// user is made the administrator of the channel and the things instead.
+func (svc service) transferPolicies(ctx context.Context, source mggroups.Group, domainID, userID string, things []mgclients.Client) error {
+	added := magistrala.AddPoliciesReq{}
+	added.AddPoliciesReq = append(added.AddPoliciesReq, &magistrala.AddPolicyReq{
+		Domain:      domainID,
+		SubjectType: auth.DomainType,
+		Subject:     domainID,
+		Relation:    auth.DomainRelation,
+		ObjectType:  auth.GroupType,
+		Object:      source.ID,
+	})
+	removed := magistrala.DeletePoliciesReq{}
+	removed.DeletePoliciesReq = append(removed.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+		Domain:      source.Domain,
+		SubjectType: auth.DomainType,
+		Subject:     source.Domain,
+		Relation:    auth.DomainRelation,
+		ObjectType:  auth.GroupType,
+		Object:      source.ID,
+	})
+	if source.Parent != "" {
+		removed.DeletePoliciesReq = append(removed.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+			Domain:      source.Domain,
+			SubjectType: auth.GroupType,
+			Subject:     source.Parent,
+			Relation:    auth.ParentGroupRelation,
+			ObjectKind:  auth.NewChannelKind,
+			ObjectType:  auth.GroupType,
+			Object:      source.ID,
+		})
+	}
+	for _, t := range things {
+		added.AddPoliciesReq = append(added.AddPoliciesReq, &magistrala.AddPolicyReq{
+			Domain:      domainID,
+			SubjectType: auth.DomainType,
+			Subject:     domainID,
+			Relation:    auth.DomainRelation,
+			ObjectType:  auth.ThingType,
+			Object:      t.ID,
+		})
+		removed.DeletePoliciesReq = append(removed.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+			Domain:      source.Domain,
+			SubjectType: auth.DomainType,
+			Subject:     source.Domain,
+			Relation:    auth.DomainRelation,
+			ObjectType:  auth.ThingType,
+			Object:      t.ID,
+		})
+	}
+
+	if _, err := svc.auth.AddPolicies(ctx, &added); err != nil {
+		return errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+	if _, err := svc.auth.DeletePolicies(ctx, &removed); err != nil {
+		rollback := magistrala.DeletePoliciesReq{}
+		for _, p := range added.AddPoliciesReq {
+			rollback.DeletePoliciesReq = append(rollback.DeletePoliciesReq, &magistrala.DeletePolicyReq{
+				Domain:      p.Domain,
+				SubjectType: p.SubjectType,
+				Subject:     p.Subject,
+				Relation:    p.Relation,
+				ObjectType:  p.ObjectType,
+				Object:      p.Object,
+			})
+		}
+		if _, errRollback := svc.auth.DeletePolicies(ctx, &rollback); errRollback != nil {
+			err = errors.Wrap(errors.Wrap(errors.ErrRollbackTx, errRollback), err)
+		}
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+
+	// Once the domain relations are moved, the channel and the things belong
+	// to the target domain, so the remaining steps are not rolled back.
+	grants := []*magistrala.DeletePolicyFilterReq{{
+		SubjectType: auth.UserType,
+		ObjectType:  auth.GroupType,
+		Object:      source.ID,
+	}}
+	admins := magistrala.AddPoliciesReq{}
+	admins.AddPoliciesReq = append(admins.AddPoliciesReq, &magistrala.AddPolicyReq{
+		Domain:      domainID,
+		SubjectType: auth.UserType,
+		Subject:     userID,
+		Relation:    auth.AdministratorRelation,
+		ObjectKind:  auth.NewChannelKind,
+		ObjectType:  auth.GroupType,
+		Object:      source.ID,
+	})
+	for _, t := range things {
+		grants = append(grants, &magistrala.DeletePolicyFilterReq{
+			SubjectType: auth.UserType,
+			ObjectType:  auth.ThingType,
+			Object:      t.ID,
+		})
+		admins.AddPoliciesReq = append(admins.AddPoliciesReq, &magistrala.AddPolicyReq{
+			Domain:      domainID,
+			SubjectType: auth.UserType,
+			Subject:     userID,
+			Relation:    auth.AdministratorRelation,
+			ObjectKind:  auth.NewThingKind,
+			ObjectType:  auth.ThingType,
+			Object:      t.ID,
+		})
+	}
+	for _, g := range grants {
+		if _, err := svc.auth.DeletePolicyFilter(ctx, g); err != nil {
+			return errors.Wrap(svcerr.ErrDeletePolicies, err)
+		}
+	}
+	if _, err := svc.auth.AddPolicies(ctx, &admins); err != nil {
+		return errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+
+	return nil
+}
+
+func (svc service) EnableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
+	client := mgclients.Client{
+		ID:        id,
+		Status:    mgclients.EnabledStatus,
+		UpdatedAt: time.Now(),
+	}
+	client, err := svc.changeClientStatus(ctx, token, client)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(mgclients.ErrEnableClient, err)
+	}
+
+	if err := svc.clientCache.Enable(ctx, client.ID); err != nil {
+		return client, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return client, nil
+}
+
+func (svc service) DisableClient(ctx context.Context, token, id string) (mgclients.Client, error) {
+	client := mgclients.Client{
+		ID:        id,
+		Status:    mgclients.DisabledStatus,
+		UpdatedAt: time.Now(),
+	}
+	client, err := svc.changeClientStatus(ctx, token, client)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(mgclients.ErrDisableClient, err)
+	}
+
+	if err := svc.clientCache.Disable(ctx, client.ID); err != nil {
+		return client, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return client, nil
+}
+
+func (svc service) Share(ctx context.Context, token, id, relation string, userids ...string) error {
+	user, err := svc.identify(ctx, token, mgclients.ThingsWriteScope)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.authorize(ctx, user.GetDomainId(), auth.UserType, auth.UsersKind, user.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	policies := magistrala.AddPoliciesReq{}
 	for _, userid := range userids {
 		policies.AddPoliciesReq = append(policies.AddPoliciesReq, &magistrala.AddPolicyReq{
 			SubjectType: auth.UserType,
@@ -401,7 +1329,7 @@ func (svc service) Share(ctx context.Context, token, id, relation string, userid
 }
 
 func (svc service) Unshare(ctx context.Context, token, id, relation string, userids ...string) error {
-	user, err := svc.identify(ctx, token)
+	user, err := svc.identify(ctx, token, mgclients.ThingsWriteScope)
 	if err != nil {
 		return err
 	}
@@ -429,17 +1357,46 @@ func (svc service) Unshare(ctx context.Context, token, id, relation string, user
 	return nil
 }
 
-func (svc service) DeleteClient(ctx context.Context, token, id string) error {
-	res, err := svc.identify(ctx, token)
+func (svc service) DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (mgclients.Client, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsWriteScope)
 	if err != nil {
-		return err
+		return mgclients.Client{}, err
 	}
 	if _, err := svc.authorize(ctx, res.GetDomainId(), auth.UserType, auth.UsersKind, res.GetId(), auth.DeletePermission, auth.ThingType, id); err != nil {
-		return err
+		return mgclients.Client{}, err
+	}
+
+	// The client is returned as it was before the removal, so its domain
+	// is known to the callers once it's gone.
+	client, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if !unmodifiedSince.IsZero() {
+		modified := client.UpdatedAt
+		if modified.IsZero() {
+			modified = client.CreatedAt
+		}
+		// HTTP dates have a second precision.
+		if modified.Truncate(time.Second).After(unmodifiedSince) {
+			return mgclients.Client{}, svcerr.ErrPreconditionFailed
+		}
 	}
 
 	if err := svc.clientCache.Remove(ctx, id); err != nil {
-		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	// Channels are collected before the policies are deleted so that their
+	// thing counts can be updated once the thing is gone.
+	chs, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+		SubjectType: auth.GroupType,
+		Permission:  auth.GroupRelation,
+		ObjectType:  auth.ThingType,
+		Object:      id,
+	})
+	if err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
 
 	deleteRes, err := svc.auth.DeleteEntityPolicies(ctx, &magistrala.DeleteEntityPoliciesReq{
@@ -447,21 +1404,124 @@ func (svc service) DeleteClient(ctx context.Context, token, id string) error {
 		Id:         id,
 	})
 	if err != nil {
-		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
 	if !deleteRes.Deleted {
-		return svcerr.ErrAuthorization
+		return mgclients.Client{}, svcerr.ErrAuthorization
 	}
 
 	if err := svc.clients.Delete(ctx, id); err != nil {
+		return mgclients.Client{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	if err := svc.updateThingCount(ctx, chs.GetPolicies()...); err != nil {
+		return mgclients.Client{}, err
+	}
+
+	return client, nil
+}
+
+func (svc service) WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (<-chan ThingEvent, error) {
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
+	if err != nil {
+		return nil, err
+	}
+	if domainID == "" {
+		domainID = res.GetDomainId()
+	}
+	if domainID != res.GetDomainId() {
+		return nil, svcerr.ErrDomainAuthorization
+	}
+	if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.MembershipPermission, auth.DomainType, domainID); err != nil {
+		return nil, err
+	}
+
+	return svc.watcher.Watch(ctx, domainID, lastSeq)
+}
+
+func (svc service) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (mgclients.ScopedToken, error) {
+	res, err := svc.identifyTokenAdmin(ctx, token)
+	if err != nil {
+		return mgclients.ScopedToken{}, err
+	}
+
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return mgclients.ScopedToken{}, err
+	}
+	secret, err := svc.idProvider.ID()
+	if err != nil {
+		return mgclients.ScopedToken{}, err
+	}
+	now := time.Now()
+	st := mgclients.ScopedToken{
+		ID:        id,
+		Token:     mgclients.ScopedTokenPrefix + secret,
+		DomainID:  res.GetDomainId(),
+		UserID:    res.GetUserId(),
+		Subject:   res.GetId(),
+		Scopes:    scopes,
+		CreatedAt: now,
+	}
+	if ttl > 0 {
+		exp := now.Add(ttl)
+		st.ExpiresAt = &exp
+	}
+	// Only the token hash is stored, the token is returned once.
+	saved := st
+	saved.Token = ""
+	saved.TokenHash = mgclients.HashToken(st.Token)
+	if err := svc.clients.SaveToken(ctx, saved); err != nil {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return st, nil
+}
+
+func (svc service) ListTokens(ctx context.Context, token string, pm mgclients.Page) (mgclients.ScopedTokensPage, error) {
+	res, err := svc.identifyTokenAdmin(ctx, token)
+	if err != nil {
+		return mgclients.ScopedTokensPage{}, err
+	}
+	tp, err := svc.clients.RetrieveAllTokens(ctx, res.GetDomainId(), pm)
+	if err != nil {
+		return mgclients.ScopedTokensPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tp, nil
+}
+
+func (svc service) RevokeToken(ctx context.Context, token, id string) error {
+	res, err := svc.identifyTokenAdmin(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.clients.RemoveToken(ctx, res.GetDomainId(), id); err != nil {
 		return errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
 
 	return nil
 }
 
+// identifyTokenAdmin identifies the domain administrator managing scoped
+// tokens. Scoped tokens can't be used to manage tokens.
+func (svc service) identifyTokenAdmin(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
+	if mgclients.IsScopedToken(token) {
+		return nil, errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrTokenScope)
+	}
+	res, err := svc.identify(ctx, token, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := svc.authorize(ctx, "", auth.UserType, auth.UsersKind, res.GetId(), auth.AdminPermission, auth.DomainType, res.GetDomainId()); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (svc service) changeClientStatus(ctx context.Context, token string, client mgclients.Client) (mgclients.Client, error) {
-	userID, err := svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, auth.DeletePermission, auth.ThingType, client.ID)
+	userID, err := svc.authorizeToken(ctx, token, mgclients.ThingsWriteScope, auth.DeletePermission, auth.ThingType, client.ID)
 	if err != nil {
 		return mgclients.Client{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
@@ -483,7 +1543,7 @@ func (svc service) changeClientStatus(ctx context.Context, token string, client
 }
 
 func (svc service) ListClientsByGroup(ctx context.Context, token, groupID string, pm mgclients.Page) (mgclients.MembersPage, error) {
-	res, err := svc.identify(ctx, token)
+	res, err := svc.identify(ctx, token, mgclients.ThingsReadScope)
 	if err != nil {
 		return mgclients.MembersPage{}, err
 	}
@@ -502,6 +1562,13 @@ func (svc service) ListClientsByGroup(ctx context.Context, token, groupID string
 	}
 
 	pm.IDs = tids.Policies
+	ok, err := svc.filterConnection(ctx, res.GetDomainId(), &pm)
+	if err != nil {
+		return mgclients.MembersPage{}, err
+	}
+	if !ok {
+		return mgclients.MembersPage{Page: mgclients.Page{Offset: pm.Offset, Limit: pm.Limit}}, nil
+	}
 
 	cp, err := svc.clients.RetrieveAllByIDs(ctx, pm)
 	if err != nil {
@@ -535,19 +1602,476 @@ func (svc service) Identify(ctx context.Context, key string) (string, error) {
 	if err == nil {
 		return id, nil
 	}
+	if errors.Contains(err, svcerr.ErrThingDisabled) {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
 
 	client, err := svc.clients.RetrieveBySecret(ctx, key)
 	if err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
-	if err := svc.clientCache.Save(ctx, key, client.ID); err != nil {
+	var expiresAt time.Time
+	if client.Credentials.ExpiresAt != nil {
+		if client.Credentials.Expired() {
+			return "", errors.Wrap(svcerr.ErrAuthentication, svcerr.ErrKeyExpired)
+		}
+		expiresAt = *client.Credentials.ExpiresAt
+	}
+	if err := svc.clientCache.Save(ctx, key, client.ID, expiresAt); err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+	if client.Status == mgclients.DisabledStatus {
+		if err := svc.clientCache.Disable(ctx, client.ID); err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		return "", errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrThingDisabled)
+	}
 
 	return client.ID, nil
 }
 
-func (svc service) identify(ctx context.Context, token string) (*magistrala.IdentityRes, error) {
+func (svc service) Heartbeat(ctx context.Context, key, id string) error {
+	thingID, err := svc.Identify(ctx, key)
+	if err != nil {
+		return err
+	}
+	if thingID != id {
+		return svcerr.ErrAuthorization
+	}
+	ok, err := svc.clientCache.Heartbeat(ctx, thingID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if !ok {
+		return svcerr.ErrRateLimited
+	}
+	if err := svc.seen(ctx, thingID); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// seen records the activity of the thing. The domain the activity is
+// recorded under is looked up the first time the thing is seen.
+func (svc service) seen(ctx context.Context, thingID string) error {
+	err := svc.clientCache.Seen(ctx, thingID)
+	if !errors.Contains(err, repoerr.ErrNotFound) {
+		return err
+	}
+	client, err := svc.clients.RetrieveByID(ctx, thingID)
+	if err != nil {
+		return err
+	}
+	if err := svc.clientCache.SaveDomain(ctx, thingID, client.Domain); err != nil {
+		return err
+	}
+
+	return svc.clientCache.Seen(ctx, thingID)
+}
+
+func (svc service) ValidateKey(ctx context.Context, key string) (KeyValidation, error) {
+	id, err := svc.clientCache.ID(ctx, key)
+	if err == nil {
+		expiresAt, err := svc.clientCache.Expiry(ctx, key)
+		if err != nil {
+			return KeyValidation{}, errors.Wrap(svcerr.ErrServiceUnavailable, err)
+		}
+		res := KeyValidation{Valid: true, ThingID: id}
+		if !expiresAt.IsZero() {
+			res.ExpiresAt = &expiresAt
+		}
+		return res, nil
+	}
+	if errors.Contains(err, svcerr.ErrThingDisabled) {
+		return KeyValidation{}, nil
+	}
+
+	// Failures other than unknown keys are reported, so they are not
+	// mistaken for invalid keys.
+	client, err := svc.clients.RetrieveBySecret(ctx, key)
+	if errors.Contains(err, repoerr.ErrNotFound) {
+		return KeyValidation{}, nil
+	}
+	if err != nil {
+		return KeyValidation{}, errors.Wrap(svcerr.ErrServiceUnavailable, err)
+	}
+	if client.Credentials.Expired() {
+		return KeyValidation{}, nil
+	}
+	var expiresAt time.Time
+	if client.Credentials.ExpiresAt != nil {
+		expiresAt = *client.Credentials.ExpiresAt
+	}
+	if err := svc.clientCache.Save(ctx, key, client.ID, expiresAt); err != nil {
+		return KeyValidation{}, errors.Wrap(svcerr.ErrServiceUnavailable, err)
+	}
+	if client.Status == mgclients.DisabledStatus {
+		if err := svc.clientCache.Disable(ctx, client.ID); err != nil {
+			return KeyValidation{}, errors.Wrap(svcerr.ErrServiceUnavailable, err)
+		}
+		return KeyValidation{}, nil
+	}
+
+	return KeyValidation{
+		Valid:     true,
+		ThingID:   client.ID,
+		ExpiresAt: client.Credentials.ExpiresAt,
+	}, nil
+}
+
+func (svc service) IdentifyCert(ctx context.Context, fingerprint, subject string) (string, error) {
+	fp, ok := mgclients.NormalizeFingerprint(fingerprint)
+	if !ok {
+		return "", svcerr.ErrAuthentication
+	}
+	cert, err := svc.clientCache.Cert(ctx, fp)
+	switch {
+	case err == nil:
+		return svc.checkCertSubject(cert, subject)
+	case errors.Contains(err, svcerr.ErrThingDisabled):
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	// Unknown certificates fail the authentication, unlike unknown keys,
+	// since the certificate is the only credential the thing presents.
+	cert, status, err := svc.clients.RetrieveByCert(ctx, fp)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if err := svc.clientCache.SaveCert(ctx, cert); err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if status == mgclients.DisabledStatus {
+		if err := svc.clientCache.Disable(ctx, cert.ThingID); err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		return "", errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrThingDisabled)
+	}
+
+	return svc.checkCertSubject(cert, subject)
+}
+
+// checkCertSubject returns the ID of the thing bound to the certificate
+// unless the binding holds a subject other than the presented one.
+func (svc service) checkCertSubject(cert mgclients.CertBinding, subject string) (string, error) {
+	if cert.Subject != "" && cert.Subject != subject {
+		return "", errors.Wrap(svcerr.ErrAuthentication, ErrCertSubject)
+	}
+
+	return cert.ThingID, nil
+}
+
+func (svc service) BindCert(ctx context.Context, token string, cert mgclients.CertBinding) (mgclients.CertBinding, error) {
+	if _, err := svc.authorizeToken(ctx, token, mgclients.ThingsWriteScope, auth.EditPermission, auth.ThingType, cert.ThingID); err != nil {
+		return mgclients.CertBinding{}, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	fp, ok := mgclients.NormalizeFingerprint(cert.Fingerprint)
+	if !ok {
+		return mgclients.CertBinding{}, svcerr.ErrMalformedEntity
+	}
+
+	cert.Fingerprint = fp
+	cert.CreatedAt = time.Now()
+	if err := svc.clients.SaveCert(ctx, cert); err != nil {
+		if errors.Contains(err, repoerr.ErrConflict) {
+			return mgclients.CertBinding{}, svcerr.ErrConflict
+		}
+		return mgclients.CertBinding{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return cert, nil
+}
+
+func (svc service) UnbindCert(ctx context.Context, token, thingID, fingerprint string) error {
+	if _, err := svc.authorizeToken(ctx, token, mgclients.ThingsWriteScope, auth.EditPermission, auth.ThingType, thingID); err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	fp, ok := mgclients.NormalizeFingerprint(fingerprint)
+	if !ok {
+		return svcerr.ErrMalformedEntity
+	}
+
+	// The binding is removed from the cache after the database, so that a
+	// concurrent identification can't cache it again.
+	if err := svc.clients.RemoveCert(ctx, thingID, fp); err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return svcerr.ErrNotFound
+		}
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if err := svc.clientCache.RemoveCert(ctx, fp, thingID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) ReconcileCache(ctx context.Context, token string) (CacheReconciliation, error) {
+	if err := svc.authorizeReconcile(ctx, token); err != nil {
+		return CacheReconciliation{}, err
+	}
+
+	svc.reconciler.mu.Lock()
+	defer svc.reconciler.mu.Unlock()
+	if svc.reconciler.state.Running {
+		return svc.reconciler.state, nil
+	}
+	svc.reconciler.state = CacheReconciliation{
+		Running:   true,
+		StartedAt: time.Now(),
+	}
+	// The reconciliation outlives the request which started it.
+	go svc.reconcileCache(context.WithoutCancel(ctx))
+
+	return svc.reconciler.state, nil
+}
+
+func (svc service) ViewCacheReconciliation(ctx context.Context, token string) (CacheReconciliation, error) {
+	if err := svc.authorizeReconcile(ctx, token); err != nil {
+		return CacheReconciliation{}, err
+	}
+
+	svc.reconciler.mu.Lock()
+	defer svc.reconciler.mu.Unlock()
+
+	return svc.reconciler.state, nil
+}
+
+func (svc service) authorizeReconcile(ctx context.Context, token string) error {
+	res, err := svc.identify(ctx, token, "")
+	if err != nil {
+		return err
+	}
+	if err := svc.checkSuperAdmin(ctx, res.GetUserId()); err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// reconcileCache reconciles the cache with the database in batches,
+// publishing the progress after each batch.
+func (svc service) reconcileCache(ctx context.Context) {
+	var rec CacheReconciliation
+	err := svc.reconcile(ctx, &rec)
+
+	svc.reconciler.mu.Lock()
+	defer svc.reconciler.mu.Unlock()
+	svc.reconciler.progress(rec)
+	svc.reconciler.state.Running = false
+	svc.reconciler.state.FinishedAt = time.Now()
+	if err != nil {
+		svc.reconciler.state.Error = err.Error()
+	}
+}
+
+func (svc service) reconcile(ctx context.Context, rec *CacheReconciliation) error {
+	var afterID string
+	for {
+		cs, err := svc.clients.RetrieveKeys(ctx, afterID, reconcileBatchSize)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if err := svc.reconcileKeys(ctx, rec, cs); err != nil {
+			return err
+		}
+		svc.reconciler.publish(*rec)
+		if len(cs) < reconcileBatchSize {
+			break
+		}
+		afterID = cs[len(cs)-1].ID
+	}
+
+	var cursor uint64
+	for {
+		entries, next, err := svc.clientCache.Scan(ctx, cursor, reconcileBatchSize)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if err := svc.removeStaleKeys(ctx, rec, entries); err != nil {
+			return err
+		}
+		svc.reconciler.publish(*rec)
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// publish updates the reported counts of the running reconciliation.
+func (r *reconciler) publish(rec CacheReconciliation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.progress(rec)
+}
+
+func (r *reconciler) progress(rec CacheReconciliation) {
+	r.state.Added = rec.Added
+	r.state.Corrected = rec.Corrected
+	r.state.Removed = rec.Removed
+}
+
+// reconcileKeys caches the keys of the things which are missing from the
+// cache or cached with another key, and removes the cached keys of the
+// things which can't be identified. The disabled marks of the things are
+// restored from their status, so disabled things stay rejected.
+//
+// The status and keys of the batch are read again once the cache is
+// updated. Things are disabled in the database before the cache, so a
+// thing disabled while the batch was reconciled is either disabled again
+// here or marked after its disabled mark was cleared.
+func (svc service) reconcileKeys(ctx context.Context, rec *CacheReconciliation, cs []mgclients.Client) error {
+	var saved []mgclients.Client
+	for _, c := range cs {
+		switch c.Status {
+		case mgclients.DisabledStatus:
+			if err := svc.clientCache.Disable(ctx, c.ID); err != nil {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+		default:
+			if err := svc.clientCache.Enable(ctx, c.ID); err != nil {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+		}
+		cached, err := svc.clientCache.Key(ctx, c.ID)
+		if err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if !identifiable(c) {
+			if cached != "" {
+				if err := svc.clientCache.RemoveKey(ctx, cached); err != nil {
+					return errors.Wrap(svcerr.ErrRemoveEntity, err)
+				}
+				rec.Removed++
+			}
+			continue
+		}
+		if cached == c.Credentials.Secret {
+			if id, err := svc.clientCache.ID(ctx, cached); err == nil && id == c.ID {
+				continue
+			}
+		}
+		if cached != "" && cached != c.Credentials.Secret {
+			if err := svc.clientCache.RemoveKey(ctx, cached); err != nil {
+				return errors.Wrap(svcerr.ErrRemoveEntity, err)
+			}
+		}
+		var expiresAt time.Time
+		if c.Credentials.ExpiresAt != nil {
+			expiresAt = *c.Credentials.ExpiresAt
+		}
+		if err := svc.clientCache.Save(ctx, c.Credentials.Secret, c.ID, expiresAt); err != nil {
+			return errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		saved = append(saved, c)
+		switch cached {
+		case "":
+			rec.Added++
+		default:
+			rec.Corrected++
+		}
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(cs))
+	for i, c := range cs {
+		ids[i] = c.ID
+	}
+	current, err := svc.clients.RetrieveKeysByIDs(ctx, ids...)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	keys := make(map[string]mgclients.Client, len(current))
+	for _, c := range current {
+		keys[c.ID] = c
+		if c.Status == mgclients.DisabledStatus {
+			if err := svc.clientCache.Disable(ctx, c.ID); err != nil {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+		}
+	}
+	// A key changed while the batch was reconciled must not stay cached.
+	for _, c := range saved {
+		if cur, ok := keys[c.ID]; ok && identifiable(cur) && cur.Credentials.Secret == c.Credentials.Secret {
+			continue
+		}
+		if err := svc.clientCache.RemoveKey(ctx, c.Credentials.Secret); err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// removeStaleKeys removes the cached keys of the things which don't exist,
+// can't be identified or have other keys.
+func (svc service) removeStaleKeys(ctx context.Context, rec *CacheReconciliation, entries []CacheEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ThingID
+	}
+	cs, err := svc.clients.RetrieveKeysByIDs(ctx, ids...)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	keys := make(map[string]mgclients.Client, len(cs))
+	for _, c := range cs {
+		keys[c.ID] = c
+	}
+	for _, e := range entries {
+		if c, ok := keys[e.ThingID]; ok && identifiable(c) && c.Credentials.Secret == e.Secret {
+			continue
+		}
+		if err := svc.clientCache.RemoveKey(ctx, e.Secret); err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+		rec.Removed++
+	}
+
+	return nil
+}
+
+// identifiable reports whether the thing can be identified by its key.
+func identifiable(c mgclients.Client) bool {
+	return c.Status == mgclients.EnabledStatus && !c.Credentials.Expired()
+}
+
+func (svc service) updateThingCount(ctx context.Context, groupIDs ...string) error {
+	for _, id := range groupIDs {
+		res, err := svc.auth.CountObjects(ctx, &magistrala.CountObjectsReq{
+			SubjectType: auth.GroupType,
+			Subject:     id,
+			Permission:  auth.GroupRelation,
+			ObjectType:  auth.ThingType,
+		})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		if err := svc.grepo.UpdateThingCount(ctx, id, res.GetCount()); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// identify identifies the user of the bearer token, or the user on behalf
+// of whom the scoped token acts if it allows the operation scope.
+func (svc service) identify(ctx context.Context, token, scope string) (*magistrala.IdentityRes, error) {
+	if mgclients.IsScopedToken(token) {
+		st, err := svc.identifyScoped(ctx, token, scope)
+		if err != nil {
+			return nil, err
+		}
+		return &magistrala.IdentityRes{Id: st.Subject, UserId: st.UserID, DomainId: st.DomainID}, nil
+	}
 	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
 	if err != nil {
 		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
@@ -558,6 +2082,35 @@ func (svc service) identify(ctx context.Context, token string) (*magistrala.Iden
 	return res, nil
 }
 
+func (svc service) identifyScoped(ctx context.Context, token, scope string) (mgclients.ScopedToken, error) {
+	st, err := svc.clients.RetrieveToken(ctx, mgclients.HashToken(token))
+	if err != nil {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if st.Expired() {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrAuthentication, svcerr.ErrKeyExpired)
+	}
+	if !st.Allows(scope) {
+		return mgclients.ScopedToken{}, errors.Wrap(svcerr.ErrAuthorization, svcerr.ErrTokenScope)
+	}
+
+	return st, nil
+}
+
+// authorizeToken authorizes the user of the bearer token, or the user on
+// behalf of whom the scoped token acts if it allows the operation scope.
+func (svc *service) authorizeToken(ctx context.Context, token, scope, perm, objType, obj string) (string, error) {
+	if !mgclients.IsScopedToken(token) {
+		return svc.authorize(ctx, "", auth.UserType, auth.TokenKind, token, perm, objType, obj)
+	}
+	st, err := svc.identifyScoped(ctx, token, scope)
+	if err != nil {
+		return "", err
+	}
+
+	return svc.authorize(ctx, st.DomainID, auth.UserType, auth.UsersKind, st.Subject, perm, objType, obj)
+}
+
 func (svc *service) authorize(ctx context.Context, domainID, subjType, subjKind, subj, perm, objType, obj string) (string, error) {
 	req := &magistrala.AuthorizeReq{
 		Domain:      domainID,
@@ -6,7 +6,10 @@ package things_test
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala"
 	authsvc "github.com/absmach/magistrala/auth"
@@ -16,6 +19,7 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
 	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/magistrala/things"
@@ -53,7 +57,7 @@ func newService() (things.Service, *mocks.Repository, *authmocks.AuthClient, *mo
 	cRepo := new(mocks.Repository)
 	gRepo := new(gmocks.Repository)
 
-	return things.NewService(auth, cRepo, gRepo, thingCache, idProvider), cRepo, auth, thingCache
+	return things.NewService(auth, cRepo, gRepo, thingCache, new(mocks.Watcher), idProvider, mgclients.KeyPolicy{}), cRepo, auth, thingCache
 }
 
 func TestCreateThings(t *testing.T) {
@@ -88,6 +92,17 @@ func TestCreateThings(t *testing.T) {
 			saveErr:      repoerr.ErrConflict,
 			err:          repoerr.ErrConflict,
 		},
+		{
+			desc: "create a new thing with invalid allowed subtopics",
+			thing: mgclients.Client{
+				Name:     "clientWithSubtopics",
+				Metadata: mgclients.Metadata{mgclients.AllowedSubtopicsKey: []interface{}{"room.>", 1}},
+				Status:   mgclients.EnabledStatus,
+			},
+			token:        validToken,
+			authResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:          errors.ErrMalformedEntity,
+		},
 		{
 			desc: "create a new thing without secret",
 			thing: mgclients.Client{
@@ -412,6 +427,94 @@ func TestViewClient(t *testing.T) {
 	}
 }
 
+func TestViewClients(t *testing.T) {
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	deniedID := testsutil.GenerateUUID(t)
+	missingID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc             string
+		token            string
+		ids              []string
+		identifyResponse *magistrala.IdentityRes
+		identifyErr      error
+		retrieveResponse mgclients.ClientsPage
+		retrieveErr      error
+		authorizeCalls   int
+		response         []things.ViewResult
+		err              error
+	}{
+		{
+			desc:             "view things in request order",
+			token:            validToken,
+			ids:              []string{missingID, client.ID, deniedID, client.ID},
+			identifyResponse: &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			retrieveResponse: mgclients.ClientsPage{Clients: []mgclients.Client{client}},
+			authorizeCalls:   3,
+			response: []things.ViewResult{
+				{ID: missingID, Err: svcerr.ErrNotFound},
+				{ID: client.ID, Client: client},
+				{ID: deniedID, Err: svcerr.ErrAuthorization},
+				{ID: client.ID, Client: client},
+			},
+		},
+		{
+			desc:             "view things without access to any of them",
+			token:            validToken,
+			ids:              []string{deniedID},
+			identifyResponse: &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			authorizeCalls:   1,
+			response: []things.ViewResult{
+				{ID: deniedID, Err: svcerr.ErrAuthorization},
+			},
+		},
+		{
+			desc:             "view things with invalid token",
+			token:            inValidToken,
+			ids:              []string{client.ID},
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "view things with failed retrieval",
+			token:            validToken,
+			ids:              []string{client.ID},
+			identifyResponse: &magistrala.IdentityRes{Id: userID, DomainId: domainID},
+			retrieveErr:      repoerr.ErrViewEntity,
+			err:              svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, cRepo, auth, _ := newService()
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+			auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetObject() != deniedID
+			})).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetObject() == deniedID
+			})).Return(&magistrala.AuthorizeRes{Authorized: false}, nil)
+			cRepo.On("RetrieveAllByIDs", mock.Anything, mock.Anything).Return(tc.retrieveResponse, tc.retrieveErr)
+
+			rs, err := svc.ViewClients(context.Background(), tc.token, tc.ids...)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				return
+			}
+			require.Len(t, rs, len(tc.response), fmt.Sprintf("%s: expected %d results got %d", tc.desc, len(tc.response), len(rs)))
+			for i, r := range rs {
+				assert.Equal(t, tc.response[i].ID, r.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.response[i].ID, r.ID))
+				assert.Equal(t, tc.response[i].Client, r.Client, fmt.Sprintf("%s: expected client %v got %v", tc.desc, tc.response[i].Client, r.Client))
+				assert.True(t, errors.Contains(r.Err, tc.response[i].Err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.response[i].Err, r.Err))
+			}
+			auth.AssertNumberOfCalls(t, "Authorize", tc.authorizeCalls)
+		})
+	}
+}
+
 func TestListClients(t *testing.T) {
 	svc, cRepo, auth, _ := newService()
 
@@ -819,8 +922,12 @@ func TestUpdateClient(t *testing.T) {
 
 	client1 := client
 	client2 := client
+	client3 := client
 	client1.Name = "Updated client"
 	client2.Metadata = mgclients.Metadata{"role": "test"}
+	client3.Version = 2
+	client4 := client
+	client4.Metadata = mgclients.Metadata{mgclients.AllowedSubtopicsKey: "room.>"}
 
 	cases := []struct {
 		desc              string
@@ -896,12 +1003,29 @@ func TestUpdateClient(t *testing.T) {
 			token:             validToken,
 			err:               svcerr.ErrUpdateEntity,
 		},
+		{
+			desc:              "update client with stale version",
+			client:            client3,
+			updateResponse:    mgclients.Client{},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			updateErr:         repoerr.ErrConflict,
+			token:             validToken,
+			err:               svcerr.ErrConflict,
+		},
+		{
+			desc:              "update client with invalid allowed subtopics",
+			client:            client4,
+			updateResponse:    mgclients.Client{},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			token:             validToken,
+			err:               errors.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
 		repoCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
 		repoCall1 := cRepo.On("Update", context.Background(), mock.Anything).Return(tc.updateResponse, tc.updateErr)
-		updatedClient, err := svc.UpdateClient(context.Background(), tc.token, tc.client)
+		updatedClient, err := svc.UpdateClient(context.Background(), tc.token, tc.client, false)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.updateResponse, updatedClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateResponse, updatedClient))
 		repoCall.Unset()
@@ -909,6 +1033,56 @@ func TestUpdateClient(t *testing.T) {
 	}
 }
 
+func TestUpdateClientMerge(t *testing.T) {
+	current := client
+	current.Version = 3
+	current.Metadata = mgclients.Metadata{"region": "eu", "line": "a"}
+
+	cases := []struct {
+		desc             string
+		client           mgclients.Client
+		retrieveResponse mgclients.Client
+		retrieveErr      error
+		expected         mgclients.Client
+		err              error
+	}{
+		{
+			desc: "merge client metadata successfully",
+			client: mgclients.Client{
+				ID:       client.ID,
+				Metadata: mgclients.Metadata{"line": nil, "cell": "1"},
+			},
+			retrieveResponse: current,
+			expected: mgclients.Client{
+				ID:       client.ID,
+				Metadata: mgclients.Metadata{"region": "eu", "cell": "1"},
+				Version:  current.Version,
+			},
+			err: nil,
+		},
+		{
+			desc: "merge client metadata with failed to retrieve client",
+			client: mgclients.Client{
+				ID:       client.ID,
+				Metadata: mgclients.Metadata{"cell": "1"},
+			},
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, _ := newService()
+		auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		cRepo.On("RetrieveByID", context.Background(), tc.client.ID).Return(tc.retrieveResponse, tc.retrieveErr)
+		cRepo.On("Update", context.Background(), mock.MatchedBy(func(c mgclients.Client) bool {
+			return assert.ObjectsAreEqual(tc.expected.Metadata, c.Metadata) && c.Version == tc.expected.Version
+		})).Return(tc.expected, nil)
+		_, err := svc.UpdateClient(context.Background(), validToken, tc.client, true)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestUpdateClientTags(t *testing.T) {
 	svc, cRepo, auth, _ := newService()
 
@@ -976,15 +1150,18 @@ func TestUpdateClientTags(t *testing.T) {
 }
 
 func TestUpdateClientSecret(t *testing.T) {
-	svc, cRepo, auth, _ := newService()
+	expiresAt := time.Now().Add(time.Hour)
 
 	cases := []struct {
 		desc                 string
 		client               mgclients.Client
 		newSecret            string
+		ttl                  time.Duration
 		updateSecretResponse mgclients.Client
 		authorizeResponse    *magistrala.AuthorizeRes
 		token                string
+		cachedKey            string
+		keyErr               error
 		updateErr            error
 		authorizeErr         error
 		err                  error
@@ -1002,6 +1179,41 @@ func TestUpdateClientSecret(t *testing.T) {
 			},
 			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
 			token:             validToken,
+			cachedKey:         client.Credentials.Secret,
+			err:               nil,
+		},
+		{
+			desc:      "update client secret of thing without cached key",
+			client:    client,
+			newSecret: "newSecret",
+			updateSecretResponse: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity: client.Credentials.Identity,
+					Secret:   "newSecret",
+				},
+			},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			token:             validToken,
+			keyErr:            repoerr.ErrNotFound,
+			err:               nil,
+		},
+		{
+			desc:      "update client secret with ttl successfully",
+			client:    client,
+			newSecret: "newSecret",
+			ttl:       time.Hour,
+			updateSecretResponse: mgclients.Client{
+				ID: client.ID,
+				Credentials: mgclients.Credentials{
+					Identity:  client.Credentials.Identity,
+					Secret:    "newSecret",
+					ExpiresAt: &expiresAt,
+				},
+			},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			token:             validToken,
+			cachedKey:         client.Credentials.Secret,
 			err:               nil,
 		},
 		{
@@ -1039,18 +1251,471 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		repoCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
-		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateErr)
-		updatedClient, err := svc.UpdateClientSecret(context.Background(), tc.token, tc.client.ID, tc.newSecret)
+		svc, cRepo, auth, cache := newService()
+		auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		cRepo.On("UpdateSecret", context.Background(), mock.MatchedBy(func(c mgclients.Client) bool {
+			return (tc.ttl == 0) == (c.Credentials.ExpiresAt == nil)
+		})).Return(tc.updateSecretResponse, tc.updateErr)
+		cache.On("Key", mock.Anything, tc.client.ID).Return(tc.cachedKey, tc.keyErr)
+		cache.On("RemoveKey", mock.Anything, tc.cachedKey).Return(nil)
+		updatedClient, err := svc.UpdateClientSecret(context.Background(), tc.token, tc.client.ID, tc.newSecret, tc.ttl)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.updateSecretResponse, updatedClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateSecretResponse, updatedClient))
-		repoCall.Unset()
-		repoCall1.Unset()
+		if tc.err == nil {
+			switch tc.keyErr {
+			case nil:
+				cache.AssertCalled(t, "RemoveKey", mock.Anything, tc.cachedKey)
+			default:
+				cache.AssertNotCalled(t, "RemoveKey", mock.Anything, mock.Anything)
+			}
+			cache.AssertNotCalled(t, "Remove", mock.Anything, tc.client.ID)
+		}
+	}
+}
+
+func TestKeyPolicy(t *testing.T) {
+	policy := mgclients.KeyPolicy{MinLength: 40, RequireUpper: true, RequireDigit: true}
+
+	cases := []struct {
+		desc      string
+		newSecret string
+		err       error
+	}{
+		{
+			desc:      "update client secret with key satisfying the policy",
+			newSecret: "Strong-key-satisfying-the-policy-0123456789",
+		},
+		{
+			desc:      "update client secret with short key",
+			newSecret: "Sh0rt",
+			err:       mgclients.ErrWeakKey,
+		},
+		{
+			desc:      "update client secret with key missing character class",
+			newSecret: "weak-key-without-upper-case-letters-0123456789",
+			err:       mgclients.ErrWeakKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			cRepo := new(mocks.Repository)
+			cache := new(mocks.Cache)
+			svc := things.NewService(auth, cRepo, new(gmocks.Repository), cache, new(mocks.Watcher), uuid.NewMock(), policy)
+
+			auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(mgclients.Client{ID: client.ID}, nil)
+			cache.On("Key", mock.Anything, client.ID).Return("", repoerr.ErrNotFound)
+			_, err := svc.UpdateClientSecret(context.Background(), validToken, client.ID, tc.newSecret, 0)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v got %v", tc.err, err))
+			if tc.err != nil {
+				cRepo.AssertNotCalled(t, "UpdateSecret", context.Background(), mock.Anything)
+			}
+		})
+	}
+
+	t.Run("create thing with generated key", func(t *testing.T) {
+		auth := new(authmocks.AuthClient)
+		cRepo := new(mocks.Repository)
+		svc := things.NewService(auth, cRepo, new(gmocks.Repository), new(mocks.Cache), new(mocks.Watcher), uuid.NewMock(), policy)
+
+		auth.On("Identify", mock.Anything, mock.Anything).Return(&magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)}, nil)
+		auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		auth.On("AddPolicies", mock.Anything, mock.Anything).Return(&magistrala.AddPoliciesRes{Added: true}, nil)
+		cRepo.On("Save", context.Background(), mock.MatchedBy(func(c mgclients.Client) bool {
+			return policy.Check(c.Credentials.Secret) == nil
+		})).Return([]mgclients.Client{client}, nil)
+		_, err := svc.CreateThings(context.Background(), validToken, mgclients.Client{Name: "thing"})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	})
+}
+
+func TestRotateKeys(t *testing.T) {
+	groupID := testsutil.GenerateUUID(t)
+	thing := mgclients.Client{
+		ID:          testsutil.GenerateUUID(t),
+		Credentials: mgclients.Credentials{Identity: "thing", Secret: secret},
+		Status:      mgclients.DisabledStatus,
+	}
+	page := mgclients.Page{Offset: 0, Limit: 10}
+
+	cases := []struct {
+		desc                     string
+		token                    string
+		identifyResponse         *magistrala.IdentityRes
+		authorizeResponse        *magistrala.AuthorizeRes
+		listObjectsResponse      *magistrala.ListObjectsRes
+		retrieveAllByIDsResponse mgclients.ClientsPage
+		identifyErr              error
+		authorizeErr             error
+		listObjectsErr           error
+		retrieveAllByIDsErr      error
+		updateSecretsErr         error
+		removeErr                error
+		total                    uint64
+		err                      error
+	}{
+		{
+			desc:                "rotate keys successfully",
+			token:               validToken,
+			identifyResponse:    &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true, Id: validID},
+			listObjectsResponse: &magistrala.ListObjectsRes{Policies: []string{thing.ID}},
+			retrieveAllByIDsResponse: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1, Limit: 10},
+				Clients: []mgclients.Client{thing},
+			},
+			total: 1,
+		},
+		{
+			desc:                "rotate keys of channel without things",
+			token:               validToken,
+			identifyResponse:    &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true, Id: validID},
+			listObjectsResponse: &magistrala.ListObjectsRes{},
+		},
+		{
+			desc:        "rotate keys with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "rotate keys with unauthorized user",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:                "rotate keys with failed to list objects",
+			token:               validToken,
+			identifyResponse:    &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true, Id: validID},
+			listObjectsResponse: &magistrala.ListObjectsRes{},
+			listObjectsErr:      svcerr.ErrNotFound,
+			err:                 svcerr.ErrNotFound,
+		},
+		{
+			desc:                "rotate keys with failed to retrieve things",
+			token:               validToken,
+			identifyResponse:    &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true, Id: validID},
+			listObjectsResponse: &magistrala.ListObjectsRes{Policies: []string{thing.ID}},
+			retrieveAllByIDsErr: repoerr.ErrViewEntity,
+			err:                 svcerr.ErrViewEntity,
+		},
+		{
+			desc:                "rotate keys with failed to update secrets",
+			token:               validToken,
+			identifyResponse:    &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true, Id: validID},
+			listObjectsResponse: &magistrala.ListObjectsRes{Policies: []string{thing.ID}},
+			retrieveAllByIDsResponse: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1, Limit: 10},
+				Clients: []mgclients.Client{thing},
+			},
+			updateSecretsErr: repoerr.ErrNotFound,
+			err:              svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                "rotate keys with failed to remove cached key",
+			token:               validToken,
+			identifyResponse:    &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:   &magistrala.AuthorizeRes{Authorized: true, Id: validID},
+			listObjectsResponse: &magistrala.ListObjectsRes{Policies: []string{thing.ID}},
+			retrieveAllByIDsResponse: mgclients.ClientsPage{
+				Page:    mgclients.Page{Total: 1, Limit: 10},
+				Clients: []mgclients.Client{thing},
+			},
+			removeErr: repoerr.ErrRemoveEntity,
+			total:     1,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, cRepo, auth, cache := newService()
+		auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+			return req.GetPermission() == authsvc.AdminPermission && req.GetObject() == groupID
+		})).Return(tc.authorizeResponse, tc.authorizeErr)
+		auth.On("ListAllObjects", mock.Anything, mock.Anything).Return(tc.listObjectsResponse, tc.listObjectsErr)
+		cRepo.On("RetrieveAllByIDs", context.Background(), mock.MatchedBy(func(pm mgclients.Page) bool {
+			return pm.Status == mgclients.AllStatus && pm.Offset == page.Offset && pm.Limit == page.Limit
+		})).Return(tc.retrieveAllByIDsResponse, tc.retrieveAllByIDsErr)
+		cRepo.On("UpdateSecrets", context.Background(), mock.MatchedBy(func(c mgclients.Client) bool {
+			return c.ID == thing.ID && c.Credentials.Secret != "" && c.Credentials.Secret != secret && c.UpdatedBy == validID
+		})).Return(tc.updateSecretsErr)
+		cache.On("Key", mock.Anything, thing.ID).Return(secret, nil)
+		cache.On("RemoveKey", mock.Anything, secret).Return(tc.removeErr)
+		cp, err := svc.RotateKeys(context.Background(), tc.token, groupID, page)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.total, cp.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, cp.Total))
+		if tc.err == nil && tc.total > 0 {
+			assert.NotEqual(t, secret, cp.Clients[0].Credentials.Secret, fmt.Sprintf("%s: expected new key\n", tc.desc))
+			cRepo.AssertCalled(t, "UpdateSecrets", context.Background(), mock.Anything)
+			cache.AssertCalled(t, "RemoveKey", mock.Anything, secret)
+			cache.AssertNotCalled(t, "Remove", mock.Anything, thing.ID)
+		}
+	}
+}
+
+func TestTransferChannel(t *testing.T) {
+	userID := testsutil.GenerateUUID(t)
+	sourceDomain := testsutil.GenerateUUID(t)
+	targetDomain := testsutil.GenerateUUID(t)
+	channel := mggroups.Group{
+		ID:     testsutil.GenerateUUID(t),
+		Domain: sourceDomain,
+		Parent: testsutil.GenerateUUID(t),
+		Name:   "channel",
+	}
+	thing := mgclients.Client{
+		ID:     testsutil.GenerateUUID(t),
+		Name:   "thing",
+		Domain: sourceDomain,
+		Status: mgclients.EnabledStatus,
+	}
+	identity := &magistrala.IdentityRes{Id: validID, UserId: userID, DomainId: sourceDomain}
+	authorized := &magistrala.AuthorizeRes{Authorized: true, Id: validID}
+	connected := &magistrala.ListObjectsRes{Policies: []string{thing.ID}}
+	onlyChannel := &magistrala.ListSubjectsRes{Policies: []string{channel.ID}}
+	connectedThings := mgclients.ClientsPage{Page: mgclients.Page{Total: 1}, Clients: []mgclients.Client{thing}}
+	scopedToken := mgclients.ScopedTokenPrefix + "things"
+
+	cases := []struct {
+		desc                string
+		token               string
+		domainID            string
+		identifyResponse    *magistrala.IdentityRes
+		targetAuthResponse  *magistrala.AuthorizeRes
+		retrieveByIDRes     mggroups.Group
+		childrenIDs         []string
+		listObjectsResponse *magistrala.ListObjectsRes
+		listSubjectsRes     *magistrala.ListSubjectsRes
+		retrieveThingsRes   mgclients.ClientsPage
+		channelNamesRes     mggroups.Page
+		thingNamesRes       mgclients.ClientsPage
+		identifyErr         error
+		transferErr         error
+		saveDomainErr       error
+		addPoliciesErr      error
+		deletePoliciesErr   error
+		err                 error
+	}{
+		{
+			desc:                "transfer channel successfully",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+		},
+		{
+			desc:        "transfer channel with invalid token",
+			token:       inValidToken,
+			domainID:    targetDomain,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "transfer channel with things:write scoped token",
+			token:    scopedToken,
+			domainID: targetDomain,
+			err:      svcerr.ErrTokenScope,
+		},
+		{
+			desc:             "transfer channel to the same domain",
+			token:            validToken,
+			domainID:         sourceDomain,
+			identifyResponse: identity,
+			err:              mggroups.ErrSameDomain,
+		},
+		{
+			desc:               "transfer channel without administering the target domain",
+			token:              validToken,
+			domainID:           targetDomain,
+			identifyResponse:   identity,
+			targetAuthResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:                svcerr.ErrAuthorization,
+		},
+		{
+			desc:               "transfer channel of another domain",
+			token:              validToken,
+			domainID:           targetDomain,
+			identifyResponse:   identity,
+			targetAuthResponse: authorized,
+			retrieveByIDRes:    mggroups.Group{ID: channel.ID, Domain: testsutil.GenerateUUID(t)},
+			err:                svcerr.ErrAuthorization,
+		},
+		{
+			desc:               "transfer channel with children",
+			token:              validToken,
+			domainID:           targetDomain,
+			identifyResponse:   identity,
+			targetAuthResponse: authorized,
+			retrieveByIDRes:    channel,
+			childrenIDs:        []string{testsutil.GenerateUUID(t)},
+			err:                mggroups.ErrGroupHasChildren,
+		},
+		{
+			desc:                "transfer channel with thing connected to other channels",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     &magistrala.ListSubjectsRes{Policies: []string{channel.ID, testsutil.GenerateUUID(t)}},
+			err:                 mggroups.ErrSharedThing,
+		},
+		{
+			desc:                "transfer channel with channel name taken in the target domain",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+			channelNamesRes:     mggroups.Page{PageMeta: mggroups.PageMeta{Total: 1}},
+			err:                 mggroups.ErrNameConflict,
+		},
+		{
+			desc:                "transfer channel with thing name taken in the target domain",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+			thingNamesRes:       mgclients.ClientsPage{Page: mgclients.Page{Total: 1}},
+			err:                 mggroups.ErrNameConflict,
+		},
+		{
+			desc:                "transfer channel with failed to update repository",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+			transferErr:         repoerr.ErrUpdateEntity,
+			err:                 svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                "transfer channel with failed to save domain to cache",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+			saveDomainErr:       repoerr.ErrCreateEntity,
+			err:                 svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                "transfer channel with failed to add policies",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+			addPoliciesErr:      svcerr.ErrAuthorization,
+			err:                 svcerr.ErrAddPolicies,
+		},
+		{
+			desc:                "transfer channel with failed to delete policies",
+			token:               validToken,
+			domainID:            targetDomain,
+			identifyResponse:    identity,
+			targetAuthResponse:  authorized,
+			retrieveByIDRes:     channel,
+			listObjectsResponse: connected,
+			listSubjectsRes:     onlyChannel,
+			retrieveThingsRes:   connectedThings,
+			deletePoliciesErr:   errRemovePolicies,
+			err:                 svcerr.ErrDeletePolicies,
+		},
+	}
+
+	for _, tc := range cases {
+		auth := new(authmocks.AuthClient)
+		cRepo := new(mocks.Repository)
+		gRepo := new(gmocks.Repository)
+		cache := new(mocks.Cache)
+		svc := things.NewService(auth, cRepo, gRepo, cache, new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+
+		auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		cRepo.On("RetrieveToken", context.Background(), mgclients.HashToken(scopedToken)).Return(mgclients.ScopedToken{
+			DomainID: sourceDomain,
+			UserID:   userID,
+			Subject:  validID,
+			Scopes:   []string{mgclients.ThingsReadScope, mgclients.ThingsWriteScope},
+		}, nil)
+		auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+			return req.GetObject() == sourceDomain
+		})).Return(authorized, nil)
+		auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+			return req.GetObject() == tc.domainID && req.GetSubject() == authsvc.EncodeDomainUserID(tc.domainID, userID)
+		})).Return(tc.targetAuthResponse, nil)
+		gRepo.On("RetrieveByID", context.Background(), channel.ID).Return(tc.retrieveByIDRes, nil)
+		gRepo.On("RetrieveChildrenIDs", context.Background(), channel.ID).Return(tc.childrenIDs, nil)
+		auth.On("ListAllObjects", mock.Anything, mock.Anything).Return(tc.listObjectsResponse, nil)
+		auth.On("ListAllSubjects", mock.Anything, mock.Anything).Return(tc.listSubjectsRes, nil)
+		cRepo.On("RetrieveAllByIDs", context.Background(), mock.Anything).Return(tc.retrieveThingsRes, nil)
+		gRepo.On("RetrieveAll", context.Background(), mock.Anything).Return(tc.channelNamesRes, nil)
+		cRepo.On("RetrieveAll", context.Background(), mock.Anything).Return(tc.thingNamesRes, nil)
+		cRepo.On("TransferChannel", context.Background(), mock.MatchedBy(func(g mggroups.Group) bool {
+			return g.Domain == targetDomain
+		}), mock.Anything).Return(tc.transferErr)
+		cRepo.On("TransferChannel", context.Background(), channel, thing).Return(nil)
+		cache.On("SaveDomain", context.Background(), thing.ID, targetDomain).Return(tc.saveDomainErr)
+		cache.On("SaveDomain", context.Background(), thing.ID, sourceDomain).Return(nil)
+		auth.On("AddPolicies", mock.Anything, mock.Anything).Return(&magistrala.AddPoliciesRes{Added: tc.addPoliciesErr == nil}, tc.addPoliciesErr)
+		auth.On("DeletePolicies", mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: tc.deletePoliciesErr == nil}, tc.deletePoliciesErr)
+		auth.On("DeletePolicyFilter", mock.Anything, mock.Anything).Return(&magistrala.DeletePolicyRes{Deleted: true}, nil)
+
+		ct, err := svc.TransferChannel(context.Background(), tc.token, channel.ID, tc.domainID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		switch tc.err {
+		case nil:
+			assert.Equal(t, targetDomain, ct.Channel.Domain, fmt.Sprintf("%s: expected channel domain %s got %s\n", tc.desc, targetDomain, ct.Channel.Domain))
+			assert.Empty(t, ct.Channel.Parent, fmt.Sprintf("%s: expected channel to be detached from its parent\n", tc.desc))
+			require.Len(t, ct.Things, 1, fmt.Sprintf("%s: expected one thing\n", tc.desc))
+			assert.Equal(t, targetDomain, ct.Things[0].Domain, fmt.Sprintf("%s: expected thing domain %s got %s\n", tc.desc, targetDomain, ct.Things[0].Domain))
+			auth.AssertNumberOfCalls(t, "DeletePolicyFilter", 2)
+			cache.AssertCalled(t, "SaveDomain", context.Background(), thing.ID, targetDomain)
+			cache.AssertNotCalled(t, "SaveDomain", context.Background(), thing.ID, sourceDomain)
+		case svcerr.ErrAddPolicies, svcerr.ErrDeletePolicies:
+			cRepo.AssertCalled(t, "TransferChannel", context.Background(), channel, thing)
+			cache.AssertCalled(t, "SaveDomain", context.Background(), thing.ID, sourceDomain)
+		}
+		if tc.saveDomainErr != nil {
+			cRepo.AssertCalled(t, "TransferChannel", context.Background(), channel, thing)
+			cache.AssertCalled(t, "SaveDomain", context.Background(), thing.ID, sourceDomain)
+		}
 	}
 }
 
 func TestEnableClient(t *testing.T) {
-	svc, cRepo, auth, _ := newService()
+	svc, cRepo, auth, cache := newService()
 
 	enabledClient1 := mgclients.Client{ID: ID, Credentials: mgclients.Credentials{Identity: "client1@example.com", Secret: "password"}, Status: mgclients.EnabledStatus}
 	disabledClient1 := mgclients.Client{ID: ID, Credentials: mgclients.Credentials{Identity: "client3@example.com", Secret: "password"}, Status: mgclients.DisabledStatus}
@@ -1068,6 +1733,7 @@ func TestEnableClient(t *testing.T) {
 		changeStatusErr      error
 		retrieveIDErr        error
 		authorizeErr         error
+		enableErr            error
 		err                  error
 	}{
 		{
@@ -1080,6 +1746,17 @@ func TestEnableClient(t *testing.T) {
 			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
 			err:                  nil,
 		},
+		{
+			desc:                 "enable disabled client with failed to clear its disabled mark in cache",
+			id:                   disabledClient1.ID,
+			token:                validToken,
+			client:               disabledClient1,
+			changeStatusResponse: endisabledClient1,
+			retrieveByIDResponse: disabledClient1,
+			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
+			enableErr:            repoerr.ErrRemoveEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
 		{
 			desc:                 "enable disabled client with failed to update repo",
 			id:                   disabledClient1.ID,
@@ -1130,11 +1807,13 @@ func TestEnableClient(t *testing.T) {
 		repoCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
 		repoCall1 := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveIDErr)
 		repoCall2 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
+		repoCall3 := cache.On("Enable", mock.Anything, mock.Anything).Return(tc.enableErr)
 		_, err := svc.EnableClient(context.Background(), tc.token, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
+		repoCall3.Unset()
 	}
 
 	cases2 := []struct {
@@ -1224,7 +1903,7 @@ func TestDisableClient(t *testing.T) {
 		changeStatusErr      error
 		retrieveIDErr        error
 		authorizeErr         error
-		removeErr            error
+		disableErr           error
 		err                  error
 	}{
 		{
@@ -1282,15 +1961,15 @@ func TestDisableClient(t *testing.T) {
 			err:                  svcerr.ErrAuthorization,
 		},
 		{
-			desc:                 "disable client with failed to remove from cache",
+			desc:                 "disable client with failed to mark it disabled in cache",
 			id:                   enabledClient1.ID,
 			token:                validToken,
 			client:               disabledClient1,
 			changeStatusResponse: disenabledClient1,
 			retrieveByIDResponse: enabledClient1,
 			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
-			removeErr:            svcerr.ErrRemoveEntity,
-			err:                  svcerr.ErrRemoveEntity,
+			disableErr:           repoerr.ErrCreateEntity,
+			err:                  svcerr.ErrUpdateEntity,
 		},
 	}
 
@@ -1298,7 +1977,7 @@ func TestDisableClient(t *testing.T) {
 		repoCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
 		repoCall1 := cRepo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveIDErr)
 		repoCall2 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
-		repoCall3 := cache.On("Remove", mock.Anything, mock.Anything).Return(tc.removeErr)
+		repoCall3 := cache.On("Disable", mock.Anything, mock.Anything).Return(tc.disableErr)
 		_, err := svc.DisableClient(context.Background(), tc.token, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
@@ -1594,7 +2273,11 @@ func TestListMembers(t *testing.T) {
 }
 
 func TestDeleteClient(t *testing.T) {
-	svc, cRepo, auth, cache := newService()
+	auth := new(authmocks.AuthClient)
+	cache := new(mocks.Cache)
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	svc := things.NewService(auth, cRepo, gRepo, cache, new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
 
 	client := mgclients.Client{
 		ID:   testsutil.GenerateUUID(t),
@@ -1620,6 +2303,11 @@ func TestDeleteClient(t *testing.T) {
 		removeErr            error
 		deleteErr            error
 		deletePolicyErr      error
+		listChannelsErr      error
+		countErr             error
+		unmodifiedSince      time.Time
+		retrieveResponse     mgclients.Client
+		retrieveErr          error
 		err                  error
 	}{
 		{
@@ -1629,8 +2317,37 @@ func TestDeleteClient(t *testing.T) {
 			identifyResponse:     &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
 			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
 			deletePolicyResponse: &magistrala.DeletePolicyRes{Deleted: true},
+			retrieveResponse:     client,
 			err:                  nil,
 		},
+		{
+			desc:              "Delete client with failed to retrieve client",
+			token:             validToken,
+			clientID:          client.ID,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr:       repoerr.ErrNotFound,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:              "Delete client with failed to list channels",
+			token:             validToken,
+			clientID:          client.ID,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			listChannelsErr:   svcerr.ErrAuthorization,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:                 "Delete client with failed to count channel things",
+			token:                validToken,
+			clientID:             client.ID,
+			identifyResponse:     &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
+			deletePolicyResponse: &magistrala.DeletePolicyRes{Deleted: true},
+			countErr:             svcerr.ErrAuthorization,
+			err:                  svcerr.ErrUpdateEntity,
+		},
 		{
 			desc:             "Delete client with unauthorized token",
 			token:            authmocks.InvalidValue,
@@ -1677,6 +2394,37 @@ func TestDeleteClient(t *testing.T) {
 			deletePolicyErr:      errRemovePolicies,
 			err:                  errRemovePolicies,
 		},
+		{
+			desc:                 "Delete client unmodified since given time",
+			token:                validToken,
+			clientID:             client.ID,
+			identifyResponse:     &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse:    &magistrala.AuthorizeRes{Authorized: true},
+			deletePolicyResponse: &magistrala.DeletePolicyRes{Deleted: true},
+			unmodifiedSince:      time.Now().Add(time.Hour),
+			retrieveResponse:     mgclients.Client{ID: client.ID, UpdatedAt: time.Now()},
+			err:                  nil,
+		},
+		{
+			desc:              "Delete client modified since given time",
+			token:             validToken,
+			clientID:          client.ID,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			unmodifiedSince:   time.Now().Add(-time.Hour),
+			retrieveResponse:  mgclients.Client{ID: client.ID, UpdatedAt: time.Now()},
+			err:               svcerr.ErrPreconditionFailed,
+		},
+		{
+			desc:              "Delete client with unmodified since and failed to retrieve client",
+			token:             validToken,
+			clientID:          client.ID,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			unmodifiedSince:   time.Now(),
+			retrieveErr:       repoerr.ErrNotFound,
+			err:               svcerr.ErrRemoveEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1688,13 +2436,29 @@ func TestDeleteClient(t *testing.T) {
 			Id:         tc.clientID,
 		}).Return(tc.deletePolicyResponse, tc.deletePolicyErr)
 		repoCall4 := cRepo.On("Delete", context.Background(), tc.clientID).Return(tc.deleteErr)
-		err := svc.DeleteClient(context.Background(), tc.token, tc.clientID)
+		repoCall5 := cRepo.On("RetrieveByID", context.Background(), tc.clientID).Return(tc.retrieveResponse, tc.retrieveErr)
+		repoCall6 := auth.On("ListAllSubjects", context.Background(), &magistrala.ListSubjectsReq{
+			SubjectType: authsvc.GroupType,
+			Permission:  authsvc.GroupRelation,
+			ObjectType:  authsvc.ThingType,
+			Object:      tc.clientID,
+		}).Return(&magistrala.ListSubjectsRes{Policies: []string{validID}}, tc.listChannelsErr)
+		repoCall7 := auth.On("CountObjects", context.Background(), mock.Anything).Return(&magistrala.CountObjectsRes{Count: 0}, tc.countErr)
+		repoCall8 := gRepo.On("UpdateThingCount", context.Background(), validID, uint64(0)).Return(nil)
+		deleted, err := svc.DeleteClient(context.Background(), tc.token, tc.clientID, tc.unmodifiedSince)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.retrieveResponse, deleted, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.retrieveResponse, deleted))
+		}
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
 		repoCall3.Unset()
 		repoCall4.Unset()
+		repoCall5.Unset()
+		repoCall6.Unset()
+		repoCall7.Unset()
+		repoCall8.Unset()
 	}
 }
 
@@ -1855,71 +2619,89 @@ func TestUnShare(t *testing.T) {
 }
 
 func TestViewClientPerms(t *testing.T) {
-	svc, _, auth, _ := newService()
+	svc, cRepo, auth, _ := newService()
 
 	validID := valid
+	domainID := testsutil.GenerateUUID(t)
 
 	cases := []struct {
-		desc              string
-		token             string
-		thingID           string
-		permissions       []string
-		identifyResponse  *magistrala.IdentityRes
-		authorizeResponse *magistrala.AuthorizeRes
-		listPermResponse  *magistrala.ListPermissionsRes
-		identifyErr       error
-		authorizeErr      error
-		listPermErr       error
-		err               error
+		desc             string
+		token            string
+		thingID          string
+		permissions      []string
+		identifyResponse *magistrala.IdentityRes
+		listPermResponse *magistrala.ListPermissionsRes
+		retrieveResponse mgclients.Client
+		identifyErr      error
+		listPermErr      error
+		retrieveErr      error
+		err              error
 	}{
 		{
-			desc:              "view client permissions successfully",
-			token:             validToken,
-			thingID:           validID,
-			permissions:       []string{"admin"},
-			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
-			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
-			listPermResponse:  &magistrala.ListPermissionsRes{Permissions: []string{"admin"}},
-			err:               nil,
+			desc:             "view client permissions successfully",
+			token:            validToken,
+			thingID:          validID,
+			permissions:      []string{"admin"},
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			listPermResponse: &magistrala.ListPermissionsRes{Permissions: []string{"admin"}},
+			err:              nil,
 		},
 		{
 			desc:             "view client permissions with invalid token",
 			token:            inValidToken,
 			thingID:          validID,
-			permissions:      []string{"admin"},
 			identifyResponse: &magistrala.IdentityRes{},
 			identifyErr:      svcerr.ErrAuthentication,
 			err:              svcerr.ErrAuthentication,
 		},
 		{
-			desc:              "view client permissions with invalid ID",
-			token:             validToken,
-			thingID:           inValidToken,
-			permissions:       []string{},
-			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
-			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
-			authorizeErr:      svcerr.ErrAuthorization,
-			err:               svcerr.ErrAuthorization,
+			desc:             "view client permissions without permissions on thing in domain",
+			token:            validToken,
+			thingID:          validID,
+			permissions:      []string{},
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			listPermResponse: &magistrala.ListPermissionsRes{},
+			retrieveResponse: mgclients.Client{ID: validID, Domain: domainID},
+			err:              nil,
 		},
 		{
-			desc:              "view permissions with failed retrieve list permissions response",
-			token:             validToken,
-			thingID:           validID,
-			permissions:       []string{},
-			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: testsutil.GenerateUUID(t)},
-			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
-			listPermResponse:  &magistrala.ListPermissionsRes{},
-			listPermErr:       svcerr.ErrAuthorization,
-			err:               svcerr.ErrAuthorization,
+			desc:             "view client permissions without permissions on thing in other domain",
+			token:            validToken,
+			thingID:          validID,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			listPermResponse: &magistrala.ListPermissionsRes{},
+			retrieveResponse: mgclients.Client{ID: validID, Domain: testsutil.GenerateUUID(t)},
+			err:              svcerr.ErrNotFound,
+		},
+		{
+			desc:             "view client permissions with invalid ID",
+			token:            validToken,
+			thingID:          inValidToken,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			listPermResponse: &magistrala.ListPermissionsRes{},
+			retrieveErr:      repoerr.ErrNotFound,
+			err:              svcerr.ErrNotFound,
+		},
+		{
+			desc:             "view permissions with failed retrieve list permissions response",
+			token:            validToken,
+			thingID:          validID,
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			listPermResponse: &magistrala.ListPermissionsRes{},
+			listPermErr:      svcerr.ErrAuthorization,
+			err:              svcerr.ErrAuthorization,
 		},
 	}
 
 	for _, tc := range cases {
 		repoCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
-		repoCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
-		repoCall2 := auth.On("ListPermissions", mock.Anything, mock.Anything).Return(tc.listPermResponse, tc.listPermErr)
-		_, err := svc.ViewClientPerms(context.Background(), tc.token, tc.thingID)
+		repoCall1 := auth.On("ListPermissions", mock.Anything, mock.Anything).Return(tc.listPermResponse, tc.listPermErr)
+		repoCall2 := cRepo.On("RetrieveByID", mock.Anything, tc.thingID).Return(tc.retrieveResponse, tc.retrieveErr)
+		permissions, err := svc.ViewClientPerms(context.Background(), tc.token, tc.thingID)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.permissions, permissions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.permissions, permissions))
+		}
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
@@ -1930,6 +2712,11 @@ func TestIdentify(t *testing.T) {
 	svc, cRepo, _, cache := newService()
 
 	valid := valid
+	expiredAt := time.Now().Add(-time.Hour)
+	expiredClient := client
+	expiredClient.Credentials.ExpiresAt = &expiredAt
+	disabledClient := client
+	disabledClient.Status = mgclients.DisabledStatus
 
 	cases := []struct {
 		desc                string
@@ -1939,6 +2726,7 @@ func TestIdentify(t *testing.T) {
 		repoIDResponse      mgclients.Client
 		retrieveBySecretErr error
 		saveErr             error
+		disableErr          error
 		err                 error
 	}{
 		{
@@ -1973,121 +2761,1933 @@ func TestIdentify(t *testing.T) {
 			saveErr:         errors.ErrMalformedEntity,
 			err:             svcerr.ErrAuthorization,
 		},
+		{
+			desc:            "identify client with expired key",
+			key:             valid,
+			cacheIDResponse: "",
+			cacheIDErr:      repoerr.ErrNotFound,
+			repoIDResponse:  expiredClient,
+			err:             svcerr.ErrKeyExpired,
+		},
+		{
+			desc:            "identify disabled client from cache",
+			key:             valid,
+			cacheIDResponse: "",
+			cacheIDErr:      svcerr.ErrThingDisabled,
+			err:             svcerr.ErrThingDisabled,
+		},
+		{
+			desc:            "identify disabled client from repo",
+			key:             valid,
+			cacheIDResponse: "",
+			cacheIDErr:      repoerr.ErrNotFound,
+			repoIDResponse:  disabledClient,
+			err:             svcerr.ErrThingDisabled,
+		},
+		{
+			desc:            "identify disabled client from repo with failed to mark it disabled in cache",
+			key:             valid,
+			cacheIDResponse: "",
+			cacheIDErr:      repoerr.ErrNotFound,
+			repoIDResponse:  disabledClient,
+			disableErr:      repoerr.ErrCreateEntity,
+			err:             svcerr.ErrAuthorization,
+		},
 	}
 
 	for _, tc := range cases {
 		repoCall := cache.On("ID", mock.Anything, tc.key).Return(tc.cacheIDResponse, tc.cacheIDErr)
 		repoCall1 := cRepo.On("RetrieveBySecret", mock.Anything, mock.Anything).Return(tc.repoIDResponse, tc.retrieveBySecretErr)
-		repoCall2 := cache.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(tc.saveErr)
+		repoCall2 := cache.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.saveErr)
+		repoCall3 := cache.On("Disable", mock.Anything, mock.Anything).Return(tc.disableErr)
 		_, err := svc.Identify(context.Background(), tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
+		repoCall3.Unset()
 	}
 }
 
-func TestAuthorize(t *testing.T) {
-	svc, cRepo, auth, cache := newService()
+func TestHeartbeat(t *testing.T) {
+	cases := []struct {
+		desc          string
+		key           string
+		id            string
+		identifyErr   error
+		heartbeat     bool
+		heartbeatErr  error
+		seenErr       error
+		retrieveErr   error
+		saveDomainErr error
+		err           error
+	}{
+		{
+			desc:      "heartbeat with valid key",
+			key:       valid,
+			id:        client.ID,
+			heartbeat: true,
+		},
+		{
+			desc:        "heartbeat with invalid key",
+			key:         invalid,
+			id:          client.ID,
+			identifyErr: svcerr.ErrThingDisabled,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc: "heartbeat with key of other thing",
+			key:  valid,
+			id:   testsutil.GenerateUUID(t),
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "heartbeat sent too often",
+			key:       valid,
+			id:        client.ID,
+			heartbeat: false,
+			err:       svcerr.ErrRateLimited,
+		},
+		{
+			desc:         "heartbeat with failed to check the interval",
+			key:          valid,
+			id:           client.ID,
+			heartbeatErr: repoerr.ErrCreateEntity,
+			err:          svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "heartbeat with failed to record activity",
+			key:       valid,
+			id:        client.ID,
+			heartbeat: true,
+			seenErr:   repoerr.ErrCreateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "heartbeat of thing with unknown domain",
+			key:       valid,
+			id:        client.ID,
+			heartbeat: true,
+			seenErr:   repoerr.ErrNotFound,
+		},
+		{
+			desc:        "heartbeat of thing with unknown domain with failed to retrieve thing",
+			key:         valid,
+			id:          client.ID,
+			heartbeat:   true,
+			seenErr:     repoerr.ErrNotFound,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:          "heartbeat of thing with unknown domain with failed to save domain",
+			key:           valid,
+			id:            client.ID,
+			heartbeat:     true,
+			seenErr:       repoerr.ErrNotFound,
+			saveDomainErr: repoerr.ErrCreateEntity,
+			err:           svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, cRepo, _, cache := newService()
+			cache.On("ID", mock.Anything, tc.key).Return(client.ID, tc.identifyErr)
+			cache.On("Heartbeat", mock.Anything, client.ID).Return(tc.heartbeat, tc.heartbeatErr)
+			cache.On("Seen", mock.Anything, client.ID).Return(tc.seenErr).Once()
+			cache.On("Seen", mock.Anything, client.ID).Return(nil)
+			cRepo.On("RetrieveByID", mock.Anything, client.ID).Return(client, tc.retrieveErr)
+			cache.On("SaveDomain", mock.Anything, client.ID, client.Domain).Return(tc.saveDomainErr)
+			err := svc.Heartbeat(context.Background(), tc.key, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	svc, cRepo, _, cache := newService()
+
+	expiresAt := time.Now().Add(time.Hour).Round(time.Second)
+	expiringClient := client
+	expiringClient.Credentials.ExpiresAt = &expiresAt
+	expiredAt := time.Now().Add(-time.Hour)
+	expiredClient := client
+	expiredClient.Credentials.ExpiresAt = &expiredAt
+	disabledClient := client
+	disabledClient.Status = mgclients.DisabledStatus
 
 	cases := []struct {
 		desc                string
-		request             *magistrala.AuthorizeReq
-		cacheIDRes          string
+		key                 string
+		cacheIDResponse     string
 		cacheIDErr          error
-		retrieveBySecretRes mgclients.Client
+		expiryResponse      time.Time
+		expiryErr           error
+		repoIDResponse      mgclients.Client
 		retrieveBySecretErr error
-		cacheSaveErr        error
-		authorizeRes        *magistrala.AuthorizeRes
-		authErr             error
-		id                  string
+		saveErr             error
+		res                 things.KeyValidation
 		err                 error
 	}{
 		{
-			desc:                "authorize client with valid key not in cache",
-			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
-			cacheIDRes:          "",
-			cacheIDErr:          repoerr.ErrNotFound,
-			retrieveBySecretRes: mgclients.Client{ID: valid},
-			retrieveBySecretErr: nil,
-			cacheSaveErr:        nil,
-			authorizeRes:        &magistrala.AuthorizeRes{Authorized: true},
-			authErr:             nil,
-			id:                  valid,
-			err:                 nil,
+			desc:            "validate valid key from cache",
+			key:             valid,
+			cacheIDResponse: client.ID,
+			res:             things.KeyValidation{Valid: true, ThingID: client.ID},
 		},
 		{
-			desc:         "authorize client with valid key in cache",
-			request:      &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
-			cacheIDRes:   valid,
-			authorizeRes: &magistrala.AuthorizeRes{Authorized: true},
-			id:           valid,
+			desc:            "validate expiring key from cache",
+			key:             valid,
+			cacheIDResponse: client.ID,
+			expiryResponse:  expiresAt,
+			res:             things.KeyValidation{Valid: true, ThingID: client.ID, ExpiresAt: &expiresAt},
 		},
 		{
-			desc:                "authorize client with invalid key not in cache for non existing client",
-			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
-			cacheIDRes:          "",
+			desc:            "validate key from cache with failed to retrieve expiry",
+			key:             valid,
+			cacheIDResponse: client.ID,
+			expiryErr:       repoerr.ErrViewEntity,
+			err:             svcerr.ErrServiceUnavailable,
+		},
+		{
+			desc:           "validate valid key from repo",
+			key:            valid,
+			cacheIDErr:     repoerr.ErrNotFound,
+			repoIDResponse: client,
+			res:            things.KeyValidation{Valid: true, ThingID: client.ID},
+		},
+		{
+			desc:           "validate expiring key from repo",
+			key:            valid,
+			cacheIDErr:     repoerr.ErrNotFound,
+			repoIDResponse: expiringClient,
+			res:            things.KeyValidation{Valid: true, ThingID: client.ID, ExpiresAt: &expiresAt},
+		},
+		{
+			desc:                "validate unknown key",
+			key:                 invalid,
 			cacheIDErr:          repoerr.ErrNotFound,
-			retrieveBySecretRes: mgclients.Client{},
 			retrieveBySecretErr: repoerr.ErrNotFound,
-			err:                 repoerr.ErrNotFound,
+			res:                 things.KeyValidation{},
 		},
 		{
-			desc:                "authorize client with valid key not in cache with failed to save to cache",
-			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
-			cacheIDRes:          "",
+			desc:                "validate key with failed to retrieve from repo",
+			key:                 valid,
 			cacheIDErr:          repoerr.ErrNotFound,
-			retrieveBySecretRes: mgclients.Client{ID: valid},
-			cacheSaveErr:        errors.ErrMalformedEntity,
-			err:                 svcerr.ErrAuthorization,
+			retrieveBySecretErr: repoerr.ErrViewEntity,
+			err:                 svcerr.ErrServiceUnavailable,
 		},
 		{
-			desc:                "authorize client with valid key not in cache and failed to authorize",
-			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
-			cacheIDRes:          "",
-			cacheIDErr:          repoerr.ErrNotFound,
-			retrieveBySecretRes: mgclients.Client{ID: valid},
-			retrieveBySecretErr: nil,
-			cacheSaveErr:        nil,
-			authorizeRes:        &magistrala.AuthorizeRes{},
-			authErr:             svcerr.ErrAuthorization,
-			err:                 svcerr.ErrAuthorization,
+			desc:           "validate expired key",
+			key:            valid,
+			cacheIDErr:     repoerr.ErrNotFound,
+			repoIDResponse: expiredClient,
+			res:            things.KeyValidation{},
 		},
 		{
-			desc:                "authorize client with valid key not in cache and not authorize",
-			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
-			cacheIDRes:          "",
-			cacheIDErr:          repoerr.ErrNotFound,
-			retrieveBySecretRes: mgclients.Client{ID: valid},
-			retrieveBySecretErr: nil,
-			cacheSaveErr:        nil,
-			authorizeRes:        &magistrala.AuthorizeRes{Authorized: false},
-			authErr:             nil,
-			err:                 svcerr.ErrAuthorization,
+			desc:       "validate key of disabled client from cache",
+			key:        valid,
+			cacheIDErr: svcerr.ErrThingDisabled,
+			res:        things.KeyValidation{},
+		},
+		{
+			desc:           "validate key of disabled client from repo",
+			key:            valid,
+			cacheIDErr:     repoerr.ErrNotFound,
+			repoIDResponse: disabledClient,
+			res:            things.KeyValidation{},
+		},
+		{
+			desc:           "validate key with failed to save to cache",
+			key:            valid,
+			cacheIDErr:     repoerr.ErrNotFound,
+			repoIDResponse: client,
+			saveErr:        repoerr.ErrCreateEntity,
+			err:            svcerr.ErrServiceUnavailable,
 		},
 	}
 
 	for _, tc := range cases {
-		cacheCall := cache.On("ID", context.Background(), tc.request.GetSubject()).Return(tc.cacheIDRes, tc.cacheIDErr)
-		repoCall := cRepo.On("RetrieveBySecret", context.Background(), tc.request.GetSubject()).Return(tc.retrieveBySecretRes, tc.retrieveBySecretErr)
-		cacheCall1 := cache.On("Save", context.Background(), tc.request.GetSubject(), tc.retrieveBySecretRes.ID).Return(tc.cacheSaveErr)
-		authCall := auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeRes, tc.authErr)
-		id, err := svc.Authorize(context.Background(), tc.request)
+		cacheCall := cache.On("ID", mock.Anything, tc.key).Return(tc.cacheIDResponse, tc.cacheIDErr)
+		cacheCall1 := cache.On("Expiry", mock.Anything, tc.key).Return(tc.expiryResponse, tc.expiryErr)
+		repoCall := cRepo.On("RetrieveBySecret", mock.Anything, tc.key).Return(tc.repoIDResponse, tc.retrieveBySecretErr)
+		cacheCall2 := cache.On("Save", mock.Anything, tc.key, mock.Anything, mock.Anything).Return(tc.saveErr)
+		cacheCall3 := cache.On("Disable", mock.Anything, mock.Anything).Return(nil)
+		res, err := svc.ValidateKey(context.Background(), tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		if tc.err == nil {
-			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
-		}
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
 		cacheCall.Unset()
 		cacheCall1.Unset()
 		repoCall.Unset()
-		authCall.Unset()
+		cacheCall2.Unset()
+		cacheCall3.Unset()
 	}
+	cache.AssertNotCalled(t, "Seen", mock.Anything, mock.Anything)
+	cache.AssertNotCalled(t, "Heartbeat", mock.Anything, mock.Anything)
 }
 
-func getIDs(clients []mgclients.Client) []string {
-	ids := []string{}
-	for _, client := range clients {
-		ids = append(ids, client.ID)
-	}
-	return ids
+func TestIdentifyCert(t *testing.T) {
+	svc, cRepo, _, cache := newService()
+
+	fingerprint := strings.Repeat("ab", 32)
+	subject := "CN=sensor-1"
+	cert := mgclients.CertBinding{Fingerprint: fingerprint, ThingID: client.ID, Subject: subject}
+	anySubject := mgclients.CertBinding{Fingerprint: fingerprint, ThingID: client.ID}
+
+	cases := []struct {
+		desc              string
+		fingerprint       string
+		subject           string
+		cacheCertResponse mgclients.CertBinding
+		cacheCertErr      error
+		repoCertResponse  mgclients.CertBinding
+		repoStatus        mgclients.Status
+		retrieveByCertErr error
+		saveErr           error
+		disableErr        error
+		id                string
+		err               error
+	}{
+		{
+			desc:              "identify client with known cert from cache",
+			fingerprint:       fingerprint,
+			subject:           subject,
+			cacheCertResponse: cert,
+			id:                client.ID,
+			err:               nil,
+		},
+		{
+			desc:             "identify client with known cert from repo",
+			fingerprint:      strings.ToUpper(fingerprint),
+			subject:          subject,
+			cacheCertErr:     repoerr.ErrNotFound,
+			repoCertResponse: cert,
+			id:               client.ID,
+			err:              nil,
+		},
+		{
+			desc:              "identify client with cert bound without subject",
+			fingerprint:       fingerprint,
+			subject:           "CN=sensor-2",
+			cacheCertResponse: anySubject,
+			id:                client.ID,
+			err:               nil,
+		},
+		{
+			desc:              "identify client with other subject from cache",
+			fingerprint:       fingerprint,
+			subject:           "CN=sensor-2",
+			cacheCertResponse: cert,
+			err:               things.ErrCertSubject,
+		},
+		{
+			desc:             "identify client with other subject from repo",
+			fingerprint:      fingerprint,
+			subject:          "CN=sensor-2",
+			cacheCertErr:     repoerr.ErrNotFound,
+			repoCertResponse: cert,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "identify client with unknown cert",
+			fingerprint:       fingerprint,
+			subject:           subject,
+			cacheCertErr:      repoerr.ErrNotFound,
+			retrieveByCertErr: repoerr.ErrNotFound,
+			err:               svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "identify client with invalid fingerprint",
+			fingerprint: invalid,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "identify client with failed to save to cache",
+			fingerprint:      fingerprint,
+			subject:          subject,
+			cacheCertErr:     repoerr.ErrNotFound,
+			repoCertResponse: cert,
+			saveErr:          repoerr.ErrCreateEntity,
+			err:              svcerr.ErrAuthorization,
+		},
+		{
+			desc:         "identify disabled client from cache",
+			fingerprint:  fingerprint,
+			subject:      subject,
+			cacheCertErr: svcerr.ErrThingDisabled,
+			err:          svcerr.ErrThingDisabled,
+		},
+		{
+			desc:             "identify disabled client from repo",
+			fingerprint:      fingerprint,
+			subject:          subject,
+			cacheCertErr:     repoerr.ErrNotFound,
+			repoCertResponse: cert,
+			repoStatus:       mgclients.DisabledStatus,
+			err:              svcerr.ErrThingDisabled,
+		},
+		{
+			desc:             "identify disabled client from repo with failed to mark it disabled in cache",
+			fingerprint:      fingerprint,
+			subject:          subject,
+			cacheCertErr:     repoerr.ErrNotFound,
+			repoCertResponse: cert,
+			repoStatus:       mgclients.DisabledStatus,
+			disableErr:       repoerr.ErrCreateEntity,
+			err:              svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cache.On("Cert", mock.Anything, fingerprint).Return(tc.cacheCertResponse, tc.cacheCertErr)
+		repoCall1 := cRepo.On("RetrieveByCert", mock.Anything, fingerprint).Return(tc.repoCertResponse, tc.repoStatus, tc.retrieveByCertErr)
+		repoCall2 := cache.On("SaveCert", mock.Anything, tc.repoCertResponse).Return(tc.saveErr)
+		repoCall3 := cache.On("Disable", mock.Anything, mock.Anything).Return(tc.disableErr)
+		id, err := svc.IdentifyCert(context.Background(), tc.fingerprint, tc.subject)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestBindCert(t *testing.T) {
+	svc, cRepo, auth, _ := newService()
+
+	cert := mgclients.CertBinding{
+		Fingerprint: strings.TrimSuffix(strings.Repeat("AB:", 32), ":"),
+		ThingID:     client.ID,
+		Subject:     "CN=sensor-1",
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		cert              mgclients.CertBinding
+		authorizeResponse *magistrala.AuthorizeRes
+		authorizeErr      error
+		saveErr           error
+		err               error
+	}{
+		{
+			desc:              "bind cert successfully",
+			token:             validToken,
+			cert:              cert,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               nil,
+		},
+		{
+			desc:              "bind cert with unauthorized user",
+			token:             validToken,
+			cert:              cert,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "bind cert with invalid fingerprint",
+			token:             validToken,
+			cert:              mgclients.CertBinding{Fingerprint: invalid, ThingID: client.ID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:              "bind cert already bound",
+			token:             validToken,
+			cert:              cert,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:           repoerr.ErrConflict,
+			err:               svcerr.ErrConflict,
+		},
+		{
+			desc:              "bind cert with failed to save cert",
+			token:             validToken,
+			cert:              cert,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:           repoerr.ErrCreateEntity,
+			err:               svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		repoCall := cRepo.On("SaveCert", mock.Anything, mock.Anything).Return(tc.saveErr)
+		cb, err := svc.BindCert(context.Background(), tc.token, tc.cert)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, strings.Repeat("ab", 32), cb.Fingerprint, fmt.Sprintf("%s: expected normalized fingerprint got %s\n", tc.desc, cb.Fingerprint))
+			assert.Equal(t, tc.cert.ThingID, cb.ThingID, fmt.Sprintf("%s: expected thing %s got %s\n", tc.desc, tc.cert.ThingID, cb.ThingID))
+		}
+		authCall.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestUnbindCert(t *testing.T) {
+	svc, cRepo, auth, cache := newService()
+
+	fingerprint := strings.Repeat("ab", 32)
+
+	cases := []struct {
+		desc              string
+		fingerprint       string
+		authorizeResponse *magistrala.AuthorizeRes
+		authorizeErr      error
+		removeErr         error
+		cacheRemoveErr    error
+		err               error
+	}{
+		{
+			desc:              "unbind cert successfully",
+			fingerprint:       strings.ToUpper(fingerprint),
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               nil,
+		},
+		{
+			desc:              "unbind cert with unauthorized user",
+			fingerprint:       fingerprint,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "unbind cert with invalid fingerprint",
+			fingerprint:       invalid,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			err:               svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:              "unbind cert not bound to the thing",
+			fingerprint:       fingerprint,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			removeErr:         repoerr.ErrNotFound,
+			err:               svcerr.ErrNotFound,
+		},
+		{
+			desc:              "unbind cert with failed to remove cert",
+			fingerprint:       fingerprint,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			removeErr:         repoerr.ErrRemoveEntity,
+			err:               svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:              "unbind cert with failed to remove cert from cache",
+			fingerprint:       fingerprint,
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			cacheRemoveErr:    repoerr.ErrRemoveEntity,
+			err:               svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		repoCall := cRepo.On("RemoveCert", mock.Anything, client.ID, fingerprint).Return(tc.removeErr)
+		repoCall1 := cache.On("RemoveCert", mock.Anything, fingerprint, client.ID).Return(tc.cacheRemoveErr)
+		err := svc.UnbindCert(context.Background(), validToken, client.ID, tc.fingerprint)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authCall.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	svc, cRepo, auth, cache := newService()
+
+	cases := []struct {
+		desc                string
+		request             *magistrala.AuthorizeReq
+		cacheIDRes          string
+		cacheIDErr          error
+		retrieveBySecretRes mgclients.Client
+		retrieveBySecretErr error
+		cacheSaveErr        error
+		cacheSeenErr        error
+		authorizeRes        *magistrala.AuthorizeRes
+		authErr             error
+		id                  string
+		err                 error
+	}{
+		{
+			desc:                "authorize client with valid key not in cache",
+			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:          "",
+			cacheIDErr:          repoerr.ErrNotFound,
+			retrieveBySecretRes: mgclients.Client{ID: valid},
+			retrieveBySecretErr: nil,
+			cacheSaveErr:        nil,
+			authorizeRes:        &magistrala.AuthorizeRes{Authorized: true},
+			authErr:             nil,
+			id:                  valid,
+			err:                 nil,
+		},
+		{
+			desc:         "authorize client with valid key in cache",
+			request:      &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:   valid,
+			authorizeRes: &magistrala.AuthorizeRes{Authorized: true},
+			id:           valid,
+		},
+		{
+			desc:         "authorize client with valid key in cache and failed to record activity",
+			request:      &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:   valid,
+			cacheSeenErr: repoerr.ErrCreateEntity,
+			authorizeRes: &magistrala.AuthorizeRes{Authorized: true},
+			id:           valid,
+		},
+		{
+			desc:                "authorize client with invalid key not in cache for non existing client",
+			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:          "",
+			cacheIDErr:          repoerr.ErrNotFound,
+			retrieveBySecretRes: mgclients.Client{},
+			retrieveBySecretErr: repoerr.ErrNotFound,
+			err:                 repoerr.ErrNotFound,
+		},
+		{
+			desc:                "authorize client with valid key not in cache with failed to save to cache",
+			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:          "",
+			cacheIDErr:          repoerr.ErrNotFound,
+			retrieveBySecretRes: mgclients.Client{ID: valid},
+			cacheSaveErr:        errors.ErrMalformedEntity,
+			err:                 svcerr.ErrAuthorization,
+		},
+		{
+			desc:                "authorize client with valid key not in cache and failed to authorize",
+			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:          "",
+			cacheIDErr:          repoerr.ErrNotFound,
+			retrieveBySecretRes: mgclients.Client{ID: valid},
+			retrieveBySecretErr: nil,
+			cacheSaveErr:        nil,
+			authorizeRes:        &magistrala.AuthorizeRes{},
+			authErr:             svcerr.ErrAuthorization,
+			err:                 svcerr.ErrAuthorization,
+		},
+		{
+			desc:                "authorize client with valid key not in cache and not authorize",
+			request:             &magistrala.AuthorizeReq{Subject: valid, Object: valid, Permission: "admin"},
+			cacheIDRes:          "",
+			cacheIDErr:          repoerr.ErrNotFound,
+			retrieveBySecretRes: mgclients.Client{ID: valid},
+			retrieveBySecretErr: nil,
+			cacheSaveErr:        nil,
+			authorizeRes:        &magistrala.AuthorizeRes{Authorized: false},
+			authErr:             nil,
+			err:                 svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		cacheCall := cache.On("ID", context.Background(), tc.request.GetSubject()).Return(tc.cacheIDRes, tc.cacheIDErr)
+		repoCall := cRepo.On("RetrieveBySecret", context.Background(), tc.request.GetSubject()).Return(tc.retrieveBySecretRes, tc.retrieveBySecretErr)
+		cacheCall1 := cache.On("Save", context.Background(), tc.request.GetSubject(), tc.retrieveBySecretRes.ID, time.Time{}).Return(tc.cacheSaveErr)
+		authCall := auth.On("Authorize", context.Background(), mock.Anything).Return(tc.authorizeRes, tc.authErr)
+		cacheCall2 := cache.On("Seen", context.Background(), tc.id).Return(tc.cacheSeenErr)
+		id, err := svc.Authorize(context.Background(), tc.request)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+		}
+		cacheCall.Unset()
+		cacheCall1.Unset()
+		cacheCall2.Unset()
+		repoCall.Unset()
+		authCall.Unset()
+	}
+}
+
+func getIDs(clients []mgclients.Client) []string {
+	ids := []string{}
+	for _, client := range clients {
+		ids = append(ids, client.ID)
+	}
+	return ids
+}
+
+func TestWatchThings(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc              string
+		token             string
+		domainID          string
+		lastSeq           uint64
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		authorizeErr      error
+		watchErr          error
+		err               error
+	}{
+		{
+			desc:              "watch things successfully",
+			token:             validToken,
+			domainID:          domainID,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:              "watch things of the token domain successfully",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:             "watch things with invalid token",
+			token:            inValidToken,
+			domainID:         domainID,
+			identifyResponse: &magistrala.IdentityRes{},
+			identifyErr:      svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "watch things of another domain",
+			token:            validToken,
+			domainID:         testsutil.GenerateUUID(t),
+			identifyResponse: &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			err:              svcerr.ErrDomainAuthorization,
+		},
+		{
+			desc:              "watch things with failed authorization",
+			token:             validToken,
+			domainID:          domainID,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "watch things with expired sequence",
+			token:             validToken,
+			domainID:          domainID,
+			lastSeq:           10,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			watchErr:          things.ErrSeqExpired,
+			err:               things.ErrSeqExpired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			watcher := new(mocks.Watcher)
+			svc := things.NewService(auth, new(mocks.Repository), new(gmocks.Repository), new(mocks.Cache), watcher, uuid.NewMock(), mgclients.KeyPolicy{})
+
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+			auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+				SubjectType: authsvc.UserType,
+				SubjectKind: authsvc.UsersKind,
+				Subject:     validID,
+				Permission:  authsvc.MembershipPermission,
+				ObjectType:  authsvc.DomainType,
+				Object:      domainID,
+			}).Return(tc.authorizeResponse, tc.authorizeErr)
+			watcher.On("Watch", mock.Anything, domainID, tc.lastSeq).Return(make(<-chan things.ThingEvent), tc.watchErr)
+			_, err := svc.WatchThings(context.Background(), tc.token, tc.domainID, tc.lastSeq)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestRetrieveMetadata(t *testing.T) {
+	metadata := mgclients.Metadata{"forward": map[string]interface{}{"url": "https://example.com"}}
+	thing := mgclients.Client{ID: testsutil.GenerateUUID(t), Metadata: metadata, Status: mgclients.EnabledStatus}
+	channel := mggroups.Group{ID: testsutil.GenerateUUID(t), Metadata: metadata, Status: mgclients.DisabledStatus}
+
+	cases := []struct {
+		desc        string
+		entityType  string
+		id          string
+		thing       mgclients.Client
+		channel     mggroups.Group
+		retrieveErr error
+		metadata    mgclients.Metadata
+		status      mgclients.Status
+		err         error
+	}{
+		{
+			desc:       "retrieve thing metadata",
+			entityType: authsvc.ThingType,
+			id:         thing.ID,
+			thing:      thing,
+			metadata:   metadata,
+			status:     mgclients.EnabledStatus,
+		},
+		{
+			desc:       "retrieve channel metadata",
+			entityType: authsvc.GroupType,
+			id:         channel.ID,
+			channel:    channel,
+			metadata:   metadata,
+			status:     mgclients.DisabledStatus,
+		},
+		{
+			desc:        "retrieve metadata of unknown thing",
+			entityType:  authsvc.ThingType,
+			id:          wrongID,
+			retrieveErr: repoerr.ErrNotFound,
+			status:      mgclients.DisabledStatus,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:        "retrieve metadata of unknown channel",
+			entityType:  authsvc.GroupType,
+			id:          wrongID,
+			retrieveErr: repoerr.ErrNotFound,
+			status:      mgclients.DisabledStatus,
+			err:         repoerr.ErrNotFound,
+		},
+		{
+			desc:       "retrieve metadata of invalid entity type",
+			entityType: authsvc.UserType,
+			id:         thing.ID,
+			status:     mgclients.DisabledStatus,
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cRepo := new(mocks.Repository)
+			gRepo := new(gmocks.Repository)
+			svc := things.NewService(new(authmocks.AuthClient), cRepo, gRepo, new(mocks.Cache), new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+
+			cRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.thing, tc.retrieveErr)
+			gRepo.On("RetrieveByID", context.Background(), tc.id).Return(tc.channel, tc.retrieveErr)
+			md, status, err := svc.RetrieveMetadata(context.Background(), tc.entityType, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.metadata, md, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.metadata, md))
+			assert.Equal(t, tc.status, status, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.status, status))
+		})
+	}
+}
+
+func TestListClientsByConnection(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	ids := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	other := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc       string
+		connection string
+		superAdmin bool
+		online     []string
+		onlineErr  error
+		retrieve   bool
+		ids        []string
+		excludeIDs []string
+		err        error
+	}{
+		{
+			desc:       "list online things",
+			connection: things.OnlineConnection,
+			online:     []string{ids[1], other},
+			retrieve:   true,
+			ids:        []string{ids[1]},
+		},
+		{
+			desc:       "list online things with no thing online",
+			connection: things.OnlineConnection,
+			online:     []string{other},
+		},
+		{
+			desc:       "list offline things",
+			connection: things.OfflineConnection,
+			online:     []string{ids[1], other},
+			retrieve:   true,
+			ids:        ids,
+			excludeIDs: []string{ids[1], other},
+		},
+		{
+			desc:       "list online things as super admin",
+			connection: things.OnlineConnection,
+			superAdmin: true,
+			online:     []string{ids[1], other},
+			retrieve:   true,
+			ids:        []string{ids[1], other},
+		},
+		{
+			desc:       "list online things as super admin with no thing online",
+			connection: things.OnlineConnection,
+			superAdmin: true,
+		},
+		{
+			desc:       "list offline things as super admin",
+			connection: things.OfflineConnection,
+			superAdmin: true,
+			online:     []string{ids[1]},
+			retrieve:   true,
+			excludeIDs: []string{ids[1]},
+		},
+		{
+			desc:       "list online things with failed online retrieval",
+			connection: things.OnlineConnection,
+			onlineErr:  repoerr.ErrViewEntity,
+			err:        svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "list online things with too many things online",
+			connection: things.OnlineConnection,
+			online:     make([]string, 10001),
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, cRepo, auth, cache := newService()
+
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, nil)
+			auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetObjectType() == authsvc.PlatformType
+			})).Return(&magistrala.AuthorizeRes{Authorized: tc.superAdmin}, nil)
+			auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetObjectType() == authsvc.DomainType
+			})).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			auth.On("ListAllObjects", mock.Anything, mock.Anything).Return(&magistrala.ListObjectsRes{Policies: ids}, nil)
+			cache.On("Online", mock.Anything, domainID, int64(10001)).Return(tc.online, tc.onlineErr)
+			cRepo.On("RetrieveAllByIDs", mock.Anything, mock.Anything).Return(mgclients.ClientsPage{}, nil)
+
+			pm := mgclients.Page{Limit: 10, Connection: tc.connection}
+			_, err := svc.ListClients(context.Background(), validToken, "", pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if !tc.retrieve {
+				cRepo.AssertNotCalled(t, "RetrieveAllByIDs", mock.Anything, mock.Anything)
+				return
+			}
+			page := cRepo.Calls[0].Arguments.Get(1).(mgclients.Page)
+			assert.ElementsMatch(t, tc.ids, page.IDs, fmt.Sprintf("%s: expected IDs %v got %v\n", tc.desc, tc.ids, page.IDs))
+			assert.ElementsMatch(t, tc.excludeIDs, page.ExcludeIDs, fmt.Sprintf("%s: expected excluded IDs %v got %v\n", tc.desc, tc.excludeIDs, page.ExcludeIDs))
+		})
+	}
+}
+
+func TestListClientsWait(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	since := time.Now().Add(-time.Hour)
+	changed := mgclients.ClientsPage{
+		Page:    mgclients.Page{Total: 1, Limit: 10},
+		Clients: []mgclients.Client{client},
+	}
+
+	cases := []struct {
+		desc      string
+		wait      time.Duration
+		pages     []mgclients.ClientsPage
+		change    bool
+		cancel    bool
+		watchErr  error
+		response  mgclients.ClientsPage
+		retrieved int
+		err       error
+	}{
+		{
+			desc:      "list changed things without waiting",
+			wait:      time.Minute,
+			pages:     []mgclients.ClientsPage{changed},
+			response:  changed,
+			retrieved: 1,
+		},
+		{
+			desc:      "list things changed while waiting",
+			wait:      time.Minute,
+			pages:     []mgclients.ClientsPage{{}, changed},
+			change:    true,
+			response:  changed,
+			retrieved: 2,
+		},
+		{
+			desc:      "list unchanged things until the wait elapses",
+			wait:      10 * time.Millisecond,
+			pages:     []mgclients.ClientsPage{{}},
+			retrieved: 1,
+		},
+		{
+			desc:      "list unchanged things until the client disconnects",
+			wait:      time.Minute,
+			pages:     []mgclients.ClientsPage{{}},
+			cancel:    true,
+			retrieved: 1,
+		},
+		{
+			desc:     "list things with failed watch",
+			wait:     time.Minute,
+			watchErr: svcerr.ErrMalformedEntity,
+			err:      svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			cRepo := new(mocks.Repository)
+			watcher := new(mocks.Watcher)
+			svc := things.NewService(auth, cRepo, new(gmocks.Repository), new(mocks.Cache), watcher, uuid.NewMock(), mgclients.KeyPolicy{})
+
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, nil)
+			auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			for _, page := range tc.pages {
+				cRepo.On("RetrieveAllByIDs", mock.Anything, mock.Anything).Return(page, nil).Once()
+			}
+			events := make(chan things.ThingEvent, 1)
+			var watchCtx context.Context
+			watcher.On("Watch", mock.Anything, domainID, uint64(0)).Run(func(args mock.Arguments) {
+				watchCtx = args.Get(0).(context.Context)
+			}).Return((<-chan things.ThingEvent)(events), tc.watchErr)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			switch {
+			case tc.change:
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					events <- things.ThingEvent{Operation: things.UpdateOp, DomainID: domainID}
+				}()
+			case tc.cancel:
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					cancel()
+				}()
+			}
+
+			pm := mgclients.Page{Limit: 10, UpdatedSince: since, Wait: tc.wait}
+			page, err := svc.ListClients(ctx, validToken, "", pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+			cRepo.AssertNumberOfCalls(t, "RetrieveAllByIDs", tc.retrieved)
+			if watchCtx != nil {
+				assert.Error(t, watchCtx.Err(), fmt.Sprintf("%s: expected the watch to end with the request\n", tc.desc))
+			}
+		})
+	}
+}
+
+func TestAggregateMetadata(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	aggs := []mgclients.MetadataAggregate{{Value: "eu", Count: 2}}
+
+	cases := []struct {
+		desc         string
+		token        string
+		status       mgclients.Status
+		identifyErr  error
+		aggregateErr error
+		err          error
+	}{
+		{
+			desc:   "aggregate metadata of enabled things",
+			token:  validToken,
+			status: mgclients.EnabledStatus,
+		},
+		{
+			desc:   "aggregate metadata of all things",
+			token:  validToken,
+			status: mgclients.AllStatus,
+		},
+		{
+			desc:        "aggregate metadata with invalid token",
+			token:       inValidToken,
+			status:      mgclients.EnabledStatus,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:         "aggregate metadata with failed to aggregate",
+			token:        validToken,
+			status:       mgclients.EnabledStatus,
+			aggregateErr: repoerr.ErrViewEntity,
+			err:          svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, cRepo, auth, _ := newService()
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, tc.identifyErr)
+			auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+			cRepo.On("AggregateMetadata", mock.Anything, mgclients.Page{Status: tc.status, Role: mgclients.AllRole, Domain: domainID}, "region").Return(aggs, tc.aggregateErr)
+
+			res, err := svc.AggregateMetadata(context.Background(), tc.token, "region", tc.status)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, aggs, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, aggs, res))
+			}
+		})
+	}
+}
+
+func TestListOrphanedClients(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	connected := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	online := testsutil.GenerateUUID(t)
+	orphaned := mgclients.ClientsPage{
+		Page:    mgclients.Page{Total: 1, Limit: 10},
+		Clients: []mgclients.Client{client},
+	}
+
+	cases := []struct {
+		desc           string
+		token          string
+		connection     string
+		identifyErr    error
+		authorized     bool
+		listObjectsErr error
+		retrieveErr    error
+		excludeIDs     []string
+		err            error
+	}{
+		{
+			desc:       "list orphaned things",
+			token:      validToken,
+			authorized: true,
+			excludeIDs: connected,
+		},
+		{
+			desc:       "list offline orphaned things",
+			token:      validToken,
+			connection: things.OfflineConnection,
+			authorized: true,
+			excludeIDs: append([]string{online}, connected...),
+		},
+		{
+			desc:        "list orphaned things with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "list orphaned things as non admin",
+			token: validToken,
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:           "list orphaned things with failed to list connections",
+			token:          validToken,
+			authorized:     true,
+			listObjectsErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "list orphaned things with failed to retrieve things",
+			token:       validToken,
+			authorized:  true,
+			retrieveErr: repoerr.ErrViewEntity,
+			excludeIDs:  connected,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			cRepo := new(mocks.Repository)
+			gRepo := new(gmocks.Repository)
+			cache := new(mocks.Cache)
+			svc := things.NewService(auth, cRepo, gRepo, cache, new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, tc.identifyErr)
+			auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: tc.authorized}, nil)
+			auth.On("ListAllObjects", mock.Anything, &magistrala.ListObjectsReq{SubjectType: authsvc.DomainType, Subject: domainID, Permission: authsvc.ConnectedDomainPermission, ObjectType: authsvc.ThingType}).Return(&magistrala.ListObjectsRes{Policies: connected}, tc.listObjectsErr)
+			cache.On("Online", mock.Anything, mock.Anything, mock.Anything).Return([]string{online}, nil)
+			cRepo.On("RetrieveAllByIDs", mock.Anything, mock.Anything).Return(orphaned, tc.retrieveErr)
+
+			page, err := svc.ListOrphanedClients(context.Background(), tc.token, mgclients.Page{Limit: 10, Connection: tc.connection})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.excludeIDs == nil {
+				cRepo.AssertNotCalled(t, "RetrieveAllByIDs", mock.Anything, mock.Anything)
+				return
+			}
+			pm := cRepo.Calls[0].Arguments.Get(1).(mgclients.Page)
+			assert.Equal(t, domainID, pm.Domain, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, pm.Domain))
+			assert.ElementsMatch(t, tc.excludeIDs, pm.ExcludeIDs, fmt.Sprintf("%s: expected excluded IDs %v got %v\n", tc.desc, tc.excludeIDs, pm.ExcludeIDs))
+			if tc.err == nil {
+				assert.Equal(t, orphaned, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, orphaned, page))
+			}
+		})
+	}
+}
+
+func TestListThingsChannels(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	thingIDs := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	ch1 := mggroups.Group{ID: testsutil.GenerateUUID(t), Domain: domainID, Name: "ch1"}
+	ch2 := mggroups.Group{ID: testsutil.GenerateUUID(t), Domain: domainID, Name: "ch2"}
+	conns := map[string][]string{
+		thingIDs[0]: {ch1.ID, ch2.ID},
+		thingIDs[1]: {},
+		thingIDs[2]: {ch1.ID},
+	}
+
+	cases := []struct {
+		desc         string
+		token        string
+		identifyErr  error
+		superAdmin   bool
+		listSubjErr  error
+		retrieveErr  error
+		retrievedIDs []string
+		retrieved    []mggroups.Group
+		channels     map[string][]mggroups.Group
+		err          error
+	}{
+		{
+			desc:         "list things channels as super admin",
+			token:        validToken,
+			superAdmin:   true,
+			retrievedIDs: []string{ch1.ID, ch2.ID},
+			retrieved:    []mggroups.Group{ch1, ch2},
+			channels: map[string][]mggroups.Group{
+				thingIDs[0]: {ch1, ch2},
+				thingIDs[2]: {ch1},
+			},
+		},
+		{
+			desc:         "list things channels as domain member",
+			token:        validToken,
+			retrievedIDs: []string{ch1.ID},
+			retrieved:    []mggroups.Group{ch1},
+			channels: map[string][]mggroups.Group{
+				thingIDs[0]: {ch1},
+			},
+		},
+		{
+			desc:        "list things channels with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "list things channels with failed to list connections",
+			token:       validToken,
+			superAdmin:  true,
+			listSubjErr: svcerr.ErrAuthorization,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "list things channels with failed to retrieve channels",
+			token:        validToken,
+			superAdmin:   true,
+			retrieveErr:  repoerr.ErrViewEntity,
+			retrievedIDs: []string{ch1.ID, ch2.ID},
+			err:          svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			gRepo := new(gmocks.Repository)
+			svc := things.NewService(auth, new(mocks.Repository), gRepo, new(mocks.Cache), new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, tc.identifyErr)
+			auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: tc.superAdmin}, nil)
+			// Domain members can view the first two things and the first channel.
+			auth.On("ListAllObjects", mock.Anything, &magistrala.ListObjectsReq{SubjectType: authsvc.UserType, Subject: validID, Permission: authsvc.ViewPermission, ObjectType: authsvc.ThingType}).Return(&magistrala.ListObjectsRes{Policies: thingIDs[:2]}, nil)
+			auth.On("ListAllObjects", mock.Anything, &magistrala.ListObjectsReq{SubjectType: authsvc.UserType, Subject: validID, Permission: authsvc.ViewPermission, ObjectType: authsvc.GroupType}).Return(&magistrala.ListObjectsRes{Policies: []string{ch1.ID}}, nil)
+			for id, chs := range conns {
+				auth.On("ListAllSubjects", mock.Anything, &magistrala.ListSubjectsReq{SubjectType: authsvc.GroupType, Permission: authsvc.GroupRelation, ObjectType: authsvc.ThingType, Object: id}).Return(&magistrala.ListSubjectsRes{Policies: chs}, tc.listSubjErr)
+			}
+			gRepo.On("RetrieveByIDs", mock.Anything, mock.MatchedBy(func(pm mggroups.Page) bool {
+				return pm.DomainID == domainID
+			}), mock.Anything).Return(mggroups.Page{Groups: tc.retrieved}, tc.retrieveErr)
+
+			chs, err := svc.ListThingsChannels(context.Background(), tc.token, thingIDs...)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.retrievedIDs != nil {
+				ids := gRepo.Calls[0].Arguments.Get(2).([]string)
+				assert.ElementsMatch(t, tc.retrievedIDs, ids, fmt.Sprintf("%s: expected retrieved channels %v got %v\n", tc.desc, tc.retrievedIDs, ids))
+			}
+			if tc.err == nil {
+				assert.Equal(t, tc.channels, chs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.channels, chs))
+			}
+		})
+	}
+}
+
+func TestListThingsChannelsConcurrency(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	thingIDs := make([]string, 50)
+	for i := range thingIDs {
+		thingIDs[i] = testsutil.GenerateUUID(t)
+	}
+
+	auth := new(authmocks.AuthClient)
+	gRepo := new(gmocks.Repository)
+	svc := things.NewService(auth, new(mocks.Repository), gRepo, new(mocks.Cache), new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+
+	var mu sync.Mutex
+	var running, peak int
+	auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, nil)
+	auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	auth.On("ListAllSubjects", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}).Return(&magistrala.ListSubjectsRes{}, nil)
+
+	_, err := svc.ListThingsChannels(context.Background(), validToken, thingIDs...)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	auth.AssertNumberOfCalls(t, "ListAllSubjects", len(thingIDs))
+	assert.LessOrEqual(t, peak, 10, fmt.Sprintf("expected at most 10 concurrent connection lookups got %d", peak))
+}
+
+func TestExportSubtree(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	rootID := testsutil.GenerateUUID(t)
+	childID := testsutil.GenerateUUID(t)
+	grandchildID := testsutil.GenerateUUID(t)
+	root := mggroups.Group{ID: rootID, Domain: domainID, Name: "line"}
+	child := mggroups.Group{ID: childID, Domain: domainID, Name: "press", Parent: rootID}
+	grandchild := mggroups.Group{ID: grandchildID, Domain: domainID, Name: "valve", Parent: childID}
+	thingIDs := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	// The first thing is connected to the root and the child, so it's exported once.
+	conns := map[string][]string{
+		rootID:       {thingIDs[0]},
+		childID:      {thingIDs[1], thingIDs[0]},
+		grandchildID: {thingIDs[2]},
+	}
+	adminID := testsutil.GenerateUUID(t)
+	editorID := testsutil.GenerateUUID(t)
+	roles := map[string][]string{
+		authsvc.AdministratorRelation: {authsvc.EncodeDomainUserID(domainID, adminID)},
+		authsvc.EditorRelation:        {authsvc.EncodeDomainUserID(domainID, editorID), authsvc.EncodeDomainUserID(domainID, adminID)},
+	}
+	var exported []mgclients.Client
+	var keys []mgclients.Client
+	for _, id := range thingIDs {
+		exported = append(exported, mgclients.Client{ID: id, Domain: domainID})
+		keys = append(keys, mgclients.Client{ID: id, Credentials: mgclients.Credentials{Secret: "key-" + id}})
+	}
+	keyed := make([]mgclients.Client, len(exported))
+	for i, c := range exported {
+		c.Credentials.Secret = "key-" + c.ID
+		keyed[i] = c
+	}
+	page := things.SubtreePageMeta{
+		Channels: mggroups.PageMeta{Limit: 10},
+		Things:   mggroups.PageMeta{Limit: 10},
+		Members:  mggroups.PageMeta{Limit: 10},
+	}
+	pagedMembers := page
+	pagedMembers.Members = mggroups.PageMeta{Offset: 1, Limit: 1}
+
+	cases := []struct {
+		desc        string
+		page        things.SubtreePageMeta
+		keys        bool
+		authorized  bool
+		retrieveErr error
+		childrenErr error
+		listErr     error
+		things      []mgclients.Client
+		members     things.MemberRolesPage
+		err         error
+	}{
+		{
+			desc:       "export subtree successfully",
+			page:       page,
+			authorized: true,
+			things:     exported,
+			members: things.MemberRolesPage{
+				PageMeta: mggroups.PageMeta{Total: 2, Limit: 10},
+				Members: []mggroups.MemberRole{
+					{MemberID: adminID, Role: authsvc.AdministratorRelation},
+					{MemberID: editorID, Role: authsvc.EditorRelation},
+				},
+			},
+		},
+		{
+			desc:       "export subtree with keys",
+			page:       page,
+			keys:       true,
+			authorized: true,
+			things:     keyed,
+			members: things.MemberRolesPage{
+				PageMeta: mggroups.PageMeta{Total: 2, Limit: 10},
+				Members: []mggroups.MemberRole{
+					{MemberID: adminID, Role: authsvc.AdministratorRelation},
+					{MemberID: editorID, Role: authsvc.EditorRelation},
+				},
+			},
+		},
+		{
+			desc:       "export subtree with paged members",
+			page:       pagedMembers,
+			authorized: true,
+			things:     exported,
+			members: things.MemberRolesPage{
+				PageMeta: mggroups.PageMeta{Total: 2, Offset: 1, Limit: 1},
+				Members:  []mggroups.MemberRole{{MemberID: editorID, Role: authsvc.EditorRelation}},
+			},
+		},
+		{
+			desc: "export subtree without administrator role",
+			page: page,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "export subtree with failed to retrieve channel",
+			page:        page,
+			authorized:  true,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "export subtree with failed to retrieve descendants",
+			page:        page,
+			authorized:  true,
+			childrenErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "export subtree with failed to list things",
+			page:       page,
+			authorized: true,
+			listErr:    svcerr.ErrAuthorization,
+			err:        svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			auth := new(authmocks.AuthClient)
+			cRepo := new(mocks.Repository)
+			gRepo := new(gmocks.Repository)
+			svc := things.NewService(auth, cRepo, gRepo, new(mocks.Cache), new(mocks.Watcher), uuid.NewMock(), mgclients.KeyPolicy{})
+
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID}, nil)
+			auth.On("Authorize", mock.Anything, mock.MatchedBy(func(req *magistrala.AuthorizeReq) bool {
+				return req.GetPermission() == authsvc.AdminPermission && req.GetObject() == rootID
+			})).Return(&magistrala.AuthorizeRes{Authorized: tc.authorized}, nil)
+			for groupID, ids := range conns {
+				auth.On("ListAllObjects", mock.Anything, mock.MatchedBy(func(req *magistrala.ListObjectsReq) bool {
+					return req.GetSubject() == groupID && req.GetPermission() == authsvc.GroupRelation
+				})).Return(&magistrala.ListObjectsRes{Policies: ids}, tc.listErr)
+			}
+			for role, duids := range roles {
+				auth.On("ListAllSubjects", mock.Anything, mock.MatchedBy(func(req *magistrala.ListSubjectsReq) bool {
+					return req.GetObject() == rootID && req.GetPermission() == role
+				})).Return(&magistrala.ListSubjectsRes{Policies: duids}, nil)
+			}
+			auth.On("ListAllSubjects", mock.Anything, mock.Anything).Return(&magistrala.ListSubjectsRes{}, nil)
+			gRepo.On("RetrieveByID", mock.Anything, rootID).Return(root, tc.retrieveErr)
+			gRepo.On("RetrieveChildrenIDs", mock.Anything, rootID).Return([]string{childID}, tc.childrenErr)
+			gRepo.On("RetrieveChildrenIDs", mock.Anything, childID).Return([]string{grandchildID}, nil)
+			gRepo.On("RetrieveChildrenIDs", mock.Anything, grandchildID).Return([]string{}, nil)
+			gRepo.On("RetrieveByIDs", mock.Anything, mock.Anything, []string{childID, grandchildID}).Return(mggroups.Page{PageMeta: mggroups.PageMeta{Total: 2, Limit: 10}, Groups: []mggroups.Group{child, grandchild}}, nil)
+			cRepo.On("RetrieveAllByIDs", mock.Anything, mock.MatchedBy(func(pm mgclients.Page) bool {
+				return assert.ObjectsAreEqual(thingIDs, pm.IDs) && pm.Offset == tc.page.Things.Offset && pm.Limit == tc.page.Things.Limit
+			})).Return(mgclients.ClientsPage{Page: mgclients.Page{Total: 3, Limit: 10}, Clients: exported}, nil)
+			cRepo.On("RetrieveKeysByIDs", mock.Anything, thingIDs[0], thingIDs[1], thingIDs[2]).Return(keys, nil)
+
+			st, err := svc.ExportSubtree(context.Background(), validToken, rootID, tc.page, tc.keys)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if err != nil {
+				return
+			}
+			assert.Equal(t, root, st.Channel)
+			assert.Equal(t, []mggroups.Group{child, grandchild}, st.Channels.Groups)
+			assert.Equal(t, uint64(3), st.Things.Total)
+			assert.Equal(t, tc.things, st.Things.Members)
+			assert.Equal(t, tc.members, st.Members)
+			if !tc.keys {
+				cRepo.AssertNotCalled(t, "RetrieveKeysByIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	svc, cRepo, auth, _ := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	scopes := []string{mgclients.ThingsReadScope}
+
+	cases := []struct {
+		desc              string
+		token             string
+		ttl               time.Duration
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		authorizeErr      error
+		saveErr           error
+		err               error
+	}{
+		{
+			desc:              "issue token successfully",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:              "issue expiring token successfully",
+			token:             validToken,
+			ttl:               time.Hour,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:  "issue token with scoped token",
+			token: mgclients.ScopedTokenPrefix + validToken,
+			err:   svcerr.ErrTokenScope,
+		},
+		{
+			desc:        "issue token with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "issue token with unauthorized user",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "issue token with failed to save token",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:           repoerr.ErrCreateEntity,
+			err:               svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		repoCall := cRepo.On("SaveToken", mock.Anything, mock.Anything).Return(tc.saveErr)
+		st, err := svc.IssueToken(context.Background(), tc.token, scopes, tc.ttl)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			saved := repoCall.Parent.Calls[len(repoCall.Parent.Calls)-1].Arguments.Get(1).(mgclients.ScopedToken)
+			assert.Empty(t, saved.Token, fmt.Sprintf("%s: expected token not to be stored\n", tc.desc))
+			assert.Equal(t, mgclients.HashToken(st.Token), saved.TokenHash, fmt.Sprintf("%s: expected stored token hash %s got %s\n", tc.desc, mgclients.HashToken(st.Token), saved.TokenHash))
+			assert.True(t, mgclients.IsScopedToken(st.Token), fmt.Sprintf("%s: expected scoped token got %s\n", tc.desc, st.Token))
+			assert.Equal(t, domainID, st.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, st.DomainID))
+			assert.Equal(t, scopes, st.Scopes, fmt.Sprintf("%s: expected scopes %v got %v\n", tc.desc, scopes, st.Scopes))
+			assert.Equal(t, tc.ttl > 0, st.ExpiresAt != nil, fmt.Sprintf("%s: unexpected token expiry %v\n", tc.desc, st.ExpiresAt))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestListTokens(t *testing.T) {
+	svc, cRepo, auth, _ := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	pm := mgclients.Page{Offset: 0, Limit: 10}
+	page := mgclients.ScopedTokensPage{
+		Page:   mgclients.Page{Total: 1, Offset: 0, Limit: 10},
+		Tokens: []mgclients.ScopedToken{{ID: testsutil.GenerateUUID(t), DomainID: domainID, Scopes: []string{mgclients.ThingsReadScope}}},
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		authorizeErr      error
+		retrieveResponse  mgclients.ScopedTokensPage
+		retrieveErr       error
+		response          mgclients.ScopedTokensPage
+		err               error
+	}{
+		{
+			desc:              "list tokens successfully",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveResponse:  page,
+			response:          page,
+		},
+		{
+			desc:  "list tokens with scoped token",
+			token: mgclients.ScopedTokenPrefix + validToken,
+			err:   svcerr.ErrTokenScope,
+		},
+		{
+			desc:        "list tokens with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "list tokens with unauthorized user",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "list tokens with failed to retrieve tokens",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr:       repoerr.ErrViewEntity,
+			err:               svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		repoCall := cRepo.On("RetrieveAllTokens", mock.Anything, domainID, pm).Return(tc.retrieveResponse, tc.retrieveErr)
+		tp, err := svc.ListTokens(context.Background(), tc.token, pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, tp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, tp))
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	svc, cRepo, auth, _ := newService()
+
+	domainID := testsutil.GenerateUUID(t)
+	tokenID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc              string
+		token             string
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		authorizeErr      error
+		removeErr         error
+		err               error
+	}{
+		{
+			desc:              "revoke token successfully",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+		},
+		{
+			desc:  "revoke token with scoped token",
+			token: mgclients.ScopedTokenPrefix + validToken,
+			err:   svcerr.ErrTokenScope,
+		},
+		{
+			desc:        "revoke token with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "revoke token with unauthorized user",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			authorizeErr:      svcerr.ErrAuthorization,
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "revoke non-existing token",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, DomainId: domainID},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			removeErr:         repoerr.ErrNotFound,
+			err:               svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+		authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+		repoCall := cRepo.On("RemoveToken", mock.Anything, domainID, tokenID).Return(tc.removeErr)
+		err := svc.RevokeToken(context.Background(), tc.token, tokenID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authCall.Unset()
+		authCall1.Unset()
+		repoCall.Unset()
+	}
+}
+
+func TestReconcileCache(t *testing.T) {
+	added := mgclients.Client{ID: testsutil.GenerateUUID(t), Credentials: mgclients.Credentials{Secret: testsutil.GenerateUUID(t)}, Status: mgclients.EnabledStatus}
+	corrected := mgclients.Client{ID: testsutil.GenerateUUID(t), Credentials: mgclients.Credentials{Secret: testsutil.GenerateUUID(t)}, Status: mgclients.EnabledStatus}
+	disabled := mgclients.Client{ID: testsutil.GenerateUUID(t), Credentials: mgclients.Credentials{Secret: testsutil.GenerateUUID(t)}, Status: mgclients.DisabledStatus}
+	cached := mgclients.Client{ID: testsutil.GenerateUUID(t), Credentials: mgclients.Credentials{Secret: testsutil.GenerateUUID(t)}, Status: mgclients.EnabledStatus}
+	disabledCached := cached
+	disabledCached.Status = mgclients.DisabledStatus
+	oldKey := testsutil.GenerateUUID(t)
+	removedID := testsutil.GenerateUUID(t)
+	removedKey := testsutil.GenerateUUID(t)
+	entries := []things.CacheEntry{
+		{Secret: removedKey, ThingID: removedID},
+		{Secret: cached.Credentials.Secret, ThingID: cached.ID},
+	}
+
+	cases := []struct {
+		desc              string
+		token             string
+		identifyResponse  *magistrala.IdentityRes
+		authorizeResponse *magistrala.AuthorizeRes
+		identifyErr       error
+		authorizeErr      error
+		retrieveErr       error
+		saveErr           error
+		disableErr        error
+		scanErr           error
+		currentRes        []mgclients.Client
+		rec               things.CacheReconciliation
+		disabled          []string
+		failed            bool
+		err               error
+	}{
+		{
+			desc:              "reconcile cache successfully",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			currentRes:        []mgclients.Client{added, corrected, disabled, cached},
+			rec:               things.CacheReconciliation{Added: 1, Corrected: 1, Removed: 2},
+			disabled:          []string{disabled.ID},
+		},
+		{
+			desc:              "reconcile cache with thing disabled during reconciliation",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			currentRes:        []mgclients.Client{added, corrected, disabled, disabledCached},
+			rec:               things.CacheReconciliation{Added: 1, Corrected: 1, Removed: 2},
+			disabled:          []string{disabled.ID, cached.ID},
+		},
+		{
+			desc:        "reconcile cache with invalid token",
+			token:       inValidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:              "reconcile cache with non-admin user",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: false},
+			err:               svcerr.ErrAuthorization,
+		},
+		{
+			desc:              "reconcile cache with failed to retrieve keys",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			retrieveErr:       repoerr.ErrViewEntity,
+			failed:            true,
+		},
+		{
+			desc:              "reconcile cache with failed to disable thing",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			disableErr:        repoerr.ErrUpdateEntity,
+			failed:            true,
+		},
+		{
+			desc:              "reconcile cache with failed to save to cache",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			saveErr:           repoerr.ErrCreateEntity,
+			failed:            true,
+		},
+		{
+			desc:              "reconcile cache with failed to scan cache",
+			token:             validToken,
+			identifyResponse:  &magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)},
+			authorizeResponse: &magistrala.AuthorizeRes{Authorized: true},
+			scanErr:           repoerr.ErrViewEntity,
+			failed:            true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, cRepo, auth, cache := newService()
+			auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(tc.identifyResponse, tc.identifyErr)
+			auth.On("Authorize", mock.Anything, mock.Anything).Return(tc.authorizeResponse, tc.authorizeErr)
+			cRepo.On("RetrieveKeys", mock.Anything, "", uint64(100)).Return([]mgclients.Client{added, corrected, disabled, cached}, tc.retrieveErr)
+			cRepo.On("RetrieveKeysByIDs", mock.Anything, added.ID, corrected.ID, disabled.ID, cached.ID).Return(tc.currentRes, nil)
+			cRepo.On("RetrieveKeysByIDs", mock.Anything, removedID, cached.ID).Return([]mgclients.Client{cached}, nil)
+			cache.On("Disable", mock.Anything, disabled.ID).Return(tc.disableErr)
+			cache.On("Disable", mock.Anything, cached.ID).Return(nil)
+			cache.On("Enable", mock.Anything, mock.Anything).Return(nil)
+			cache.On("Key", mock.Anything, added.ID).Return("", repoerr.ErrNotFound)
+			cache.On("Key", mock.Anything, corrected.ID).Return(oldKey, nil)
+			cache.On("Key", mock.Anything, disabled.ID).Return(disabled.Credentials.Secret, nil)
+			cache.On("Key", mock.Anything, cached.ID).Return(cached.Credentials.Secret, nil)
+			cache.On("ID", mock.Anything, cached.Credentials.Secret).Return(cached.ID, nil)
+			cache.On("RemoveKey", mock.Anything, mock.Anything).Return(nil)
+			cache.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.saveErr)
+			cache.On("Scan", mock.Anything, uint64(0), int64(100)).Return(entries, uint64(0), tc.scanErr)
+
+			rec, err := svc.ReconcileCache(context.Background(), tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				return
+			}
+			assert.True(t, rec.Running, fmt.Sprintf("%s: expected reconciliation to be running", tc.desc))
+
+			assert.Eventually(t, func() bool {
+				rec, err = svc.ViewCacheReconciliation(context.Background(), tc.token)
+				return err == nil && !rec.Running
+			}, time.Second, 10*time.Millisecond, fmt.Sprintf("%s: expected reconciliation to finish", tc.desc))
+			assert.Equal(t, tc.failed, rec.Error != "", fmt.Sprintf("%s: expected failure %t got error %q\n", tc.desc, tc.failed, rec.Error))
+			if tc.failed {
+				return
+			}
+			assert.Equal(t, tc.rec.Added, rec.Added, fmt.Sprintf("%s: expected %d added got %d\n", tc.desc, tc.rec.Added, rec.Added))
+			assert.Equal(t, tc.rec.Corrected, rec.Corrected, fmt.Sprintf("%s: expected %d corrected got %d\n", tc.desc, tc.rec.Corrected, rec.Corrected))
+			assert.Equal(t, tc.rec.Removed, rec.Removed, fmt.Sprintf("%s: expected %d removed got %d\n", tc.desc, tc.rec.Removed, rec.Removed))
+			assert.False(t, rec.FinishedAt.Before(rec.StartedAt), fmt.Sprintf("%s: expected finish after start", tc.desc))
+			for _, id := range tc.disabled {
+				cache.AssertCalled(t, "Disable", mock.Anything, id)
+			}
+			if len(tc.disabled) == 1 {
+				cache.AssertNotCalled(t, "Disable", mock.Anything, cached.ID)
+			}
+			cache.AssertCalled(t, "Enable", mock.Anything, added.ID)
+			cache.AssertCalled(t, "Save", mock.Anything, added.Credentials.Secret, added.ID, time.Time{})
+			cache.AssertCalled(t, "Save", mock.Anything, corrected.Credentials.Secret, corrected.ID, time.Time{})
+			cache.AssertCalled(t, "RemoveKey", mock.Anything, oldKey)
+			cache.AssertCalled(t, "RemoveKey", mock.Anything, disabled.Credentials.Secret)
+			cache.AssertCalled(t, "RemoveKey", mock.Anything, removedKey)
+			cache.AssertNotCalled(t, "RemoveKey", mock.Anything, cached.Credentials.Secret)
+		})
+	}
+}
+
+func TestReconcileCacheRunning(t *testing.T) {
+	svc, cRepo, auth, cache := newService()
+
+	release := make(chan time.Time)
+	auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{Id: validID, UserId: validID, DomainId: testsutil.GenerateUUID(t)}, nil)
+	auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	cRepo.On("RetrieveKeys", mock.Anything, "", uint64(100)).WaitUntil(release).Return([]mgclients.Client{}, nil)
+	cache.On("Scan", mock.Anything, uint64(0), int64(100)).Return([]things.CacheEntry{}, uint64(0), nil)
+
+	first, err := svc.ReconcileCache(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("reconcile cache: unexpected error %s", err))
+	second, err := svc.ReconcileCache(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("reconcile cache while running: unexpected error %s", err))
+	assert.Equal(t, first.StartedAt, second.StartedAt, "reconcile cache while running: expected the running reconciliation")
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		rec, err := svc.ViewCacheReconciliation(context.Background(), validToken)
+		return err == nil && !rec.Running
+	}, time.Second, 10*time.Millisecond, "reconcile cache: expected reconciliation to finish")
+	cRepo.AssertNumberOfCalls(t, "RetrieveKeys", 1)
+}
+
+func TestScopedTokenAuthorization(t *testing.T) {
+	svc, cRepo, auth, _ := newService()
+
+	expiredAt := time.Now().Add(-time.Minute)
+	readToken := mgclients.ScopedToken{
+		Token:    mgclients.ScopedTokenPrefix + "read",
+		DomainID: validID,
+		Subject:  validID,
+		Scopes:   []string{mgclients.ThingsReadScope},
+	}
+	expiredToken := mgclients.ScopedToken{
+		Token:     mgclients.ScopedTokenPrefix + "expired",
+		DomainID:  validID,
+		Subject:   validID,
+		Scopes:    []string{mgclients.ThingsReadScope},
+		ExpiresAt: &expiredAt,
+	}
+
+	cases := []struct {
+		desc        string
+		token       mgclients.ScopedToken
+		retrieveErr error
+		op          func(token string) error
+		err         error
+	}{
+		{
+			desc:  "view thing with things:read token",
+			token: readToken,
+			op: func(token string) error {
+				_, err := svc.ViewClient(context.Background(), token, client.ID)
+				return err
+			},
+		},
+		{
+			desc:  "update thing with things:read token",
+			token: readToken,
+			op: func(token string) error {
+				_, err := svc.UpdateClient(context.Background(), token, client, false)
+				return err
+			},
+			err: svcerr.ErrTokenScope,
+		},
+		{
+			desc:  "delete thing with things:read token",
+			token: readToken,
+			op: func(token string) error {
+				_, err := svc.DeleteClient(context.Background(), token, client.ID, time.Time{})
+				return err
+			},
+			err: svcerr.ErrTokenScope,
+		},
+		{
+			desc:  "view thing with expired token",
+			token: expiredToken,
+			op: func(token string) error {
+				_, err := svc.ViewClient(context.Background(), token, client.ID)
+				return err
+			},
+			err: svcerr.ErrKeyExpired,
+		},
+		{
+			desc:        "view thing with revoked token",
+			token:       readToken,
+			retrieveErr: repoerr.ErrNotFound,
+			op: func(token string) error {
+				_, err := svc.ViewClient(context.Background(), token, client.ID)
+				return err
+			},
+			err: svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveToken", mock.Anything, mgclients.HashToken(tc.token.Token)).Return(tc.token, tc.retrieveErr)
+		authCall := auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+			Domain:      tc.token.DomainID,
+			SubjectType: authsvc.UserType,
+			SubjectKind: authsvc.UsersKind,
+			Subject:     tc.token.Subject,
+			Permission:  authsvc.ViewPermission,
+			ObjectType:  authsvc.ThingType,
+			Object:      client.ID,
+		}).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+		repoCall1 := cRepo.On("RetrieveByID", mock.Anything, client.ID).Return(client, nil)
+		err := tc.op(tc.token.Token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		authCall.Unset()
+		repoCall1.Unset()
+	}
 }
@@ -5,9 +5,41 @@ package things
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/groups"
+)
+
+// ErrSeqExpired indicates that the requested sequence number is older than
+// the oldest event retained for resuming a watch.
+var ErrSeqExpired = errors.New("sequence number is no longer available")
+
+// ErrWatchDropped indicates that a watcher fell too far behind and its
+// stream was closed; it should resume from the last sequence it received.
+var ErrWatchDropped = errors.New("watcher dropped for falling behind")
+
+// ErrConnectionFilterTooLarge indicates that the domain has too many online
+// things to filter the things by connection state.
+var ErrConnectionFilterTooLarge = errors.New("too many online things to filter by connection state")
+
+// ErrCertSubject indicates that the subject of the client certificate
+// doesn't match the subject of its binding.
+var ErrCertSubject = errors.New("certificate subject doesn't match the bound subject")
+
+// Thing event operations delivered to watchers.
+const (
+	CreateOp = "create"
+	UpdateOp = "update"
+	RemoveOp = "remove"
+)
+
+// Connection states of things, derived from the time they were last seen.
+const (
+	OnlineConnection  = "online"
+	OfflineConnection = "offline"
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -22,10 +54,17 @@ type Service interface {
 	// ViewClient retrieves client info for a given client ID and an authorized token.
 	ViewClient(ctx context.Context, token, id string) (clients.Client, error)
 
+	// ViewClients retrieves the clients with the given IDs in the order of
+	// the IDs. Clients which can't be viewed with the token are returned as
+	// results with an error instead of failing the whole call.
+	ViewClients(ctx context.Context, token string, ids ...string) ([]ViewResult, error)
+
 	// ViewClientPerms retrieves permissions on the client id for the given authorized token.
 	ViewClientPerms(ctx context.Context, token, id string) ([]string, error)
 
-	// ListClients retrieves clients list for a valid auth token.
+	// ListClients retrieves clients list for a valid auth token. If the page
+	// sets Wait and UpdatedSince and no client was updated since then, the
+	// call blocks until some client of the domain changes or Wait elapses.
 	ListClients(ctx context.Context, token string, reqUserID string, pm clients.Page) (clients.ClientsPage, error)
 
 	// ListClientsByGroup retrieves data about subset of things that are
@@ -33,14 +72,44 @@ type Service interface {
 	// the provided key.
 	ListClientsByGroup(ctx context.Context, token, groupID string, pm clients.Page) (clients.MembersPage, error)
 
-	// UpdateClient updates the client's name and metadata.
-	UpdateClient(ctx context.Context, token string, client clients.Client) (clients.Client, error)
+	// ListOrphanedClients retrieves the things of the token domain which are
+	// not connected to any channel. Only domain administrators can list them.
+	ListOrphanedClients(ctx context.Context, token string, pm clients.Page) (clients.ClientsPage, error)
+
+	// ListThingsChannels retrieves the channels the things are connected to,
+	// keyed by the thing IDs. Things and channels which can't be viewed with
+	// the token are left out.
+	ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]groups.Group, error)
+
+	// ExportSubtree retrieves the channel along with a page of each of its
+	// collections: the channels descending from it, the things connected to
+	// the channel or its descendants and the channel members with their
+	// roles. Only the channel administrators can export the channel, and the
+	// thing keys are left out unless keys is set.
+	ExportSubtree(ctx context.Context, token, groupID string, pm SubtreePageMeta, keys bool) (Subtree, error)
+
+	// AggregateMetadata returns distinct values of the given metadata field
+	// and their counts across the things accessible with the token.
+	AggregateMetadata(ctx context.Context, token, field string, status clients.Status) ([]clients.MetadataAggregate, error)
+
+	// UpdateClient updates the client's name and metadata. If merge is set,
+	// the metadata is deep-merged into the existing one instead of replacing it.
+	UpdateClient(ctx context.Context, token string, client clients.Client, merge bool) (clients.Client, error)
 
 	// UpdateClientTags updates the client's tags.
 	UpdateClientTags(ctx context.Context, token string, client clients.Client) (clients.Client, error)
 
-	// UpdateClientSecret updates the client's secret
-	UpdateClientSecret(ctx context.Context, token, id, key string) (clients.Client, error)
+	// UpdateClientSecret updates the client's secret. A non-zero ttl issues a
+	// key which expires after the given duration.
+	UpdateClientSecret(ctx context.Context, token, id, key string, ttl time.Duration) (clients.Client, error)
+
+	// RotateKeys issues new keys to the page of things connected to the
+	// channel and returns the things with their new keys.
+	RotateKeys(ctx context.Context, token, groupID string, pm clients.Page) (clients.ClientsPage, error)
+
+	// TransferChannel moves the channel and the things connected to it to
+	// the target domain. The user has to be an administrator of both domains.
+	TransferChannel(ctx context.Context, token, groupID, domainID string) (ChannelTransfer, error)
 
 	// EnableClient logically enableds the client identified with the provided ID
 	EnableClient(ctx context.Context, token, id string) (clients.Client, error)
@@ -57,23 +126,248 @@ type Service interface {
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
 
+	// Heartbeat records the thing identified by the key as active without
+	// publishing a message, so that idle things are reported online. The key
+	// has to belong to the thing with the given ID. Heartbeats sent more
+	// often than the heartbeat interval fail with ErrRateLimited.
+	Heartbeat(ctx context.Context, key, id string) error
+
+	// ValidateKey reports whether the key identifies an enabled thing, along
+	// with the thing ID and the key expiry. Unlike Identify, unknown, expired
+	// and disabled keys are reported as invalid rather than failing, and the
+	// thing is not recorded as seen.
+	ValidateKey(ctx context.Context, key string) (KeyValidation, error)
+
+	// IdentifyCert returns thing ID for given verified client certificate
+	// fingerprint. If the binding of the certificate holds a subject, the
+	// subject of the certificate has to match it.
+	IdentifyCert(ctx context.Context, fingerprint, subject string) (string, error)
+
+	// BindCert binds the client certificate to the thing, so the thing can
+	// be identified by the certificate fingerprint.
+	BindCert(ctx context.Context, token string, cert clients.CertBinding) (clients.CertBinding, error)
+
+	// UnbindCert removes the binding of the client certificate to the thing,
+	// so the certificate no longer identifies the thing.
+	UnbindCert(ctx context.Context, token, thingID, fingerprint string) error
+
 	// Authorize used for AuthZ gRPC server implementation and Things authorization.
 	Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (string, error)
 
-	// DeleteClient deletes client with given ID.
-	DeleteClient(ctx context.Context, token, id string) error
+	// RetrieveMetadata returns the metadata and the status of the thing or
+	// the channel with the given ID, for the services configured through
+	// them. It serves the internal gRPC API, so it takes no token.
+	RetrieveMetadata(ctx context.Context, entityType, id string) (clients.Metadata, clients.Status, error)
+
+	// DeleteClient deletes client with given ID and returns the client as it
+	// was before the removal. If unmodifiedSince is not zero, the client is
+	// deleted only if it wasn't updated after that time.
+	DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (clients.Client, error)
+
+	// WatchThings streams changes of the things in the domain which occurred
+	// after the lastSeq sequence number. Zero lastSeq streams only new changes.
+	// The returned channel is closed when ctx is done.
+	WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (<-chan ThingEvent, error)
+
+	// IssueToken issues a token bound to the domain of the token, which acts
+	// on behalf of the user but is allowed to perform only the operations of
+	// the given scopes. A non-zero ttl issues a token which expires after the
+	// given duration.
+	IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (clients.ScopedToken, error)
+
+	// ListTokens lists the scoped tokens of the token domain. The token
+	// values are not retrievable once issued.
+	ListTokens(ctx context.Context, token string, pm clients.Page) (clients.ScopedTokensPage, error)
+
+	// RevokeToken revokes the scoped token with the given ID.
+	RevokeToken(ctx context.Context, token, id string) error
+
+	// ReconcileCache starts rebuilding the cached thing keys and disabled
+	// marks from the database in batches, and removing the cached keys which
+	// are no longer valid, in the background. If a reconciliation is already
+	// running, no other is started. It returns the state of the running
+	// reconciliation. Only platform administrators can reconcile the cache.
+	ReconcileCache(ctx context.Context, token string) (CacheReconciliation, error)
+
+	// ViewCacheReconciliation returns the state of the running or the last
+	// cache reconciliation.
+	ViewCacheReconciliation(ctx context.Context, token string) (CacheReconciliation, error)
+}
+
+// ViewResult is the result of viewing a single client of a bulk view.
+type ViewResult struct {
+	ID     string
+	Client clients.Client
+	Err    error
+}
+
+// ChannelTransfer is the result of moving a channel to another domain.
+type ChannelTransfer struct {
+	Channel groups.Group
+	Things  []clients.Client
+}
+
+// SubtreePageMeta holds the offset and the limit of each collection of an
+// exported channel, as the collections are paged independently.
+type SubtreePageMeta struct {
+	Channels groups.PageMeta
+	Things   groups.PageMeta
+	Members  groups.PageMeta
+}
+
+// MemberRolesPage represents a page of the channel members with their roles.
+type MemberRolesPage struct {
+	groups.PageMeta
+	Members []groups.MemberRole
+}
+
+// Subtree represents a channel exported along with a page of each of its
+// collections.
+type Subtree struct {
+	Channel  groups.Group
+	Channels groups.Page
+	Things   clients.MembersPage
+	Members  MemberRolesPage
+}
+
+// ThingEvent represents a change of a thing.
+type ThingEvent struct {
+	Seq       uint64
+	Operation string
+	ThingID   string
+	DomainID  string
+	CreatedAt time.Time
+}
+
+// Watcher streams changes of things.
+//
+//go:generate mockery --name Watcher --filename watcher.go --quiet --note "Copyright (c) Abstract Machines"
+type Watcher interface {
+	// Watch returns the changes of the things in the domain which occurred
+	// after the lastSeq sequence number. The channel is closed when ctx is done.
+	Watch(ctx context.Context, domainID string, lastSeq uint64) (<-chan ThingEvent, error)
 }
 
 // Cache contains thing caching interface.
 //
 //go:generate mockery --name Cache --filename cache.go --quiet --note "Copyright (c) Abstract Machines"
 type Cache interface {
-	// Save stores pair thing secret, thing id.
-	Save(ctx context.Context, thingSecret, thingID string) error
+	// Save stores pair thing secret, thing id. If expiresAt is not zero,
+	// the pair is not cached beyond the secret expiry.
+	Save(ctx context.Context, thingSecret, thingID string, expiresAt time.Time) error
 
-	// ID returns thing ID for given thing secret.
+	// ID returns thing ID for given thing secret. If the thing is disabled,
+	// ErrThingDisabled is returned.
 	ID(ctx context.Context, thingSecret string) (string, error)
 
-	// Removes thing from cache.
+	// Key returns the cached thing secret for given thing ID.
+	Key(ctx context.Context, thingID string) (string, error)
+
+	// Remove removes the thing from cache along with its activity, domain
+	// and certificates. It is meant for removed things; use RemoveKey to
+	// drop only the key of a thing.
 	Remove(ctx context.Context, thingID string) error
+
+	// RemoveKey removes the cached pair of the thing secret, leaving the
+	// other cached pairs of the thing untouched.
+	RemoveKey(ctx context.Context, thingSecret string) error
+
+	// Disable marks the thing as disabled, so that its cached secrets
+	// don't identify it until it's enabled.
+	Disable(ctx context.Context, thingID string) error
+
+	// Enable removes the disabled mark of the thing.
+	Enable(ctx context.Context, thingID string) error
+
+	// SaveCert stores the certificate binding.
+	SaveCert(ctx context.Context, cert clients.CertBinding) error
+
+	// Cert returns the binding of the certificate with given fingerprint. If
+	// the thing is disabled, ErrThingDisabled is returned.
+	Cert(ctx context.Context, fingerprint string) (clients.CertBinding, error)
+
+	// RemoveCert removes the binding of the certificate with given
+	// fingerprint to the thing.
+	RemoveCert(ctx context.Context, fingerprint, thingID string) error
+
+	// Scan returns a batch of about count cached pairs starting at the
+	// cursor and the cursor of the next batch, which is zero once all the
+	// pairs are scanned. A pair may be returned more than once.
+	Scan(ctx context.Context, cursor uint64, count int64) ([]CacheEntry, uint64, error)
+
+	// SaveDomain stores the domain of the thing, which Seen records the
+	// activity under. It replaces the domain of a thing moved to another
+	// domain.
+	SaveDomain(ctx context.Context, thingID, domainID string) error
+
+	// Seen records the thing as active at the current time. Activity of a
+	// thing seen again shortly after may not be written. It returns
+	// ErrNotFound if the domain of the thing is not stored.
+	Seen(ctx context.Context, thingID string) error
+
+	// Online returns up to limit IDs of the domain things which were seen
+	// within the stale threshold.
+	Online(ctx context.Context, domainID string, limit int64) ([]string, error)
+
+	// Heartbeat reports whether the thing may send a heartbeat, which is
+	// allowed once per heartbeat interval.
+	Heartbeat(ctx context.Context, thingID string) (bool, error)
+
+	// Expiry returns the expiry of the cached thing secret, or zero time if
+	// the secret doesn't expire or is not cached.
+	Expiry(ctx context.Context, thingSecret string) (time.Time, error)
+}
+
+// KeyValidation is the outcome of validating a thing key.
+type KeyValidation struct {
+	Valid   bool
+	ThingID string
+	// ExpiresAt is the key expiry, nil for non-expiring keys.
+	ExpiresAt *time.Time
+}
+
+// CacheEntry is a cached pair of thing secret and thing ID.
+type CacheEntry struct {
+	Secret  string
+	ThingID string
+}
+
+// CacheReconciliation reports the cache entries changed by reconciling the
+// cache with the database.
+type CacheReconciliation struct {
+	Running    bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Error is the error the reconciliation stopped with, empty if it
+	// completed or is running.
+	Error     string
+	Added     uint64
+	Corrected uint64
+	Removed   uint64
+}
+
+// IdempotentResponse is the stored outcome of a request carrying an
+// idempotency key.
+type IdempotentResponse struct {
+	// Fingerprint identifies the request payload.
+	Fingerprint string `json:"fingerprint"`
+
+	// Body is the encoded response, empty while the request is in progress.
+	Body []byte `json:"body,omitempty"`
+}
+
+// IdempotencyCache stores responses of requests carrying an idempotency key
+// so retried requests are not processed more than once.
+//
+//go:generate mockery --name IdempotencyCache --filename idempotency.go --quiet --note "Copyright (c) Abstract Machines"
+type IdempotencyCache interface {
+	// Reserve reserves the key for the request with the given fingerprint.
+	// If the key is already reserved, it returns false and the stored response.
+	Reserve(ctx context.Context, key, fingerprint string) (IdempotentResponse, bool, error)
+
+	// Save stores the response of the request which reserved the key.
+	Save(ctx context.Context, key string, res IdempotentResponse) error
+
+	// Remove releases the key.
+	Remove(ctx context.Context, key string) error
 }
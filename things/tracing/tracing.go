@@ -5,9 +5,11 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	mggroups "github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/things"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -40,6 +42,13 @@ func (tm *tracingMiddleware) ViewClient(ctx context.Context, token, id string) (
 	return tm.svc.ViewClient(ctx, token, id)
 }
 
+// ViewClients traces the "ViewClients" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ViewClients(ctx context.Context, token string, ids ...string) ([]things.ViewResult, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_clients", trace.WithAttributes(attribute.Int("ids", len(ids))))
+	defer span.End()
+	return tm.svc.ViewClients(ctx, token, ids...)
+}
+
 // ViewClientPerms traces the "ViewClientPerms" operation of the wrapped policies.Service.
 func (tm *tracingMiddleware) ViewClientPerms(ctx context.Context, token, id string) ([]string, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_view_client_permissions", trace.WithAttributes(attribute.String("id", id)))
@@ -54,12 +63,43 @@ func (tm *tracingMiddleware) ListClients(ctx context.Context, token, reqUserID s
 	return tm.svc.ListClients(ctx, token, reqUserID, pm)
 }
 
+// ListOrphanedClients traces the "ListOrphanedClients" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ListOrphanedClients(ctx context.Context, token string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_orphaned_clients")
+	defer span.End()
+	return tm.svc.ListOrphanedClients(ctx, token, pm)
+}
+
+// ListThingsChannels traces the "ListThingsChannels" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ListThingsChannels(ctx context.Context, token string, thingIDs ...string) (map[string][]mggroups.Group, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_things_channels", trace.WithAttributes(attribute.Int("things", len(thingIDs))))
+	defer span.End()
+	return tm.svc.ListThingsChannels(ctx, token, thingIDs...)
+}
+
+// ExportSubtree traces the "ExportSubtree" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ExportSubtree(ctx context.Context, token, groupID string, pm things.SubtreePageMeta, keys bool) (things.Subtree, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_export_subtree", trace.WithAttributes(
+		attribute.String("group_id", groupID),
+		attribute.Bool("keys", keys),
+	))
+	defer span.End()
+	return tm.svc.ExportSubtree(ctx, token, groupID, pm, keys)
+}
+
+// AggregateMetadata traces the "AggregateMetadata" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) AggregateMetadata(ctx context.Context, token, field string, status mgclients.Status) ([]mgclients.MetadataAggregate, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_aggregate_metadata", trace.WithAttributes(attribute.String("field", field)))
+	defer span.End()
+	return tm.svc.AggregateMetadata(ctx, token, field, status)
+}
+
 // UpdateClient traces the "UpdateClient" operation of the wrapped policies.Service.
-func (tm *tracingMiddleware) UpdateClient(ctx context.Context, token string, cli mgclients.Client) (mgclients.Client, error) {
+func (tm *tracingMiddleware) UpdateClient(ctx context.Context, token string, cli mgclients.Client, merge bool) (mgclients.Client, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_client_name_and_metadata", trace.WithAttributes(attribute.String("id", cli.ID)))
 	defer span.End()
 
-	return tm.svc.UpdateClient(ctx, token, cli)
+	return tm.svc.UpdateClient(ctx, token, cli, merge)
 }
 
 // UpdateClientTags traces the "UpdateClientTags" operation of the wrapped policies.Service.
@@ -74,11 +114,30 @@ func (tm *tracingMiddleware) UpdateClientTags(ctx context.Context, token string,
 }
 
 // UpdateClientSecret traces the "UpdateClientSecret" operation of the wrapped policies.Service.
-func (tm *tracingMiddleware) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string) (mgclients.Client, error) {
+func (tm *tracingMiddleware) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string, ttl time.Duration) (mgclients.Client, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_client_secret")
 	defer span.End()
 
-	return tm.svc.UpdateClientSecret(ctx, token, oldSecret, newSecret)
+	return tm.svc.UpdateClientSecret(ctx, token, oldSecret, newSecret, ttl)
+}
+
+// RotateKeys traces the "RotateKeys" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) RotateKeys(ctx context.Context, token, groupID string, pm mgclients.Page) (mgclients.ClientsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rotate_channel_thing_keys", trace.WithAttributes(attribute.String("groupID", groupID)))
+	defer span.End()
+
+	return tm.svc.RotateKeys(ctx, token, groupID, pm)
+}
+
+// TransferChannel traces the "TransferChannel" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) TransferChannel(ctx context.Context, token, groupID, domainID string) (things.ChannelTransfer, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_transfer_channel", trace.WithAttributes(
+		attribute.String("groupID", groupID),
+		attribute.String("domainID", domainID),
+	))
+	defer span.End()
+
+	return tm.svc.TransferChannel(ctx, token, groupID, domainID)
 }
 
 // EnableClient traces the "EnableClient" operation of the wrapped policies.Service.
@@ -113,6 +172,52 @@ func (tm *tracingMiddleware) Identify(ctx context.Context, key string) (string,
 	return tm.svc.Identify(ctx, key)
 }
 
+// Heartbeat traces the "Heartbeat" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) Heartbeat(ctx context.Context, key, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_heartbeat", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.Heartbeat(ctx, key, id)
+}
+
+// ValidateKey traces the "ValidateKey" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ValidateKey(ctx context.Context, key string) (things.KeyValidation, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_validate_key")
+	defer span.End()
+
+	return tm.svc.ValidateKey(ctx, key)
+}
+
+// IdentifyCert traces the "IdentifyCert" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) IdentifyCert(ctx context.Context, fingerprint, subject string) (string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_identify_cert", trace.WithAttributes(attribute.String("fingerprint", fingerprint)))
+	defer span.End()
+
+	return tm.svc.IdentifyCert(ctx, fingerprint, subject)
+}
+
+// BindCert traces the "BindCert" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) BindCert(ctx context.Context, token string, cert mgclients.CertBinding) (mgclients.CertBinding, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_bind_cert", trace.WithAttributes(
+		attribute.String("thing_id", cert.ThingID),
+		attribute.String("fingerprint", cert.Fingerprint),
+	))
+	defer span.End()
+
+	return tm.svc.BindCert(ctx, token, cert)
+}
+
+// UnbindCert traces the "UnbindCert" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) UnbindCert(ctx context.Context, token, thingID, fingerprint string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unbind_cert", trace.WithAttributes(
+		attribute.String("thing_id", thingID),
+		attribute.String("fingerprint", fingerprint),
+	))
+	defer span.End()
+
+	return tm.svc.UnbindCert(ctx, token, thingID, fingerprint)
+}
+
 func (tm *tracingMiddleware) Authorize(ctx context.Context, req *magistrala.AuthorizeReq) (string, error) {
 	ctx, span := tm.tracer.Start(ctx, "connect", trace.WithAttributes(attribute.String("subject", req.Subject), attribute.String("object", req.Object)))
 	defer span.End()
@@ -120,6 +225,14 @@ func (tm *tracingMiddleware) Authorize(ctx context.Context, req *magistrala.Auth
 	return tm.svc.Authorize(ctx, req)
 }
 
+// RetrieveMetadata traces the "RetrieveMetadata" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) RetrieveMetadata(ctx context.Context, entityType, id string) (mgclients.Metadata, mgclients.Status, error) {
+	ctx, span := tm.tracer.Start(ctx, "retrieve_metadata", trace.WithAttributes(attribute.String("entity_type", entityType), attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RetrieveMetadata(ctx, entityType, id)
+}
+
 // Share traces the "Share" operation of the wrapped things.Service.
 func (tm *tracingMiddleware) Share(ctx context.Context, token, id, relation string, userids ...string) error {
 	ctx, span := tm.tracer.Start(ctx, "share", trace.WithAttributes(attribute.String("id", id), attribute.String("relation", relation), attribute.StringSlice("user_ids", userids)))
@@ -135,8 +248,56 @@ func (tm *tracingMiddleware) Unshare(ctx context.Context, token, id, relation st
 }
 
 // DeleteClient traces the "DeleteClient" operation of the wrapped things.Service.
-func (tm *tracingMiddleware) DeleteClient(ctx context.Context, token, id string) error {
+func (tm *tracingMiddleware) DeleteClient(ctx context.Context, token, id string, unmodifiedSince time.Time) (mgclients.Client, error) {
 	ctx, span := tm.tracer.Start(ctx, "delete_client", trace.WithAttributes(attribute.String("id", id)))
 	defer span.End()
-	return tm.svc.DeleteClient(ctx, token, id)
+	return tm.svc.DeleteClient(ctx, token, id, unmodifiedSince)
+}
+
+// WatchThings traces the "WatchThings" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) WatchThings(ctx context.Context, token, domainID string, lastSeq uint64) (<-chan things.ThingEvent, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_watch_things", trace.WithAttributes(
+		attribute.String("domain_id", domainID),
+		attribute.Int64("last_seq", int64(lastSeq)),
+	))
+	defer span.End()
+	return tm.svc.WatchThings(ctx, token, domainID, lastSeq)
+}
+
+// IssueToken traces the "IssueToken" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) IssueToken(ctx context.Context, token string, scopes []string, ttl time.Duration) (mgclients.ScopedToken, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_token", trace.WithAttributes(
+		attribute.StringSlice("scopes", scopes),
+		attribute.String("ttl", ttl.String()),
+	))
+	defer span.End()
+	return tm.svc.IssueToken(ctx, token, scopes, ttl)
+}
+
+// ListTokens traces the "ListTokens" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ListTokens(ctx context.Context, token string, pm mgclients.Page) (mgclients.ScopedTokensPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_tokens")
+	defer span.End()
+	return tm.svc.ListTokens(ctx, token, pm)
+}
+
+// RevokeToken traces the "RevokeToken" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) RevokeToken(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_token", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+	return tm.svc.RevokeToken(ctx, token, id)
+}
+
+// ReconcileCache traces the "ReconcileCache" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ReconcileCache(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_reconcile_cache")
+	defer span.End()
+	return tm.svc.ReconcileCache(ctx, token)
+}
+
+// ViewCacheReconciliation traces the "ViewCacheReconciliation" operation of the wrapped things.Service.
+func (tm *tracingMiddleware) ViewCacheReconciliation(ctx context.Context, token string) (things.CacheReconciliation, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_cache_reconciliation")
+	defer span.End()
+	return tm.svc.ViewCacheReconciliation(ctx, token)
 }
@@ -21,6 +21,7 @@ import (
 	mgclients "github.com/absmach/magistrala/pkg/clients"
 	"github.com/absmach/magistrala/pkg/errors"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/groups"
 	gmocks "github.com/absmach/magistrala/pkg/groups/mocks"
 	oauth2mocks "github.com/absmach/magistrala/pkg/oauth2/mocks"
 	httpapi "github.com/absmach/magistrala/users/api"
@@ -47,6 +48,7 @@ var (
 	validID      = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
 	passRegex    = regexp.MustCompile("^.{8,}$")
 	testReferer  = "http://localhost"
+	pageLimits   = api.PageLimits{Default: api.DefLimit, Max: api.MaxLimitSize}
 )
 
 const contentType = "application/json"
@@ -88,7 +90,7 @@ func newUsersServer() (*httptest.Server, *mocks.Service, *gmocks.Service) {
 	mux := chi.NewRouter()
 	provider := new(oauth2mocks.Provider)
 	provider.On("Name").Return("test")
-	httpapi.MakeHandler(svc, gsvc, mux, logger, "", passRegex, provider)
+	httpapi.MakeHandler(svc, gsvc, pageLimits, mux, logger, "", passRegex, provider)
 
 	return httptest.NewServer(mux), svc, gsvc
 }
@@ -3287,6 +3289,170 @@ func TestAssignGroups(t *testing.T) {
 	}
 }
 
+func TestAssignRoles(t *testing.T) {
+	us, _, gsvc := newUsersServer()
+	defer us.Close()
+
+	assignments := []groups.RoleAssignment{
+		{GroupID: testsutil.GenerateUUID(t), Role: "editor"},
+		{GroupID: testsutil.GenerateUUID(t), Role: "viewer"},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		memberID string
+		reqBody  interface{}
+		svcRes   []groups.RoleAssignment
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "assign roles to member successfully",
+			token:    validToken,
+			memberID: validID,
+			reqBody:  map[string]interface{}{"assignments": assignments},
+			svcRes:   assignments,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "assign roles to member with invalid token",
+			token:    inValidToken,
+			memberID: validID,
+			reqBody:  map[string]interface{}{"assignments": assignments},
+			svcErr:   svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "assign roles to member with empty token",
+			token:    "",
+			memberID: validID,
+			reqBody:  map[string]interface{}{"assignments": assignments},
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "assign roles to member with empty assignments",
+			token:    validToken,
+			memberID: validID,
+			reqBody:  map[string]interface{}{"assignments": []groups.RoleAssignment{}},
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "assign roles to member with invalid role",
+			token:    validToken,
+			memberID: validID,
+			reqBody:  map[string]interface{}{"assignments": assignments},
+			svcErr:   groups.ErrInvalidRole,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "assign roles to member with invalid request body",
+			token:    validToken,
+			memberID: validID,
+			reqBody: map[string]interface{}{
+				"assignments": make(chan int),
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range cases {
+		data := toJSON(tc.reqBody)
+		req := testRequest{
+			client:      us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/members/%s/roles", us.URL, tc.memberID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(data),
+		}
+
+		svcCall := gsvc.On("AssignRoles", mock.Anything, tc.token, tc.memberID, mock.Anything).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListMemberGroups(t *testing.T) {
+	us, _, gsvc := newUsersServer()
+	defer us.Close()
+
+	page := groups.MemberGroupsPage{
+		PageMeta: groups.PageMeta{Total: 1, Limit: 10},
+		Groups: []groups.MemberGroup{
+			{Group: groups.Group{ID: testsutil.GenerateUUID(t), Name: "group"}, Role: "editor"},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		memberID string
+		query    string
+		svcRes   groups.MemberGroupsPage
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "list member groups successfully",
+			token:    validToken,
+			memberID: validID,
+			svcRes:   page,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list member groups with offset and limit",
+			token:    validToken,
+			memberID: validID,
+			query:    "offset=1&limit=5",
+			svcRes:   page,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list member groups with invalid token",
+			token:    inValidToken,
+			memberID: validID,
+			svcErr:   svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list member groups with empty token",
+			token:    "",
+			memberID: validID,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "list member groups with invalid limit",
+			token:    validToken,
+			memberID: validID,
+			query:    "limit=invalid",
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "list member groups with limit above maximum",
+			token:    validToken,
+			memberID: validID,
+			query:    "limit=1000",
+			status:   http.StatusBadRequest,
+		},
+	}
+	for _, tc := range cases {
+		req := testRequest{
+			client: us.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/members/%s/groups?%s", us.URL, tc.memberID, tc.query),
+			token:  tc.token,
+		}
+
+		svcCall := gsvc.On("ListMemberGroups", mock.Anything, tc.token, tc.memberID, mock.Anything).Return(tc.svcRes, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
 func TestUnassignGroups(t *testing.T) {
 	us, _, gsvc := newUsersServer()
 	defer us.Close()
@@ -44,7 +44,7 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 
 		r.Delete("/{groupID}", otelhttp.NewHandler(kithttp.NewServer(
 			gapi.DeleteGroupEndpoint(svc),
-			gapi.DecodeGroupRequest,
+			gapi.DecodeDeleteGroupRequest,
 			api.EncodeResponse,
 			opts...,
 		), "delete_group").ServeHTTP)
@@ -142,9 +142,54 @@ func groupsHandler(svc groups.Service, r *chi.Mux, logger *slog.Logger) http.Han
 		api.EncodeResponse,
 		opts...,
 	), "list_groups_by_user_id").ServeHTTP)
+
+	r.Post("/members/{memberID}/roles", otelhttp.NewHandler(kithttp.NewServer(
+		assignRolesEndpoint(svc),
+		decodeAssignRolesRequest,
+		api.EncodeResponse,
+		opts...,
+	), "assign_member_roles").ServeHTTP)
+
+	r.Get("/members/{memberID}/groups", otelhttp.NewHandler(kithttp.NewServer(
+		listMemberGroupsEndpoint(svc),
+		decodeListMemberGroupsRequest,
+		api.EncodeResponse,
+		opts...,
+	), "list_member_groups").ServeHTTP)
 	return r
 }
 
+func decodeAssignRolesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := assignRolesReq{
+		token:    apiutil.ExtractBearerToken(r),
+		memberID: chi.URLParam(r, "memberID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	return req, nil
+}
+
+func decodeListMemberGroupsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefaultLimit(r.Context()))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	req := listMemberGroupsReq{
+		token:    apiutil.ExtractBearerToken(r),
+		memberID: chi.URLParam(r, "memberID"),
+		PageMeta: groups.PageMeta{
+			Offset: o,
+			Limit:  l,
+		},
+	}
+	return req, nil
+}
+
 func decodeAssignUsersRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := assignUsersReq{
 		token:   apiutil.ExtractBearerToken(r),
@@ -232,6 +277,45 @@ func assignGroupsEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
+func assignRolesEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignRolesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		assignments, err := svc.AssignRoles(ctx, req.token, req.memberID, req.Assignments)
+		if err != nil {
+			return nil, err
+		}
+		return assignRolesRes{Assignments: assignments}, nil
+	}
+}
+
+func listMemberGroupsEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listMemberGroupsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListMemberGroups(ctx, req.token, req.memberID, req.PageMeta)
+		if err != nil {
+			return nil, err
+		}
+		return memberGroupsPageRes{
+			pageRes: pageRes{
+				Limit:  page.Limit,
+				Offset: page.Offset,
+				Total:  page.Total,
+			},
+			Groups: page.Groups,
+		}, nil
+	}
+}
+
 func unassignGroupsEndpoint(svc groups.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(unassignGroupsReq)
@@ -7,6 +7,7 @@ import (
 	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/groups"
 )
 
 const maxLimitSize = 100
@@ -380,3 +381,56 @@ func (req unassignGroupsReq) validate() error {
 
 	return nil
 }
+
+type assignRolesReq struct {
+	token       string
+	memberID    string
+	Assignments []groups.RoleAssignment `json:"assignments"`
+}
+
+func (req assignRolesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.memberID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Assignments) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, a := range req.Assignments {
+		if a.GroupID == "" {
+			return apiutil.ErrMissingID
+		}
+		if a.Role == "" {
+			return apiutil.ErrMissingRelation
+		}
+	}
+
+	return nil
+}
+
+type listMemberGroupsReq struct {
+	groups.PageMeta
+	token    string
+	memberID string
+}
+
+func (req listMemberGroupsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.memberID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
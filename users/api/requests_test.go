@@ -11,6 +11,7 @@ import (
 	"github.com/absmach/magistrala/internal/testsutil"
 	"github.com/absmach/magistrala/pkg/apiutil"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -922,3 +923,70 @@ func TestUnassignGroupsRequestValidate(t *testing.T) {
 		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
 	}
 }
+
+func TestAssignRolesRequestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  assignRolesReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: assignRolesReq{
+				token:       valid,
+				memberID:    validID,
+				Assignments: []groups.RoleAssignment{{GroupID: validID, Role: "editor"}},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: assignRolesReq{
+				token:       "",
+				memberID:    validID,
+				Assignments: []groups.RoleAssignment{{GroupID: validID, Role: "editor"}},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty member id",
+			req: assignRolesReq{
+				token:       valid,
+				memberID:    "",
+				Assignments: []groups.RoleAssignment{{GroupID: validID, Role: "editor"}},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty assignments",
+			req: assignRolesReq{
+				token:       valid,
+				memberID:    validID,
+				Assignments: []groups.RoleAssignment{},
+			},
+			err: apiutil.ErrEmptyList,
+		},
+		{
+			desc: "assignment with empty group id",
+			req: assignRolesReq{
+				token:       valid,
+				memberID:    validID,
+				Assignments: []groups.RoleAssignment{{GroupID: "", Role: "editor"}},
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "assignment with empty role",
+			req: assignRolesReq{
+				token:       valid,
+				memberID:    validID,
+				Assignments: []groups.RoleAssignment{{GroupID: validID, Role: ""}},
+			},
+			err: apiutil.ErrMissingRelation,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
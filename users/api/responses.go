@@ -9,6 +9,7 @@ import (
 
 	"github.com/absmach/magistrala"
 	mgclients "github.com/absmach/magistrala/pkg/clients"
+	"github.com/absmach/magistrala/pkg/groups"
 )
 
 // MailSent message response when link is sent.
@@ -25,6 +26,8 @@ var (
 	_ magistrala.Response = (*passwChangeRes)(nil)
 	_ magistrala.Response = (*assignUsersRes)(nil)
 	_ magistrala.Response = (*unassignUsersRes)(nil)
+	_ magistrala.Response = (*assignRolesRes)(nil)
+	_ magistrala.Response = (*memberGroupsPageRes)(nil)
 	_ magistrala.Response = (*updateClientRes)(nil)
 	_ magistrala.Response = (*tokenRes)(nil)
 	_ magistrala.Response = (*deleteClientRes)(nil)
@@ -220,6 +223,39 @@ func (res unassignUsersRes) Empty() bool {
 	return true
 }
 
+type assignRolesRes struct {
+	Assignments []groups.RoleAssignment `json:"assignments"`
+}
+
+func (res assignRolesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res assignRolesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res assignRolesRes) Empty() bool {
+	return false
+}
+
+type memberGroupsPageRes struct {
+	pageRes
+	Groups []groups.MemberGroup `json:"groups"`
+}
+
+func (res memberGroupsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res memberGroupsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res memberGroupsPageRes) Empty() bool {
+	return false
+}
+
 type deleteClientRes struct {
 	deleted bool
 }
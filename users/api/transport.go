@@ -9,6 +9,7 @@ import (
 	"regexp"
 
 	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
 	"github.com/absmach/magistrala/pkg/groups"
 	"github.com/absmach/magistrala/pkg/oauth2"
 	"github.com/absmach/magistrala/users"
@@ -17,7 +18,8 @@ import (
 )
 
 // MakeHandler returns a HTTP handler for Users and Groups API endpoints.
-func MakeHandler(cls users.Service, grps groups.Service, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, providers ...oauth2.Provider) http.Handler {
+func MakeHandler(cls users.Service, grps groups.Service, pageLimits api.PageLimits, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, providers ...oauth2.Provider) http.Handler {
+	mux.Use(api.LimitPageSize(pageLimits))
 	clientsHandler(cls, mux, logger, pr, providers...)
 	groupsHandler(grps, mux, logger)
 